@@ -8,6 +8,8 @@ import (
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
 
 // Interface compliance
@@ -23,6 +25,9 @@ type Client interface {
 	AliasChain(chainID string, alias string) (bool, error)
 	GetChainAliases(chainID string) ([]string, error)
 	Stacktrace() (bool, error)
+	SetNetworkACL(allowlist []string, denylist []string, exemptBeaconsAndValidators bool) (bool, error)
+	StartMessageTrace(nodeIDs []string, chains []string, maxRecordsPerSecond uint32) (bool, error)
+	StopMessageTrace() (bool, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -92,3 +97,29 @@ func (c *client) Stacktrace() (bool, error) {
 	err := c.requester.SendRequest("stacktrace", struct{}{}, res)
 	return res.Success, err
 }
+
+func (c *client) SetNetworkACL(allowlist []string, denylist []string, exemptBeaconsAndValidators bool) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("setNetworkACL", &SetNetworkACLArgs{
+		Allowlist:                  allowlist,
+		Denylist:                   denylist,
+		ExemptBeaconsAndValidators: exemptBeaconsAndValidators,
+	}, res)
+	return res.Success, err
+}
+
+func (c *client) StartMessageTrace(nodeIDs []string, chains []string, maxRecordsPerSecond uint32) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("startMessageTrace", &StartMessageTraceArgs{
+		NodeIDs:             nodeIDs,
+		Chains:              chains,
+		MaxRecordsPerSecond: cjson.Uint32(maxRecordsPerSecond),
+	}, res)
+	return res.Success, err
+}
+
+func (c *client) StopMessageTrace() (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("stopMessageTrace", struct{}{}, res)
+	return res.Success, err
+}
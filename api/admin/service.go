@@ -5,6 +5,7 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/rpc/v2"
@@ -13,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -41,6 +43,7 @@ type Config struct {
 	NodeConfig   interface{}
 	ChainManager chains.Manager
 	HTTPServer   *server.Server
+	Network      network.Network
 }
 
 // Admin is the API service for node admin management
@@ -219,6 +222,80 @@ func (service *Admin) SetLoggerLevel(_ *http.Request, args *SetLoggerLevelArgs,
 	return nil
 }
 
+// SetNetworkACLArgs are the arguments for calling SetNetworkACL
+type SetNetworkACLArgs struct {
+	Allowlist                  []string `json:"allowlist"`
+	Denylist                   []string `json:"denylist"`
+	ExemptBeaconsAndValidators bool     `json:"exemptBeaconsAndValidators"`
+}
+
+// SetNetworkACL replaces the CIDR ranges of the IPs this node may connect to,
+// and accept connections from. If [args.Allowlist] is empty, all IPs not in
+// [args.Denylist] are allowed. Connections to IPs that are no longer allowed
+// are closed.
+func (service *Admin) SetNetworkACL(_ *http.Request, args *SetNetworkACLArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: SetNetworkACL called with Allowlist: %v, Denylist: %v, ExemptBeaconsAndValidators: %v", args.Allowlist, args.Denylist, args.ExemptBeaconsAndValidators)
+
+	if err := service.Network.SetACL(network.ACLConfig{
+		Allowlist:                  args.Allowlist,
+		Denylist:                   args.Denylist,
+		ExemptBeaconsAndValidators: args.ExemptBeaconsAndValidators,
+	}); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// StartMessageTraceArgs are the arguments for calling StartMessageTrace
+type StartMessageTraceArgs struct {
+	NodeIDs             []string     `json:"nodeIDs"`
+	Chains              []string     `json:"chains"`
+	MaxRecordsPerSecond cjson.Uint32 `json:"maxRecordsPerSecond"`
+}
+
+// StartMessageTrace logs a record of each message sent to, or received from,
+// the peers in [args.NodeIDs], and of each message about the chains in
+// [args.Chains]. Replaces the previous message trace, if any.
+func (service *Admin) StartMessageTrace(_ *http.Request, args *StartMessageTraceArgs, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: StartMessageTrace called with NodeIDs: %v, Chains: %v, MaxRecordsPerSecond: %d", args.NodeIDs, args.Chains, args.MaxRecordsPerSecond)
+
+	config := network.MessageTraceConfig{
+		NodeIDs:             make([]ids.ShortID, len(args.NodeIDs)),
+		ChainIDs:            make([]ids.ID, len(args.Chains)),
+		MaxRecordsPerSecond: uint(args.MaxRecordsPerSecond),
+	}
+	for i, nodeID := range args.NodeIDs {
+		nID, err := ids.ShortFromPrefixedString(nodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return fmt.Errorf("couldn't parse node ID %q: %w", nodeID, err)
+		}
+		config.NodeIDs[i] = nID
+	}
+	for i, chain := range args.Chains {
+		chainID, err := service.ChainManager.Lookup(chain)
+		if err != nil {
+			return fmt.Errorf("couldn't find chain %q: %w", chain, err)
+		}
+		config.ChainIDs[i] = chainID
+	}
+
+	if err := service.Network.StartMessageTrace(config); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// StopMessageTrace stops logging records of messages. Succeeds only if
+// messages were being traced.
+func (service *Admin) StopMessageTrace(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+	service.Log.Debug("Admin: StopMessageTrace called")
+
+	reply.Success = service.Network.StopMessageTrace()
+	return nil
+}
+
 type LogAndDisplayLevels struct {
 	LogLevel     logging.Level `json:"logLevel"`
 	DisplayLevel logging.Level `json:"displayLevel"`
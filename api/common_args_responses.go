@@ -89,6 +89,9 @@ type FormattedTx struct {
 type Index struct {
 	Address string `json:"address"` // The address as a string
 	UTXO    string `json:"utxo"`    // The UTXO ID as a string
+	// The chain the UTXOs were fetched from, if the VM reports it. An index
+	// can only be used to continue fetching UTXOs from the same chain.
+	SourceChain string `json:"sourceChain,omitempty"`
 }
 
 // GetUTXOsArgs are arguments for passing into GetUTXOs.
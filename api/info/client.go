@@ -22,8 +22,9 @@ type Client interface {
 	GetNetworkID() (uint32, error)
 	GetNetworkName() (string, error)
 	GetBlockchainID(alias string) (ids.ID, error)
-	Peers() ([]network.PeerInfo, error)
+	Peers(nodeIDs ...string) ([]network.PeerInfo, error)
 	IsBootstrapped(chainID string) (bool, error)
+	GetBootstrapStatus(chainID string) (*GetBootstrapStatusResponse, error)
 	GetTxFee() (*GetTxFeeResponse, error)
 	Uptime() (*UptimeResponse, error)
 }
@@ -78,9 +79,13 @@ func (c *client) GetBlockchainID(alias string) (ids.ID, error) {
 	return res.BlockchainID, err
 }
 
-func (c *client) Peers() ([]network.PeerInfo, error) {
+// Peers returns the info about the peers with IDs [nodeIDs]. If [nodeIDs] is
+// empty, returns the info about all peers.
+func (c *client) Peers(nodeIDs ...string) ([]network.PeerInfo, error) {
 	res := &PeersReply{}
-	err := c.requester.SendRequest("peers", struct{}{}, res)
+	err := c.requester.SendRequest("peers", &PeersArgs{
+		NodeIDs: nodeIDs,
+	}, res)
 	return res.Peers, err
 }
 
@@ -92,6 +97,14 @@ func (c *client) IsBootstrapped(chainID string) (bool, error) {
 	return res.IsBootstrapped, err
 }
 
+func (c *client) GetBootstrapStatus(chainID string) (*GetBootstrapStatusResponse, error) {
+	res := &GetBootstrapStatusResponse{}
+	err := c.requester.SendRequest("getBootstrapStatus", &GetBootstrapStatusArgs{
+		Chain: chainID,
+	}, res)
+	return res, err
+}
+
 func (c *client) GetTxFee() (*GetTxFeeResponse, error) {
 	res := &GetTxFeeResponse{}
 	err := c.requester.SendRequest("getTxFee", struct{}{}, res)
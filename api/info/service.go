@@ -184,7 +184,8 @@ type PeersReply struct {
 	Peers []network.PeerInfo `json:"peers"`
 }
 
-// Peers returns the list of current validators
+// Peers returns the info about the peers with IDs [args.NodeIDs], or about
+// all peers if [args.NodeIDs] is empty
 func (service *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error {
 	service.log.Debug("Info: Peers called")
 	nodeIDs := make([]ids.ShortID, 0, len(args.NodeIDs))
@@ -230,6 +231,60 @@ func (service *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, r
 	return nil
 }
 
+// GetBootstrapStatusArgs are the arguments for calling GetBootstrapStatus
+type GetBootstrapStatusArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain string `json:"chain"`
+}
+
+// GetBootstrapStatusResponse are the results from calling GetBootstrapStatus.
+// The numbers describe the current bootstrapping attempt, so they're reset
+// whenever bootstrapping is restarted.
+type GetBootstrapStatusResponse struct {
+	// True iff the chain is done bootstrapping
+	IsBootstrapped bool `json:"isBootstrapped"`
+	// Number of containers fetched
+	Fetched json.Uint64 `json:"fetched"`
+	// Number of containers executed
+	Executed json.Uint64 `json:"executed"`
+	// Height of the last accepted container when bootstrapping started
+	StartingHeight json.Uint64 `json:"startingHeight"`
+	// Greatest known height of the accepted frontier being bootstrapped to
+	TargetHeight json.Uint64 `json:"targetHeight"`
+	// Average number of containers fetched per second
+	FetchRate json.Float64 `json:"fetchRate"`
+	// Average number of containers executed per second
+	ExecuteRate json.Float64 `json:"executeRate"`
+}
+
+// GetBootstrapStatus returns the progress of bootstrapping [args.Chain]
+// Returns an error if the chain doesn't exist
+func (service *Info) GetBootstrapStatus(_ *http.Request, args *GetBootstrapStatusArgs, reply *GetBootstrapStatusResponse) error {
+	service.log.Debug("Info: GetBootstrapStatus called with chain: %s", args.Chain)
+
+	if args.Chain == "" {
+		return errNoChainProvided
+	}
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+	}
+	reply.IsBootstrapped = service.chainManager.IsBootstrapped(chainID)
+
+	status, ok := service.chainManager.BootstrapStatus(chainID)
+	if !ok {
+		return nil
+	}
+	reply.Fetched = json.Uint64(status.Fetched)
+	reply.Executed = json.Uint64(status.Executed)
+	reply.StartingHeight = json.Uint64(status.StartingHeight)
+	reply.TargetHeight = json.Uint64(status.TargetHeight)
+	reply.FetchRate = json.Float64(status.FetchRate)
+	reply.ExecuteRate = json.Float64(status.ExecuteRate)
+	return nil
+}
+
 // UptimeResponse are the results from calling Uptime
 type UptimeResponse struct {
 	// RewardingStakePercentage shows what percent of network stake thinks we're
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/avalanchego/app"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/manager"
@@ -120,7 +122,18 @@ func (p *process) Start() error {
 			"Please confirm the settings in your router")
 	}
 
-	mapper := nat.NewPortMapper(log, p.config.Nat)
+	natMetrics := prometheus.NewRegistry()
+	mapper, err := nat.NewPortMapper(log, p.config.Nat, natMetrics)
+	if err != nil {
+		log.Fatal("couldn't initialize NAT traversal metrics: %s", err)
+		if err := dbManager.Close(); err != nil {
+			log.Warn("failed to close the node's DB: %s", err)
+		}
+		log.Stop()
+		logFactory.Close()
+		return err
+	}
+	p.config.NatMetrics = natMetrics
 
 	// Open staking port we want for NAT Traversal to have the external port
 	// (config.IP.Port) to connect to our internal listening port
@@ -61,6 +61,17 @@ func (c *LRU) Flush() {
 	c.flush()
 }
 
+// Len returns the number of elements in the cache
+func (c *LRU) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entryList == nil {
+		return 0
+	}
+	return c.entryList.Len()
+}
+
 func (c *LRU) init() {
 	if c.entryMap == nil {
 		c.entryMap = make(map[interface{}]*list.Element, minCacheSize)
@@ -21,6 +21,24 @@ func TestLRUEviction(t *testing.T) {
 	TestEviction(t, cache)
 }
 
+func TestLRULen(t *testing.T) {
+	cache := LRU{Size: 2}
+	if l := cache.Len(); l != 0 {
+		t.Fatalf("Expected an empty cache but has %d elements", l)
+	}
+
+	cache.Put(ids.ID{1}, 1)
+	cache.Put(ids.ID{2}, 2)
+	if l := cache.Len(); l != 2 {
+		t.Fatalf("Expected 2 elements but has %d", l)
+	}
+
+	cache.Evict(ids.ID{1})
+	if l := cache.Len(); l != 1 {
+		t.Fatalf("Expected 1 element but has %d", l)
+	}
+}
+
 func TestLRUResize(t *testing.T) {
 	cache := LRU{Size: 2}
 
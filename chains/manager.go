@@ -32,6 +32,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/triggers"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -94,6 +95,10 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns the progress of bootstrapping the chain with the given ID.
+	// Returns false if the chain doesn't exist or doesn't report its progress.
+	BootstrapStatus(ids.ID) (common.BootstrapStatus, bool)
+
 	Shutdown()
 }
 
@@ -145,9 +150,10 @@ type ManagerConfig struct {
 	AtomicMemory                *atomic.Memory
 	AVAXAssetID                 ids.ID
 	XChainID                    ids.ID
-	CriticalChains              ids.Set          // Chains that can't exit gracefully
-	WhitelistedSubnets          ids.Set          // Subnets to validate
-	TimeoutManager              *timeout.Manager // Manages request timeouts when sending messages to other validators
+	CriticalChains              ids.Set                      // Chains that can't exit gracefully
+	WhitelistedSubnets          ids.Set                      // Subnets to validate
+	TimeoutManager              *timeout.Manager             // Manages request timeouts when sending messages to other validators
+	CPUTracker                  tracker.AggregateTimeTracker // Tracks CPU time spent handling messages from each node, across all chains
 	HealthService               health.Service
 	RetryBootstrap              bool                    // Should Bootstrap be retried
 	RetryBootstrapWarnFrequency int                     // Max number of times to retry bootstrap before warning the node operator
@@ -162,6 +168,12 @@ type ManagerConfig struct {
 	AppGossipNonValidatorSize  int
 	GossipAcceptedFrontierSize int
 
+	// Number of workers per snowman chain that verify blocks ahead of their
+	// issuance to consensus
+	ConsensusVerifyWorkers int
+	// Number of recently rejected blocks each snowman chain remembers
+	ConsensusRejectedCacheSize int
+
 	// Max Time to spend fetching a container and its
 	// ancestors when responding to a GetAncestors
 	BootstrapMaxTimeGetAncestors time.Duration
@@ -620,6 +632,7 @@ func (m *manager) createAvalancheChain(
 		engine,
 		vdrs,
 		msgChan,
+		m.CPUTracker.NewTracker(),
 	)
 
 	return &chain{
@@ -762,8 +775,10 @@ func (m *manager) createSnowmanChain(
 			VM:           vm,
 			Bootstrapped: m.unblockChains,
 		},
-		Params:    consensusParams,
-		Consensus: &smcon.Topological{},
+		Params:            consensusParams,
+		Consensus:         &smcon.Topological{},
+		VerifyWorkers:     m.ConsensusVerifyWorkers,
+		RejectedCacheSize: m.ConsensusRejectedCacheSize,
 	}); err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
@@ -773,6 +788,7 @@ func (m *manager) createSnowmanChain(
 		engine,
 		vdrs,
 		msgChan,
+		m.CPUTracker.NewTracker(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize message handler: %s", err)
@@ -823,6 +839,21 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Engine().IsBootstrapped()
 }
 
+func (m *manager) BootstrapStatus(id ids.ID) (common.BootstrapStatus, bool) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[id]
+	m.chainsLock.Unlock()
+	if !exists {
+		return common.BootstrapStatus{}, false
+	}
+
+	reporter, ok := chain.Engine().(common.BootstrapStatusReporter)
+	if !ok {
+		return common.BootstrapStatus{}, false
+	}
+	return reporter.BootstrapStatus(), true
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
@@ -5,6 +5,7 @@ package chains
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 )
 
@@ -26,6 +27,10 @@ func (mm MockManager) Shutdown()                           {}
 func (mm MockManager) SubnetID(ids.ID) (ids.ID, error)     { return ids.ID{}, nil }
 func (mm MockManager) IsBootstrapped(ids.ID) bool          { return false }
 
+func (mm MockManager) BootstrapStatus(ids.ID) (common.BootstrapStatus, bool) {
+	return common.BootstrapStatus{}, false
+}
+
 func (mm MockManager) Lookup(s string) (ids.ID, error) {
 	id, err := ids.FromString(s)
 	if err == nil {
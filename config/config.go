@@ -13,6 +13,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
@@ -61,6 +63,7 @@ var (
 	errAuthPasswordTooWeak           = errors.New("API auth password is not strong enough")
 	errInvalidUptimeRequirement      = errors.New("uptime requirement must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax     = errors.New("minimum validator stake can't be greater than maximum validator stake")
+	errInvalidMaxWeightFactor        = errors.New("maximum validator weight factor must be > 0")
 	errInvalidDelegationFee          = errors.New("delegation fee must be in the range [0, 1,000,000]")
 	errInvalidMinStakeDuration       = errors.New("min stake duration must be > 0")
 	errMinStakeDurationAboveMax      = errors.New("max stake duration can't be less than min stake duration")
@@ -273,6 +276,12 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 				UpgradeCooldown:        upgradeCooldown,
 				MaxRecentConnsUpgraded: maxRecentConnsUpgraded,
 			},
+			InboundConnGaterConfig: throttling.InboundConnGaterConfig{
+				MaxConnsPerIP:          v.GetInt(InboundConnGaterMaxConnsPerIPKey),
+				MaxValidatorConnsPerIP: v.GetInt(InboundConnGaterMaxValidatorConnsPerIPKey),
+				MaxRecentAttemptsPerIP: v.GetInt(InboundConnGaterMaxRecentAttemptsPerIPKey),
+				AttemptWindow:          v.GetDuration(InboundConnGaterAttemptWindowKey),
+			},
 
 			InboundMsgThrottlerConfig: throttling.InboundMsgThrottlerConfig{
 				MsgByteThrottlerConfig: throttling.MsgByteThrottlerConfig{
@@ -284,14 +293,36 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 					RefillRate:   v.GetUint64(InboundThrottlerBandwidthRefillRateKey),
 					MaxBurstSize: v.GetUint64(InboundThrottlerBandwidthMaxBurstSizeKey),
 				},
+				InboundAppMsgThrottlerConfig: throttling.InboundAppMsgThrottlerConfig{
+					BytesRefillRate:    v.GetUint64(InboundThrottlerAppBytesRefillRateKey),
+					BytesMaxBurstSize:  v.GetUint64(InboundThrottlerAppBytesMaxBurstSizeKey),
+					MsgRefillRate:      v.GetUint64(InboundThrottlerAppMsgRefillRateKey),
+					MsgMaxBurstSize:    v.GetUint64(InboundThrottlerAppMsgMaxBurstSizeKey),
+					MaxMsgSize:         v.GetUint64(InboundThrottlerAppMaxMsgSizeKey),
+					MaxProcessingMsgs:  v.GetUint64(InboundThrottlerAppMaxProcessingMsgsKey),
+					MaxProcessingBytes: v.GetUint64(InboundThrottlerAppMaxProcessingBytesKey),
+				},
 				MaxProcessingMsgsPerNode: v.GetUint64(InboundThrottlerMaxProcessingMsgsPerNodeKey),
 			},
 
+			InboundCPUThrottlerConfig: throttling.InboundCPUThrottlerConfig{
+				Halflife:        v.GetDuration(InboundThrottlerCPUHalflifeKey),
+				VdrAlloc:        v.GetFloat64(InboundThrottlerCPUVdrAllocKey),
+				MaxAtLargeAlloc: v.GetFloat64(InboundThrottlerCPUMaxAtLargeAllocKey),
+				MaxDelay:        v.GetDuration(InboundThrottlerCPUMaxDelayKey),
+			},
+
 			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
 				AtLargeAllocSize:    v.GetUint64(OutboundThrottlerAtLargeAllocSizeKey),
 				VdrAllocSize:        v.GetUint64(OutboundThrottlerVdrAllocSizeKey),
 				NodeMaxAtLargeBytes: v.GetUint64(OutboundThrottlerNodeMaxAtLargeBytesKey),
 			},
+
+			OutboundBandwidthThrottlerConfig: throttling.OutboundBandwidthThrottlerConfig{
+				RefillRate:   v.GetUint64(OutboundThrottlerBandwidthRefillRateKey),
+				MaxBurstSize: v.GetUint64(OutboundThrottlerBandwidthMaxBurstSizeKey),
+				MaxDelay:     v.GetDuration(OutboundThrottlerBandwidthMaxDelayKey),
+			},
 		},
 
 		HealthConfig: network.HealthConfig{
@@ -301,6 +332,10 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 			MinConnectedPeers:            v.GetUint(NetworkHealthMinPeersKey),
 			MaxSendFailRate:              v.GetFloat64(NetworkHealthMaxSendFailRateKey),
 			MaxSendFailRateHalflife:      halflife,
+			ChurnWindow:                  v.GetDuration(NetworkHealthChurnWindowKey),
+			MaxDisconnectsInWindow:       v.GetUint(NetworkHealthMaxDisconnectsInWindowKey),
+			MaxConnectedStakeDrop:        v.GetFloat64(NetworkHealthMaxConnectedStakeDropKey),
+			MaxTimeSinceInboundConn:      v.GetDuration(NetworkHealthMaxTimeSinceInboundConnKey),
 		},
 
 		DialerConfig: dialer.Config{
@@ -308,6 +343,30 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 			ConnectionTimeout: v.GetDuration(OutboundConnectionTimeout),
 		},
 
+		PeerPersistenceConfig: network.PeerPersistenceConfig{
+			PersistPeersEnabled:     v.GetBool(NetworkPeerPersistenceEnabledKey),
+			PersistPeersFreq:        v.GetDuration(NetworkPeerPersistenceFreqKey),
+			PersistedPeerTTL:        v.GetDuration(NetworkPeerPersistenceTTLKey),
+			MaxPersistedPeersToDial: v.GetInt(NetworkPeerPersistenceMaxDialKey),
+		},
+
+		ACLConfig: network.ACLConfig{
+			Allowlist:                  strings.Split(v.GetString(NetworkACLAllowlistKey), ","),
+			Denylist:                   strings.Split(v.GetString(NetworkACLDenylistKey), ","),
+			ExemptBeaconsAndValidators: v.GetBool(NetworkACLExemptBeaconsAndValidatorsKey),
+		},
+
+		ProxyProtocolConfig: network.ProxyProtocolConfig{
+			ProxyProtocolEnabled: v.GetBool(NetworkProxyProtocolEnabledKey),
+			ProxyHeaderTimeout:   v.GetDuration(NetworkProxyProtocolHeaderTimeoutKey),
+		},
+
+		BeaconResolverConfig: network.BeaconResolverConfig{
+			BeaconResolveFreq:        v.GetDuration(BootstrapBeaconResolveFreqKey),
+			BeaconMaxResolveBackoff:  v.GetDuration(BootstrapBeaconMaxResolveBackoffKey),
+			BeaconMaxConnectFailures: v.GetInt(BootstrapBeaconMaxConnectFailuresKey),
+		},
+
 		TimeoutConfig: network.TimeoutConfig{
 			PeerAliasTimeout:     v.GetDuration(PeerAliasTimeoutKey),
 			GetVersionTimeout:    v.GetDuration(NetworkGetVersionTimeoutKey),
@@ -320,6 +379,8 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 			PeerListGossipFreq:           v.GetDuration(NetworkPeerListGossipFreqKey),
 			PeerListGossipSize:           v.GetUint32(NetworkPeerListGossipSizeKey),
 			PeerListStakerGossipFraction: v.GetUint32(NetworkPeerListStakerGossipFractionKey),
+			PeerListMaxStrikes:           v.GetUint32(NetworkPeerListMaxStrikesKey),
+			PeerListMaxIPAge:             v.GetDuration(NetworkPeerListMaxIPAgeKey),
 		},
 
 		GossipConfig: network.GossipConfig{
@@ -330,15 +391,18 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		},
 
 		DelayConfig: network.DelayConfig{
-			MaxReconnectDelay:     v.GetDuration(NetworkMaxReconnectDelayKey),
-			InitialReconnectDelay: v.GetDuration(NetworkInitialReconnectDelayKey),
+			MaxReconnectDelay:          v.GetDuration(NetworkMaxReconnectDelayKey),
+			InitialReconnectDelay:      v.GetDuration(NetworkInitialReconnectDelayKey),
+			MaxValidatorReconnectDelay: v.GetDuration(NetworkMaxValidatorReconnectDelayKey),
 		},
 
-		MaxClockDifference: v.GetDuration(NetworkMaxClockDifferenceKey),
-		CompressionEnabled: v.GetBool(NetworkCompressionEnabledKey),
-		PingFrequency:      v.GetDuration(NetworkPingFrequencyKey),
-		AllowPrivateIPs:    v.GetBool(NetworkAllowPrivateIPsKey),
-		UptimeMetricFreq:   v.GetDuration(UptimeMetricFreqKey),
+		MaxClockDifference:     v.GetDuration(NetworkMaxClockDifferenceKey),
+		CompressionEnabled:     v.GetBool(NetworkCompressionEnabledKey),
+		ZstdCompressionEnabled: v.GetBool(NetworkZstdCompressionEnabledKey),
+		OversizedMsgMaxStrikes: v.GetUint32(NetworkOversizedMsgMaxStrikesKey),
+		PingFrequency:          v.GetDuration(NetworkPingFrequencyKey),
+		AllowPrivateIPs:        v.GetBool(NetworkAllowPrivateIPsKey),
+		UptimeMetricFreq:       v.GetDuration(UptimeMetricFreqKey),
 
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 	}
@@ -352,12 +416,20 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		return network.Config{}, fmt.Errorf("%s must be in [0,1]", NetworkHealthMaxSendFailRateKey)
 	case config.HealthConfig.MaxPortionSendQueueBytesFull < 0 || config.HealthConfig.MaxPortionSendQueueBytesFull > 1:
 		return network.Config{}, fmt.Errorf("%s must be in [0,1]", NetworkHealthMaxPortionSendQueueFillKey)
+	case config.HealthConfig.ChurnWindow < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkHealthChurnWindowKey)
+	case config.HealthConfig.MaxConnectedStakeDrop < 0 || config.HealthConfig.MaxConnectedStakeDrop > 1:
+		return network.Config{}, fmt.Errorf("%s must be in [0,1]", NetworkHealthMaxConnectedStakeDropKey)
+	case config.HealthConfig.MaxTimeSinceInboundConn < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkHealthMaxTimeSinceInboundConnKey)
 	case config.DialerConfig.ConnectionTimeout < 0:
 		return network.Config{}, fmt.Errorf("%q must be >= 0", OutboundConnectionTimeout)
 	case config.PeerAliasTimeout < 0:
 		return network.Config{}, fmt.Errorf("%q must be >= 0", PeerAliasTimeoutKey)
-	case config.PeerListGossipFreq < 0:
-		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListGossipFreqKey)
+	case config.PeerListSize > network.MaxPeerListSize:
+		return network.Config{}, fmt.Errorf("%s must be <= %d to fit in the max message size", NetworkPeerListSizeKey, network.MaxPeerListSize)
+	case config.PeerListGossipFreq <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerListGossipFreqKey)
 	case config.GetVersionTimeout < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkGetVersionTimeoutKey)
 	case config.PeerListStakerGossipFraction < 1:
@@ -368,6 +440,10 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkInitialReconnectDelayKey)
 	case config.MaxReconnectDelay < config.InitialReconnectDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %s", NetworkMaxReconnectDelayKey, NetworkInitialReconnectDelayKey)
+	case config.MaxValidatorReconnectDelay < config.InitialReconnectDelay:
+		return network.Config{}, fmt.Errorf("%s must be >= %s", NetworkMaxValidatorReconnectDelayKey, NetworkInitialReconnectDelayKey)
+	case config.MaxValidatorReconnectDelay > config.MaxReconnectDelay:
+		return network.Config{}, fmt.Errorf("%s must be <= %s", NetworkMaxValidatorReconnectDelayKey, NetworkMaxReconnectDelayKey)
 	case config.PingPongTimeout < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPingTimeoutKey)
 	case config.PingFrequency < 0:
@@ -376,13 +452,82 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		return network.Config{}, fmt.Errorf("%s must be > %s", NetworkPingTimeoutKey, NetworkPingFrequencyKey)
 	case config.ReadHandshakeTimeout < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
+	case config.ProxyHeaderTimeout <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkProxyProtocolHeaderTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.PeerListMaxIPAge < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListMaxIPAgeKey)
+	case config.ThrottlerConfig.InboundMsgThrottlerConfig.InboundAppMsgThrottlerConfig.BytesRefillRate > 0 &&
+		config.ThrottlerConfig.InboundMsgThrottlerConfig.InboundAppMsgThrottlerConfig.BytesMaxBurstSize < config.ThrottlerConfig.InboundMsgThrottlerConfig.InboundAppMsgThrottlerConfig.MaxMsgSize:
+		return network.Config{}, fmt.Errorf("%s must be >= %s", InboundThrottlerAppBytesMaxBurstSizeKey, InboundThrottlerAppMaxMsgSizeKey)
+	case config.ThrottlerConfig.InboundMsgThrottlerConfig.InboundAppMsgThrottlerConfig.MsgRefillRate > 0 && config.ThrottlerConfig.InboundMsgThrottlerConfig.InboundAppMsgThrottlerConfig.MsgMaxBurstSize < 1:
+		return network.Config{}, fmt.Errorf("%s must be >= 1", InboundThrottlerAppMsgMaxBurstSizeKey)
+	case config.ThrottlerConfig.InboundMsgThrottlerConfig.InboundAppMsgThrottlerConfig.MaxProcessingBytes > 0 &&
+		config.ThrottlerConfig.InboundMsgThrottlerConfig.InboundAppMsgThrottlerConfig.MaxProcessingBytes < config.ThrottlerConfig.InboundMsgThrottlerConfig.InboundAppMsgThrottlerConfig.MaxMsgSize:
+		return network.Config{}, fmt.Errorf("%s must be 0 or >= %s", InboundThrottlerAppMaxProcessingBytesKey, InboundThrottlerAppMaxMsgSizeKey)
+	case config.ThrottlerConfig.InboundCPUThrottlerConfig.Halflife <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", InboundThrottlerCPUHalflifeKey)
+	case config.ThrottlerConfig.InboundCPUThrottlerConfig.VdrAlloc < 0 || config.ThrottlerConfig.InboundCPUThrottlerConfig.VdrAlloc > 1:
+		return network.Config{}, fmt.Errorf("%s must be in [0,1]", InboundThrottlerCPUVdrAllocKey)
+	case config.ThrottlerConfig.InboundCPUThrottlerConfig.MaxAtLargeAlloc < 0 || config.ThrottlerConfig.InboundCPUThrottlerConfig.MaxAtLargeAlloc > 1:
+		return network.Config{}, fmt.Errorf("%s must be in [0,1]", InboundThrottlerCPUMaxAtLargeAllocKey)
+	case config.ThrottlerConfig.InboundCPUThrottlerConfig.MaxDelay < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", InboundThrottlerCPUMaxDelayKey)
+	case config.ThrottlerConfig.OutboundBandwidthThrottlerConfig.MaxDelay < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", OutboundThrottlerBandwidthMaxDelayKey)
+	case config.ThrottlerConfig.InboundConnGaterConfig.MaxValidatorConnsPerIP < config.ThrottlerConfig.InboundConnGaterConfig.MaxConnsPerIP:
+		return network.Config{}, fmt.Errorf("%s must be >= %s", InboundConnGaterMaxValidatorConnsPerIPKey, InboundConnGaterMaxConnsPerIPKey)
+	case config.ThrottlerConfig.InboundConnGaterConfig.AttemptWindow < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", InboundConnGaterAttemptWindowKey)
+	case config.BeaconResolveFreq <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", BootstrapBeaconResolveFreqKey)
+	case config.BeaconMaxResolveBackoff <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", BootstrapBeaconMaxResolveBackoffKey)
+	case config.BeaconMaxConnectFailures < 1:
+		return network.Config{}, fmt.Errorf("%s must be >= 1", BootstrapBeaconMaxConnectFailuresKey)
+	case config.PersistPeersEnabled && config.PersistPeersFreq <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerPersistenceFreqKey)
+	case config.PersistedPeerTTL < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerPersistenceTTLKey)
+	case config.MaxPersistedPeersToDial < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerPersistenceMaxDialKey)
+	}
+
+	maxMessageSizes, err := getMaxMessageSizes(v)
+	if err != nil {
+		return network.Config{}, err
 	}
-
+	config.MaxMessageSizes = maxMessageSizes
 	return config, nil
 }
 
+// getMaxMessageSizes returns the max message size of each op given by
+// [NetworkMaxMessageSizesKey], which maps op names to sizes.
+func getMaxMessageSizes(v *viper.Viper) (map[message.Op]int64, error) {
+	sizesByName := make(map[string]int64)
+	if err := json.Unmarshal([]byte(v.GetString(NetworkMaxMessageSizesKey)), &sizesByName); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %w", NetworkMaxMessageSizesKey, err)
+	}
+
+	opsByName := make(map[string]message.Op, len(message.ExternalOps))
+	for _, op := range message.ExternalOps {
+		opsByName[op.String()] = op
+	}
+	maxSizes := make(map[message.Op]int64, len(sizesByName))
+	for name, size := range sizesByName {
+		op, ok := opsByName[name]
+		if !ok {
+			return nil, fmt.Errorf("%s contains unknown op %q", NetworkMaxMessageSizesKey, name)
+		}
+		if size <= 0 || size > int64(constants.DefaultMaxMessageSize) {
+			return nil, fmt.Errorf("%s: max size of %s must be in (0, %d]", NetworkMaxMessageSizesKey, name, constants.DefaultMaxMessageSize)
+		}
+		maxSizes[op] = size
+	}
+	return maxSizes, nil
+}
+
 func getBenchlistConfig(v *viper.Viper, alpha, k int) (benchlist.Config, error) {
 	config := benchlist.Config{
 		Threshold:              v.GetInt(BenchlistFailThresholdKey),
@@ -418,11 +563,26 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		if ip == "" {
 			continue
 		}
-		addr, err := utils.ToIPDesc(ip)
+		if addr, err := utils.ToIPDesc(ip); err == nil {
+			config.BootstrapIPs = append(config.BootstrapIPs, addr)
+			continue
+		}
+		// [ip] isn't a literal IP, so it must be a hostname
+		host, portStr, err := net.SplitHostPort(ip)
 		if err != nil {
 			return node.BootstrapConfig{}, fmt.Errorf("couldn't parse bootstrap ip %s: %w", ip, err)
 		}
-		config.BootstrapIPs = append(config.BootstrapIPs, addr)
+		if host == "" {
+			return node.BootstrapConfig{}, fmt.Errorf("couldn't parse bootstrap ip %s: missing host", ip)
+		}
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			return node.BootstrapConfig{}, fmt.Errorf("couldn't parse bootstrap ip %s: %w", ip, err)
+		}
+		config.BootstrapHostnames = append(config.BootstrapHostnames, node.BootstrapHostname{
+			Hostname: host,
+			Port:     uint16(port),
+		})
 	}
 
 	if v.IsSet(BootstrapIDsKey) {
@@ -534,6 +694,21 @@ func getStakingTLSCert(v *viper.Viper) (tls.Certificate, error) {
 	return *cert, nil
 }
 
+func getStakingTLSOptions(v *viper.Viper) (network.TLSOptions, error) {
+	minVersion, err := network.ParseTLSVersion(v.GetString(StakingTLSMinVersionKey))
+	if err != nil {
+		return network.TLSOptions{}, fmt.Errorf("invalid %s: %w", StakingTLSMinVersionKey, err)
+	}
+	cipherSuites, err := network.ParseCipherSuites(strings.Split(v.GetString(StakingTLSCipherSuitesKey), ","))
+	if err != nil {
+		return network.TLSOptions{}, fmt.Errorf("invalid %s: %w", StakingTLSCipherSuitesKey, err)
+	}
+	return network.TLSOptions{
+		MinVersion:   minVersion,
+		CipherSuites: cipherSuites,
+	}, nil
+}
+
 func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, error) {
 	config := node.StakingConfig{
 		EnableStaking:         v.GetBool(StakingEnabledKey),
@@ -550,10 +725,15 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 	if err != nil {
 		return node.StakingConfig{}, err
 	}
+	config.StakingTLSOptions, err = getStakingTLSOptions(v)
+	if err != nil {
+		return node.StakingConfig{}, err
+	}
 	if networkID != constants.MainnetID && networkID != constants.FujiID {
 		config.UptimeRequirement = v.GetFloat64(UptimeRequirementKey)
 		config.MinValidatorStake = v.GetUint64(MinValidatorStakeKey)
 		config.MaxValidatorStake = v.GetUint64(MaxValidatorStakeKey)
+		config.MaxValidatorWeightFactor = v.GetUint64(MaxValidatorWeightFactorKey)
 		config.MinDelegatorStake = v.GetUint64(MinDelegatorStakeKey)
 		config.MinStakeDuration = v.GetDuration(MinStakeDurationKey)
 		config.MaxStakeDuration = v.GetDuration(MaxStakeDurationKey)
@@ -564,6 +744,8 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 			return node.StakingConfig{}, errInvalidUptimeRequirement
 		case config.MinValidatorStake > config.MaxValidatorStake:
 			return node.StakingConfig{}, errMinValidatorStakeAboveMax
+		case config.MaxValidatorWeightFactor == 0:
+			return node.StakingConfig{}, errInvalidMaxWeightFactor
 		case config.MinDelegationFee > 1_000_000:
 			return node.StakingConfig{}, errInvalidDelegationFee
 		case config.MinStakeDuration <= 0:
@@ -579,6 +761,30 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 	return config, nil
 }
 
+// applyGenesisParams overrides the staking and X-chain parameters in [config]
+// with the ones specified in the custom genesis at [genesisConfigFile], if any.
+// The genesis must have already been validated.
+func applyGenesisParams(config *node.Config, genesisConfigFile string) error {
+	if len(genesisConfigFile) == 0 {
+		return nil
+	}
+	genesisConfig, err := genesis.GetConfigFile(genesisConfigFile)
+	if err != nil {
+		return fmt.Errorf("unable to load genesis file: %w", err)
+	}
+	if genesisConfig.AVMParams != nil {
+		genesisConfig.AVMParams.Apply(&config.AVMConfig)
+	}
+	if genesisConfig.StakingParams == nil {
+		return nil
+	}
+	genesisConfig.StakingParams.Apply(&config.StakingConfig.StakingConfig)
+	if config.StakeMintingPeriod < config.MaxStakeDuration {
+		return errStakeMintingPeriodBelowMin
+	}
+	return nil
+}
+
 func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 	if networkID != constants.MainnetID && networkID != constants.FujiID {
 		return genesis.TxFeeConfig{
@@ -822,6 +1028,8 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	if nodeConfig.ConsensusShutdownTimeout < 0 {
 		return node.Config{}, fmt.Errorf("%q must be >= 0", ConsensusShutdownTimeoutKey)
 	}
+	nodeConfig.ConsensusVerifyWorkers = int(v.GetUint(ConsensusVerifyWorkersKey))
+	nodeConfig.ConsensusRejectedCacheSize = int(v.GetUint(ConsensusRejectedCacheSizeKey))
 
 	// Gossiping
 	nodeConfig.ConsensusGossipFrequency = v.GetDuration(ConsensusGossipFrequencyKey)
@@ -920,14 +1128,21 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	// Tx Fee
 	nodeConfig.TxFeeConfig = getTxFeeConfig(v, nodeConfig.NetworkID)
 
+	// X-Chain parameters
+	nodeConfig.AVMConfig = genesis.GetAVMConfig(nodeConfig.NetworkID)
+
 	// Genesis Data
+	genesisConfigFile := os.ExpandEnv(v.GetString(GenesisConfigFileKey))
 	nodeConfig.GenesisBytes, nodeConfig.AvaxAssetID, err = genesis.Genesis(
 		nodeConfig.NetworkID,
-		os.ExpandEnv(v.GetString(GenesisConfigFileKey)),
+		genesisConfigFile,
 	)
 	if err != nil {
 		return node.Config{}, fmt.Errorf("unable to load genesis file: %w", err)
 	}
+	if err := applyGenesisParams(&nodeConfig, genesisConfigFile); err != nil {
+		return node.Config{}, err
+	}
 
 	// Assertions
 	nodeConfig.EnableAssertions = v.GetBool(AssertionsEnabledKey)
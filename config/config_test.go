@@ -18,6 +18,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 )
 
 func TestSetChainConfigs(t *testing.T) {
@@ -352,6 +353,24 @@ func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 	return configFilePath
 }
 
+func TestGetMaxMessageSizes(t *testing.T) {
+	assert := assert.New(t)
+	v := viper.New()
+
+	v.Set(NetworkMaxMessageSizesKey, `{"ping": 16, "chits": 1024}`)
+	maxSizes, err := getMaxMessageSizes(v)
+	assert.NoError(err)
+	assert.Equal(map[message.Op]int64{message.Ping: 16, message.Chits: 1024}, maxSizes)
+
+	v.Set(NetworkMaxMessageSizesKey, `{"pingg": 16}`)
+	_, err = getMaxMessageSizes(v)
+	assert.Error(err)
+
+	v.Set(NetworkMaxMessageSizesKey, `{"ping": 0}`)
+	_, err = getMaxMessageSizes(v)
+	assert.Error(err)
+}
+
 // setups file creates necessary path and writes value to it.
 func setupFile(t *testing.T, path string, fileName string, value string) {
 	assert.NoError(t, os.MkdirAll(path, 0o700))
@@ -118,6 +118,23 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint(NetworkPeerListGossipSizeKey, 50, gossipHelpMsg)
 	fs.Duration(NetworkPeerListGossipFreqKey, time.Minute, gossipHelpMsg)
 	fs.Uint(NetworkPeerListStakerGossipFractionKey, 2, fmt.Sprintf("1 of each %s peer list messages gossiped will be to validators", NetworkPeerListStakerGossipFractionKey))
+	fs.Uint(NetworkPeerListMaxStrikesKey, 3, "Number of gossiped peer IPs with invalid signatures after which the peer that gossiped them is disconnected from. If 0, peers are never disconnected from for it.")
+	fs.Duration(NetworkPeerListMaxIPAgeKey, 0, "Max age of the signed timestamp of a gossiped peer IP for it to be dialed. If 0, gossiped IPs are never ignored for their age.")
+
+	// Peer Persistence
+	fs.Bool(NetworkPeerPersistenceEnabledKey, true, "If true, the IPs of connected peers are persisted to the database, and some of them are dialed after a restart.")
+	fs.Duration(NetworkPeerPersistenceFreqKey, time.Minute, "Frequency at which the IPs of connected peers are persisted.")
+	fs.Duration(NetworkPeerPersistenceTTLKey, 24*time.Hour, "Persisted peers that weren't connected to within this duration are removed.")
+	fs.Int(NetworkPeerPersistenceMaxDialKey, 32, "Max number of persisted peers to dial on startup.")
+
+	// Network ACL
+	fs.String(NetworkACLAllowlistKey, "", "Comma separated list of CIDR ranges of the IPs this node may connect to, and accept connections from. If empty, all IPs not in the denylist are allowed.")
+	fs.String(NetworkACLDenylistKey, "", "Comma separated list of CIDR ranges of the IPs this node won't connect to, or accept connections from.")
+	fs.Bool(NetworkACLExemptBeaconsAndValidatorsKey, false, "If true, bootstrap beacons and validators are allowed even if their IPs are in the denylist.")
+
+	// PROXY Protocol
+	fs.Bool(NetworkProxyProtocolEnabledKey, false, "If true, inbound connections must start with a PROXY protocol (v1 or v2) header, whose client address is used as the address of the peer. Should only be set if this node is only reachable through a load balancer that sends the header.")
+	fs.Duration(NetworkProxyProtocolHeaderTimeoutKey, 5*time.Second, "Timeout for reading the PROXY protocol header of an inbound connection.")
 
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT. Ignored if dynamic-public-ip is non-empty.")
@@ -128,6 +145,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(InboundConnUpgradeThrottlerCooldownKey, 10*time.Second, "Upgrade an inbound connection from a given IP at most once per this duration. If 0, don't rate-limit inbound connection upgrades.")
 	fs.Int(InboundConnUpgradeThrottlerMaxRecentKey, 5120, "DEPRECATED") // Deprecated starting in v1.6.0. TODO remove in future release.
 	fs.Float64(InboundThrottlerMaxConnsPerSecKey, 256, "Max number of inbound connections to accept (from all peers) per second.")
+	fs.Int(InboundConnGaterMaxConnsPerIPKey, 8, "Max number of concurrent inbound connections from a given IP. If 0, inbound connections aren't limited per IP.")
+	fs.Int(InboundConnGaterMaxValidatorConnsPerIPKey, 32, fmt.Sprintf("Max number of concurrent inbound connections from a given IP known to belong to a validator. Must be >= %s.", InboundConnGaterMaxConnsPerIPKey))
+	fs.Int(InboundConnGaterMaxRecentAttemptsPerIPKey, 16, fmt.Sprintf("Max number of inbound connection attempts from a given IP within %s. If 0, connection attempts aren't rate-limited per IP.", InboundConnGaterAttemptWindowKey))
+	fs.Duration(InboundConnGaterAttemptWindowKey, 10*time.Second, fmt.Sprintf("Duration over which inbound connection attempts are counted towards %s.", InboundConnGaterMaxRecentAttemptsPerIPKey))
 	// Outbound Connection Throttling
 	fs.Uint(OutboundConnectionThrottlingRps, 50, "Make at most this number of outgoing peer connection attempts per second.")
 	fs.Duration(OutboundConnectionTimeout, 30*time.Second, "Timeout when dialing a peer.")
@@ -143,6 +164,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers.")
 
 	fs.Bool(NetworkCompressionEnabledKey, true, "If true, compress certain outbound messages. This node will be able to parse compressed inbound messages regardless of this flag's value")
+	fs.String(NetworkMaxMessageSizesKey, "{}", "JSON map of message op names (e.g. \"ping\") to the max size, in bytes, of inbound messages of that op. Ops not in the map use their default max size.")
+	fs.Uint(NetworkOversizedMsgMaxStrikesKey, 3, "Number of messages exceeding the max size of their op after which the peer that sent them is disconnected from. If 0, peers are never disconnected from for it.")
+	fs.Bool(NetworkZstdCompressionEnabledKey, false, "If true, compress large outbound messages with zstd when sent to peers that support it. This node will be able to parse zstd compressed inbound messages regardless of this flag's value")
 	fs.Duration(NetworkMaxClockDifferenceKey, time.Minute, "Max allowed clock difference value between this node and peers.")
 	fs.Bool(NetworkAllowPrivateIPsKey, true, "Allows the node to connect peers with private IPs")
 	fs.Bool(NetworkRequireValidatorToConnectKey, false, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
@@ -161,6 +185,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(ConsensusShutdownTimeoutKey, 5*time.Second, "Timeout before killing an unresponsive chain.")
 	fs.Uint(ConsensusGossipAcceptedFrontierSizeKey, 35, "Number of peers to gossip to when gossiping accepted frontier")
 	fs.Uint(ConsensusGossipOnAcceptSizeKey, 20, "Number of peers to gossip to each accepted container to")
+	fs.Uint(ConsensusVerifyWorkersKey, 4, "Number of workers per chain that verify blocks ahead of their issuance to consensus, if the chain's VM supports it. If 0, blocks are verified when they're issued")
+	fs.Uint(ConsensusRejectedCacheSizeKey, 1024, "Number of recently rejected blocks each chain remembers, so that they're dropped when received again. If 0, rejected blocks aren't remembered")
 	fs.Uint(AppGossipNonValidatorSizeKey, 0, "Number of peers (which may be validators or non-validators) to gossip an AppGossip message to")
 	fs.Uint(AppGossipValidatorSizeKey, 6, "Number of validators to gossip an AppGossip message to")
 
@@ -171,11 +197,25 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(InboundThrottlerMaxProcessingMsgsPerNodeKey, 1024, "Max number of messages currently processing from a given node.")
 	fs.Uint64(InboundThrottlerBandwidthRefillRateKey, 512*units.KiB, "Max average inbound bandwidth usage of a peer, in bytes per second. See BandwidthThrottler.")
 	fs.Uint64(InboundThrottlerBandwidthMaxBurstSizeKey, uint64(constants.DefaultMaxMessageSize), "Max inbound bandwidth a node can use at once. Must be at least the max message size. See BandwidthThrottler.")
+	fs.Uint64(InboundThrottlerAppBytesRefillRateKey, 512*units.KiB, "Max average number of bytes per second of AppRequest, AppResponse and AppGossip messages a peer can send. App messages exceeding this are dropped. If 0, app message bytes aren't rate-limited.")
+	fs.Uint64(InboundThrottlerAppBytesMaxBurstSizeKey, uint64(constants.DefaultMaxMessageSize), "Max number of bytes of app messages a peer can send at once. Must be at least the max app message size.")
+	fs.Uint64(InboundThrottlerAppMsgRefillRateKey, 512, "Max average number of app messages per second a peer can send. App messages exceeding this are dropped. If 0, the number of app messages isn't rate-limited.")
+	fs.Uint64(InboundThrottlerAppMsgMaxBurstSizeKey, 1024, "Max number of app messages a peer can send at once.")
+	fs.Uint64(InboundThrottlerAppMaxMsgSizeKey, uint64(constants.DefaultMaxMessageSize), "Max size, in bytes, of an app message. Larger app messages are dropped.")
+	fs.Uint64(InboundThrottlerAppMaxProcessingMsgsKey, 256, "Max number of app messages from a given peer that can be processing at once. App messages exceeding this are dropped. If 0, the number of processing app messages isn't limited.")
+	fs.Uint64(InboundThrottlerAppMaxProcessingBytesKey, 8*units.MiB, "Max number of bytes of app messages from a given peer that can be processing at once. App messages exceeding this are dropped. Must be 0 or at least the max app message size. If 0, the processing app message bytes aren't limited.")
+	fs.Duration(InboundThrottlerCPUHalflifeKey, 15*time.Second, "Halflife of the decay of the CPU time spent handling messages from each node.")
+	fs.Float64(InboundThrottlerCPUVdrAllocKey, 0.5, "Portion of CPU time split between validators in proportion to their stake in the inbound CPU throttler. Must be in [0,1].")
+	fs.Float64(InboundThrottlerCPUMaxAtLargeAllocKey, 0.25, "Max portion of CPU time any node may use, in addition to its validator allocation, before its messages are delayed. Must be in [0,1].")
+	fs.Duration(InboundThrottlerCPUMaxDelayKey, time.Second, "Max amount of time a message from a node exceeding its CPU allocation is delayed before being dropped.")
 
 	// Outbound Throttling
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, 6*units.MiB, "Size, in bytes, of at-large byte allocation in outbound message throttler.")
 	fs.Uint64(OutboundThrottlerVdrAllocSizeKey, 32*units.MiB, "Size, in bytes, of validator byte allocation in outbound message throttler.")
 	fs.Uint64(OutboundThrottlerNodeMaxAtLargeBytesKey, uint64(constants.DefaultMaxMessageSize), "Max number of bytes a node can take from the outbound message throttler's at-large allocation.  Must be at least the max message size.")
+	fs.Uint64(OutboundThrottlerBandwidthRefillRateKey, 0, "Max average outbound bandwidth usage of this node, across all peers, in bytes per second. If 0, outbound bandwidth isn't throttled.")
+	fs.Uint64(OutboundThrottlerBandwidthMaxBurstSizeKey, uint64(constants.DefaultMaxMessageSize), "Max outbound bandwidth this node can use at once.")
+	fs.Duration(OutboundThrottlerBandwidthMaxDelayKey, 10*time.Second, "Max amount of time a message waits for outbound bandwidth before being dropped.")
 
 	// HTTP APIs
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server")
@@ -203,6 +243,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Float64(NetworkHealthMaxPortionSendQueueFillKey, 0.9, "Network layer returns unhealthy if more than this portion of the pending send queue is full")
 	fs.Uint(NetworkHealthMinPeersKey, 1, "Network layer returns unhealthy if connected to less than this many peers")
 	fs.Float64(NetworkHealthMaxSendFailRateKey, .9, "Network layer reports unhealthy if more than this portion of attempted message sends fail")
+	fs.Duration(NetworkHealthChurnWindowKey, 10*time.Minute, fmt.Sprintf("Duration of the sliding window used by [%s] and [%s]. If 0, neither is checked", NetworkHealthMaxDisconnectsInWindowKey, NetworkHealthMaxConnectedStakeDropKey))
+	fs.Uint(NetworkHealthMaxDisconnectsInWindowKey, 500, fmt.Sprintf("Network layer reports unhealthy if more than this many peers disconnected within [%s]. If 0, isn't checked", NetworkHealthChurnWindowKey))
+	fs.Float64(NetworkHealthMaxConnectedStakeDropKey, .5, fmt.Sprintf("Network layer reports unhealthy if the connected stake dropped by more than this portion of the most stake connected within [%s]. Must be in [0,1]. If 0, isn't checked", NetworkHealthChurnWindowKey))
+	fs.Duration(NetworkHealthMaxTimeSinceInboundConnKey, 0, "Network layer reports unhealthy if no inbound connection was accepted for at least this much time. Should only be set on publicly reachable nodes. If 0, isn't checked")
 	// Router Health
 	fs.Float64(RouterHealthMaxDropRateKey, 1, "Node reports unhealthy if the router drops more than this portion of messages.")
 	fs.Uint(RouterHealthMaxOutstandingRequestsKey, 1024, "Node reports unhealthy if there are more than this many outstanding consensus requests (Get, PullQuery, etc.) over all chains")
@@ -214,6 +258,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(StakingEphemeralCertEnabledKey, false, "If true, the node uses an ephemeral staking key and certificate, and has an ephemeral node ID.")
 	fs.String(StakingKeyPathKey, defaultStakingKeyPath, "Path to the TLS private key for staking")
 	fs.String(StakingCertPathKey, defaultStakingCertPath, "Path to the TLS certificate for staking")
+	fs.String(StakingTLSMinVersionKey, "1.2", "Minimum TLS version used for peer connections. One of 1.0, 1.1, 1.2 or 1.3.")
+	fs.String(StakingTLSCipherSuitesKey, "", "Comma separated list of the cipher suites that may be used for TLS 1.2 and below peer connections (e.g. TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256). If empty, the crypto/tls defaults are used.")
 	fs.Uint64(StakingDisabledWeightKey, 100, "Weight to provide to each peer when staking is disabled")
 	// Uptime Requirement
 	fs.Float64(UptimeRequirementKey, genesis.LocalParams.UptimeRequirement, "Fraction of time a validator must be online to receive rewards")
@@ -221,6 +267,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(MinValidatorStakeKey, genesis.LocalParams.MinValidatorStake, "Minimum stake, in nAVAX, required to validate the primary network")
 	// Maximum Stake that can be staked and delegated to a validator on the Primary Network
 	fs.Uint64(MaxValidatorStakeKey, genesis.LocalParams.MaxValidatorStake, "Maximum stake, in nAVAX, that can be placed on a validator on the primary network")
+	// Maximum factor of a validator's own stake that its total weight may reach
+	fs.Uint64(MaxValidatorWeightFactorKey, genesis.LocalParams.MaxValidatorWeightFactor, "Maximum factor of a validator's own stake that its total weight, including delegations, may reach on the primary network")
 	// Minimum Stake that can be delegated on the Primary Network
 	fs.Uint64(MinDelegatorStakeKey, genesis.LocalParams.MinDelegatorStake, "Minimum stake, in nAVAX, that can be delegated on the primary network")
 	fs.Uint64(MinDelegatorFeeKey, uint64(genesis.LocalParams.MinDelegationFee), "Minimum delegation fee, in the range [0, 1000000], that can be charged for delegation on the primary network")
@@ -234,11 +282,14 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(WhitelistedSubnetsKey, "", "Whitelist of subnets to validate.")
 
 	// Bootstrapping
-	fs.String(BootstrapIPsKey, "", "Comma separated list of bootstrap peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
+	fs.String(BootstrapIPsKey, "", "Comma separated list of bootstrap peer ips or hostnames to connect to. Example: 127.0.0.1:9630,beacon.example.com:9631")
 	fs.String(BootstrapIDsKey, "", "Comma separated list of bootstrap peer ids to connect to. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
 	fs.Bool(RetryBootstrapKey, true, "Specifies whether bootstrap should be retried")
 	fs.Int(RetryBootstrapWarnFrequencyKey, 50, "Specifies how many times bootstrap should be retried before warning the operator")
 	fs.Duration(BootstrapBeaconConnectionTimeoutKey, time.Minute, "Timeout when attempting to connect to bootstrapping beacons.")
+	fs.Duration(BootstrapBeaconResolveFreqKey, 5*time.Minute, "Frequency at which the hostnames of bootstrap beacons are re-resolved.")
+	fs.Duration(BootstrapBeaconMaxResolveBackoffKey, time.Minute, "Max delay between attempts to resolve a bootstrap beacon's hostname after resolution failed.")
+	fs.Int(BootstrapBeaconMaxConnectFailuresKey, 3, "Number of consecutive failed attempts to connect to a bootstrap beacon's IP after which its next IP is tried, or its hostname is re-resolved.")
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapMultiputMaxContainersSentKey, 2000, "Max number of containers in a Multiput message sent by this node")
 	fs.Uint(BootstrapMultiputMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Multiput message")
@@ -281,6 +332,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// Delays
 	fs.Duration(NetworkInitialReconnectDelayKey, time.Second, "Initial delay duration must be waited before attempting to reconnect a peer.")
 	fs.Duration(NetworkMaxReconnectDelayKey, time.Hour, "Maximum delay duration must be waited before attempting to reconnect a peer.")
+	fs.Duration(NetworkMaxValidatorReconnectDelayKey, time.Minute, "Maximum delay duration must be waited before attempting to reconnect a validator or bootstrap beacon.")
 }
 
 // BuildFlagSet returns a complete set of flags for avalanchego
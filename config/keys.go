@@ -16,6 +16,7 @@ const (
 	UptimeRequirementKey                        = "uptime-requirement"
 	MinValidatorStakeKey                        = "min-validator-stake"
 	MaxValidatorStakeKey                        = "max-validator-stake"
+	MaxValidatorWeightFactorKey                 = "max-validator-weight-factor"
 	MinDelegatorStakeKey                        = "min-delegator-stake"
 	MinDelegatorFeeKey                          = "min-delegation-fee"
 	MinStakeDurationKey                         = "min-stake-duration"
@@ -32,6 +33,10 @@ const (
 	InboundConnUpgradeThrottlerCooldownKey      = "inbound-connection-throttling-cooldown"
 	InboundConnUpgradeThrottlerMaxRecentKey     = "inbound-connection-throttling-max-recent" // Deprecated starting in v1.6.0. TODO remove in a future release.
 	InboundThrottlerMaxConnsPerSecKey           = "inbound-connection-throttling-max-conns-per-sec"
+	InboundConnGaterMaxConnsPerIPKey            = "inbound-connection-gater-max-conns-per-ip"
+	InboundConnGaterMaxValidatorConnsPerIPKey   = "inbound-connection-gater-max-validator-conns-per-ip"
+	InboundConnGaterMaxRecentAttemptsPerIPKey   = "inbound-connection-gater-max-recent-attempts-per-ip"
+	InboundConnGaterAttemptWindowKey            = "inbound-connection-gater-attempt-window"
 	OutboundConnectionThrottlingRps             = "outbound-connection-throttling-rps"
 	OutboundConnectionTimeout                   = "outbound-connection-timeout"
 	HTTPHostKey                                 = "http-host"
@@ -49,6 +54,8 @@ const (
 	StakingEphemeralCertEnabledKey              = "staking-ephemeral-cert-enabled"
 	StakingKeyPathKey                           = "staking-tls-key-file"
 	StakingCertPathKey                          = "staking-tls-cert-file"
+	StakingTLSMinVersionKey                     = "staking-tls-min-version"
+	StakingTLSCipherSuitesKey                   = "staking-tls-cipher-suites"
 	StakingDisabledWeightKey                    = "staking-disabled-weight"
 	NetworkInitialTimeoutKey                    = "network-initial-timeout"
 	NetworkMinimumTimeoutKey                    = "network-minimum-timeout"
@@ -60,18 +67,28 @@ const (
 	NetworkHealthMaxTimeSinceMsgSentKey         = "network-health-max-time-since-msg-sent"
 	NetworkHealthMaxPortionSendQueueFillKey     = "network-health-max-portion-send-queue-full"
 	NetworkHealthMaxSendFailRateKey             = "network-health-max-send-fail-rate"
+	NetworkHealthChurnWindowKey                 = "network-health-churn-window"
+	NetworkHealthMaxDisconnectsInWindowKey      = "network-health-max-disconnects-in-window"
+	NetworkHealthMaxConnectedStakeDropKey       = "network-health-max-connected-stake-drop"
+	NetworkHealthMaxTimeSinceInboundConnKey     = "network-health-max-time-since-inbound-conn"
 	NetworkHealthMaxOutstandingDurationKey      = "network-health-max-outstanding-request-duration"
 	NetworkPeerListSizeKey                      = "network-peer-list-size"
 	NetworkPeerListGossipSizeKey                = "network-peer-list-gossip-size"
 	NetworkPeerListGossipFreqKey                = "network-peer-list-gossip-frequency"
 	NetworkPeerListStakerGossipFractionKey      = "network-peer-list-staker-gossip-fraction"
+	NetworkPeerListMaxStrikesKey                = "network-peer-list-max-strikes"
+	NetworkPeerListMaxIPAgeKey                  = "network-peer-list-max-ip-age"
 	NetworkInitialReconnectDelayKey             = "network-initial-reconnect-delay"
 	NetworkGetVersionTimeoutKey                 = "network-get-version-timeout"
 	NetworkReadHandshakeTimeoutKey              = "network-read-handshake-timeout"
 	NetworkPingTimeoutKey                       = "network-ping-timeout"
 	NetworkPingFrequencyKey                     = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                 = "network-max-reconnect-delay"
+	NetworkMaxValidatorReconnectDelayKey        = "network-max-validator-reconnect-delay"
 	NetworkCompressionEnabledKey                = "network-compression-enabled"
+	NetworkZstdCompressionEnabledKey            = "network-zstd-compression-enabled"
+	NetworkMaxMessageSizesKey                   = "network-max-message-sizes"
+	NetworkOversizedMsgMaxStrikesKey            = "network-oversized-msg-max-strikes"
 	NetworkMaxClockDifferenceKey                = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                   = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey         = "network-require-validator-to-connect"
@@ -110,6 +127,8 @@ const (
 	AppGossipNonValidatorSizeKey                = "consensus-app-gossip-non-validator-size"
 	AppGossipValidatorSizeKey                   = "consensus-app-gossip-validator-size"
 	ConsensusShutdownTimeoutKey                 = "consensus-shutdown-timeout"
+	ConsensusVerifyWorkersKey                   = "consensus-verify-workers"
+	ConsensusRejectedCacheSizeKey               = "consensus-rejected-cache-size"
 	FdLimitKey                                  = "fd-limit"
 	IndexEnabledKey                             = "index-enabled"
 	IndexAllowIncompleteKey                     = "index-allow-incomplete"
@@ -122,6 +141,18 @@ const (
 	PeerAliasTimeoutKey                         = "peer-alias-timeout"
 	PluginModeKey                               = "plugin-mode-enabled"
 	BootstrapBeaconConnectionTimeoutKey         = "bootstrap-beacon-connection-timeout"
+	BootstrapBeaconResolveFreqKey               = "bootstrap-beacon-resolve-frequency"
+	NetworkPeerPersistenceEnabledKey            = "network-peer-persistence-enabled"
+	NetworkPeerPersistenceFreqKey               = "network-peer-persistence-frequency"
+	NetworkPeerPersistenceTTLKey                = "network-peer-persistence-ttl"
+	NetworkPeerPersistenceMaxDialKey            = "network-peer-persistence-max-dial"
+	NetworkACLAllowlistKey                      = "network-acl-allowlist"
+	NetworkACLDenylistKey                       = "network-acl-denylist"
+	NetworkACLExemptBeaconsAndValidatorsKey     = "network-acl-exempt-beacons-and-validators"
+	NetworkProxyProtocolEnabledKey              = "network-proxy-protocol-enabled"
+	NetworkProxyProtocolHeaderTimeoutKey        = "network-proxy-protocol-header-timeout"
+	BootstrapBeaconMaxResolveBackoffKey         = "bootstrap-beacon-max-resolve-backoff"
+	BootstrapBeaconMaxConnectFailuresKey        = "bootstrap-beacon-max-connect-failures"
 	BootstrapMaxTimeGetAncestorsKey             = "boostrap-max-time-get-ancestors"
 	BootstrapMultiputMaxContainersSentKey       = "bootstrap-multiput-max-containers-sent"
 	BootstrapMultiputMaxContainersReceivedKey   = "bootstrap-multiput-max-containers-received"
@@ -137,9 +168,23 @@ const (
 	InboundThrottlerMaxProcessingMsgsPerNodeKey = "throttler-inbound-node-max-processing-msgs"
 	InboundThrottlerBandwidthRefillRateKey      = "throttler-inbound-bandwidth-refill-rate"
 	InboundThrottlerBandwidthMaxBurstSizeKey    = "throttler-inbound-bandwidth-max-burst-size"
+	InboundThrottlerAppBytesRefillRateKey       = "throttler-inbound-app-bytes-refill-rate"
+	InboundThrottlerAppBytesMaxBurstSizeKey     = "throttler-inbound-app-bytes-max-burst-size"
+	InboundThrottlerAppMsgRefillRateKey         = "throttler-inbound-app-msg-refill-rate"
+	InboundThrottlerAppMsgMaxBurstSizeKey       = "throttler-inbound-app-msg-max-burst-size"
+	InboundThrottlerAppMaxMsgSizeKey            = "throttler-inbound-app-max-msg-size"
+	InboundThrottlerAppMaxProcessingMsgsKey     = "throttler-inbound-app-max-processing-msgs"
+	InboundThrottlerAppMaxProcessingBytesKey    = "throttler-inbound-app-max-processing-bytes"
+	InboundThrottlerCPUHalflifeKey              = "throttler-inbound-cpu-halflife"
+	InboundThrottlerCPUVdrAllocKey              = "throttler-inbound-cpu-validator-alloc"
+	InboundThrottlerCPUMaxAtLargeAllocKey       = "throttler-inbound-cpu-max-at-large-alloc"
+	InboundThrottlerCPUMaxDelayKey              = "throttler-inbound-cpu-max-delay"
 	OutboundThrottlerAtLargeAllocSizeKey        = "throttler-outbound-at-large-alloc-size"
 	OutboundThrottlerVdrAllocSizeKey            = "throttler-outbound-validator-alloc-size"
 	OutboundThrottlerNodeMaxAtLargeBytesKey     = "throttler-outbound-node-max-at-large-bytes"
+	OutboundThrottlerBandwidthRefillRateKey     = "throttler-outbound-bandwidth-refill-rate"
+	OutboundThrottlerBandwidthMaxBurstSizeKey   = "throttler-outbound-bandwidth-max-burst-size"
+	OutboundThrottlerBandwidthMaxDelayKey       = "throttler-outbound-bandwidth-max-delay"
 	UptimeMetricFreqKey                         = "uptime-metric-freq"
 	VMAliasesFileKey                            = "vm-aliases-file"
 )
@@ -15,10 +15,12 @@ const (
 )
 
 var (
-	headKey = []byte{0x01}
+	headKey    = []byte{0x01}
+	nodePrefix = []byte{0x00}
 
 	_ LinkedDB          = &linkedDB{}
 	_ database.Iterator = &iterator{}
+	_ database.Iterator = &sortedIterator{}
 )
 
 // LinkedDB provides a key value interface while allowing iteration.
@@ -32,6 +34,7 @@ type LinkedDB interface {
 
 	NewIterator() database.Iterator
 	NewIteratorWithStart(start []byte) database.Iterator
+	NewSortedIteratorWithStart(start []byte) database.Iterator
 }
 
 type linkedDB struct {
@@ -247,6 +250,17 @@ func (ldb *linkedDB) NewIteratorWithStart(start []byte) database.Iterator {
 	return ldb.NewIterator()
 }
 
+// NewSortedIteratorWithStart returns an iterator over the keys that are
+// greater than or equal to [start], in lexicographic order.
+// Unlike NewIteratorWithStart, [start] doesn't need to be in the list, so
+// iteration can be resumed after the previously returned key was deleted.
+func (ldb *linkedDB) NewSortedIteratorWithStart(start []byte) database.Iterator {
+	return &sortedIterator{
+		ldb:  ldb,
+		iter: ldb.db.NewIteratorWithStartAndPrefix(nodeKey(start), nodePrefix),
+	}
+}
+
 func (ldb *linkedDB) getHeadKey() ([]byte, error) {
 	// If the ldb read lock is held, then there needs to be additional
 	// synchronization here to avoid racy behavior.
@@ -422,6 +436,47 @@ func (it *iterator) Key() []byte   { return it.key }
 func (it *iterator) Value() []byte { return it.value }
 func (it *iterator) Release()      {}
 
+// sortedIterator iterates over the nodes of the list in the order they are
+// stored in the underlying database, which is sorted by key.
+type sortedIterator struct {
+	ldb        *linkedDB
+	iter       database.Iterator
+	key, value []byte
+	err        error
+}
+
+func (it *sortedIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.ldb.lock.RLock()
+	defer it.ldb.lock.RUnlock()
+
+	if !it.iter.Next() {
+		it.key = nil
+		it.value = nil
+		it.err = it.iter.Error()
+		return false
+	}
+
+	n := node{}
+	if _, err := c.Unmarshal(it.iter.Value(), &n); err != nil {
+		it.key = nil
+		it.value = nil
+		it.err = err
+		return false
+	}
+	it.key = it.iter.Key()[len(nodePrefix):]
+	it.value = n.Value
+	return true
+}
+
+func (it *sortedIterator) Error() error  { return it.err }
+func (it *sortedIterator) Key() []byte   { return it.key }
+func (it *sortedIterator) Value() []byte { return it.value }
+func (it *sortedIterator) Release()      { it.iter.Release() }
+
 func nodeKey(key []byte) []byte {
 	newKey := make([]byte, len(key)+1)
 	copy(newKey[1:], key)
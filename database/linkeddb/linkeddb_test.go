@@ -399,6 +399,56 @@ func TestEmptyLinkedDBIteratorStart(t *testing.T) {
 	iter.Release()
 }
 
+// Test that the sorted iterator returns keys in lexicographic order, starting
+// at the first key that isn't less than the start key, even if the start key
+// isn't in the database
+func TestLinkedDBSortedIteratorWithStart(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	ldb := NewDefault(db)
+
+	key0 := []byte("hello0")
+	key1 := []byte("hello1")
+	key2 := []byte("hello2")
+	value0 := []byte("world0")
+	value1 := []byte("world1")
+	value2 := []byte("world2")
+
+	err := ldb.Put(key2, value2)
+	assert.NoError(err)
+
+	err = ldb.Put(key0, value0)
+	assert.NoError(err)
+
+	err = ldb.Put(key1, value1)
+	assert.NoError(err)
+
+	iter := ldb.NewSortedIteratorWithStart(nil)
+	keys := [][]byte(nil)
+	values := [][]byte(nil)
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		values = append(values, iter.Value())
+	}
+	assert.NoError(iter.Error())
+	iter.Release()
+	assert.Equal([][]byte{key0, key1, key2}, keys)
+	assert.Equal([][]byte{value0, value1, value2}, values)
+
+	err = ldb.Delete(key1)
+	assert.NoError(err)
+
+	iter = ldb.NewSortedIteratorWithStart(key1)
+	keys = nil
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	assert.NoError(iter.Error())
+	iter.Release()
+	assert.Equal([][]byte{key2}, keys)
+}
+
 func TestLinkedDBIsEmpty(t *testing.T) {
 	assert := assert.New(t)
 
@@ -78,6 +78,14 @@ type Config struct {
 
 	CChainGenesis string `json:"cChainGenesis"`
 
+	// StakingParams, if set, overrides the default staking parameters of a
+	// custom network.
+	StakingParams *StakingParams `json:"stakingParams,omitempty"`
+
+	// AVMParams, if set, overrides the default X-chain parameters of a custom
+	// network.
+	AVMParams *AVMParams `json:"avmParams,omitempty"`
+
 	Message string `json:"message"`
 }
 
@@ -91,6 +99,8 @@ func (c Config) Unparse() (UnparsedConfig, error) {
 		InitialStakedFunds:         make([]string, len(c.InitialStakedFunds)),
 		InitialStakers:             make([]UnparsedStaker, len(c.InitialStakers)),
 		CChainGenesis:              c.CChainGenesis,
+		StakingParams:              c.StakingParams,
+		AVMParams:                  c.AVMParams,
 		Message:                    c.Message,
 	}
 	for i, a := range c.Allocations {
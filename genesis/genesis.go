@@ -162,6 +162,34 @@ func validateConfig(networkID uint32, config *Config) error {
 		return errNoCChainGenesis
 	}
 
+	if config.StakingParams != nil {
+		switch networkID {
+		case constants.MainnetID, constants.TestnetID:
+			return fmt.Errorf(
+				"cannot override staking parameters for standard network %s (%d)",
+				constants.NetworkName(networkID),
+				networkID,
+			)
+		}
+		if err := config.StakingParams.Verify(); err != nil {
+			return fmt.Errorf("invalid staking parameters: %w", err)
+		}
+	}
+
+	if config.AVMParams != nil {
+		switch networkID {
+		case constants.MainnetID, constants.TestnetID:
+			return fmt.Errorf(
+				"cannot override X-chain parameters for standard network %s (%d)",
+				constants.NetworkName(networkID),
+				networkID,
+			)
+		}
+		if err := config.AVMParams.Verify(); err != nil {
+			return fmt.Errorf("invalid X-chain parameters: %w", err)
+		}
+	}
+
 	return nil
 }
 
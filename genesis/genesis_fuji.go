@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
 
 var (
@@ -205,14 +206,18 @@ var (
 			CreateBlockchainTxFee: 100 * units.MilliAvax,
 		},
 		StakingConfig: StakingConfig{
-			UptimeRequirement:  .8, // 80%
-			MinValidatorStake:  1 * units.Avax,
-			MaxValidatorStake:  3 * units.MegaAvax,
-			MinDelegatorStake:  1 * units.Avax,
-			MinDelegationFee:   20000, // 2%
-			MinStakeDuration:   24 * time.Hour,
-			MaxStakeDuration:   365 * 24 * time.Hour,
-			StakeMintingPeriod: 365 * 24 * time.Hour,
+			UptimeRequirement:        .8, // 80%
+			MinValidatorStake:        1 * units.Avax,
+			MaxValidatorStake:        3 * units.MegaAvax,
+			MaxValidatorWeightFactor: 5,
+			MinDelegatorStake:        1 * units.Avax,
+			MinDelegationFee:         20000, // 2%
+			MinStakeDuration:         24 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			StakeMintingPeriod:       365 * 24 * time.Hour,
+		},
+		AVMConfig: AVMConfig{
+			MaxMemoSize: avax.MaxMemoSize,
 		},
 	}
 )
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
 
 // PrivateKey-vmRQiZeXEXYMyJhEiqdC2z5JhuDbxL8ix9UVvjgMu2Er1NepE => P-local1g65uqn6t77p656w64023nh8nd9updzmxyymev2
@@ -100,14 +101,18 @@ var (
 			CreateBlockchainTxFee: 100 * units.MilliAvax,
 		},
 		StakingConfig: StakingConfig{
-			UptimeRequirement:  .8, // 80%
-			MinValidatorStake:  2 * units.KiloAvax,
-			MaxValidatorStake:  3 * units.MegaAvax,
-			MinDelegatorStake:  25 * units.Avax,
-			MinDelegationFee:   20000, // 2%
-			MinStakeDuration:   24 * time.Hour,
-			MaxStakeDuration:   365 * 24 * time.Hour,
-			StakeMintingPeriod: 365 * 24 * time.Hour,
+			UptimeRequirement:        .8, // 80%
+			MinValidatorStake:        2 * units.KiloAvax,
+			MaxValidatorStake:        3 * units.MegaAvax,
+			MaxValidatorWeightFactor: 5,
+			MinDelegatorStake:        25 * units.Avax,
+			MinDelegationFee:         20000, // 2%
+			MinStakeDuration:         24 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			StakeMintingPeriod:       365 * 24 * time.Hour,
+		},
+		AVMConfig: AVMConfig{
+			MaxMemoSize: avax.MaxMemoSize,
 		},
 	}
 )
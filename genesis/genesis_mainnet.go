@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
 
 var (
@@ -175778,14 +175779,18 @@ var (
 			CreateBlockchainTxFee: 1 * units.Avax,
 		},
 		StakingConfig: StakingConfig{
-			UptimeRequirement:  .8, // 80%
-			MinValidatorStake:  2 * units.KiloAvax,
-			MaxValidatorStake:  3 * units.MegaAvax,
-			MinDelegatorStake:  25 * units.Avax,
-			MinDelegationFee:   20000, // 2%
-			MinStakeDuration:   2 * 7 * 24 * time.Hour,
-			MaxStakeDuration:   365 * 24 * time.Hour,
-			StakeMintingPeriod: 365 * 24 * time.Hour,
+			UptimeRequirement:        .8, // 80%
+			MinValidatorStake:        2 * units.KiloAvax,
+			MaxValidatorStake:        3 * units.MegaAvax,
+			MaxValidatorWeightFactor: 5,
+			MinDelegatorStake:        25 * units.Avax,
+			MinDelegationFee:         20000, // 2%
+			MinStakeDuration:         2 * 7 * 24 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			StakeMintingPeriod:       365 * 24 * time.Hour,
+		},
+		AVMConfig: AVMConfig{
+			MaxMemoSize: avax.MaxMemoSize,
 		},
 	}
 )
@@ -123,6 +123,124 @@ func TestValidateConfig(t *testing.T) {
 			}(),
 			err: "C-Chain genesis cannot be empty",
 		},
+		"custom staking params": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.StakingParams = &StakingParams{
+					MinValidatorStake: 1,
+					MaxValidatorStake: 10,
+					MinDelegatorStake: 1,
+					MinStakeDuration:  60,
+					MaxStakeDuration:  120,
+				}
+				return &thisConfig
+			}(),
+		},
+		"custom max validator weight factor": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.StakingParams = &StakingParams{
+					MinValidatorStake:        1,
+					MaxValidatorStake:        10,
+					MaxValidatorWeightFactor: 2,
+					MinDelegatorStake:        1,
+					MinStakeDuration:         60,
+					MaxStakeDuration:         120,
+				}
+				return &thisConfig
+			}(),
+		},
+		"mainnet (custom staking params)": {
+			networkID: 1,
+			config: func() *Config {
+				thisConfig := MainnetConfig
+				thisConfig.StakingParams = &StakingParams{
+					MinValidatorStake: 1,
+					MaxValidatorStake: 10,
+					MinDelegatorStake: 1,
+					MinStakeDuration:  60,
+					MaxStakeDuration:  120,
+				}
+				return &thisConfig
+			}(),
+			err: "cannot override staking parameters for standard network mainnet (1)",
+		},
+		"min validator stake above max": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.StakingParams = &StakingParams{
+					MinValidatorStake: 11,
+					MaxValidatorStake: 10,
+					MinDelegatorStake: 1,
+					MinStakeDuration:  60,
+					MaxStakeDuration:  120,
+				}
+				return &thisConfig
+			}(),
+			err: "minimum validator stake 11 is greater than maximum validator stake 10",
+		},
+		"zero min stake duration": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.StakingParams = &StakingParams{
+					MinValidatorStake: 1,
+					MaxValidatorStake: 10,
+					MinDelegatorStake: 1,
+					MaxStakeDuration:  120,
+				}
+				return &thisConfig
+			}(),
+			err: "minimum stake duration must be > 0",
+		},
+		"min stake duration above max": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.StakingParams = &StakingParams{
+					MinValidatorStake: 1,
+					MaxValidatorStake: 10,
+					MinDelegatorStake: 1,
+					MinStakeDuration:  121,
+					MaxStakeDuration:  120,
+				}
+				return &thisConfig
+			}(),
+			err: "minimum stake duration 121 is greater than maximum stake duration 120",
+		},
+		"custom avm params": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.AVMParams = &AVMParams{
+					MaxMemoSize: 1024,
+				}
+				return &thisConfig
+			}(),
+		},
+		"mainnet (custom avm params)": {
+			networkID: 1,
+			config: func() *Config {
+				thisConfig := MainnetConfig
+				thisConfig.AVMParams = &AVMParams{
+					MaxMemoSize: 1024,
+				}
+				return &thisConfig
+			}(),
+			err: "cannot override X-chain parameters for standard network mainnet (1)",
+		},
+		"zero max memo size": {
+			networkID: 12345,
+			config: func() *Config {
+				thisConfig := LocalConfig
+				thisConfig.AVMParams = &AVMParams{}
+				return &thisConfig
+			}(),
+			err: "maximum memo size must be > 0",
+		},
 		"empty message": {
 			networkID: 12345,
 			config: func() *Config {
@@ -314,6 +432,22 @@ func TestGenesis(t *testing.T) {
 	}
 }
 
+func TestGenesisWithoutXChainAllocations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := LocalConfig
+	config.NetworkID = 9999
+	config.Allocations = make([]Allocation, len(LocalConfig.Allocations))
+	for i, allocation := range LocalConfig.Allocations {
+		allocation.InitialAmount = 0
+		config.Allocations[i] = allocation
+	}
+
+	_, avaxAssetID, err := FromConfig(&config)
+	assert.NoError(err)
+	assert.NotEqual(ids.Empty, avaxAssetID)
+}
+
 func TestVMGenesis(t *testing.T) {
 	type vmTest struct {
 		vmID       ids.ID
@@ -4,6 +4,8 @@
 package genesis
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -17,6 +19,9 @@ type StakingConfig struct {
 	// Maximum stake, in nAVAX, allowed to be placed on a single validator in
 	// the primary network
 	MaxValidatorStake uint64 `json:"maxValidatorStake"`
+	// MaxValidatorWeightFactor is the maximum factor of a validator's own
+	// stake that its total weight, including delegations, may reach
+	MaxValidatorWeightFactor uint64 `json:"maxValidatorWeightFactor"`
 	// Minimum stake, in nAVAX, that can be delegated on the primary network
 	MinDelegatorStake uint64 `json:"minDelegatorStake"`
 	// Minimum delegation fee, in the range [0, 1000000], that can be charged
@@ -32,6 +37,97 @@ type StakingConfig struct {
 	StakeMintingPeriod time.Duration `json:"stakeMintingPeriod"`
 }
 
+var (
+	errNoMinStakeDuration  = errors.New("minimum stake duration must be > 0")
+	errNoMaxStakeDuration  = errors.New("maximum stake duration must be > 0")
+	errNoMinValidatorStake = errors.New("minimum validator stake must be > 0")
+	errNoMinDelegatorStake = errors.New("minimum delegator stake must be > 0")
+	errNoMaxMemoSize       = errors.New("maximum memo size must be > 0")
+)
+
+// StakingParams are the staking parameters that the genesis of a custom
+// network may override. Durations are given in seconds. If
+// MaxValidatorWeightFactor is omitted, the network's default is kept.
+type StakingParams struct {
+	MinValidatorStake        uint64 `json:"minValidatorStake"`
+	MaxValidatorStake        uint64 `json:"maxValidatorStake"`
+	MaxValidatorWeightFactor uint64 `json:"maxValidatorWeightFactor,omitempty"`
+	MinDelegatorStake        uint64 `json:"minDelegatorStake"`
+	MinStakeDuration         uint64 `json:"minStakeDuration"`
+	MaxStakeDuration         uint64 `json:"maxStakeDuration"`
+}
+
+// Verify returns an error if [p] doesn't describe a usable set of staking
+// parameters.
+func (p *StakingParams) Verify() error {
+	switch {
+	case p.MinValidatorStake == 0:
+		return errNoMinValidatorStake
+	case p.MinValidatorStake > p.MaxValidatorStake:
+		return fmt.Errorf(
+			"minimum validator stake %d is greater than maximum validator stake %d",
+			p.MinValidatorStake,
+			p.MaxValidatorStake,
+		)
+	case p.MinDelegatorStake == 0:
+		return errNoMinDelegatorStake
+	case p.MinDelegatorStake > p.MaxValidatorStake:
+		return fmt.Errorf(
+			"minimum delegator stake %d is greater than maximum validator stake %d",
+			p.MinDelegatorStake,
+			p.MaxValidatorStake,
+		)
+	case p.MinStakeDuration == 0:
+		return errNoMinStakeDuration
+	case p.MaxStakeDuration == 0:
+		return errNoMaxStakeDuration
+	case p.MinStakeDuration > p.MaxStakeDuration:
+		return fmt.Errorf(
+			"minimum stake duration %d is greater than maximum stake duration %d",
+			p.MinStakeDuration,
+			p.MaxStakeDuration,
+		)
+	}
+	return nil
+}
+
+// Apply overrides the staking parameters in [config] with the ones in [p].
+func (p *StakingParams) Apply(config *StakingConfig) {
+	config.MinValidatorStake = p.MinValidatorStake
+	config.MaxValidatorStake = p.MaxValidatorStake
+	if p.MaxValidatorWeightFactor != 0 {
+		config.MaxValidatorWeightFactor = p.MaxValidatorWeightFactor
+	}
+	config.MinDelegatorStake = p.MinDelegatorStake
+	config.MinStakeDuration = time.Duration(p.MinStakeDuration) * time.Second
+	config.MaxStakeDuration = time.Duration(p.MaxStakeDuration) * time.Second
+}
+
+type AVMConfig struct {
+	// Maximum number of bytes in the memo field of an X-chain tx
+	MaxMemoSize int `json:"maxMemoSize"`
+}
+
+// AVMParams are the X-chain parameters that the genesis of a custom network
+// may override.
+type AVMParams struct {
+	MaxMemoSize int `json:"maxMemoSize"`
+}
+
+// Verify returns an error if [p] doesn't describe a usable set of X-chain
+// parameters.
+func (p *AVMParams) Verify() error {
+	if p.MaxMemoSize <= 0 {
+		return errNoMaxMemoSize
+	}
+	return nil
+}
+
+// Apply overrides the X-chain parameters in [config] with the ones in [p].
+func (p *AVMParams) Apply(config *AVMConfig) {
+	config.MaxMemoSize = p.MaxMemoSize
+}
+
 type TxFeeConfig struct {
 	// Transaction fee
 	TxFee uint64 `json:"txFee"`
@@ -46,6 +142,7 @@ type TxFeeConfig struct {
 type Params struct {
 	StakingConfig
 	TxFeeConfig
+	AVMConfig
 }
 
 func GetTxFeeConfig(networkID uint32) TxFeeConfig {
@@ -73,3 +170,18 @@ func GetStakingConfig(networkID uint32) StakingConfig {
 		return LocalParams.StakingConfig
 	}
 }
+
+// GetAVMConfig returns the X-chain parameters of [networkID]. The memo size of
+// Mainnet and Fuji is always [avax.MaxMemoSize].
+func GetAVMConfig(networkID uint32) AVMConfig {
+	switch networkID {
+	case constants.MainnetID:
+		return MainnetParams.AVMConfig
+	case constants.FujiID:
+		return FujiParams.AVMConfig
+	case constants.LocalID:
+		return LocalParams.AVMConfig
+	default:
+		return LocalParams.AVMConfig
+	}
+}
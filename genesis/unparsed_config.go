@@ -97,6 +97,9 @@ type UnparsedConfig struct {
 
 	CChainGenesis string `json:"cChainGenesis"`
 
+	StakingParams *StakingParams `json:"stakingParams,omitempty"`
+	AVMParams     *AVMParams     `json:"avmParams,omitempty"`
+
 	Message string `json:"message"`
 }
 
@@ -110,6 +113,8 @@ func (uc UnparsedConfig) Parse() (Config, error) {
 		InitialStakedFunds:         make([]ids.ShortID, len(uc.InitialStakedFunds)),
 		InitialStakers:             make([]Staker, len(uc.InitialStakers)),
 		CChainGenesis:              uc.CChainGenesis,
+		StakingParams:              uc.StakingParams,
+		AVMParams:                  uc.AVMParams,
 		Message:                    uc.Message,
 	}
 	for i, ua := range uc.Allocations {
@@ -51,6 +51,7 @@ var (
 	isIncompletePrefix      = byte(0x04)
 	previouslyIndexedPrefix = byte(0x05)
 	hasRunKey               = []byte{0x07}
+	stakerEndedPrefix       = byte(0x08)
 
 	_ Indexer = &indexer{}
 )
@@ -62,8 +63,11 @@ type Config struct {
 	IndexingEnabled                         bool
 	AllowIncompleteIndex                    bool
 	DecisionDispatcher, ConsensusDispatcher *triggers.EventDispatcher
-	APIServer                               server.RouteAdder
-	ShutdownF                               func()
+	// Notifies of stakers leaving the P-chain's current staker set. If nil,
+	// staker ended events aren't indexed.
+	StakerEndedDispatcher *triggers.EventDispatcher
+	APIServer             server.RouteAdder
+	ShutdownF             func()
 }
 
 // Indexer causes accepted containers for a given chain
@@ -79,18 +83,20 @@ type Indexer interface {
 // NewIndexer returns a new Indexer and registers a new endpoint on the given API server.
 func NewIndexer(config Config) (Indexer, error) {
 	indexer := &indexer{
-		codec:                codec.NewManager(codecMaxSize),
-		log:                  config.Log,
-		db:                   config.DB,
-		allowIncompleteIndex: config.AllowIncompleteIndex,
-		indexingEnabled:      config.IndexingEnabled,
-		consensusDispatcher:  config.ConsensusDispatcher,
-		decisionDispatcher:   config.DecisionDispatcher,
-		txIndices:            map[ids.ID]Index{},
-		vtxIndices:           map[ids.ID]Index{},
-		blockIndices:         map[ids.ID]Index{},
-		routeAdder:           config.APIServer,
-		shutdownF:            config.ShutdownF,
+		codec:                 codec.NewManager(codecMaxSize),
+		log:                   config.Log,
+		db:                    config.DB,
+		allowIncompleteIndex:  config.AllowIncompleteIndex,
+		indexingEnabled:       config.IndexingEnabled,
+		consensusDispatcher:   config.ConsensusDispatcher,
+		decisionDispatcher:    config.DecisionDispatcher,
+		stakerEndedDispatcher: config.StakerEndedDispatcher,
+		txIndices:             map[ids.ID]Index{},
+		vtxIndices:            map[ids.ID]Index{},
+		blockIndices:          map[ids.ID]Index{},
+		stakerEndedIndices:    map[ids.ID]Index{},
+		routeAdder:            config.APIServer,
+		shutdownF:             config.ShutdownF,
 	}
 	if err := indexer.codec.RegisterCodec(
 		codecVersion,
@@ -137,11 +143,15 @@ type indexer struct {
 	vtxIndices map[ids.ID]Index
 	// Chain ID --> index of txs of that chain (if applicable)
 	txIndices map[ids.ID]Index
+	// Chain ID --> index of staker ended events of that chain (if applicable)
+	stakerEndedIndices map[ids.ID]Index
 
 	// Notifies of newly accepted blocks and vertices
 	consensusDispatcher *triggers.EventDispatcher
 	// Notifies of newly accepted transactions
 	decisionDispatcher *triggers.EventDispatcher
+	// Notifies of stakers leaving the P-chain's current staker set
+	stakerEndedDispatcher *triggers.EventDispatcher
 }
 
 // Assumes [engine]'s context lock is not held
@@ -234,6 +244,19 @@ func (i *indexer) RegisterChain(name string, ctx *snow.ConsensusContext, engine
 			return
 		}
 		i.blockIndices[chainID] = index
+
+		if chainID != constants.PlatformChainID || i.stakerEndedDispatcher == nil {
+			return
+		}
+		stakerEndedIndex, err := i.registerChainHelper(chainID, stakerEndedPrefix, name, "stakerEnded", i.stakerEndedDispatcher)
+		if err != nil {
+			i.log.Fatal("couldn't create staker ended index for %s: %s", name, err)
+			if err := i.close(); err != nil {
+				i.log.Error("error while closing indexer: %s", err)
+			}
+			return
+		}
+		i.stakerEndedIndices[chainID] = stakerEndedIndex
 	case avalanche.Engine:
 		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusDispatcher)
 		if err != nil {
@@ -338,6 +361,12 @@ func (i *indexer) close() error {
 			i.consensusDispatcher.DeregisterChain(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID)),
 		)
 	}
+	for chainID, stakerEndedIndex := range i.stakerEndedIndices {
+		errs.Add(
+			stakerEndedIndex.Close(),
+			i.stakerEndedDispatcher.DeregisterChain(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID)),
+		)
+	}
 	errs.Add(i.db.Close())
 
 	go i.shutdownF()
@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/triggers"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 
 	avvtxmocks "github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex/mocks"
@@ -547,3 +548,72 @@ func TestIgnoreNonDefaultChains(t *testing.T) {
 	idxr.RegisterChain("chain1", chain1Ctx, chainEngine)
 	assert.Len(idxr.blockIndices, 0)
 }
+
+// Ensure staker ended events of the P-chain are indexed
+func TestIndexStakerEnded(t *testing.T) {
+	assert := assert.New(t)
+	cd := &triggers.EventDispatcher{}
+	cd.Initialize(logging.NoLog{})
+	dd := &triggers.EventDispatcher{}
+	dd.Initialize(logging.NoLog{})
+	sd := &triggers.EventDispatcher{}
+	sd.Initialize(logging.NoLog{})
+	config := Config{
+		IndexingEnabled:       true,
+		AllowIncompleteIndex:  false,
+		Log:                   logging.NoLog{},
+		DB:                    memdb.New(),
+		ConsensusDispatcher:   cd,
+		DecisionDispatcher:    dd,
+		StakerEndedDispatcher: sd,
+		APIServer:             &apiServerMock{},
+		ShutdownF:             func() {},
+	}
+
+	idxrIntf, err := NewIndexer(config)
+	assert.NoError(err)
+	idxr, ok := idxrIntf.(*indexer)
+	assert.True(ok)
+	now := time.Now()
+	idxr.clock.Set(now)
+
+	// Only the P-chain has a staker ended index
+	chain1Ctx := snow.DefaultConsensusContextTest()
+	chain1Ctx.ChainID = ids.GenerateTestID()
+	chain1Engine := &smengmocks.Engine{}
+	chain1Engine.On("GetVM").Return(&smblockmocks.ChainVM{})
+	idxr.RegisterChain("chain1", chain1Ctx, chain1Engine)
+	assert.Len(idxr.blockIndices, 1)
+	assert.Len(idxr.stakerEndedIndices, 0)
+
+	pChainCtx := snow.DefaultConsensusContextTest()
+	pChainCtx.ChainID = constants.PlatformChainID
+	pChainEngine := &smengmocks.Engine{}
+	pChainEngine.On("GetVM").Return(&smblockmocks.ChainVM{})
+	idxr.RegisterChain("P", pChainCtx, pChainEngine)
+	assert.Len(idxr.blockIndices, 2)
+	assert.Len(idxr.stakerEndedIndices, 1)
+
+	server := config.APIServer.(*apiServerMock)
+	assert.EqualValues(3, server.timesCalled)
+	assert.EqualValues("index/P", server.bases[2])
+	assert.EqualValues("/stakerEnded", server.endpoints[2])
+
+	txID, eventBytes := ids.GenerateTestID(), utils.RandomBytes(32)
+	assert.NoError(sd.Accept(pChainCtx, txID, eventBytes))
+
+	stakerEndedIdx := idxr.stakerEndedIndices[pChainCtx.ChainID]
+	container, err := stakerEndedIdx.GetLastAccepted()
+	assert.NoError(err)
+	assert.Equal(Container{
+		ID:        txID,
+		Bytes:     eventBytes,
+		Timestamp: now.UnixNano(),
+	}, container)
+
+	// The event isn't indexed as a block
+	_, err = idxr.blockIndices[pChainCtx.ChainID].GetLastAccepted()
+	assert.Error(err)
+
+	assert.NoError(idxr.Close())
+}
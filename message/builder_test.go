@@ -27,7 +27,7 @@ var (
 )
 
 func init() {
-	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB)
+	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -89,6 +89,32 @@ func TestBuildVersion(t *testing.T) {
 	assert.EqualValues(t, subnetIDs, parsedMsg.Get(TrackedSubnets))
 }
 
+// Ensure that IPv6 addresses are carried by Version messages and parsed back
+// out unchanged.
+func TestBuildVersionIPv6(t *testing.T) {
+	ip := utils.IPDesc{
+		IP:   net.ParseIP("2001:db8::1"),
+		Port: 9651,
+	}
+	msg, err := UncompressingBuilder.Version(
+		12345,
+		56789,
+		uint64(time.Now().Unix()),
+		ip,
+		version.NewDefaultVersion(1, 2, 3).String(),
+		uint64(time.Now().Unix()),
+		make([]byte, 65),
+		nil,
+	)
+	assert.NoError(t, err)
+
+	parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.NoError(t, err)
+	parsedIP := parsedMsg.Get(IP).(utils.IPDesc)
+	assert.True(t, ip.Equal(parsedIP))
+	assert.Equal(t, "[2001:db8::1]:9651", parsedIP.String())
+}
+
 func TestBuildGetPeerList(t *testing.T) {
 	msg, err := UncompressingBuilder.GetPeerList()
 	assert.NoError(t, err)
@@ -101,6 +127,48 @@ func TestBuildGetPeerList(t *testing.T) {
 	assert.Equal(t, GetPeerList, parsedMsg.Op())
 }
 
+func TestBuildNoncePing(t *testing.T) {
+	nonce := uint64(1234)
+	msg, err := UncompressingBuilder.NoncePing(nonce)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, NoncePing, msg.Op())
+
+	parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, NoncePing, parsedMsg.Op())
+	assert.Equal(t, nonce, parsedMsg.Get(Nonce))
+}
+
+func TestBuildNoncePong(t *testing.T) {
+	nonce := uint64(1234)
+	msg, err := UncompressingBuilder.NoncePong(nonce)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, NoncePong, msg.Op())
+
+	parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, NoncePong, parsedMsg.Op())
+	assert.Equal(t, nonce, parsedMsg.Get(Nonce))
+}
+
+func TestBuildCapabilities(t *testing.T) {
+	capabilityFlags := uint64(0b101)
+	msg, err := UncompressingBuilder.Capabilities(capabilityFlags)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, Capabilities, msg.Op())
+
+	parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, Capabilities, parsedMsg.Op())
+	assert.Equal(t, capabilityFlags, parsedMsg.Get(CapabilityFlags))
+}
+
 func TestBuildGetAcceptedFrontier(t *testing.T) {
 	chainID := ids.Empty.Prefix(0)
 	requestID := uint32(5)
@@ -240,6 +308,9 @@ func TestBuildPushQuery(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, msg)
 		assert.Equal(t, PushQuery, msg.Op())
+		// Fields aren't kept unless requested
+		assert.Nil(t, msg.Get(ChainID))
+		assert.Nil(t, msg.Get(RequestID))
 
 		parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
 		assert.NoError(t, err)
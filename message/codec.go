@@ -13,16 +13,36 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+// Default max size, in bytes, of messages whose ops have small caps. See
+// DefaultMaxSizes.
+const (
+	defaultMaxHandshakeSize = units.KiB
+	defaultMaxVersionSize   = 64 * units.KiB
+	defaultMaxChitsSize     = 64 * units.KiB
+)
+
 var (
-	errMissingField = errors.New("message missing field")
-	errBadOp        = errors.New("input field has invalid operation")
+	// ErrTooLarge is returned when parsing a message that exceeds the max
+	// size of its op
+	ErrTooLarge = errors.New("message exceeds the max size of its op")
+
+	errMissingField          = errors.New("message missing field")
+	errBadOp                 = errors.New("input field has invalid operation")
+	errUnknownCompression    = errors.New("unknown compression type")
+	errCompressionNotAllowed = errors.New("compression type not allowed for operation")
+
+	// sizeBuckets are the histogram boundaries, in bytes, used for the size of
+	// serialized messages. They span 64 B to 2 MiB.
+	sizeBuckets = prometheus.ExponentialBuckets(64, 2, 16)
 
 	_ Codec = &codec{}
 )
@@ -33,6 +53,20 @@ type Packer interface {
 		fieldValues map[Field]interface{},
 		compress bool,
 	) (OutboundMessage, error)
+
+	// Recompress returns [msg] with its payload compressed with
+	// [compressionType], regardless of how the payload of [msg] is
+	// compressed. The caller owns a reference to the returned message, and
+	// still owns its reference to [msg].
+	Recompress(
+		msg OutboundMessage,
+		compressionType compression.Type,
+	) (OutboundMessage, error)
+
+	// SetKeepFields sets whether the messages packed from now on keep the
+	// values of their fields, so that they can be read with Get. Otherwise,
+	// Get always returns nil.
+	SetKeepFields(keep bool)
 }
 
 type Parser interface {
@@ -54,25 +88,110 @@ type codec struct {
 
 	clock mockable.Clock
 
-	compressTimeMetrics   map[Op]metric.Averager
-	decompressTimeMetrics map[Op]metric.Averager
-	compressor            compression.Compressor
+	compressTimeMetrics       map[Op]metric.Averager
+	decompressTimeMetrics     map[Op]metric.Averager
+	zstdCompressTimeMetrics   map[Op]metric.Averager
+	zstdDecompressTimeMetrics map[Op]metric.Averager
+	outboundSizeMetrics       map[Op]prometheus.Histogram
+	inboundSizeMetrics        map[Op]prometheus.Histogram
+	oversizedMetrics          map[Op]prometheus.Counter
+	gzipCompressor            compression.Compressor
+	zstdCompressor            compression.Compressor
+
+	// Op --> Max size, in bytes, of inbound messages of this op, including
+	// their op code and compression type. Applies to both the compressed and
+	// the decompressed message.
+	maxSizes map[Op]int
+
+	// If true, outbound messages keep the values of their fields. Only used
+	// while messages are traced, as the fields may reference large payloads.
+	keepFields utils.AtomicBool
+}
+
+// DefaultMaxSizes returns the default max size, in bytes, of inbound messages of
+// each op. Ops that only carry a few small fields are capped well below
+// [maxMessageSize], so that a malformed message of these ops is rejected before
+// it's parsed. The other ops are capped at [maxMessageSize].
+func DefaultMaxSizes(maxMessageSize int64) map[Op]int64 {
+	maxSizes := make(map[Op]int64, len(ExternalOps))
+	for _, op := range ExternalOps {
+		maxSizes[op] = maxMessageSize
+	}
+	for _, op := range []Op{GetVersion, GetPeerList, Ping, Pong, UptimePong, NoncePing, NoncePong, Capabilities} {
+		maxSizes[op] = defaultMaxHandshakeSize
+	}
+	maxSizes[Version] = defaultMaxVersionSize
+	maxSizes[Chits] = defaultMaxChitsSize
+	return maxSizes
 }
 
-func NewCodecWithMemoryPool(namespace string, metrics prometheus.Registerer, maxMessageSize int64) (Codec, error) {
+// NewCodecWithMemoryPool returns a codec that parses messages of up to
+// [maxMessageSize] bytes. The max size of inbound messages of each op is
+// the size given in [maxSizes] or, if the op isn't in [maxSizes], the default
+// size given by DefaultMaxSizes.
+func NewCodecWithMemoryPool(
+	namespace string,
+	metrics prometheus.Registerer,
+	maxMessageSize int64,
+	maxSizes map[Op]int64,
+) (Codec, error) {
+	zstdCompressor, err := compression.NewZstdCompressor(maxMessageSize)
+	if err != nil {
+		return nil, err
+	}
 	c := &codec{
 		byteSlicePool: sync.Pool{
 			New: func() interface{} {
 				return make([]byte, 0, constants.DefaultByteSliceCap)
 			},
 		},
-		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
-		compressor:            compression.NewGzipCompressor(maxMessageSize),
+		compressTimeMetrics:       make(map[Op]metric.Averager, len(ExternalOps)),
+		decompressTimeMetrics:     make(map[Op]metric.Averager, len(ExternalOps)),
+		zstdCompressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
+		zstdDecompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+		outboundSizeMetrics:       make(map[Op]prometheus.Histogram, len(ExternalOps)),
+		inboundSizeMetrics:        make(map[Op]prometheus.Histogram, len(ExternalOps)),
+		oversizedMetrics:          make(map[Op]prometheus.Counter, len(ExternalOps)),
+		maxSizes:                  make(map[Op]int, len(ExternalOps)),
+		gzipCompressor:            compression.NewGzipCompressor(maxMessageSize),
+		zstdCompressor:            zstdCompressor,
+	}
+
+	for op, maxSize := range DefaultMaxSizes(maxMessageSize) {
+		if overrideSize, ok := maxSizes[op]; ok {
+			maxSize = overrideSize
+		}
+		c.maxSizes[op] = int(maxSize)
 	}
 
 	errs := wrappers.Errs{}
 	for _, op := range ExternalOps {
+		outboundSizeMetric := prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_outbound_size", op),
+			Help:      fmt.Sprintf("size (in bytes) of serialized outbound %s messages", op),
+			Buckets:   sizeBuckets,
+		})
+		inboundSizeMetric := prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_inbound_size", op),
+			Help:      fmt.Sprintf("size (in bytes) of serialized inbound %s messages", op),
+			Buckets:   sizeBuckets,
+		})
+		oversizedMetric := prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_oversized", op),
+			Help:      fmt.Sprintf("number of inbound %s messages dropped because they exceeded the max size of %s messages", op, op),
+		})
+		c.outboundSizeMetrics[op] = outboundSizeMetric
+		c.inboundSizeMetrics[op] = inboundSizeMetric
+		c.oversizedMetrics[op] = oversizedMetric
+		errs.Add(
+			metrics.Register(outboundSizeMetric),
+			metrics.Register(inboundSizeMetric),
+			metrics.Register(oversizedMetric),
+		)
+
 		if !op.Compressable() {
 			continue
 		}
@@ -91,6 +210,25 @@ func NewCodecWithMemoryPool(namespace string, metrics prometheus.Registerer, max
 			metrics,
 			&errs,
 		)
+
+		if !op.ZstdCompressable() {
+			continue
+		}
+
+		c.zstdCompressTimeMetrics[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_zstd_compress_time", op),
+			fmt.Sprintf("time (in ns) to compress %s messages with zstd", op),
+			metrics,
+			&errs,
+		)
+		c.zstdDecompressTimeMetrics[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_zstd_decompress_time", op),
+			fmt.Sprintf("time (in ns) to decompress %s messages with zstd", op),
+			metrics,
+			&errs,
+		)
 	}
 	return c, errs.Err
 }
@@ -99,12 +237,16 @@ func (c *codec) SetTime(t time.Time) {
 	c.clock.Set(t)
 }
 
+func (c *codec) SetKeepFields(keep bool) {
+	c.keepFields.SetValue(keep)
+}
+
 // Pack attempts to pack a map of fields into a message.
 // The first byte of the message is the opcode of the message.
 // Uses [buffer] to hold the message's byte repr.
 // [buffer]'s contents may be overwritten by this method.
 // [buffer] may be nil.
-// If [compress], compress the payload.
+// If [compress], compress the payload with gzip.
 func (c *codec) Pack(
 	op Op,
 	fieldValues map[Field]interface{},
@@ -123,9 +265,13 @@ func (c *codec) Pack(
 	// Pack the op code (message type)
 	p.PackByte(byte(op))
 
-	// Optionally, pack whether the payload is compressed
+	// Optionally, pack how the payload is compressed
+	compressionType := compression.TypeNone
+	if compress {
+		compressionType = compression.TypeGzip
+	}
 	if op.Compressable() {
-		p.PackBool(compress)
+		p.PackByte(byte(compressionType))
 	}
 
 	// Pack the uncompressed payload
@@ -145,28 +291,152 @@ func (c *codec) Pack(
 		refs:  1,
 		c:     c,
 	}
+	if c.keepFields.GetValue() {
+		msg.fields = fieldValues
+	}
 	if !compress {
+		c.observeOutboundSize(op, len(msg.bytes))
 		return msg, nil
 	}
 
-	// If [compress], compress the payload (not the op code, not isCompressed).
+	// If [compress], compress the payload (not the op code, not the
+	// compression type).
 	// The slice below is guaranteed to be in-bounds because [p.Err] == nil
 	// implies that len(msg.bytes) >= 2
-	payloadBytes := msg.bytes[wrappers.BoolLen+wrappers.ByteLen:]
-	startTime := time.Now()
-	compressedPayloadBytes, err := c.compressor.Compress(payloadBytes)
+	payloadBytes := msg.bytes[wrappers.ByteLen+wrappers.ByteLen:]
+	compressedPayloadBytes, err := c.compress(op, compressionType, payloadBytes)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't compress payload of %s message: %s", op, err)
+		return nil, err
 	}
-	c.compressTimeMetrics[op].Observe(float64(time.Since(startTime)))
 	msg.bytesSavedCompression = len(payloadBytes) - len(compressedPayloadBytes) // may be negative
-	// Remove the uncompressed payload (keep just the message type and isCompressed)
-	msg.bytes = msg.bytes[:wrappers.BoolLen+wrappers.ByteLen]
+	// Remove the uncompressed payload (keep just the message type and the
+	// compression type)
+	msg.bytes = msg.bytes[:wrappers.ByteLen+wrappers.ByteLen]
 	// Attach the compressed payload
 	msg.bytes = append(msg.bytes, compressedPayloadBytes...)
+	c.observeOutboundSize(op, len(msg.bytes))
 	return msg, nil
 }
 
+// Recompress returns [msg] with its payload compressed with
+// [compressionType]. The op code and the compression type of [msg] are
+// assumed to be valid, as [msg] was packed by this node.
+func (c *codec) Recompress(
+	msg OutboundMessage,
+	compressionType compression.Type,
+) (OutboundMessage, error) {
+	op := msg.Op()
+	if !op.Compressable() {
+		return nil, fmt.Errorf("%w: %s can't be compressed", errCompressionNotAllowed, op)
+	}
+
+	msgBytes := msg.Bytes()
+	currentType := compression.Type(msgBytes[wrappers.ByteLen])
+	if currentType == compressionType {
+		msg.AddRef()
+		return msg, nil
+	}
+
+	payloadBytes, err := c.decompress(op, currentType, msgBytes[wrappers.ByteLen+wrappers.ByteLen:])
+	if err != nil {
+		return nil, err
+	}
+	compressedPayloadBytes, err := c.compress(op, compressionType, payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var fieldValues map[Field]interface{}
+	if c.keepFields.GetValue() {
+		msgFields := messages[op]
+		fieldValues = make(map[Field]interface{}, len(msgFields))
+		for _, field := range msgFields {
+			fieldValues[field] = msg.Get(field)
+		}
+	}
+
+	buffer := c.byteSlicePool.Get().([]byte)
+	buffer = append(buffer[:0], byte(op), byte(compressionType))
+	buffer = append(buffer, compressedPayloadBytes...)
+	c.observeOutboundSize(op, len(buffer))
+	return &outboundMessage{
+		op:                    op,
+		bytes:                 buffer,
+		bytesSavedCompression: len(payloadBytes) - len(compressedPayloadBytes), // may be negative
+		fields:                fieldValues,
+		refs:                  1,
+		c:                     c,
+	}, nil
+}
+
+// observeOutboundSize records that an [op] message was serialized into [size]
+// bytes, including its op code and compression type.
+func (c *codec) observeOutboundSize(op Op, size int) {
+	if sizeMetric, ok := c.outboundSizeMetrics[op]; ok {
+		sizeMetric.Observe(float64(size))
+	}
+}
+
+// compress returns [payloadBytes], the payload of an [op] message, compressed
+// with [compressionType].
+func (c *codec) compress(op Op, compressionType compression.Type, payloadBytes []byte) ([]byte, error) {
+	var (
+		compressor  compression.Compressor
+		timeMetrics map[Op]metric.Averager
+	)
+	switch {
+	case compressionType == compression.TypeNone:
+		return payloadBytes, nil
+	case compressionType == compression.TypeGzip && op.Compressable():
+		compressor = c.gzipCompressor
+		timeMetrics = c.compressTimeMetrics
+	case compressionType == compression.TypeZstd && op.ZstdCompressable():
+		compressor = c.zstdCompressor
+		timeMetrics = c.zstdCompressTimeMetrics
+	default:
+		return nil, fmt.Errorf("%w: %s messages can't be compressed with %s", errCompressionNotAllowed, op, compressionType)
+	}
+
+	startTime := time.Now()
+	compressedPayloadBytes, err := compressor.Compress(payloadBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't compress payload of %s message with %s: %s", op, compressionType, err)
+	}
+	timeMetrics[op].Observe(float64(time.Since(startTime)))
+	return compressedPayloadBytes, nil
+}
+
+// decompress returns [compressedPayloadBytes], the payload of an [op] message
+// compressed with [compressionType], decompressed.
+func (c *codec) decompress(op Op, compressionType compression.Type, compressedPayloadBytes []byte) ([]byte, error) {
+	var (
+		compressor  compression.Compressor
+		timeMetrics map[Op]metric.Averager
+	)
+	switch {
+	case compressionType == compression.TypeNone:
+		return compressedPayloadBytes, nil
+	case compressionType == compression.TypeGzip && op.Compressable():
+		compressor = c.gzipCompressor
+		timeMetrics = c.decompressTimeMetrics
+	case compressionType == compression.TypeZstd && op.ZstdCompressable():
+		compressor = c.zstdCompressor
+		timeMetrics = c.zstdDecompressTimeMetrics
+	case compressionType > compression.TypeZstd:
+		return nil, fmt.Errorf("%w: %d", errUnknownCompression, compressionType)
+	default:
+		return nil, fmt.Errorf("%w: %s messages can't be compressed with %s", errCompressionNotAllowed, op, compressionType)
+	}
+
+	startTime := time.Now()
+	payloadBytes, err := compressor.Decompress(compressedPayloadBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decompress payload of %s message with %s: %s", op, compressionType, err)
+	}
+	timeMetrics[op].Observe(float64(time.Since(startTime)))
+	return payloadBytes, nil
+}
+
 // Parse attempts to convert bytes into a message.
 // The first byte of the message is the opcode of the message.
 func (c *codec) Parse(bytes []byte, nodeID ids.ShortID, onFinishedHandling func()) (InboundMessage, error) {
@@ -179,11 +449,17 @@ func (c *codec) Parse(bytes []byte, nodeID ids.ShortID, onFinishedHandling func(
 	if !ok { // Unknown message type
 		return nil, errBadOp
 	}
+	if sizeMetric, ok := c.inboundSizeMetrics[op]; ok {
+		sizeMetric.Observe(float64(len(bytes)))
+	}
+	if err := c.checkSize(op, len(bytes)); err != nil {
+		return nil, err
+	}
 
 	// See if messages of this type may be compressed
-	compressed := false
+	compressionType := compression.TypeNone
 	if op.Compressable() {
-		compressed = p.UnpackBool()
+		compressionType = compression.Type(p.UnpackByte())
 	}
 	if p.Err != nil {
 		return nil, p.Err
@@ -192,24 +468,26 @@ func (c *codec) Parse(bytes []byte, nodeID ids.ShortID, onFinishedHandling func(
 	bytesSaved := 0
 
 	// If the payload is compressed, decompress it
-	if compressed {
+	if compressionType != compression.TypeNone {
 		// The slice below is guaranteed to be in-bounds because [p.Err] == nil
-		compressedPayloadBytes := p.Bytes[wrappers.ByteLen+wrappers.BoolLen:]
-		startTime := time.Now()
-		payloadBytes, err := c.compressor.Decompress(compressedPayloadBytes)
+		compressedPayloadBytes := p.Bytes[wrappers.ByteLen+wrappers.ByteLen:]
+		payloadBytes, err := c.decompress(op, compressionType, compressedPayloadBytes)
 		if err != nil {
-			return nil, fmt.Errorf("couldn't decompress payload of %s message: %s", op, err)
+			return nil, err
 		}
-		c.decompressTimeMetrics[op].Observe(float64(time.Since(startTime)))
 		// Replace the compressed payload with the decompressed payload.
-		// Remove the compressed payload and isCompressed; keep just the message type
+		// Remove the compressed payload and the compression type; keep just
+		// the message type
 		p.Bytes = p.Bytes[:wrappers.ByteLen]
-		// Rewind offset by 1 because we removed the bool flag
+		// Rewind offset by 1 because we removed the compression type
 		// since the data now is uncompressed
-		p.Offset -= wrappers.BoolLen
+		p.Offset -= wrappers.ByteLen
 		// Attach the decompressed payload.
 		p.Bytes = append(p.Bytes, payloadBytes...)
 		bytesSaved = len(payloadBytes) - len(compressedPayloadBytes)
+		if err := c.checkSize(op, len(p.Bytes)); err != nil {
+			return nil, err
+		}
 	}
 
 	// Parse each field of the payload
@@ -236,3 +514,14 @@ func (c *codec) Parse(bytes []byte, nodeID ids.ShortID, onFinishedHandling func(
 		onFinishedHandling:    onFinishedHandling,
 	}, p.Err
 }
+
+// checkSize returns an error if an inbound [op] message of [size] bytes
+// exceeds the max size of [op] messages.
+func (c *codec) checkSize(op Op, size int) error {
+	maxSize, ok := c.maxSizes[op]
+	if !ok || size <= maxSize {
+		return nil
+	}
+	c.oversizedMetrics[op].Inc()
+	return fmt.Errorf("%w: %s message is %d bytes but the max is %d", ErrTooLarge, op, size, maxSize)
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build go1.18
+// +build go1.18
+
+package message
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// Ensure that parsing malformed zstd compressed payloads never panics, and
+// never produces a payload larger than the maximum message size.
+func FuzzCodecParseZstd(f *testing.F) {
+	maxMessageSize := 256 * units.KiB
+	c, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), int64(maxMessageSize), nil)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	id := ids.GenerateTestID()
+	msg, err := c.Pack(Put, map[Field]interface{}{
+		ChainID:        id[:],
+		RequestID:      uint32(1337),
+		ContainerID:    id[:],
+		ContainerBytes: make([]byte, units.KiB),
+	}, false)
+	if err != nil {
+		f.Fatal(err)
+	}
+	zstdMsg, err := c.Recompress(msg, compression.TypeZstd)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(zstdMsg.Bytes()[2:])
+	f.Add([]byte{})
+	f.Add([]byte{0x28, 0xb5, 0x2f, 0xfd}) // zstd magic number
+
+	f.Fuzz(func(t *testing.T, compressedPayload []byte) {
+		msgBytes := append([]byte{byte(Put), byte(compression.TypeZstd)}, compressedPayload...)
+		parsed, err := c.Parse(msgBytes, dummyNodeID, dummyOnFinishedHandling)
+		if err != nil {
+			return
+		}
+		if containerBytes := parsed.Get(ContainerBytes).([]byte); len(containerBytes) > maxMessageSize {
+			t.Fatalf("parsed a container of %d bytes", len(containerBytes))
+		}
+	})
+}
@@ -11,16 +11,20 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/assert"
 
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 func TestCodecPackInvalidOp(t *testing.T) {
-	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB)
+	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
 	assert.NoError(t, err)
 
 	_, err = codec.Pack(math.MaxUint8, make(map[Field]interface{}), false)
@@ -31,7 +35,7 @@ func TestCodecPackInvalidOp(t *testing.T) {
 }
 
 func TestCodecPackMissingField(t *testing.T) {
-	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB)
+	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
 	assert.NoError(t, err)
 
 	_, err = codec.Pack(Get, make(map[Field]interface{}), false)
@@ -42,7 +46,7 @@ func TestCodecPackMissingField(t *testing.T) {
 }
 
 func TestCodecParseInvalidOp(t *testing.T) {
-	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB)
+	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
 	assert.NoError(t, err)
 
 	_, err = codec.Parse([]byte{math.MaxUint8}, dummyNodeID, dummyOnFinishedHandling)
@@ -50,7 +54,7 @@ func TestCodecParseInvalidOp(t *testing.T) {
 }
 
 func TestCodecParseExtraSpace(t *testing.T) {
-	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB)
+	codec, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
 	assert.NoError(t, err)
 
 	_, err = codec.Parse([]byte{byte(GetVersion), 0x00, 0x00}, dummyNodeID, dummyOnFinishedHandling)
@@ -60,10 +64,53 @@ func TestCodecParseExtraSpace(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCodecParseTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	c, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, map[Op]int64{Put: 64})
+	assert.NoError(err)
+	cdc := c.(*codec)
+
+	// A large GetVersion is rejected before its payload is parsed
+	_, err = c.Parse(make([]byte, 2*units.MiB), dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, ErrTooLarge)
+	assert.EqualValues(1, testutil.ToFloat64(cdc.oversizedMetrics[GetVersion]))
+
+	// Large ops keep the global max message size by default
+	msgBytes := make([]byte, 64*units.KiB)
+	msgBytes[0] = byte(MultiPut)
+	_, err = c.Parse(msgBytes, dummyNodeID, dummyOnFinishedHandling)
+	assert.Error(err)
+	assert.NotErrorIs(err, ErrTooLarge)
+	assert.EqualValues(0, testutil.ToFloat64(cdc.oversizedMetrics[MultiPut]))
+
+	// The max size of an op can be overridden
+	msgBytes[0] = byte(Put)
+	_, err = c.Parse(msgBytes, dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, ErrTooLarge)
+	assert.EqualValues(1, testutil.ToFloat64(cdc.oversizedMetrics[Put]))
+
+	// The decompressed payload must not exceed the max size either
+	msg, err := c.Pack(
+		Put,
+		map[Field]interface{}{
+			ChainID:        ids.Empty[:],
+			RequestID:      uint32(1),
+			ContainerID:    ids.Empty[:],
+			ContainerBytes: make([]byte, units.KiB),
+		},
+		true,
+	)
+	assert.NoError(err)
+	assert.Less(len(msg.Bytes()), 64)
+	_, err = c.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, ErrTooLarge)
+	assert.EqualValues(2, testutil.ToFloat64(cdc.oversizedMetrics[Put]))
+}
+
 // Test packing and then parsing messages
 // when using a gzip compressor
 func TestCodecPackParseGzip(t *testing.T) {
-	c, err := NewCodecWithMemoryPool("", prometheus.DefaultRegisterer, 2*units.MiB)
+	c, err := NewCodecWithMemoryPool("", prometheus.DefaultRegisterer, 2*units.MiB, nil)
 	assert.NoError(t, err)
 	id := ids.GenerateTestID()
 	cert := &x509.Certificate{}
@@ -117,6 +164,18 @@ func TestCodecPackParseGzip(t *testing.T) {
 				Uptime: uint8(80),
 			},
 		},
+		{
+			op: NoncePing,
+			fields: map[Field]interface{}{
+				Nonce: uint64(1234),
+			},
+		},
+		{
+			op: NoncePong,
+			fields: map[Field]interface{}{
+				Nonce: uint64(1234),
+			},
+		},
 		{
 			op: GetAcceptedFrontier,
 			fields: map[Field]interface{}{
@@ -216,3 +275,180 @@ func TestCodecPackParseGzip(t *testing.T) {
 		assert.EqualValues(t, len(m.fields), len(unpacked.fields))
 	}
 }
+
+func TestCodecRecompress(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
+	assert.NoError(err)
+	id := ids.GenerateTestID()
+	container := make([]byte, 4096)
+	fields := map[Field]interface{}{
+		ChainID:        id[:],
+		RequestID:      uint32(1337),
+		ContainerID:    id[:],
+		ContainerBytes: container,
+	}
+
+	for _, compress := range []bool{false, true} {
+		msg, err := c.Pack(Put, fields, compress)
+		assert.NoError(err)
+
+		zstdMsg, err := c.Recompress(msg, compression.TypeZstd)
+		assert.NoError(err)
+		assert.Equal(Put, zstdMsg.Op())
+		assert.Equal(byte(compression.TypeZstd), zstdMsg.Bytes()[1])
+		assert.Greater(zstdMsg.BytesSavedCompression(), 0)
+		assert.Nil(zstdMsg.Get(ChainID))
+
+		parsed, err := c.Parse(zstdMsg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+		assert.NoError(err)
+		assert.Equal(container, parsed.Get(ContainerBytes))
+		assert.Equal(zstdMsg.BytesSavedCompression(), parsed.BytesSavedCompression())
+
+		// Recompressing with the same compression returns the same message
+		sameMsg, err := c.Recompress(zstdMsg, compression.TypeZstd)
+		assert.NoError(err)
+		assert.Equal(zstdMsg, sameMsg)
+
+		// The payload can be decompressed for peers that can't decompress it
+		uncompressedMsg, err := c.Recompress(zstdMsg, compression.TypeNone)
+		assert.NoError(err)
+		assert.Equal(byte(compression.TypeNone), uncompressedMsg.Bytes()[1])
+		parsed, err = c.Parse(uncompressedMsg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+		assert.NoError(err)
+		assert.Equal(container, parsed.Get(ContainerBytes))
+	}
+
+	// Only whitelisted ops can be compressed with zstd
+	msg, err := c.Pack(AppGossip, map[Field]interface{}{
+		ChainID:  id[:],
+		AppBytes: container,
+	}, true)
+	assert.NoError(err)
+	_, err = c.Recompress(msg, compression.TypeZstd)
+	assert.ErrorIs(err, errCompressionNotAllowed)
+
+	msg, err = c.Pack(Ping, nil, false)
+	assert.NoError(err)
+	_, err = c.Recompress(msg, compression.TypeGzip)
+	assert.ErrorIs(err, errCompressionNotAllowed)
+}
+
+func TestCodecKeepFields(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
+	assert.NoError(err)
+	id := ids.GenerateTestID()
+	fields := map[Field]interface{}{
+		ChainID:        id[:],
+		RequestID:      uint32(1337),
+		ContainerID:    id[:],
+		ContainerBytes: make([]byte, 4096),
+	}
+
+	// By default, the fields aren't kept
+	msg, err := c.Pack(Put, fields, false)
+	assert.NoError(err)
+	assert.Nil(msg.Get(ChainID))
+
+	c.SetKeepFields(true)
+	for _, compress := range []bool{false, true} {
+		msg, err := c.Pack(Put, fields, compress)
+		assert.NoError(err)
+		assert.Equal(id[:], msg.Get(ChainID))
+		assert.Equal(uint32(1337), msg.Get(RequestID))
+
+		zstdMsg, err := c.Recompress(msg, compression.TypeZstd)
+		assert.NoError(err)
+		assert.Equal(id[:], zstdMsg.Get(ChainID))
+		assert.Equal(uint32(1337), zstdMsg.Get(RequestID))
+	}
+
+	c.SetKeepFields(false)
+	msg, err = c.Pack(Put, fields, false)
+	assert.NoError(err)
+	assert.Nil(msg.Get(ChainID))
+}
+
+func TestCodecParseZstd(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
+	assert.NoError(err)
+	zstdCompressor, err := compression.NewZstdCompressor(2 * units.MiB)
+	assert.NoError(err)
+	id := ids.GenerateTestID()
+
+	msg, err := c.Pack(AppGossip, map[Field]interface{}{
+		ChainID:  id[:],
+		AppBytes: []byte{1, 2, 3},
+	}, false)
+	assert.NoError(err)
+	compressedPayload, err := zstdCompressor.Compress(msg.Bytes()[2:])
+	assert.NoError(err)
+
+	// Messages of ops that aren't whitelisted can't be compressed with zstd
+	msgBytes := append([]byte{byte(AppGossip), byte(compression.TypeZstd)}, compressedPayload...)
+	_, err = c.Parse(msgBytes, dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, errCompressionNotAllowed)
+
+	msgBytes = append([]byte{byte(AppGossip), byte(compression.TypeZstd + 1)}, compressedPayload...)
+	_, err = c.Parse(msgBytes, dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, errUnknownCompression)
+
+	// The decompressed payload can't be larger than the maximum message size
+	bomb, err := compression.NewZstdCompressor(4 * units.MiB)
+	assert.NoError(err)
+	compressedPayload, err = bomb.Compress(make([]byte, 3*units.MiB))
+	assert.NoError(err)
+	msgBytes = append([]byte{byte(Put), byte(compression.TypeZstd)}, compressedPayload...)
+	_, err = c.Parse(msgBytes, dummyNodeID, dummyOnFinishedHandling)
+	assert.Error(err)
+}
+
+// Ensure that the sizes of packed and parsed messages are observed as they are
+// on the wire, after compression.
+func TestCodecSizeMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, nil)
+	assert.NoError(err)
+	codec := c.(*codec)
+	id := ids.GenerateTestID()
+
+	msg, err := c.Pack(Put, map[Field]interface{}{
+		ChainID:        id[:],
+		RequestID:      uint32(1337),
+		ContainerID:    id[:],
+		ContainerBytes: make([]byte, 4096),
+	}, true)
+	assert.NoError(err)
+	assert.Less(len(msg.Bytes()), 4096)
+	assertHistogram(t, codec.outboundSizeMetrics[Put], 1, len(msg.Bytes()))
+
+	// Parsing overwrites the bytes it's given
+	msgBytes := append([]byte(nil), msg.Bytes()...)
+	_, err = c.Parse(msgBytes, dummyNodeID, dummyOnFinishedHandling)
+	assert.NoError(err)
+	assertHistogram(t, codec.inboundSizeMetrics[Put], 1, len(msg.Bytes()))
+
+	zstdMsg, err := c.Recompress(msg, compression.TypeZstd)
+	assert.NoError(err)
+	putSizes := len(msg.Bytes()) + len(zstdMsg.Bytes())
+	assertHistogram(t, codec.outboundSizeMetrics[Put], 2, putSizes)
+
+	// Other ops are observed separately
+	pingMsg, err := c.Pack(Ping, nil, false)
+	assert.NoError(err)
+	assertHistogram(t, codec.outboundSizeMetrics[Ping], 1, len(pingMsg.Bytes()))
+	assertHistogram(t, codec.outboundSizeMetrics[Put], 2, putSizes)
+}
+
+func assertHistogram(t *testing.T, h prometheus.Histogram, count int, sum int) {
+	metric := &dto.Metric{}
+	assert.NoError(t, h.Write(metric))
+	assert.EqualValues(t, count, metric.GetHistogram().GetSampleCount())
+	assert.EqualValues(t, sum, metric.GetHistogram().GetSampleSum())
+}
@@ -24,9 +24,17 @@ type creator struct {
 	InternalMsgBuilder
 }
 
-func NewCreator(metrics prometheus.Registerer, compressionEnabled bool, parentNamespace string) (Creator, error) {
+// NewCreator returns a Creator whose codec caps the size of inbound messages of
+// each op at the size given in [maxSizes], or at its default size if the op
+// isn't in [maxSizes]. See DefaultMaxSizes.
+func NewCreator(
+	metrics prometheus.Registerer,
+	compressionEnabled bool,
+	parentNamespace string,
+	maxSizes map[Op]int64,
+) (Creator, error) {
 	namespace := fmt.Sprintf("%s_codec", parentNamespace)
-	codec, err := NewCodecWithMemoryPool(namespace, metrics, int64(constants.DefaultMaxMessageSize))
+	codec, err := NewCodecWithMemoryPool(namespace, metrics, int64(constants.DefaultMaxMessageSize), maxSizes)
 	if err != nil {
 		return nil, err
 	}
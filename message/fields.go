@@ -32,6 +32,8 @@ const (
 	AppBytes                         // Used at application level
 	VMMessage                        // Used internally
 	Uptime                           // Used for Pong
+	Nonce                            // Used for NoncePing / NoncePong
+	CapabilityFlags                  // Used in handshake
 )
 
 // Packer returns the packer function that can be used to pack this field.
@@ -75,6 +77,10 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackHashes
 	case Uptime:
 		return wrappers.TryPackByte
+	case Nonce:
+		return wrappers.TryPackLong
+	case CapabilityFlags:
+		return wrappers.TryPackLong
 	default:
 		return nil
 	}
@@ -121,6 +127,10 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackHashes
 	case Uptime:
 		return wrappers.TryUnpackByte
+	case Nonce:
+		return wrappers.TryUnpackLong
+	case CapabilityFlags:
+		return wrappers.TryUnpackLong
 	default:
 		return nil
 	}
@@ -168,6 +178,10 @@ func (f Field) String() string {
 		return "VMMessage"
 	case Uptime:
 		return "Uptime"
+	case Nonce:
+		return "Nonce"
+	case CapabilityFlags:
+		return "CapabilityFlags"
 	default:
 		return "Unknown Field"
 	}
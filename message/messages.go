@@ -98,6 +98,7 @@ type OutboundMessage interface {
 	BytesSavedCompression() int
 	Bytes() []byte
 	Op() Op
+	Get(Field) interface{}
 
 	AddRef()
 	DecRef()
@@ -107,6 +108,7 @@ type outboundMessage struct {
 	bytes                 []byte
 	bytesSavedCompression int
 	op                    Op
+	fields                map[Field]interface{}
 
 	refLock sync.Mutex
 	refs    int
@@ -119,6 +121,10 @@ func (outMsg *outboundMessage) Op() Op { return outMsg.op }
 // Bytes returns this message in bytes
 func (outMsg *outboundMessage) Bytes() []byte { return outMsg.bytes }
 
+// Get returns the value of the specified field in this message, or nil if the
+// fields weren't kept when this message was packed
+func (outMsg *outboundMessage) Get(field Field) interface{} { return outMsg.fields[field] }
+
 // BytesSavedCompression returns the number of bytes this message saved due to
 // compression. That is, the number of bytes we did not send over the
 // network due to the message being compressed. 0 for messages that were not
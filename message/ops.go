@@ -40,6 +40,11 @@ const (
 	AppRequest
 	AppResponse
 	AppGossip
+	// Latency measurement:
+	NoncePing
+	NoncePong
+	// Handshake:
+	Capabilities
 
 	// Internal messages (External messages should be added above these):
 	GetAcceptedFrontierFailed
@@ -64,6 +69,9 @@ var (
 		Ping,
 		Pong,
 		UptimePong,
+		NoncePing,
+		NoncePong,
+		Capabilities,
 	}
 
 	// List of all consensus request message types
@@ -149,13 +157,16 @@ var (
 	// Defines the messages that can be sent/received with this network
 	messages = map[Op][]Field{
 		// Handshake:
-		GetVersion:  {},
-		Version:     {NetworkID, NodeID, MyTime, IP, VersionStr, VersionTime, SigBytes, TrackedSubnets},
-		GetPeerList: {},
-		PeerList:    {SignedPeers},
-		Ping:        {},
-		Pong:        {},
-		UptimePong:  {Uptime},
+		GetVersion:   {},
+		Version:      {NetworkID, NodeID, MyTime, IP, VersionStr, VersionTime, SigBytes, TrackedSubnets},
+		GetPeerList:  {},
+		PeerList:     {SignedPeers},
+		Ping:         {},
+		Pong:         {},
+		UptimePong:   {Uptime},
+		NoncePing:    {Nonce},
+		NoncePong:    {Nonce},
+		Capabilities: {CapabilityFlags},
 		// Bootstrapping:
 		GetAcceptedFrontier: {ChainID, RequestID, Deadline},
 		AcceptedFrontier:    {ChainID, RequestID, ContainerIDs},
@@ -185,6 +196,39 @@ func (op Op) Compressable() bool {
 	}
 }
 
+// IsHandshake returns true if messages of this op are handled by the network
+// itself, rather than being routed to a chain.
+func (op Op) IsHandshake() bool {
+	switch op {
+	case GetVersion, Version, GetPeerList, PeerList, Ping, Pong, UptimePong, NoncePing, NoncePong, Capabilities:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsApp returns true if messages of this op are sent by, and routed to, a VM
+// rather than the consensus engine.
+func (op Op) IsApp() bool {
+	switch op {
+	case AppRequest, AppResponse, AppGossip:
+		return true
+	default:
+		return false
+	}
+}
+
+// ZstdCompressable returns true if messages of this op may be compressed with
+// zstd. Only large messages are worth compressing with zstd.
+func (op Op) ZstdCompressable() bool {
+	switch op {
+	case PeerList, Put, MultiPut, PushQuery:
+		return true
+	default:
+		return false
+	}
+}
+
 func (op Op) String() string {
 	switch op {
 	case GetVersion:
@@ -201,6 +245,12 @@ func (op Op) String() string {
 		return "pong"
 	case UptimePong:
 		return "uptime_pong"
+	case NoncePing:
+		return "nonce_ping"
+	case NoncePong:
+		return "nonce_pong"
+	case Capabilities:
+		return "capabilities"
 	case GetAcceptedFrontier:
 		return "get_accepted_frontier"
 	case AcceptedFrontier:
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
 )
 
 var _ OutboundMsgBuilder = &outMsgBuilder{}
@@ -39,6 +40,12 @@ type OutboundMsgBuilder interface {
 
 	UptimePong(uptimePercentage uint8) (OutboundMessage, error)
 
+	NoncePing(nonce uint64) (OutboundMessage, error)
+
+	NoncePong(nonce uint64) (OutboundMessage, error)
+
+	Capabilities(capabilityFlags uint64) (OutboundMessage, error)
+
 	GetAcceptedFrontier(
 		chainID ids.ID,
 		requestID uint32,
@@ -129,6 +136,18 @@ type OutboundMsgBuilder interface {
 		chainID ids.ID,
 		msg []byte,
 	) (OutboundMessage, error)
+
+	// Recompress returns [msg] with its payload compressed with
+	// [compressionType]. The caller owns a reference to the returned message,
+	// and still owns its reference to [msg].
+	Recompress(
+		msg OutboundMessage,
+		compressionType compression.Type,
+	) (OutboundMessage, error)
+
+	// SetKeepFields sets whether the messages built from now on keep the
+	// values of their fields, so that they can be read with Get.
+	SetKeepFields(keep bool)
 }
 
 type outMsgBuilder struct {
@@ -226,6 +245,36 @@ func (b *outMsgBuilder) UptimePong(uptimePercentage uint8) (OutboundMessage, err
 	)
 }
 
+func (b *outMsgBuilder) NoncePing(nonce uint64) (OutboundMessage, error) {
+	return b.c.Pack(
+		NoncePing,
+		map[Field]interface{}{
+			Nonce: nonce,
+		},
+		NoncePing.Compressable(), // NoncePing messages can't be compressed
+	)
+}
+
+func (b *outMsgBuilder) NoncePong(nonce uint64) (OutboundMessage, error) {
+	return b.c.Pack(
+		NoncePong,
+		map[Field]interface{}{
+			Nonce: nonce,
+		},
+		NoncePong.Compressable(), // NoncePong messages can't be compressed
+	)
+}
+
+func (b *outMsgBuilder) Capabilities(capabilityFlags uint64) (OutboundMessage, error) {
+	return b.c.Pack(
+		Capabilities,
+		map[Field]interface{}{
+			CapabilityFlags: capabilityFlags,
+		},
+		Capabilities.Compressable(), // Capabilities messages can't be compressed
+	)
+}
+
 func (b *outMsgBuilder) GetAcceptedFrontier(
 	chainID ids.ID,
 	requestID uint32,
@@ -479,3 +528,14 @@ func (b *outMsgBuilder) AppGossip(chainID ids.ID, msg []byte) (OutboundMessage,
 		b.compress && AppGossip.Compressable(), // App messages may be compressed
 	)
 }
+
+func (b *outMsgBuilder) Recompress(
+	msg OutboundMessage,
+	compressionType compression.Type,
+) (OutboundMessage, error) {
+	return b.c.Recompress(msg, compressionType)
+}
+
+func (b *outMsgBuilder) SetKeepFields(keep bool) {
+	b.c.SetKeepFields(keep)
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	portMapped      prometheus.Gauge
+	mappingRenewals prometheus.Counter
+	mappingFailures prometheus.Counter
+	routerFallbacks prometheus.Counter
+}
+
+func (m *metrics) initialize(registerer prometheus.Registerer) error {
+	m.portMapped = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "port_mapped",
+		Help: "1 if the router accepted the port mapping of the advertised staking port, 0 otherwise. Doesn't verify that the port is reachable from the internet",
+	})
+	m.mappingRenewals = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "mapping_renewals",
+		Help: "Number of port mappings that were successfully renewed",
+	})
+	m.mappingFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "mapping_failures",
+		Help: "Number of port mappings that couldn't be created or renewed after retrying",
+	})
+	m.routerFallbacks = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "router_fallbacks",
+		Help: "Number of times the port mapping protocol was switched between UPnP and NAT-PMP",
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.portMapped),
+		registerer.Register(m.mappingRenewals),
+		registerer.Register(m.mappingFailures),
+		registerer.Register(m.routerFallbacks),
+	)
+	return errs.Err
+}
@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -15,6 +17,11 @@ import (
 const (
 	mapTimeout        = 30 * time.Minute
 	maxRefreshRetries = 3
+	retryDelay        = time.Second
+
+	// Some routers drop port mappings before their lease expires, so mappings
+	// are renewed well before [mapTimeout].
+	maxRenewTime = mapTimeout / 3
 )
 
 // Router describes the functionality that a network device must support to be
@@ -42,70 +49,160 @@ func GetRouter() Router {
 	return NewNoRouter()
 }
 
+// Returns a router on the current network that uses a different protocol
+// than [r], or nil if there isn't one.
+func getFallbackRouter(r Router) Router {
+	switch r.(type) {
+	case *upnpRouter:
+		if r := getPMPRouter(); r != nil {
+			return r
+		}
+	case *pmpRouter:
+		if r := getUPnPRouter(); r != nil {
+			return r
+		}
+	}
+	return nil
+}
+
 // Mapper attempts to open a set of ports on a router
 type Mapper struct {
-	log    logging.Logger
-	r      Router
-	closer chan struct{}
-	wg     sync.WaitGroup
+	log     logging.Logger
+	metrics metrics
+	closer  chan struct{}
+	wg      sync.WaitGroup
+
+	// Time to wait before retrying a failed port mapping
+	retryDelay time.Duration
+
+	// routerLock protects [r], which is replaced if the router stops
+	// responding to the protocol it was discovered with.
+	routerLock sync.Mutex
+	r          Router
 }
 
-// NewPortMapper returns an initialized mapper
-func NewPortMapper(log logging.Logger, r Router) Mapper {
-	return Mapper{
-		log:    log,
-		r:      r,
-		closer: make(chan struct{}),
+// NewPortMapper returns an initialized mapper that registers its metrics with
+// [registerer]
+func NewPortMapper(log logging.Logger, r Router, registerer prometheus.Registerer) (*Mapper, error) {
+	m := &Mapper{
+		log:        log,
+		r:          r,
+		closer:     make(chan struct{}),
+		retryDelay: retryDelay,
 	}
+	return m, m.metrics.initialize(registerer)
+}
+
+func (m *Mapper) getRouter() Router {
+	m.routerLock.Lock()
+	defer m.routerLock.Unlock()
+
+	return m.r
+}
+
+// fallback replaces the router with one that uses the other protocol, if
+// [failedRouter] is still being used and such a router exists. Returns the
+// router that should be used from now on.
+func (m *Mapper) fallback(failedRouter Router) Router {
+	m.routerLock.Lock()
+	defer m.routerLock.Unlock()
+
+	if m.r != failedRouter {
+		// Another port mapping already replaced the router
+		return m.r
+	}
+	r := getFallbackRouter(failedRouter)
+	if r == nil {
+		return m.r
+	}
+
+	m.log.Info("switching NAT Traversal from %T to %T", failedRouter, r)
+	m.metrics.routerFallbacks.Inc()
+	m.r = r
+	return r
 }
 
 // Map external port [extPort] (exposed to the internet) to internal port [intPort] (where our process is listening)
 // and set [ip]. Does this every [updateTime]. [ip] may be nil.
 func (m *Mapper) Map(protocol string, intPort, extPort uint16, desc string, ip *utils.DynamicIPDesc, updateTime time.Duration) {
-	if !m.r.SupportsNAT() {
+	if !m.getRouter().SupportsNAT() {
+		return
+	}
+	// UPnP and NAT-PMP only map IPv4 ports. IPv6 addresses are expected to
+	// be reachable without port mapping.
+	if ip != nil && isIPv6(ip.IP().IP) {
+		m.log.Info("skipping NAT Traversal because %s is an IPv6 address", ip.IP().IP)
 		return
 	}
 
 	// we attempt a port map, and log an Error if it fails.
-	err := m.retryMapPort(protocol, intPort, extPort, desc, mapTimeout)
+	err := m.mapPort(protocol, intPort, extPort, desc, ip)
 	if err != nil {
 		m.log.Error("NAT Traversal failed from external port %d to internal port %d with %s", extPort, intPort, err)
 	} else {
 		m.log.Info("NAT Traversal successful from external port %d to internal port %d", extPort, intPort)
 	}
 
+	m.wg.Add(1)
 	go m.keepPortMapping(protocol, intPort, extPort, desc, ip, updateTime)
 }
 
-// Retry port map up to maxRefreshRetries with a 1 second delay
-func (m *Mapper) retryMapPort(protocol string, intPort, extPort uint16, desc string, timeout time.Duration) error {
+// Map the port with the current router. If that fails, fall back to a router
+// that uses the other protocol. [ip] is the advertised IP that the mapping
+// makes reachable, or nil.
+func (m *Mapper) mapPort(protocol string, intPort, extPort uint16, desc string, ip *utils.DynamicIPDesc) error {
+	r := m.getRouter()
+	err := m.retryMapPort(r, protocol, intPort, extPort, desc, mapTimeout)
+	if err != nil {
+		if fallbackRouter := m.fallback(r); fallbackRouter != r {
+			err = m.retryMapPort(fallbackRouter, protocol, intPort, extPort, desc, mapTimeout)
+		}
+	}
+
+	if err != nil {
+		m.metrics.mappingFailures.Inc()
+	}
+	if ip != nil {
+		if err != nil {
+			m.metrics.portMapped.Set(0)
+		} else {
+			m.metrics.portMapped.Set(1)
+		}
+	}
+	return err
+}
+
+// Retry port map on [r] up to maxRefreshRetries with a [m.retryDelay] delay
+func (m *Mapper) retryMapPort(r Router, protocol string, intPort, extPort uint16, desc string, timeout time.Duration) error {
 	var err error
 	for retryCnt := 0; retryCnt < maxRefreshRetries; retryCnt++ {
-		err = m.r.MapPort(protocol, intPort, extPort, desc, timeout)
+		err = r.MapPort(protocol, intPort, extPort, desc, timeout)
 		if err == nil {
 			return nil
 		}
 
-		// log a message, sleep a second and retry.
+		// log a message, sleep and retry.
 		m.log.Error("Renewing port mapping try #%d from external port %d to internal port %d failed with %s",
 			retryCnt+1, extPort, intPort, err)
-		time.Sleep(1 * time.Second)
+		time.Sleep(m.retryDelay)
 	}
 	return err
 }
 
 // keepPortMapping runs in the background to keep a port mapped. It renews the mapping from [extPort]
-// to [intPort]] every [updateTime]. Updates [ip] every [updateTime].
+// to [intPort]] every [updateTime], or every [maxRenewTime] if that's shorter. Updates [ip] at the
+// same frequency. [m.wg] must be incremented before this is called.
 func (m *Mapper) keepPortMapping(protocol string, intPort, extPort uint16, desc string, ip *utils.DynamicIPDesc, updateTime time.Duration) {
+	if updateTime > maxRenewTime {
+		updateTime = maxRenewTime
+	}
 	updateTimer := time.NewTimer(updateTime)
 
-	m.wg.Add(1)
-
 	defer func(extPort uint16) {
 		updateTimer.Stop()
 
 		m.log.Debug("Unmap protocol %s external port %d", protocol, extPort)
-		if err := m.r.UnmapPort(protocol, intPort, extPort); err != nil {
+		if err := m.getRouter().UnmapPort(protocol, intPort, extPort); err != nil {
 			m.log.Debug("Error unmapping port %d to %d: %s", intPort, extPort, err)
 		}
 
@@ -115,10 +212,12 @@ func (m *Mapper) keepPortMapping(protocol string, intPort, extPort uint16, desc
 	for {
 		select {
 		case <-updateTimer.C:
-			err := m.retryMapPort(protocol, intPort, extPort, desc, mapTimeout)
+			err := m.mapPort(protocol, intPort, extPort, desc, ip)
 			if err != nil {
 				m.log.Warn("Renew NAT Traversal failed from external port %d to internal port %d with %s",
 					extPort, intPort, err)
+			} else {
+				m.metrics.mappingRenewals.Inc()
 			}
 			m.updateIP(ip)
 			updateTimer.Reset(updateTime)
@@ -132,7 +231,7 @@ func (m *Mapper) updateIP(ip *utils.DynamicIPDesc) {
 	if ip == nil {
 		return
 	}
-	newIP, err := m.r.ExternalIP()
+	newIP, err := m.getRouter().ExternalIP()
 	if err != nil {
 		m.log.Error("failed to get external IP: %s", err)
 		return
@@ -144,6 +243,11 @@ func (m *Mapper) updateIP(ip *utils.DynamicIPDesc) {
 	}
 }
 
+// Returns true if [ip] is an IPv6 address that isn't an IPv4-mapped address
+func isIPv6(ip net.IP) bool {
+	return len(ip) == net.IPv6len && ip.To4() == nil
+}
+
 // UnmapAllPorts stops mapping all ports from this mapper and attempts to unmap
 // them.
 func (m *Mapper) UnmapAllPorts() {
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	errTestMapPort = errors.New("non-nil map port error")
+
+	_ Router = &testRouter{}
+)
+
+type testRouter struct {
+	lock sync.Mutex
+
+	// Errors returned by successive calls to MapPort. Once exhausted, MapPort
+	// returns nil.
+	mapPortErrs []error
+	mapPortCalls,
+	unmapPortCalls int
+	mapPortDuration time.Duration
+	externalIP      net.IP
+}
+
+func (*testRouter) SupportsNAT() bool { return true }
+
+func (r *testRouter) MapPort(_ string, _, _ uint16, _ string, duration time.Duration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.mapPortCalls++
+	r.mapPortDuration = duration
+	if len(r.mapPortErrs) == 0 {
+		return nil
+	}
+	err := r.mapPortErrs[0]
+	r.mapPortErrs = r.mapPortErrs[1:]
+	return err
+}
+
+func (r *testRouter) UnmapPort(string, uint16, uint16) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.unmapPortCalls++
+	return nil
+}
+
+func (r *testRouter) ExternalIP() (net.IP, error) { return r.externalIP, nil }
+
+func (r *testRouter) calls() (int, int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.mapPortCalls, r.unmapPortCalls
+}
+
+func newTestMapper(t *testing.T, r Router) *Mapper {
+	m, err := NewPortMapper(logging.NoLog{}, r, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.retryDelay = 0
+	return m
+}
+
+func TestMapPort(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &testRouter{}
+	m := newTestMapper(t, r)
+	ip := utils.NewDynamicIPDesc(net.IPv4(1, 2, 3, 4), 9651)
+
+	assert.NoError(m.mapPort("TCP", 9651, 9651, "test", &ip))
+
+	mapPortCalls, _ := r.calls()
+	assert.Equal(1, mapPortCalls)
+	assert.Equal(mapTimeout, r.mapPortDuration)
+	assert.Equal(1.0, testutil.ToFloat64(m.metrics.portMapped))
+	assert.Equal(0.0, testutil.ToFloat64(m.metrics.mappingFailures))
+}
+
+func TestMapPortRetries(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &testRouter{
+		mapPortErrs: []error{errTestMapPort, errTestMapPort},
+	}
+	m := newTestMapper(t, r)
+	ip := utils.NewDynamicIPDesc(net.IPv4(1, 2, 3, 4), 9651)
+
+	assert.NoError(m.mapPort("TCP", 9651, 9651, "test", &ip))
+
+	mapPortCalls, _ := r.calls()
+	assert.Equal(3, mapPortCalls)
+	assert.Equal(1.0, testutil.ToFloat64(m.metrics.portMapped))
+	assert.Equal(0.0, testutil.ToFloat64(m.metrics.mappingFailures))
+}
+
+func TestMapPortFails(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &testRouter{}
+	for i := 0; i < maxRefreshRetries; i++ {
+		r.mapPortErrs = append(r.mapPortErrs, errTestMapPort)
+	}
+	m := newTestMapper(t, r)
+	m.metrics.portMapped.Set(1)
+	ip := utils.NewDynamicIPDesc(net.IPv4(1, 2, 3, 4), 9651)
+
+	err := m.mapPort("TCP", 9651, 9651, "test", &ip)
+	assert.ErrorIs(err, errTestMapPort)
+
+	// The test router has no fallback, so only it is retried
+	mapPortCalls, _ := r.calls()
+	assert.Equal(maxRefreshRetries, mapPortCalls)
+	assert.Equal(0.0, testutil.ToFloat64(m.metrics.portMapped))
+	assert.Equal(1.0, testutil.ToFloat64(m.metrics.mappingFailures))
+	assert.Equal(0.0, testutil.ToFloat64(m.metrics.routerFallbacks))
+}
+
+// Mapping a port that isn't advertised doesn't change whether the advertised
+// port is mapped
+func TestMapPortWithoutIP(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &testRouter{}
+	for i := 0; i < maxRefreshRetries; i++ {
+		r.mapPortErrs = append(r.mapPortErrs, errTestMapPort)
+	}
+	m := newTestMapper(t, r)
+	m.metrics.portMapped.Set(1)
+
+	assert.Error(m.mapPort("TCP", 9650, 9650, "test", nil))
+	assert.Equal(1.0, testutil.ToFloat64(m.metrics.portMapped))
+	assert.Equal(1.0, testutil.ToFloat64(m.metrics.mappingFailures))
+}
+
+func TestMapSkipsIPv6(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &testRouter{}
+	m := newTestMapper(t, r)
+	ip := utils.NewDynamicIPDesc(net.ParseIP("2001:db8::1"), 9651)
+
+	m.Map("TCP", 9651, 9651, "test", &ip, time.Hour)
+	m.UnmapAllPorts()
+
+	mapPortCalls, unmapPortCalls := r.calls()
+	assert.Zero(mapPortCalls)
+	assert.Zero(unmapPortCalls)
+}
+
+func TestKeepPortMapping(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &testRouter{
+		externalIP: net.IPv4(5, 6, 7, 8),
+	}
+	m := newTestMapper(t, r)
+	ip := utils.NewDynamicIPDesc(net.IPv4(1, 2, 3, 4), 9651)
+
+	m.Map("TCP", 9651, 9651, "test", &ip, time.Millisecond)
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(time.Millisecond) {
+		if testutil.ToFloat64(m.metrics.mappingRenewals) > 0 && ip.IP().IP.Equal(r.externalIP) {
+			break
+		}
+	}
+	m.UnmapAllPorts()
+
+	assert.Positive(testutil.ToFloat64(m.metrics.mappingRenewals))
+	assert.True(ip.IP().IP.Equal(r.externalIP))
+
+	// The mapping is removed once the mapper is stopped
+	_, unmapPortCalls := r.calls()
+	assert.Equal(1, unmapPortCalls)
+}
@@ -15,7 +15,10 @@ var (
 	_                       Router = &noRouter{}
 )
 
-const googleDNSServer = "8.8.8.8:80"
+const (
+	googleDNSServer   = "8.8.8.8:80"
+	googleDNSServerV6 = "[2001:4860:4860::8888]:80"
+)
 
 type noRouter struct {
 	ip    net.IP
@@ -38,8 +41,13 @@ func (r noRouter) ExternalIP() (net.IP, error) {
 	return r.ip, r.ipErr
 }
 
+// Returns the local IP used to reach the internet. Falls back to IPv6 if this
+// host can't reach the internet over IPv4.
 func getOutboundIP() (net.IP, error) {
 	conn, err := net.Dial("udp", googleDNSServer)
+	if err != nil {
+		conn, err = net.Dial("udp", googleDNSServerV6)
+	}
 	if err != nil {
 		return nil, err
 	}
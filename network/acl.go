@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var errDeniedByACL = errors.New("denied by the network ACL")
+
+// ACLConfig describes which IPs this node may connect to, and accept
+// connections from. An IP is allowed if it's in [Allowlist] (or [Allowlist] is
+// empty), and it isn't in [Denylist].
+type ACLConfig struct {
+	// CIDR ranges of the IPs that are allowed. A plain IP is treated as a
+	// range containing only that IP. If empty, all IPs not in [Denylist] are
+	// allowed.
+	Allowlist []string `json:"allowlist"`
+	// CIDR ranges of the IPs that are denied. A plain IP is treated as a range
+	// containing only that IP.
+	Denylist []string `json:"denylist"`
+	// If true, the IPs of bootstrap beacons and the known IPs of validators
+	// are allowed even if they're in [Denylist].
+	ExemptBeaconsAndValidators bool `json:"exemptBeaconsAndValidators"`
+}
+
+// acl is the parsed form of an ACLConfig
+type acl struct {
+	allowlist                  []*net.IPNet
+	denylist                   []*net.IPNet
+	exemptBeaconsAndValidators bool
+}
+
+// Returns the parsed form of [config], or an error if one of its CIDR ranges
+// is invalid.
+func newACL(config ACLConfig) (*acl, error) {
+	allowlist, err := parseCIDRs(config.Allowlist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowlist: %w", err)
+	}
+	denylist, err := parseCIDRs(config.Denylist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid denylist: %w", err)
+	}
+	return &acl{
+		allowlist:                  allowlist,
+		denylist:                   denylist,
+		exemptBeaconsAndValidators: config.ExemptBeaconsAndValidators,
+	}, nil
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, fmt.Errorf("couldn't parse %q as an IP or CIDR range", cidr)
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			ipNets = append(ipNets, &net.IPNet{
+				IP:   ip,
+				Mask: net.CIDRMask(8*len(ip), 8*len(ip)),
+			})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// Returns true if connections to and from [ip] are allowed. [exempt] should be
+// true if [ip] belongs to a bootstrap beacon or a validator.
+func (a *acl) allowed(ip net.IP, exempt bool) bool {
+	if len(a.allowlist) > 0 && !containsIP(a.allowlist, ip) {
+		return false
+	}
+	return (exempt && a.exemptBeaconsAndValidators) || !containsIP(a.denylist, ip)
+}
+
+func containsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetACL implements the Network interface
+// Assumes [n.stateLock] is not held.
+func (n *network) SetACL(config ACLConfig) error {
+	parsedACL, err := newACL(config)
+	if err != nil {
+		return err
+	}
+
+	n.stateLock.Lock()
+	n.acl = parsedACL
+	// Close the connections that are no longer allowed
+	var peersToClose []*peer
+	for _, peer := range n.peers.peersList {
+		addr, err := utils.ToIPDesc(peer.conn.RemoteAddr().String())
+		if err != nil {
+			continue
+		}
+		if !n.aclAllows(addr) {
+			peersToClose = append(peersToClose, peer)
+		}
+	}
+	n.stateLock.Unlock()
+
+	n.log.Info("updated the network ACL. Disconnecting from %d now denied peers", len(peersToClose))
+	for _, peer := range peersToClose {
+		peer.Close() // Grabs the stateLock
+	}
+	return nil
+}
+
+// Returns true if the network ACL allows connections to and from [ip].
+// Assumes [n.stateLock] is held.
+func (n *network) aclAllows(ip utils.IPDesc) bool {
+	if n.acl == nil {
+		return true
+	}
+	exempt := n.acl.exemptBeaconsAndValidators && (n.isBeaconIP(ip) || n.isValidatorIP(ip))
+	return n.acl.allowed(ip.IP, exempt)
+}
+
+// isBeaconIP returns true if a bootstrap beacon is being dialed at the same IP
+// (ignoring the port) as [ip].
+// Assumes [n.stateLock] is held.
+func (n *network) isBeaconIP(ip utils.IPDesc) bool {
+	_, ok := n.beaconIPs[ip.IP.String()]
+	return ok
+}
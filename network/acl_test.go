@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func newACLTestNetwork(t *testing.T, config ACLConfig, dialer *refusingDialer) *network {
+	acl, err := newACL(config)
+	assert.NoError(t, err)
+
+	n := &network{
+		log:             logging.NoLog{},
+		dialer:          dialer,
+		disconnectedIPs: make(map[string]struct{}),
+		connectedIPs:    make(map[string]struct{}),
+		peerAliasIPs:    make(map[string]struct{}),
+		myIPs:           make(map[string]struct{}),
+		latestPeerIP:    make(map[ids.ShortID]signedPeerIP),
+		beaconIPs:       make(map[string]struct{}),
+		acl:             acl,
+		config: &Config{
+			Validators: validators.NewManager(),
+		},
+	}
+	n.peers.initialize()
+	assert.NoError(t, n.metrics.initialize("", prometheus.NewRegistry()))
+	return n
+}
+
+func TestNewACLInvalid(t *testing.T) {
+	_, err := newACL(ACLConfig{Allowlist: []string{"not an IP"}})
+	assert.Error(t, err)
+
+	_, err = newACL(ACLConfig{Denylist: []string{"10.0.0.0/33"}})
+	assert.Error(t, err)
+}
+
+func TestACLAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ACLConfig
+		ip      net.IP
+		exempt  bool
+		allowed bool
+	}{
+		{
+			name:    "empty",
+			config:  ACLConfig{},
+			ip:      net.IPv4(1, 2, 3, 4),
+			allowed: true,
+		},
+		{
+			name:    "in allowlist",
+			config:  ACLConfig{Allowlist: []string{"1.2.3.0/24"}},
+			ip:      net.IPv4(1, 2, 3, 4),
+			allowed: true,
+		},
+		{
+			name:    "not in allowlist",
+			config:  ACLConfig{Allowlist: []string{"1.2.3.0/24"}},
+			ip:      net.IPv4(1, 2, 4, 4),
+			allowed: false,
+		},
+		{
+			name:    "plain IP in denylist",
+			config:  ACLConfig{Denylist: []string{"1.2.3.4"}},
+			ip:      net.IPv4(1, 2, 3, 4),
+			allowed: false,
+		},
+		{
+			name:    "plain IP not in denylist",
+			config:  ACLConfig{Denylist: []string{"1.2.3.4"}},
+			ip:      net.IPv4(1, 2, 3, 5),
+			allowed: true,
+		},
+		{
+			name:    "IPv6 in denylist",
+			config:  ACLConfig{Denylist: []string{"2001:db8::/32"}},
+			ip:      net.ParseIP("2001:db8::1"),
+			allowed: false,
+		},
+		{
+			name:    "in allowlist and denylist",
+			config:  ACLConfig{Allowlist: []string{"1.2.0.0/16"}, Denylist: []string{"1.2.3.0/24"}},
+			ip:      net.IPv4(1, 2, 3, 4),
+			allowed: false,
+		},
+		{
+			name:    "exempt without exemptions enabled",
+			config:  ACLConfig{Denylist: []string{"1.2.3.0/24"}},
+			ip:      net.IPv4(1, 2, 3, 4),
+			exempt:  true,
+			allowed: false,
+		},
+		{
+			name:    "exempt in denylist",
+			config:  ACLConfig{Denylist: []string{"1.2.3.0/24"}, ExemptBeaconsAndValidators: true},
+			ip:      net.IPv4(1, 2, 3, 4),
+			exempt:  true,
+			allowed: true,
+		},
+		{
+			name:    "exempt not in allowlist",
+			config:  ACLConfig{Allowlist: []string{"1.2.4.0/24"}, ExemptBeaconsAndValidators: true},
+			ip:      net.IPv4(1, 2, 3, 4),
+			exempt:  true,
+			allowed: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			acl, err := newACL(test.config)
+			assert.NoError(t, err)
+			assert.Equal(t, test.allowed, acl.allowed(test.ip, test.exempt))
+		})
+	}
+}
+
+// Ensure that inbound and outbound connections to denied IPs are rejected
+// before the TLS upgrade and the dial, respectively.
+func TestACLRejectsConnections(t *testing.T) {
+	assert := assert.New(t)
+
+	dialer := &refusingDialer{dialed: make(map[string]int)}
+	n := newACLTestNetwork(t, ACLConfig{Denylist: []string{"1.2.3.0/24"}}, dialer)
+
+	deniedIP := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	assert.False(n.shouldUpgradeIncoming(deniedIP))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.aclRejectedConns))
+
+	err := n.attemptConnect(context.Background(), deniedIP)
+	assert.ErrorIs(err, errDeniedByACL)
+	assert.Zero(dialer.numDialed(deniedIP))
+	assert.Equal(2.0, testutil.ToFloat64(n.metrics.aclRejectedConns))
+
+	allowedIP := utils.IPDesc{IP: net.IPv4(1, 2, 4, 4), Port: 9651}
+	err = n.attemptConnect(context.Background(), allowedIP)
+	assert.ErrorIs(err, errRefused)
+	assert.Equal(1, dialer.numDialed(allowedIP))
+	assert.Equal(2.0, testutil.ToFloat64(n.metrics.aclRejectedConns))
+}
+
+// Ensure that beacons and validators bypass the denylist only if configured
+// to.
+func TestACLExemptBeaconsAndValidators(t *testing.T) {
+	assert := assert.New(t)
+
+	config := ACLConfig{Denylist: []string{"1.2.3.0/24"}}
+	n := newACLTestNetwork(t, config, nil)
+
+	beaconIP := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	n.beaconIPs[beaconIP.IP.String()] = struct{}{}
+
+	validatorIP := utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}
+	validator := createPeer(ids.GenerateTestShortID(), validatorIP, version.CurrentApp)
+	validator.conn = &testConn{
+		remote: &net.TCPAddr{IP: validatorIP.IP, Port: int(validatorIP.Port)},
+		closed: make(chan struct{}),
+	}
+	n.peers.add(validator)
+	assert.NoError(n.config.Validators.AddWeight(constants.PrimaryNetworkID, validator.nodeID, 1))
+
+	otherIP := utils.IPDesc{IP: net.IPv4(1, 2, 3, 6), Port: 9651}
+
+	assert.False(n.aclAllows(beaconIP))
+	assert.False(n.aclAllows(validatorIP))
+	assert.False(n.aclAllows(otherIP))
+
+	config.ExemptBeaconsAndValidators = true
+	assert.NoError(n.SetACL(config))
+
+	assert.True(n.aclAllows(beaconIP))
+	assert.True(n.aclAllows(validatorIP))
+	assert.False(n.aclAllows(otherIP))
+}
+
+// Ensure that an invalid ACL doesn't replace the current one.
+func TestSetACLInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	n := newACLTestNetwork(t, ACLConfig{Denylist: []string{"1.2.3.0/24"}}, nil)
+	assert.Error(n.SetACL(ACLConfig{Denylist: []string{"1.2.3.0/99"}}))
+	assert.False(n.aclAllows(utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}))
+}
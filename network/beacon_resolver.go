@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const (
+	// Max amount of time spent resolving a beacon's hostname
+	beaconResolveTimeout = 10 * time.Second
+	// Delay before retrying to resolve a beacon's hostname after the first
+	// failure. The delay doubles after each consecutive failure.
+	initialBeaconResolveBackoff = time.Second
+)
+
+var errNoIPsResolved = errors.New("no IPs resolved")
+
+// hostResolver resolves hostnames to IPs.
+// Useful for faking DNS in tests.
+type hostResolver interface {
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+type BeaconResolverConfig struct {
+	// Frequency at which the hostnames of bootstrap beacons are re-resolved
+	BeaconResolveFreq time.Duration `json:"beaconResolveFreq"`
+	// Max delay between attempts to resolve a beacon's hostname after
+	// resolution failed
+	BeaconMaxResolveBackoff time.Duration `json:"beaconMaxResolveBackoff"`
+	// Number of consecutive failed attempts to connect to a beacon's IP after
+	// which its next IP is tried. Once all of its IPs have been tried, its
+	// hostname is re-resolved.
+	BeaconMaxConnectFailures int `json:"beaconMaxConnectFailures"`
+}
+
+// beaconHost is a bootstrap beacon that was specified by hostname
+type beaconHost struct {
+	hostname string
+	port     uint16
+	// IPs [hostname] most recently resolved to, in the order they were
+	// returned by the resolver. Empty if [hostname] was never resolved.
+	// [stateLock] should be held when accessing this field.
+	ips []utils.IPDesc
+	// Index in [ips] of the IP this beacon is currently being dialed at.
+	// [stateLock] should be held when accessing this field.
+	index int
+	// Number of consecutive failed attempts to connect to ips[index].
+	// [stateLock] should be held when accessing this field.
+	numConnectFailures int
+	// Signaled when connecting to all of [ips] failed, and [hostname] should
+	// be re-resolved without waiting for the next periodic resolution.
+	resolveNow chan struct{}
+}
+
+// Returns the IP this beacon is currently being dialed at.
+// Assumes [n.stateLock] is held.
+func (h *beaconHost) currentIP() (utils.IPDesc, bool) {
+	if len(h.ips) == 0 {
+		return utils.IPDesc{}, false
+	}
+	return h.ips[h.index], true
+}
+
+// TrackHostname implements the Network interface
+// Assumes [n.stateLock] is not held.
+func (n *network) TrackHostname(hostname string, port uint16) {
+	h := &beaconHost{
+		hostname:   hostname,
+		port:       port,
+		resolveNow: make(chan struct{}, 1),
+	}
+
+	n.stateLock.Lock()
+	n.numUnresolvedBeacons++
+	n.metrics.unresolvedBeacons.Set(float64(n.numUnresolvedBeacons))
+	n.stateLock.Unlock()
+
+	go n.resolveBeacon(h)
+}
+
+// Resolves [h]'s hostname and tracks the resolved IPs. Re-resolves the
+// hostname periodically, or when connecting to all of its IPs failed.
+// Assumes [n.stateLock] is not held. Only returns after the network is closed.
+func (n *network) resolveBeacon(h *beaconHost) {
+	backoff := initialBeaconResolveBackoff
+	for !n.closed.GetValue() {
+		ips, err := n.lookupBeacon(h)
+		if err != nil {
+			if backoff > n.config.BeaconMaxResolveBackoff {
+				backoff = n.config.BeaconMaxResolveBackoff
+			}
+			n.log.Warn("failed to resolve beacon %s: %s. Retrying in %s", h.hostname, err, backoff)
+
+			// Keep dialing the IPs this beacon previously resolved to, if any
+			n.stateLock.Lock()
+			n.trackBeaconIP(h)
+			n.stateLock.Unlock()
+
+			time.Sleep(backoff)
+			backoff *= 2
+			continue
+		}
+		backoff = initialBeaconResolveBackoff
+
+		n.stateLock.Lock()
+		n.setBeaconIPs(h, ips)
+		n.stateLock.Unlock()
+
+		select {
+		case <-time.After(n.config.BeaconResolveFreq):
+		case <-h.resolveNow:
+		}
+	}
+}
+
+// Returns the IPs [h]'s hostname resolves to, in the order returned by the
+// resolver.
+// Assumes [n.stateLock] is not held.
+func (n *network) lookupBeacon(h *beaconHost) ([]utils.IPDesc, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), beaconResolveTimeout)
+	defer cancel()
+
+	resolvedIPs, err := n.hostResolver.LookupIP(ctx, "ip", h.hostname)
+	if err != nil {
+		return nil, err
+	}
+	if len(resolvedIPs) == 0 {
+		return nil, errNoIPsResolved
+	}
+	ips := make([]utils.IPDesc, len(resolvedIPs))
+	for i, ip := range resolvedIPs {
+		ips[i] = utils.IPDesc{
+			IP:   ip,
+			Port: h.port,
+		}
+	}
+	return ips, nil
+}
+
+// Sets the IPs [h] resolved to. If the IP [h] is currently being dialed at is
+// still in [ips], keeps dialing that IP. Otherwise, stops dialing it and
+// starts dialing the first IP in [ips].
+// Assumes [n.stateLock] is held.
+func (n *network) setBeaconIPs(h *beaconHost, ips []utils.IPDesc) {
+	if len(h.ips) == 0 {
+		n.numUnresolvedBeacons--
+		n.metrics.unresolvedBeacons.Set(float64(n.numUnresolvedBeacons))
+	}
+
+	index := 0
+	if currentIP, ok := h.currentIP(); ok {
+		found := false
+		for i, ip := range ips {
+			if ip.Equal(currentIP) {
+				index = i
+				found = true
+				break
+			}
+		}
+		if !found {
+			n.log.Info("beacon %s no longer resolves to %s", h.hostname, currentIP)
+			n.untrackBeaconIP(currentIP)
+			h.numConnectFailures = 0
+		}
+	}
+	h.ips = ips
+	h.index = index
+	n.trackBeaconIP(h)
+}
+
+// Starts dialing [h] at its current IP, if it isn't already being dialed or
+// connected to.
+// Assumes [n.stateLock] is held.
+func (n *network) trackBeaconIP(h *beaconHost) {
+	ip, ok := h.currentIP()
+	if !ok {
+		return
+	}
+	n.beaconHostIPs[ip.String()] = h
+	n.beaconIPs[ip.IP.String()] = struct{}{}
+	n.track(ip, ids.ShortEmpty)
+}
+
+// Stops dialing the beacon IP [ip]. If we're connected to [ip], the connection
+// isn't closed.
+// Assumes [n.stateLock] is held.
+func (n *network) untrackBeaconIP(ip utils.IPDesc) {
+	str := ip.String()
+	delete(n.beaconHostIPs, str)
+	// Causes [connectTo] to stop attempting to connect to [ip]
+	delete(n.disconnectedIPs, str)
+}
+
+// Records the result of an attempt to connect to [ip]. If [ip] is the current
+// IP of a beacon specified by hostname, and connecting to it failed
+// [BeaconMaxConnectFailures] times in a row, the beacon's next IP is dialed
+// instead. If there is no next IP, the beacon's hostname is re-resolved.
+// Assumes [n.stateLock] is not held.
+func (n *network) beaconConnectAttempted(ip utils.IPDesc, succeeded bool) {
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	h, ok := n.beaconHostIPs[ip.String()]
+	if !ok {
+		return
+	}
+	if succeeded {
+		h.numConnectFailures = 0
+		return
+	}
+	h.numConnectFailures++
+	if h.numConnectFailures < n.config.BeaconMaxConnectFailures {
+		return
+	}
+	h.numConnectFailures = 0
+	n.untrackBeaconIP(ip)
+
+	h.index++
+	if h.index < len(h.ips) {
+		n.log.Debug("failed to connect to beacon %s at %s. Trying %s", h.hostname, ip, h.ips[h.index])
+		n.trackBeaconIP(h)
+		return
+	}
+
+	n.log.Debug("failed to connect to beacon %s at all of its IPs. Re-resolving it", h.hostname)
+	h.index = 0
+	select {
+	case h.resolveNow <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// testHostResolver resolves hostnames to [ips]. Blocks until [ready] is
+// closed.
+type testHostResolver struct {
+	ready chan struct{}
+
+	lock        sync.Mutex
+	ips         []net.IP
+	numResolved int
+}
+
+func (r *testHostResolver) LookupIP(ctx context.Context, _, _ string) ([]net.IP, error) {
+	select {
+	case <-r.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.numResolved++
+	if len(r.ips) == 0 {
+		return nil, errNoIPsResolved
+	}
+	return r.ips, nil
+}
+
+func (r *testHostResolver) setIPs(ips ...net.IP) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ips = ips
+}
+
+func (r *testHostResolver) getNumResolved() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.numResolved
+}
+
+// refusingDialer fails to dial every IP, and records the IPs it was asked to
+// dial.
+type refusingDialer struct {
+	lock   sync.Mutex
+	dialed map[string]int
+}
+
+func (d *refusingDialer) Dial(_ context.Context, ip utils.IPDesc) (net.Conn, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.dialed[ip.String()]++
+	return nil, errRefused
+}
+
+func (d *refusingDialer) numDialed(ip utils.IPDesc) int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.dialed[ip.String()]
+}
+
+func newBeaconResolverTestNetwork(t *testing.T, resolver hostResolver, dialer *refusingDialer) *network {
+	n := &network{
+		log:             logging.NoLog{},
+		dialer:          dialer,
+		disconnectedIPs: make(map[string]struct{}),
+		connectedIPs:    make(map[string]struct{}),
+		peerAliasIPs:    make(map[string]struct{}),
+		myIPs:           make(map[string]struct{}),
+		retryDelay:      make(map[string]time.Duration),
+		latestPeerIP:    make(map[ids.ShortID]signedPeerIP),
+		hostResolver:    resolver,
+		beaconHostIPs:   make(map[string]*beaconHost),
+		beaconIPs:       make(map[string]struct{}),
+		config: &Config{
+			DelayConfig: DelayConfig{
+				InitialReconnectDelay: time.Millisecond,
+				MaxReconnectDelay:     2 * time.Millisecond,
+			},
+			BeaconResolverConfig: BeaconResolverConfig{
+				BeaconResolveFreq:        time.Hour,
+				BeaconMaxResolveBackoff:  time.Millisecond,
+				BeaconMaxConnectFailures: 2,
+			},
+		},
+	}
+	assert.NoError(t, n.metrics.initialize("", prometheus.NewRegistry()))
+	t.Cleanup(func() {
+		n.stateLock.Lock()
+		n.closed.SetValue(true)
+		n.stateLock.Unlock()
+	})
+	return n
+}
+
+// Ensure that a beacon's IPs are tried in order, and that its hostname is
+// re-resolved once connecting to all of them failed.
+func TestBeaconHostnameResolution(t *testing.T) {
+	assert := assert.New(t)
+
+	resolver := &testHostResolver{ready: make(chan struct{})}
+	dialer := &refusingDialer{dialed: make(map[string]int)}
+	n := newBeaconResolverTestNetwork(t, resolver, dialer)
+
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4).To4(), Port: 9651}
+	ip1 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 5).To4(), Port: 9651}
+	ip2 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 6).To4(), Port: 9651}
+	resolver.setIPs(ip0.IP, ip1.IP)
+
+	n.TrackHostname("beacon.example.com", 9651)
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.unresolvedBeacons))
+
+	close(resolver.ready)
+	assert.Eventually(func() bool {
+		return testutil.ToFloat64(n.metrics.unresolvedBeacons) == 0
+	}, time.Second, time.Millisecond)
+
+	// Once dialing the first IP repeatedly fails, the second IP is dialed
+	assert.Eventually(func() bool {
+		return dialer.numDialed(ip1) > 0
+	}, time.Second, time.Millisecond)
+	assert.GreaterOrEqual(dialer.numDialed(ip0), 2)
+
+	// Once dialing all of the IPs failed, the hostname is re-resolved, and
+	// the new IP is dialed
+	resolver.setIPs(ip2.IP)
+	assert.Eventually(func() bool {
+		return dialer.numDialed(ip2) > 0
+	}, time.Second, time.Millisecond)
+	assert.GreaterOrEqual(resolver.getNumResolved(), 2)
+
+	// The stale IPs are no longer dialed
+	n.stateLock.RLock()
+	assert.NotContains(n.beaconHostIPs, ip0.String())
+	assert.NotContains(n.beaconHostIPs, ip1.String())
+	n.stateLock.RUnlock()
+}
+
+// Ensure that resolution failures are retried, and reported in the unresolved
+// beacons gauge.
+func TestBeaconHostnameResolutionFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	resolver := &testHostResolver{ready: make(chan struct{})}
+	close(resolver.ready)
+	dialer := &refusingDialer{dialed: make(map[string]int)}
+	n := newBeaconResolverTestNetwork(t, resolver, dialer)
+
+	n.TrackHostname("beacon.example.com", 9651)
+	assert.Eventually(func() bool {
+		return resolver.getNumResolved() >= 3
+	}, time.Second, time.Millisecond)
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.unresolvedBeacons))
+
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4).To4(), Port: 9651}
+	resolver.setIPs(ip.IP)
+	assert.Eventually(func() bool {
+		return dialer.numDialed(ip) > 0
+	}, time.Second, time.Millisecond)
+	assert.Equal(0.0, testutil.ToFloat64(n.metrics.unresolvedBeacons))
+}
@@ -3,7 +3,10 @@
 
 package network
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // HealthConfig describes parameters for network layer health checks.
 type HealthConfig struct {
@@ -31,4 +34,98 @@ type HealthConfig struct {
 	// Must be > 0.
 	// Larger value --> Drop rate affected less by recent messages
 	MaxSendFailRateHalflife time.Duration `json:"maxSendFailRateHalflife"`
+
+	// Length of the sliding window over which peer disconnects and drops in
+	// the connected stake are measured. If 0, neither is checked.
+	ChurnWindow time.Duration `json:"churnWindow"`
+
+	// If more than this many peers disconnected within [ChurnWindow], will
+	// report unhealthy. If 0, isn't checked.
+	MaxDisconnectsInWindow uint `json:"maxDisconnectsInWindow"`
+
+	// If the connected stake dropped by more than this portion of the most
+	// stake connected within [ChurnWindow], will report unhealthy. Must be in
+	// [0,1]. If 0, isn't checked.
+	MaxConnectedStakeDrop float64 `json:"maxConnectedStakeDrop"`
+
+	// If no peer is connected to this node inbound, and no inbound connection
+	// was accepted within this duration, will report unhealthy. Should only be
+	// set on nodes that are expected to be publicly reachable. If 0, isn't
+	// checked.
+	MaxTimeSinceInboundConn time.Duration `json:"maxTimeSinceInboundConn"`
+}
+
+type stakeMeasurement struct {
+	time  time.Time
+	stake uint64
+}
+
+// churnTracker tracks peer disconnects and the connected stake over a sliding
+// window.
+type churnTracker struct {
+	lock   sync.Mutex
+	window time.Duration
+
+	// Times of the disconnects within the window, in increasing order
+	disconnects []time.Time
+
+	// Connected stake measurements, in increasing time order. The first
+	// measurement is the one in effect at the start of the window.
+	stake []stakeMeasurement
+}
+
+func (c *churnTracker) observeDisconnect(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.disconnects = append(c.disconnects, now)
+	c.prune(now)
+}
+
+func (c *churnTracker) observeStake(stake uint64, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stake = append(c.stake, stakeMeasurement{
+		time:  now,
+		stake: stake,
+	})
+	c.prune(now)
+}
+
+// Returns the number of disconnects within the window and the most stake that
+// was connected within the window.
+func (c *churnTracker) read(now time.Time) (int, uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.prune(now)
+
+	maxStake := uint64(0)
+	for _, measurement := range c.stake {
+		if measurement.stake > maxStake {
+			maxStake = measurement.stake
+		}
+	}
+	return len(c.disconnects), maxStake
+}
+
+// Removes the measurements that are older than the window.
+// Assumes [c.lock] is held.
+func (c *churnTracker) prune(now time.Time) {
+	windowStart := now.Add(-c.window)
+
+	numExpired := 0
+	for numExpired < len(c.disconnects) && c.disconnects[numExpired].Before(windowStart) {
+		numExpired++
+	}
+	c.disconnects = c.disconnects[numExpired:]
+
+	// Keep the last measurement before the window, as it's the connected
+	// stake at the start of the window.
+	numExpired = 0
+	for numExpired+1 < len(c.stake) && !c.stake[numExpired+1].time.After(windowStart) {
+		numExpired++
+	}
+	c.stake = c.stake[numExpired:]
 }
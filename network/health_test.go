@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestChurnTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Unix(1000, 0)
+	c := churnTracker{window: time.Minute}
+
+	disconnects, maxStake := c.read(start)
+	assert.Zero(disconnects)
+	assert.Zero(maxStake)
+
+	c.observeStake(100, start)
+	c.observeDisconnect(start.Add(10 * time.Second))
+	c.observeStake(50, start.Add(10*time.Second))
+	c.observeDisconnect(start.Add(30 * time.Second))
+	c.observeStake(20, start.Add(30*time.Second))
+
+	disconnects, maxStake = c.read(start.Add(40 * time.Second))
+	assert.Equal(2, disconnects)
+	assert.EqualValues(100, maxStake)
+
+	// The stake of 50 was still connected at the start of the window
+	disconnects, maxStake = c.read(start.Add(80 * time.Second))
+	assert.Equal(1, disconnects)
+	assert.EqualValues(50, maxStake)
+
+	disconnects, maxStake = c.read(start.Add(time.Hour))
+	assert.Zero(disconnects)
+	assert.EqualValues(20, maxStake)
+}
+
+func newHealthTestNetwork(t *testing.T, config HealthConfig) *network {
+	n := &network{
+		log: logging.NoLog{},
+		config: &Config{
+			HealthConfig: config,
+			Validators:   getDefaultManager(),
+		},
+	}
+	n.clock.Set(time.Unix(1000, 0))
+	n.peers.initialize()
+	n.sendFailRateCalculator = math.NewSyncAverager(math.NewAverager(0, time.Second, n.clock.Time()))
+	n.lastMsgReceivedTime = n.clock.Time().Unix()
+	n.lastMsgSentTime = n.clock.Time().Unix()
+	n.lastInboundConnTime = n.clock.Time().Unix()
+	n.churn.window = config.ChurnWindow
+	assert.NoError(t, n.metrics.initialize("", prometheus.NewRegistry()))
+	return n
+}
+
+// Ensure that the health check fails when too many peers disconnect, or too
+// much stake disconnects, within the churn window.
+func TestHealthCheckChurn(t *testing.T) {
+	assert := assert.New(t)
+
+	n := newHealthTestNetwork(t, HealthConfig{
+		MaxTimeSinceMsgReceived: time.Hour,
+		MaxTimeSinceMsgSent:     time.Hour,
+		MaxSendFailRate:         1,
+		ChurnWindow:             time.Minute,
+		MaxDisconnectsInWindow:  1,
+		MaxConnectedStakeDrop:   .5,
+	})
+
+	peers := make([]*peer, 4)
+	for i := range peers {
+		ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, byte(i+1)), Port: 9651}
+		peers[i] = createPeer(ids.GenerateTestShortID(), ip, version.CurrentApp)
+		addPeerToNetwork(n, peers[i], true)
+	}
+	n.churn.observeStake(n.connectedStake(), n.clock.Time())
+
+	details, err := n.HealthCheck()
+	assert.NoError(err)
+	assert.Contains(details, "disconnectsInWindow")
+	assert.EqualValues(40, details.(map[string]interface{})["connectedStake"])
+
+	// Disconnecting 1 of the 4 validators is fine
+	n.peers.remove(peers[0])
+	n.churn.observeDisconnect(n.clock.Time())
+	n.churn.observeStake(n.connectedStake(), n.clock.Time())
+	_, err = n.HealthCheck()
+	assert.NoError(err)
+
+	// Disconnecting another validator exceeds both thresholds
+	n.peers.remove(peers[1])
+	n.churn.observeDisconnect(n.clock.Time())
+	n.churn.observeStake(n.connectedStake(), n.clock.Time())
+	details, err = n.HealthCheck()
+	assert.Error(err)
+	detailsMap := details.(map[string]interface{})
+	assert.Equal(2, detailsMap["disconnectsInWindow"])
+	assert.EqualValues(40, detailsMap["maxConnectedStakeInWindow"])
+	assert.Equal(.5, detailsMap["connectedStakeDrop"])
+
+	// After the window passes, the remaining peers are stable
+	n.clock.Set(n.clock.Time().Add(2 * time.Minute))
+	n.lastMsgReceivedTime = n.clock.Time().Unix()
+	n.lastMsgSentTime = n.clock.Time().Unix()
+	_, err = n.HealthCheck()
+	assert.NoError(err)
+}
+
+// Ensure that the health check fails when no inbound connection was accepted
+// for too long, only if configured to check it.
+func TestHealthCheckInboundConn(t *testing.T) {
+	assert := assert.New(t)
+
+	config := HealthConfig{
+		MaxTimeSinceMsgReceived: time.Hour,
+		MaxTimeSinceMsgSent:     time.Hour,
+		MaxSendFailRate:         1,
+	}
+	n := newHealthTestNetwork(t, config)
+	n.clock.Set(n.clock.Time().Add(time.Minute))
+
+	_, err := n.HealthCheck()
+	assert.NoError(err)
+
+	n.config.HealthConfig.MaxTimeSinceInboundConn = 30 * time.Second
+	details, err := n.HealthCheck()
+	assert.Error(err)
+	assert.Equal(time.Minute.String(), details.(map[string]interface{})["timeSinceLastInboundConn"])
+
+	// A node with an inbound peer is reachable
+	inboundPeer := createPeer(ids.GenerateTestShortID(), utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}, version.CurrentApp)
+	inboundPeer.inbound = true
+	n.peers.add(inboundPeer)
+	details, err = n.HealthCheck()
+	assert.NoError(err)
+	assert.Equal(1, details.(map[string]interface{})["inboundPeers"])
+
+	n.peers.remove(inboundPeer)
+	n.lastInboundConnTime = n.clock.Time().Unix()
+	_, err = n.HealthCheck()
+	assert.NoError(err)
+}
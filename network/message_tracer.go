@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const defaultMaxTraceRecordsPerSecond = 100
+
+var errEmptyTraceFilter = errors.New("a message trace must match at least one node ID or chain ID")
+
+// MessageTraceConfig describes which messages are traced. A message is traced
+// if it's sent to, or received from, a node in [NodeIDs], or if its ChainID
+// field is in [ChainIDs].
+type MessageTraceConfig struct {
+	NodeIDs  []ids.ShortID `json:"nodeIDs"`
+	ChainIDs []ids.ID      `json:"chainIDs"`
+	// Max number of trace records logged per second. Records over this rate
+	// are dropped, and counted in the next record that is logged. If 0, a
+	// default rate is used.
+	MaxRecordsPerSecond uint `json:"maxRecordsPerSecond"`
+}
+
+// StartMessageTrace implements the Network interface
+func (n *network) StartMessageTrace(config MessageTraceConfig) error {
+	if err := n.tracer.start(n.log, config); err != nil {
+		return err
+	}
+	// The chain ID and request ID of outbound messages are only kept while
+	// messages are traced
+	n.mc.SetKeepFields(true)
+	n.log.Info("started tracing messages of %d peers and %d chains", len(config.NodeIDs), len(config.ChainIDs))
+	return nil
+}
+
+// StopMessageTrace implements the Network interface
+func (n *network) StopMessageTrace() bool {
+	if !n.tracer.stop() {
+		return false
+	}
+	n.mc.SetKeepFields(false)
+	n.log.Info("stopped tracing messages")
+	return true
+}
+
+// messageTracer logs a record of each traced message. The zero value doesn't
+// trace any message.
+type messageTracer struct {
+	// If false, no message is traced. This is checked before anything else
+	// so that tracing costs nothing while it's disabled.
+	enabled utils.AtomicBool
+
+	// [lock] must be held when accessing the fields below.
+	lock     sync.Mutex
+	log      logging.Logger
+	nodeIDs  ids.ShortSet
+	chainIDs ids.Set
+	limiter  *rate.Limiter
+	// Number of records dropped by [limiter] since the last logged record
+	dropped uint64
+}
+
+// Starts tracing the messages matching [config], using [log] to log the trace
+// records. Replaces the previous trace, if any.
+func (t *messageTracer) start(log logging.Logger, config MessageTraceConfig) error {
+	if len(config.NodeIDs) == 0 && len(config.ChainIDs) == 0 {
+		return errEmptyTraceFilter
+	}
+	maxRecordsPerSecond := config.MaxRecordsPerSecond
+	if maxRecordsPerSecond == 0 {
+		maxRecordsPerSecond = defaultMaxTraceRecordsPerSecond
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.log = log
+	t.nodeIDs = ids.ShortSet{}
+	t.nodeIDs.Add(config.NodeIDs...)
+	t.chainIDs = ids.Set{}
+	t.chainIDs.Add(config.ChainIDs...)
+	t.limiter = rate.NewLimiter(rate.Limit(maxRecordsPerSecond), int(maxRecordsPerSecond))
+	t.dropped = 0
+	t.enabled.SetValue(true)
+	return nil
+}
+
+// Stops tracing messages. Returns false if no messages were being traced.
+func (t *messageTracer) stop() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	wasEnabled := t.enabled.GetValue()
+	t.enabled.SetValue(false)
+	t.log = nil
+	t.nodeIDs = nil
+	t.chainIDs = nil
+	t.limiter = nil
+	return wasEnabled
+}
+
+// Returns true if messages may be traced. Used to avoid recording timestamps
+// that would never be logged.
+func (t *messageTracer) isEnabled() bool { return t.enabled.GetValue() }
+
+// Logs a record of [msg], which was queued for sending to [nodeID] at
+// [enqueuedAt] and written to the connection at [sentAt], if it's traced.
+// [enqueuedAt] is zero if tracing started while [msg] was queued.
+func (t *messageTracer) traceOutbound(
+	nodeID ids.ShortID,
+	msg message.OutboundMessage,
+	enqueuedAt time.Time,
+	sentAt time.Time,
+) {
+	if !t.enabled.GetValue() {
+		return
+	}
+	t.trace("outbound", msg.Op(), msg.Get, nodeID, len(msg.Bytes()), "enqueued", enqueuedAt, "sent", sentAt)
+}
+
+// Logs a record of [msg], which was read from [nodeID]'s connection at
+// [receivedAt] and handled (given to the router, for consensus and app-level
+// messages) at [handledAt], if it's traced.
+func (t *messageTracer) traceInbound(
+	nodeID ids.ShortID,
+	msg message.InboundMessage,
+	size int,
+	receivedAt time.Time,
+	handledAt time.Time,
+) {
+	if !t.enabled.GetValue() {
+		return
+	}
+	t.trace("inbound", msg.Op(), msg.Get, nodeID, size, "received", receivedAt, "handled", handledAt)
+}
+
+func (t *messageTracer) trace(
+	direction string,
+	op message.Op,
+	get func(message.Field) interface{},
+	nodeID ids.ShortID,
+	size int,
+	firstEvent string,
+	firstTime time.Time,
+	secondEvent string,
+	secondTime time.Time,
+) {
+	chainID, hasChainID := chainIDOf(get)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// Tracing may have been stopped since [t.enabled] was checked
+	if !t.enabled.GetValue() {
+		return
+	}
+	if !t.nodeIDs.Contains(nodeID) && !(hasChainID && t.chainIDs.Contains(chainID)) {
+		return
+	}
+	if !t.limiter.Allow() {
+		t.dropped++
+		return
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("message trace: direction=%s op=%s peer=%s%s", direction, op, constants.NodeIDPrefix, nodeID))
+	if hasChainID {
+		sb.WriteString(fmt.Sprintf(" chainID=%s", chainID))
+	}
+	if requestID, ok := get(message.RequestID).(uint32); ok {
+		sb.WriteString(fmt.Sprintf(" requestID=%d", requestID))
+	}
+	sb.WriteString(fmt.Sprintf(" size=%d", size))
+	for _, event := range []struct {
+		name string
+		time time.Time
+	}{
+		{name: firstEvent, time: firstTime},
+		{name: secondEvent, time: secondTime},
+	} {
+		if !event.time.IsZero() {
+			sb.WriteString(fmt.Sprintf(" %s=%s", event.name, event.time.UTC().Format(time.RFC3339Nano)))
+		}
+	}
+	if t.dropped > 0 {
+		sb.WriteString(fmt.Sprintf(" droppedRecords=%d", t.dropped))
+		t.dropped = 0
+	}
+	t.log.Info("%s", sb.String())
+}
+
+// Returns the ChainID field of a message, given the function returning its
+// fields, and false if the message doesn't have a valid ChainID field.
+func chainIDOf(get func(message.Field) interface{}) (ids.ID, bool) {
+	chainIDBytes, ok := get(message.ChainID).([]byte)
+	if !ok {
+		return ids.ID{}, false
+	}
+	chainID, err := ids.ToID(chainIDBytes)
+	return chainID, err == nil
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// traceLog records the messages logged at the info level
+type traceLog struct {
+	logging.NoLog
+	records []string
+}
+
+func (l *traceLog) Info(format string, args ...interface{}) {
+	l.records = append(l.records, fmt.Sprintf(format, args...))
+}
+
+func TestMessageTracer(t *testing.T) {
+	assert := assert.New(t)
+
+	mc, err := message.NewCreator(prometheus.NewRegistry(), true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
+	assert.NoError(err)
+	mc.SetKeepFields(true)
+
+	tracedNodeID := ids.GenerateTestShortID()
+	otherNodeID := ids.GenerateTestShortID()
+	tracedChainID := ids.GenerateTestID()
+	otherChainID := ids.GenerateTestID()
+
+	tracedChainMsg, err := mc.PushQuery(tracedChainID, 1, time.Second, ids.GenerateTestID(), []byte{1})
+	assert.NoError(err)
+	otherChainMsg, err := mc.Chits(otherChainID, 2, []ids.ID{ids.GenerateTestID()})
+	assert.NoError(err)
+	inboundMsg := mc.InboundAccepted(tracedChainID, 3, []ids.ID{ids.GenerateTestID()}, otherNodeID)
+
+	log := &traceLog{}
+	tracer := messageTracer{}
+	now := time.Now()
+
+	// Nothing is traced until the trace is started
+	assert.False(tracer.isEnabled())
+	tracer.traceOutbound(tracedNodeID, tracedChainMsg, now, now)
+	assert.Empty(log.records)
+
+	assert.ErrorIs(tracer.start(log, MessageTraceConfig{}), errEmptyTraceFilter)
+	assert.False(tracer.isEnabled())
+
+	assert.NoError(tracer.start(log, MessageTraceConfig{
+		NodeIDs:  []ids.ShortID{tracedNodeID},
+		ChainIDs: []ids.ID{tracedChainID},
+	}))
+	assert.True(tracer.isEnabled())
+
+	// Messages to a traced node are traced
+	tracer.traceOutbound(tracedNodeID, otherChainMsg, now, now)
+	assert.Len(log.records, 1)
+	assert.Contains(log.records[0], "direction=outbound")
+	assert.Contains(log.records[0], "op=chits")
+	assert.Contains(log.records[0], fmt.Sprintf("chainID=%s", otherChainID))
+	assert.Contains(log.records[0], "requestID=2")
+	assert.Contains(log.records[0], fmt.Sprintf("size=%d", len(otherChainMsg.Bytes())))
+	assert.Contains(log.records[0], "enqueued=")
+	assert.Contains(log.records[0], "sent=")
+
+	// Messages about a traced chain are traced
+	tracer.traceOutbound(otherNodeID, tracedChainMsg, time.Time{}, now)
+	assert.Len(log.records, 2)
+	assert.Contains(log.records[1], "op=push_query")
+	assert.NotContains(log.records[1], "enqueued=")
+
+	tracer.traceInbound(otherNodeID, inboundMsg, 100, now, now)
+	assert.Len(log.records, 3)
+	assert.Contains(log.records[2], "direction=inbound")
+	assert.Contains(log.records[2], "requestID=3")
+	assert.Contains(log.records[2], "size=100")
+	assert.Contains(log.records[2], "received=")
+	assert.Contains(log.records[2], "handled=")
+
+	// Other messages aren't traced
+	tracer.traceOutbound(otherNodeID, otherChainMsg, now, now)
+	assert.Len(log.records, 3)
+
+	assert.True(tracer.stop())
+	assert.False(tracer.isEnabled())
+	tracer.traceOutbound(tracedNodeID, tracedChainMsg, now, now)
+	assert.Len(log.records, 3)
+	assert.False(tracer.stop())
+}
+
+func TestMessageTracerRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	mc, err := message.NewCreator(prometheus.NewRegistry(), true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
+	assert.NoError(err)
+	msg, err := mc.Ping()
+	assert.NoError(err)
+	nodeID := ids.GenerateTestShortID()
+
+	log := &traceLog{}
+	tracer := messageTracer{}
+	assert.NoError(tracer.start(log, MessageTraceConfig{
+		NodeIDs:             []ids.ShortID{nodeID},
+		MaxRecordsPerSecond: 2,
+	}))
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		tracer.traceOutbound(nodeID, msg, now, now)
+	}
+	assert.Len(log.records, 2)
+	assert.EqualValues(3, tracer.dropped)
+
+	// The dropped records are reported in the next logged record
+	tracer.limiter = rate.NewLimiter(rate.Inf, 1)
+	tracer.traceOutbound(nodeID, msg, now, now)
+	assert.Len(log.records, 3)
+	assert.Contains(log.records[2], "droppedRecords=3")
+	assert.Zero(tracer.dropped)
+}
@@ -4,7 +4,11 @@
 package network
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"syscall"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -13,6 +17,29 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+// Reasons an outbound connection attempt failed
+const (
+	dialFailureRefused = "refused"
+	dialFailureTimeout = "timeout"
+	dialFailureTLS     = "tls"
+	dialFailureOther   = "other"
+)
+
+// Returns the reason dialing a peer failed with [err]
+func dialFailureReason(err error) string {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return dialFailureRefused
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return dialFailureTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return dialFailureTimeout
+	}
+	return dialFailureOther
+}
+
 type messageMetrics struct {
 	receivedBytes, sentBytes, numSent, numFailed, numReceived prometheus.Counter
 	savedReceivedBytes, savedSentBytes                        metric.Averager
@@ -43,7 +70,7 @@ func newMessageMetrics(op message.Op, namespace string, metrics prometheus.Regis
 		sentBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      fmt.Sprintf("%s_sent_bytes", op),
-			Help:      fmt.Sprintf("Size of bytes of %s messages received from the network", op),
+			Help:      fmt.Sprintf("Number of bytes of %s messages written to the network", op),
 		}),
 	}
 	errs.Add(
@@ -78,6 +105,8 @@ func newMessageMetrics(op message.Op, namespace string, metrics prometheus.Regis
 
 type metrics struct {
 	numPeers                  prometheus.Gauge
+	numInboundPeers           prometheus.Gauge
+	numOutboundPeers          prometheus.Gauge
 	timeSinceLastMsgSent      prometheus.Gauge
 	timeSinceLastMsgReceived  prometheus.Gauge
 	sendQueuePortionFull      prometheus.Gauge
@@ -89,6 +118,24 @@ type metrics struct {
 	inboundConnAllowed        prometheus.Counter
 	nodeUptimeWeightedAverage prometheus.Gauge
 	nodeUptimeRewardingStake  prometheus.Gauge
+	zstdSavedSentBytes        prometheus.Counter
+	unresolvedBeacons         prometheus.Gauge
+	aclRejectedConns          prometheus.Counter
+	peerListIPsLearned        prometheus.Counter
+	peerListIPsKnown          prometheus.Counter
+	peersByVersion            *prometheus.GaugeVec
+	stakeByVersion            *prometheus.GaugeVec
+	dialAttempts              prometheus.Counter
+	dialFailures              *prometheus.CounterVec
+	targetsInBackoff          prometheus.Gauge
+
+	peerListIPsAccepted         prometheus.Counter
+	peerListIPsStale            prometheus.Counter
+	peerListIPsInvalidSignature prometheus.Counter
+	peerListStrikeDisconnects   prometheus.Counter
+	oversizedStrikeDisconnects  prometheus.Counter
+
+	pingRTT prometheus.Histogram
 
 	messageMetrics map[message.Op]*messageMetrics
 }
@@ -99,6 +146,16 @@ func (m *metrics) initialize(namespace string, registerer prometheus.Registerer)
 		Name:      "peers",
 		Help:      "Number of network peers",
 	})
+	m.numInboundPeers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "peers_inbound",
+		Help:      "Number of network peers that connected to this node",
+	})
+	m.numOutboundPeers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "peers_outbound",
+		Help:      "Number of network peers that this node connected to",
+	})
 	m.timeSinceLastMsgReceived = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "time_since_last_msg_received",
@@ -154,10 +211,102 @@ func (m *metrics) initialize(namespace string, registerer prometheus.Registerer)
 		Name:      "node_uptime_rewarding_stake",
 		Help:      "The percentage of total stake which thinks this node is eligible for rewards",
 	})
+	m.zstdSavedSentBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "zstd_compression_saved_sent_bytes",
+		Help:      "Bytes saved (not sent) due to compressing messages with zstd",
+	})
+	m.peerListIPsLearned = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "peer_list_ips_learned",
+		Help:      "Number of gossiped peer IPs that were new to this node",
+	})
+	m.peerListIPsKnown = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "peer_list_ips_known",
+		Help:      "Number of gossiped peer IPs that this node already knew",
+	})
+	m.peerListIPsAccepted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "peer_list_ips_accepted",
+		Help:      "Number of gossiped peer IPs whose signature and timestamp were verified",
+	})
+	m.peerListIPsStale = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "peer_list_ips_stale",
+		Help:      "Number of gossiped peer IPs ignored because their timestamp was too far in the future, too old, or older than the latest known IP of the peer",
+	})
+	m.peerListIPsInvalidSignature = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "peer_list_ips_invalid_signature",
+		Help:      "Number of gossiped peer IPs ignored because their signature was invalid",
+	})
+	m.peerListStrikeDisconnects = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "peer_list_strike_disconnects",
+		Help:      "Number of peers disconnected from for gossiping too many peer IPs with invalid signatures",
+	})
+	m.oversizedStrikeDisconnects = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "oversized_strike_disconnects",
+		Help:      "Number of peers disconnected from for sending too many messages that exceeded the max size of their op",
+	})
+	m.pingRTT = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ping_rtt",
+		Help:      "Round trip time (in seconds) of pings to peers",
+		Buckets:   prometheus.ExponentialBuckets(.001, 2, 14), // 1ms to ~8s
+	})
+	m.peersByVersion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "peers_by_version",
+			Help:      "Number of connected peers that advertised each version",
+		},
+		[]string{"version"},
+	)
+	m.stakeByVersion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "stake_by_version",
+			Help:      "Primary network stake of the connected peers that advertised each version",
+		},
+		[]string{"version"},
+	)
+	m.dialAttempts = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dial_attempts",
+		Help:      "Number of attempts to connect to a peer",
+	})
+	m.dialFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dial_failures",
+			Help:      "Number of failed attempts to connect to a peer, by reason",
+		},
+		[]string{"reason"},
+	)
+	m.targetsInBackoff = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "dial_targets_in_backoff",
+		Help:      "Number of IPs waiting before the next attempt to connect to them",
+	})
+	m.aclRejectedConns = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "acl_rejected_conns",
+		Help:      "Number of inbound and outbound connections rejected by the network ACL",
+	})
+	m.unresolvedBeacons = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "unresolved_beacons",
+		Help:      "Number of bootstrap beacons whose hostname hasn't been resolved",
+	})
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(m.numPeers),
+		registerer.Register(m.numInboundPeers),
+		registerer.Register(m.numOutboundPeers),
 		registerer.Register(m.timeSinceLastMsgReceived),
 		registerer.Register(m.timeSinceLastMsgSent),
 		registerer.Register(m.sendQueuePortionFull),
@@ -169,6 +318,22 @@ func (m *metrics) initialize(namespace string, registerer prometheus.Registerer)
 		registerer.Register(m.inboundConnRateLimited),
 		registerer.Register(m.nodeUptimeWeightedAverage),
 		registerer.Register(m.nodeUptimeRewardingStake),
+		registerer.Register(m.zstdSavedSentBytes),
+		registerer.Register(m.unresolvedBeacons),
+		registerer.Register(m.aclRejectedConns),
+		registerer.Register(m.peerListIPsLearned),
+		registerer.Register(m.peerListIPsKnown),
+		registerer.Register(m.peerListIPsAccepted),
+		registerer.Register(m.peerListIPsStale),
+		registerer.Register(m.peerListIPsInvalidSignature),
+		registerer.Register(m.peerListStrikeDisconnects),
+		registerer.Register(m.oversizedStrikeDisconnects),
+		registerer.Register(m.pingRTT),
+		registerer.Register(m.peersByVersion),
+		registerer.Register(m.stakeByVersion),
+		registerer.Register(m.dialAttempts),
+		registerer.Register(m.dialFailures),
+		registerer.Register(m.targetsInBackoff),
 	)
 
 	m.messageMetrics = make(map[message.Op]*messageMetrics, len(message.ExternalOps))
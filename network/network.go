@@ -21,6 +21,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/health"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
@@ -30,10 +31,12 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/triggers"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -41,9 +44,28 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 )
 
+const (
+	// Upper bound on the size of the staking certificate, and of the signature
+	// of the IP, of a peer included in a PeerList message
+	maxPeerListCertLen      = 4 * units.KiB
+	maxPeerListSignatureLen = 1 * units.KiB
+	// Upper bound on the number of bytes a peer takes up in a PeerList message
+	maxPeerListEntryLen = wrappers.IntLen + maxPeerListCertLen + wrappers.IPLen + wrappers.LongLen + wrappers.IntLen + maxPeerListSignatureLen
+
+	// MaxPeerListSize is the max number of peers that may be included in a
+	// PeerList message such that the message fits in the max message size.
+	MaxPeerListSize = (constants.DefaultMaxMessageSize - units.KiB) / maxPeerListEntryLen
+
+	// Weight of each new round trip time sample in a peer's smoothed round
+	// trip time
+	rttSmoothingFactor = 0.125
+)
+
 var (
 	errNetworkClosed       = errors.New("network closed")
 	errPeerIsMyself        = errors.New("peer is myself")
@@ -77,6 +99,28 @@ type Network interface {
 	// internally to the network.
 	Track(ip utils.IPDesc, nodeID ids.ShortID)
 
+	// Replace the ACL that decides which IPs this node may connect to, and
+	// accept connections from. Existing connections that are no longer
+	// allowed are closed. Thread safety must be managed internally to the
+	// network.
+	SetACL(config ACLConfig) error
+
+	// Log a record of each message sent to, or received from, the peers in
+	// [config.NodeIDs], and of each message about the chains in
+	// [config.ChainIDs]. Replaces the previous message trace, if any. Thread
+	// safety must be managed internally to the network.
+	StartMessageTrace(config MessageTraceConfig) error
+
+	// Stop logging records of messages. Returns false if no messages were
+	// being traced. Thread safety must be managed internally to the network.
+	StopMessageTrace() bool
+
+	// Attempt to connect to the bootstrap beacon at [hostname]:[port]. The
+	// hostname is periodically re-resolved, and the network will never stop
+	// attempting to connect to the IPs it resolves to. Thread safety must be
+	// managed internally to the network.
+	TrackHostname(hostname string, port uint16)
+
 	// Returns the description of the specified [nodeIDs] this network is currently
 	// connected to externally or all nodes this network is connected to if [nodeIDs]
 	// is empty. Thread safety must be managed internally to the network.
@@ -111,6 +155,11 @@ type network struct {
 	// Unix time at which last message of any type sent over network
 	// Must only be accessed atomically
 	lastMsgSentTime int64
+	// Unix time at which the last inbound connection was upgraded
+	// Must only be accessed atomically
+	lastInboundConnTime int64
+	// Tracks peer disconnects and the connected stake for the health check
+	churn churnTracker
 	// Keeps track of the percentage of sends that fail
 	sendFailRateCalculator math.Averager
 	log                    logging.Logger
@@ -175,6 +224,24 @@ type network struct {
 	// TODO also remove from this map when the peer leaves the validator set
 	latestPeerIP map[ids.ShortID]signedPeerIP
 
+	// Resolves the hostnames of bootstrap beacons
+	hostResolver hostResolver
+	// IP (utils.IPDesc.String()) --> Beacon that is currently being dialed at
+	// that IP, for beacons specified by hostname.
+	// [stateLock] should be held when accessing this map.
+	beaconHostIPs map[string]*beaconHost
+	// Number of beacons specified by hostname whose hostname hasn't been
+	// resolved yet.
+	// [stateLock] should be held when accessing this field.
+	numUnresolvedBeacons int
+	// Set of IPs (ignoring the port) that bootstrap beacons are dialed at.
+	// [stateLock] should be held when accessing this map.
+	beaconIPs map[string]struct{}
+
+	// Decides which IPs we may connect to, and accept connections from.
+	// [stateLock] should be held when accessing this field.
+	acl *acl
+
 	// Node ID --> Function to execute to stop trying to dial the node.
 	// A node is present in this map if and only if we are actively
 	// trying to dial the node.
@@ -182,17 +249,37 @@ type network struct {
 
 	// Rate-limits incoming messages
 	inboundMsgThrottler         throttling.InboundMsgThrottler
+	inboundCPUThrottler         throttling.InboundCPUThrottler
 	inboundConnUpgradeThrottler throttling.InboundConnUpgradeThrottler
+	inboundConnGater            throttling.InboundConnGater
 
 	// Rate-limits outgoing messages
-	outboundMsgThrottler throttling.OutboundMsgThrottler
+	outboundMsgThrottler       throttling.OutboundMsgThrottler
+	outboundBandwidthThrottler throttling.OutboundBandwidthThrottler
+
+	// Logs records of the messages selected by the admin API
+	tracer messageTracer
 }
 
 type PeerListGossipConfig struct {
-	PeerListSize                 uint32        `json:"peerListSize"`
-	PeerListGossipSize           uint32        `json:"peerListGossipSize"`
-	PeerListStakerGossipFraction uint32        `json:"peerListStakerGossipFraction"`
-	PeerListGossipFreq           time.Duration `json:"peerListGossipFreq"`
+	// Max number of peers included in each PeerList message. Must be at most
+	// [MaxPeerListSize].
+	PeerListSize uint32 `json:"peerListSize"`
+	// Number of peers each PeerList message is gossiped to
+	PeerListGossipSize uint32 `json:"peerListGossipSize"`
+	// 1 of each [PeerListStakerGossipFraction] peers a PeerList message is
+	// gossiped to is a validator
+	PeerListStakerGossipFraction uint32 `json:"peerListStakerGossipFraction"`
+	// Frequency at which PeerList messages are gossiped
+	PeerListGossipFreq time.Duration `json:"peerListGossipFreq"`
+	// Number of gossiped IPs with invalid signatures after which the peer that
+	// gossiped them is disconnected from. If 0, peers are never disconnected
+	// from for gossiping invalid IPs.
+	PeerListMaxStrikes uint32 `json:"peerListMaxStrikes"`
+	// Max age of the timestamp of a gossiped IP for it to be tracked. A node
+	// only signs a new timestamp when its IP changes, so a small age drops the
+	// IPs of long running nodes. If 0, gossiped IPs are never too old.
+	PeerListMaxIPAge time.Duration `json:"peerListMaxIPAge"`
 }
 
 type TimeoutConfig struct {
@@ -208,6 +295,9 @@ type TimeoutConfig struct {
 type DelayConfig struct {
 	InitialReconnectDelay time.Duration `json:"initialReconnectDelay"`
 	MaxReconnectDelay     time.Duration `json:"maxReconnectDelay"`
+	// Max delay between attempts to connect to validators and bootstrap
+	// beacons. If <= 0, [MaxReconnectDelay] is used.
+	MaxValidatorReconnectDelay time.Duration `json:"maxValidatorReconnectDelay"`
 }
 
 type GossipConfig struct {
@@ -219,18 +309,25 @@ type GossipConfig struct {
 
 type ThrottlerConfig struct {
 	InboundConnUpgradeThrottlerConfig throttling.InboundConnUpgradeThrottlerConfig `json:"inboundConnUpgradeThrottlerConfig"`
+	InboundConnGaterConfig            throttling.InboundConnGaterConfig            `json:"inboundConnGaterConfig"`
 	InboundMsgThrottlerConfig         throttling.InboundMsgThrottlerConfig         `json:"inboundMsgThrottlerConfig"`
+	InboundCPUThrottlerConfig         throttling.InboundCPUThrottlerConfig         `json:"inboundCPUThrottlerConfig"`
 	OutboundMsgThrottlerConfig        throttling.MsgByteThrottlerConfig            `json:"outboundMsgThrottlerConfig"`
+	OutboundBandwidthThrottlerConfig  throttling.OutboundBandwidthThrottlerConfig  `json:"outboundBandwidthThrottlerConfig"`
 	MaxIncomingConnsPerSec            float64                                      `json:"maxIncomingConnsPerSec"`
 }
 
 type Config struct {
-	HealthConfig         `json:"healthConfig"`
-	PeerListGossipConfig `json:"peerListGossipConfig"`
-	GossipConfig         `json:"gossipConfig"`
-	TimeoutConfig        `json:"timeoutConfigs"`
-	DelayConfig          `json:"delayConfig"`
-	ThrottlerConfig      ThrottlerConfig `json:"throttlerConfig"`
+	HealthConfig          `json:"healthConfig"`
+	PeerListGossipConfig  `json:"peerListGossipConfig"`
+	GossipConfig          `json:"gossipConfig"`
+	TimeoutConfig         `json:"timeoutConfigs"`
+	DelayConfig           `json:"delayConfig"`
+	BeaconResolverConfig  `json:"beaconResolverConfig"`
+	ACLConfig             `json:"aclConfig"`
+	PeerPersistenceConfig `json:"peerPersistenceConfig"`
+	ProxyProtocolConfig   `json:"proxyProtocolConfig"`
+	ThrottlerConfig       ThrottlerConfig `json:"throttlerConfig"`
 
 	DialerConfig dialer.Config `json:"dialerConfig"`
 	TLSConfig    *tls.Config   `json:"-"`
@@ -243,6 +340,16 @@ type Config struct {
 	PingFrequency      time.Duration       `json:"pingFrequency"`
 	AllowPrivateIPs    bool                `json:"allowPrivateIPs"`
 	CompressionEnabled bool                `json:"compressionEnabled"`
+	// Op --> Max size, in bytes, of inbound messages of this op. Ops not in
+	// this map use their default max size. See message.DefaultMaxSizes.
+	MaxMessageSizes map[message.Op]int64 `json:"maxMessageSizes"`
+	// Number of messages exceeding the max size of their op after which the
+	// peer that sent them is disconnected from. If 0, peers are never
+	// disconnected from for sending oversized messages.
+	OversizedMsgMaxStrikes uint32 `json:"oversizedMsgMaxStrikes"`
+	// If true, messages that may be compressed with zstd are compressed with
+	// zstd when sent to peers that advertised they can decompress them
+	ZstdCompressionEnabled bool `json:"zstdCompressionEnabled"`
 	// This node's TLS key
 	TLSKey crypto.Signer `json:"-"`
 	// WhitelistedSubnets of the node
@@ -254,6 +361,14 @@ type Config struct {
 	UptimeMetricFreq  time.Duration      `json:"uptimeMetricFreq"`
 	UptimeRequirement float64            `json:"uptimeRequirement"`
 
+	// Tracks the CPU time spent handling messages from each node. If nil,
+	// inbound messages aren't throttled based on CPU time.
+	CPUTracker tracker.Utilizer `json:"-"`
+
+	// Database the IPs of peers are persisted to. If nil, peer IPs aren't
+	// persisted.
+	PeerDB database.Database `json:"-"`
+
 	// Require that all connections must have at least one validator between the
 	// 2 peers. This can be useful to enable if the node wants to connect to the
 	// minimum number of nodes without impacting the network negatively.
@@ -296,11 +411,20 @@ func NewNetwork(
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		benchlistManager:            benchlistManager,
 		latestPeerIP:                make(map[ids.ShortID]signedPeerIP),
+		hostResolver:                net.DefaultResolver,
+		beaconHostIPs:               make(map[string]*beaconHost),
+		beaconIPs:                   make(map[string]struct{}),
 		versionCompatibility:        version.GetCompatibility(config.NetworkID),
 		config:                      config,
 		mc:                          msgCreator,
 	}
 
+	acl, err := newACL(config.ACLConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initializing the network ACL failed with: %w", err)
+	}
+	netw.acl = acl
+
 	netw.serverUpgrader = NewTLSServerUpgrader(config.TLSConfig)
 	netw.clientUpgrader = NewTLSClientUpgrader(config.TLSConfig)
 
@@ -322,6 +446,20 @@ func NewNetwork(
 	}
 	netw.inboundMsgThrottler = inboundMsgThrottler
 
+	netw.inboundCPUThrottler = throttling.NewNoInboundCPUThrottler()
+	if config.CPUTracker != nil {
+		netw.inboundCPUThrottler, err = throttling.NewInboundCPUThrottler(
+			config.Namespace,
+			metricsRegisterer,
+			primaryNetworkValidators,
+			config.CPUTracker,
+			config.ThrottlerConfig.InboundCPUThrottlerConfig,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("initializing inbound CPU throttler failed with: %s", err)
+		}
+	}
+
 	outboundMsgThrottler, err := throttling.NewSybilOutboundMsgThrottler(
 		log,
 		config.Namespace,
@@ -334,8 +472,30 @@ func NewNetwork(
 	}
 	netw.outboundMsgThrottler = outboundMsgThrottler
 
+	outboundBandwidthThrottler, err := throttling.NewOutboundBandwidthThrottler(
+		config.Namespace,
+		metricsRegisterer,
+		config.ThrottlerConfig.OutboundBandwidthThrottlerConfig,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing outbound bandwidth throttler failed with: %s", err)
+	}
+	netw.outboundBandwidthThrottler = outboundBandwidthThrottler
+
+	inboundConnGater, err := throttling.NewInboundConnGater(
+		config.Namespace,
+		metricsRegisterer,
+		config.ThrottlerConfig.InboundConnGaterConfig,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing inbound connection gater failed with: %s", err)
+	}
+	netw.inboundConnGater = inboundConnGater
+
 	netw.peers.initialize()
 	netw.sendFailRateCalculator = math.NewSyncAverager(math.NewAverager(0, config.MaxSendFailRateHalflife, netw.clock.Time()))
+	netw.lastInboundConnTime = netw.clock.Time().Unix()
+	netw.churn.window = config.ChurnWindow
 	if err := netw.metrics.initialize(config.Namespace, metricsRegisterer); err != nil {
 		return nil, fmt.Errorf("initializing network failed with: %s", err)
 	}
@@ -426,9 +586,14 @@ func (n *network) selectPeersForGossip(subnetID ids.ID, validatorOnly bool, numV
 func (n *network) send(msg message.OutboundMessage, connectedOnly bool, peers []*peer) ids.ShortSet {
 	var (
 		now    = n.clock.Time()
-		msgLen = len(msg.Bytes())
 		sentTo = ids.NewShortSet(len(peers))
 		op     = msg.Op()
+
+		// [msg] compressed with zstd, for the peers that can decompress it.
+		// It is built at most once.
+		zstdMsg     message.OutboundMessage
+		zstdAllowed = n.config.ZstdCompressionEnabled && op.ZstdCompressable()
+		zstdFailed  bool
 	)
 
 	msgMetrics := n.metrics.messageMetrics[op]
@@ -444,22 +609,41 @@ func (n *network) send(msg message.OutboundMessage, connectedOnly bool, peers []
 	// send to peer and update metrics
 	// note: peer may be nil
 	for _, peer := range peers {
+		// Peers that didn't advertise that they can decompress zstd must
+		// never be sent zstd compressed messages.
+		peerMsg := msg
+		if zstdAllowed && !zstdFailed && peer != nil && peer.canDecompressZstd() {
+			if zstdMsg == nil {
+				var err error
+				zstdMsg, err = n.mc.Recompress(msg, compression.TypeZstd)
+				if err != nil {
+					n.log.Debug("failed to compress %s message with zstd: %s", op, err)
+					zstdFailed = true
+				}
+			}
+			if zstdMsg != nil {
+				peerMsg = zstdMsg
+			}
+		}
+
 		// Add a reference to the message so that if it is sent, it won't be
 		// collected until it is done being processed.
-		msg.AddRef()
+		peerMsg.AddRef()
 		if peer != nil &&
 			(!connectedOnly || peer.finishedHandshake.GetValue()) &&
 			!sentTo.Contains(peer.nodeID) &&
-			peer.Send(msg) {
+			peer.Send(peerMsg) {
 			sentTo.Add(peer.nodeID)
 
 			// record metrics for success
 			n.sendFailRateCalculator.Observe(0, now)
 			msgMetrics.numSent.Inc()
-			msgMetrics.sentBytes.Add(float64(msgLen))
-			if saved := msg.BytesSavedCompression(); saved != 0 {
+			if saved := peerMsg.BytesSavedCompression(); saved != 0 {
 				msgMetrics.savedSentBytes.Observe(float64(saved))
 			}
+			if peerMsg == zstdMsg {
+				n.metrics.zstdSavedSentBytes.Add(float64(zstdMsg.BytesSavedCompression()))
+			}
 		} else {
 			// record metrics for failure
 			n.sendFailRateCalculator.Observe(1, now)
@@ -467,12 +651,15 @@ func (n *network) send(msg message.OutboundMessage, connectedOnly bool, peers []
 
 			// The message wasn't passed to the peer, so we should remove the
 			// reference that was added.
-			msg.DecRef()
+			peerMsg.DecRef()
 		}
 	}
 
 	// The message has been passed to all peers that it will be sent to, so we
-	// can decrease the sender reference now.
+	// can decrease the sender references now.
+	if zstdMsg != nil {
+		zstdMsg.DecRef()
+	}
 	msg.DecRef()
 	return sentTo
 }
@@ -485,6 +672,11 @@ func (n *network) shouldUpgradeIncoming(ip utils.IPDesc) bool {
 	defer n.stateLock.RUnlock()
 
 	ipStr := ip.String()
+	if !n.aclAllows(ip) {
+		n.log.Debug("not upgrading connection to %s because it's denied by the network ACL", ipStr)
+		n.metrics.aclRejectedConns.Inc()
+		return false
+	}
 	if _, ok := n.connectedIPs[ipStr]; ok {
 		n.log.Debug("not upgrading connection to %s because it's connected", ipStr)
 		return false
@@ -497,9 +689,14 @@ func (n *network) shouldUpgradeIncoming(ip utils.IPDesc) bool {
 		n.log.Debug("not upgrading connection to %s because it's an alias", ipStr)
 		return false
 	}
+	if !n.inboundConnGater.Allow(ip, n.isValidatorIP(ip)) {
+		n.log.Debug("not upgrading connection to %s due to per-IP connection limits", ipStr)
+		return false
+	}
 	if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
 		n.log.Debug("not upgrading connection to %s due to rate-limiting", ipStr)
 		n.metrics.inboundConnRateLimited.Inc()
+		n.inboundConnGater.Release(ip)
 		return false
 	}
 	n.metrics.inboundConnAllowed.Inc()
@@ -510,6 +707,23 @@ func (n *network) shouldUpgradeIncoming(ip utils.IPDesc) bool {
 	return true
 }
 
+// isValidatorIP returns true if a primary network validator is known to be
+// at the same IP (ignoring the port) as [ip].
+// Assumes [n.stateLock] is held.
+func (n *network) isValidatorIP(ip utils.IPDesc) bool {
+	for _, peer := range n.peers.peersList {
+		if peer.getIP().IP.Equal(ip.IP) && n.config.Validators.Contains(constants.PrimaryNetworkID, peer.nodeID) {
+			return true
+		}
+	}
+	for nodeID, signedIP := range n.latestPeerIP {
+		if signedIP.ip.IP.Equal(ip.IP) && n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) {
+			return true
+		}
+	}
+	return false
+}
+
 // shouldHoldConnection returns true if this node should have a connection to
 // the provided peerID. If the node is attempting to connect to the minimum
 // number of peers, then it should only connect if this node is a validator, or
@@ -529,6 +743,10 @@ func (n *network) Dispatch() error {
 	go n.updateUptimeMetrics() // Periodically update uptime metrics
 	go n.inboundConnUpgradeThrottler.Dispatch()
 	defer n.inboundConnUpgradeThrottler.Stop()
+	if n.persistPeersEnabled() {
+		go n.dialPersistedPeers()       // Reconnect to peers from before a restart
+		go n.persistPeersPeriodically() // Periodically persist connected peers
+	}
 	go func() {
 		duration := time.Until(n.versionCompatibility.MaskTime())
 		time.Sleep(duration)
@@ -583,19 +801,27 @@ func (n *network) Dispatch() error {
 			continue
 		}
 
-		if conn, ok := conn.(*net.TCPConn); ok {
-			if err := conn.SetLinger(0); err != nil {
+		if tcpConn, ok := tcpConnOf(conn); ok {
+			if err := tcpConn.SetLinger(0); err != nil {
 				n.log.Warn("failed to set no linger due to: %s", err)
 			}
-			if err := conn.SetNoDelay(true); err != nil {
+			if err := tcpConn.SetNoDelay(true); err != nil {
 				n.log.Warn("failed to set socket nodelay due to: %s", err)
 			}
 		}
 
+		// Free this connection's slot in [n.inboundConnGater] once it's
+		// closed, whether or not the upgrade succeeds.
+		conn = throttling.NewGatedConn(conn, n.inboundConnGater, ip)
+
 		go func() {
-			if err := n.upgrade(newPeer(n, conn, utils.IPDesc{}), n.serverUpgrader); err != nil {
+			p := newPeer(n, conn, utils.IPDesc{})
+			p.inbound = true
+			if err := n.upgrade(p, n.serverUpgrader); err != nil {
 				n.log.Verbo("failed to upgrade connection: %s", err)
+				return
 			}
+			atomic.StoreInt64(&n.lastInboundConnTime, n.clock.Time().Unix())
 		}()
 	}
 }
@@ -606,34 +832,51 @@ func (n *network) Dispatch() error {
 // that have finished the handshake.
 // Assumes [n.stateLock] is not held.
 func (n *network) Peers(nodeIDs []ids.ShortID) []PeerInfo {
+	peers := n.connectedPeers(nodeIDs)
+
+	// [n.stateLock] isn't held while the peer info is built, because
+	// calculating a validator's uptime may grab the P-chain's lock.
+	infos := make([]PeerInfo, len(peers))
+	for i, peer := range peers {
+		infos[i] = n.NewPeerInfo(peer)
+	}
+	return infos
+}
+
+// Returns the peers with IDs [nodeIDs] that have finished the handshake. If
+// [nodeIDs] is empty, returns all peers that have finished the handshake.
+// Assumes [n.stateLock] is not held.
+func (n *network) connectedPeers(nodeIDs []ids.ShortID) []*peer {
 	n.stateLock.RLock()
 	defer n.stateLock.RUnlock()
 
-	if len(nodeIDs) == 0 { // Return info about all peers
-		peers := make([]PeerInfo, 0, n.peers.size())
+	if len(nodeIDs) == 0 { // Return all peers
+		peers := make([]*peer, 0, n.peers.size())
 		for _, peer := range n.peers.peersList {
 			if peer.finishedHandshake.GetValue() {
-				peers = append(peers, n.NewPeerInfo(peer))
+				peers = append(peers, peer)
 			}
 		}
 		return peers
 	}
 
-	peers := make([]PeerInfo, 0, len(nodeIDs))
-	for _, nodeID := range nodeIDs { // Return info about given peers
+	peers := make([]*peer, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs { // Return the given peers
 		if peer, ok := n.peers.getByID(nodeID); ok && peer.finishedHandshake.GetValue() {
-			peers = append(peers, n.NewPeerInfo(peer))
+			peers = append(peers, peer)
 		}
 	}
 	return peers
 }
 
+// NewPeerInfo returns the info about [peer] reported by the info API.
+// Assumes [n.stateLock] is not held.
 func (n *network) NewPeerInfo(peer *peer) PeerInfo {
 	publicIPStr := ""
 	if !peer.ip.IsZero() {
 		publicIPStr = peer.getIP().String()
 	}
-	return PeerInfo{
+	info := PeerInfo{
 		IP:             peer.conn.RemoteAddr().String(),
 		PublicIP:       publicIPStr,
 		ID:             peer.nodeID.PrefixedString(constants.NodeIDPrefix),
@@ -642,7 +885,33 @@ func (n *network) NewPeerInfo(peer *peer) PeerInfo {
 		LastReceived:   time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
 		Benched:        n.benchlistManager.GetBenched(peer.nodeID),
 		ObservedUptime: json.Uint8(peer.observedUptime),
+		TrackedSubnets: peer.trackedSubnets.List(),
+		BytesSent:      json.Uint64(atomic.LoadUint64(&peer.bytesSent)),
+		BytesReceived:  json.Uint64(atomic.LoadUint64(&peer.bytesReceived)),
+		Direction:      outboundDirection,
+	}
+	if peer.inbound {
+		info.Direction = inboundDirection
 	}
+	if rtt := peer.getSmoothedRTT(); rtt != 0 {
+		info.SmoothedRTT = rtt.String()
+	}
+	if n.config.Validators.Contains(constants.PrimaryNetworkID, peer.nodeID) {
+		uptimePercent, err := n.config.UptimeCalculator.CalculateUptimePercent(peer.nodeID)
+		if err != nil {
+			n.log.Debug("failed to calculate the uptime of %s%s: %s", constants.NodeIDPrefix, peer.nodeID, err)
+		} else {
+			uptime := json.Uint8(gomath.Floor(uptimePercent * 100))
+			info.Uptime = &uptime
+		}
+	}
+	if op, ok := peer.lastSentOp.GetValue().(message.Op); ok {
+		info.LastMessageSent = op.String()
+	}
+	if op, ok := peer.lastReceivedOp.GetValue().(message.Op); ok {
+		info.LastMessageReceived = op.String()
+	}
+	return info
 }
 
 // Close implements the Network interface
@@ -683,7 +952,11 @@ func (n *network) close() {
 // TrackIP implements the Network interface
 // Assumes [n.stateLock] is not held.
 func (n *network) TrackIP(ip utils.IPDesc) {
-	n.Track(ip, ids.ShortEmpty)
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	n.beaconIPs[ip.IP.String()] = struct{}{}
+	n.track(ip, ids.ShortEmpty)
 }
 
 // Track implements the Network interface
@@ -852,7 +1125,11 @@ func (n *network) connectTo(ip utils.IPDesc, nodeID ids.ShortID) {
 	n.stateLock.RUnlock()
 
 	for {
-		time.Sleep(delay)
+		if delay > 0 {
+			n.metrics.targetsInBackoff.Inc()
+			time.Sleep(delay)
+			n.metrics.targetsInBackoff.Dec()
+		}
 
 		if delay == 0 {
 			delay = n.config.InitialReconnectDelay
@@ -862,9 +1139,9 @@ func (n *network) connectTo(ip utils.IPDesc, nodeID ids.ShortID) {
 		// attempts to a node that previously shut down. This doesn't require
 		// cryptographically secure random number generation.
 		delay = time.Duration(float64(delay) * (1 + rand.Float64())) // #nosec G404
-		if delay > n.config.MaxReconnectDelay {
-			// set the timeout to [.75, 1) * maxReconnectDelay
-			delay = time.Duration(float64(n.config.MaxReconnectDelay) * (3 + rand.Float64()) / 4) // #nosec G404
+		if maxDelay := n.maxReconnectDelay(nodeID); delay > maxDelay {
+			// set the timeout to [.75, 1) * maxDelay
+			delay = time.Duration(float64(maxDelay) * (3 + rand.Float64()) / 4) // #nosec G404
 		}
 
 		n.stateLock.Lock()
@@ -922,6 +1199,7 @@ func (n *network) connectTo(ip utils.IPDesc, nodeID ids.ShortID) {
 		cancel() // to avoid goroutine leak
 
 		n.connAttempts.Delete(nodeID)
+		n.beaconConnectAttempted(ip, err == nil)
 
 		if err == nil {
 			return
@@ -931,6 +1209,19 @@ func (n *network) connectTo(ip utils.IPDesc, nodeID ids.ShortID) {
 	}
 }
 
+// Returns the max delay between attempts to connect to [nodeID]. Validators,
+// and bootstrap beacons (whose node ID is empty), have a lower max delay
+// because we're required to connect to them.
+func (n *network) maxReconnectDelay(nodeID ids.ShortID) time.Duration {
+	if n.config.MaxValidatorReconnectDelay <= 0 {
+		return n.config.MaxReconnectDelay
+	}
+	if nodeID == ids.ShortEmpty || n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) {
+		return n.config.MaxValidatorReconnectDelay
+	}
+	return n.config.MaxReconnectDelay
+}
+
 // Attempt to connect to the peer at [ip].
 // If [ctx] is canceled, stops trying to connect.
 // Returns nil if:
@@ -939,7 +1230,16 @@ func (n *network) connectTo(ip utils.IPDesc, nodeID ids.ShortID) {
 // * [ctx] is canceled.
 // Assumes [n.stateLock] is not held when this method is called.
 func (n *network) attemptConnect(ctx context.Context, ip utils.IPDesc) error {
+	n.stateLock.RLock()
+	allowed := n.aclAllows(ip)
+	n.stateLock.RUnlock()
+	if !allowed {
+		n.metrics.aclRejectedConns.Inc()
+		return errDeniedByACL
+	}
+
 	n.log.Verbo("attempting to connect to %s", ip)
+	n.metrics.dialAttempts.Inc()
 	conn, err := n.dialer.Dial(ctx, ip)
 	if err != nil {
 		// If [ctx] was canceled, return nil so we don't try to connect again
@@ -947,6 +1247,7 @@ func (n *network) attemptConnect(ctx context.Context, ip utils.IPDesc) error {
 			return nil
 		}
 		// Error wasn't because connection attempt was canceled
+		n.metrics.dialFailures.WithLabelValues(dialFailureReason(err)).Inc()
 		return err
 	}
 
@@ -958,7 +1259,11 @@ func (n *network) attemptConnect(ctx context.Context, ip utils.IPDesc) error {
 			n.log.Warn("failed to set socket nodelay due to: %s", err)
 		}
 	}
-	return n.upgrade(newPeer(n, conn, ip), n.clientUpgrader)
+	if err := n.upgrade(newPeer(n, conn, ip), n.clientUpgrader); err != nil {
+		n.metrics.dialFailures.WithLabelValues(dialFailureTLS).Inc()
+		return err
+	}
+	return nil
 }
 
 // Assumes [n.stateLock] is not held. Returns an error if the peer's connection
@@ -1048,13 +1353,14 @@ func (n *network) tryAddPeer(p *peer) error {
 	}
 
 	n.peers.add(p)
-	n.metrics.numPeers.Set(float64(n.peers.size()))
+	n.updatePeerCountMetrics()
 	p.Start()
 	return nil
 }
 
-// Returns the IPs, certs and signatures of validators we're connected
-// to that have finished the handshake.
+// Returns the IPs, certs and signatures of up to [PeerListSize] validators
+// we're connected to that have finished the handshake. Non-validator IPs aren't
+// included because peers only track the gossiped IPs of validators and beacons.
 // Assumes [n.stateLock] is not held.
 func (n *network) validatorIPs() ([]utils.IPCertDesc, error) {
 	n.stateLock.RLock()
@@ -1167,6 +1473,9 @@ func (n *network) connected(p *peer) {
 		n.connectedIPs[str] = struct{}{}
 	}
 
+	n.updateVersionMetrics()
+	n.churn.observeStake(n.connectedStake(), n.clock.Time())
+
 	n.router.Connected(p.nodeID)
 	n.metrics.connected.Inc()
 }
@@ -1181,7 +1490,7 @@ func (n *network) disconnected(p *peer) {
 	n.log.Debug("disconnected from %s at %s", p.nodeID, ip)
 
 	n.peers.remove(p)
-	n.metrics.numPeers.Set(float64(n.peers.size()))
+	n.updatePeerCountMetrics()
 
 	p.releaseAllAliases()
 
@@ -1198,11 +1507,78 @@ func (n *network) disconnected(p *peer) {
 
 	// Only send Disconnected to router if Connected was sent
 	if p.finishedHandshake.GetValue() {
+		n.updateVersionMetrics()
+		now := n.clock.Time()
+		n.churn.observeDisconnect(now)
+		n.churn.observeStake(n.connectedStake(), now)
 		n.router.Disconnected(p.nodeID)
 	}
 	n.metrics.disconnected.Inc()
 }
 
+// Assumes [n.stateLock] is held.
+func (n *network) updatePeerCountMetrics() {
+	numPeers := n.peers.size()
+	numInbound := n.peers.inboundSize()
+	n.metrics.numPeers.Set(float64(numPeers))
+	n.metrics.numInboundPeers.Set(float64(numInbound))
+	n.metrics.numOutboundPeers.Set(float64(numPeers - numInbound))
+}
+
+// Recomputes the number of connected peers, and the primary network stake of
+// the connected peers, that advertised each version. Versions are normalized
+// to major.minor.patch to bound the number of label values.
+// A peer's version is only set once, before it finishes the handshake, so the
+// metrics only change when a peer connects or disconnects.
+// Assumes [n.stateLock] is held.
+func (n *network) updateVersionMetrics() {
+	primaryValidators, _ := n.config.Validators.GetValidators(constants.PrimaryNetworkID)
+
+	numPeers := make(map[string]int)
+	stake := make(map[string]uint64)
+	for _, peer := range n.peers.peersList {
+		if !peer.finishedHandshake.GetValue() {
+			continue
+		}
+		peerVersion, ok := peer.versionStruct.GetValue().(version.Application)
+		if !ok {
+			continue
+		}
+		versionLabel := fmt.Sprintf("%d.%d.%d", peerVersion.Major(), peerVersion.Minor(), peerVersion.Patch())
+		numPeers[versionLabel]++
+		if primaryValidators != nil {
+			weight, _ := primaryValidators.GetWeight(peer.nodeID)
+			stake[versionLabel] += weight
+		}
+	}
+
+	n.metrics.peersByVersion.Reset()
+	n.metrics.stakeByVersion.Reset()
+	for versionLabel, num := range numPeers {
+		n.metrics.peersByVersion.WithLabelValues(versionLabel).Set(float64(num))
+		n.metrics.stakeByVersion.WithLabelValues(versionLabel).Set(float64(stake[versionLabel]))
+	}
+}
+
+// Returns the primary network stake of the peers that finished the handshake.
+// Assumes [n.stateLock] is held.
+func (n *network) connectedStake() uint64 {
+	primaryValidators, ok := n.config.Validators.GetValidators(constants.PrimaryNetworkID)
+	if !ok {
+		return 0
+	}
+
+	stake := uint64(0)
+	for _, peer := range n.peers.peersList {
+		if !peer.finishedHandshake.GetValue() {
+			continue
+		}
+		weight, _ := primaryValidators.GetWeight(peer.nodeID)
+		stake += weight
+	}
+	return stake
+}
+
 // Safe copy the peers dressed as a peerElement
 // Assumes [n.stateLock] is not held.
 func (n *network) getPeerElements(nodeIDs ids.ShortSet) []*peerElement {
@@ -1268,6 +1644,8 @@ func (n *network) HealthCheck() (interface{}, error) {
 		}
 	}
 	sendFailRate := n.sendFailRateCalculator.Read()
+	connectedStake := n.connectedStake()
+	numInbound := n.peers.inboundSize()
 	n.stateLock.RUnlock()
 
 	// Make sure we're connected to at least the minimum number of peers
@@ -1301,6 +1679,36 @@ func (n *network) HealthCheck() (interface{}, error) {
 	details["sendFailRate"] = sendFailRate
 	n.metrics.sendFailRate.Set(sendFailRate)
 
+	// Make sure peers aren't disconnecting too often, and the connected stake
+	// hasn't dropped too much, within the churn window
+	isChurnOK, isStakeOK := true, true
+	disconnects, maxStake, stakeDrop := 0, uint64(0), 0.0
+	if n.config.HealthConfig.ChurnWindow > 0 {
+		disconnects, maxStake = n.churn.read(now)
+		if maxStake > connectedStake {
+			stakeDrop = float64(maxStake-connectedStake) / float64(maxStake)
+		}
+		maxDisconnects := n.config.HealthConfig.MaxDisconnectsInWindow
+		isChurnOK = maxDisconnects == 0 || disconnects <= int(maxDisconnects)
+		maxStakeDrop := n.config.HealthConfig.MaxConnectedStakeDrop
+		isStakeOK = maxStakeDrop == 0 || stakeDrop <= maxStakeDrop
+		healthy = healthy && isChurnOK && isStakeOK
+		details["disconnectsInWindow"] = disconnects
+		details["connectedStake"] = connectedStake
+		details["maxConnectedStakeInWindow"] = maxStake
+		details["connectedStakeDrop"] = stakeDrop
+	}
+
+	// Make sure we've accepted an inbound connection within the threshold
+	lastInboundConnAt := time.Unix(atomic.LoadInt64(&n.lastInboundConnTime), 0)
+	timeSinceInboundConn := now.Sub(lastInboundConnAt)
+	maxTimeSinceInboundConn := n.config.HealthConfig.MaxTimeSinceInboundConn
+	// A node that currently has an inbound peer is reachable
+	isInboundConn := maxTimeSinceInboundConn == 0 || numInbound > 0 || timeSinceInboundConn <= maxTimeSinceInboundConn
+	healthy = healthy && isInboundConn
+	details["inboundPeers"] = numInbound
+	details["timeSinceLastInboundConn"] = timeSinceInboundConn.String()
+
 	// Network layer is unhealthy
 	if !healthy {
 		var errorReasons []string
@@ -1316,6 +1724,15 @@ func (n *network) HealthCheck() (interface{}, error) {
 		if !isMsgFailRate {
 			errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, n.config.HealthConfig.MaxSendFailRate))
 		}
+		if !isChurnOK {
+			errorReasons = append(errorReasons, fmt.Sprintf("%d peer(s) disconnected in %s > %d", disconnects, n.config.HealthConfig.ChurnWindow, n.config.HealthConfig.MaxDisconnectsInWindow))
+		}
+		if !isStakeOK {
+			errorReasons = append(errorReasons, fmt.Sprintf("connected stake dropped from %d to %d in %s, a drop of %g > %g", maxStake, connectedStake, n.config.HealthConfig.ChurnWindow, stakeDrop, n.config.HealthConfig.MaxConnectedStakeDrop))
+		}
+		if !isInboundConn {
+			errorReasons = append(errorReasons, fmt.Sprintf("no inbound connections accepted in %s > %s", timeSinceInboundConn, maxTimeSinceInboundConn))
+		}
 
 		return details, fmt.Errorf("network layer is unhealthy reason: %s", strings.Join(errorReasons, ", "))
 	}
@@ -11,11 +11,14 @@ import (
 	"errors"
 	"math"
 	"net"
+	"os"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/assert"
 
@@ -320,13 +323,13 @@ func initCerts(t *testing.T) {
 	var err error
 	cert0, err = staking.NewTLSCert()
 	assert.NoError(t, err)
-	tlsConfig0 = TLSConfig(*cert0)
+	tlsConfig0 = TLSConfig(*cert0, TLSOptions{})
 	cert1, err = staking.NewTLSCert()
 	assert.NoError(t, err)
-	tlsConfig1 = TLSConfig(*cert1)
+	tlsConfig1 = TLSConfig(*cert1, TLSOptions{})
 	cert2, err = staking.NewTLSCert()
 	assert.NoError(t, err)
-	tlsConfig2 = TLSConfig(*cert2)
+	tlsConfig2 = TLSConfig(*cert2, TLSOptions{})
 }
 
 var (
@@ -355,7 +358,7 @@ func TestNewDefaultNetwork(t *testing.T) {
 	vdrs := getDefaultManager()
 	beacons := validators.NewSet()
 	metrics := prometheus.NewRegistry()
-	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler := &testHandler{}
 	net, err := newDefaultNetwork(
@@ -442,7 +445,7 @@ func TestEstablishConnection(t *testing.T) {
 	wg1.Add(1)
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -453,7 +456,7 @@ func TestEstablishConnection(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -579,7 +582,7 @@ func TestDoubleTrack(t *testing.T) {
 	wg1.Add(1)
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -590,7 +593,7 @@ func TestDoubleTrack(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -717,7 +720,7 @@ func TestDoubleClose(t *testing.T) {
 	wg1.Add(1)
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -728,7 +731,7 @@ func TestDoubleClose(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -860,7 +863,7 @@ func TestTrackConnected(t *testing.T) {
 	wg1.Add(1)
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -871,7 +874,7 @@ func TestTrackConnected(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -991,11 +994,11 @@ func TestTrackConnectedRace(t *testing.T) {
 	vdrs := getDefaultManager()
 	beacons := validators.NewSet()
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 
 	handler := &testHandler{}
@@ -1178,7 +1181,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 	wg2.Add(2)
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1199,7 +1202,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1216,7 +1219,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 	}
 
 	metrics2 := prometheus.NewRegistry()
-	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler2 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1229,7 +1232,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 	}
 
 	metrics3 := prometheus.NewRegistry()
-	msgCreator3, err := message.NewCreator(metrics3, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator3, err := message.NewCreator(metrics3, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler3 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1567,7 +1570,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 	wg3.Add(2)
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1599,7 +1602,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1616,7 +1619,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 	}
 
 	metrics2 := prometheus.NewRegistry()
-	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler2 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1629,7 +1632,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 	}
 
 	metrics3 := prometheus.NewRegistry()
-	msgCreator3, err := message.NewCreator(metrics3, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator3, err := message.NewCreator(metrics3, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler3 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1936,7 +1939,7 @@ func TestPeerSignature(t *testing.T) {
 	handled := make(map[string]struct{})
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1950,7 +1953,7 @@ func TestPeerSignature(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -1964,7 +1967,7 @@ func TestPeerSignature(t *testing.T) {
 	}
 
 	metrics2 := prometheus.NewRegistry()
-	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler2 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -2285,6 +2288,106 @@ func TestValidatorIPs(t *testing.T) {
 	assert.True(t, len(IPs) == int(dummyNetwork.config.PeerListSize))
 }
 
+// Test that the connected peers, and their stake, are grouped by normalized
+// version
+func TestVersionMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	netConfig := newDefaultConfig()
+	netConfig.Validators = getDefaultManager()
+	dummyNetwork := network{config: &netConfig}
+	dummyNetwork.peers.initialize()
+	assert.NoError(dummyNetwork.metrics.initialize("", prometheus.NewRegistry()))
+
+	v1 := version.NewDefaultApplication("avalanche", 1, 2, 3)
+	v1OtherApp := version.NewDefaultApplication("other", 1, 2, 3)
+	v2 := version.NewDefaultApplication("avalanche", 1, 3, 0)
+
+	validator1 := createPeer(ids.ShortID{0x01}, utils.IPDesc{IP: net.IPv4(172, 17, 0, 1), Port: 1}, v1)
+	nonValidator1 := createPeer(ids.ShortID{0x02}, utils.IPDesc{IP: net.IPv4(172, 17, 0, 2), Port: 2}, v1OtherApp)
+	validator2 := createPeer(ids.ShortID{0x03}, utils.IPDesc{IP: net.IPv4(172, 17, 0, 3), Port: 3}, v2)
+	handshakingPeer := createPeer(ids.ShortID{0x04}, utils.IPDesc{IP: net.IPv4(172, 17, 0, 4), Port: 4}, v2)
+	handshakingPeer.finishedHandshake.SetValue(false)
+	addPeerToNetwork(&dummyNetwork, validator1, true)
+	addPeerToNetwork(&dummyNetwork, nonValidator1, false)
+	addPeerToNetwork(&dummyNetwork, validator2, true)
+	addPeerToNetwork(&dummyNetwork, handshakingPeer, true)
+
+	dummyNetwork.updateVersionMetrics()
+	assert.Equal(2.0, testutil.ToFloat64(dummyNetwork.metrics.peersByVersion.WithLabelValues("1.2.3")))
+	assert.Equal(10.0, testutil.ToFloat64(dummyNetwork.metrics.stakeByVersion.WithLabelValues("1.2.3")))
+	assert.Equal(1.0, testutil.ToFloat64(dummyNetwork.metrics.peersByVersion.WithLabelValues("1.3.0")))
+	assert.Equal(10.0, testutil.ToFloat64(dummyNetwork.metrics.stakeByVersion.WithLabelValues("1.3.0")))
+
+	// Versions with no connected peers are removed
+	dummyNetwork.peers.remove(validator2)
+	dummyNetwork.updateVersionMetrics()
+	assert.Equal(1, testutil.CollectAndCount(dummyNetwork.metrics.peersByVersion))
+	assert.Equal(1, testutil.CollectAndCount(dummyNetwork.metrics.stakeByVersion))
+}
+
+func TestDialFailureReason(t *testing.T) {
+	refused := &net.OpError{
+		Op:  "dial",
+		Net: "tcp",
+		Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+	}
+	assert.Equal(t, dialFailureRefused, dialFailureReason(refused))
+	assert.Equal(t, dialFailureTimeout, dialFailureReason(context.DeadlineExceeded))
+	assert.Equal(t, dialFailureTimeout, dialFailureReason(os.ErrDeadlineExceeded))
+	assert.Equal(t, dialFailureOther, dialFailureReason(errRefused))
+}
+
+// Test that validators and beacons have a lower max reconnect delay
+func TestMaxReconnectDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	netConfig := newDefaultConfig()
+	netConfig.Validators = getDefaultManager()
+	netConfig.MaxReconnectDelay = time.Hour
+	dummyNetwork := network{config: &netConfig}
+
+	validatorID := ids.GenerateTestShortID()
+	assert.NoError(netConfig.Validators.AddWeight(constants.PrimaryNetworkID, validatorID, 1))
+	nonValidatorID := ids.GenerateTestShortID()
+
+	// If unset, the validator max delay defaults to the max delay
+	assert.Equal(time.Hour, dummyNetwork.maxReconnectDelay(validatorID))
+
+	netConfig.MaxValidatorReconnectDelay = time.Minute
+	assert.Equal(time.Minute, dummyNetwork.maxReconnectDelay(validatorID))
+	assert.Equal(time.Minute, dummyNetwork.maxReconnectDelay(ids.ShortEmpty))
+	assert.Equal(time.Hour, dummyNetwork.maxReconnectDelay(nonValidatorID))
+}
+
+// Test that failed connection attempts are retried, and reported in the dial
+// metrics
+func TestConnectToDialMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	dialer := &refusingDialer{dialed: make(map[string]int)}
+	n := newBeaconResolverTestNetwork(t, nil, dialer)
+
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	n.stateLock.Lock()
+	n.track(ip, ids.GenerateTestShortID())
+	n.stateLock.Unlock()
+
+	assert.Eventually(func() bool {
+		return dialer.numDialed(ip) >= 3
+	}, time.Second, time.Millisecond)
+	assert.GreaterOrEqual(testutil.ToFloat64(n.metrics.dialAttempts), 3.0)
+	assert.GreaterOrEqual(testutil.ToFloat64(n.metrics.dialFailures.WithLabelValues(dialFailureOther)), 3.0)
+
+	// Once the IP is no longer tracked, it's no longer in backoff
+	n.stateLock.Lock()
+	delete(n.disconnectedIPs, ip.String())
+	n.stateLock.Unlock()
+	assert.Eventually(func() bool {
+		return testutil.ToFloat64(n.metrics.targetsInBackoff) == 0
+	}, time.Second, time.Millisecond)
+}
+
 // Test that a node will not finish the handshake if the peer's version
 // is incompatible
 func TestDontFinishHandshakeOnIncompatibleVersion(t *testing.T) {
@@ -2350,7 +2453,7 @@ func TestDontFinishHandshakeOnIncompatibleVersion(t *testing.T) {
 	assert.NoError(t, vdrs.AddWeight(constants.PrimaryNetworkID, id0, 1))
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	net0Compatibility := version.NewCompatibility(
 		net0Version,
@@ -2363,7 +2466,7 @@ func TestDontFinishHandshakeOnIncompatibleVersion(t *testing.T) {
 	)
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	net1Compatibility := version.NewCompatibility(
 		net1Version,
@@ -2497,7 +2600,7 @@ func TestPeerTrackedSubnets(t *testing.T) {
 	wg1.Add(1)
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -2507,7 +2610,7 @@ func TestPeerTrackedSubnets(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -2686,7 +2789,7 @@ func TestPeerGossip(t *testing.T) {
 	allContainerIDs := []ids.ID{testSubnetContainerID, testPrimaryContainerID}
 
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -2699,7 +2802,7 @@ func TestPeerGossip(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -2713,7 +2816,7 @@ func TestPeerGossip(t *testing.T) {
 	}
 
 	metrics2 := prometheus.NewRegistry()
-	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler2 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -2924,7 +3027,7 @@ func TestAppGossip(t *testing.T) {
 	testAppGossipBytes := []byte("appgossip")
 	testAppGossipSpecificBytes := []byte("appgossipspecific")
 	metrics0 := prometheus.NewRegistry()
-	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator0, err := message.NewCreator(metrics0, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler0 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -2940,7 +3043,7 @@ func TestAppGossip(t *testing.T) {
 	}
 
 	metrics1 := prometheus.NewRegistry()
-	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator1, err := message.NewCreator(metrics1, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler1 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -2957,7 +3060,7 @@ func TestAppGossip(t *testing.T) {
 	}
 
 	metrics2 := prometheus.NewRegistry()
-	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator2, err := message.NewCreator(metrics2, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler2 := &testHandler{
 		ConnectedF: func(id ids.ShortID) {
@@ -3206,3 +3309,63 @@ func newDefaultConfig() Config {
 		UptimeMetricFreq:   30 * time.Second,
 	}
 }
+
+// Ensure that the peer info reports the subnets the peer tracks, and our
+// observed uptime of the peer only if it's a validator.
+func TestNewPeerInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	validatorID := ids.GenerateTestShortID()
+	state := uptime.NewTestState()
+	state.AddNode(validatorID, now.Add(-100*time.Second))
+	assert.NoError(state.SetUptime(validatorID, 50*time.Second, now))
+	uptimeManager := uptime.NewManager(state).(uptime.TestManager)
+	uptimeManager.SetTime(now)
+
+	n := &network{
+		log:              logging.NoLog{},
+		benchlistManager: benchlist.NewManager(&benchlist.Config{}),
+		config: &Config{
+			Validators:       getDefaultManager(),
+			UptimeCalculator: uptimeManager,
+		},
+	}
+	n.peers.initialize()
+
+	subnetID := ids.GenerateTestID()
+	newPeer := func(nodeID ids.ShortID, port uint16) *peer {
+		ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: port}
+		p := createPeer(nodeID, ip, version.CurrentApp)
+		p.conn = &testConn{
+			remote: &net.TCPAddr{IP: ip.IP, Port: int(ip.Port)},
+			closed: make(chan struct{}),
+		}
+		p.versionStr.SetValue(version.CurrentApp.String())
+		p.trackedSubnets.Add(constants.PrimaryNetworkID, subnetID)
+		return p
+	}
+	validator := newPeer(validatorID, 1)
+	nonValidator := newPeer(ids.GenerateTestShortID(), 2)
+	addPeerToNetwork(n, validator, true)
+	addPeerToNetwork(n, nonValidator, false)
+
+	peers := n.Peers([]ids.ShortID{validatorID})
+	assert.Len(peers, 1)
+	info := peers[0]
+	assert.Equal(validatorID.PrefixedString(constants.NodeIDPrefix), info.ID)
+	assert.ElementsMatch([]ids.ID{constants.PrimaryNetworkID, subnetID}, info.TrackedSubnets)
+	if assert.NotNil(info.Uptime) {
+		assert.EqualValues(50, *info.Uptime)
+	}
+
+	info = n.NewPeerInfo(nonValidator)
+	assert.ElementsMatch([]ids.ID{constants.PrimaryNetworkID, subnetID}, info.TrackedSubnets)
+	assert.Nil(info.Uptime)
+	assert.Equal(outboundDirection, info.Direction)
+
+	nonValidator.inbound = true
+	assert.Equal(inboundDirection, n.NewPeerInfo(nonValidator).Direction)
+
+	assert.Len(n.Peers(nil), 2)
+}
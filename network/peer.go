@@ -8,8 +8,10 @@ import (
 	"bytes"
 	"crypto/x509"
 	"encoding/binary"
+	"errors"
 	"io"
 	"math"
+	"math/rand"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -17,6 +19,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -44,6 +47,28 @@ type alias struct {
 	expiry time.Time
 }
 
+// Optional protocol features that a node advertises in the Capabilities
+// message it sends right after its Version message. Peers that don't send a
+// Capabilities message support none of them.
+const (
+	// The node can decompress messages compressed with zstd
+	zstdCapability uint64 = 1 << iota
+	// The node answers NoncePing messages with NoncePong messages
+	noncePingCapability
+)
+
+// localCapabilities are the capabilities this node advertises to its peers
+const localCapabilities = zstdCapability | noncePingCapability
+
+// queuedMessage is a message waiting to be sent to a peer
+type queuedMessage struct {
+	msg message.OutboundMessage
+
+	// enqueuedAt is when the message was queued. It's only set while messages
+	// are being traced.
+	enqueuedAt time.Time
+}
+
 type peer struct {
 	net *network // network this peer is part of
 
@@ -78,13 +103,22 @@ type peer struct {
 	closed utils.AtomicBool
 
 	// queue of messages to be sent to this peer
-	sendQueue []message.OutboundMessage
+	sendQueue []queuedMessage
+
+	// queue of handshake and ping messages to be sent to this peer. These
+	// messages are written by their own goroutine, so they never wait for
+	// outbound bandwidth behind the messages in [sendQueue].
+	prioritySendQueue []queuedMessage
 
-	// Signalled when a message is added to [sendQueue],
+	// Broadcast when a message is added to [sendQueue] or [prioritySendQueue],
 	// and when [p.closed] is set to true.
-	// [sendQueueCond.L] must be held when using [sendQueue].
+	// [sendQueueCond.L] must be held when using [sendQueue] or
+	// [prioritySendQueue].
 	sendQueueCond *sync.Cond
 
+	// writeLock must be held when writing a message to [conn]
+	writeLock sync.Mutex
+
 	// ip may or may not be set when the peer is first started. is only modified
 	// on the connection's reader routine.
 	ip utils.IPDesc
@@ -105,6 +139,9 @@ type peer struct {
 	// node ID of this peer.
 	nodeID ids.ShortID
 
+	// True if this peer connected to us, false if we connected to this peer
+	inbound bool
+
 	// the connection object that is used to read/write messages from
 	conn net.Conn
 
@@ -112,10 +149,22 @@ type peer struct {
 	// Set when we process the Version message from this peer.
 	versionStruct, versionStr utils.AtomicInterface
 
+	// Capabilities that this peer advertised during the handshake.
+	// Must only be accessed atomically
+	capabilities uint64
+
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	lastSent, lastReceived int64
 
+	// Number of message bytes sent to and received from this peer
+	// respectively. Must only be accessed atomically
+	bytesSent, bytesReceived uint64
+
+	// Op of the last message sent to and received from this peer
+	// respectively. Unset until a message has been sent/received.
+	lastSentOp, lastReceivedOp utils.AtomicInterface
+
 	tickerCloser chan struct{}
 
 	// ticker processes
@@ -135,6 +184,27 @@ type peer struct {
 
 	// observedUptime is the uptime of this node in peer's point of view
 	observedUptime uint8
+
+	// Number of gossiped IPs this peer sent us with invalid signatures.
+	// Only accessed when handling PeerList messages.
+	peerListStrikes uint32
+
+	// Number of messages this peer sent us that exceeded the max size of
+	// their op. Only accessed when reading messages.
+	oversizedStrikes uint32
+
+	// pingLock must be held when accessing [pingNonce], [pingSentAt],
+	// [pingOutstanding] or [smoothedRTT].
+	pingLock sync.Mutex
+	// Nonce of the last NoncePing sent to this peer, and when it was sent.
+	// [pingSentAt] has a monotonic clock reading.
+	pingNonce  uint64
+	pingSentAt time.Time
+	// True if the last NoncePing sent to this peer hasn't been answered
+	pingOutstanding bool
+	// Exponentially weighted moving average of the round trip times of the
+	// NoncePings sent to this peer. 0 if no round trip has been measured.
+	smoothedRTT time.Duration
 }
 
 // newPeer returns a properly initialized *peer.
@@ -265,16 +335,35 @@ func (p *peer) ReadMessages() {
 			return
 		}
 
+		// Peek at the op of the message, which is its first byte, so that
+		// the message can be throttled according to its type.
+		var op message.Op
+		if msgLen > 0 {
+			opBytes, err := reader.Peek(wrappers.ByteLen)
+			if err != nil {
+				p.net.log.Verbo("error reading from %s%s at %s: %s", constants.NodeIDPrefix, p.nodeID, p.getIP(), err)
+				return
+			}
+			op = message.Op(opBytes[0])
+		}
+
 		// Wait until the throttler says we can proceed to read the message.
 		// Note that when we are done handling this message, or give up
 		// trying to read it, we must call [p.net.msgThrottler.Release]
 		// to give back the bytes used by this message.
-		p.net.inboundMsgThrottler.Acquire(uint64(msgLen), p.nodeID)
+		if !p.net.inboundMsgThrottler.Acquire(op, uint64(msgLen), p.nodeID) {
+			p.net.log.Debug("dropping %s message from %s%s at %s due to app message throttling", op, constants.NodeIDPrefix, p.nodeID, p.getIP())
+			if _, err := reader.Discard(int(msgLen)); err != nil {
+				p.net.log.Verbo("error reading from %s%s at %s: %s", constants.NodeIDPrefix, p.nodeID, p.getIP(), err)
+				return
+			}
+			continue
+		}
 
 		// Invariant: When done processing this message, onFinishedHandling() is called.
 		// If this is not honored, the message throttler will leak until no new messages can be read.
 		// You can look at message throttler metrics to verify that there is no leak.
-		onFinishedHandling := func() { p.net.inboundMsgThrottler.Release(uint64(msgLen), p.nodeID) }
+		onFinishedHandling := func() { p.net.inboundMsgThrottler.Release(op, uint64(msgLen), p.nodeID) }
 
 		// Time out and close connection if we can't read message
 		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
@@ -290,6 +379,10 @@ func (p *peer) ReadMessages() {
 			onFinishedHandling()
 			return
 		}
+		var receivedAt time.Time
+		if p.net.tracer.isEnabled() {
+			receivedAt = time.Now()
+		}
 
 		p.net.log.Verbo("parsing message from %s%s at %s:\n%s", constants.NodeIDPrefix, p.nodeID, p.getIP(), formatting.DumpBytes(msgBytes))
 
@@ -300,6 +393,22 @@ func (p *peer) ReadMessages() {
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
 			p.net.metrics.failedToParse.Inc()
+			if errors.Is(err, message.ErrTooLarge) && p.addOversizedStrike() {
+				p.net.log.Debug(
+					"disconnecting from peer %s%s at %s because it sent %d messages exceeding the max size of their op",
+					constants.NodeIDPrefix, p.nodeID, p.getIP(), p.oversizedStrikes,
+				)
+				p.net.metrics.oversizedStrikeDisconnects.Inc()
+				return
+			}
+			continue
+		}
+
+		// Delay, or drop, consensus and app-level messages from peers that
+		// recently used more than their share of CPU time.
+		if op := msg.Op(); !op.IsHandshake() && !p.net.inboundCPUThrottler.Acquire(p.nodeID) {
+			p.net.log.Debug("dropping %s message from %s%s at %s due to excessive CPU usage", op, constants.NodeIDPrefix, p.nodeID, p.getIP())
+			msg.OnFinishedHandling()
 			continue
 		}
 
@@ -307,6 +416,9 @@ func (p *peer) ReadMessages() {
 		// this message, we must call [p.net.msgThrottler.Release]
 		// to release the bytes used by this message. See MsgThrottler.
 		p.handle(msg, float64(len(msgBytes)))
+		if !receivedAt.IsZero() {
+			p.net.tracer.traceInbound(p.nodeID, msg, len(msgBytes), receivedAt, time.Now())
+		}
 	}
 }
 
@@ -314,39 +426,72 @@ func (p *peer) ReadMessages() {
 func (p *peer) WriteMessages() {
 	defer p.Close()
 
-	var reader bytes.Reader
 	writer := bufio.NewWriter(p.conn)
+	go func() {
+		defer p.Close()
+
+		p.writeQueuedMessages(writer, true)
+	}()
+	p.writeQueuedMessages(writer, false)
+}
+
+// writeQueuedMessages writes the messages in [p.prioritySendQueue] if
+// [priority], or the messages in [p.sendQueue] otherwise, until the peer is
+// closed or a write fails.
+func (p *peer) writeQueuedMessages(writer *bufio.Writer, priority bool) {
+	var reader bytes.Reader
 	for { // When this loop exits, p.sendQueueCond.L is unlocked
 		p.sendQueueCond.L.Lock()
+		queue := &p.sendQueue
+		if priority {
+			queue = &p.prioritySendQueue
+		}
 		for {
 			if p.closed.GetValue() {
 				p.sendQueueCond.L.Unlock()
 				return
 			}
-			if len(p.sendQueue) > 0 {
+			if len(*queue) > 0 {
 				// There is a message to send
 				break
 			}
 			// Wait until there is a message to send
 			p.sendQueueCond.Wait()
 		}
-		msg := p.sendQueue[0]
-		p.sendQueue = p.sendQueue[1:]
+		queued := (*queue)[0]
+		*queue = (*queue)[1:]
 		p.sendQueueCond.L.Unlock()
+		msg := queued.msg
 
 		msgLen := uint32(len(msg.Bytes()))
 		p.net.outboundMsgThrottler.Release(uint64(msgLen), p.nodeID)
+
+		if priority {
+			// Priority messages never wait for outbound bandwidth, but the
+			// bandwidth they use is still accounted for
+			p.net.outboundBandwidthThrottler.Consume(uint64(msgLen))
+		} else if !p.net.outboundBandwidthThrottler.Acquire(msg.Op(), uint64(msgLen)) {
+			// There wasn't enough outbound bandwidth to write [msg] in time
+			p.net.log.Debug("dropping %s message to %s%s at %s due to a lack of outbound bandwidth", msg.Op(), constants.NodeIDPrefix, p.nodeID, p.getIP())
+			msg.DecRef()
+			continue
+		}
+
 		p.net.log.Verbo("sending message to %s%s at %s:\n%s", constants.NodeIDPrefix, p.nodeID, p.getIP(), formatting.DumpBytes(msg.Bytes()))
 		msgb := [wrappers.IntLen]byte{}
 		binary.BigEndian.PutUint32(msgb[:], msgLen)
+
+		p.writeLock.Lock()
 		for _, byteSlice := range [2][]byte{msgb[:], msg.Bytes()} {
 			reader.Reset(byteSlice)
 			if err := p.conn.SetWriteDeadline(p.nextTimeout()); err != nil {
+				p.writeLock.Unlock()
 				p.net.log.Verbo("error setting write deadline to %s%s at %s due to: %s", constants.NodeIDPrefix, p.nodeID, p.getIP(), err)
 				msg.DecRef()
 				return
 			}
 			if _, err := io.CopyN(writer, &reader, int64(len((byteSlice)))); err != nil {
+				p.writeLock.Unlock()
 				p.net.log.Verbo("error writing to %s%s at %s due to: %s", constants.NodeIDPrefix, p.nodeID, p.getIP(), err)
 				msg.DecRef()
 				return
@@ -354,7 +499,9 @@ func (p *peer) WriteMessages() {
 		}
 		p.tickerOnce.Do(p.StartTicker)
 		// Make sure the peer got the entire message
-		if err := writer.Flush(); err != nil {
+		err := writer.Flush()
+		p.writeLock.Unlock()
+		if err != nil {
 			p.net.log.Verbo("couldn't flush writer to %s%s at %s: %s", constants.NodeIDPrefix, p.nodeID, p.getIP(), err)
 			msg.DecRef()
 			return
@@ -364,6 +511,16 @@ func (p *peer) WriteMessages() {
 		atomic.StoreInt64(&p.lastSent, now)
 		atomic.StoreInt64(&p.net.lastMsgSentTime, now)
 
+		op := msg.Op()
+		atomic.AddUint64(&p.bytesSent, uint64(msgLen))
+		p.lastSentOp.SetValue(op)
+		if msgMetrics := p.net.metrics.messageMetrics[op]; msgMetrics != nil {
+			msgMetrics.sentBytes.Add(float64(msgLen))
+		}
+		if p.net.tracer.isEnabled() {
+			p.net.tracer.traceOutbound(p.nodeID, msg, queued.enqueuedAt, time.Now())
+		}
+
 		msg.DecRef()
 	}
 }
@@ -394,11 +551,27 @@ func (p *peer) Send(msg message.OutboundMessage) bool {
 		return false
 	}
 
-	p.sendQueue = append(p.sendQueue, msg)
-	p.sendQueueCond.Signal()
+	queued := queuedMessage{msg: msg}
+	if p.net.tracer.isEnabled() {
+		queued.enqueuedAt = time.Now()
+	}
+	if p.isPriorityMsg(msg.Op()) {
+		p.prioritySendQueue = append(p.prioritySendQueue, queued)
+	} else {
+		p.sendQueue = append(p.sendQueue, queued)
+	}
+	p.sendQueueCond.Broadcast()
 	return true
 }
 
+// isPriorityMsg returns true if messages of [op] must be written to the peer
+// without waiting for outbound bandwidth. This includes the PeerList message
+// sent during the handshake, as the peer won't finish the handshake without it.
+func (p *peer) isPriorityMsg(op message.Op) bool {
+	return throttling.IsOutboundBandwidthExempt(op) ||
+		(op == message.PeerList && !p.finishedHandshake.GetValue())
+}
+
 // assumes the [stateLock] is not held
 func (p *peer) handle(msg message.InboundMessage, msgLen float64) {
 	now := p.net.clock.Time()
@@ -406,6 +579,9 @@ func (p *peer) handle(msg message.InboundMessage, msgLen float64) {
 	atomic.StoreInt64(&p.net.lastMsgReceivedTime, now.Unix())
 
 	op := msg.Op()
+	atomic.AddUint64(&p.bytesReceived, uint64(msgLen))
+	p.lastReceivedOp.SetValue(op)
+
 	msgMetrics := p.net.metrics.messageMetrics[op]
 	if msgMetrics == nil {
 		p.net.log.Error("dropping an unknown message from %s%s at %s with op %s", constants.NodeIDPrefix, p.nodeID, p.getIP(), op)
@@ -440,6 +616,18 @@ func (p *peer) handle(msg message.InboundMessage, msgLen float64) {
 		p.handleUptimePong(msg)
 		msg.OnFinishedHandling()
 		return
+	case message.NoncePing:
+		p.handleNoncePing(msg)
+		msg.OnFinishedHandling()
+		return
+	case message.NoncePong:
+		p.handleNoncePong(msg)
+		msg.OnFinishedHandling()
+		return
+	case message.Capabilities:
+		p.handleCapabilities(msg)
+		msg.OnFinishedHandling()
+		return
 	case message.GetPeerList:
 		p.handleGetPeerList(msg)
 		msg.OnFinishedHandling()
@@ -488,17 +676,20 @@ func (p *peer) close() {
 
 	p.sendQueueCond.L.Lock()
 	// Release the bytes of the unsent messages to the outbound message throttler
-	for i := 0; i < len(p.sendQueue); i++ {
-		msg := p.sendQueue[i]
-		p.net.outboundMsgThrottler.Release(uint64(len(msg.Bytes())), p.nodeID)
-		msg.DecRef()
+	for _, queue := range [2][]queuedMessage{p.sendQueue, p.prioritySendQueue} {
+		for _, queued := range queue {
+			msg := queued.msg
+			p.net.outboundMsgThrottler.Release(uint64(len(msg.Bytes())), p.nodeID)
+			msg.DecRef()
+		}
 	}
 	p.sendQueue = nil
+	p.prioritySendQueue = nil
 	p.sendQueueCond.L.Unlock()
-	// Per [p.sendQueueCond]'s spec, it is signalled when [p.closed] is set to true
-	// so that we exit the WriteMessages goroutine.
-	// Since [p.closed] is now true, nothing else will be put on [p.sendQueue]
-	p.sendQueueCond.Signal()
+	// Per [p.sendQueueCond]'s spec, it is broadcast when [p.closed] is set to
+	// true so that we exit the goroutines writing messages.
+	// Since [p.closed] is now true, nothing else will be put on the queues
+	p.sendQueueCond.Broadcast()
 	p.net.disconnected(p)
 }
 
@@ -532,6 +723,15 @@ func (p *peer) sendVersion() {
 	p.net.stateLock.RUnlock()
 	p.net.log.AssertNoError(err)
 
+	p.net.send(msg, false, []*peer{p})
+	p.sendCapabilities()
+}
+
+// assumes the [stateLock] is not held
+func (p *peer) sendCapabilities() {
+	msg, err := p.net.mc.Capabilities(localCapabilities)
+	p.net.log.AssertNoError(err)
+
 	p.net.send(msg, false, []*peer{p})
 }
 
@@ -561,9 +761,25 @@ func (p *peer) sendPeerList() {
 
 // assumes the [stateLock] is not held
 func (p *peer) sendPing() {
-	msg, err := p.net.mc.Ping()
+	if !p.supportsNoncePing() {
+		msg, err := p.net.mc.Ping()
+		p.net.log.AssertNoError(err)
+
+		p.net.send(msg, false, []*peer{p})
+		return
+	}
+
+	nonce := rand.Uint64() // #nosec G404
+	msg, err := p.net.mc.NoncePing(nonce)
 	p.net.log.AssertNoError(err)
 
+	// A previous ping that wasn't answered is no longer measured
+	p.pingLock.Lock()
+	p.pingNonce = nonce
+	p.pingSentAt = time.Now()
+	p.pingOutstanding = true
+	p.pingLock.Unlock()
+
 	p.net.send(msg, false, []*peer{p})
 }
 
@@ -575,6 +791,14 @@ func (p *peer) sendPong() {
 	p.net.send(msg, false, []*peer{p})
 }
 
+// assumes the [stateLock] is not held
+func (p *peer) sendNoncePong(nonce uint64) {
+	msg, err := p.net.mc.NoncePong(nonce)
+	p.net.log.AssertNoError(err)
+
+	p.net.send(msg, false, []*peer{p})
+}
+
 // assumes the [stateLock] is not held
 func (p *peer) sendUptimePong() {
 	uptimePercent, err := p.net.config.UptimeCalculator.CalculateUptimePercent(p.nodeID)
@@ -741,6 +965,11 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 	p.tryMarkFinishedHandshake()
 }
 
+// assumes the [stateLock] is not held
+func (p *peer) handleCapabilities(msg message.InboundMessage) {
+	atomic.StoreUint64(&p.capabilities, msg.Get(message.CapabilityFlags).(uint64))
+}
+
 // assumes the [stateLock] is not held
 func (p *peer) handleGetPeerList(_ message.InboundMessage) {
 	if p.gotVersion.GetValue() && !p.peerListSent.GetValue() {
@@ -748,22 +977,19 @@ func (p *peer) handleGetPeerList(_ message.InboundMessage) {
 	}
 }
 
-func (p *peer) trackSignedPeer(peer utils.IPCertDesc) {
+// Tracks [peer] if it's a validator or beacon that we should dial. Returns
+// false if the signature of [peer] is invalid.
+func (p *peer) trackSignedPeer(peer utils.IPCertDesc) bool {
 	p.net.stateLock.Lock()
 	defer p.net.stateLock.Unlock()
 
 	switch {
 	case peer.IPDesc.Equal(p.net.currentIP.IP()):
-		return
+		return true
 	case peer.IPDesc.IsZero():
-		return
+		return true
 	case !p.net.config.AllowPrivateIPs && peer.IPDesc.IsPrivate():
-		return
-	}
-
-	if float64(peer.Time)-float64(p.net.clock.Unix()) > p.net.config.MaxClockDifference.Seconds() {
-		p.net.log.Debug("ignoring gossiped peer with version timestamp (%d) too far in the future", peer.Time)
-		return
+		return true
 	}
 
 	nodeID := certToID(peer.Cert)
@@ -772,7 +998,19 @@ func (p *peer) trackSignedPeer(peer utils.IPCertDesc) {
 			"not peering to %s at %s because they are not a validator or beacon",
 			nodeID.PrefixedString(constants.NodeIDPrefix), peer.IPDesc,
 		)
-		return
+		return true
+	}
+
+	now := float64(p.net.clock.Unix())
+	if float64(peer.Time)-now > p.net.config.MaxClockDifference.Seconds() {
+		p.net.log.Debug("ignoring gossiped peer with version timestamp (%d) too far in the future", peer.Time)
+		p.net.metrics.peerListIPsStale.Inc()
+		return true
+	}
+	if maxAge := p.net.config.PeerListMaxIPAge; maxAge > 0 && now-float64(peer.Time) > maxAge.Seconds() {
+		p.net.log.Debug("ignoring gossiped peer with version timestamp (%d) older than %s", peer.Time, maxAge)
+		p.net.metrics.peerListIPsStale.Inc()
+		return true
 	}
 
 	// Am I already peered to them? (safe because [p.net.stateLock] is held)
@@ -782,15 +1020,18 @@ func (p *peer) trackSignedPeer(peer utils.IPCertDesc) {
 			"not peering to %s because we are already connected to %s",
 			peer.IPDesc, nodeID.PrefixedString(constants.NodeIDPrefix),
 		)
-		return
+		p.net.metrics.peerListIPsKnown.Inc()
+		return true
 	}
 
-	if p.net.latestPeerIP[nodeID].time > peer.Time {
+	latestIP, hasLatestIP := p.net.latestPeerIP[nodeID]
+	if latestIP.time > peer.Time {
 		p.net.log.Verbo(
 			"not peering to %s at %s: the given timestamp (%d) < latest (%d)",
-			nodeID.PrefixedString(constants.NodeIDPrefix), peer.IPDesc, peer.Time, p.net.latestPeerIP[nodeID].time,
+			nodeID.PrefixedString(constants.NodeIDPrefix), peer.IPDesc, peer.Time, latestIP.time,
 		)
-		return
+		p.net.metrics.peerListIPsStale.Inc()
+		return true
 	}
 
 	signed := ipAndTimeBytes(peer.IPDesc, peer.Time)
@@ -800,14 +1041,22 @@ func (p *peer) trackSignedPeer(peer utils.IPCertDesc) {
 			"signature verification failed for %s at %s: %s",
 			nodeID.PrefixedString(constants.NodeIDPrefix), peer.IPDesc, err,
 		)
-		return
+		p.net.metrics.peerListIPsInvalidSignature.Inc()
+		return false
 	}
+	p.net.metrics.peerListIPsAccepted.Inc()
 	p.net.latestPeerIP[nodeID] = signedPeerIP{
 		ip:   peer.IPDesc,
 		time: peer.Time,
 	}
+	if hasLatestIP && latestIP.ip.Equal(peer.IPDesc) {
+		p.net.metrics.peerListIPsKnown.Inc()
+	} else {
+		p.net.metrics.peerListIPsLearned.Inc()
+	}
 
 	p.net.track(peer.IPDesc, nodeID)
+	return true
 }
 
 // assumes the [stateLock] is not held
@@ -817,16 +1066,82 @@ func (p *peer) handlePeerList(msg message.InboundMessage) {
 
 	ips := msg.Get(message.SignedPeers).([]utils.IPCertDesc)
 	for _, ip := range ips {
-		p.trackSignedPeer(ip)
+		if !p.trackSignedPeer(ip) && p.addPeerListStrike() {
+			p.net.log.Debug(
+				"disconnecting from peer %s%s at %s because it gossiped %d IPs with invalid signatures",
+				constants.NodeIDPrefix, p.nodeID, p.getIP(), p.peerListStrikes,
+			)
+			p.net.metrics.peerListStrikeDisconnects.Inc()
+			p.discardIP()
+			return
+		}
 	}
 }
 
+// Records that this peer gossiped an IP with an invalid signature. Returns
+// true if we should disconnect from this peer.
+func (p *peer) addPeerListStrike() bool {
+	p.peerListStrikes++
+	maxStrikes := p.net.config.PeerListMaxStrikes
+	return maxStrikes != 0 && p.peerListStrikes >= maxStrikes
+}
+
+// Records that this peer sent a message exceeding the max size of its op.
+// Returns true if we should disconnect from this peer.
+func (p *peer) addOversizedStrike() bool {
+	p.oversizedStrikes++
+	maxStrikes := p.net.config.OversizedMsgMaxStrikes
+	return maxStrikes != 0 && p.oversizedStrikes >= maxStrikes
+}
+
 // assumes the [stateLock] is not held
 func (p *peer) handlePing(_ message.InboundMessage) {
 	p.sendPong()
 	p.sendUptimePong()
 }
 
+// assumes the [stateLock] is not held
+func (p *peer) handleNoncePing(msg message.InboundMessage) {
+	p.sendNoncePong(msg.Get(message.Nonce).(uint64))
+	p.sendUptimePong()
+}
+
+// assumes the [stateLock] is not held
+func (p *peer) handleNoncePong(msg message.InboundMessage) {
+	p.observeNoncePong(msg.Get(message.Nonce).(uint64), time.Now())
+}
+
+// Records the round trip time of the last NoncePing if [nonce] answers it.
+// Pongs that answer older pings, or that answer the last ping again, are
+// ignored.
+func (p *peer) observeNoncePong(nonce uint64, receivedAt time.Time) {
+	p.pingLock.Lock()
+	defer p.pingLock.Unlock()
+
+	if !p.pingOutstanding || nonce != p.pingNonce {
+		p.net.log.Verbo("ignoring unexpected pong from %s%s at %s", constants.NodeIDPrefix, p.nodeID, p.getIP())
+		return
+	}
+	p.pingOutstanding = false
+
+	rtt := receivedAt.Sub(p.pingSentAt)
+	if p.smoothedRTT == 0 {
+		p.smoothedRTT = rtt
+	} else {
+		p.smoothedRTT = time.Duration((1-rttSmoothingFactor)*float64(p.smoothedRTT) + rttSmoothingFactor*float64(rtt))
+	}
+	p.net.metrics.pingRTT.Observe(rtt.Seconds())
+}
+
+// Returns the smoothed round trip time to this peer, or 0 if it hasn't been
+// measured.
+func (p *peer) getSmoothedRTT() time.Duration {
+	p.pingLock.Lock()
+	defer p.pingLock.Unlock()
+
+	return p.smoothedRTT
+}
+
 // assumes the [stateLock] is not held
 func (p *peer) handlePong(msg message.InboundMessage) {
 	p.pongHandle(msg, false)
@@ -866,6 +1181,24 @@ func (p *peer) pongHandle(msg message.InboundMessage, isUptime bool) {
 	}
 }
 
+// supportsNoncePing returns true if this peer advertised, in its
+// Capabilities message, that it responds to NoncePing messages.
+func (p *peer) supportsNoncePing() bool {
+	return p.hasCapability(noncePingCapability)
+}
+
+// canDecompressZstd returns true if this peer advertised, in its
+// Capabilities message, that it can decompress messages compressed with zstd.
+func (p *peer) canDecompressZstd() bool {
+	return p.hasCapability(zstdCapability)
+}
+
+// hasCapability returns true if this peer advertised [capability] during the
+// handshake.
+func (p *peer) hasCapability(capability uint64) bool {
+	return atomic.LoadUint64(&p.capabilities)&capability != 0
+}
+
 // assumes the [stateLock] is held
 func (p *peer) tryMarkFinishedHandshake() {
 	if !p.finishedHandshake.GetValue() && // not already marked as finished with handshake
@@ -10,6 +10,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/json"
 )
 
+const (
+	inboundDirection  = "inbound"
+	outboundDirection = "outbound"
+)
+
 type PeerInfo struct {
 	IP             string     `json:"ip"`
 	PublicIP       string     `json:"publicIP,omitempty"`
@@ -19,4 +24,28 @@ type PeerInfo struct {
 	LastReceived   time.Time  `json:"lastReceived"`
 	Benched        []ids.ID   `json:"benched"`
 	ObservedUptime json.Uint8 `json:"observedUptime"`
+
+	// Uptime of the peer from this node's point of view, as a percentage.
+	// Omitted if the peer isn't a validator.
+	Uptime *json.Uint8 `json:"uptime,omitempty"`
+
+	// Subnets the peer claims to track
+	TrackedSubnets []ids.ID `json:"trackedSubnets"`
+
+	// Smoothed round trip time of pings to the peer. Omitted if it hasn't been
+	// measured.
+	SmoothedRTT string `json:"smoothedRTT,omitempty"`
+
+	// "inbound" if the peer connected to this node, "outbound" if this node
+	// connected to the peer
+	Direction string `json:"direction"`
+
+	// Number of message bytes sent to and received from the peer
+	BytesSent     json.Uint64 `json:"bytesSent"`
+	BytesReceived json.Uint64 `json:"bytesReceived"`
+
+	// Ops of the last messages sent to and received from the peer. Omitted if
+	// no message has been sent/received.
+	LastMessageSent     string `json:"lastMessageSent,omitempty"`
+	LastMessageReceived string `json:"lastMessageReceived,omitempty"`
 }
@@ -6,15 +6,24 @@ package network
 import (
 	"context"
 	"crypto"
+	"crypto/rand"
+	"crypto/x509"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,7 +84,7 @@ func TestPeer_Close(t *testing.T) {
 	vdrs := getDefaultManager()
 	beacons := validators.NewSet()
 	metrics := prometheus.NewRegistry()
-	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler := &testHandler{}
 
@@ -111,7 +120,7 @@ func TestPeer_Close(t *testing.T) {
 
 	// fake a peer, and write a message
 	peer := newPeer(basenetwork, conn, ip1.IP())
-	peer.sendQueue = make([]message.OutboundMessage, 0)
+	peer.sendQueue = make([]queuedMessage, 0)
 	testMsg := newTestMsg(message.GetVersion, newmsgbytes)
 	peer.Send(testMsg)
 
@@ -122,3 +131,642 @@ func TestPeer_Close(t *testing.T) {
 
 	peer.Close()
 }
+
+// Ensure that messages are only compressed with zstd for peers that advertised
+// that they can decompress them.
+func TestSendZstdOnlyToCapablePeers(t *testing.T) {
+	initCerts(t)
+
+	ip := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id := ids.ShortID(hashing.ComputeHash160Array([]byte(ip.IP().String())))
+
+	listener := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	vdrs := getDefaultManager()
+	beacons := validators.NewSet()
+	metrics := prometheus.NewRegistry()
+	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
+	assert.NoError(t, err)
+	handler := &testHandler{}
+
+	netwrk, err := newTestNetwork(
+		id,
+		ip,
+		defaultVersionManager,
+		vdrs,
+		beacons,
+		cert0.PrivateKey.(crypto.Signer),
+		ids.Set{},
+		tlsConfig0,
+		listener,
+		caller,
+		metrics,
+		msgCreator,
+		handler,
+	)
+	assert.NoError(t, err)
+	basenetwork := netwrk.(*network)
+	basenetwork.config.ZstdCompressionEnabled = true
+
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	caller.outbounds[ip1.IP().String()] = listener
+	conn, err := caller.Dial(context.Background(), ip1.IP())
+	assert.NoError(t, err)
+
+	peerCapabilities := []uint64{
+		zstdCapability,
+		^zstdCapability, // The peer advertised every other capability
+		0,               // The peer didn't send a Capabilities message
+	}
+	peers := make([]*peer, len(peerCapabilities))
+	for i, capabilities := range peerCapabilities {
+		peers[i] = newPeer(basenetwork, conn, ip1.IP())
+		peers[i].nodeID = ids.GenerateTestShortID()
+		peers[i].finishedHandshake.SetValue(true)
+		peers[i].capabilities = capabilities
+	}
+
+	compressionTypes := func() []compression.Type {
+		types := make([]compression.Type, len(peers))
+		for i, peer := range peers {
+			types[i] = compression.Type(peer.sendQueue[len(peer.sendQueue)-1].msg.Bytes()[1])
+		}
+		return types
+	}
+
+	chainID := ids.GenerateTestID()
+	msg, err := msgCreator.Put(chainID, 1, ids.GenerateTestID(), make([]byte, 4096))
+	assert.NoError(t, err)
+	sentTo := basenetwork.send(msg, true, peers)
+	assert.Equal(t, len(peers), sentTo.Len())
+	assert.Equal(t, []compression.Type{compression.TypeZstd, compression.TypeGzip, compression.TypeGzip}, compressionTypes())
+	assert.Greater(t, testutil.ToFloat64(basenetwork.metrics.zstdSavedSentBytes), 0.0)
+
+	// Ops that aren't whitelisted are never compressed with zstd
+	msg, err = msgCreator.AppGossip(chainID, make([]byte, 4096))
+	assert.NoError(t, err)
+	basenetwork.send(msg, true, peers)
+	assert.Equal(t, []compression.Type{compression.TypeGzip, compression.TypeGzip, compression.TypeGzip}, compressionTypes())
+
+	// Nothing is compressed with zstd unless it is enabled
+	basenetwork.config.ZstdCompressionEnabled = false
+	msg, err = msgCreator.Put(chainID, 1, ids.GenerateTestID(), make([]byte, 4096))
+	assert.NoError(t, err)
+	basenetwork.send(msg, true, peers)
+	assert.Equal(t, []compression.Type{compression.TypeGzip, compression.TypeGzip, compression.TypeGzip}, compressionTypes())
+
+	assert.NoError(t, netwrk.Close())
+}
+
+// Ensure that the bytes and ops of the messages sent to and received from a
+// peer are reported in its info.
+func TestPeerBandwidthAccounting(t *testing.T) {
+	initCerts(t)
+
+	ip := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id := ids.ShortID(hashing.ComputeHash160Array([]byte(ip.IP().String())))
+
+	listener := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	vdrs := getDefaultManager()
+	beacons := validators.NewSet()
+	metrics := prometheus.NewRegistry()
+	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
+	assert.NoError(t, err)
+	handler := &testHandler{}
+
+	netwrk, err := newTestNetwork(
+		id,
+		ip,
+		defaultVersionManager,
+		vdrs,
+		beacons,
+		cert0.PrivateKey.(crypto.Signer),
+		ids.Set{},
+		tlsConfig0,
+		listener,
+		caller,
+		metrics,
+		msgCreator,
+		handler,
+	)
+	assert.NoError(t, err)
+	basenetwork := netwrk.(*network)
+
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	caller.outbounds[ip1.IP().String()] = listener
+	conn, err := caller.Dial(context.Background(), ip1.IP())
+	assert.NoError(t, err)
+
+	peer := newPeer(basenetwork, conn, ip1.IP())
+	peer.nodeID = ids.GenerateTestShortID()
+	peer.versionStr.SetValue(version.CurrentApp.String())
+
+	info := basenetwork.NewPeerInfo(peer)
+	assert.Zero(t, info.BytesSent)
+	assert.Zero(t, info.BytesReceived)
+	assert.Empty(t, info.LastMessageSent)
+	assert.Empty(t, info.LastMessageReceived)
+
+	// Messages are only accounted for once they are written to the peer
+	testMsg := newTestMsg(message.GetVersion, []byte("hello"))
+	assert.True(t, peer.Send(testMsg))
+	assert.Zero(t, basenetwork.NewPeerInfo(peer).BytesSent)
+
+	go peer.WriteMessages()
+	assert.Eventually(t, func() bool {
+		return basenetwork.NewPeerInfo(peer).BytesSent == 5
+	}, time.Second, time.Millisecond)
+	sentBytes := basenetwork.metrics.messageMetrics[message.GetVersion].sentBytes
+	assert.Equal(t, 5.0, testutil.ToFloat64(sentBytes))
+
+	pong, err := msgCreator.Pong()
+	assert.NoError(t, err)
+	inMsg, err := basenetwork.mc.Parse(pong.Bytes(), peer.nodeID, func() {})
+	assert.NoError(t, err)
+	peer.handle(inMsg, float64(len(pong.Bytes())))
+
+	info = basenetwork.NewPeerInfo(peer)
+	assert.EqualValues(t, 5, info.BytesSent)
+	assert.EqualValues(t, len(pong.Bytes()), info.BytesReceived)
+	assert.Equal(t, message.GetVersion.String(), info.LastMessageSent)
+	assert.Equal(t, message.Pong.String(), info.LastMessageReceived)
+
+	go func() {
+		assert.NoError(t, netwrk.Close())
+	}()
+	peer.Close()
+}
+
+// blockingBandwidthThrottler makes every message that waits for outbound
+// bandwidth wait until [release] is closed
+type blockingBandwidthThrottler struct {
+	release chan struct{}
+}
+
+func (t *blockingBandwidthThrottler) Acquire(message.Op, uint64) bool {
+	<-t.release
+	return true
+}
+
+func (*blockingBandwidthThrottler) Consume(uint64) {}
+
+// Ensure that handshake and ping messages are written while other messages are
+// waiting for outbound bandwidth.
+func TestPeerPriorityMessagesSkipBandwidthQueue(t *testing.T) {
+	initCerts(t)
+
+	ip := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id := ids.ShortID(hashing.ComputeHash160Array([]byte(ip.IP().String())))
+
+	listener := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	vdrs := getDefaultManager()
+	beacons := validators.NewSet()
+	metrics := prometheus.NewRegistry()
+	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
+	assert.NoError(t, err)
+	handler := &testHandler{}
+
+	netwrk, err := newTestNetwork(
+		id,
+		ip,
+		defaultVersionManager,
+		vdrs,
+		beacons,
+		cert0.PrivateKey.(crypto.Signer),
+		ids.Set{},
+		tlsConfig0,
+		listener,
+		caller,
+		metrics,
+		msgCreator,
+		handler,
+	)
+	assert.NoError(t, err)
+	basenetwork := netwrk.(*network)
+	throttler := &blockingBandwidthThrottler{release: make(chan struct{})}
+	basenetwork.outboundBandwidthThrottler = throttler
+
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	caller.outbounds[ip1.IP().String()] = listener
+	conn, err := caller.Dial(context.Background(), ip1.IP())
+	assert.NoError(t, err)
+
+	peer := newPeer(basenetwork, conn, ip1.IP())
+	peer.nodeID = ids.GenerateTestShortID()
+
+	// The bulk message is queued first, and waits for bandwidth
+	assert.True(t, peer.Send(newTestMsg(message.MultiPut, []byte("bulk"))))
+	assert.True(t, peer.Send(newTestMsg(message.Ping, []byte("ping"))))
+	// The PeerList sent during the handshake must not wait either
+	assert.True(t, peer.Send(newTestMsg(message.PeerList, []byte("peers"))))
+	assert.Len(t, peer.sendQueue, 1)
+	assert.Len(t, peer.prioritySendQueue, 2)
+
+	go peer.WriteMessages()
+
+	sentBytes := func(op message.Op) float64 {
+		return testutil.ToFloat64(basenetwork.metrics.messageMetrics[op].sentBytes)
+	}
+	assert.Eventually(t, func() bool {
+		return sentBytes(message.Ping) == 4 && sentBytes(message.PeerList) == 5
+	}, time.Second, time.Millisecond)
+	assert.Zero(t, sentBytes(message.MultiPut))
+
+	close(throttler.release)
+	assert.Eventually(t, func() bool {
+		return sentBytes(message.MultiPut) == 4
+	}, time.Second, time.Millisecond)
+
+	// PeerList gossip after the handshake waits for bandwidth
+	peer.finishedHandshake.SetValue(true)
+	assert.False(t, peer.isPriorityMsg(message.PeerList))
+	assert.True(t, peer.isPriorityMsg(message.Ping))
+
+	go func() {
+		assert.NoError(t, netwrk.Close())
+	}()
+	peer.Close()
+}
+
+// Ensure that a Capabilities message is sent right after the Version message,
+// and that the capabilities a peer advertises are recorded.
+func TestPeerCapabilities(t *testing.T) {
+	initCerts(t)
+
+	ip := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id := ids.ShortID(hashing.ComputeHash160Array([]byte(ip.IP().String())))
+
+	listener := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	vdrs := getDefaultManager()
+	beacons := validators.NewSet()
+	metrics := prometheus.NewRegistry()
+	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
+	assert.NoError(t, err)
+	handler := &testHandler{}
+
+	netwrk, err := newTestNetwork(
+		id,
+		ip,
+		defaultVersionManager,
+		vdrs,
+		beacons,
+		cert0.PrivateKey.(crypto.Signer),
+		ids.Set{},
+		tlsConfig0,
+		listener,
+		caller,
+		metrics,
+		msgCreator,
+		handler,
+	)
+	assert.NoError(t, err)
+	basenetwork := netwrk.(*network)
+
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	caller.outbounds[ip1.IP().String()] = listener
+	conn, err := caller.Dial(context.Background(), ip1.IP())
+	assert.NoError(t, err)
+
+	peer := newPeer(basenetwork, conn, ip1.IP())
+	peer.nodeID = ids.GenerateTestShortID()
+
+	peer.sendVersion()
+	assert.Len(t, peer.prioritySendQueue, 2)
+	assert.Equal(t, message.Version, peer.prioritySendQueue[0].msg.Op())
+	capabilitiesMsg := peer.prioritySendQueue[1].msg
+	assert.Equal(t, message.Capabilities, capabilitiesMsg.Op())
+
+	// The peer doesn't support anything until it sends its capabilities
+	assert.False(t, peer.canDecompressZstd())
+
+	inMsg, err := basenetwork.mc.Parse(capabilitiesMsg.Bytes(), peer.nodeID, func() {})
+	assert.NoError(t, err)
+	peer.handle(inMsg, float64(len(capabilitiesMsg.Bytes())))
+	assert.True(t, peer.canDecompressZstd())
+	assert.True(t, peer.supportsNoncePing())
+
+	go func() {
+		assert.NoError(t, netwrk.Close())
+	}()
+	peer.Close()
+}
+
+// IPs are always signed, and sent, in their 16 byte form. Ensure that the
+// signed bytes of an IPv4 address don't depend on how it's represented, so
+// that signatures from peers on any version verify.
+func TestIPAndTimeBytesIPRepresentation(t *testing.T) {
+	assert := assert.New(t)
+
+	ip4 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4).To4(), Port: 9651}
+	ip16 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4).To16(), Port: 9651}
+	assert.Equal(ipAndTimeBytes(ip4, 1), ipAndTimeBytes(ip16, 1))
+
+	ip6 := utils.IPDesc{IP: net.ParseIP("2001:db8::1"), Port: 9651}
+	assert.NotEqual(ipAndTimeBytes(ip4, 1), ipAndTimeBytes(ip6, 1))
+}
+
+// Ensure that gossiped IPs are counted as learned if they're new, and as known
+// otherwise.
+func TestTrackSignedPeerMetrics(t *testing.T) {
+	assert := assert.New(t)
+	initCerts(t)
+
+	leaf, err := x509.ParseCertificate(cert0.Certificate[0])
+	assert.NoError(err)
+	nodeID := certToID(leaf)
+	tlsKey := cert0.PrivateKey.(crypto.Signer)
+
+	vdrs := validators.NewManager()
+	assert.NoError(vdrs.AddWeight(constants.PrimaryNetworkID, nodeID, 1))
+
+	n := &network{
+		log:          logging.NoLog{},
+		currentIP:    utils.NewDynamicIPDesc(net.IPv4(10, 0, 0, 1), 9651),
+		latestPeerIP: make(map[ids.ShortID]signedPeerIP),
+		config: &Config{
+			Validators:         vdrs,
+			Beacons:            validators.NewSet(),
+			AllowPrivateIPs:    true,
+			MaxClockDifference: time.Minute,
+		},
+	}
+	n.peers.initialize()
+	assert.NoError(n.metrics.initialize("", prometheus.NewRegistry()))
+	n.clock.Set(time.Unix(1000, 0))
+	// Don't dial the tracked IPs
+	n.closed.SetValue(true)
+	p := &peer{net: n}
+
+	signedPeer := func(ip utils.IPDesc, timestamp uint64) utils.IPCertDesc {
+		sig, err := tlsKey.Sign(rand.Reader, ipAndTimeHash(ip, timestamp), crypto.SHA256)
+		assert.NoError(err)
+		return utils.IPCertDesc{
+			Cert:      leaf,
+			IPDesc:    ip,
+			Time:      timestamp,
+			Signature: sig,
+		}
+	}
+
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	ip1 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}
+
+	assert.True(p.trackSignedPeer(signedPeer(ip0, 1000)))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsLearned))
+	assert.Equal(0.0, testutil.ToFloat64(n.metrics.peerListIPsKnown))
+
+	// Same IP gossiped again
+	assert.True(p.trackSignedPeer(signedPeer(ip0, 1001)))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsLearned))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsKnown))
+
+	// Older IP
+	assert.True(p.trackSignedPeer(signedPeer(ip1, 999)))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsLearned))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsKnown))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsStale))
+
+	// Newer IP
+	assert.True(p.trackSignedPeer(signedPeer(ip1, 1002)))
+	assert.Equal(2.0, testutil.ToFloat64(n.metrics.peerListIPsLearned))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsKnown))
+	assert.Equal(3.0, testutil.ToFloat64(n.metrics.peerListIPsAccepted))
+}
+
+// Ensure that gossiped IPs with forged signatures, replayed old timestamps, or
+// timestamps too far in the future or past aren't tracked, and that peers that gossip
+// too many forged IPs are disconnected from.
+func TestTrackSignedPeerInvalidClaims(t *testing.T) {
+	assert := assert.New(t)
+	initCerts(t)
+
+	leaf, err := x509.ParseCertificate(cert0.Certificate[0])
+	assert.NoError(err)
+	nodeID := certToID(leaf)
+	tlsKey := cert0.PrivateKey.(crypto.Signer)
+	forgerKey := cert1.PrivateKey.(crypto.Signer)
+
+	vdrs := validators.NewManager()
+	assert.NoError(vdrs.AddWeight(constants.PrimaryNetworkID, nodeID, 1))
+
+	n := &network{
+		log:          logging.NoLog{},
+		currentIP:    utils.NewDynamicIPDesc(net.IPv4(10, 0, 0, 1), 9651),
+		latestPeerIP: make(map[ids.ShortID]signedPeerIP),
+		config: &Config{
+			Validators:         vdrs,
+			Beacons:            validators.NewSet(),
+			AllowPrivateIPs:    true,
+			MaxClockDifference: time.Minute,
+			PeerListGossipConfig: PeerListGossipConfig{
+				PeerListMaxStrikes: 2,
+				PeerListMaxIPAge:   100 * time.Second,
+			},
+		},
+	}
+	n.peers.initialize()
+	assert.NoError(n.metrics.initialize("", prometheus.NewRegistry()))
+	n.clock.Set(time.Unix(1000, 0))
+	// Don't dial the tracked IPs
+	n.closed.SetValue(true)
+	p := &peer{net: n}
+
+	signedPeer := func(key crypto.Signer, ip utils.IPDesc, timestamp uint64) utils.IPCertDesc {
+		sig, err := key.Sign(rand.Reader, ipAndTimeHash(ip, timestamp), crypto.SHA256)
+		assert.NoError(err)
+		return utils.IPCertDesc{
+			Cert:      leaf,
+			IPDesc:    ip,
+			Time:      timestamp,
+			Signature: sig,
+		}
+	}
+
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	ip1 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}
+
+	// Forged signature
+	assert.False(p.trackSignedPeer(signedPeer(forgerKey, ip0, 1000)))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsInvalidSignature))
+	assert.NotContains(n.latestPeerIP, nodeID)
+
+	// Signature of a different IP
+	claim := signedPeer(tlsKey, ip0, 1000)
+	claim.IPDesc = ip1
+	assert.False(p.trackSignedPeer(claim))
+	assert.Equal(2.0, testutil.ToFloat64(n.metrics.peerListIPsInvalidSignature))
+	assert.NotContains(n.latestPeerIP, nodeID)
+
+	// Timestamp too far in the future
+	assert.True(p.trackSignedPeer(signedPeer(tlsKey, ip0, 1000+uint64(time.Hour.Seconds()))))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsStale))
+	assert.NotContains(n.latestPeerIP, nodeID)
+
+	// Timestamp older than the max age
+	assert.True(p.trackSignedPeer(signedPeer(tlsKey, ip0, 899)))
+	assert.Equal(2.0, testutil.ToFloat64(n.metrics.peerListIPsStale))
+	assert.NotContains(n.latestPeerIP, nodeID)
+
+	assert.True(p.trackSignedPeer(signedPeer(tlsKey, ip1, 1000)))
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsAccepted))
+	assert.Equal(ip1, n.latestPeerIP[nodeID].ip)
+
+	// Replayed old claim
+	assert.True(p.trackSignedPeer(signedPeer(tlsKey, ip0, 999)))
+	assert.Equal(3.0, testutil.ToFloat64(n.metrics.peerListIPsStale))
+	assert.Equal(ip1, n.latestPeerIP[nodeID].ip)
+	assert.Equal(1.0, testutil.ToFloat64(n.metrics.peerListIPsAccepted))
+
+	assert.False(p.addPeerListStrike())
+	assert.True(p.addPeerListStrike())
+
+	n.config.PeerListMaxStrikes = 0
+	assert.False(p.addPeerListStrike())
+}
+
+func TestPeerOversizedStrikes(t *testing.T) {
+	assert := assert.New(t)
+	p := &peer{net: &network{config: &Config{OversizedMsgMaxStrikes: 2}}}
+
+	assert.False(p.addOversizedStrike())
+	assert.True(p.addOversizedStrike())
+
+	p.net.config.OversizedMsgMaxStrikes = 0
+	assert.False(p.addOversizedStrike())
+}
+
+// Ensure that only the pong that answers the last ping is used to measure the
+// round trip time, and that the round trip time is smoothed.
+func TestObserveNoncePong(t *testing.T) {
+	assert := assert.New(t)
+
+	n := &network{log: logging.NoLog{}}
+	assert.NoError(n.metrics.initialize("", prometheus.NewRegistry()))
+	p := &peer{net: n}
+
+	sentAt := time.Now()
+	ping := func(nonce uint64) {
+		p.pingNonce = nonce
+		p.pingSentAt = sentAt
+		p.pingOutstanding = true
+	}
+
+	// Pongs received before any ping are ignored
+	p.observeNoncePong(0, sentAt.Add(time.Second))
+	assert.Zero(p.getSmoothedRTT())
+
+	ping(1)
+	p.observeNoncePong(1, sentAt.Add(100*time.Millisecond))
+	assert.Equal(100*time.Millisecond, p.getSmoothedRTT())
+
+	// Duplicate pong
+	p.observeNoncePong(1, sentAt.Add(time.Second))
+	assert.Equal(100*time.Millisecond, p.getSmoothedRTT())
+
+	// Delayed pong of a previous ping
+	ping(2)
+	p.observeNoncePong(1, sentAt.Add(time.Second))
+	assert.Equal(100*time.Millisecond, p.getSmoothedRTT())
+
+	p.observeNoncePong(2, sentAt.Add(900*time.Millisecond))
+	assert.Equal(200*time.Millisecond, p.getSmoothedRTT())
+
+	metric := &dto.Metric{}
+	assert.NoError(n.metrics.pingRTT.Write(metric))
+	assert.EqualValues(2, metric.GetHistogram().GetSampleCount())
+	assert.InDelta(1, metric.GetHistogram().GetSampleSum(), .001)
+}
+
+func TestSupportsNoncePing(t *testing.T) {
+	p := &peer{}
+	assert.False(t, p.supportsNoncePing())
+
+	p.capabilities = zstdCapability
+	assert.False(t, p.supportsNoncePing())
+
+	p.capabilities = noncePingCapability
+	assert.True(t, p.supportsNoncePing())
+}
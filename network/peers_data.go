@@ -16,11 +16,13 @@ import (
 type peersData struct {
 	peersIdxes map[ids.ShortID]int // peerID -> *peer index in peersList
 	peersList  []*peer             // invariant: len(peersList) == len(peersIdxes)
+	numInbound int                 // number of peers in peersList that connected to us
 }
 
 func (p *peersData) initialize() {
 	p.peersIdxes = make(map[ids.ShortID]int)
 	p.peersList = make([]*peer, 0)
+	p.numInbound = 0
 }
 
 func (p *peersData) reset() {
@@ -28,18 +30,28 @@ func (p *peersData) reset() {
 }
 
 func (p *peersData) add(peer *peer) {
-	if _, ok := p.getByID(peer.nodeID); !ok { // new insertion
+	if oldPeer, ok := p.getByID(peer.nodeID); !ok { // new insertion
 		p.peersList = append(p.peersList, peer)
 		p.peersIdxes[peer.nodeID] = len(p.peersList) - 1
 	} else { // update
 		p.peersList[p.peersIdxes[peer.nodeID]] = peer
+		if oldPeer.inbound {
+			p.numInbound--
+		}
+	}
+	if peer.inbound {
+		p.numInbound++
 	}
 }
 
 func (p *peersData) remove(peer *peer) {
-	if _, ok := p.getByID(peer.nodeID); !ok {
+	removedPeer, ok := p.getByID(peer.nodeID)
+	if !ok {
 		return
 	}
+	if removedPeer.inbound {
+		p.numInbound--
+	}
 
 	// Drop p by replacing it with last peer in peersList.
 	// if p is already the last peer, simply drop it.
@@ -76,6 +88,11 @@ func (p *peersData) size() int {
 	return len(p.peersList)
 }
 
+// Returns the number of peers that connected to us
+func (p *peersData) inboundSize() int {
+	return p.numInbound
+}
+
 // Randomly sample [n] peers that have finished the handshake and tracks the subnetID.
 // If < [n] peers have finished the handshake and tracks the subnetID, returns < [n] peers.
 // If [n] > [p.size()], returns <= [p.size()] peers.
@@ -102,6 +102,45 @@ func TestPeersData(t *testing.T) {
 	assert.True(t, data.size() == 0)
 }
 
+func TestPeersDataInboundSize(t *testing.T) {
+	data := peersData{}
+	data.initialize()
+
+	inboundPeer := peer{
+		nodeID:  ids.ShortID{0x01},
+		inbound: true,
+	}
+	outboundPeer := peer{
+		nodeID: ids.ShortID{0x02},
+	}
+	data.add(&inboundPeer)
+	data.add(&outboundPeer)
+	assert.Equal(t, 1, data.inboundSize())
+
+	// reconnecting in the other direction updates the count
+	reconnectedPeer := peer{
+		nodeID: ids.ShortID{0x01},
+	}
+	data.add(&reconnectedPeer)
+	assert.Equal(t, 0, data.inboundSize())
+
+	reconnectedPeer2 := peer{
+		nodeID:  ids.ShortID{0x02},
+		inbound: true,
+	}
+	data.add(&reconnectedPeer2)
+	assert.Equal(t, 1, data.inboundSize())
+
+	data.remove(&reconnectedPeer2)
+	assert.Equal(t, 0, data.inboundSize())
+	assert.Equal(t, 1, data.size())
+
+	data.add(&inboundPeer)
+	assert.Equal(t, 1, data.inboundSize())
+	data.reset()
+	assert.Equal(t, 0, data.inboundSize())
+}
+
 func TestPeersDataSample(t *testing.T) {
 	data := peersData{}
 	data.initialize()
@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const persistedPeerLen = wrappers.IPLen + wrappers.LongLen
+
+type PeerPersistenceConfig struct {
+	// If true, the IPs of peers this node finished the handshake with are
+	// periodically persisted to [PeerDB], and some of them are dialed when
+	// the network starts.
+	PersistPeersEnabled bool `json:"persistPeersEnabled"`
+	// Frequency at which the IPs of connected peers are persisted
+	PersistPeersFreq time.Duration `json:"persistPeersFreq"`
+	// Persisted peers that weren't connected to within this duration are
+	// removed, and never dialed.
+	PersistedPeerTTL time.Duration `json:"persistedPeerTTL"`
+	// Max number of persisted peers dialed when the network starts
+	MaxPersistedPeersToDial int `json:"maxPersistedPeersToDial"`
+}
+
+// persistedPeer is a peer whose IP was persisted to the database
+type persistedPeer struct {
+	nodeID ids.ShortID
+	ip     utils.IPDesc
+	// Last time we were connected to this peer
+	lastSeen time.Time
+}
+
+// Returns true if peer IPs should be persisted and dialed.
+func (n *network) persistPeersEnabled() bool {
+	return n.config.PersistPeersEnabled && n.config.PeerDB != nil
+}
+
+// Periodically persists the IPs of connected peers.
+// Assumes [n.stateLock] is not held. Only returns after the network is closed.
+func (n *network) persistPeersPeriodically() {
+	t := time.NewTicker(n.config.PersistPeersFreq)
+	defer t.Stop()
+
+	for range t.C {
+		if n.closed.GetValue() {
+			return
+		}
+		if err := n.persistPeers(); err != nil {
+			n.log.Error("failed to persist peers: %s", err)
+		}
+	}
+}
+
+// Persists the IPs of the peers we finished the handshake with, and removes
+// the persisted peers we haven't been connected to within the TTL.
+// Assumes [n.stateLock] is not held.
+func (n *network) persistPeers() error {
+	now := n.clock.Time()
+	n.stateLock.RLock()
+	peers := make([]persistedPeer, 0, n.peers.size())
+	for _, peer := range n.peers.peersList {
+		ip := peer.getIP()
+		if !peer.finishedHandshake.GetValue() || ip.IsZero() {
+			continue
+		}
+		peers = append(peers, persistedPeer{
+			nodeID:   peer.nodeID,
+			ip:       ip,
+			lastSeen: now,
+		})
+	}
+	n.stateLock.RUnlock()
+
+	_, stale, err := n.getPersistedPeers()
+	if err != nil {
+		return err
+	}
+
+	batch := n.config.PeerDB.NewBatch()
+	for _, nodeID := range stale {
+		if err := batch.Delete(nodeID.Bytes()); err != nil {
+			return err
+		}
+	}
+	for _, peer := range peers {
+		p := wrappers.Packer{MaxSize: persistedPeerLen}
+		p.PackIP(peer.ip)
+		p.PackLong(uint64(peer.lastSeen.Unix()))
+		if p.Errored() {
+			return p.Err
+		}
+		if err := batch.Put(peer.nodeID.Bytes(), p.Bytes); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// Returns the persisted peers that were connected to within the TTL, and the
+// IDs of the persisted peers that weren't.
+// Assumes [n.stateLock] is not held.
+func (n *network) getPersistedPeers() ([]persistedPeer, []ids.ShortID, error) {
+	cutoff := n.clock.Time().Add(-n.config.PersistedPeerTTL)
+
+	it := n.config.PeerDB.NewIterator()
+	defer it.Release()
+
+	var (
+		peers []persistedPeer
+		stale []ids.ShortID
+	)
+	for it.Next() {
+		nodeID, err := ids.ToShortID(it.Key())
+		if err != nil {
+			return nil, nil, err
+		}
+		p := wrappers.Packer{Bytes: it.Value()}
+		ip := p.UnpackIP()
+		lastSeen := time.Unix(int64(p.UnpackLong()), 0)
+		if p.Errored() || lastSeen.Before(cutoff) {
+			stale = append(stale, nodeID)
+			continue
+		}
+		peers = append(peers, persistedPeer{
+			nodeID:   nodeID,
+			ip:       ip,
+			lastSeen: lastSeen,
+		})
+	}
+	return peers, stale, it.Error()
+}
+
+// Attempts to connect to a random sample of the persisted peers. Unlike
+// tracked IPs, each persisted peer is only dialed once.
+// Assumes [n.stateLock] is not held.
+func (n *network) dialPersistedPeers() {
+	peers, _, err := n.getPersistedPeers()
+	if err != nil {
+		n.log.Error("failed to read persisted peers: %s", err)
+		return
+	}
+
+	numToDial := n.config.MaxPersistedPeersToDial
+	if len(peers) < numToDial {
+		numToDial = len(peers)
+	}
+	if numToDial <= 0 {
+		return
+	}
+
+	s := sampler.NewUniform()
+	if err := s.Initialize(uint64(len(peers))); err != nil {
+		n.log.Error("failed to sample persisted peers: %s", err)
+		return
+	}
+	indices, err := s.Sample(numToDial)
+	if err != nil {
+		n.log.Error("failed to sample persisted peers: %s", err)
+		return
+	}
+
+	n.log.Info("attempting to connect to %d of %d persisted peers", numToDial, len(peers))
+	for _, index := range indices {
+		peer := peers[index]
+		if peer.nodeID == n.config.MyNodeID || !n.shouldDialPersistedPeer(peer.ip) {
+			continue
+		}
+		go func() {
+			if err := n.attemptConnect(context.Background(), peer.ip); err != nil {
+				n.log.Verbo("failed to connect to persisted peer %s at %s: %s", peer.nodeID, peer.ip, err)
+			}
+		}()
+	}
+}
+
+// Returns false if we're already connected, or attempting to connect, to
+// [ip], or if [ip] is one of our IPs.
+// Assumes [n.stateLock] is not held.
+func (n *network) shouldDialPersistedPeer(ip utils.IPDesc) bool {
+	n.stateLock.RLock()
+	defer n.stateLock.RUnlock()
+
+	str := ip.String()
+	_, isDisconnected := n.disconnectedIPs[str]
+	_, isConnected := n.connectedIPs[str]
+	_, isAlias := n.peerAliasIPs[str]
+	_, isMyself := n.myIPs[str]
+	return !isDisconnected && !isConnected && !isAlias && !isMyself
+}
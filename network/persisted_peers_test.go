@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func newPersistedPeersTestNetwork(t *testing.T, dialer *refusingDialer) *network {
+	n := &network{
+		log:             logging.NoLog{},
+		dialer:          dialer,
+		disconnectedIPs: make(map[string]struct{}),
+		connectedIPs:    make(map[string]struct{}),
+		peerAliasIPs:    make(map[string]struct{}),
+		myIPs:           make(map[string]struct{}),
+		config: &Config{
+			PeerPersistenceConfig: PeerPersistenceConfig{
+				PersistPeersEnabled:     true,
+				PersistPeersFreq:        time.Minute,
+				PersistedPeerTTL:        time.Hour,
+				MaxPersistedPeersToDial: 2,
+			},
+			PeerDB: memdb.New(),
+		},
+	}
+	n.peers.initialize()
+	assert.NoError(t, n.metrics.initialize("", prometheus.NewRegistry()))
+	n.clock.Set(time.Now())
+	return n
+}
+
+// Ensure that the IPs of peers that finished the handshake are persisted, and
+// that stale peers are pruned.
+func TestPersistPeers(t *testing.T) {
+	assert := assert.New(t)
+
+	n := newPersistedPeersTestNetwork(t, nil)
+	assert.True(n.persistPeersEnabled())
+
+	peer0 := createPeer(ids.GenerateTestShortID(), utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}, version.CurrentApp)
+	peer1 := createPeer(ids.GenerateTestShortID(), utils.IPDesc{IP: net.ParseIP("2001:db8::1"), Port: 9651}, version.CurrentApp)
+	unfinishedPeer := createPeer(ids.GenerateTestShortID(), utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}, version.CurrentApp)
+	unfinishedPeer.finishedHandshake.SetValue(false)
+	n.peers.add(peer0)
+	n.peers.add(peer1)
+	n.peers.add(unfinishedPeer)
+
+	assert.NoError(n.persistPeers())
+	peers, stale, err := n.getPersistedPeers()
+	assert.NoError(err)
+	assert.Empty(stale)
+	assert.Len(peers, 2)
+	persistedIPs := make(map[ids.ShortID]utils.IPDesc)
+	for _, peer := range peers {
+		persistedIPs[peer.nodeID] = peer.ip
+	}
+	assert.True(peer0.ip.Equal(persistedIPs[peer0.nodeID]))
+	assert.True(peer1.ip.Equal(persistedIPs[peer1.nodeID]))
+
+	// [peer1] disconnects, and isn't seen again within the TTL
+	n.peers.remove(peer1)
+	n.clock.Set(n.clock.Time().Add(time.Hour + time.Second))
+
+	peers, stale, err = n.getPersistedPeers()
+	assert.NoError(err)
+	assert.Empty(peers)
+	assert.Len(stale, 2)
+
+	assert.NoError(n.persistPeers())
+	peers, stale, err = n.getPersistedPeers()
+	assert.NoError(err)
+	assert.Empty(stale)
+	assert.Len(peers, 1)
+	assert.Equal(peer0.nodeID, peers[0].nodeID)
+
+	has, err := n.config.PeerDB.Has(peer1.nodeID.Bytes())
+	assert.NoError(err)
+	assert.False(has)
+}
+
+// Ensure that only up to [MaxPersistedPeersToDial] persisted peers are dialed,
+// and that peers we're already connected to aren't dialed.
+func TestDialPersistedPeers(t *testing.T) {
+	assert := assert.New(t)
+
+	dialer := &refusingDialer{dialed: make(map[string]int)}
+	n := newPersistedPeersTestNetwork(t, dialer)
+
+	ips := []utils.IPDesc{
+		{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+		{IP: net.IPv4(1, 2, 3, 5), Port: 9651},
+		{IP: net.IPv4(1, 2, 3, 6), Port: 9651},
+	}
+	for _, ip := range ips {
+		n.peers.add(createPeer(ids.GenerateTestShortID(), ip, version.CurrentApp))
+	}
+	assert.NoError(n.persistPeers())
+	n.peers.reset()
+
+	numDialed := func() int {
+		dialer.lock.Lock()
+		defer dialer.lock.Unlock()
+		return len(dialer.dialed)
+	}
+
+	n.dialPersistedPeers()
+	assert.Eventually(func() bool {
+		return numDialed() == 2
+	}, time.Second, time.Millisecond)
+
+	// Persisted peers we're connected to aren't dialed
+	dialer.lock.Lock()
+	dialer.dialed = make(map[string]int)
+	dialer.lock.Unlock()
+	n.config.MaxPersistedPeersToDial = len(ips)
+	n.connectedIPs[ips[0].String()] = struct{}{}
+	n.dialPersistedPeers()
+	assert.Eventually(func() bool {
+		return numDialed() == 2
+	}, time.Second, time.Millisecond)
+	assert.Zero(dialer.numDialed(ips[0]))
+}
@@ -0,0 +1,310 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// Max length of a PROXY protocol v1 header, including the CRLF
+	proxyV1MaxHeaderLen = 107
+	// Max length of the part of a PROXY protocol v2 header that follows the
+	// fixed-size prefix. Long enough for IPv6 addresses and the TLVs added by
+	// common load balancers.
+	proxyV2MaxPayloadLen = 512
+	// Length of the fixed-size prefix of a PROXY protocol v2 header: the
+	// signature, the version and command, the address family and protocol,
+	// and the payload length.
+	proxyV2PrefixLen = 16
+
+	proxyV2Version      = 0x2
+	proxyV2CommandLocal = 0x0
+	proxyV2CommandProxy = 0x1
+	proxyV2TCP4         = 0x11
+	proxyV2TCP6         = 0x21
+	proxyV2TCP4AddrLen  = 2*net.IPv4len + 4
+	proxyV2TCP6AddrLen  = 2*net.IPv6len + 4
+)
+
+var (
+	proxyV1Prefix    = []byte("PROXY ")
+	proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+	errMissingProxyHeader   = errors.New("connection doesn't start with a PROXY protocol header")
+	errProxyHeaderTooLong   = errors.New("PROXY protocol header is too long")
+	errMalformedProxyHeader = errors.New("malformed PROXY protocol header")
+
+	_ net.Listener = &proxyProtocolListener{}
+	_ net.Conn     = &proxyProtocolConn{}
+)
+
+// ProxyProtocolConfig describes whether inbound connections are expected to
+// come through a load balancer that sends the address of the client in a
+// PROXY protocol (v1 or v2) header.
+type ProxyProtocolConfig struct {
+	// If true, each inbound connection must start with a PROXY protocol
+	// header, and the client address it contains is used as the address of
+	// the peer. Connections without a valid header are closed. This node
+	// should then only be reachable through the load balancer, as the header
+	// can be forged by any client.
+	ProxyProtocolEnabled bool `json:"proxyProtocolEnabled"`
+	// Max time to wait for the PROXY protocol header of an inbound connection
+	ProxyHeaderTimeout time.Duration `json:"proxyHeaderTimeout"`
+}
+
+// NewProxyProtocolListener wraps [listener] and returns a net.Listener whose
+// connections report the client address sent in their PROXY protocol header
+// as their remote address. Connections that don't send a valid header within
+// [headerTimeout] are closed, and aren't returned by Accept.
+func NewProxyProtocolListener(listener net.Listener, headerTimeout time.Duration, log logging.Logger) net.Listener {
+	l := &proxyProtocolListener{
+		listener:      listener,
+		headerTimeout: headerTimeout,
+		log:           log,
+		conns:         make(chan net.Conn),
+		acceptDone:    make(chan struct{}),
+		closed:        make(chan struct{}),
+	}
+	go l.acceptConns()
+	return l
+}
+
+// [proxyProtocolListener] reads the PROXY protocol header of each accepted
+// connection on its own goroutine, so that a client that is slow to send its
+// header doesn't delay the acceptance of other connections.
+type proxyProtocolListener struct {
+	listener      net.Listener
+	headerTimeout time.Duration
+	log           logging.Logger
+
+	// Connections whose header has been read
+	conns chan net.Conn
+	// Closed when the underlying listener returns a non-temporary error,
+	// which is then [acceptErr]
+	acceptDone chan struct{}
+	acceptErr  error
+	// Closed when Close() is called
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *proxyProtocolListener) acceptConns() {
+	defer close(l.acceptDone)
+
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			l.acceptErr = err
+			return
+		}
+		go l.readHeader(conn)
+	}
+}
+
+func (l *proxyProtocolListener) readHeader(conn net.Conn) {
+	remoteAddr := conn.RemoteAddr()
+	if err := conn.SetReadDeadline(time.Now().Add(l.headerTimeout)); err != nil {
+		l.log.Verbo("error setting the PROXY protocol header read deadline on the connection from %s: %s", remoteAddr, err)
+		_ = conn.Close()
+		return
+	}
+	reader := bufio.NewReader(conn)
+	clientAddr, err := readProxyHeader(reader)
+	if err != nil {
+		l.log.Debug("closing the connection from %s: %s", remoteAddr, err)
+		_ = conn.Close()
+		return
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		l.log.Verbo("error clearing the PROXY protocol header read deadline on the connection from %s: %s", remoteAddr, err)
+		_ = conn.Close()
+		return
+	}
+	if clientAddr == nil {
+		// The load balancer didn't proxy this connection, e.g. for a health
+		// check, so the connection's address is the actual remote address.
+		clientAddr = remoteAddr
+	}
+
+	proxiedConn := &proxyProtocolConn{
+		Conn:       conn,
+		reader:     reader,
+		remoteAddr: clientAddr,
+	}
+	select {
+	case l.conns <- proxiedConn:
+	case <-l.closed:
+		_ = conn.Close()
+	}
+}
+
+func (l *proxyProtocolListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.acceptDone:
+		return nil, l.acceptErr
+	}
+}
+
+func (l *proxyProtocolListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closed) })
+	return l.listener.Close()
+}
+
+func (l *proxyProtocolListener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// [proxyProtocolConn] is a connection whose PROXY protocol header has been
+// read. Its remote address is the one sent in the header.
+type proxyProtocolConn struct {
+	net.Conn
+	// Contains the bytes read from [Conn] after the header
+	reader     *bufio.Reader
+	remoteAddr net.Addr
+}
+
+func (c *proxyProtocolConn) Read(b []byte) (int, error) { return c.reader.Read(b) }
+
+func (c *proxyProtocolConn) RemoteAddr() net.Addr { return c.remoteAddr }
+
+// Returns the TCP connection underlying [conn], if any.
+func tcpConnOf(conn net.Conn) (*net.TCPConn, bool) {
+	if proxiedConn, ok := conn.(*proxyProtocolConn); ok {
+		conn = proxiedConn.Conn
+	}
+	tcpConn, ok := conn.(*net.TCPConn)
+	return tcpConn, ok
+}
+
+// Reads a PROXY protocol v1 or v2 header from [r], and returns the client
+// address it contains. Returns a nil address if the header doesn't contain the
+// client address, as the connection wasn't proxied on behalf of a client.
+// Returns an error if [r] doesn't start with a valid header. Doesn't read past
+// the end of the header.
+func readProxyHeader(r *bufio.Reader) (net.Addr, error) {
+	prefix, err := r.Peek(len(proxyV2Signature))
+	switch {
+	case bytes.Equal(prefix, proxyV2Signature):
+		return readProxyV2Header(r)
+	case bytes.HasPrefix(prefix, proxyV1Prefix):
+		return readProxyV1Header(r)
+	case err != nil:
+		return nil, fmt.Errorf("couldn't read PROXY protocol header: %w", err)
+	default:
+		return nil, errMissingProxyHeader
+	}
+}
+
+// Reads a header of the form:
+// "PROXY TCP4 <client IP> <proxy IP> <client port> <proxy port>\r\n"
+func readProxyV1Header(r *bufio.Reader) (net.Addr, error) {
+	line := make([]byte, 0, proxyV1MaxHeaderLen)
+	for !bytes.HasSuffix(line, []byte("\r\n")) {
+		if len(line) == proxyV1MaxHeaderLen {
+			return nil, errProxyHeaderTooLong
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read PROXY protocol header: %w", err)
+		}
+		line = append(line, b)
+	}
+
+	fields := strings.Split(string(line[:len(line)-2]), " ")
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		// The rest of the line must be ignored
+		return nil, nil
+	}
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("%w: expected 6 fields but got %d", errMalformedProxyHeader, len(fields))
+	}
+
+	ip := net.ParseIP(fields[2])
+	switch {
+	case ip == nil:
+		return nil, fmt.Errorf("%w: invalid client IP %q", errMalformedProxyHeader, fields[2])
+	case fields[1] == "TCP4" && ip.To4() == nil, fields[1] == "TCP6" && ip.To4() != nil:
+		return nil, fmt.Errorf("%w: client IP %q doesn't match protocol %s", errMalformedProxyHeader, fields[2], fields[1])
+	case fields[1] != "TCP4" && fields[1] != "TCP6":
+		return nil, fmt.Errorf("%w: unknown protocol %q", errMalformedProxyHeader, fields[1])
+	}
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid client port %q", errMalformedProxyHeader, fields[4])
+	}
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
+
+// Reads a binary header, which follows the format described in section 2.2 of
+// the PROXY protocol specification.
+func readProxyV2Header(r *bufio.Reader) (net.Addr, error) {
+	prefix := make([]byte, proxyV2PrefixLen)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, fmt.Errorf("couldn't read PROXY protocol header: %w", err)
+	}
+	versionAndCommand := prefix[12]
+	family := prefix[13]
+	payloadLen := int(binary.BigEndian.Uint16(prefix[14:]))
+
+	if version := versionAndCommand >> 4; version != proxyV2Version {
+		return nil, fmt.Errorf("%w: unknown version %d", errMalformedProxyHeader, version)
+	}
+	if payloadLen > proxyV2MaxPayloadLen {
+		return nil, fmt.Errorf("%w: payload is %d bytes", errProxyHeaderTooLong, payloadLen)
+	}
+	payload := make([]byte, payloadLen)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("couldn't read PROXY protocol header: %w", err)
+	}
+
+	switch command := versionAndCommand & 0xf; command {
+	case proxyV2CommandLocal:
+		return nil, nil
+	case proxyV2CommandProxy:
+	default:
+		return nil, fmt.Errorf("%w: unknown command %d", errMalformedProxyHeader, command)
+	}
+
+	var ipLen int
+	switch family {
+	case proxyV2TCP4:
+		if payloadLen < proxyV2TCP4AddrLen {
+			return nil, fmt.Errorf("%w: payload is too short for IPv4 addresses", errMalformedProxyHeader)
+		}
+		ipLen = net.IPv4len
+	case proxyV2TCP6:
+		if payloadLen < proxyV2TCP6AddrLen {
+			return nil, fmt.Errorf("%w: payload is too short for IPv6 addresses", errMalformedProxyHeader)
+		}
+		ipLen = net.IPv6len
+	default:
+		// The addresses of other families and protocols must be ignored
+		return nil, nil
+	}
+	// The payload starts with the client IP, the proxy IP, the client port and
+	// the proxy port.
+	ip := make(net.IP, ipLen)
+	copy(ip, payload[:ipLen])
+	port := binary.BigEndian.Uint16(payload[2*ipLen:])
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
@@ -0,0 +1,241 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Returns a PROXY protocol v2 header with [versionAndCommand], [family] and
+// [payload], whose payload length is [payloadLen].
+func proxyV2Header(versionAndCommand, family byte, payloadLen uint16, payload []byte) []byte {
+	header := append([]byte{}, proxyV2Signature...)
+	header = append(header, versionAndCommand, family)
+	header = append(header, 0, 0)
+	binary.BigEndian.PutUint16(header[len(header)-2:], payloadLen)
+	return append(header, payload...)
+}
+
+func TestReadProxyHeader(t *testing.T) {
+	tcp4Payload := []byte{
+		1, 2, 3, 4, // client IP
+		5, 6, 7, 8, // proxy IP
+		0x30, 0x39, // client port
+		0x25, 0x8b, // proxy port
+	}
+	tcp6Payload := make([]byte, proxyV2TCP6AddrLen)
+	copy(tcp6Payload, net.ParseIP("2001:db8::1"))
+	copy(tcp6Payload[2*net.IPv6len:], []byte{0x30, 0x39})
+	// A TLV added by the load balancer
+	tlvPayload := append(append([]byte{}, tcp4Payload...), 0xea, 0, 3, 1, 2, 3)
+
+	tests := []struct {
+		name         string
+		header       []byte
+		expectedAddr net.Addr
+		expectedErr  error
+	}{
+		{
+			name:         "v1 TCP4",
+			header:       []byte("PROXY TCP4 1.2.3.4 5.6.7.8 12345 9651\r\n"),
+			expectedAddr: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 12345},
+		},
+		{
+			name:         "v1 TCP6",
+			header:       []byte("PROXY TCP6 2001:db8::1 2001:db8::2 12345 9651\r\n"),
+			expectedAddr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 12345},
+		},
+		{
+			name:   "v1 unknown",
+			header: []byte("PROXY UNKNOWN ignored\r\n"),
+		},
+		{
+			name:        "v1 truncated",
+			header:      []byte("PROXY TCP4 1.2.3.4 5.6.7.8 12345"),
+			expectedErr: io.EOF,
+		},
+		{
+			name:        "v1 oversized",
+			header:      []byte("PROXY TCP4 " + strings.Repeat("1", proxyV1MaxHeaderLen) + "\r\n"),
+			expectedErr: errProxyHeaderTooLong,
+		},
+		{
+			name:        "v1 missing field",
+			header:      []byte("PROXY TCP4 1.2.3.4 5.6.7.8 12345\r\n"),
+			expectedErr: errMalformedProxyHeader,
+		},
+		{
+			name:        "v1 invalid IP",
+			header:      []byte("PROXY TCP4 1.2.3 5.6.7.8 12345 9651\r\n"),
+			expectedErr: errMalformedProxyHeader,
+		},
+		{
+			name:        "v1 IP doesn't match protocol",
+			header:      []byte("PROXY TCP4 2001:db8::1 2001:db8::2 12345 9651\r\n"),
+			expectedErr: errMalformedProxyHeader,
+		},
+		{
+			name:        "v1 unknown protocol",
+			header:      []byte("PROXY UDP4 1.2.3.4 5.6.7.8 12345 9651\r\n"),
+			expectedErr: errMalformedProxyHeader,
+		},
+		{
+			name:        "v1 invalid port",
+			header:      []byte("PROXY TCP4 1.2.3.4 5.6.7.8 65536 9651\r\n"),
+			expectedErr: errMalformedProxyHeader,
+		},
+		{
+			name:         "v2 TCP4",
+			header:       proxyV2Header(0x21, proxyV2TCP4, uint16(len(tcp4Payload)), tcp4Payload),
+			expectedAddr: &net.TCPAddr{IP: net.IP{1, 2, 3, 4}, Port: 12345},
+		},
+		{
+			name:         "v2 TCP6",
+			header:       proxyV2Header(0x21, proxyV2TCP6, uint16(len(tcp6Payload)), tcp6Payload),
+			expectedAddr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 12345},
+		},
+		{
+			name:         "v2 TLVs",
+			header:       proxyV2Header(0x21, proxyV2TCP4, uint16(len(tlvPayload)), tlvPayload),
+			expectedAddr: &net.TCPAddr{IP: net.IP{1, 2, 3, 4}, Port: 12345},
+		},
+		{
+			name:   "v2 local",
+			header: proxyV2Header(0x20, 0, 0, nil),
+		},
+		{
+			name:   "v2 unspecified family",
+			header: proxyV2Header(0x21, 0, 0, nil),
+		},
+		{
+			name:        "v2 truncated prefix",
+			header:      proxyV2Header(0x21, proxyV2TCP4, uint16(len(tcp4Payload)), nil)[:proxyV2PrefixLen-1],
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:        "v2 truncated payload",
+			header:      proxyV2Header(0x21, proxyV2TCP4, uint16(len(tcp4Payload)), tcp4Payload[:8]),
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:        "v2 oversized",
+			header:      proxyV2Header(0x21, proxyV2TCP4, proxyV2MaxPayloadLen+1, make([]byte, proxyV2MaxPayloadLen+1)),
+			expectedErr: errProxyHeaderTooLong,
+		},
+		{
+			name:        "v2 payload too short for family",
+			header:      proxyV2Header(0x21, proxyV2TCP6, uint16(len(tcp4Payload)), tcp4Payload),
+			expectedErr: errMalformedProxyHeader,
+		},
+		{
+			name:        "v2 unknown version",
+			header:      proxyV2Header(0x11, proxyV2TCP4, uint16(len(tcp4Payload)), tcp4Payload),
+			expectedErr: errMalformedProxyHeader,
+		},
+		{
+			name:        "v2 unknown command",
+			header:      proxyV2Header(0x22, proxyV2TCP4, uint16(len(tcp4Payload)), tcp4Payload),
+			expectedErr: errMalformedProxyHeader,
+		},
+		{
+			name:        "missing header",
+			header:      []byte{0x16, 0x03, 0x01, 0x02, 0x00, 0x01, 0x00, 0x01, 0xfc, 0x03, 0x03, 0x00, 0x00},
+			expectedErr: errMissingProxyHeader,
+		},
+		{
+			name:        "empty",
+			expectedErr: io.EOF,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			// The bytes sent after the header must still be readable
+			data := []byte("client hello")
+			r := bufio.NewReader(bytes.NewReader(append(append([]byte{}, test.header...), data...)))
+			if test.expectedErr != nil {
+				// Don't let the data complete a truncated header
+				r = bufio.NewReader(bytes.NewReader(test.header))
+			}
+
+			addr, err := readProxyHeader(r)
+			assert.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			if test.expectedAddr == nil {
+				assert.Nil(addr)
+			} else {
+				assert.Equal(test.expectedAddr.String(), addr.String())
+			}
+			rest, err := io.ReadAll(r)
+			assert.NoError(err)
+			assert.Equal(data, rest)
+		})
+	}
+}
+
+func TestProxyProtocolListener(t *testing.T) {
+	assert := assert.New(t)
+
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	listener := NewProxyProtocolListener(tcpListener, 100*time.Millisecond, logging.NoLog{})
+	defer listener.Close()
+
+	dial := func(data []byte) net.Conn {
+		conn, err := net.Dial("tcp", tcpListener.Addr().String())
+		assert.NoError(err)
+		_, err = conn.Write(data)
+		assert.NoError(err)
+		return conn
+	}
+
+	// Connections without a header, or that don't send their header in time,
+	// are closed without being accepted
+	missingHeaderConn := dial([]byte("not a PROXY protocol header"))
+	defer missingHeaderConn.Close()
+	slowConn := dial(nil)
+	defer slowConn.Close()
+	for _, conn := range []net.Conn{missingHeaderConn, slowConn} {
+		assert.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		_, err := conn.Read(make([]byte, 1))
+		assert.Error(err)
+		if netErr, ok := err.(net.Error); ok {
+			assert.False(netErr.Timeout())
+		}
+	}
+
+	clientConn := dial([]byte("PROXY TCP4 1.2.3.4 5.6.7.8 12345 9651\r\nclient hello"))
+	defer clientConn.Close()
+
+	conn, err := listener.Accept()
+	assert.NoError(err)
+	defer conn.Close()
+	assert.Equal("1.2.3.4:12345", conn.RemoteAddr().String())
+	_, ok := tcpConnOf(conn)
+	assert.True(ok)
+
+	data := make([]byte, len("client hello"))
+	_, err = io.ReadFull(conn, data)
+	assert.NoError(err)
+	assert.Equal("client hello", string(data))
+
+	// Closing the listener unblocks Accept
+	assert.NoError(listener.Close())
+	_, err = listener.Accept()
+	assert.Error(err)
+}
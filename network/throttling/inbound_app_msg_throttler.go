@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type InboundAppMsgThrottlerConfig struct {
+	// Rate, in bytes per second, at which the app message bytes consumable
+	// by a peer replenish. If 0, app message bytes aren't rate-limited.
+	BytesRefillRate uint64 `json:"appBytesRefillRate"`
+	// Max number of app message bytes that can accumulate for a given peer.
+	// Must be at least [MaxMsgSize].
+	BytesMaxBurstSize uint64 `json:"appBytesMaxBurstSize"`
+	// Rate, in messages per second, at which the app messages consumable by
+	// a peer replenish. If 0, the number of app messages isn't rate-limited.
+	MsgRefillRate uint64 `json:"appMsgRefillRate"`
+	// Max number of app messages that can accumulate for a given peer
+	MsgMaxBurstSize uint64 `json:"appMsgMaxBurstSize"`
+	// Max size, in bytes, of an inbound app message
+	MaxMsgSize uint64 `json:"appMaxMsgSize"`
+	// Max number of app messages from a given peer that can be processing at
+	// once. If 0, the number of processing app messages isn't limited.
+	MaxProcessingMsgs uint64 `json:"appMaxProcessingMsgs"`
+	// Max number of bytes of app messages from a given peer that can be
+	// processing at once. If 0, the processing app message bytes aren't
+	// limited.
+	MaxProcessingBytes uint64 `json:"appMaxProcessingBytes"`
+}
+
+// Rate-limits inbound AppRequest, AppResponse and AppGossip messages using a
+// per-node token bucket for bytes and another for messages, and limits the
+// number and size of the app messages from each node that are processing.
+// App messages don't take space on the buffers shared by the other inbound
+// messages. Unlike the other inbound throttlers, it never blocks. Messages
+// that don't fit in their sender's budget are dropped, so that app messages
+// never delay the reading of the consensus messages sent after them, even if
+// the VM is slow to handle them.
+type inboundAppMsgThrottler struct {
+	InboundAppMsgThrottlerConfig
+	metrics inboundAppMsgThrottlerMetrics
+	log     logging.Logger
+	lock    sync.Mutex
+	// Node ID --> Rate limiters of the app messages from this node
+	limiters map[ids.ShortID]*appMsgLimiters
+}
+
+type appMsgLimiters struct {
+	// Each token is a byte of an app message
+	bytes *rate.Limiter
+	// Each token is an app message
+	msgs *rate.Limiter
+	// Number of app messages from this node, and their size in bytes, that
+	// are currently processing
+	processingMsgs, processingBytes uint64
+}
+
+func newInboundAppMsgThrottler(
+	log logging.Logger,
+	namespace string,
+	registerer prometheus.Registerer,
+	config InboundAppMsgThrottlerConfig,
+) (*inboundAppMsgThrottler, error) {
+	t := &inboundAppMsgThrottler{
+		InboundAppMsgThrottlerConfig: config,
+		log:                          log,
+		limiters:                     make(map[ids.ShortID]*appMsgLimiters),
+	}
+	return t, t.metrics.initialize(namespace, registerer)
+}
+
+// Returns true if an app message of size [msgSize] from [nodeID] fits in the
+// node's app message budget, in which case the budget is consumed.
+// Returns false, without blocking, if the message should be dropped.
+// If this returns true, Release([msgSize], [nodeID]) must be called (!) when
+// done with the message or when we give up trying to read the message.
+func (t *inboundAppMsgThrottler) Acquire(msgSize uint64, nodeID ids.ShortID) bool {
+	if msgSize > t.MaxMsgSize {
+		t.metrics.oversizedMsgs.Inc()
+		return false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	limiters, ok := t.limiters[nodeID]
+	if !ok {
+		// This should never happen. If it is, the caller is misusing this struct.
+		t.log.Debug("tried to acquire %d app message bytes for %s but that node isn't registered", msgSize, nodeID.PrefixedString(constants.NodeIDPrefix))
+		return true
+	}
+
+	if (t.MaxProcessingMsgs != 0 && limiters.processingMsgs >= t.MaxProcessingMsgs) ||
+		(t.MaxProcessingBytes != 0 && limiters.processingBytes+msgSize > t.MaxProcessingBytes) {
+		t.metrics.bufferFullMsgs.Inc()
+		return false
+	}
+
+	// Only consume from the byte budget if the message budget has room too,
+	// so that a dropped message doesn't cost its sender anything.
+	now := time.Now()
+	msgReservation := limiters.msgs.ReserveN(now, 1)
+	if !msgReservation.OK() || msgReservation.DelayFrom(now) > 0 {
+		msgReservation.CancelAt(now)
+		t.metrics.droppedMsgs.Inc()
+		return false
+	}
+	if !limiters.bytes.AllowN(now, int(msgSize)) {
+		msgReservation.CancelAt(now)
+		t.metrics.droppedMsgs.Inc()
+		return false
+	}
+	limiters.processingMsgs++
+	limiters.processingBytes += msgSize
+	return true
+}
+
+// Must correspond to a previous call of Acquire([msgSize], [nodeID]) that
+// returned true.
+func (t *inboundAppMsgThrottler) Release(msgSize uint64, nodeID ids.ShortID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	limiters, ok := t.limiters[nodeID]
+	if !ok {
+		// The node was removed while the message was processing
+		return
+	}
+	// The node may have been removed and re-added while the message was
+	// processing, in which case the message isn't accounted for anymore.
+	if limiters.processingMsgs > 0 {
+		limiters.processingMsgs--
+	}
+	if limiters.processingBytes >= msgSize {
+		limiters.processingBytes -= msgSize
+	} else {
+		limiters.processingBytes = 0
+	}
+}
+
+// Must be called before Acquire(..., [nodeID]) is called.
+func (t *inboundAppMsgThrottler) AddNode(nodeID ids.ShortID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.limiters[nodeID]; ok {
+		t.log.Debug("tried to add %s but it's already registered", nodeID.PrefixedString(constants.NodeIDPrefix))
+	}
+	t.limiters[nodeID] = &appMsgLimiters{
+		bytes: newAppMsgLimiter(t.BytesRefillRate, t.BytesMaxBurstSize),
+		msgs:  newAppMsgLimiter(t.MsgRefillRate, t.MsgMaxBurstSize),
+	}
+}
+
+// Must be called when we stop reading messages from [nodeID].
+func (t *inboundAppMsgThrottler) RemoveNode(nodeID ids.ShortID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.limiters[nodeID]; !ok {
+		t.log.Debug("tried to remove %s but it isn't registered", nodeID.PrefixedString(constants.NodeIDPrefix))
+	}
+	delete(t.limiters, nodeID)
+}
+
+// Returns a token bucket that refills at [refillRate] tokens per second and
+// holds up to [maxBurstSize] tokens. If [refillRate] is 0, the returned
+// limiter never runs out of tokens.
+func newAppMsgLimiter(refillRate, maxBurstSize uint64) *rate.Limiter {
+	if refillRate == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(refillRate), int(maxBurstSize))
+}
+
+type inboundAppMsgThrottlerMetrics struct {
+	droppedMsgs    prometheus.Counter
+	oversizedMsgs  prometheus.Counter
+	bufferFullMsgs prometheus.Counter
+}
+
+func (m *inboundAppMsgThrottlerMetrics) initialize(namespace string, reg prometheus.Registerer) error {
+	m.droppedMsgs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "app_throttler_inbound_dropped_msgs",
+		Help:      "Number of inbound app messages dropped because their sender exceeded its app message budget",
+	})
+	m.oversizedMsgs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "app_throttler_inbound_oversized_msgs",
+		Help:      "Number of inbound app messages dropped because they exceeded the max app message size",
+	})
+	m.bufferFullMsgs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "app_throttler_inbound_buffer_full_msgs",
+		Help:      "Number of inbound app messages dropped because too many app messages from their sender were processing",
+	})
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.droppedMsgs),
+		reg.Register(m.oversizedMsgs),
+		reg.Register(m.bufferFullMsgs),
+	)
+	return errs.Err
+}
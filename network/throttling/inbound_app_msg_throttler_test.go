@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestInboundAppMsgThrottler(t *testing.T) {
+	assert := assert.New(t)
+	throttler, err := newInboundAppMsgThrottler(
+		&logging.Log{},
+		"",
+		prometheus.NewRegistry(),
+		InboundAppMsgThrottlerConfig{
+			BytesRefillRate:   1,
+			BytesMaxBurstSize: 10,
+			MsgRefillRate:     1,
+			MsgMaxBurstSize:   3,
+			MaxMsgSize:        8,
+		},
+	)
+	assert.NoError(err)
+
+	nodeID1, nodeID2 := ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	throttler.AddNode(nodeID1)
+	throttler.AddNode(nodeID2)
+
+	// Messages larger than the max app message size are always dropped
+	assert.False(throttler.Acquire(9, nodeID1))
+	assert.EqualValues(1, testutil.ToFloat64(throttler.metrics.oversizedMsgs))
+	assert.EqualValues(0, testutil.ToFloat64(throttler.metrics.droppedMsgs))
+
+	// Exhaust [nodeID1]'s byte budget
+	assert.True(throttler.Acquire(8, nodeID1))
+	assert.False(throttler.Acquire(8, nodeID1))
+	assert.EqualValues(1, testutil.ToFloat64(throttler.metrics.droppedMsgs))
+
+	// The dropped message shouldn't have used any of the message budget
+	assert.True(throttler.Acquire(1, nodeID1))
+	assert.True(throttler.Acquire(1, nodeID1))
+
+	// Exhaust [nodeID1]'s message budget
+	assert.False(throttler.Acquire(0, nodeID1))
+	assert.EqualValues(2, testutil.ToFloat64(throttler.metrics.droppedMsgs))
+
+	// [nodeID2] has its own budget
+	assert.True(throttler.Acquire(8, nodeID2))
+
+	// Re-adding a node resets its budget
+	throttler.RemoveNode(nodeID1)
+	assert.Len(throttler.limiters, 1)
+	throttler.AddNode(nodeID1)
+	assert.True(throttler.Acquire(8, nodeID1))
+}
+
+func TestInboundAppMsgThrottlerProcessingLimits(t *testing.T) {
+	assert := assert.New(t)
+	throttler, err := newInboundAppMsgThrottler(
+		&logging.Log{},
+		"",
+		prometheus.NewRegistry(),
+		InboundAppMsgThrottlerConfig{
+			MaxMsgSize:         8,
+			MaxProcessingMsgs:  3,
+			MaxProcessingBytes: 10,
+		},
+	)
+	assert.NoError(err)
+
+	nodeID1, nodeID2 := ids.GenerateTestShortID(), ids.GenerateTestShortID()
+	throttler.AddNode(nodeID1)
+	throttler.AddNode(nodeID2)
+
+	// Fill [nodeID1]'s processing bytes
+	assert.True(throttler.Acquire(8, nodeID1))
+	assert.False(throttler.Acquire(3, nodeID1))
+	assert.EqualValues(1, testutil.ToFloat64(throttler.metrics.bufferFullMsgs))
+
+	// Fill [nodeID1]'s processing messages
+	assert.True(throttler.Acquire(1, nodeID1))
+	assert.True(throttler.Acquire(1, nodeID1))
+	assert.False(throttler.Acquire(0, nodeID1))
+	assert.EqualValues(2, testutil.ToFloat64(throttler.metrics.bufferFullMsgs))
+
+	// [nodeID2] has its own buffer
+	assert.True(throttler.Acquire(8, nodeID2))
+
+	// Releasing a message makes room for another one
+	throttler.Release(8, nodeID1)
+	assert.True(throttler.Acquire(8, nodeID1))
+	assert.False(throttler.Acquire(0, nodeID1))
+
+	// Releasing a message of a removed node is a no-op
+	throttler.RemoveNode(nodeID1)
+	throttler.Release(8, nodeID1)
+	throttler.AddNode(nodeID1)
+	throttler.Release(8, nodeID1)
+	assert.Zero(throttler.limiters[nodeID1].processingMsgs)
+	assert.Zero(throttler.limiters[nodeID1].processingBytes)
+}
+
+func TestInboundAppMsgThrottlerNoRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	throttler, err := newInboundAppMsgThrottler(
+		&logging.Log{},
+		"",
+		prometheus.NewRegistry(),
+		InboundAppMsgThrottlerConfig{
+			MaxMsgSize: 8,
+		},
+	)
+	assert.NoError(err)
+
+	nodeID := ids.GenerateTestShortID()
+	throttler.AddNode(nodeID)
+	for i := 0; i < 100; i++ {
+		assert.True(throttler.Acquire(8, nodeID))
+	}
+	assert.False(throttler.Acquire(9, nodeID))
+}
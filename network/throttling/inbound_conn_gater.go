@@ -0,0 +1,242 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_ InboundConnGater = &inboundConnGater{}
+	_ InboundConnGater = &noInboundConnGater{}
+	_ net.Conn         = &gatedConn{}
+)
+
+// InboundConnGater limits the number of concurrent inbound connections, and
+// the rate of new inbound connection attempts, from each remote IP.
+// Only the IP (not the port) is used so that many nodes on one host share the
+// same limits.
+// Note that InboundConnGater is applied before an inbound connection is
+// upgraded, so it can't know which node is on the other end of the
+// connection.
+type InboundConnGater interface {
+	// Returns whether a new inbound connection from [ip] should be accepted.
+	// [isValidator] should be true if [ip] is a known IP of a validator.
+	// If Allow returns true, Release must be called with [ip] once the
+	// connection is closed.
+	// If [ip] is a local IP, this method always returns true.
+	// It's safe for multiple goroutines to concurrently call Allow.
+	Allow(ip utils.IPDesc, isValidator bool) bool
+	// Marks that a connection from [ip], for which Allow returned true, was
+	// closed.
+	Release(ip utils.IPDesc)
+}
+
+type InboundConnGaterConfig struct {
+	// Max number of concurrent inbound connections from a given IP.
+	// If <= 0, inbound connections aren't gated.
+	MaxConnsPerIP int `json:"maxConnsPerIP"`
+	// Max number of concurrent inbound connections from a given IP that is
+	// known to belong to a validator. Connections from validators' IPs aren't
+	// subject to [MaxConnsPerIP] or [MaxRecentAttemptsPerIP].
+	MaxValidatorConnsPerIP int `json:"maxValidatorConnsPerIP"`
+	// Max number of inbound connection attempts from a given IP within
+	// [AttemptWindow]. Rejected attempts count towards this limit.
+	// If <= 0, connection attempts aren't rate-limited.
+	MaxRecentAttemptsPerIP int `json:"maxRecentAttemptsPerIP"`
+	// Duration over which connection attempts are counted
+	AttemptWindow time.Duration `json:"attemptWindow"`
+}
+
+// Returns a new InboundConnGater. If [config.MaxConnsPerIP] <= 0, the returned
+// gater accepts all inbound connections.
+func NewInboundConnGater(
+	namespace string,
+	registerer prometheus.Registerer,
+	config InboundConnGaterConfig,
+) (InboundConnGater, error) {
+	if config.MaxConnsPerIP <= 0 {
+		return &noInboundConnGater{}, nil
+	}
+	g := &inboundConnGater{
+		InboundConnGaterConfig: config,
+		ips:                    make(map[string]*ipConns),
+		rejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "inbound_conn_gater_rejected",
+			Help:      "Number of inbound connection attempts rejected due to per-IP limits",
+		}),
+	}
+	g.lastSweep = g.clock.Time()
+	return g, registerer.Register(g.rejected)
+}
+
+// Tracks the inbound connections from a single IP
+type ipConns struct {
+	// Number of open inbound connections from this IP
+	numConns int
+	// Times of the recent connection attempts from this IP, in order of
+	// increasing time. Its length is at most [MaxRecentAttemptsPerIP].
+	recentAttempts []time.Time
+}
+
+type inboundConnGater struct {
+	InboundConnGaterConfig
+	rejected prometheus.Counter
+	// Useful for faking time in tests
+	clock mockable.Clock
+
+	lock sync.Mutex
+	// IP --> Connections from that IP.
+	// An IP is removed once it has no open connections and no recent
+	// connection attempts.
+	// Must only be accessed when [lock] is held.
+	ips map[string]*ipConns
+	// Last time stale entries were removed from [ips].
+	// Must only be accessed when [lock] is held.
+	lastSweep time.Time
+}
+
+// See InboundConnGater.
+func (g *inboundConnGater) Allow(ip utils.IPDesc, isValidator bool) bool {
+	if ip.IsPrivate() {
+		// Don't gate private (local) IPs
+		return true
+	}
+	ipStr := ip.IP.String()
+	now := g.clock.Time()
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.sweep(now)
+
+	conns, ok := g.ips[ipStr]
+	if !ok {
+		conns = &ipConns{}
+		g.ips[ipStr] = conns
+	}
+
+	if isValidator {
+		if conns.numConns >= g.MaxValidatorConnsPerIP {
+			g.reject(ipStr, conns)
+			return false
+		}
+		conns.numConns++
+		return true
+	}
+
+	rateLimited := false
+	if g.MaxRecentAttemptsPerIP > 0 {
+		conns.pruneAttempts(now.Add(-g.AttemptWindow))
+		rateLimited = len(conns.recentAttempts) >= g.MaxRecentAttemptsPerIP
+		if !rateLimited {
+			conns.recentAttempts = append(conns.recentAttempts, now)
+		}
+	}
+	if rateLimited || conns.numConns >= g.MaxConnsPerIP {
+		g.reject(ipStr, conns)
+		return false
+	}
+	conns.numConns++
+	return true
+}
+
+// See InboundConnGater.
+func (g *inboundConnGater) Release(ip utils.IPDesc) {
+	if ip.IsPrivate() {
+		return
+	}
+	ipStr := ip.IP.String()
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	conns, ok := g.ips[ipStr]
+	if !ok || conns.numConns == 0 {
+		return
+	}
+	conns.numConns--
+	// Free the slot immediately. Only keep the entry around if it's needed to
+	// rate-limit connection attempts.
+	if conns.numConns == 0 {
+		conns.pruneAttempts(g.clock.Time().Add(-g.AttemptWindow))
+		if len(conns.recentAttempts) == 0 {
+			delete(g.ips, ipStr)
+		}
+	}
+}
+
+// Records that an inbound connection attempt from [ipStr] was rejected.
+// Assumes [g.lock] is held.
+func (g *inboundConnGater) reject(ipStr string, conns *ipConns) {
+	g.rejected.Inc()
+	if conns.numConns == 0 && len(conns.recentAttempts) == 0 {
+		delete(g.ips, ipStr)
+	}
+}
+
+// Removes the entries of IPs with no open connections and no recent
+// connection attempts. Runs at most once per [AttemptWindow] so IPs that
+// attempted to connect only once don't accumulate.
+// Assumes [g.lock] is held.
+func (g *inboundConnGater) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < g.AttemptWindow {
+		return
+	}
+	g.lastSweep = now
+	cutoff := now.Add(-g.AttemptWindow)
+	for ipStr, conns := range g.ips {
+		conns.pruneAttempts(cutoff)
+		if conns.numConns == 0 && len(conns.recentAttempts) == 0 {
+			delete(g.ips, ipStr)
+		}
+	}
+}
+
+// Removes the attempts that happened before [cutoff].
+func (c *ipConns) pruneAttempts(cutoff time.Time) {
+	i := 0
+	for i < len(c.recentAttempts) && c.recentAttempts[i].Before(cutoff) {
+		i++
+	}
+	c.recentAttempts = c.recentAttempts[i:]
+}
+
+// noInboundConnGater accepts all inbound connections
+type noInboundConnGater struct{}
+
+func (*noInboundConnGater) Allow(utils.IPDesc, bool) bool { return true }
+func (*noInboundConnGater) Release(utils.IPDesc)          {}
+
+// Wraps [conn], which is a connection from [ip] for which [gater].Allow
+// returned true, and returns a net.Conn that calls [gater].Release the first
+// time it's closed.
+func NewGatedConn(conn net.Conn, gater InboundConnGater, ip utils.IPDesc) net.Conn {
+	return &gatedConn{
+		Conn:  conn,
+		gater: gater,
+		ip:    ip,
+	}
+}
+
+type gatedConn struct {
+	net.Conn
+	gater     InboundConnGater
+	ip        utils.IPDesc
+	closeOnce sync.Once
+}
+
+func (c *gatedConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.gater.Release(c.ip)
+	})
+	return c.Conn.Close()
+}
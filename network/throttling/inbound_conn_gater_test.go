@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	gaterIP1 = utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	gaterIP2 = utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}
+	// Same host as [gaterIP1], different port
+	gaterIP1OtherPort = utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9652}
+)
+
+func newTestInboundConnGater(t *testing.T, config InboundConnGaterConfig) *inboundConnGater {
+	gaterIntf, err := NewInboundConnGater("", prometheus.NewRegistry(), config)
+	assert.NoError(t, err)
+	return gaterIntf.(*inboundConnGater)
+}
+
+func TestInboundConnGaterDisabled(t *testing.T) {
+	gater, err := NewInboundConnGater("", prometheus.NewRegistry(), InboundConnGaterConfig{})
+	assert.NoError(t, err)
+	assert.IsType(t, &noInboundConnGater{}, gater)
+	for i := 0; i < 100; i++ {
+		assert.True(t, gater.Allow(gaterIP1, false))
+	}
+}
+
+func TestInboundConnGaterMaxConns(t *testing.T) {
+	assert := assert.New(t)
+
+	gater := newTestInboundConnGater(t, InboundConnGaterConfig{
+		MaxConnsPerIP:          2,
+		MaxValidatorConnsPerIP: 3,
+	})
+
+	// The limit is per host, regardless of the port
+	assert.True(gater.Allow(gaterIP1, false))
+	assert.True(gater.Allow(gaterIP1OtherPort, false))
+	assert.False(gater.Allow(gaterIP1, false))
+	assert.Equal(1.0, testutil.ToFloat64(gater.rejected))
+
+	// Other IPs aren't affected
+	assert.True(gater.Allow(gaterIP2, false))
+
+	// Validators' IPs are allowed more connections
+	assert.True(gater.Allow(gaterIP1, true))
+	assert.False(gater.Allow(gaterIP1, true))
+	assert.Equal(2.0, testutil.ToFloat64(gater.rejected))
+
+	// Closing a connection frees its slot immediately
+	gater.Release(gaterIP1)
+	assert.True(gater.Allow(gaterIP1, true))
+
+	// Once all connections are closed, the IP is no longer tracked
+	gater.Release(gaterIP2)
+	assert.NotContains(gater.ips, gaterIP2.IP.String())
+
+	// Private IPs aren't gated
+	localIP := utils.IPDesc{IP: net.IPv4(127, 0, 0, 1), Port: 9651}
+	for i := 0; i < 10; i++ {
+		assert.True(gater.Allow(localIP, false))
+	}
+	assert.Equal(2.0, testutil.ToFloat64(gater.rejected))
+}
+
+func TestInboundConnGaterRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	gater := newTestInboundConnGater(t, InboundConnGaterConfig{
+		MaxConnsPerIP:          10,
+		MaxValidatorConnsPerIP: 10,
+		MaxRecentAttemptsPerIP: 2,
+		AttemptWindow:          time.Second,
+	})
+	now := time.Now()
+	gater.clock.Set(now)
+
+	// Releasing connections doesn't reset the rate limit
+	assert.True(gater.Allow(gaterIP1, false))
+	gater.Release(gaterIP1)
+	assert.True(gater.Allow(gaterIP1, false))
+	gater.Release(gaterIP1)
+	assert.False(gater.Allow(gaterIP1, false))
+	assert.Equal(1.0, testutil.ToFloat64(gater.rejected))
+
+	// Validators' IPs aren't rate-limited
+	assert.True(gater.Allow(gaterIP1, true))
+	gater.Release(gaterIP1)
+
+	// Once the attempts are outside the window, new attempts are allowed
+	gater.clock.Set(now.Add(time.Second + time.Millisecond))
+	assert.True(gater.Allow(gaterIP1, false))
+	gater.Release(gaterIP1)
+
+	// Stale entries are eventually removed
+	gater.clock.Set(now.Add(3 * time.Second))
+	assert.True(gater.Allow(gaterIP2, false))
+	assert.NotContains(gater.ips, gaterIP1.IP.String())
+}
+
+func TestGatedConnReleasesOnce(t *testing.T) {
+	assert := assert.New(t)
+
+	gater := newTestInboundConnGater(t, InboundConnGaterConfig{
+		MaxConnsPerIP:          1,
+		MaxValidatorConnsPerIP: 2,
+	})
+
+	assert.True(gater.Allow(gaterIP1, true))
+	assert.True(gater.Allow(gaterIP1, true))
+
+	client, server := net.Pipe()
+	defer client.Close()
+	conn := NewGatedConn(server, gater, gaterIP1)
+	assert.NoError(conn.Close())
+	_ = conn.Close()
+	assert.Equal(1, gater.ips[gaterIP1.IP.String()].numConns)
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// How often a delayed message re-checks whether its sender is back within
+// its CPU allocation
+const cpuThrottlerRecheckInterval = 10 * time.Millisecond
+
+var (
+	_ InboundCPUThrottler = &inboundCPUThrottler{}
+	_ InboundCPUThrottler = &noInboundCPUThrottler{}
+)
+
+// InboundCPUThrottler rate-limits inbound messages based on the CPU time
+// recently spent handling messages from each node.
+type InboundCPUThrottler interface {
+	// Blocks until [nodeID] is within its CPU allocation, or until the max
+	// delay has passed. Returns false if [nodeID] is still exceeding its
+	// allocation, in which case the message should be dropped.
+	// It's safe for multiple goroutines to concurrently call Acquire.
+	Acquire(nodeID ids.ShortID) bool
+}
+
+type InboundCPUThrottlerConfig struct {
+	// Halflife of the decay of the CPU time tracked per node
+	Halflife time.Duration `json:"halflife"`
+	// Fraction of the CPU time that is split between validators in
+	// proportion to their stake
+	VdrAlloc float64 `json:"vdrAlloc"`
+	// Fraction of the CPU time that any node may use, in addition to its
+	// share of [VdrAlloc]
+	MaxAtLargeAlloc float64 `json:"maxAtLargeAlloc"`
+	// Max amount of time a message from a node exceeding its allocation is
+	// delayed before being dropped
+	MaxDelay time.Duration `json:"maxDelay"`
+}
+
+// Returns a new inbound CPU throttler that allows each node to use, over time,
+// up to [config.MaxAtLargeAlloc] of the CPU time tracked by [cpuTracker], plus
+// its stake-weighted portion of [config.VdrAlloc].
+func NewInboundCPUThrottler(
+	namespace string,
+	registerer prometheus.Registerer,
+	vdrs validators.Set,
+	cpuTracker tracker.Utilizer,
+	config InboundCPUThrottlerConfig,
+) (InboundCPUThrottler, error) {
+	t := &inboundCPUThrottler{
+		InboundCPUThrottlerConfig: config,
+		vdrs:                      vdrs,
+		cpuTracker:                cpuTracker,
+		nodeToNumDelayedMsgs:      make(map[ids.ShortID]int),
+	}
+	return t, t.metrics.initialize(namespace, registerer)
+}
+
+type inboundCPUThrottler struct {
+	InboundCPUThrottlerConfig
+	metrics    inboundCPUThrottlerMetrics
+	clock      mockable.Clock
+	vdrs       validators.Set
+	cpuTracker tracker.Utilizer
+	lock       sync.Mutex
+	// Node ID --> Number of messages from this node currently being delayed.
+	// Must only be accessed when [lock] is held.
+	nodeToNumDelayedMsgs map[ids.ShortID]int
+}
+
+// See InboundCPUThrottler.
+func (t *inboundCPUThrottler) Acquire(nodeID ids.ShortID) bool {
+	if t.withinAlloc(nodeID) {
+		return true
+	}
+
+	// [nodeID] recently used more than its allocation. Wait for its
+	// utilization to decay.
+	t.metrics.delayedMsgs.Inc()
+	t.addDelayedMsg(nodeID, 1)
+	defer t.addDelayedMsg(nodeID, -1)
+
+	ticker := time.NewTicker(cpuThrottlerRecheckInterval)
+	defer ticker.Stop()
+	deadline := time.After(t.MaxDelay)
+	for {
+		select {
+		case <-ticker.C:
+			if t.withinAlloc(nodeID) {
+				return true
+			}
+		case <-deadline:
+			if t.withinAlloc(nodeID) {
+				return true
+			}
+			t.metrics.droppedMsgs.Inc()
+			return false
+		}
+	}
+}
+
+// Returns true if [nodeID]'s recent CPU utilization doesn't exceed its
+// allocation.
+func (t *inboundCPUThrottler) withinAlloc(nodeID ids.ShortID) bool {
+	maxUtilization := t.MaxAtLargeAlloc
+	// The sum of validator weights should never be 0, but handle that case
+	// for completeness here to avoid divide by 0.
+	if weight, isVdr := t.vdrs.GetWeight(nodeID); isVdr {
+		if totalWeight := t.vdrs.Weight(); totalWeight != 0 {
+			maxUtilization += t.VdrAlloc * float64(weight) / float64(totalWeight)
+		}
+	}
+	return t.cpuTracker.Utilization(nodeID, t.clock.Time()) <= maxUtilization
+}
+
+// Adds [delta] to the number of messages from [nodeID] currently being
+// delayed.
+func (t *inboundCPUThrottler) addDelayedMsg(nodeID ids.ShortID, delta int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	numDelayedMsgs := t.nodeToNumDelayedMsgs[nodeID] + delta
+	if numDelayedMsgs == 0 {
+		delete(t.nodeToNumDelayedMsgs, nodeID)
+	} else {
+		t.nodeToNumDelayedMsgs[nodeID] = numDelayedMsgs
+	}
+	t.metrics.delayedNodes.Set(float64(len(t.nodeToNumDelayedMsgs)))
+}
+
+type inboundCPUThrottlerMetrics struct {
+	delayedNodes prometheus.Gauge
+	delayedMsgs  prometheus.Counter
+	droppedMsgs  prometheus.Counter
+}
+
+func (m *inboundCPUThrottlerMetrics) initialize(namespace string, reg prometheus.Registerer) error {
+	m.delayedNodes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cpu_throttler_inbound_delayed_nodes",
+		Help:      "Number of nodes whose messages are being delayed because they're exceeding their CPU allocation",
+	})
+	m.delayedMsgs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cpu_throttler_inbound_delayed_msgs",
+		Help:      "Number of inbound messages delayed because their sender was exceeding its CPU allocation",
+	})
+	m.droppedMsgs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cpu_throttler_inbound_dropped_msgs",
+		Help:      "Number of inbound messages dropped because their sender was exceeding its CPU allocation",
+	})
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.delayedNodes),
+		reg.Register(m.delayedMsgs),
+		reg.Register(m.droppedMsgs),
+	)
+	return errs.Err
+}
+
+// Returns an InboundCPUThrottler where Acquire() always returns true
+// immediately.
+func NewNoInboundCPUThrottler() InboundCPUThrottler {
+	return &noInboundCPUThrottler{}
+}
+
+type noInboundCPUThrottler struct{}
+
+func (*noInboundCPUThrottler) Acquire(ids.ShortID) bool { return true }
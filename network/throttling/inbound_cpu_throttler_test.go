@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/uptime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Ensure that a peer using more than its share of CPU time is throttled
+// without delaying messages from other peers.
+func TestCPUThrottlerAbusivePeer(t *testing.T) {
+	assert := assert.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID := ids.GenerateTestShortID()
+	assert.NoError(vdrs.AddWeight(vdrID, 1))
+	abusiveID, honestID := ids.GenerateTestShortID(), ids.GenerateTestShortID()
+
+	now := time.Now()
+	cpuTracker := tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second)
+	// The validator and the abusive peer spend the same amount of CPU time
+	cpuTracker.UtilizeTime(vdrID, now.Add(-2*time.Second), now)
+	cpuTracker.UtilizeTime(abusiveID, now.Add(-2*time.Second), now)
+	cpuTracker.UtilizeTime(honestID, now.Add(-10*time.Millisecond), now)
+
+	throttlerIntf, err := NewInboundCPUThrottler(
+		"",
+		prometheus.NewRegistry(),
+		vdrs,
+		cpuTracker,
+		InboundCPUThrottlerConfig{
+			VdrAlloc:        0.5,
+			MaxAtLargeAlloc: 0.2,
+			MaxDelay:        100 * time.Millisecond,
+		},
+	)
+	assert.NoError(err)
+	throttler := throttlerIntf.(*inboundCPUThrottler)
+	throttler.clock.Set(now.Add(time.Second))
+
+	// The abusive peer's message is delayed, and then dropped
+	abusiveDone := make(chan bool, 1)
+	go func() {
+		abusiveDone <- throttler.Acquire(abusiveID)
+	}()
+	assert.Eventually(func() bool {
+		return testutil.ToFloat64(throttler.metrics.delayedNodes) == 1
+	}, time.Second, time.Millisecond)
+
+	// Meanwhile, messages from other peers aren't delayed. The validator is
+	// allowed to use more CPU time than the abusive peer due to its stake.
+	assert.True(throttler.Acquire(honestID))
+	assert.True(throttler.Acquire(vdrID))
+	select {
+	case <-abusiveDone:
+		t.Fatal("abusive peer's message shouldn't have been handled yet")
+	default:
+	}
+
+	assert.False(<-abusiveDone)
+	assert.Equal(1.0, testutil.ToFloat64(throttler.metrics.delayedMsgs))
+	assert.Equal(1.0, testutil.ToFloat64(throttler.metrics.droppedMsgs))
+	assert.Equal(0.0, testutil.ToFloat64(throttler.metrics.delayedNodes))
+
+	// Once its CPU usage decays, the abusive peer's messages are allowed again
+	throttler.clock.Set(now.Add(3 * time.Second))
+	assert.True(throttler.Acquire(abusiveID))
+	assert.Equal(1.0, testutil.ToFloat64(throttler.metrics.delayedMsgs))
+	assert.Equal(1.0, testutil.ToFloat64(throttler.metrics.droppedMsgs))
+}
+
+// Ensure that a delayed message is allowed through once its sender's CPU usage
+// decays below its allocation.
+func TestCPUThrottlerDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID := ids.GenerateTestShortID()
+	cpuTracker := &tracker.MockTimeTracker{}
+	cpuTracker.On("Utilization", nodeID, mock.Anything).Return(0.5).Twice()
+	cpuTracker.On("Utilization", nodeID, mock.Anything).Return(0.1)
+
+	throttlerIntf, err := NewInboundCPUThrottler(
+		"",
+		prometheus.NewRegistry(),
+		validators.NewSet(),
+		cpuTracker,
+		InboundCPUThrottlerConfig{
+			MaxAtLargeAlloc: 0.2,
+			MaxDelay:        time.Second,
+		},
+	)
+	assert.NoError(err)
+	throttler := throttlerIntf.(*inboundCPUThrottler)
+
+	assert.True(throttler.Acquire(nodeID))
+	assert.Equal(1.0, testutil.ToFloat64(throttler.metrics.delayedMsgs))
+	assert.Equal(0.0, testutil.ToFloat64(throttler.metrics.droppedMsgs))
+	assert.Equal(0.0, testutil.ToFloat64(throttler.metrics.delayedNodes))
+	cpuTracker.AssertNumberOfCalls(t, "Utilization", 3)
+}
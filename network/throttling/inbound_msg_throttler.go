@@ -5,6 +5,7 @@ package throttling
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/prometheus/client_golang/prometheus"
@@ -14,22 +15,33 @@ var _ InboundMsgThrottler = &inboundMsgThrottler{}
 
 // InboundMsgThrottler rate-limits inbound messages from the network.
 type InboundMsgThrottler interface {
-	BandwidthThrottler
+	// Blocks until we can read a message of [op] and size [msgSize] from
+	// [nodeID]. Returns false, without blocking, if the message should be
+	// dropped instead. Only app messages are ever dropped.
+	// For every call to Acquire([op], [msgSize], [nodeID]) that returns true,
+	// we must (!) call Release([op], [msgSize], [nodeID]) when done processing
+	// the message (or when we give up trying to read the message.)
+	Acquire(op message.Op, msgSize uint64, nodeID ids.ShortID) bool
 
-	// Blocks until we can read a message of size [msgSize] from [nodeID].
-	// For every call to Acquire([msgSize], [nodeID]), we must (!) call
-	// Release([msgSize], [nodeID]) when done processing the message
-	// (or when we give up trying to read the message.)
-	Acquire(msgSize uint64, nodeID ids.ShortID)
-
-	// Mark that we're done processing a message of size [msgSize]
+	// Mark that we're done processing a message of [op] and size [msgSize]
 	// from [nodeID].
-	Release(msgSize uint64, nodeID ids.ShortID)
+	Release(op message.Op, msgSize uint64, nodeID ids.ShortID)
+
+	// Add a new node to this throttler.
+	// Must be called before Acquire(..., [nodeID]) is called.
+	// See BandwidthThrottler.
+	AddNode(nodeID ids.ShortID)
+
+	// Remove a node from this throttler.
+	// Must be called when we stop reading messages from [nodeID].
+	// See BandwidthThrottler.
+	RemoveNode(nodeID ids.ShortID)
 }
 
 type InboundMsgThrottlerConfig struct {
 	MsgByteThrottlerConfig
 	BandwidthThrottlerConfig
+	InboundAppMsgThrottlerConfig
 	MaxProcessingMsgsPerNode uint64 `json:"maxProcessingMsgsPerNode"`
 }
 
@@ -68,25 +80,39 @@ func NewInboundMsgThrottler(
 	if err != nil {
 		return nil, err
 	}
+	appThrottler, err := newInboundAppMsgThrottler(
+		log,
+		namespace,
+		registerer,
+		config.InboundAppMsgThrottlerConfig,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return &inboundMsgThrottler{
 		byteThrottler:      byteThrottler,
 		bufferThrottler:    bufferThrottler,
 		bandwidthThrottler: bandwidthThrottler,
+		appThrottler:       appThrottler,
 	}, nil
 }
 
 // A sybil-safe inbound message throttler.
 // Rate-limits reading of inbound messages to prevent peers from
 // consuming excess resources.
-// The three resources considered are:
+// The four resources considered are:
 // 1. An inbound message buffer, where each message that we're currently
 //    processing takes up 1 unit of space on the buffer.
 // 2. An inbound message byte buffer, where a message of length n
 //    that we're currently processing takes up n units of space on the buffer.
 // 3. Bandwidth. The bandwidth rate-limiting is implemented using a token bucket,
 //    where each token is 1 byte. See BandwidthThrottler.
-// A call to Acquire([msgSize], [nodeID]) blocks until we've secured
-// enough of both these resources to read a message of size [msgSize] from [nodeID].
+// 4. App message budget. App messages don't consume any of (1), (2) or (3).
+//    Instead, they're rate-limited by their own token buckets and buffers,
+//    and dropped when their sender's budget is exhausted, so that a chatty
+//    or slow VM can't delay the handling of consensus messages.
+// A call to Acquire([op], [msgSize], [nodeID]) blocks until we've secured
+// enough of these resources to read a message of size [msgSize] from [nodeID].
 type inboundMsgThrottler struct {
 	// Rate-limits based on number of messages from a given
 	// node that we're currently processing.
@@ -96,23 +122,36 @@ type inboundMsgThrottler struct {
 	// Rate-limits based on size of all messages from a given
 	// node that we're currently processing.
 	byteThrottler *inboundMsgByteThrottler
+	// Rate-limits app messages separately from the other messages
+	appThrottler *inboundAppMsgThrottler
 }
 
-// Returns when we can read a message of size [msgSize] from node [nodeID].
-// Release([msgSize], [nodeID]) must be called (!) when done with the message
-// or when we give up trying to read the message, if applicable.
-func (t *inboundMsgThrottler) Acquire(msgSize uint64, nodeID ids.ShortID) {
+// Returns when we can read a message of [op] and size [msgSize] from node
+// [nodeID], or returns false immediately if the message should be dropped.
+// If this returns true, Release([op], [msgSize], [nodeID]) must be called (!)
+// when done with the message or when we give up trying to read the message, if
+// applicable.
+func (t *inboundMsgThrottler) Acquire(op message.Op, msgSize uint64, nodeID ids.ShortID) bool {
+	// Acquire from the app message budget, or drop the message
+	if op.IsApp() {
+		return t.appThrottler.Acquire(msgSize, nodeID)
+	}
 	// Acquire space on the inbound message buffer
 	t.bufferThrottler.Acquire(nodeID)
 	// Acquire bandwidth
 	t.bandwidthThrottler.Acquire(msgSize, nodeID)
 	// Acquire space on the inbound message byte buffer
 	t.byteThrottler.Acquire(msgSize, nodeID)
+	return true
 }
 
-// Must correspond to a previous call of Acquire([msgSize], [nodeID]).
+// Must correspond to a previous call of Acquire([op], [msgSize], [nodeID]).
 // See InboundMsgThrottler interface.
-func (t *inboundMsgThrottler) Release(msgSize uint64, nodeID ids.ShortID) {
+func (t *inboundMsgThrottler) Release(op message.Op, msgSize uint64, nodeID ids.ShortID) {
+	if op.IsApp() {
+		t.appThrottler.Release(msgSize, nodeID)
+		return
+	}
 	// Release space on the inbound message buffer
 	t.bufferThrottler.Release(nodeID)
 	// Release space on the inbound message byte buffer
@@ -122,9 +161,11 @@ func (t *inboundMsgThrottler) Release(msgSize uint64, nodeID ids.ShortID) {
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) AddNode(nodeID ids.ShortID) {
 	t.bandwidthThrottler.AddNode(nodeID)
+	t.appThrottler.AddNode(nodeID)
 }
 
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.ShortID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
+	t.appThrottler.RemoveNode(nodeID)
 }
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Saturating the app message budget of a node shouldn't delay the consensus
+// messages it sends.
+func TestInboundMsgThrottlerAppGossipDoesntDelayQueries(t *testing.T) {
+	assert := assert.New(t)
+	vdrs := validators.NewSet()
+	nodeID := ids.GenerateTestShortID()
+	assert.NoError(vdrs.AddWeight(nodeID, 1))
+
+	throttler, err := NewInboundMsgThrottler(
+		&logging.Log{},
+		"",
+		prometheus.NewRegistry(),
+		vdrs,
+		InboundMsgThrottlerConfig{
+			MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+				VdrAllocSize:        1024 * 1024,
+				AtLargeAllocSize:    1024 * 1024,
+				NodeMaxAtLargeBytes: 1024 * 1024,
+			},
+			BandwidthThrottlerConfig: BandwidthThrottlerConfig{
+				RefillRate:   1,
+				MaxBurstSize: 1024,
+			},
+			InboundAppMsgThrottlerConfig: InboundAppMsgThrottlerConfig{
+				BytesRefillRate:   1,
+				BytesMaxBurstSize: 8 * 1024,
+				MsgRefillRate:     1,
+				MsgMaxBurstSize:   16,
+				MaxMsgSize:        1024,
+			},
+			MaxProcessingMsgsPerNode: 1024,
+		},
+	)
+	assert.NoError(err)
+	throttler.AddNode(nodeID)
+
+	// Saturate the app message budget. Acquiring for app messages never
+	// blocks.
+	numAcquired := 0
+	for i := 0; i < 64; i++ {
+		if throttler.Acquire(message.AppGossip, 1024, nodeID) {
+			numAcquired++
+			throttler.Release(message.AppGossip, 1024, nodeID)
+		}
+	}
+	assert.Equal(8, numAcquired)
+
+	// The bandwidth used by the app messages shouldn't have been taken from
+	// the bandwidth available to queries.
+	done := make(chan bool)
+	go func() {
+		done <- throttler.Acquire(message.PullQuery, 1024, nodeID)
+	}()
+	select {
+	case acquired := <-done:
+		assert.True(acquired)
+	case <-time.After(time.Second):
+		t.Fatal("query should not have been delayed")
+	}
+	throttler.Release(message.PullQuery, 1024, nodeID)
+}
+
+// App messages that are still processing shouldn't take space on the buffers
+// used by consensus messages.
+func TestInboundMsgThrottlerProcessingAppMsgsDontDelayQueries(t *testing.T) {
+	assert := assert.New(t)
+	vdrs := validators.NewSet()
+	nodeID := ids.GenerateTestShortID()
+	assert.NoError(vdrs.AddWeight(nodeID, 1))
+
+	throttler, err := NewInboundMsgThrottler(
+		&logging.Log{},
+		"",
+		prometheus.NewRegistry(),
+		vdrs,
+		InboundMsgThrottlerConfig{
+			MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+				VdrAllocSize:        1024,
+				AtLargeAllocSize:    1024,
+				NodeMaxAtLargeBytes: 1024,
+			},
+			BandwidthThrottlerConfig: BandwidthThrottlerConfig{
+				RefillRate:   1024,
+				MaxBurstSize: 1024,
+			},
+			InboundAppMsgThrottlerConfig: InboundAppMsgThrottlerConfig{
+				MaxMsgSize:        1024,
+				MaxProcessingMsgs: 4,
+			},
+			MaxProcessingMsgsPerNode: 2,
+		},
+	)
+	assert.NoError(err)
+	throttler.AddNode(nodeID)
+
+	// Fill the app message buffer, and never release the app messages, as if
+	// the VM was stuck handling them. More app messages are dropped.
+	for i := 0; i < 4; i++ {
+		assert.True(throttler.Acquire(message.AppGossip, 1024, nodeID))
+	}
+	assert.False(throttler.Acquire(message.AppGossip, 1024, nodeID))
+
+	// The processing app messages take up more than the message buffer and
+	// the byte buffer shared by the other messages. Queries still shouldn't
+	// be delayed.
+	done := make(chan bool)
+	go func() {
+		done <- throttler.Acquire(message.PullQuery, 1024, nodeID)
+	}()
+	select {
+	case acquired := <-done:
+		assert.True(acquired)
+	case <-time.After(time.Second):
+		t.Fatal("query should not have been delayed")
+	}
+	throttler.Release(message.PullQuery, 1024, nodeID)
+
+	// Releasing an app message makes room for another one
+	throttler.Release(message.AppGossip, 1024, nodeID)
+	assert.True(throttler.Acquire(message.AppGossip, 1024, nodeID))
+}
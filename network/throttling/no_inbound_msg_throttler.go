@@ -3,7 +3,10 @@
 
 package throttling
 
-import "github.com/ava-labs/avalanchego/ids"
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
 
 var _ InboundMsgThrottler = &noInboundMsgThrottler{}
 
@@ -16,9 +19,9 @@ func NewNoInboundThrottler() InboundMsgThrottler {
 // [Acquire] always returns immediately.
 type noInboundMsgThrottler struct{}
 
-func (*noInboundMsgThrottler) Acquire(uint64, ids.ShortID) {}
+func (*noInboundMsgThrottler) Acquire(message.Op, uint64, ids.ShortID) bool { return true }
 
-func (*noInboundMsgThrottler) Release(uint64, ids.ShortID) {}
+func (*noInboundMsgThrottler) Release(message.Op, uint64, ids.ShortID) {}
 
 func (*noInboundMsgThrottler) AddNode(ids.ShortID) {}
 
@@ -0,0 +1,257 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Max amount of time a waiting message sleeps before re-checking whether it
+// can be sent
+const outboundBandwidthRecheckInterval = 10 * time.Millisecond
+
+var (
+	_ OutboundBandwidthThrottler = &outboundBandwidthThrottler{}
+	_ OutboundBandwidthThrottler = &noOutboundBandwidthThrottler{}
+)
+
+// Priority tiers of the outbound bandwidth throttler
+type bandwidthTier int
+
+const (
+	// Handshake and ping messages are never delayed, because delaying them
+	// may cause peers to disconnect from us
+	exemptTier bandwidthTier = iota
+	// Messages needed for consensus to make progress
+	consensusTier
+	// Large messages, or messages that aren't time sensitive. Only sent when
+	// no message in [consensusTier] is waiting to be sent.
+	bulkTier
+)
+
+func (t bandwidthTier) String() string {
+	switch t {
+	case exemptTier:
+		return "exempt"
+	case consensusTier:
+		return "consensus"
+	case bulkTier:
+		return "bulk"
+	default:
+		return fmt.Sprintf("unknown tier %d", int(t))
+	}
+}
+
+// IsOutboundBandwidthExempt returns true if messages of [op] are handshake or
+// ping messages, which must never wait for outbound bandwidth.
+func IsOutboundBandwidthExempt(op message.Op) bool {
+	return outboundBandwidthTier(op) == exemptTier
+}
+
+// Returns the priority tier of messages of [op]
+func outboundBandwidthTier(op message.Op) bandwidthTier {
+	switch op {
+	case message.GetVersion, message.Version, message.GetPeerList, message.Ping, message.Pong, message.UptimePong, message.NoncePing, message.NoncePong, message.Capabilities:
+		return exemptTier
+	case message.MultiPut, message.PeerList, message.AppGossip:
+		return bulkTier
+	default:
+		return consensusTier
+	}
+}
+
+// OutboundBandwidthThrottler rate-limits the number of bytes this node writes
+// to the network, across all peers, using a token bucket model where each
+// token is 1 byte.
+type OutboundBandwidthThrottler interface {
+	// Blocks until a message of [op] and size [msgSize] can be written.
+	// Returns false if the message should be dropped.
+	// Handshake and ping messages are never delayed or dropped, though the
+	// bytes they use are still accounted for.
+	// It's safe for multiple goroutines to concurrently call Acquire.
+	Acquire(op message.Op, msgSize uint64) bool
+
+	// Accounts for a message of size [msgSize] that is written without
+	// waiting for bandwidth, regardless of its op.
+	// It's safe for multiple goroutines to concurrently call Consume.
+	Consume(msgSize uint64)
+}
+
+type OutboundBandwidthThrottlerConfig struct {
+	// Rate, in bytes per second, at which the outbound bandwidth replenishes.
+	// If 0, outbound bandwidth isn't throttled.
+	RefillRate uint64 `json:"bandwidthRefillRate"`
+	// Max amount of outbound bandwidth that can accumulate
+	MaxBurstSize uint64 `json:"bandwidthMaxBurstSize"`
+	// Max amount of time a message waits for bandwidth before being dropped
+	MaxDelay time.Duration `json:"bandwidthMaxDelay"`
+}
+
+// Returns a new outbound bandwidth throttler. If [config.RefillRate] is 0, the
+// returned throttler never delays messages.
+func NewOutboundBandwidthThrottler(
+	namespace string,
+	registerer prometheus.Registerer,
+	config OutboundBandwidthThrottlerConfig,
+) (OutboundBandwidthThrottler, error) {
+	if config.RefillRate == 0 {
+		return &noOutboundBandwidthThrottler{}, nil
+	}
+	t := &outboundBandwidthThrottler{
+		OutboundBandwidthThrottlerConfig: config,
+		tokens:                           float64(config.MaxBurstSize),
+	}
+	t.lastRefill = t.clock.Time()
+	return t, t.metrics.initialize(namespace, registerer)
+}
+
+type outboundBandwidthThrottler struct {
+	OutboundBandwidthThrottlerConfig
+	metrics outboundBandwidthThrottlerMetrics
+	clock   mockable.Clock
+
+	lock sync.Mutex
+	// Number of bytes that can currently be written. May be negative if
+	// exempt messages used more bandwidth than was available.
+	// Must only be accessed when [lock] is held.
+	tokens float64
+	// Last time [tokens] was refilled.
+	// Must only be accessed when [lock] is held.
+	lastRefill time.Time
+	// Number of consensus messages waiting for bandwidth.
+	// Must only be accessed when [lock] is held.
+	numWaitingConsensusMsgs int
+}
+
+// See OutboundBandwidthThrottler.
+func (t *outboundBandwidthThrottler) Acquire(op message.Op, msgSize uint64) bool {
+	tier := outboundBandwidthTier(op)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.refill()
+	if tier == exemptTier || t.canTake(tier, msgSize) {
+		t.tokens -= float64(msgSize)
+		return true
+	}
+
+	// There isn't enough bandwidth to write this message now. Wait until
+	// there is, or until we give up on the message.
+	t.metrics.delayedBytes[tier].Add(float64(msgSize))
+	if tier == consensusTier {
+		t.numWaitingConsensusMsgs++
+		defer func() { t.numWaitingConsensusMsgs-- }()
+	}
+
+	deadline := time.Now().Add(t.MaxDelay)
+	for {
+		now := time.Now()
+		if !now.Before(deadline) {
+			t.metrics.droppedMsgs[tier].Inc()
+			return false
+		}
+
+		// Sleep until enough bytes should have accumulated, re-checking
+		// regularly in case the bucket may be used by another tier.
+		sleepDuration := time.Duration((float64(msgSize) - t.tokens) / float64(t.RefillRate) * float64(time.Second))
+		if sleepDuration > outboundBandwidthRecheckInterval {
+			sleepDuration = outboundBandwidthRecheckInterval
+		}
+		if remaining := deadline.Sub(now); sleepDuration > remaining {
+			sleepDuration = remaining
+		}
+		t.lock.Unlock()
+		time.Sleep(sleepDuration)
+		t.lock.Lock()
+
+		t.refill()
+		if t.canTake(tier, msgSize) {
+			t.tokens -= float64(msgSize)
+			return true
+		}
+	}
+}
+
+// See OutboundBandwidthThrottler.
+func (t *outboundBandwidthThrottler) Consume(msgSize uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.refill()
+	t.tokens -= float64(msgSize)
+}
+
+// Returns true if a message of [tier] and size [msgSize] can be written now.
+// Messages larger than the max burst size can be written once the bucket is
+// full.
+// Assumes [t.lock] is held.
+func (t *outboundBandwidthThrottler) canTake(tier bandwidthTier, msgSize uint64) bool {
+	if tier == bulkTier && t.numWaitingConsensusMsgs > 0 {
+		return false
+	}
+	needed := float64(msgSize)
+	if burst := float64(t.MaxBurstSize); needed > burst {
+		needed = burst
+	}
+	return t.tokens >= needed
+}
+
+// Adds the bytes that accumulated since the last refill to [t.tokens].
+// Assumes [t.lock] is held.
+func (t *outboundBandwidthThrottler) refill() {
+	now := t.clock.Time()
+	if elapsed := now.Sub(t.lastRefill); elapsed > 0 {
+		t.tokens += elapsed.Seconds() * float64(t.RefillRate)
+		if burst := float64(t.MaxBurstSize); t.tokens > burst {
+			t.tokens = burst
+		}
+	}
+	t.lastRefill = now
+}
+
+type outboundBandwidthThrottlerMetrics struct {
+	// Tier --> Bytes of messages in that tier that had to wait for bandwidth
+	delayedBytes map[bandwidthTier]prometheus.Counter
+	// Tier --> Messages in that tier dropped due to lack of bandwidth
+	droppedMsgs map[bandwidthTier]prometheus.Counter
+}
+
+func (m *outboundBandwidthThrottlerMetrics) initialize(namespace string, registerer prometheus.Registerer) error {
+	m.delayedBytes = make(map[bandwidthTier]prometheus.Counter)
+	m.droppedMsgs = make(map[bandwidthTier]prometheus.Counter)
+	errs := wrappers.Errs{}
+	for _, tier := range []bandwidthTier{consensusTier, bulkTier} {
+		m.delayedBytes[tier] = prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("bandwidth_throttler_outbound_%s_delayed_bytes", tier),
+			Help:      fmt.Sprintf("Number of bytes of %s messages that waited for outbound bandwidth", tier),
+		})
+		m.droppedMsgs[tier] = prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("bandwidth_throttler_outbound_%s_dropped_msgs", tier),
+			Help:      fmt.Sprintf("Number of %s messages dropped due to a lack of outbound bandwidth", tier),
+		})
+		errs.Add(
+			registerer.Register(m.delayedBytes[tier]),
+			registerer.Register(m.droppedMsgs[tier]),
+		)
+	}
+	return errs.Err
+}
+
+// noOutboundBandwidthThrottler implements OutboundBandwidthThrottler.
+// [Acquire] always returns true immediately.
+type noOutboundBandwidthThrottler struct{}
+
+func (*noOutboundBandwidthThrottler) Acquire(message.Op, uint64) bool { return true }
+
+func (*noOutboundBandwidthThrottler) Consume(uint64) {}
@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOutboundBandwidthThrottler(t *testing.T, config OutboundBandwidthThrottlerConfig) *outboundBandwidthThrottler {
+	throttlerIntf, err := NewOutboundBandwidthThrottler("", prometheus.NewRegistry(), config)
+	assert.NoError(t, err)
+	throttler := throttlerIntf.(*outboundBandwidthThrottler)
+	// Bandwidth only refills when the clock is advanced
+	throttler.clock.Set(time.Now())
+	throttler.lastRefill = throttler.clock.Time()
+	return throttler
+}
+
+func (t *outboundBandwidthThrottler) getNumWaitingConsensusMsgs() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.numWaitingConsensusMsgs
+}
+
+func (t *outboundBandwidthThrottler) advanceTime(duration time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.clock.Set(t.clock.Time().Add(duration))
+}
+
+func (t *outboundBandwidthThrottler) getTokens() float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.tokens
+}
+
+func TestOutboundBandwidthThrottlerDisabled(t *testing.T) {
+	throttler, err := NewOutboundBandwidthThrottler("", prometheus.NewRegistry(), OutboundBandwidthThrottlerConfig{})
+	assert.NoError(t, err)
+	assert.IsType(t, &noOutboundBandwidthThrottler{}, throttler)
+	assert.True(t, throttler.Acquire(message.MultiPut, 1024))
+}
+
+// Ensure that handshake and ping messages are never delayed or dropped, even
+// when there is no bandwidth left.
+func TestOutboundBandwidthThrottlerHandshakeCarveOut(t *testing.T) {
+	assert := assert.New(t)
+
+	throttler := newTestOutboundBandwidthThrottler(t, OutboundBandwidthThrottlerConfig{
+		RefillRate:   1,
+		MaxBurstSize: 100,
+		MaxDelay:     100 * time.Millisecond,
+	})
+
+	// Use all of the bandwidth
+	assert.True(throttler.Acquire(message.Chits, 100))
+
+	// A consensus message waits for bandwidth
+	consensusDone := make(chan bool, 1)
+	go func() {
+		consensusDone <- throttler.Acquire(message.PushQuery, 100)
+	}()
+	assert.Eventually(func() bool {
+		return throttler.getNumWaitingConsensusMsgs() == 1
+	}, time.Second, time.Millisecond)
+
+	// Meanwhile, handshake and ping messages are written immediately
+	for _, op := range []message.Op{
+		message.GetVersion,
+		message.Version,
+		message.GetPeerList,
+		message.Ping,
+		message.Pong,
+		message.UptimePong,
+		message.NoncePing,
+		message.NoncePong,
+	} {
+		startTime := time.Now()
+		assert.True(throttler.Acquire(op, 100), op)
+		assert.Less(time.Since(startTime), outboundBandwidthRecheckInterval, op)
+	}
+
+	// Messages sent during the handshake are accounted for without waiting,
+	// regardless of their op
+	tokens := throttler.getTokens()
+	throttler.Consume(100)
+	assert.Equal(tokens-100, throttler.getTokens())
+
+	// The bytes used by the exempt messages are still accounted for, so the
+	// consensus message can't be sent until they are paid back
+	assert.Less(throttler.getTokens(), -500.0)
+	assert.False(<-consensusDone)
+}
+
+// Ensure that bulk messages are only sent when no consensus message is
+// waiting for bandwidth.
+func TestOutboundBandwidthThrottlerPriority(t *testing.T) {
+	assert := assert.New(t)
+
+	throttler := newTestOutboundBandwidthThrottler(t, OutboundBandwidthThrottlerConfig{
+		RefillRate:   100,
+		MaxBurstSize: 100,
+		MaxDelay:     10 * time.Second,
+	})
+
+	// Use all of the bandwidth
+	assert.True(throttler.Acquire(message.Chits, 100))
+
+	bulkDone := make(chan bool, 1)
+	go func() {
+		bulkDone <- throttler.Acquire(message.MultiPut, 100)
+	}()
+	consensusDone := make(chan bool, 1)
+	go func() {
+		consensusDone <- throttler.Acquire(message.PushQuery, 100)
+	}()
+	assert.Eventually(func() bool {
+		return throttler.getNumWaitingConsensusMsgs() == 1
+	}, time.Second, time.Millisecond)
+
+	// Refill enough bandwidth for one message. The consensus message is sent,
+	// even though the bulk message has been waiting longer.
+	throttler.advanceTime(time.Second)
+	assert.True(<-consensusDone)
+	select {
+	case <-bulkDone:
+		t.Fatal("bulk message shouldn't have been sent")
+	case <-time.After(5 * outboundBandwidthRecheckInterval):
+	}
+
+	throttler.advanceTime(time.Second)
+	assert.True(<-bulkDone)
+
+	assert.Equal(100.0, testutil.ToFloat64(throttler.metrics.delayedBytes[consensusTier]))
+	assert.Equal(100.0, testutil.ToFloat64(throttler.metrics.delayedBytes[bulkTier]))
+	assert.Equal(0.0, testutil.ToFloat64(throttler.metrics.droppedMsgs[consensusTier]))
+	assert.Equal(0.0, testutil.ToFloat64(throttler.metrics.droppedMsgs[bulkTier]))
+}
+
+// Ensure that messages that can't get bandwidth in time are dropped, and that
+// messages larger than the max burst size can be sent once the bucket is full.
+func TestOutboundBandwidthThrottlerDrop(t *testing.T) {
+	assert := assert.New(t)
+
+	throttler := newTestOutboundBandwidthThrottler(t, OutboundBandwidthThrottlerConfig{
+		RefillRate:   100,
+		MaxBurstSize: 100,
+		MaxDelay:     20 * time.Millisecond,
+	})
+
+	// A message larger than the max burst size can be sent from a full bucket
+	assert.True(throttler.Acquire(message.MultiPut, 1000))
+
+	assert.False(throttler.Acquire(message.PeerList, 10))
+	assert.Equal(10.0, testutil.ToFloat64(throttler.metrics.delayedBytes[bulkTier]))
+	assert.Equal(1.0, testutil.ToFloat64(throttler.metrics.droppedMsgs[bulkTier]))
+
+	assert.False(throttler.Acquire(message.Chits, 20))
+	assert.Equal(20.0, testutil.ToFloat64(throttler.metrics.delayedBytes[consensusTier]))
+	assert.Equal(1.0, testutil.ToFloat64(throttler.metrics.droppedMsgs[consensusTier]))
+
+	// Once the debt is paid back, and the bucket refilled, messages are sent
+	throttler.advanceTime(20 * time.Second)
+	assert.True(throttler.Acquire(message.Chits, 100))
+	assert.Equal(1.0, testutil.ToFloat64(throttler.metrics.droppedMsgs[consensusTier]))
+}
@@ -3,9 +3,32 @@
 
 package network
 
-import "crypto/tls"
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
 
-func TLSConfig(cert tls.Certificate) *tls.Config {
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSOptions restricts the TLS versions and cipher suites that may be
+// negotiated with peers, both by the TLS client and the TLS server.
+type TLSOptions struct {
+	// Minimum TLS version that may be negotiated. If 0, the crypto/tls default
+	// is used.
+	MinVersion uint16 `json:"minVersion"`
+	// Cipher suites that may be negotiated for TLS 1.2 and below. If empty,
+	// the crypto/tls defaults are used. The TLS 1.3 cipher suites aren't
+	// configurable.
+	CipherSuites []uint16 `json:"cipherSuites"`
+}
+
+func TLSConfig(cert tls.Certificate, options TLSOptions) *tls.Config {
 	// #nosec G402
 	return &tls.Config{
 		Certificates: []tls.Certificate{cert},
@@ -17,5 +40,61 @@ func TLSConfig(cert tls.Certificate) *tls.Config {
 		// During our security audit by Quantstamp, this was investigated
 		// and confirmed to be safe and correct.
 		InsecureSkipVerify: true,
+		MinVersion:         options.MinVersion,
+		CipherSuites:       options.CipherSuites,
+	}
+}
+
+// ParseTLSVersion returns the TLS version named [name], which must be one of
+// "1.0", "1.1", "1.2" or "1.3".
+func ParseTLSVersion(name string) (uint16, error) {
+	version, ok := tlsVersions[strings.TrimSpace(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown TLS version %q", name)
+	}
+	return version, nil
+}
+
+// ParseCipherSuites returns the IDs of the cipher suites named [names], using
+// the names defined by crypto/tls (e.g.
+// "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"). Returns an error if a name is
+// unknown, names an insecure cipher suite, or names a TLS 1.3 cipher suite.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	suites := make(map[string]*tls.CipherSuite)
+	for _, suite := range tls.CipherSuites() {
+		suites[suite.Name] = suite
+	}
+	insecureSuites := make(map[string]struct{})
+	for _, suite := range tls.InsecureCipherSuites() {
+		insecureSuites[suite.Name] = struct{}{}
+	}
+
+	suiteIDs := make([]uint16, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := insecureSuites[name]; ok {
+			return nil, fmt.Errorf("cipher suite %s is insecure", name)
+		}
+		suite, ok := suites[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite %q", name)
+		}
+		if !supportsPreTLS13(suite) {
+			return nil, fmt.Errorf("cipher suite %s is only used by TLS 1.3, whose cipher suites aren't configurable", name)
+		}
+		suiteIDs = append(suiteIDs, suite.ID)
+	}
+	return suiteIDs, nil
+}
+
+func supportsPreTLS13(suite *tls.CipherSuite) bool {
+	for _, version := range suite.SupportedVersions {
+		if version < tls.VersionTLS13 {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var strictTLSOptions = TLSOptions{
+	MinVersion: tls.VersionTLS12,
+	CipherSuites: []uint16{
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	},
+}
+
+// Performs a TLS handshake over an in-memory connection. Returns the
+// connection state of the client, and the errors returned by both sides.
+func tlsHandshake(serverConfig, clientConfig *tls.Config) (tls.ConnectionState, error, error) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	serverErrs := make(chan error, 1)
+	go func() {
+		_, conn, _, err := NewTLSServerUpgrader(serverConfig).Upgrade(serverConn)
+		if err != nil {
+			// Unblock the client if it's waiting for the handshake to finish
+			_ = serverConn.Close()
+		} else {
+			// Unblock the client if it's waiting for a session ticket
+			_ = conn.Close()
+		}
+		serverErrs <- err
+	}()
+
+	_, conn, _, clientErr := NewTLSClientUpgrader(clientConfig).Upgrade(clientConn)
+	if clientErr != nil {
+		_ = clientConn.Close()
+		return tls.ConnectionState{}, <-serverErrs, clientErr
+	}
+	state := conn.(*tls.Conn).ConnectionState()
+	_ = conn.Close()
+	return state, <-serverErrs, nil
+}
+
+// Ensure that a peer with strict TLS options can connect to, and accept
+// connections from, peers with the default TLS options.
+func TestTLSOptionsStrictToDefaultHandshake(t *testing.T) {
+	initCerts(t)
+
+	strictConfig := TLSConfig(*cert0, strictTLSOptions)
+	defaultConfig := TLSConfig(*cert1, TLSOptions{})
+
+	// Peers that cap the TLS version at 1.2 must negotiate an allowed cipher
+	// suite
+	tls12Config := defaultConfig.Clone()
+	tls12Config.MaxVersion = tls.VersionTLS12
+
+	tests := []struct {
+		name         string
+		serverConfig *tls.Config
+		clientConfig *tls.Config
+	}{
+		{
+			name:         "strict server default client",
+			serverConfig: strictConfig,
+			clientConfig: defaultConfig,
+		},
+		{
+			name:         "default server strict client",
+			serverConfig: defaultConfig,
+			clientConfig: strictConfig,
+		},
+		{
+			name:         "strict server TLS 1.2 client",
+			serverConfig: strictConfig,
+			clientConfig: tls12Config,
+		},
+		{
+			name:         "TLS 1.2 server strict client",
+			serverConfig: tls12Config,
+			clientConfig: strictConfig,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			state, serverErr, clientErr := tlsHandshake(test.serverConfig, test.clientConfig)
+			assert.NoError(serverErr)
+			assert.NoError(clientErr)
+			assert.GreaterOrEqual(state.Version, strictTLSOptions.MinVersion)
+			if state.Version == tls.VersionTLS12 {
+				assert.Contains(strictTLSOptions.CipherSuites, state.CipherSuite)
+			}
+		})
+	}
+}
+
+// Ensure that a peer that requires TLS 1.3 rejects peers that only support
+// older versions.
+func TestTLSOptionsRejectsOldVersion(t *testing.T) {
+	assert := assert.New(t)
+	initCerts(t)
+
+	strictConfig := TLSConfig(*cert0, TLSOptions{MinVersion: tls.VersionTLS13})
+	oldConfig := TLSConfig(*cert1, TLSOptions{})
+	oldConfig.MaxVersion = tls.VersionTLS12
+
+	_, serverErr, clientErr := tlsHandshake(strictConfig, oldConfig)
+	assert.Error(serverErr)
+	assert.Error(clientErr)
+
+	_, serverErr, clientErr = tlsHandshake(oldConfig, strictConfig)
+	assert.Error(serverErr)
+	assert.Error(clientErr)
+}
+
+func TestParseTLSVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	version, err := ParseTLSVersion("1.2")
+	assert.NoError(err)
+	assert.EqualValues(tls.VersionTLS12, version)
+
+	version, err = ParseTLSVersion("1.3")
+	assert.NoError(err)
+	assert.EqualValues(tls.VersionTLS13, version)
+
+	_, err = ParseTLSVersion("1.4")
+	assert.Error(err)
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	assert := assert.New(t)
+
+	suites, err := ParseCipherSuites([]string{
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		" TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"",
+	})
+	assert.NoError(err)
+	assert.Equal(strictTLSOptions.CipherSuites, suites)
+
+	suites, err = ParseCipherSuites([]string{""})
+	assert.NoError(err)
+	assert.Empty(suites)
+
+	_, err = ParseCipherSuites([]string{"TLS_NOT_A_CIPHER_SUITE"})
+	assert.Error(err)
+
+	// Insecure
+	_, err = ParseCipherSuites([]string{"TLS_RSA_WITH_RC4_128_SHA"})
+	assert.Error(err)
+
+	// TLS 1.3 only
+	_, err = ParseCipherSuites([]string{"TLS_AES_128_GCM_SHA256"})
+	assert.Error(err)
+}
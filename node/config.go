@@ -7,6 +7,8 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
@@ -70,6 +72,8 @@ type IPConfig struct {
 	AttemptedNATTraversal bool `json:"attemptedNATTraversal"`
 	// Tries to perform network address translation
 	Nat nat.Router `json:"-"`
+	// Metrics of the NAT traversal, which is started before the node
+	NatMetrics prometheus.Gatherer `json:"-"`
 	// Dynamic Update duration for IP or NAT traversal
 	DynamicUpdateDuration time.Duration `json:"dynamicUpdateDuration"`
 	// Tries to resolve our IP from an external source
@@ -78,11 +82,12 @@ type IPConfig struct {
 
 type StakingConfig struct {
 	genesis.StakingConfig
-	EnableStaking         bool            `json:"enableStaking"`
-	StakingTLSCert        tls.Certificate `json:"-"`
-	DisabledStakingWeight uint64          `json:"disabledStakingWeight"`
-	StakingKeyPath        string          `json:"stakingKeyPath"`
-	StakingCertPath       string          `json:"stakingCertPath"`
+	EnableStaking         bool               `json:"enableStaking"`
+	StakingTLSCert        tls.Certificate    `json:"-"`
+	StakingTLSOptions     network.TLSOptions `json:"stakingTLSOptions"`
+	DisabledStakingWeight uint64             `json:"disabledStakingWeight"`
+	StakingKeyPath        string             `json:"stakingKeyPath"`
+	StakingCertPath       string             `json:"stakingCertPath"`
 }
 
 type BootstrapConfig struct {
@@ -108,6 +113,15 @@ type BootstrapConfig struct {
 
 	BootstrapIDs []ids.ShortID  `json:"bootstrapIDs"`
 	BootstrapIPs []utils.IPDesc `json:"bootstrapIPs"`
+	// Bootstrap beacons specified by hostname rather than by IP
+	BootstrapHostnames []BootstrapHostname `json:"bootstrapHostnames"`
+}
+
+// BootstrapHostname is the address of a bootstrap beacon that is resolved
+// using DNS
+type BootstrapHostname struct {
+	Hostname string `json:"hostname"`
+	Port     uint16 `json:"port"`
 }
 
 type DatabaseConfig struct {
@@ -127,6 +141,7 @@ type Config struct {
 	IPConfig            `json:"ipConfig"`
 	StakingConfig       `json:"stakingConfig"`
 	genesis.TxFeeConfig `json:"txFeeConfig"`
+	genesis.AVMConfig   `json:"avmConfig"`
 	BootstrapConfig     `json:"bootstrapConfig"`
 	DatabaseConfig      `json:"databaseConfig"`
 
@@ -173,6 +188,10 @@ type Config struct {
 	ConsensusRouter          router.Router       `json:"-"`
 	RouterHealthConfig       router.HealthConfig `json:"routerHealthConfig"`
 	ConsensusShutdownTimeout time.Duration       `json:"consensusShutdownTimeout"`
+	// Number of workers per chain that verify blocks ahead of their issuance
+	ConsensusVerifyWorkers int `json:"consensusVerifyWorkers"`
+	// Number of recently rejected blocks each chain remembers
+	ConsensusRejectedCacheSize int `json:"consensusRejectedCacheSize"`
 	// Gossip a container in the accepted frontier every [ConsensusGossipFrequency]
 	ConsensusGossipFrequency time.Duration `json:"consensusGossipFreq"`
 
@@ -41,6 +41,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/triggers"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -62,11 +63,13 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	ipcsapi "github.com/ava-labs/avalanchego/api/ipcs"
+	uptimemeter "github.com/ava-labs/avalanchego/utils/uptime"
 )
 
 var (
 	genesisHashKey  = []byte("genesisID")
 	indexerDBPrefix = []byte{0x00}
+	peersDBPrefix   = []byte("peers")
 
 	errInvalidTLSKey               = errors.New("invalid TLS key")
 	errPNotCreated                 = errors.New("P-Chain not created")
@@ -115,10 +118,16 @@ type Node struct {
 
 	uptimeCalculator uptime.LockedCalculator
 
+	// Tracks the CPU time spent handling messages from each node
+	cpuTracker tracker.AggregateTimeTracker
+
 	// dispatcher for events as they happen in consensus
 	DecisionDispatcher  *triggers.EventDispatcher
 	ConsensusDispatcher *triggers.EventDispatcher
 
+	// dispatcher for stakers leaving the P-chain's current staker set
+	StakerEndedDispatcher *triggers.EventDispatcher
+
 	IPCs *ipcs.ChainIPCs
 
 	// Net runs the networking stack
@@ -168,6 +177,11 @@ func (n *Node) initNetworking() error {
 	}
 	// Wrap listener so it will only accept a certain number of incoming connections per second
 	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxIncomingConnsPerSec)
+	if n.Config.NetworkConfig.ProxyProtocolEnabled {
+		// Wrap listener so the addresses of peers are read from the PROXY
+		// protocol header sent by the load balancer in front of this node
+		listener = network.NewProxyProtocolListener(listener, n.Config.NetworkConfig.ProxyHeaderTimeout, n.Log)
+	}
 
 	ipDesc, err := utils.ToIPDesc(listener.Addr().String())
 	if err != nil {
@@ -185,7 +199,7 @@ func (n *Node) initNetworking() error {
 		return errInvalidTLSKey
 	}
 
-	tlsConfig := network.TLSConfig(n.Config.StakingTLSCert)
+	tlsConfig := network.TLSConfig(n.Config.StakingTLSCert, n.Config.StakingTLSOptions)
 
 	// Initialize validator manager and primary network's validator set
 	primaryNetworkValidators := validators.NewSet()
@@ -201,6 +215,10 @@ func (n *Node) initNetworking() error {
 	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
+	n.cpuTracker = tracker.NewAggregateCPUTracker(
+		uptimemeter.IntervalFactory{},
+		n.Config.NetworkConfig.ThrottlerConfig.InboundCPUThrottlerConfig.Halflife,
+	)
 
 	consensusRouter := n.Config.ConsensusRouter
 	if !n.Config.EnableStaking {
@@ -249,10 +267,12 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.Validators = n.vdrs
 	n.Config.NetworkConfig.Beacons = n.beacons
 	n.Config.NetworkConfig.TLSConfig = tlsConfig
+	n.Config.NetworkConfig.PeerDB = prefixdb.New(peersDBPrefix, n.DB)
 	n.Config.NetworkConfig.TLSKey = tlsKey
 	n.Config.NetworkConfig.WhitelistedSubnets = n.Config.WhitelistedSubnets
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
+	n.Config.NetworkConfig.CPUTracker = n.cpuTracker
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -356,6 +376,9 @@ func (n *Node) Dispatch() error {
 			n.Net.TrackIP(peerIP)
 		}
 	}
+	for _, beacon := range n.Config.BootstrapHostnames {
+		n.Net.TrackHostname(beacon.Hostname, beacon.Port)
+	}
 
 	// Start P2P connections
 	err := n.Net.Dispatch()
@@ -425,6 +448,9 @@ func (n *Node) initEventDispatcher() error {
 	n.ConsensusDispatcher = &triggers.EventDispatcher{}
 	n.ConsensusDispatcher.Initialize(n.Log)
 
+	n.StakerEndedDispatcher = &triggers.EventDispatcher{}
+	n.StakerEndedDispatcher.Initialize(n.Log)
+
 	return n.ConsensusDispatcher.Register("gossip", n.Net)
 }
 
@@ -450,14 +476,15 @@ func (n *Node) initIndexer() error {
 	txIndexerDB := prefixdb.New(indexerDBPrefix, n.DB)
 	var err error
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
-		IndexingEnabled:      n.Config.IndexAPIEnabled,
-		AllowIncompleteIndex: n.Config.IndexAllowIncomplete,
-		DB:                   txIndexerDB,
-		Log:                  n.Log,
-		DecisionDispatcher:   n.DecisionDispatcher,
-		ConsensusDispatcher:  n.ConsensusDispatcher,
-		APIServer:            &n.APIServer,
-		ShutdownF:            func() { n.Shutdown(0) }, // TODO put exit code here
+		IndexingEnabled:       n.Config.IndexAPIEnabled,
+		AllowIncompleteIndex:  n.Config.IndexAllowIncomplete,
+		DB:                    txIndexerDB,
+		Log:                   n.Log,
+		DecisionDispatcher:    n.DecisionDispatcher,
+		ConsensusDispatcher:   n.ConsensusDispatcher,
+		StakerEndedDispatcher: n.StakerEndedDispatcher,
+		APIServer:             &n.APIServer,
+		ShutdownF:             func() { n.Shutdown(0) }, // TODO put exit code here
 	})
 	if err != nil {
 		return fmt.Errorf("couldn't create index for txs: %w", err)
@@ -620,12 +647,15 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		XChainID:                               xChainID,
 		CriticalChains:                         criticalChains,
 		TimeoutManager:                         timeoutManager,
+		CPUTracker:                             n.cpuTracker,
 		HealthService:                          n.healthService,
 		WhitelistedSubnets:                     n.Config.WhitelistedSubnets,
 		RetryBootstrap:                         n.Config.RetryBootstrap,
 		RetryBootstrapWarnFrequency:            n.Config.RetryBootstrapWarnFrequency,
 		ShutdownNodeFunc:                       n.Shutdown,
 		MeterVMEnabled:                         n.Config.MeterVMEnabled,
+		ConsensusVerifyWorkers:                 n.Config.ConsensusVerifyWorkers,
+		ConsensusRejectedCacheSize:             n.Config.ConsensusRejectedCacheSize,
 		Metrics:                                n.MetricsGatherer,
 		SubnetConfigs:                          n.Config.SubnetConfigs,
 		ChainConfigs:                           n.Config.ChainConfigs,
@@ -652,30 +682,35 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	errs := wrappers.Errs{}
 	errs.Add(
 		n.Config.VMManager.RegisterFactory(platformvm.ID, &platformvm.Factory{
-			Chains:                 n.chainManager,
-			Validators:             vdrs,
-			UptimeLockedCalculator: n.uptimeCalculator,
-			StakingEnabled:         n.Config.EnableStaking,
-			WhitelistedSubnets:     n.Config.WhitelistedSubnets,
-			TxFee:                  n.Config.TxFee,
-			CreateAssetTxFee:       n.Config.CreateAssetTxFee,
-			CreateSubnetTxFee:      n.Config.CreateSubnetTxFee,
-			CreateBlockchainTxFee:  n.Config.CreateBlockchainTxFee,
-			UptimePercentage:       n.Config.UptimeRequirement,
-			MinValidatorStake:      n.Config.MinValidatorStake,
-			MaxValidatorStake:      n.Config.MaxValidatorStake,
-			MinDelegatorStake:      n.Config.MinDelegatorStake,
-			MinDelegationFee:       n.Config.MinDelegationFee,
-			MinStakeDuration:       n.Config.MinStakeDuration,
-			MaxStakeDuration:       n.Config.MaxStakeDuration,
-			StakeMintingPeriod:     n.Config.StakeMintingPeriod,
-			ApricotPhase3Time:      version.GetApricotPhase3Time(n.Config.NetworkID),
-			ApricotPhase4Time:      version.GetApricotPhase4Time(n.Config.NetworkID),
-			ApricotPhase5Time:      version.GetApricotPhase5Time(n.Config.NetworkID),
+			Chains:                   n.chainManager,
+			Validators:               vdrs,
+			UptimeLockedCalculator:   n.uptimeCalculator,
+			StakingEnabled:           n.Config.EnableStaking,
+			WhitelistedSubnets:       n.Config.WhitelistedSubnets,
+			TxFee:                    n.Config.TxFee,
+			CreateAssetTxFee:         n.Config.CreateAssetTxFee,
+			CreateSubnetTxFee:        n.Config.CreateSubnetTxFee,
+			CreateBlockchainTxFee:    n.Config.CreateBlockchainTxFee,
+			UptimePercentage:         n.Config.UptimeRequirement,
+			MinValidatorStake:        n.Config.MinValidatorStake,
+			MaxValidatorStake:        n.Config.MaxValidatorStake,
+			MaxValidatorWeightFactor: n.Config.MaxValidatorWeightFactor,
+			MinDelegatorStake:        n.Config.MinDelegatorStake,
+			MinDelegationFee:         n.Config.MinDelegationFee,
+			MinStakeDuration:         n.Config.MinStakeDuration,
+			MaxStakeDuration:         n.Config.MaxStakeDuration,
+			StakeMintingPeriod:       n.Config.StakeMintingPeriod,
+			ApricotPhase3Time:        version.GetApricotPhase3Time(n.Config.NetworkID),
+			ApricotPhase4Time:        version.GetApricotPhase4Time(n.Config.NetworkID),
+			ApricotPhase5Time:        version.GetApricotPhase5Time(n.Config.NetworkID),
+			ApricotPhase6Time:        version.GetApricotPhase6Time(n.Config.NetworkID),
+			StakerEndedEvents:        n.StakerEndedDispatcher,
 		}),
 		n.Config.VMManager.RegisterFactory(avm.ID, &avm.Factory{
-			TxFee:            n.Config.TxFee,
-			CreateAssetTxFee: n.Config.CreateAssetTxFee,
+			TxFee:             n.Config.TxFee,
+			CreateAssetTxFee:  n.Config.CreateAssetTxFee,
+			MaxMemoSize:       n.Config.MaxMemoSize,
+			ApricotPhase6Time: version.GetApricotPhase6Time(n.Config.NetworkID),
 		}),
 		n.Config.VMManager.RegisterFactory(secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.Config.VMManager.RegisterFactory(nftfx.ID, &nftfx.Factory{}),
@@ -801,6 +836,11 @@ func (n *Node) initMetricsAPI() error {
 	if err := n.MetricsGatherer.Register(constants.PlatformName, n.MetricsRegisterer); err != nil {
 		return err
 	}
+	if n.Config.NatMetrics != nil {
+		if err := n.MetricsGatherer.Register("nat", n.Config.NatMetrics); err != nil {
+			return err
+		}
+	}
 
 	n.Log.Info("initializing metrics API")
 
@@ -847,6 +887,7 @@ func (n *Node) initAdminAPI() error {
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
 			LogFactory:   n.LogFactory,
 			NodeConfig:   n.Config,
+			Network:      n.Net,
 		},
 	)
 	if err != nil {
@@ -1042,6 +1083,9 @@ func (n *Node) Initialize(
 	n.Log = logger
 	n.Config = config
 	var err error
+	// The node ID is the hash of the raw staking certificate, so a different
+	// certificate is always a different node ID. The staking certificate can't
+	// be rotated without changing the node's identity.
 	n.ID, err = ids.ToShortID(hashing.PubkeyBytesToAddress(n.Config.StakingTLSCert.Leaf.Raw))
 	if err != nil {
 		return fmt.Errorf("problem deriving node ID from certificate: %w", err)
@@ -1087,7 +1131,8 @@ func (n *Node) Initialize(
 	n.networkNamespace = "network"
 	if n.msgCreator, err = message.NewCreator(n.MetricsRegisterer,
 		n.Config.NetworkConfig.CompressionEnabled,
-		n.networkNamespace); err != nil {
+		n.networkNamespace,
+		n.Config.NetworkConfig.MaxMessageSizes); err != nil {
 		return fmt.Errorf("problem TheOneCreator: %w", err)
 	}
 
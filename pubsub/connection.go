@@ -19,12 +19,28 @@ var (
 	ErrFilterNotInitialized        = errors.New("filter not initialized")
 	ErrAddressLimit                = errors.New("address limit exceeded")
 	ErrInvalidFilterParam          = errors.New("invalid bloom filter params")
+	ErrInvalidTxIDPrefix           = errors.New("invalid txID prefix")
 	ErrInvalidCommand              = errors.New("invalid command")
+	ErrInvalidMessage              = errors.New("invalid message")
 	_                       Filter = &connection{}
+
+	// errorCodes are the codes sent to the client for each kind of error
+	errorCodes = []struct {
+		err  error
+		code string
+	}{
+		{ErrFilterNotInitialized, "filterNotInitialized"},
+		{ErrAddressLimit, "addressLimit"},
+		{ErrInvalidFilterParam, "invalidFilterParam"},
+		{ErrInvalidTxIDPrefix, "invalidTxIDPrefix"},
+		{ErrInvalidCommand, "invalidCommand"},
+		{ErrInvalidMessage, "invalidMessage"},
+	}
 )
 
 type Filter interface {
 	Check(addr []byte) bool
+	CheckTxID(txID []byte) bool
 }
 
 // connection is a representation of the websocket connection.
@@ -46,6 +62,10 @@ func (c *connection) Check(addr []byte) bool {
 	return c.fp.Check(addr)
 }
 
+func (c *connection) CheckTxID(txID []byte) bool {
+	return c.fp.CheckTxID(txID)
+}
+
 func (c *connection) isActive() bool {
 	active := atomic.LoadUint32(&c.active)
 	return active != 0
@@ -147,15 +167,18 @@ func (c *connection) writePump() {
 	}
 }
 
+// readMessage reads and handles the next command from the client. Errors
+// handling the command are reported to the client, so only errors reading from
+// the connection are returned.
 func (c *connection) readMessage() error {
 	_, r, err := c.conn.NextReader()
 	if err != nil {
 		return err
 	}
 	cmd := &Command{}
-	err = json.NewDecoder(r).Decode(cmd)
-	if err != nil {
-		return err
+	if err := json.NewDecoder(r).Decode(cmd); err != nil {
+		c.sendError(cmd, fmt.Errorf("%w: %s", ErrInvalidMessage, err))
+		return nil
 	}
 
 	switch {
@@ -165,26 +188,45 @@ func (c *connection) readMessage() error {
 		c.handleNewSet(cmd.NewSet)
 	case cmd.AddAddresses != nil:
 		err = c.handleAddAddresses(cmd.AddAddresses)
+	case cmd.NewTxIDFilter != nil:
+		err = c.handleNewTxIDFilter(cmd.NewTxIDFilter)
 	default:
 		err = ErrInvalidCommand
 	}
 	if err != nil {
-		c.Send(&errorMsg{
-			Error: err.Error(),
-		})
+		c.s.log.Debug("pubsub command %s failed: %s", cmd, err)
+		c.sendError(cmd, err)
 	}
-	return err
+	return nil
+}
+
+func (c *connection) sendError(cmd *Command, err error) {
+	code := "unknown"
+	for _, errorCode := range errorCodes {
+		if errors.Is(err, errorCode.err) {
+			code = errorCode.code
+			break
+		}
+	}
+	c.Send(&errorMsg{
+		Error:   err.Error(),
+		Code:    code,
+		Command: cmd.String(),
+	})
 }
 
 func (c *connection) handleNewBloom(cmd *NewBloom) error {
-	if !cmd.IsParamsValid() {
-		return ErrInvalidFilterParam
+	if err := cmd.Verify(); err != nil {
+		return err
 	}
 	filter, err := bloom.New(uint64(cmd.MaxElements), float64(cmd.CollisionProb), MaxBytes)
 	if err != nil {
-		return fmt.Errorf("bloom filter creation failed %w", err)
+		return fmt.Errorf("%w: bloom filter creation failed %s", ErrInvalidFilterParam, err)
 	}
 	c.fp.SetFilter(filter)
+	c.Send(&newBloomReply{
+		NewBloom: cmd.Params(),
+	})
 	return nil
 }
 
@@ -203,3 +245,12 @@ func (c *connection) handleAddAddresses(cmd *AddAddresses) error {
 	c.s.subscribedConnections.Add(c)
 	return nil
 }
+
+func (c *connection) handleNewTxIDFilter(cmd *NewTxIDFilter) error {
+	if err := cmd.parsePrefixes(); err != nil {
+		return err
+	}
+	c.fp.SetTxIDPrefixes(cmd.prefixes)
+	c.s.subscribedConnections.Add(c)
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestConnectionInvalidCommandKeepsConnection(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(0, logging.NoLog{})
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(err)
+	defer conn.Close()
+
+	err = conn.WriteJSON(&Command{NewBloom: &NewBloom{
+		MaxElements:   1000,
+		CollisionProb: 1,
+	}})
+	assert.NoError(err)
+	errMsg := &errorMsg{}
+	assert.NoError(conn.ReadJSON(errMsg))
+	assert.Equal("invalidFilterParam", errMsg.Code)
+	assert.Equal("newBloom", errMsg.Command)
+
+	assert.NoError(conn.WriteMessage(websocket.TextMessage, []byte("{")))
+	errMsg = &errorMsg{}
+	assert.NoError(conn.ReadJSON(errMsg))
+	assert.Equal("invalidMessage", errMsg.Code)
+
+	// The connection is still usable after the errors
+	err = conn.WriteJSON(&Command{NewBloom: &NewBloom{
+		MaxElements:   1000,
+		CollisionProb: 0.01,
+	}})
+	assert.NoError(err)
+	reply := &newBloomReply{}
+	assert.NoError(conn.ReadJSON(reply))
+	assert.EqualValues(1000, reply.NewBloom.MaxElements)
+	assert.NotZero(reply.NewBloom.Bytes)
+}
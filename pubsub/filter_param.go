@@ -13,6 +13,11 @@ type FilterParam struct {
 	lock   sync.RWMutex
 	set    map[string]struct{}
 	filter bloom.Filter
+
+	// txIDPrefixes the txID prefixes to match against
+	txIDPrefixes map[string]struct{}
+	// prefixLens the distinct lengths of the prefixes in [txIDPrefixes]
+	prefixLens []int
 }
 
 func NewFilterParam() *FilterParam {
@@ -56,6 +61,41 @@ func (f *FilterParam) Check(addr []byte) bool {
 	return ok
 }
 
+// SetTxIDPrefixes replaces the txID prefixes that are matched against.
+func (f *FilterParam) SetTxIDPrefixes(prefixes [][]byte) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.txIDPrefixes = make(map[string]struct{}, len(prefixes))
+	f.prefixLens = nil
+	for _, prefix := range prefixes {
+		f.txIDPrefixes[string(prefix)] = struct{}{}
+	}
+	lens := make(map[int]struct{})
+	for prefix := range f.txIDPrefixes {
+		if _, ok := lens[len(prefix)]; !ok {
+			lens[len(prefix)] = struct{}{}
+			f.prefixLens = append(f.prefixLens, len(prefix))
+		}
+	}
+}
+
+// CheckTxID returns true if [txID] starts with one of the txID prefixes.
+func (f *FilterParam) CheckTxID(txID []byte) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	for _, prefixLen := range f.prefixLens {
+		if prefixLen > len(txID) {
+			continue
+		}
+		if _, ok := f.txIDPrefixes[string(txID[:prefixLen])]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *FilterParam) Add(bl ...[]byte) error {
 	filter := f.Filter()
 	if filter != nil {
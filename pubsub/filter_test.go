@@ -4,6 +4,7 @@
 package pubsub
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -92,3 +93,68 @@ func TestNewBloom(t *testing.T) {
 		t.Fatalf("new filter check failed")
 	}
 }
+
+func TestNewBloomVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := &NewBloom{
+		MaxElements:   100000,
+		CollisionProb: 0.001,
+	}
+	assert.NoError(cmd.Verify())
+	params := cmd.Params()
+	assert.Equal(cmd.MaxElements, params.MaxElements)
+	assert.InEpsilon(0.001, float64(params.CollisionProb), 0.5)
+	assert.LessOrEqual(uint64(params.Bytes), uint64(MaxBytes))
+
+	cmd.MaxElements = MaxBloomElements + 1
+	assert.ErrorIs(cmd.Verify(), ErrInvalidFilterParam)
+
+	cmd.MaxElements = 100000
+	cmd.CollisionProb = MaxCollisionProb * 2
+	assert.ErrorIs(cmd.Verify(), ErrInvalidFilterParam)
+
+	// The filter would be larger than [MaxBytes]
+	cmd.MaxElements = MaxBloomElements
+	cmd.CollisionProb = MinCollisionProb
+	assert.ErrorIs(cmd.Verify(), ErrInvalidFilterParam)
+}
+
+func TestNewTxIDFilterParsePrefixes(t *testing.T) {
+	assert := assert.New(t)
+
+	txID := ids.GenerateTestID()
+	cmd := &NewTxIDFilter{Prefixes: []string{
+		"0x" + hex.EncodeToString(txID[:4]),
+		hex.EncodeToString(txID[:]),
+	}}
+	assert.NoError(cmd.parsePrefixes())
+	assert.Equal([][]byte{txID[:4], txID[:]}, cmd.prefixes)
+
+	for _, prefix := range []string{"", "zz", hex.EncodeToString(make([]byte, len(ids.ID{})+1))} {
+		cmd := &NewTxIDFilter{Prefixes: []string{prefix}}
+		assert.ErrorIs(cmd.parsePrefixes(), ErrInvalidTxIDPrefix)
+	}
+
+	cmd = &NewTxIDFilter{Prefixes: make([]string, MaxTxIDPrefixes+1)}
+	assert.ErrorIs(cmd.parsePrefixes(), ErrInvalidTxIDPrefix)
+}
+
+func TestFilterParamCheckTxID(t *testing.T) {
+	assert := assert.New(t)
+
+	fp := NewFilterParam()
+	txID := ids.GenerateTestID()
+	assert.False(fp.CheckTxID(txID[:]))
+
+	otherTxID := txID
+	otherTxID[1]++
+	fp.SetTxIDPrefixes([][]byte{txID[:2], {^txID[0]}})
+	assert.True(fp.CheckTxID(txID[:]))
+	assert.False(fp.CheckTxID(otherTxID[:]))
+
+	// Address filters don't match txIDs
+	assert.NoError(fp.Add(txID[:]))
+	fp.SetTxIDPrefixes(nil)
+	assert.False(fp.CheckTxID(txID[:]))
+}
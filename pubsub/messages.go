@@ -4,7 +4,13 @@
 package pubsub
 
 import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bloom"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
 )
@@ -20,6 +26,33 @@ type NewBloom struct {
 // NewSet command for a new map set
 type NewSet struct{}
 
+// NewTxIDFilter command for a new filter that matches transactions whose IDs
+// start with one of the provided prefixes
+type NewTxIDFilter struct {
+	// Prefixes hex encoded txID prefixes
+	Prefixes []string `json:"prefixes"`
+
+	// prefixes kept as a [][]byte for use in the filter
+	prefixes [][]byte
+}
+
+// BloomParams are the parameters of the bloom filter that was created in
+// response to a NewBloom command
+type BloomParams struct {
+	// MaxElements size of bloom filter
+	MaxElements json.Uint64 `json:"maxElements"`
+	// CollisionProb expected error rate of the filter once it contains
+	// MaxElements elements
+	CollisionProb json.Float64 `json:"collisionProb"`
+	// Bytes allocated by the filter
+	Bytes json.Uint64 `json:"bytes"`
+}
+
+// newBloomReply is sent to the client after a bloom filter is created
+type newBloomReply struct {
+	NewBloom BloomParams `json:"newBloom"`
+}
+
 // AddAddresses command to add addresses
 type AddAddresses struct {
 	api.JSONAddresses
@@ -30,9 +63,10 @@ type AddAddresses struct {
 
 // Command execution command
 type Command struct {
-	NewBloom     *NewBloom     `json:"newBloom,omitempty"`
-	NewSet       *NewSet       `json:"newSet,omitempty"`
-	AddAddresses *AddAddresses `json:"addAddresses,omitempty"`
+	NewBloom      *NewBloom      `json:"newBloom,omitempty"`
+	NewSet        *NewSet        `json:"newSet,omitempty"`
+	AddAddresses  *AddAddresses  `json:"addAddresses,omitempty"`
+	NewTxIDFilter *NewTxIDFilter `json:"newTxIDFilter,omitempty"`
 }
 
 func (c *Command) String() string {
@@ -43,14 +77,46 @@ func (c *Command) String() string {
 		return "newSet"
 	case c.AddAddresses != nil:
 		return "addAddresses"
+	case c.NewTxIDFilter != nil:
+		return "newTxIDFilter"
 	default:
 		return "unknown"
 	}
 }
 
 func (c *NewBloom) IsParamsValid() bool {
+	return c.Verify() == nil
+}
+
+// Verify returns an error describing why the requested bloom filter can't be
+// created, if it can't be.
+func (c *NewBloom) Verify() error {
 	p := float64(c.CollisionProb)
-	return c.MaxElements > 0 && 0 < p && p <= 1
+	switch {
+	case c.MaxElements == 0 || uint64(c.MaxElements) > MaxBloomElements:
+		return fmt.Errorf("%w: maxElements must be in [1, %d] but is %d",
+			ErrInvalidFilterParam, MaxBloomElements, c.MaxElements)
+	case !(MinCollisionProb <= p && p <= MaxCollisionProb):
+		return fmt.Errorf("%w: collisionProb must be in [%g, %g] but is %g",
+			ErrInvalidFilterParam, MinCollisionProb, MaxCollisionProb, p)
+	}
+	if numBytes := bloom.Bytes(uint64(c.MaxElements), p); numBytes > MaxBytes {
+		return fmt.Errorf("%w: filter would use %d bytes, but at most %d bytes are allowed",
+			ErrInvalidFilterParam, numBytes, MaxBytes)
+	}
+	return nil
+}
+
+// Params returns the parameters of the bloom filter created by this command.
+// Assumes the command has been verified.
+func (c *NewBloom) Params() BloomParams {
+	maxN := uint64(c.MaxElements)
+	p := float64(c.CollisionProb)
+	return BloomParams{
+		MaxElements:   c.MaxElements,
+		CollisionProb: json.Float64(bloom.EffectiveCollisionProb(maxN, p)),
+		Bytes:         json.Uint64(bloom.Bytes(maxN, p)),
+	}
 }
 
 // parseAddresses converts the bech32 addresses to their byte format.
@@ -67,3 +133,23 @@ func (c *AddAddresses) parseAddresses() error {
 	}
 	return nil
 }
+
+// parsePrefixes converts the hex encoded prefixes to their byte format.
+func (c *NewTxIDFilter) parsePrefixes() error {
+	if len(c.Prefixes) > MaxTxIDPrefixes {
+		return fmt.Errorf("%w: at most %d prefixes are allowed but %d were provided",
+			ErrInvalidTxIDPrefix, MaxTxIDPrefixes, len(c.Prefixes))
+	}
+	c.prefixes = make([][]byte, len(c.Prefixes))
+	for i, prefixStr := range c.Prefixes {
+		prefix, err := hex.DecodeString(strings.TrimPrefix(prefixStr, "0x"))
+		if err != nil {
+			return fmt.Errorf("%w: couldn't decode %q: %s", ErrInvalidTxIDPrefix, prefixStr, err)
+		}
+		if len(prefix) == 0 || len(prefix) > len(ids.ID{}) {
+			return fmt.Errorf("%w: %q must be between 1 and %d bytes", ErrInvalidTxIDPrefix, prefixStr, len(ids.ID{}))
+		}
+		c.prefixes[i] = prefix
+	}
+	return nil
+}
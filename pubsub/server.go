@@ -41,10 +41,32 @@ const (
 
 	// MaxAddresses the max number of addresses allowed
 	MaxAddresses = 10000
+
+	// MaxBloomElements the max number of elements a bloom filter can be
+	// created for
+	MaxBloomElements = 10000000
+
+	// MinCollisionProb the min collision probability a bloom filter can be
+	// created with
+	MinCollisionProb = 1e-9
+
+	// MaxCollisionProb the max collision probability a bloom filter can be
+	// created with
+	MaxCollisionProb = 0.5
+
+	// MaxTxIDPrefixes the max number of txID prefixes allowed
+	MaxTxIDPrefixes = 1000
 )
 
+// errorMsg is sent to the client when a command fails. The connection stays
+// open, so the client can send a corrected command.
 type errorMsg struct {
+	// Error human readable description of the error
 	Error string `json:"error"`
+	// Code machine readable kind of the error
+	Code string `json:"code"`
+	// Command that failed
+	Command string `json:"command"`
 }
 
 var upgrader = websocket.Upgrader{
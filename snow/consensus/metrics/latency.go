@@ -52,6 +52,10 @@ type Latency struct {
 	// rejected tracks the number of nanoseconds that an item was processing
 	// before being rejected
 	latRejected metric.Averager
+
+	// latDecided tracks the distribution of the number of seconds that an
+	// item was processing before being accepted or rejected
+	latDecided prometheus.Histogram
 }
 
 // Initialize the metrics with the provided names.
@@ -64,6 +68,12 @@ func (m *Latency) Initialize(metricName, descriptionName string, log logging.Log
 		Name:      fmt.Sprintf("%s_processing", metricName),
 		Help:      fmt.Sprintf("Number of currently processing %s", metricName),
 	})
+	m.latDecided = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_decision_latency", metricName),
+		Help:      fmt.Sprintf("time (in seconds) from issuance of a %s to its acceptance or rejection", descriptionName),
+		Buckets:   prometheus.ExponentialBuckets(.01, 2, 15), // 10ms to ~164s
+	})
 
 	errs := wrappers.Errs{}
 	m.pollsAccepted = metric.NewAveragerWithErrs(
@@ -95,7 +105,10 @@ func (m *Latency) Initialize(metricName, descriptionName string, log logging.Log
 		&errs,
 	)
 
-	errs.Add(reg.Register(m.numProcessing))
+	errs.Add(
+		reg.Register(m.numProcessing),
+		reg.Register(m.latDecided),
+	)
 	return errs.Err
 }
 
@@ -124,6 +137,7 @@ func (m *Latency) Accepted(id ids.ID, pollNumber uint64) {
 	endTime := m.Clock.Time()
 	duration := endTime.Sub(start.time)
 	m.latAccepted.Observe(float64(duration))
+	m.latDecided.Observe(duration.Seconds())
 	m.numProcessing.Dec()
 }
 
@@ -143,6 +157,7 @@ func (m *Latency) Rejected(id ids.ID, pollNumber uint64) {
 	endTime := m.Clock.Time()
 	duration := endTime.Sub(start.time)
 	m.latRejected.Observe(float64(duration))
+	m.latDecided.Observe(duration.Seconds())
 	m.numProcessing.Dec()
 }
 
@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestLatencyProcessingAndDecisions(t *testing.T) {
+	assert := assert.New(t)
+	m := Latency{}
+	assert.NoError(m.Initialize("blks", "block(s)", logging.NoLog{}, "", prometheus.NewRegistry()))
+	m.Clock.Set(time.Unix(0, 0))
+
+	blkID0, blkID1, blkID2 := ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()
+	m.Issued(blkID0, 0)
+	m.Issued(blkID1, 0)
+	m.Issued(blkID2, 0)
+	assert.EqualValues(3, testutil.ToFloat64(m.numProcessing))
+
+	m.Clock.Set(time.Unix(1, 0))
+	m.Accepted(blkID0, 1)
+	m.Clock.Set(time.Unix(3, 0))
+	m.Rejected(blkID1, 2)
+	assert.EqualValues(1, testutil.ToFloat64(m.numProcessing))
+	assert.Equal(3*time.Second, m.MeasureAndGetOldestDuration())
+
+	// Deciding an item that isn't processing doesn't change the metrics
+	m.Accepted(blkID0, 3)
+	assert.EqualValues(1, testutil.ToFloat64(m.numProcessing))
+
+	decided := &dto.Metric{}
+	assert.NoError(m.latDecided.Write(decided))
+	assert.EqualValues(2, decided.GetHistogram().GetSampleCount())
+	assert.EqualValues(4, decided.GetHistogram().GetSampleSum())
+}
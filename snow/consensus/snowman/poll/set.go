@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 type pollHolder interface {
@@ -39,7 +40,10 @@ type set struct {
 	log      logging.Logger
 	numPolls prometheus.Gauge
 	durPolls metric.Averager
-	factory  Factory
+	// Number of polls created
+	numStarted        prometheus.Counter
+	durPollsHistogram prometheus.Histogram
+	factory           Factory
 	// maps requestID -> poll
 	polls linkedhashmap.LinkedHashmap
 }
@@ -70,12 +74,34 @@ func NewSet(
 		log.Error("failed to register poll_duration statistics due to %s", err)
 	}
 
+	numStarted := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "polls_started",
+		Help:      "Number of network polls started",
+	})
+	durPollsHistogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "poll_duration_seconds",
+		Help:      "Time (in seconds) network polls took to finish",
+		Buckets:   prometheus.ExponentialBuckets(.01, 2, 12), // 10ms to ~20s
+	})
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(numStarted),
+		reg.Register(durPollsHistogram),
+	)
+	if errs.Errored() {
+		log.Error("failed to register poll statistics due to %s", errs.Err)
+	}
+
 	return &set{
-		log:      log,
-		numPolls: numPolls,
-		durPolls: durPolls,
-		factory:  factory,
-		polls:    linkedhashmap.New(),
+		log:               log,
+		numPolls:          numPolls,
+		durPolls:          durPolls,
+		numStarted:        numStarted,
+		durPollsHistogram: durPollsHistogram,
+		factory:           factory,
+		polls:             linkedhashmap.New(),
 	}
 }
 
@@ -97,6 +123,7 @@ func (s *set) Add(requestID uint32, vdrs ids.ShortBag) bool {
 		start: time.Now(),
 	})
 	s.numPolls.Inc() // increase the metrics
+	s.numStarted.Inc()
 	return true
 }
 
@@ -143,7 +170,9 @@ func (s *set) processFinishedPolls() []ids.Bag {
 		}
 
 		s.log.Verbo("poll with requestID %d finished as %s", iter.Key(), holder.GetPoll())
-		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
+		duration := time.Since(holder.StartTime())
+		s.durPolls.Observe(float64(duration))
+		s.durPollsHistogram.Observe(duration.Seconds())
 		s.numPolls.Dec() // decrease the metrics
 
 		results = append(results, p.Result())
@@ -9,6 +9,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	dto "github.com/prometheus/client_model/go"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -324,3 +327,41 @@ func TestSetString(t *testing.T) {
 			str)
 	}
 }
+
+func TestSetPollMetrics(t *testing.T) {
+	assert := assert.New(t)
+	factory := NewEarlyTermNoTraversalFactory(2)
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s := NewSet(factory, log, namespace, registerer).(*set)
+
+	vdr1 := ids.ShortID{1}
+	vdr2 := ids.ShortID{2}
+	vdr3 := ids.ShortID{3}
+	vtxID := ids.ID{1}
+
+	// Finish a poll with votes
+	vdrBag := ids.ShortBag{}
+	vdrBag.Add(vdr1, vdr2, vdr3)
+	assert.True(s.Add(1, vdrBag))
+	assert.EqualValues(1, testutil.ToFloat64(s.numStarted))
+	assert.EqualValues(1, testutil.ToFloat64(s.numPolls))
+	assert.Empty(s.Vote(1, vdr1, vtxID))
+	assert.Len(s.Vote(1, vdr2, vtxID), 1)
+	assert.EqualValues(0, testutil.ToFloat64(s.numPolls))
+
+	// Finish a poll with dropped votes
+	vdrBag = ids.ShortBag{}
+	vdrBag.Add(vdr1, vdr2, vdr3)
+	assert.True(s.Add(2, vdrBag))
+	assert.EqualValues(2, testutil.ToFloat64(s.numStarted))
+	assert.Empty(s.Vote(2, vdr1, vtxID))
+	assert.Empty(s.Drop(2, vdr2))
+	assert.Len(s.Drop(2, vdr3), 1)
+	assert.EqualValues(0, testutil.ToFloat64(s.numPolls))
+
+	durations := &dto.Metric{}
+	assert.NoError(s.durPollsHistogram.Write(durations))
+	assert.EqualValues(2, durations.GetHistogram().GetSampleCount())
+}
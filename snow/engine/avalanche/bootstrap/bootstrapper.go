@@ -71,6 +71,8 @@ type Bootstrapper struct {
 	processedCache *cache.LRU
 	// number of state transitions executed
 	executedStateTransitions int
+	// Greatest height of the vertices that are being bootstrapped
+	tipHeight uint64
 
 	awaitingTimeout bool
 }
@@ -93,11 +95,15 @@ func (b *Bootstrapper) Initialize(
 	if err := b.metrics.Initialize(namespace, registerer); err != nil {
 		return err
 	}
+	if err := b.Progress.Initialize(namespace, registerer); err != nil {
+		return err
+	}
 
 	if err := b.VtxBlocked.SetParser(&vtxParser{
 		log:         config.Ctx.Log,
 		numAccepted: b.numAcceptedVts,
 		numDropped:  b.numDroppedVts,
+		progress:    &b.Progress,
 		manager:     b.Manager,
 	}); err != nil {
 		return err
@@ -206,6 +212,7 @@ func (b *Bootstrapper) process(vtxs ...avalanche.Vertex) error {
 				log:         b.Ctx.Log,
 				numAccepted: b.numAcceptedVts,
 				numDropped:  b.numDroppedVts,
+				progress:    &b.Progress,
 				vtx:         vtx,
 			}); err != nil {
 				return err
@@ -234,6 +241,7 @@ func (b *Bootstrapper) process(vtxs ...avalanche.Vertex) error {
 			}
 
 			b.numFetchedVts.Inc()
+			b.Progress.AddFetched(1)
 			b.NumFetched++ // Progress tracker
 			if b.NumFetched%common.StatusUpdateFrequency == 0 {
 				if !b.Restarted {
@@ -259,6 +267,12 @@ func (b *Bootstrapper) process(vtxs ...avalanche.Vertex) error {
 			if err != nil {
 				return err
 			}
+			// The vertices are traversed from the accepted frontier, so the
+			// highest of them is in the accepted frontier.
+			if height > b.tipHeight {
+				b.tipHeight = height
+				b.Progress.SetTargetHeight(b.tipHeight)
+			}
 			if height%stripeDistance < stripeWidth { // See comment for stripeDistance
 				b.processedCache.Put(vtxID, nil)
 			}
@@ -401,10 +415,20 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs []ids.ID) error {
 	pendingContainerIDs = append(pendingContainerIDs, acceptedContainerIDs...)
 	b.Ctx.Log.Debug("Starting bootstrapping with %d missing vertices and %d from the accepted frontier", len(pendingContainerIDs), len(acceptedContainerIDs))
 	toProcess := make([]avalanche.Vertex, 0, len(pendingContainerIDs))
+	// The progress starts from the highest of these vertices that we've
+	// already accepted
+	startingHeight := uint64(0)
 	for _, vtxID := range pendingContainerIDs {
 		if vtx, err := b.Manager.GetVtx(vtxID); err == nil {
 			if vtx.Status() == choices.Accepted {
 				b.VtxBlocked.RemoveMissingID(vtxID)
+				height, err := vtx.Height()
+				if err != nil {
+					return err
+				}
+				if height > startingHeight {
+					startingHeight = height
+				}
 			} else {
 				toProcess = append(toProcess, vtx) // Process this vertex.
 			}
@@ -413,6 +437,11 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs []ids.ID) error {
 			b.needToFetch.Add(vtxID) // We don't have this vertex. Mark that we have to fetch it.
 		}
 	}
+	if startingHeight > b.tipHeight {
+		b.tipHeight = startingHeight
+		b.Progress.SetTargetHeight(b.tipHeight)
+	}
+	b.Progress.Restart(startingHeight)
 	return b.process(toProcess...)
 }
 
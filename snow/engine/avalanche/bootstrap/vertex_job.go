@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -22,6 +23,7 @@ var errMissingVtxDependenciesOnAccept = errors.New("attempting to execute blocke
 type vtxParser struct {
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
+	progress                *common.BootstrapProgress
 	manager                 vertex.Manager
 }
 
@@ -34,6 +36,7 @@ func (p *vtxParser) Parse(vtxBytes []byte) (queue.Job, error) {
 		log:         p.log,
 		numAccepted: p.numAccepted,
 		numDropped:  p.numDropped,
+		progress:    p.progress,
 		vtx:         vtx,
 	}, nil
 }
@@ -41,6 +44,7 @@ func (p *vtxParser) Parse(vtxBytes []byte) (queue.Job, error) {
 type vertexJob struct {
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
+	progress                *common.BootstrapProgress
 	vtx                     avalanche.Vertex
 }
 
@@ -101,6 +105,7 @@ func (v *vertexJob) Execute() error {
 		return fmt.Errorf("attempting to execute vertex with status %s", status)
 	case choices.Processing:
 		v.numAccepted.Inc()
+		v.progress.AddExecuted(1)
 		v.log.Trace("accepting vertex %s in bootstrapping", v.vtx.ID())
 		if err := v.vtx.Accept(); err != nil {
 			return fmt.Errorf("failed to accept vertex in bootstrapping: %w", err)
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// BootstrapStatus is a snapshot of the progress of bootstrapping a chain.
+// The numbers are reset whenever bootstrapping is restarted.
+type BootstrapStatus struct {
+	// Number of containers fetched
+	Fetched uint64
+	// Number of containers executed
+	Executed uint64
+	// Height of the last accepted container when bootstrapping started
+	StartingHeight uint64
+	// Greatest known height of the accepted frontier being bootstrapped to
+	TargetHeight uint64
+	// Average number of containers fetched per second
+	FetchRate float64
+	// Average number of containers executed per second, since the first
+	// container was executed
+	ExecuteRate float64
+}
+
+// BootstrapStatusReporter is implemented by engines that report the progress
+// of their bootstrapping
+type BootstrapStatusReporter interface {
+	BootstrapStatus() BootstrapStatus
+}
+
+// BootstrapProgress tracks the progress of bootstrapping a chain. Unlike the
+// rest of the engine, it's safe for concurrent use, so that the progress can
+// be reported while the chain is bootstrapping.
+type BootstrapProgress struct {
+	lock   sync.Mutex
+	clock  mockable.Clock
+	status BootstrapStatus
+	// Time the current bootstrapping attempt started
+	fetchStart time.Time
+	// Time the first container of the current attempt was executed
+	executeStart time.Time
+}
+
+// Initialize registers the progress gauges
+func (p *BootstrapProgress) Initialize(namespace string, registerer prometheus.Registerer) error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(p.newGauge(namespace, "progress_fetched",
+			"Number of containers fetched during the current bootstrapping attempt",
+			func(s BootstrapStatus) float64 { return float64(s.Fetched) },
+		)),
+		registerer.Register(p.newGauge(namespace, "progress_executed",
+			"Number of containers executed during the current bootstrapping attempt",
+			func(s BootstrapStatus) float64 { return float64(s.Executed) },
+		)),
+		registerer.Register(p.newGauge(namespace, "progress_target_height",
+			"Greatest known height of the accepted frontier being bootstrapped to",
+			func(s BootstrapStatus) float64 { return float64(s.TargetHeight) },
+		)),
+		registerer.Register(p.newGauge(namespace, "progress_fetch_rate",
+			"Average number of containers fetched per second during the current bootstrapping attempt",
+			func(s BootstrapStatus) float64 { return s.FetchRate },
+		)),
+		registerer.Register(p.newGauge(namespace, "progress_execute_rate",
+			"Average number of containers executed per second during the current bootstrapping attempt",
+			func(s BootstrapStatus) float64 { return s.ExecuteRate },
+		)),
+	)
+	return errs.Err
+}
+
+func (p *BootstrapProgress) newGauge(
+	namespace string,
+	name string,
+	help string,
+	value func(BootstrapStatus) float64,
+) prometheus.GaugeFunc {
+	return prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      name,
+			Help:      help,
+		},
+		func() float64 { return value(p.Status()) },
+	)
+}
+
+// Restart resets the progress for a new bootstrapping attempt that starts
+// from [startingHeight]. The target height is kept.
+func (p *BootstrapProgress) Restart(startingHeight uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.status.Fetched = 0
+	p.status.Executed = 0
+	p.status.StartingHeight = startingHeight
+	p.fetchStart = p.clock.Time()
+	p.executeStart = time.Time{}
+}
+
+// SetTargetHeight sets the greatest known height of the accepted frontier
+func (p *BootstrapProgress) SetTargetHeight(height uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.status.TargetHeight = height
+}
+
+// AddFetched marks that [n] more containers were fetched
+func (p *BootstrapProgress) AddFetched(n uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.status.Fetched += n
+}
+
+// AddExecuted marks that [n] more containers were executed
+func (p *BootstrapProgress) AddExecuted(n uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.executeStart.IsZero() {
+		p.executeStart = p.clock.Time()
+	}
+	p.status.Executed += n
+}
+
+// Status returns a snapshot of the progress
+func (p *BootstrapProgress) Status() BootstrapStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.clock.Time()
+	status := p.status
+	status.FetchRate = rate(status.Fetched, p.fetchStart, now)
+	status.ExecuteRate = rate(status.Executed, p.executeStart, now)
+	return status
+}
+
+// Returns the average number of events per second between [start] and [now],
+// given that [n] events happened.
+func rate(n uint64, start, now time.Time) float64 {
+	if start.IsZero() {
+		return 0
+	}
+	elapsed := now.Sub(start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(n) / elapsed
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapProgress(t *testing.T) {
+	assert := assert.New(t)
+
+	p := BootstrapProgress{}
+	start := time.Unix(1000, 0)
+	p.clock.Set(start)
+
+	p.Restart(10)
+	p.SetTargetHeight(110)
+	p.AddFetched(40)
+
+	p.clock.Set(start.Add(2 * time.Second))
+	p.AddExecuted(5)
+
+	p.clock.Set(start.Add(4 * time.Second))
+	p.AddExecuted(15)
+
+	status := p.Status()
+	assert.EqualValues(40, status.Fetched)
+	assert.EqualValues(20, status.Executed)
+	assert.EqualValues(10, status.StartingHeight)
+	assert.EqualValues(110, status.TargetHeight)
+	assert.Equal(10.0, status.FetchRate)
+	assert.Equal(10.0, status.ExecuteRate)
+
+	// Restarting resets the counts and rates, but keeps the target
+	p.Restart(30)
+	status = p.Status()
+	assert.EqualValues(0, status.Fetched)
+	assert.EqualValues(0, status.Executed)
+	assert.EqualValues(30, status.StartingHeight)
+	assert.EqualValues(110, status.TargetHeight)
+	assert.Zero(status.FetchRate)
+	assert.Zero(status.ExecuteRate)
+}
+
+func TestBootstrapProgressMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	p := BootstrapProgress{}
+	registerer := prometheus.NewRegistry()
+	assert.NoError(p.Initialize("", registerer))
+
+	p.Restart(0)
+	p.SetTargetHeight(7)
+	p.AddFetched(3)
+	p.AddExecuted(2)
+
+	count, err := testutil.GatherAndCount(registerer)
+	assert.NoError(err)
+	assert.Equal(5, count)
+
+	families, err := registerer.Gather()
+	assert.NoError(err)
+	values := make(map[string]float64, len(families))
+	for _, family := range families {
+		values[family.GetName()] = family.GetMetric()[0].GetGauge().GetValue()
+	}
+	assert.Equal(3.0, values["progress_fetched"])
+	assert.Equal(2.0, values["progress_executed"])
+	assert.Equal(7.0, values["progress_target_height"])
+}
@@ -7,9 +7,17 @@ type Fetcher struct {
 	// number of containers fetched so far
 	NumFetched uint32
 
+	// reports the progress of bootstrapping
+	Progress BootstrapProgress
+
 	// tracks which validators were asked for which containers in which requests
 	OutstandingRequests Requests
 
 	// Called when bootstrapping is done
 	OnFinished func() error
 }
+
+// BootstrapStatus implements the BootstrapStatusReporter interface
+func (f *Fetcher) BootstrapStatus() BootstrapStatus {
+	return f.Progress.Status()
+}
@@ -0,0 +1,17 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+// ConcurrentVerifierVM is implemented by ChainVMs whose blocks may be verified
+// concurrently with each other and with calls to the VM's other methods.
+// The consensus engine only verifies blocks ahead of their issuance to
+// consensus if the VM reports that this is supported. The engine never
+// verifies, accepts or rejects a block while it is still being verified.
+//
+// VMs that wrap another ChainVM should forward this to the wrapped VM.
+type ConcurrentVerifierVM interface {
+	// Returns true if Verify may be called on this VM's blocks from multiple
+	// goroutines at the same time.
+	ConcurrentVerifyEnabled() bool
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -22,6 +23,7 @@ var errMissingDependenciesOnAccept = errors.New("attempting to accept a block wi
 type parser struct {
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
+	progress                *common.BootstrapProgress
 	vm                      block.ChainVM
 }
 
@@ -35,6 +37,7 @@ func (p *parser) Parse(blkBytes []byte) (queue.Job, error) {
 		log:         p.log,
 		numAccepted: p.numAccepted,
 		numDropped:  p.numDropped,
+		progress:    p.progress,
 		blk:         blk,
 		vm:          p.vm,
 	}, nil
@@ -44,6 +47,7 @@ type blockJob struct {
 	parser                  *parser
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
+	progress                *common.BootstrapProgress
 	blk                     snowman.Block
 	vm                      block.Getter
 }
@@ -88,6 +92,7 @@ func (b *blockJob) Execute() error {
 		}
 
 		b.numAccepted.Inc()
+		b.progress.AddExecuted(1)
 		b.log.Trace("accepting block (%s, %d) in bootstrapping", blkID, b.blk.Height())
 		if err := b.blk.Accept(); err != nil {
 			b.log.Debug("block %s failed to accept during bootstrapping due to %s", blkID, err)
@@ -92,11 +92,15 @@ func (b *Bootstrapper) Initialize(
 	if err := b.metrics.Initialize(namespace, registerer); err != nil {
 		return err
 	}
+	if err := b.Progress.Initialize(namespace, registerer); err != nil {
+		return err
+	}
 
 	b.parser = &parser{
 		log:         config.Ctx.Log,
 		numAccepted: b.numAccepted,
 		numDropped:  b.numDropped,
+		progress:    &b.Progress,
 		vm:          b.VM,
 	}
 	if err := b.Blocked.SetParser(b.parser); err != nil {
@@ -132,6 +136,16 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs []ids.ID) error {
 
 	b.NumFetched = 0
 
+	lastAcceptedID, err := b.VM.LastAccepted()
+	if err != nil {
+		return fmt.Errorf("couldn't get last accepted ID: %w", err)
+	}
+	lastAccepted, err := b.VM.GetBlock(lastAcceptedID)
+	if err != nil {
+		return fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+	b.Progress.Restart(lastAccepted.Height())
+
 	pendingContainerIDs := b.Blocked.MissingIDs()
 
 	// Append the list of accepted container IDs to pendingContainerIDs to ensure
@@ -158,6 +172,7 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs []ids.ID) error {
 			}
 		}
 	}
+	b.Progress.SetTargetHeight(b.tipHeight)
 
 	// Process received blocks
 	for _, blk := range toProcess {
@@ -275,6 +290,7 @@ func (b *Bootstrapper) process(blk snowman.Block, processingBlocks map[ids.ID]sn
 	blkHeight := blk.Height()
 	if blkHeight > b.tipHeight && b.startingAcceptedFrontier.Contains(blkID) {
 		b.tipHeight = blkHeight
+		b.Progress.SetTargetHeight(b.tipHeight)
 	}
 
 	for status == choices.Processing {
@@ -288,6 +304,7 @@ func (b *Bootstrapper) process(blk snowman.Block, processingBlocks map[ids.ID]sn
 			parser:      b.parser,
 			numAccepted: b.numAccepted,
 			numDropped:  b.numDropped,
+			progress:    &b.Progress,
 			blk:         blk,
 			vm:          b.VM,
 		})
@@ -319,6 +336,7 @@ func (b *Bootstrapper) process(blk snowman.Block, processingBlocks map[ids.ID]sn
 		}
 
 		b.numFetched.Inc()
+		b.Progress.AddFetched(1)
 		b.NumFetched++                                      // Progress tracker
 		if b.NumFetched%common.StatusUpdateFrequency == 0 { // Periodically print progress
 			if !b.Restarted {
@@ -1068,3 +1068,124 @@ func TestRestartBootstrapping(t *testing.T) {
 		t.Fatalf("Block should be accepted")
 	}
 }
+
+func TestBootstrapperProgress(t *testing.T) {
+	config, peerID, sender, vm := newConfig(t)
+
+	blkID0 := ids.Empty.Prefix(0)
+	blkID1 := ids.Empty.Prefix(1)
+	blkID2 := ids.Empty.Prefix(2)
+
+	blkBytes0 := []byte{0}
+	blkBytes1 := []byte{1}
+	blkBytes2 := []byte{2}
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+		BytesV:  blkBytes0,
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID1,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk0.IDV,
+		HeightV: 1,
+		BytesV:  blkBytes1,
+	}
+	blk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID2,
+			StatusV: choices.Processing,
+		},
+		ParentV: blk1.IDV,
+		HeightV: 2,
+		BytesV:  blkBytes2,
+	}
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func() (ids.ID, error) { return blk0.ID(), nil }
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		assert.Equal(t, blk0.ID(), blkID)
+		return blk0, nil
+	}
+
+	finished := new(bool)
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		"chain_"+config.Ctx.ChainID.String(),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsedBlk1 := false
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case blkID0:
+			return blk0, nil
+		case blkID1:
+			if parsedBlk1 {
+				return blk1, nil
+			}
+			return nil, errUnknownBlock
+		case blkID2:
+			return blk2, nil
+		default:
+			t.Fatal(errUnknownBlock)
+			panic(errUnknownBlock)
+		}
+	}
+	vm.ParseBlockF = func(blkBytes []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(blkBytes, blkBytes1):
+			blk1.StatusV = choices.Processing
+			parsedBlk1 = true
+			return blk1, nil
+		case bytes.Equal(blkBytes, blkBytes2):
+			return blk2, nil
+		}
+		t.Fatal(errUnknownBlock)
+		return nil, errUnknownBlock
+	}
+
+	requestID := new(uint32)
+	sender.SendGetAncestorsF = func(vdr ids.ShortID, reqID uint32, vtxID ids.ID) {
+		assert.Equal(t, peerID, vdr)
+		assert.Equal(t, blkID1, vtxID)
+		*requestID = reqID
+	}
+
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted([]ids.ID{blkID2}); err != nil { // should request blk1
+		t.Fatal(err)
+	}
+
+	status := bs.BootstrapStatus()
+	assert.Equal(t, uint64(1), status.Fetched)
+	assert.Equal(t, uint64(0), status.Executed)
+	assert.Equal(t, uint64(0), status.StartingHeight)
+	assert.Equal(t, uint64(2), status.TargetHeight)
+
+	vm.CantBootstrapped = false
+
+	if err := bs.MultiPut(peerID, *requestID, [][]byte{blkBytes1}); err != nil { // respond with blk1
+		t.Fatal(err)
+	}
+	if !*finished {
+		t.Fatalf("Bootstrapping should have finished")
+	}
+
+	status = bs.BootstrapStatus()
+	assert.Equal(t, uint64(2), status.Fetched)
+	assert.Equal(t, uint64(2), status.Executed)
+	assert.Equal(t, uint64(2), status.TargetHeight)
+}
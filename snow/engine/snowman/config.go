@@ -15,4 +15,15 @@ type Config struct {
 
 	Params    snowball.Parameters
 	Consensus snowman.Consensus
+
+	// Number of workers that verify blocks ahead of their issuance to
+	// consensus. Only used if the VM implements block.ConcurrentVerifierVM
+	// and reports that concurrent verification is enabled. If 0, blocks are
+	// only verified when they're issued.
+	VerifyWorkers int
+
+	// Number of recently rejected blocks to remember, so that they can be
+	// dropped without being parsed when they're received again. If 0,
+	// rejected blocks aren't remembered.
+	RejectedCacheSize int
 }
@@ -29,6 +29,7 @@ func (i *issuer) Abandon(ids.ID) {
 	if !i.abandoned {
 		blkID := i.blk.ID()
 		i.t.removeFromPending(i.blk)
+		i.t.verifier.forget(blkID)
 		i.t.addToNonVerifieds(i.blk)
 		i.t.blocked.Abandon(blkID)
 
@@ -4,6 +4,7 @@
 package snowman
 
 import (
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 )
 
@@ -11,8 +12,11 @@ import (
 type memoryBlock struct {
 	snowman.Block
 
-	tree    AncestorTree
-	metrics *metrics
+	tree     AncestorTree
+	metrics  *metrics
+	verifier *verifier
+	// recently rejected blocks. May be nil.
+	rejected cache.Cacher
 }
 
 // Accept accepts the underlying block & removes sibling subtrees
@@ -24,7 +28,12 @@ func (mb *memoryBlock) Accept() error {
 
 // Reject rejects the underlying block & removes child subtrees
 func (mb *memoryBlock) Reject() error {
-	mb.tree.RemoveSubtree(mb.ID())
+	blkID := mb.ID()
+	mb.tree.RemoveSubtree(blkID)
+	mb.verifier.rejected(blkID)
+	if mb.rejected != nil {
+		mb.rejected.Put(blkID, nil)
+	}
 	mb.metrics.numNonVerifieds.Set(float64(mb.tree.Len()))
 	return mb.Block.Reject()
 }
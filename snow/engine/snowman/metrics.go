@@ -14,6 +14,10 @@ type metrics struct {
 	bootstrapFinished, numRequests, numBlocked, numBlockers, numNonVerifieds prometheus.Gauge
 	numBuilt, numBuildsFailed                                                prometheus.Counter
 	getAncestorsBlks                                                         metric.Averager
+	numPrefetched                                                            prometheus.Gauge
+	numPrefetchHits                                                          prometheus.Counter
+	numDroppedRejected                                                       prometheus.Counter
+	numPollsReachedAlpha, numPollsMissedAlpha                                prometheus.Counter
 }
 
 // Initialize the metrics
@@ -61,6 +65,31 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		Name:      "non_verified_blks",
 		Help:      "Number of non-verified blocks in the memory",
 	})
+	m.numPrefetched = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "prefetched_blks",
+		Help:      "Number of blocks verified, or being verified, ahead of their issuance to consensus",
+	})
+	m.numPrefetchHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "prefetched_blk_hits",
+		Help:      "Number of blocks issued to consensus whose verification was done ahead of their issuance",
+	})
+	m.numDroppedRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rejected_blks_dropped",
+		Help:      "Number of received blocks that were dropped because they were recently rejected",
+	})
+	m.numPollsReachedAlpha = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "polls_reached_alpha",
+		Help:      "Number of finished polls where a processing block received alpha votes, counting votes for its descendants",
+	})
+	m.numPollsMissedAlpha = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "polls_missed_alpha",
+		Help:      "Number of finished polls where no processing block received alpha votes, counting votes for its descendants",
+	})
 
 	errs.Add(
 		reg.Register(m.bootstrapFinished),
@@ -70,6 +99,11 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		reg.Register(m.numBuilt),
 		reg.Register(m.numBuildsFailed),
 		reg.Register(m.numNonVerifieds),
+		reg.Register(m.numPrefetched),
+		reg.Register(m.numPrefetchHits),
+		reg.Register(m.numDroppedRejected),
+		reg.Register(m.numPollsReachedAlpha),
+		reg.Register(m.numPollsMissedAlpha),
 	)
 	return errs.Err
 }
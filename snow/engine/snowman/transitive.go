@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
@@ -45,6 +46,13 @@ type Transitive struct {
 	// Block ID --> Parent ID
 	nonVerifieds AncestorTree
 
+	// verifies blocks ahead of their issuance to consensus
+	verifier *verifier
+
+	// IDs of recently rejected blocks. nil if rejected blocks aren't
+	// remembered.
+	rejected cache.Cacher
+
 	// operations that are blocked on a block being issued. This could be
 	// issuing another block, responding to a query, or applying votes to consensus
 	blocked events.Blocker
@@ -77,6 +85,17 @@ func (t *Transitive) Initialize(config Config) error {
 		return err
 	}
 
+	// Blocks are only verified concurrently if the VM supports it
+	verifyWorkers := 0
+	if vm, ok := config.VM.(block.ConcurrentVerifierVM); ok && vm.ConcurrentVerifyEnabled() {
+		verifyWorkers = config.VerifyWorkers
+	}
+	t.verifier = newVerifier(verifyWorkers, &t.metrics)
+
+	if config.RejectedCacheSize > 0 {
+		t.rejected = &cache.LRU{Size: config.RejectedCacheSize}
+	}
+
 	return t.Bootstrapper.Initialize(
 		config.Config,
 		t.finishBootstrapping,
@@ -152,6 +171,8 @@ func (t *Transitive) Gossip() error {
 // Shutdown implements the Engine interface
 func (t *Transitive) Shutdown() error {
 	t.Ctx.Log.Info("shutting down consensus engine")
+	// Wait for outstanding verifications before shutting down the VM
+	t.verifier.shutdown()
 	return t.VM.Shutdown()
 }
 
@@ -204,6 +225,14 @@ func (t *Transitive) Put(vdr ids.ShortID, requestID uint32, blkID ids.ID, blkByt
 		return nil
 	}
 
+	if t.wasRejected(blkID) {
+		t.Ctx.Log.Verbo("dropping Put(%s, %d, %s) as the block was rejected", vdr, requestID, blkID)
+		t.metrics.numDroppedRejected.Inc()
+		// The block will never be issued, so we shouldn't wait on a response
+		// to a request for it.
+		return t.GetFailed(vdr, requestID)
+	}
+
 	blk, err := t.VM.ParseBlock(blkBytes)
 	if err != nil {
 		t.Ctx.Log.Debug("failed to parse block %s: %s", blkID, err)
@@ -289,6 +318,15 @@ func (t *Transitive) PushQuery(vdr ids.ShortID, requestID uint32, blkID ids.ID,
 		return nil
 	}
 
+	if t.wasRejected(blkID) {
+		t.Ctx.Log.Verbo("dropping block %s from PushQuery(%s, %d) as it was rejected", blkID, vdr, requestID)
+		t.metrics.numDroppedRejected.Inc()
+		// The block will never be issued, so respond with our preference
+		// right away.
+		t.Sender.SendChits(vdr, requestID, []ids.ID{t.Consensus.Preference()})
+		return t.buildBlocks()
+	}
+
 	blk, err := t.VM.ParseBlock(blkBytes)
 	// If parsing fails, we just drop the request, as we didn't ask for it
 	if err != nil {
@@ -686,6 +724,7 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 	if err != nil || !t.Consensus.AcceptedOrProcessing(parent) {
 		// if the parent isn't processing or the last accepted block, then this
 		// block is effectively rejected
+		t.verifier.forget(blkID)
 		t.blocked.Abandon(blkID)
 		t.metrics.numBlocked.Set(float64(len(t.pending))) // Tracks performance statistics
 		t.metrics.numBlockers.Set(float64(t.blocked.Len()))
@@ -697,7 +736,7 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 	// calling Verify on this block is allowed.
 
 	// make sure this block is valid
-	if err := blk.Verify(); err != nil {
+	if err := t.verifier.verify(blk); err != nil {
 		t.Ctx.Log.Debug("block failed verification due to %s, dropping block", err)
 
 		// if verify fails, then all descendants are also invalid
@@ -711,9 +750,11 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 	t.metrics.numNonVerifieds.Set(float64(t.nonVerifieds.Len()))
 	t.Ctx.Log.Verbo("adding block to consensus: %s", blkID)
 	wrappedBlk := &memoryBlock{
-		Block:   blk,
-		metrics: &t.metrics,
-		tree:    t.nonVerifieds,
+		Block:    blk,
+		metrics:  &t.metrics,
+		tree:     t.nonVerifieds,
+		verifier: t.verifier,
+		rejected: t.rejected,
 	}
 	if err := t.Consensus.Add(wrappedBlk); err != nil {
 		return err
@@ -731,8 +772,13 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 				return err
 			}
 
+			// The options can be verified concurrently, as their parent was
+			// just verified.
 			for _, blk := range options {
-				if err := blk.Verify(); err != nil {
+				t.prefetch(blk)
+			}
+			for _, blk := range options {
+				if err := t.verifier.verify(blk); err != nil {
 					t.Ctx.Log.Debug("block failed verification due to %s, dropping block", err)
 					dropped = append(dropped, blk)
 					// block fails verification, hold this in memory for bubbling
@@ -743,9 +789,11 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 					t.nonVerifieds.Remove(blk.ID())
 					t.metrics.numNonVerifieds.Set(float64(t.nonVerifieds.Len()))
 					wrappedBlk := &memoryBlock{
-						Block:   blk,
-						metrics: &t.metrics,
-						tree:    t.nonVerifieds,
+						Block:    blk,
+						metrics:  &t.metrics,
+						tree:     t.nonVerifieds,
+						verifier: t.verifier,
+						rejected: t.rejected,
 					}
 					if err := t.Consensus.Add(wrappedBlk); err != nil {
 						return err
@@ -766,6 +814,14 @@ func (t *Transitive) deliver(blk snowman.Block) error {
 		t.pushQuery(blk)
 	}
 
+	// The pending children of the newly added blocks can now be verified.
+	// Start verifying all of them before any of them is issued, so that
+	// independent branches are verified concurrently.
+	t.prefetchChildren(blkID)
+	for _, blk := range added {
+		t.prefetchChildren(blk.ID())
+	}
+
 	t.blocked.Fulfill(blkID)
 	for _, blk := range added {
 		if t.Consensus.IsPreferred(blk) {
@@ -841,6 +897,36 @@ func (t *Transitive) pendingContains(blkID ids.ID) bool {
 	return ok
 }
 
+// prefetch starts verifying [blk] ahead of its issuance to consensus. The
+// parent of [blk] must have been verified.
+func (t *Transitive) prefetch(blk snowman.Block) {
+	// Blocks aren't verified ahead of time during bootstrapping, as the VM's
+	// state is still being executed.
+	if !t.Ctx.IsBootstrapped() {
+		return
+	}
+	t.verifier.prefetch(blk)
+}
+
+// prefetchChildren starts verifying the pending blocks whose parent is
+// [parentID]. The block [parentID] must have been verified.
+func (t *Transitive) prefetchChildren(parentID ids.ID) {
+	for _, blk := range t.pending {
+		if blk.Parent() == parentID {
+			t.prefetch(blk)
+		}
+	}
+}
+
+// Returns true if the block whose ID is [blkID] was recently rejected
+func (t *Transitive) wasRejected(blkID ids.ID) bool {
+	if t.rejected == nil {
+		return false
+	}
+	_, ok := t.rejected.Get(blkID)
+	return ok
+}
+
 func (t *Transitive) removeFromPending(blk snowman.Block) {
 	delete(t.pending, blk.ID())
 }
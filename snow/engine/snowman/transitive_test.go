@@ -6,8 +6,10 @@ package snowman
 import (
 	"bytes"
 	"errors"
+	"sync/atomic"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
@@ -17,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -3023,3 +3026,170 @@ func TestEngineBubbleVotesThroughInvalidChain(t *testing.T) {
 		t.Fatalf("Expected blk1 to be Accepted, but found status: %s", blk1.Status())
 	}
 }
+
+func TestEnginePrefetchesPendingChildren(t *testing.T) {
+	_, _, sender, vm, te, gBlk := setup(t)
+	te.verifier = newTestVerifier(t, 1)
+
+	parentBlk := newCountingBlock(gBlk.ID(), 1)
+	childBlk := newCountingBlock(parentBlk.ID(), 2)
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case parentBlk.ID():
+			return parentBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	sender.CantSendPushQuery = false
+	sender.CantSendPullQuery = false
+
+	// Issuing the child issues its parent, after which the child is verified
+	// ahead of its own issuance.
+	added, err := te.issueWithAncestors(childBlk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !added {
+		t.Fatalf("should have issued the block")
+	}
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&parentBlk.verifies))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&childBlk.verifies))
+	assert.Equal(t, 1.0, testutil.ToFloat64(te.verifier.metrics.numPrefetchHits))
+	assert.Equal(t, 2, te.Consensus.NumProcessing())
+}
+
+func TestEngineDropsRejectedBlock(t *testing.T) {
+	vdr, _, sender, vm, te, gBlk := setup(t)
+	te.rejected = &cache.LRU{Size: 16}
+
+	acceptedBlk := newCountingBlock(gBlk.ID(), 1)
+	rejectedBlk := newCountingBlock(gBlk.ID(), 1)
+	rejectedBlk.BytesV = []byte{2}
+
+	vm.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, acceptedBlk.Bytes()):
+			return acceptedBlk, nil
+		case bytes.Equal(b, rejectedBlk.Bytes()):
+			return rejectedBlk, nil
+		default:
+			t.Fatalf("Unknown block bytes")
+			return nil, nil
+		}
+	}
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case acceptedBlk.ID():
+			return acceptedBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	var reqID uint32
+	sender.SendPushQueryF = func(_ ids.ShortSet, rID uint32, _ ids.ID, _ []byte) { reqID = rID }
+	sender.SendPullQueryF = func(_ ids.ShortSet, rID uint32, _ ids.ID) { reqID = rID }
+
+	if err := te.Put(vdr, constants.GossipMsgRequestID, acceptedBlk.ID(), acceptedBlk.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.Put(vdr, constants.GossipMsgRequestID, rejectedBlk.ID(), rejectedBlk.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Vote for [acceptedBlk] until it's accepted, which rejects [rejectedBlk]
+	for i := 0; i < te.Params.BetaRogue; i++ {
+		if err := te.Chits(vdr, reqID, []ids.ID{acceptedBlk.ID()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if status := rejectedBlk.Status(); status != choices.Rejected {
+		t.Fatalf("Should have rejected the block, but its status is %s", status)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&rejectedBlk.verifies))
+
+	// The VM may no longer know that the block was rejected
+	rejectedBlk.StatusV = choices.Processing
+	vm.ParseBlockF = func([]byte) (snowman.Block, error) {
+		t.Fatalf("Shouldn't have parsed the rejected block")
+		return nil, nil
+	}
+
+	if err := te.Put(vdr, constants.GossipMsgRequestID, rejectedBlk.ID(), rejectedBlk.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	chitsSent := false
+	sender.SendChitsF = func(inVdr ids.ShortID, requestID uint32, votes []ids.ID) {
+		chitsSent = true
+		assert.Equal(t, vdr, inVdr)
+		assert.Equal(t, uint32(5), requestID)
+		assert.Equal(t, []ids.ID{acceptedBlk.ID()}, votes)
+	}
+	if err := te.PushQuery(vdr, 5, rejectedBlk.ID(), rejectedBlk.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !chitsSent {
+		t.Fatalf("Should have responded to the query with our preference")
+	}
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&rejectedBlk.verifies))
+	assert.Equal(t, 2.0, testutil.ToFloat64(te.metrics.numDroppedRejected))
+}
+
+func TestEngineReachedAlphaCountsVotesForDescendants(t *testing.T) {
+	assert := assert.New(t)
+	_, _, _, vm, te, gBlk := setup(t)
+	te.Params.Alpha = 2
+
+	parentBlk := newCountingBlock(gBlk.ID(), 1)
+	childBlk0 := newCountingBlock(parentBlk.ID(), 2)
+	childBlk1 := newCountingBlock(parentBlk.ID(), 2)
+	childBlk1.BytesV = []byte{3}
+	siblingBlk := newCountingBlock(gBlk.ID(), 1)
+	siblingBlk.BytesV = []byte{4}
+	for _, blk := range []snowman.Block{parentBlk, childBlk0, childBlk1, siblingBlk} {
+		assert.NoError(te.Consensus.Add(blk))
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case parentBlk.ID():
+			return parentBlk, nil
+		case childBlk0.ID():
+			return childBlk0, nil
+		case childBlk1.ID():
+			return childBlk1, nil
+		case siblingBlk.ID():
+			return siblingBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	v := &voter{t: te}
+
+	// A single vote for each child is alpha votes for their parent
+	votes := ids.Bag{}
+	votes.Add(childBlk0.ID(), childBlk1.ID())
+	assert.True(v.reachedAlpha(votes))
+
+	// A single vote isn't enough
+	votes = ids.Bag{}
+	votes.Add(childBlk0.ID())
+	assert.False(v.reachedAlpha(votes))
+
+	// Votes on conflicting branches don't add up
+	votes = ids.Bag{}
+	votes.Add(childBlk0.ID(), siblingBlk.ID())
+	assert.False(v.reachedAlpha(votes))
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+// maxPrefetchedPerWorker bounds the number of verifications that may be
+// queued or cached per worker.
+const maxPrefetchedPerWorker = 16
+
+// verification is the result of verifying a block on a worker
+type verification struct {
+	blk      snowman.Block
+	parentID ids.ID
+	// closed once [err] has been set
+	done chan struct{}
+	err  error
+}
+
+// verifier verifies blocks on a pool of workers ahead of their issuance into
+// consensus. Only blocks whose parent has already been verified and added to
+// consensus may be prefetched, so every result is computed against the same
+// parent state that the engine would verify the block against.
+// Besides the workers, verifier is only accessed by the engine's goroutine.
+// A verifier without workers never prefetches.
+type verifier struct {
+	// nil if there are no workers
+	jobs chan *verification
+	wg   sync.WaitGroup
+
+	// Block ID --> Verification of the block
+	verifications map[ids.ID]*verification
+
+	metrics *metrics
+}
+
+func newVerifier(numWorkers int, metrics *metrics) *verifier {
+	v := &verifier{
+		verifications: make(map[ids.ID]*verification),
+		metrics:       metrics,
+	}
+	if numWorkers <= 0 {
+		return v
+	}
+	v.jobs = make(chan *verification, numWorkers*maxPrefetchedPerWorker)
+	v.wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go v.work()
+	}
+	return v
+}
+
+func (v *verifier) work() {
+	defer v.wg.Done()
+
+	for job := range v.jobs {
+		job.err = job.blk.Verify()
+		close(job.done)
+	}
+}
+
+// prefetch starts verifying [blk] on a worker. The parent of [blk] must have
+// been verified. If the workers are saturated, [blk] is left to be verified
+// synchronously.
+func (v *verifier) prefetch(blk snowman.Block) {
+	if v.jobs == nil {
+		return
+	}
+	blkID := blk.ID()
+	if _, ok := v.verifications[blkID]; ok {
+		return
+	}
+	if len(v.verifications) >= cap(v.jobs) {
+		return
+	}
+
+	job := &verification{
+		blk:      blk,
+		parentID: blk.Parent(),
+		done:     make(chan struct{}),
+	}
+	select {
+	case v.jobs <- job:
+		v.verifications[blkID] = job
+		v.metrics.numPrefetched.Set(float64(len(v.verifications)))
+	default:
+	}
+}
+
+// verify returns the result of verifying [blk]. If [blk] was prefetched, this
+// waits for its verification to finish rather than verifying it again.
+func (v *verifier) verify(blk snowman.Block) error {
+	blkID := blk.ID()
+	job, ok := v.verifications[blkID]
+	if !ok {
+		return blk.Verify()
+	}
+	delete(v.verifications, blkID)
+	v.metrics.numPrefetched.Set(float64(len(v.verifications)))
+
+	<-job.done
+	v.metrics.numPrefetchHits.Inc()
+	return job.err
+}
+
+// forget drops the prefetched verification of [blkID], if any. If the block is
+// still being verified, this waits for the verification to finish so that the
+// block is never verified again while a worker is still verifying it.
+func (v *verifier) forget(blkID ids.ID) {
+	job, ok := v.verifications[blkID]
+	if !ok {
+		return
+	}
+	delete(v.verifications, blkID)
+	v.metrics.numPrefetched.Set(float64(len(v.verifications)))
+
+	<-job.done
+}
+
+// rejected drops the prefetched verifications of the children of [blkID], as
+// they were verified against the state of a block that will never be
+// accepted. This waits for any of those verifications that are still running.
+func (v *verifier) rejected(blkID ids.ID) {
+	for childID, job := range v.verifications {
+		if job.parentID == blkID {
+			delete(v.verifications, childID)
+			<-job.done
+		}
+	}
+	v.metrics.numPrefetched.Set(float64(len(v.verifications)))
+}
+
+// shutdown stops the workers once the outstanding verifications are done.
+func (v *verifier) shutdown() {
+	if v.jobs == nil {
+		return
+	}
+	close(v.jobs)
+	v.wg.Wait()
+	v.jobs = nil
+	v.verifications = make(map[ids.ID]*verification)
+	v.metrics.numPrefetched.Set(0)
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+// blockingBlock blocks in Verify until [release] is closed
+type blockingBlock struct {
+	*countingBlock
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingBlock) Verify() error {
+	close(b.started)
+	<-b.release
+	return b.countingBlock.Verify()
+}
+
+// countingBlock counts the number of times it has been verified
+type countingBlock struct {
+	*snowman.TestBlock
+	verifies int32
+}
+
+func (b *countingBlock) Verify() error {
+	atomic.AddInt32(&b.verifies, 1)
+	return b.TestBlock.Verify()
+}
+
+func newCountingBlock(parentID ids.ID, height uint64) *countingBlock {
+	return &countingBlock{
+		TestBlock: &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentV: parentID,
+			HeightV: height,
+			BytesV:  []byte{byte(height)},
+		},
+	}
+}
+
+func newTestVerifier(t *testing.T, numWorkers int) *verifier {
+	m := &metrics{}
+	if err := m.Initialize("", prometheus.NewRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	return newVerifier(numWorkers, m)
+}
+
+func TestVerifierReusesPrefetchedVerification(t *testing.T) {
+	assert := assert.New(t)
+
+	v := newTestVerifier(t, 2)
+	defer v.shutdown()
+
+	blk := newCountingBlock(ids.GenerateTestID(), 1)
+	blk.VerifyV = errUnknownBlock
+
+	v.prefetch(blk)
+	v.prefetch(blk)
+	assert.Equal(errUnknownBlock, v.verify(blk))
+	assert.EqualValues(1, atomic.LoadInt32(&blk.verifies))
+	assert.Equal(1.0, testutil.ToFloat64(v.metrics.numPrefetchHits))
+	assert.Equal(0.0, testutil.ToFloat64(v.metrics.numPrefetched))
+
+	// The cached result is consumed by the first call to verify
+	assert.Equal(errUnknownBlock, v.verify(blk))
+	assert.EqualValues(2, atomic.LoadInt32(&blk.verifies))
+}
+
+func TestVerifierRejectedParentInvalidatesChildren(t *testing.T) {
+	assert := assert.New(t)
+
+	v := newTestVerifier(t, 1)
+	defer v.shutdown()
+
+	parentID := ids.GenerateTestID()
+	child := newCountingBlock(parentID, 1)
+	unrelated := newCountingBlock(ids.GenerateTestID(), 1)
+
+	v.prefetch(child)
+	v.prefetch(unrelated)
+	v.rejected(parentID)
+	assert.Len(v.verifications, 1)
+	assert.Contains(v.verifications, unrelated.ID())
+
+	// The child must be verified again against its parent's state
+	assert.NoError(v.verify(child))
+	assert.Equal(0.0, testutil.ToFloat64(v.metrics.numPrefetchHits))
+}
+
+func TestVerifierForgetWaitsForInFlightVerification(t *testing.T) {
+	assert := assert.New(t)
+
+	v := newTestVerifier(t, 1)
+	defer v.shutdown()
+
+	blk := &blockingBlock{
+		countingBlock: newCountingBlock(ids.GenerateTestID(), 1),
+		started:       make(chan struct{}),
+		release:       make(chan struct{}),
+	}
+	v.prefetch(blk)
+	<-blk.started
+
+	forgotten := make(chan struct{})
+	go func() {
+		v.forget(blk.ID())
+		close(forgotten)
+	}()
+
+	select {
+	case <-forgotten:
+		t.Fatal("forget returned while the block was still being verified")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(blk.release)
+	<-forgotten
+	assert.EqualValues(1, atomic.LoadInt32(&blk.verifies))
+	assert.Empty(v.verifications)
+}
+
+func TestVerifierWithoutWorkers(t *testing.T) {
+	assert := assert.New(t)
+
+	v := newTestVerifier(t, 0)
+	defer v.shutdown()
+
+	blk := newCountingBlock(ids.GenerateTestID(), 1)
+	v.prefetch(blk)
+	assert.Empty(v.verifications)
+	assert.EqualValues(0, atomic.LoadInt32(&blk.verifies))
+
+	assert.NoError(v.verify(blk))
+	assert.EqualValues(1, atomic.LoadInt32(&blk.verifies))
+}
+
+func TestEngineNoPrefetchDuringBootstrapping(t *testing.T) {
+	assert := assert.New(t)
+
+	te := &Transitive{}
+	te.Ctx = snow.DefaultConsensusContextTest()
+	te.verifier = newTestVerifier(t, 1)
+	defer te.verifier.shutdown()
+
+	blk := newCountingBlock(ids.GenerateTestID(), 1)
+	te.prefetch(blk)
+	assert.Empty(te.verifier.verifications)
+
+	te.Ctx.Bootstrapped()
+	te.prefetch(blk)
+	assert.Len(te.verifier.verifications, 1)
+}
@@ -47,6 +47,11 @@ func (v *voter) Update() {
 	// must be bubbled to the nearest valid block
 	for i, result := range results {
 		results[i] = v.bubbleVotes(result)
+		if v.reachedAlpha(results[i]) {
+			v.t.metrics.numPollsReachedAlpha.Inc()
+		} else {
+			v.t.metrics.numPollsMissedAlpha.Inc()
+		}
 	}
 
 	for _, result := range results {
@@ -130,3 +135,31 @@ votesLoop:
 	}
 	return bubbledVotes
 }
+
+// reachedAlpha returns true if some processing block received at least alpha
+// of the bubbled [votes]. As in RecordPoll, a vote for a block also counts for
+// each of its processing ancestors.
+func (v *voter) reachedAlpha(votes ids.Bag) bool {
+	if votes.Len() < v.t.Params.Alpha {
+		return false
+	}
+	if _, freq := votes.Mode(); freq >= v.t.Params.Alpha {
+		return true
+	}
+
+	transitiveVotes := ids.Bag{}
+	for _, vote := range votes.List() {
+		count := votes.Count(vote)
+		blkID := vote
+		for {
+			blk, err := v.t.GetBlock(blkID)
+			if err != nil || blk.Status().Decided() || !v.t.Consensus.DecidedOrProcessing(blk) {
+				break
+			}
+			transitiveVotes.AddCount(blkID, count)
+			blkID = blk.Parent()
+		}
+	}
+	_, freq := transitiveVotes.Mode()
+	return freq >= v.t.Params.Alpha
+}
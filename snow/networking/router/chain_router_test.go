@@ -17,9 +17,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/uptime"
 )
 
 func TestShutdown(t *testing.T) {
@@ -47,7 +49,7 @@ func TestShutdown(t *testing.T) {
 
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, mc, &tm, time.Hour, time.Second, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -67,6 +69,7 @@ func TestShutdown(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -118,7 +121,7 @@ func TestShutdownTimesOut(t *testing.T) {
 
 	chainRouter := ChainRouter{}
 
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.ShortEmpty,
@@ -158,6 +161,7 @@ func TestShutdownTimesOut(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -210,7 +214,7 @@ func TestRouterTimeout(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, mc, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -265,6 +269,7 @@ func TestRouterTimeout(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -317,7 +322,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 
 	assert.NoError(t, err)
@@ -339,6 +344,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -410,7 +416,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, mc, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -446,6 +452,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
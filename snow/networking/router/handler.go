@@ -17,7 +17,6 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
-	"github.com/ava-labs/avalanchego/utils/uptime"
 )
 
 var errDuplicatedContainerID = errors.New("inbound message contains duplicated container ID")
@@ -38,7 +37,7 @@ type Handler struct {
 	closed chan struct{}
 	// Receives messages from the VM
 	msgFromVMChan <-chan common.Message
-	// Tracks CPU time spent processing messages from each node
+	// Tracks CPU time spent processing messages from each node on this chain
 	cpuTracker tracker.TimeTracker
 	// Called in a goroutine when this handler/engine shuts down.
 	// May be nil.
@@ -52,11 +51,15 @@ type Handler struct {
 
 // Initialize this consensus handler
 // [engine] must be initialized before initializing this handler
+// [cpuTracker] is used to track the CPU time spent handling messages from each
+// node on this chain. It must not be shared with other chains, as their
+// messages can be handled at the same time.
 func (h *Handler) Initialize(
 	mc message.Creator,
 	engine common.Engine,
 	validators validators.Set,
 	msgFromVMChan <-chan common.Message,
+	cpuTracker tracker.TimeTracker,
 ) error {
 	h.ctx = engine.Context()
 	if err := h.metrics.Initialize("handler", h.ctx.Registerer); err != nil {
@@ -69,7 +72,7 @@ func (h *Handler) Initialize(
 	h.validators = validators
 	var lock sync.Mutex
 	h.unprocessedMsgsCond = sync.NewCond(&lock)
-	h.cpuTracker = tracker.NewCPUTracker(uptime.IntervalFactory{}, defaultCPUInterval)
+	h.cpuTracker = cpuTracker
 	var err error
 	h.unprocessedMsgs, err = newUnprocessedMsgs(h.ctx.Log, h.validators, h.cpuTracker, "handler", h.ctx.Registerer)
 	return err
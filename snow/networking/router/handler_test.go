@@ -15,7 +15,9 @@ import (
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/uptime"
 )
 
 func TestHandlerDropsTimedOutMessages(t *testing.T) {
@@ -40,13 +42,14 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 	err := vdrs.AddWeight(vdr0, 1)
 	assert.NoError(t, err)
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 	err = handler.Initialize(
 		mc,
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -95,7 +98,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 	err := vdrs.AddWeight(ids.GenerateTestShortID(), 1)
 	assert.NoError(t, err)
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler := &Handler{}
 	err = handler.Initialize(
@@ -103,6 +106,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -145,7 +149,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 	err := vdrs.AddWeight(ids.GenerateTestShortID(), 1)
 	assert.NoError(t, err)
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 	handler := &Handler{}
 	err = handler.Initialize(
@@ -153,6 +157,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -193,13 +198,14 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	err := vdrs.AddWeight(ids.GenerateTestShortID(), 1)
 	assert.NoError(t, err)
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 	err = handler.Initialize(
 		mc,
 		&engine,
 		vdrs,
 		msgFromVMChan,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
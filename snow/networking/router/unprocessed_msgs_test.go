@@ -30,7 +30,7 @@ func TestUnprocessedMsgs(t *testing.T) {
 	currentTime := time.Now()
 	u.clock.Set(currentTime)
 
-	mc, err := message.NewCreator(prometheus.NewRegistry(), true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(prometheus.NewRegistry(), true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(err)
 	mc.SetTime(currentTime)
 	msg1 := mc.InboundPut(ids.Empty,
@@ -21,15 +21,17 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/uptime"
 )
 
 func TestSenderContext(t *testing.T) {
 	context := snow.DefaultConsensusContextTest()
 	metrics := prometheus.NewRegistry()
-	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	msgCreator, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	externalSender := &ExternalSenderTest{TB: t}
 	externalSender.Default(true)
@@ -75,7 +77,7 @@ func TestTimeout(t *testing.T) {
 
 	chainRouter := router.ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, mc, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
@@ -109,6 +111,7 @@ func TestTimeout(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -154,7 +157,7 @@ func TestReliableMessages(t *testing.T) {
 
 	chainRouter := router.ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, mc, &tm, time.Hour, time.Second,
 		ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
@@ -192,6 +195,7 @@ func TestReliableMessages(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -246,7 +250,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 
 	chainRouter := router.ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/)
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace" /*parentNamespace*/, nil /*maxSizes*/)
 	assert.NoError(t, err)
 	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, mc, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
@@ -283,6 +287,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		&engine,
 		vdrs,
 		nil,
+		tracker.NewCPUTracker(uptime.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
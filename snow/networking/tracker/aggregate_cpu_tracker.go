@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/uptime"
+)
+
+var _ AggregateTimeTracker = &aggregateCPUTracker{}
+
+// Utilizer reports peers' recent usage of CPU Time
+type Utilizer interface {
+	Utilization(ids.ShortID, time.Time) float64
+}
+
+// AggregateTimeTracker tracks peers' usage of CPU Time across a set of
+// TimeTrackers, such as one per chain.
+//
+// Messages of different chains can be handled at the same time. A single
+// tracker would only count the union of the overlapping intervals, so each
+// chain tracks its own time and the utilizations of the trackers are summed.
+type AggregateTimeTracker interface {
+	Utilizer
+
+	// NewTracker returns a new TimeTracker whose utilization is included in
+	// the aggregate
+	NewTracker() TimeTracker
+}
+
+// aggregateCPUTracker implements AggregateTimeTracker
+type aggregateCPUTracker struct {
+	lock sync.Mutex
+
+	factory  uptime.Factory
+	halflife time.Duration
+	trackers []TimeTracker
+}
+
+func NewAggregateCPUTracker(factory uptime.Factory, halflife time.Duration) AggregateTimeTracker {
+	return &aggregateCPUTracker{
+		factory:  factory,
+		halflife: halflife,
+	}
+}
+
+// NewTracker returns a new CPU tracker whose utilization is included in the
+// aggregate
+func (act *aggregateCPUTracker) NewTracker() TimeTracker {
+	act.lock.Lock()
+	defer act.lock.Unlock()
+
+	tracker := NewCPUTracker(act.factory, act.halflife)
+	act.trackers = append(act.trackers, tracker)
+	return tracker
+}
+
+// Utilization returns the sum of the current EWMAs of CPU utilization for
+// [vdr] over all the trackers
+func (act *aggregateCPUTracker) Utilization(vdr ids.ShortID, currentTime time.Time) float64 {
+	act.lock.Lock()
+	defer act.lock.Unlock()
+
+	utilization := 0.
+	for _, tracker := range act.trackers {
+		utilization += tracker.Utilization(vdr, currentTime)
+	}
+	return utilization
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/uptime"
+)
+
+func TestAggregateCPUTrackerSumsConcurrentChains(t *testing.T) {
+	assert := assert.New(t)
+
+	halflife := time.Second
+	aggregate := NewAggregateCPUTracker(uptime.IntervalFactory{}, halflife)
+	chain1Tracker := aggregate.NewTracker()
+	chain2Tracker := aggregate.NewTracker()
+	vdr1 := ids.ShortID{1}
+	vdr2 := ids.ShortID{2}
+
+	// [vdr1] keeps two chains busy at the same time
+	startTime := time.Now()
+	endTime := startTime.Add(halflife)
+	chain1Tracker.UtilizeTime(vdr1, startTime, endTime)
+	chain2Tracker.UtilizeTime(vdr1, startTime, endTime)
+
+	// Each chain only sees the time spent on it
+	chainUtilization := chain1Tracker.Utilization(vdr1, endTime)
+	assert.Greater(chainUtilization, 0.)
+	assert.Equal(chainUtilization, chain2Tracker.Utilization(vdr1, endTime))
+
+	// The overlapping time is counted once per chain
+	assert.InDelta(2*chainUtilization, aggregate.Utilization(vdr1, endTime), epsilon)
+	assert.Zero(aggregate.Utilization(vdr2, endTime))
+
+	// A tracker used on its own only counts the union of the intervals
+	sharedTracker := NewCPUTracker(uptime.IntervalFactory{}, halflife)
+	sharedTracker.UtilizeTime(vdr1, startTime, endTime)
+	sharedTracker.UtilizeTime(vdr1, startTime, endTime)
+	assert.InDelta(chainUtilization, sharedTracker.Utilization(vdr1, endTime), epsilon)
+}
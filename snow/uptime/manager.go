@@ -25,6 +25,10 @@ type Tracker interface {
 	// Should only be called once
 	Shutdown(nodeIDs []ids.ShortID) error
 
+	// Sync writes the uptimes of [nodeIDs], as observed up to now, to the
+	// state without changing their connection status.
+	Sync(nodeIDs []ids.ShortID) error
+
 	Connect(nodeID ids.ShortID) error
 	IsConnected(nodeID ids.ShortID) bool
 	Disconnect(nodeID ids.ShortID) error
@@ -109,6 +113,23 @@ func (m *manager) Shutdown(nodeIDs []ids.ShortID) error {
 	return nil
 }
 
+func (m *manager) Sync(nodeIDs []ids.ShortID) error {
+	if !m.startedTracking {
+		return nil
+	}
+
+	for _, nodeID := range nodeIDs {
+		newDuration, newLastUpdated, err := m.CalculateUptime(nodeID)
+		if err != nil {
+			return err
+		}
+		if err := m.state.SetUptime(nodeID, newDuration, newLastUpdated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *manager) Connect(nodeID ids.ShortID) error {
 	m.connections[nodeID] = m.clock.Time()
 	return nil
@@ -122,6 +122,101 @@ func TestShutdownDecreasesUptime(t *testing.T) {
 	assert.Equal(currentTime, lastUpdated)
 }
 
+func TestSyncPersistsObservedDowntime(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID0 := ids.GenerateTestShortID()
+	currentTime := time.Now()
+	startTime := currentTime
+
+	s := NewTestState()
+	s.AddNode(nodeID0, startTime)
+
+	up := NewManager(s).(*manager)
+	up.clock.Set(currentTime)
+
+	err := up.StartTracking([]ids.ShortID{nodeID0})
+	assert.NoError(err)
+
+	currentTime = startTime.Add(time.Second)
+	up.clock.Set(currentTime)
+
+	err = up.Sync([]ids.ShortID{nodeID0})
+	assert.NoError(err)
+
+	// Restart without shutting down
+	up = NewManager(s).(*manager)
+	up.clock.Set(currentTime)
+
+	err = up.StartTracking([]ids.ShortID{nodeID0})
+	assert.NoError(err)
+
+	duration, lastUpdated, err := up.CalculateUptime(nodeID0)
+	assert.NoError(err)
+	assert.Equal(time.Duration(0), duration)
+	assert.Equal(currentTime, lastUpdated)
+}
+
+func TestSyncPersistsObservedUptime(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID0 := ids.GenerateTestShortID()
+	currentTime := time.Now()
+	startTime := currentTime
+
+	s := NewTestState()
+	s.AddNode(nodeID0, startTime)
+
+	up := NewManager(s).(*manager)
+	up.clock.Set(currentTime)
+
+	err := up.StartTracking([]ids.ShortID{nodeID0})
+	assert.NoError(err)
+
+	err = up.Connect(nodeID0)
+	assert.NoError(err)
+
+	currentTime = startTime.Add(time.Second)
+	up.clock.Set(currentTime)
+
+	err = up.Sync([]ids.ShortID{nodeID0})
+	assert.NoError(err)
+	assert.True(up.IsConnected(nodeID0))
+
+	// Syncing again must not double count the connected time
+	currentTime = currentTime.Add(time.Second)
+	up.clock.Set(currentTime)
+
+	err = up.Sync([]ids.ShortID{nodeID0})
+	assert.NoError(err)
+
+	duration, lastUpdated, err := s.GetUptime(nodeID0)
+	assert.NoError(err)
+	assert.Equal(2*time.Second, duration)
+	assert.Equal(currentTime, lastUpdated)
+}
+
+func TestSyncBeforeStartTracking(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID0 := ids.GenerateTestShortID()
+	startTime := time.Now()
+
+	s := NewTestState()
+	s.AddNode(nodeID0, startTime)
+
+	up := NewManager(s).(*manager)
+	up.clock.Set(startTime.Add(time.Second))
+
+	err := up.Sync([]ids.ShortID{nodeID0})
+	assert.NoError(err)
+
+	duration, lastUpdated, err := s.GetUptime(nodeID0)
+	assert.NoError(err)
+	assert.Equal(time.Duration(0), duration)
+	assert.Equal(startTime, lastUpdated)
+}
+
 func TestShutdownIncreasesUptime(t *testing.T) {
 	assert := assert.New(t)
 
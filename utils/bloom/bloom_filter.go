@@ -5,6 +5,7 @@ package bloom
 
 import (
 	"errors"
+	"math"
 	"sync"
 
 	"github.com/spaolacci/murmur3"
@@ -30,6 +31,21 @@ func New(maxN uint64, p float64, maxBytes uint64) (Filter, error) {
 	return newSteakKnifeFilter(maxN, p)
 }
 
+// Bytes returns the number of bytes allocated by a filter created with [maxN]
+// and [p].
+func Bytes(maxN uint64, p float64) uint64 {
+	return bytesSteakKnifeFilter(maxN, p)
+}
+
+// EffectiveCollisionProb returns the false positive probability of a filter
+// created with [maxN] and [p] once [maxN] elements have been added to it. The
+// size of the filter is rounded, so this can differ slightly from [p].
+func EffectiveCollisionProb(maxN uint64, p float64) float64 {
+	m := float64(streakKnife.OptimalM(maxN, p))
+	k := float64(streakKnife.OptimalK(uint64(m), maxN))
+	return math.Pow(1-math.Exp(-k*float64(maxN)/m), k)
+}
+
 type steakKnifeFilter struct {
 	lock   sync.RWMutex
 	filter *streakKnife.Filter
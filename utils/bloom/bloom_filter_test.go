@@ -29,3 +29,17 @@ func TestNew(t *testing.T) {
 	checked = f.Check([]byte("bye"))
 	assert.False(checked, "shouldn't have contained the key")
 }
+
+func TestEffectiveCollisionProb(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, p := range []float64{0.5, 0.1, 0.01, 0.0001} {
+		effectiveP := EffectiveCollisionProb(10000, p)
+		// Rounding up the number of hash functions can noticeably increase
+		// the false positive probability
+		assert.InEpsilon(p, effectiveP, 0.5)
+	}
+
+	_, err := New(10000, 0.1, Bytes(10000, 0.1)-1)
+	assert.Error(err)
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+// Type is the algorithm a payload was compressed with
+type Type byte
+
+const (
+	TypeNone Type = iota
+	TypeGzip
+	TypeZstd
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeNone:
+		return "none"
+	case TypeGzip:
+		return "gzip"
+	case TypeZstd:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
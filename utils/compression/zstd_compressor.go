@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdCompressor implements Compressor
+type zstdCompressor struct {
+	maxSize int64
+
+	// Both are safe to be used by multiple goroutines concurrently
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// Compress [msg] and returns the compressed bytes.
+func (z *zstdCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > z.maxSize {
+		return nil, fmt.Errorf("msg length (%d) > maximum msg length (%d)", len(msg), z.maxSize)
+	}
+	return z.encoder.EncodeAll(msg, nil), nil
+}
+
+// Decompress decompresses [msg]. The decompressed payload is never allowed to
+// grow past the maximum size, regardless of the size claimed by [msg].
+func (z *zstdCompressor) Decompress(msg []byte) ([]byte, error) {
+	decompressed, err := z.decoder.DecodeAll(msg, nil)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > z.maxSize {
+		return nil, fmt.Errorf("msg length > maximum msg length (%d)", z.maxSize)
+	}
+	return decompressed, nil
+}
+
+// NewZstdCompressor returns a new zstd Compressor that compresses and
+// decompresses messages of at most [maxSize] bytes.
+func NewZstdCompressor(maxSize int64) (Compressor, error) {
+	// The decoder refuses windows larger than [maxSize], so the encoder's
+	// window must not be larger than [maxSize] either.
+	windowSize := zstd.MinWindowSize
+	for int64(windowSize)*2 <= maxSize && windowSize*2 <= zstd.MaxWindowSize {
+		windowSize *= 2
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithWindowSize(windowSize))
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := zstd.NewReader(
+		nil,
+		zstd.WithDecoderMaxMemory(uint64(maxSize)),
+		zstd.WithDecoderLowmem(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &zstdCompressor{
+		maxSize: maxSize,
+		encoder: encoder,
+		decoder: decoder,
+	}, nil
+}
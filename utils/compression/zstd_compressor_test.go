@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestZstdCompressDecompress(t *testing.T) {
+	data := make([]byte, 4096)
+	for i := 0; i < len(data); i++ {
+		data[i] = byte(rand.Intn(256)) // #nosec G404
+	}
+	data2 := make([]byte, 4096)
+
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	assert.NoError(t, err)
+
+	dataCompressed, err := compressor.Compress(data)
+	assert.NoError(t, err)
+
+	data2Compressed, err := compressor.Compress(data2)
+	assert.NoError(t, err)
+	assert.Less(t, len(data2Compressed), len(data2))
+
+	dataDecompressed, err := compressor.Decompress(dataCompressed)
+	assert.NoError(t, err)
+	assert.EqualValues(t, data, dataDecompressed)
+
+	data2Decompressed, err := compressor.Decompress(data2Compressed)
+	assert.NoError(t, err)
+	assert.EqualValues(t, data2, data2Decompressed)
+
+	nonZstdData := []byte{1, 2, 3}
+	_, err = compressor.Decompress(nonZstdData)
+	assert.Error(t, err)
+}
+
+func TestZstdCompressDecompressMaxSize(t *testing.T) {
+	data := make([]byte, 2*units.MiB)
+	for i := 0; i < len(data); i += 3 {
+		data[i] = byte(rand.Intn(256)) // #nosec G404
+	}
+
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	assert.NoError(t, err)
+	dataCompressed, err := compressor.Compress(data)
+	assert.NoError(t, err)
+	dataDecompressed, err := compressor.Decompress(dataCompressed)
+	assert.NoError(t, err)
+	assert.EqualValues(t, data, dataDecompressed)
+}
+
+func TestZstdSizeLimiting(t *testing.T) {
+	data := make([]byte, 3*units.MiB)
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	assert.NoError(t, err)
+	_, err = compressor.Compress(data) // should be too large
+	assert.Error(t, err)
+
+	compressor2, err := NewZstdCompressor(4 * units.MiB)
+	assert.NoError(t, err)
+	dataCompressed, err := compressor2.Compress(data)
+	assert.NoError(t, err)
+
+	_, err = compressor.Decompress(dataCompressed) // should be too large
+	assert.Error(t, err)
+}
+
+// Ensure that a payload that doesn't declare its decompressed size can't
+// decompress past the maximum size.
+func TestZstdSizeLimitingStreamedPayload(t *testing.T) {
+	// A streamed payload doesn't declare its decompressed size in its frame
+	// header, so it can only be rejected while it is being decompressed. Its
+	// window is small enough to be accepted by the decompressor.
+	bomb := bytes.Buffer{}
+	encoder, err := zstd.NewWriter(&bomb, zstd.WithWindowSize(units.MiB))
+	assert.NoError(t, err)
+	_, err = encoder.Write(make([]byte, 3*units.MiB))
+	assert.NoError(t, err)
+	assert.NoError(t, encoder.Close())
+
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	assert.NoError(t, err)
+	_, err = compressor.Decompress(bomb.Bytes())
+	assert.Error(t, err)
+}
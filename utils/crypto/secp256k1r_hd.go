@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v3"
+)
+
+const (
+	// HardenedKeyStart is the index of the first hardened child key, as
+	// defined in BIP32
+	HardenedKeyStart uint32 = 1 << 31
+
+	// AvaxCoinType is the BIP44 coin type registered for AVAX
+	AvaxCoinType uint32 = 9000
+
+	minSeedLen = 16
+	maxSeedLen = 64
+)
+
+var (
+	// masterKeyHMACKey is the HMAC key used to derive the master key from a
+	// seed, as defined in BIP32
+	masterKeyHMACKey = []byte("Bitcoin seed")
+
+	errInvalidSeedLen = errors.New("seed must be between 16 and 64 bytes")
+	errUnusableKey    = errors.New("derived key is unusable")
+)
+
+// ExtendedKeySECP256K1R is a BIP32 extended private key
+type ExtendedKeySECP256K1R struct {
+	key       secp256k1.ModNScalar
+	chainCode []byte
+}
+
+// NewMasterKeySECP256K1R returns the BIP32 master key of [seed]
+func NewMasterKeySECP256K1R(seed []byte) (*ExtendedKeySECP256K1R, error) {
+	if len(seed) < minSeedLen || len(seed) > maxSeedLen {
+		return nil, errInvalidSeedLen
+	}
+
+	mac := hmac.New(sha512.New, masterKeyHMACKey)
+	_, _ = mac.Write(seed)
+	return newExtendedKey(mac.Sum(nil), nil)
+}
+
+// Child returns the child key at [index]. Indices at or above
+// HardenedKeyStart are hardened.
+func (k *ExtendedKeySECP256K1R) Child(index uint32) (*ExtendedKeySECP256K1R, error) {
+	data := make([]byte, 0, SECP256K1RPKLen+4)
+	if index >= HardenedKeyStart {
+		keyBytes := k.key.Bytes()
+		data = append(data, 0)
+		data = append(data, keyBytes[:]...)
+	} else {
+		data = append(data, secp256k1.NewPrivateKey(&k.key).PubKey().SerializeCompressed()...)
+	}
+	data = data[:len(data)+4]
+	binary.BigEndian.PutUint32(data[len(data)-4:], index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	_, _ = mac.Write(data)
+	return newExtendedKey(mac.Sum(nil), &k.key)
+}
+
+// Derive returns the key at [path], relative to this key
+func (k *ExtendedKeySECP256K1R) Derive(path ...uint32) (*ExtendedKeySECP256K1R, error) {
+	key := k
+	for _, index := range path {
+		child, err := key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+	return key, nil
+}
+
+// PrivateKey returns the private key of this extended key
+func (k *ExtendedKeySECP256K1R) PrivateKey() *PrivateKeySECP256K1R {
+	return &PrivateKeySECP256K1R{sk: secp256k1.NewPrivateKey(&k.key)}
+}
+
+// newExtendedKey returns the key described by the HMAC-SHA512 output [i],
+// tweaking [parent] by its left half if [parent] is non-nil.
+func newExtendedKey(i []byte, parent *secp256k1.ModNScalar) (*ExtendedKeySECP256K1R, error) {
+	key := secp256k1.ModNScalar{}
+	if overflow := key.SetByteSlice(i[:32]); overflow {
+		return nil, errUnusableKey
+	}
+	if parent != nil {
+		key.Add(parent)
+	}
+	if key.IsZero() {
+		return nil, errUnusableKey
+	}
+	return &ExtendedKeySECP256K1R{
+		key:       key,
+		chainCode: i[32:],
+	}, nil
+}
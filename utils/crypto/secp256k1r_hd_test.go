@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vector 1 of BIP32
+func TestExtendedKeySECP256K1RDerive(t *testing.T) {
+	assert := assert.New(t)
+
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	assert.NoError(err)
+	master, err := NewMasterKeySECP256K1R(seed)
+	assert.NoError(err)
+
+	tests := []struct {
+		path []uint32
+		key  string
+	}{
+		{
+			path: nil,
+			key:  "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		},
+		{
+			path: []uint32{HardenedKeyStart},
+			key:  "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		},
+		{
+			path: []uint32{HardenedKeyStart, 1},
+			key:  "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		},
+		{
+			path: []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2},
+			key:  "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		},
+		{
+			path: []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2},
+			key:  "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4",
+		},
+		{
+			path: []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2, 1000000000},
+			key:  "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+		},
+	}
+	for _, test := range tests {
+		key, err := master.Derive(test.path...)
+		assert.NoError(err)
+		assert.Equal(test.key, hex.EncodeToString(key.PrivateKey().Bytes()), "path %v", test.path)
+	}
+}
+
+func TestNewMasterKeySECP256K1RInvalidSeed(t *testing.T) {
+	_, err := NewMasterKeySECP256K1R(make([]byte, minSeedLen-1))
+	assert.ErrorIs(t, err, errInvalidSeedLen)
+	_, err = NewMasterKeySECP256K1R(make([]byte, maxSeedLen+1))
+	assert.ErrorIs(t, err, errInvalidSeedLen)
+}
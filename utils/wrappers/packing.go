@@ -31,7 +31,10 @@ const (
 )
 
 var (
-	errBadLength      = errors.New("packer has insufficient length for input")
+	// ErrInsufficientLength is returned when unpacking past the end of the
+	// bytes, or when packing would grow the bytes past their maximum size
+	ErrInsufficientLength = errors.New("packer has insufficient length for input")
+
 	errNegativeOffset = errors.New("negative offset")
 	errInvalidInput   = errors.New("input does not match expected format")
 	errBadType        = errors.New("wrong type passed")
@@ -59,7 +62,7 @@ func (p *Packer) CheckSpace(bytes int) {
 	case bytes < 0:
 		p.Add(errInvalidInput)
 	case len(p.Bytes)-p.Offset < bytes:
-		p.Add(errBadLength)
+		p.Add(ErrInsufficientLength)
 	}
 }
 
@@ -73,7 +76,7 @@ func (p *Packer) Expand(bytes int) {
 	case neededSize <= len(p.Bytes): // Byte slice has sufficient length already
 		return
 	case neededSize > p.MaxSize: // Lengthening the byte slice would cause it to grow too large
-		p.Err = errBadLength
+		p.Err = ErrInsufficientLength
 		return
 	case neededSize <= cap(p.Bytes): // Byte slice has sufficient capacity to lengthen it without mem alloc
 		p.Bytes = p.Bytes[:neededSize]
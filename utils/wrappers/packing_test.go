@@ -41,13 +41,13 @@ func TestPackerCheckSpace(t *testing.T) {
 	p = Packer{Bytes: []byte{0x01}, Offset: 1}
 	p.CheckSpace(1)
 	if !p.Errored() {
-		t.Fatal("Expected errBadLength")
+		t.Fatal("Expected ErrInsufficientLength")
 	}
 
 	p = Packer{Bytes: []byte{0x01}, Offset: 2}
 	p.CheckSpace(0)
 	if !p.Errored() {
-		t.Fatal("Expected errBadLength, due to out of bounds offset")
+		t.Fatal("Expected ErrInsufficientLength, due to out of bounds offset")
 	}
 }
 
@@ -629,6 +629,20 @@ func TestPackIPCert(t *testing.T) {
 	assert.Equal(t, ipCert.Signature, resolvedUnpackedIPCert.Signature)
 }
 
+func TestPackIPv6(t *testing.T) {
+	ip := utils.IPDesc{IP: net.ParseIP("2001:db8::1"), Port: 9651}
+
+	p := Packer{MaxSize: IPLen}
+	p.PackIP(ip)
+	assert.NoError(t, p.Err)
+	assert.Len(t, p.Bytes, IPLen)
+
+	p.Offset = 0
+	unpackedIP := p.UnpackIP()
+	assert.NoError(t, p.Err)
+	assert.True(t, ip.Equal(unpackedIP))
+}
+
 func TestPackIPCertList(t *testing.T) {
 	cert, err := staking.NewTLSCert()
 	assert.NoError(t, err)
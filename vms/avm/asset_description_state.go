@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	defaultAssetDescriptionCacheSize = 2048
+)
+
+var _ AssetDescriptionState = &assetDescriptionState{}
+
+// AssetDescription is the human readable description of an asset, as given in
+// the CreateAssetTx that created it.
+type AssetDescription struct {
+	Name         string `serialize:"true"`
+	Symbol       string `serialize:"true"`
+	Denomination byte   `serialize:"true"`
+}
+
+// AssetDescriptionState is a thin wrapper around a database to provide
+// caching, serialization, and de-serialization of asset descriptions, indexed
+// by both asset ID and symbol.
+type AssetDescriptionState interface {
+	// GetAssetDescription attempts to load the description of an asset from
+	// storage.
+	GetAssetDescription(assetID ids.ID) (*AssetDescription, error)
+
+	// PutAssetDescription saves the description of an asset to storage.
+	PutAssetDescription(assetID ids.ID, desc *AssetDescription) error
+
+	// AssetIDsWithSymbol returns the IDs of the assets with the provided
+	// symbol.
+	AssetIDsWithSymbol(symbol string) ([]ids.ID, error)
+}
+
+type assetDescriptionState struct {
+	codec codec.Manager
+
+	// Caches AssetID -> *AssetDescription. If the *AssetDescription is nil,
+	// that means the description is not in storage.
+	descCache cache.Cacher
+	descDB    database.Database
+	// Contains symbol + AssetID -> nil
+	symbolDB database.Database
+}
+
+func NewAssetDescriptionState(descDB, symbolDB database.Database, codec codec.Manager) AssetDescriptionState {
+	return &assetDescriptionState{
+		codec: codec,
+
+		descCache: &cache.LRU{
+			Size: defaultAssetDescriptionCacheSize,
+		},
+		descDB:   descDB,
+		symbolDB: symbolDB,
+	}
+}
+
+// NewMeteredAssetDescriptionState returns an AssetDescriptionState that caches
+// up to [cacheSize] descriptions and reports its cache hits and misses to
+// [metrics].
+func NewMeteredAssetDescriptionState(
+	descDB,
+	symbolDB database.Database,
+	codec codec.Manager,
+	cacheSize int,
+	metrics prometheus.Registerer,
+) (AssetDescriptionState, error) {
+	cache, err := metercacher.New(
+		"asset_description_cache",
+		metrics,
+		&cache.LRU{Size: cacheSize},
+	)
+	return &assetDescriptionState{
+		codec: codec,
+
+		descCache: cache,
+		descDB:    descDB,
+		symbolDB:  symbolDB,
+	}, err
+}
+
+func (s *assetDescriptionState) GetAssetDescription(assetID ids.ID) (*AssetDescription, error) {
+	if descIntf, found := s.descCache.Get(assetID); found {
+		if descIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return descIntf.(*AssetDescription), nil
+	}
+
+	descBytes, err := s.descDB.Get(assetID[:])
+	if err == database.ErrNotFound {
+		s.descCache.Put(assetID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	desc := &AssetDescription{}
+	if _, err := s.codec.Unmarshal(descBytes, desc); err != nil {
+		return nil, err
+	}
+	s.descCache.Put(assetID, desc)
+	return desc, nil
+}
+
+func (s *assetDescriptionState) PutAssetDescription(assetID ids.ID, desc *AssetDescription) error {
+	descBytes, err := s.codec.Marshal(codecVersion, desc)
+	if err != nil {
+		return err
+	}
+
+	s.descCache.Put(assetID, desc)
+	if err := s.descDB.Put(assetID[:], descBytes); err != nil {
+		return err
+	}
+	return prefixdb.New([]byte(desc.Symbol), s.symbolDB).Put(assetID[:], nil)
+}
+
+func (s *assetDescriptionState) AssetIDsWithSymbol(symbol string) ([]ids.ID, error) {
+	iter := prefixdb.New([]byte(symbol), s.symbolDB).NewIterator()
+	defer iter.Release()
+
+	assetIDs := []ids.ID(nil)
+	for iter.Next() {
+		assetID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		assetIDs = append(assetIDs, assetID)
+	}
+	return assetIDs, iter.Error()
+}
+
+// assetDescription returns the description of the asset created by [utx], if
+// [utx] creates an asset.
+func assetDescription(utx UnsignedTx) (*AssetDescription, bool) {
+	createAssetTx, ok := utx.(*CreateAssetTx)
+	if !ok {
+		return nil, false
+	}
+	return &AssetDescription{
+		Name:         createAssetTx.Name,
+		Symbol:       createAssetTx.Symbol,
+		Denomination: createAssetTx.Denomination,
+	}, true
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/index"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// assetTxRoles returns the roles that the tx with ID [txID] plays for each of
+// the assets it involves.
+func assetTxRoles(txID ids.ID, utx UnsignedTx) map[ids.ID]index.AssetTxRole {
+	roles := make(map[ids.ID]index.AssetTxRole)
+	var baseTx *BaseTx
+	switch utx := utx.(type) {
+	case *BaseTx:
+		baseTx = utx
+	case *CreateAssetTx:
+		baseTx = &utx.BaseTx
+		roles[txID] |= index.AssetTxCreated
+	case *OperationTx:
+		baseTx = &utx.BaseTx
+		for _, op := range utx.Ops {
+			role := index.AssetTxTransferred
+			switch op.Op.(type) {
+			case *secp256k1fx.MintOperation, *nftfx.MintOperation, *propertyfx.MintOperation:
+				role = index.AssetTxMinted
+			}
+			roles[op.AssetID()] |= role
+		}
+	case *ImportTx:
+		baseTx = &utx.BaseTx
+		for _, in := range utx.ImportedIns {
+			roles[in.AssetID()] |= index.AssetTxTransferred
+		}
+	case *ExportTx:
+		baseTx = &utx.BaseTx
+		for _, out := range utx.ExportedOuts {
+			roles[out.AssetID()] |= index.AssetTxTransferred
+		}
+	default:
+		return roles
+	}
+
+	for _, in := range baseTx.Ins {
+		roles[in.AssetID()] |= index.AssetTxTransferred
+	}
+	for _, out := range baseTx.Outs {
+		roles[out.AssetID()] |= index.AssetTxTransferred
+	}
+	return roles
+}
@@ -35,6 +35,11 @@ func (t *BaseTx) Init(vm *VM) error {
 			return err
 		}
 		in.FxID = fx.ID
+
+		in.Addresses, err = vm.utxoAddresses(&in.UTXOID)
+		if err != nil {
+			return err
+		}
 	}
 
 	for _, out := range t.Outs {
@@ -56,11 +61,12 @@ func (t *BaseTx) SyntacticVerify(
 	txFee uint64,
 	_ uint64,
 	_ int,
+	maxMemoSize int,
 ) error {
 	if t == nil {
 		return errNilTx
 	}
-	if err := t.MetadataVerify(ctx); err != nil {
+	if err := t.MetadataVerifyWithMaxMemoSize(ctx, maxMemoSize); err != nil {
 		return err
 	}
 
@@ -5,6 +5,7 @@ package avm
 
 import (
 	"bytes"
+	"errors"
 	"math"
 	"testing"
 
@@ -223,7 +224,7 @@ func TestBaseTxSyntacticVerify(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err != nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -267,8 +268,13 @@ func TestBaseTxSyntacticVerifyMemoTooLarge(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
-		t.Fatal("should have failed because memo is too large")
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); !errors.Is(err, avax.ErrMemoTooLarge) {
+		t.Fatalf("should have failed because memo is too large but got %v", err)
+	}
+
+	// A larger maximum allows the memo
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize+1); err != nil {
+		t.Fatal(err)
 	}
 }
 
@@ -277,7 +283,7 @@ func TestBaseTxSyntacticVerifyNil(t *testing.T) {
 	_, c := setupCodec()
 
 	tx := (*BaseTx)(nil)
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Nil BaseTx should have errored")
 	}
 }
@@ -320,7 +326,7 @@ func TestBaseTxSyntacticVerifyWrongNetworkID(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Wrong networkID should have errored")
 	}
 }
@@ -363,7 +369,7 @@ func TestBaseTxSyntacticVerifyWrongChainID(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Wrong chain ID should have errored")
 	}
 }
@@ -397,7 +403,7 @@ func TestBaseTxSyntacticVerifyInvalidOutput(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Invalid output should have errored")
 	}
 }
@@ -454,7 +460,7 @@ func TestBaseTxSyntacticVerifyUnsortedOutputs(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Unsorted outputs should have errored")
 	}
 }
@@ -480,7 +486,7 @@ func TestBaseTxSyntacticVerifyInvalidInput(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Invalid input should have errored")
 	}
 }
@@ -543,7 +549,7 @@ func TestBaseTxSyntacticVerifyInputOverflow(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Input overflow should have errored")
 	}
 }
@@ -598,7 +604,7 @@ func TestBaseTxSyntacticVerifyOutputOverflow(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Output overflow should have errored")
 	}
 }
@@ -641,7 +647,7 @@ func TestBaseTxSyntacticVerifyInsufficientFunds(t *testing.T) {
 	}}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Insufficient funds should have errored")
 	}
 }
@@ -683,7 +689,7 @@ func TestBaseTxSyntacticVerifyUninitialized(t *testing.T) {
 		}},
 	}}
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 0, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Uninitialized tx should have errored")
 	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
@@ -23,6 +24,9 @@ var _ Client = &client{}
 // Client for interacting with an AVM (X-Chain) instance
 type Client interface {
 	WalletClient
+	// IssueTxs issues each of [txs], in order, and returns the result of
+	// issuing each of them
+	IssueTxs(txs [][]byte) ([]IssueTxResult, error)
 	// GetTxStatus returns the status of [txID]
 	GetTxStatus(txID ids.ID) (choices.Status, error)
 	// ConfirmTx attempts to confirm [txID] by checking its status [numChecks] times
@@ -49,6 +53,14 @@ type Client interface {
 		startAddress,
 		startUTXOID string,
 	) ([][]byte, api.Index, error)
+	// GetAddressTxs returns up to [pageSize] IDs of the txs that changed the
+	// balance of [assetID] held by [addr], starting from [cursor], and the
+	// cursor to continue from
+	GetAddressTxs(addr string, assetID string, cursor uint64, pageSize uint64) ([]ids.ID, uint64, error)
+	// GetAssetTxs returns up to [pageSize] txs that played any of [roles] for
+	// [assetID], starting from [cursor], and the cursor to continue from. If
+	// [roles] is empty, txs are returned regardless of their role.
+	GetAssetTxs(assetID string, roles []string, cursor uint64, pageSize uint64) ([]index.AssetTx, uint64, error)
 	// GetAssetDescription returns a description of [assetID]
 	GetAssetDescription(assetID string) (*GetAssetDescriptionReply, error)
 	// GetBalance returns the balance of [assetID] held by [addr].
@@ -57,6 +69,10 @@ type Client interface {
 	// GetAllBalances returns all asset balances for [addr]
 	// CreateAsset creates a new asset and returns its assetID
 	GetAllBalances(string, bool) (*GetAllBalancesReply, error)
+	// GetAllBalancesPage returns at most [limit] asset balances held by [addrs],
+	// starting from [startAssetID]. If [assetIDs] is non-empty, only the
+	// balances of those assets are returned.
+	GetAllBalancesPage(addrs []string, includePartial bool, assetIDs []string, startAssetID string, limit uint32) (*GetAllBalancesReply, error)
 	CreateAsset(
 		user api.UserPass,
 		from []string,
@@ -98,6 +114,11 @@ type Client interface {
 	) (ids.ID, error)
 	// CreateAddress creates a new address controlled by [user]
 	CreateAddress(user api.UserPass) (string, error)
+	// CreateDerivedAddress creates a new address controlled by [user] that is
+	// derived from [user]'s HD seed
+	CreateDerivedAddress(user api.UserPass) (string, error)
+	// ListDerivedAddresses returns the addresses derived from [user]'s HD seed
+	ListDerivedAddresses(user api.UserPass) ([]DerivedAddress, error)
 	// ListAddresses returns all addresses on this chain controlled by [user]
 	ListAddresses(user api.UserPass) ([]string, error)
 	// ExportKey returns the private key corresponding to [addr] controlled by [user]
@@ -113,6 +134,20 @@ type Client interface {
 		assetID,
 		to string,
 	) (ids.ID, error)
+	// FreezeAsset freezes transfers of the managed asset [assetID]
+	FreezeAsset(
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		assetID string,
+	) (ids.ID, error)
+	// UnfreezeAsset unfreezes transfers of the managed asset [assetID]
+	UnfreezeAsset(
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		assetID string,
+	) (ids.ID, error)
 	// SendNFT sends an NFT and returns the ID of the newly created transaction
 	SendNFT(
 		user api.UserPass,
@@ -131,6 +166,34 @@ type Client interface {
 		payload []byte,
 		to string,
 	) (ids.ID, error)
+	// MintNFTs issues as many transactions as needed to mint [payloads[i]] to
+	// [tos[i]], and returns the IDs of the issued transactions along with the
+	// number of NFTs they mint
+	MintNFTs(
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		assetID string,
+		payloads [][]byte,
+		tos []string,
+	) ([]ids.ID, uint32, error)
+	// MintProperty mints a property of [assetID] to [to] and returns the ID of
+	// the newly created transaction
+	MintProperty(
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		assetID string,
+		to string,
+	) (ids.ID, error)
+	// BurnProperty burns every property of [assetID] owned by [user] and
+	// returns the ID of the newly created transaction
+	BurnProperty(
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		assetID string,
+	) (ids.ID, error)
 	// Import sends an import transaction to import funds from [sourceChain] and
 	// returns the ID of the newly created transaction
 	Import(user api.UserPass, to, sourceChain string) (ids.ID, error) // Export sends an asset from this chain to the P/C-Chain.
@@ -171,6 +234,23 @@ func (c *client) IssueTx(txBytes []byte) (ids.ID, error) {
 	return res.TxID, err
 }
 
+func (c *client) IssueTxs(txs [][]byte) ([]IssueTxResult, error) {
+	txStrs := make([]string, len(txs))
+	for i, txBytes := range txs {
+		txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+		if err != nil {
+			return nil, err
+		}
+		txStrs[i] = txStr
+	}
+	res := &IssueTxsReply{}
+	err := c.requester.SendRequest("issueTxs", &IssueTxsArgs{
+		Txs:      txStrs,
+		Encoding: formatting.Hex,
+	}, res)
+	return res.Results, err
+}
+
 func (c *client) GetTxStatus(txID ids.ID) (choices.Status, error) {
 	res := &GetTxStatusReply{}
 	err := c.requester.SendRequest("getTxStatus", &api.JSONTxID{
@@ -243,6 +323,28 @@ func (c *client) GetAtomicUTXOs(addrs []string, sourceChain string, limit uint32
 	return utxos, res.EndIndex, nil
 }
 
+func (c *client) GetAddressTxs(addr string, assetID string, cursor uint64, pageSize uint64) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest("getAddressTxs", &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr},
+		AssetID:     assetID,
+		Cursor:      cjson.Uint64(cursor),
+		PageSize:    cjson.Uint64(pageSize),
+	}, res)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
+func (c *client) GetAssetTxs(assetID string, roles []string, cursor uint64, pageSize uint64) ([]index.AssetTx, uint64, error) {
+	res := &GetAssetTxsReply{}
+	err := c.requester.SendRequest("getAssetTxs", &GetAssetTxsArgs{
+		AssetID:  assetID,
+		Roles:    roles,
+		Cursor:   cjson.Uint64(cursor),
+		PageSize: cjson.Uint64(pageSize),
+	}, res)
+	return res.Txs, uint64(res.Cursor), err
+}
+
 func (c *client) GetAssetDescription(assetID string) (*GetAssetDescriptionReply, error) {
 	res := &GetAssetDescriptionReply{}
 	err := c.requester.SendRequest("getAssetDescription", &GetAssetDescriptionArgs{
@@ -270,6 +372,24 @@ func (c *client) GetAllBalances(addr string, includePartial bool) (*GetAllBalanc
 	return res, err
 }
 
+func (c *client) GetAllBalancesPage(
+	addrs []string,
+	includePartial bool,
+	assetIDs []string,
+	startAssetID string,
+	limit uint32,
+) (*GetAllBalancesReply, error) {
+	res := &GetAllBalancesReply{}
+	err := c.requester.SendRequest("getAllBalances", &GetAllBalancesArgs{
+		Addresses:      addrs,
+		IncludePartial: includePartial,
+		AssetIDs:       assetIDs,
+		Limit:          cjson.Uint32(limit),
+		StartAssetID:   startAssetID,
+	}, res)
+	return res, err
+}
+
 func (c *client) CreateAsset(
 	user api.UserPass,
 	from []string,
@@ -372,6 +492,21 @@ func (c *client) CreateAddress(user api.UserPass) (string, error) {
 	return res.Address, err
 }
 
+func (c *client) CreateDerivedAddress(user api.UserPass) (string, error) {
+	res := &api.JSONAddress{}
+	err := c.requester.SendRequest("createAddress", &CreateAddressArgs{
+		UserPass: user,
+		Derive:   true,
+	}, res)
+	return res.Address, err
+}
+
+func (c *client) ListDerivedAddresses(user api.UserPass) ([]DerivedAddress, error) {
+	res := &ListDerivedAddressesReply{}
+	err := c.requester.SendRequest("listDerivedAddresses", &user, res)
+	return res.Addresses, err
+}
+
 func (c *client) ListAddresses(user api.UserPass) ([]string, error) {
 	res := &api.JSONAddresses{}
 	err := c.requester.SendRequest("listAddresses", &user, res)
@@ -464,6 +599,42 @@ func (c *client) Mint(
 	return res.TxID, err
 }
 
+func (c *client) FreezeAsset(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("freezeAsset", &ManagedAssetArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID: assetID,
+	}, res)
+	return res.TxID, err
+}
+
+func (c *client) UnfreezeAsset(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("unfreezeAsset", &ManagedAssetArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID: assetID,
+	}, res)
+	return res.TxID, err
+}
+
 func (c *client) SendNFT(
 	user api.UserPass,
 	from []string,
@@ -513,6 +684,80 @@ func (c *client) MintNFT(
 	return res.TxID, err
 }
 
+func (c *client) MintNFTs(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	payloads [][]byte,
+	tos []string,
+) ([]ids.ID, uint32, error) {
+	if len(payloads) != len(tos) {
+		return nil, 0, fmt.Errorf("got %d payloads but %d recipients", len(payloads), len(tos))
+	}
+	mints := make([]NFTMint, len(payloads))
+	for i, payload := range payloads {
+		payloadStr, err := formatting.EncodeWithChecksum(formatting.Hex, payload)
+		if err != nil {
+			return nil, 0, err
+		}
+		mints[i] = NFTMint{
+			Payload: payloadStr,
+			To:      tos[i],
+		}
+	}
+	res := &MintNFTsReply{}
+	err := c.requester.SendRequest("mintNFTs", &MintNFTsArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID:  assetID,
+		Mints:    mints,
+		Encoding: formatting.Hex,
+	}, res)
+	return res.TxIDs, uint32(res.NumMinted), err
+}
+
+func (c *client) MintProperty(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+	to string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("mintProperty", &MintPropertyArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID: assetID,
+		To:      to,
+	}, res)
+	return res.TxID, err
+}
+
+func (c *client) BurnProperty(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	assetID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("burnProperty", &BurnPropertyArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		AssetID: assetID,
+	}, res)
+	return res.TxID, err
+}
+
 func (c *client) Import(user api.UserPass, to, sourceChain string) (ids.ID, error) {
 	res := &api.JSONTxID{}
 	err := c.requester.SendRequest("import", &ImportArgs{
@@ -100,6 +100,24 @@ func newCustomCodecs(
 			return nil, nil, err
 		}
 	}
+
+	// The managed asset types are registered after every fx has been
+	// initialized so that the type IDs of previously serialized txs are
+	// preserved.
+	for i, fx := range fxs {
+		secpFx, ok := fx.(*secp256k1fx.Fx)
+		if !ok {
+			continue
+		}
+		vm.codecRegistry = &codecRegistry{
+			codecs:      []codec.Registry{gc, c},
+			index:       i,
+			typeToIndex: vm.typeToFxIndex,
+		}
+		if err := secpFx.RegisterManagedAssetTypes(); err != nil {
+			return nil, nil, err
+		}
+	}
 	return gcm, cm, nil
 }
 
@@ -13,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 const (
@@ -34,6 +36,7 @@ var (
 	errIllegalSymbolCharacter       = errors.New("asset's symbol must be all upper case letters")
 	errUnexpectedWhitespace         = errors.New("unexpected whitespace provided")
 	errDenominationTooLarge         = errors.New("denomination is too large")
+	errMultipleAssetStatuses        = errors.New("asset can't have more than one managed asset status")
 
 	_ UnsignedTx = &CreateAssetTx{}
 )
@@ -91,6 +94,7 @@ func (t *CreateAssetTx) SyntacticVerify(
 	_ uint64,
 	txFee uint64,
 	numFxs int,
+	maxMemoSize int,
 ) error {
 	switch {
 	case t == nil:
@@ -122,14 +126,23 @@ func (t *CreateAssetTx) SyntacticVerify(
 		}
 	}
 
-	if err := t.BaseTx.SyntacticVerify(ctx, c, txFeeAssetID, txFee, txFee, numFxs); err != nil {
+	if err := t.BaseTx.SyntacticVerify(ctx, c, txFeeAssetID, txFee, txFee, numFxs, maxMemoSize); err != nil {
 		return err
 	}
 
+	numStatuses := 0
 	for _, state := range t.States {
 		if err := state.Verify(c, numFxs); err != nil {
 			return err
 		}
+		for _, out := range state.Outs {
+			if _, ok := out.(*secp256k1fx.ManagedAssetStatusOutput); ok {
+				numStatuses++
+			}
+		}
+	}
+	if numStatuses > 1 {
+		return errMultipleAssetStatuses
 	}
 	if !isSortedAndUniqueInitialStates(t.States) {
 		return errInitialStatesNotSortedUnique
@@ -137,4 +150,27 @@ func (t *CreateAssetTx) SyntacticVerify(
 	return nil
 }
 
+// SemanticVerify that this transaction is valid to be spent.
+func (t *CreateAssetTx) SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verifiable) error {
+	if err := t.BaseTx.SemanticVerify(vm, tx, creds); err != nil {
+		return err
+	}
+	if t.isManaged() {
+		return vm.verifyManagedAssetsEnabled()
+	}
+	return nil
+}
+
+// isManaged returns true if this transaction creates a managed asset.
+func (t *CreateAssetTx) isManaged() bool {
+	for _, state := range t.States {
+		for _, out := range state.Outs {
+			if _, ok := out.(*secp256k1fx.ManagedAssetStatusOutput); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (t *CreateAssetTx) Sort() { sortInitialStates(t.States) }
@@ -85,7 +85,7 @@ func validCreateAssetTx(t *testing.T) (*CreateAssetTx, codec.Manager, *snow.Cont
 	tx.Initialize(unsignedBytes, unsignedBytes)
 
 	ctx := NewContext(t)
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err != nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err != nil {
 		t.Fatalf("Valid CreateAssetTx failed syntactic verification due to: %s", err)
 	}
 	return tx, c, ctx
@@ -320,7 +320,7 @@ func TestCreateAssetTxSyntacticVerify(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err != nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -331,7 +331,7 @@ func TestCreateAssetTxSyntacticVerifyNil(t *testing.T) {
 
 	tx := (*CreateAssetTx)(nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Nil CreateAssetTx should have errored")
 	}
 }
@@ -354,7 +354,7 @@ func TestCreateAssetTxSyntacticVerifyNameTooShort(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Too short name should have errored")
 	}
 }
@@ -379,7 +379,7 @@ func TestCreateAssetTxSyntacticVerifyNameTooLong(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Too long name should have errored")
 	}
 }
@@ -402,7 +402,7 @@ func TestCreateAssetTxSyntacticVerifySymbolTooShort(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Too short symbol should have errored")
 	}
 }
@@ -425,7 +425,7 @@ func TestCreateAssetTxSyntacticVerifySymbolTooLong(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Too long symbol should have errored")
 	}
 }
@@ -445,7 +445,7 @@ func TestCreateAssetTxSyntacticVerifyNoFxs(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("No Fxs should have errored")
 	}
 }
@@ -468,7 +468,7 @@ func TestCreateAssetTxSyntacticVerifyDenominationTooLong(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Too large denomination should have errored")
 	}
 }
@@ -491,7 +491,7 @@ func TestCreateAssetTxSyntacticVerifyNameWithWhitespace(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Whitespace at the end of the name should have errored")
 	}
 }
@@ -514,7 +514,7 @@ func TestCreateAssetTxSyntacticVerifyNameWithInvalidCharacter(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Name with an invalid character should have errored")
 	}
 }
@@ -537,7 +537,7 @@ func TestCreateAssetTxSyntacticVerifyNameWithUnicodeCharacter(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Name with an invalid character should have errored")
 	}
 }
@@ -560,7 +560,7 @@ func TestCreateAssetTxSyntacticVerifySymbolWithInvalidCharacter(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Symbol with an invalid character should have errored")
 	}
 }
@@ -583,7 +583,7 @@ func TestCreateAssetTxSyntacticVerifyInvalidBaseTx(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Invalid BaseTx should have errored")
 	}
 }
@@ -606,7 +606,7 @@ func TestCreateAssetTxSyntacticVerifyInvalidInitialState(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Invalid InitialState should have errored")
 	}
 }
@@ -634,7 +634,7 @@ func TestCreateAssetTxSyntacticVerifyUnsortedInitialStates(t *testing.T) {
 	}
 	tx.Initialize(nil, nil)
 
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 2); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 2, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Unsorted InitialStates should have errored")
 	}
 }
@@ -652,17 +652,17 @@ func TestCreateAssetTxSyntacticVerifyName(t *testing.T) {
 	// String of Length 129 should fail SyntacticVerify
 	tx.Name = nameTooLong
 
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to name too long")
 	}
 
 	tx.Name = invalidWhitespaceStr
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to invalid whitespace in name")
 	}
 
 	tx.Name = invalidASCIIStr
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to invalid ASCII character in name")
 	}
 }
@@ -671,17 +671,17 @@ func TestCreateAssetTxSyntacticVerifySymbol(t *testing.T) {
 	tx, c, ctx := validCreateAssetTx(t)
 
 	tx.Symbol = symbolTooLong
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to symbol too long")
 	}
 
 	tx.Symbol = " F"
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to invalid whitespace in symbol")
 	}
 
 	tx.Symbol = "É"
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to invalid ASCII character in symbol")
 	}
 }
@@ -690,7 +690,7 @@ func TestCreateAssetTxSyntacticVerifyInvalidDenomination(t *testing.T) {
 	tx, c, ctx := validCreateAssetTx(t)
 
 	tx.Denomination = byte(33)
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to denomination too large")
 	}
 }
@@ -699,7 +699,7 @@ func TestCreateAssetTxSyntacticVerifyInitialStates(t *testing.T) {
 	tx, c, ctx := validCreateAssetTx(t)
 
 	tx.States = []*InitialState{}
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to no Initial States")
 	}
 
@@ -719,7 +719,7 @@ func TestCreateAssetTxSyntacticVerifyInitialStates(t *testing.T) {
 	}
 
 	// NumFxs is 1, so FxIndex 5 should cause an error
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to invalid Fx")
 	}
 
@@ -769,7 +769,7 @@ func TestCreateAssetTxSyntacticVerifyInitialStates(t *testing.T) {
 		uniqueStates[2],
 		uniqueStates[0],
 	}
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 3); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 3, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to non-sorted initial states")
 	}
 
@@ -777,7 +777,7 @@ func TestCreateAssetTxSyntacticVerifyInitialStates(t *testing.T) {
 		uniqueStates[0],
 		uniqueStates[0],
 	}
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 3); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 3, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to non-unique initial states")
 	}
 }
@@ -786,7 +786,38 @@ func TestCreateAssetTxSyntacticVerifyBaseTx(t *testing.T) {
 	tx, c, ctx := validCreateAssetTx(t)
 	var baseTx BaseTx
 	tx.BaseTx = baseTx
-	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 2); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 2, avax.MaxMemoSize); err == nil {
 		t.Fatal("CreateAssetTx should have failed syntactic verification due to invalid BaseTx (nil)")
 	}
 }
+
+func TestCreateAssetTxSyntacticVerifyMultipleAssetStatuses(t *testing.T) {
+	tx, _, ctx := validCreateAssetTx(t)
+	c, err := staticCodec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := func(addr ids.ShortID) *secp256k1fx.ManagedAssetStatusOutput {
+		return &secp256k1fx.ManagedAssetStatusOutput{
+			Manager: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		}
+	}
+	state := &InitialState{
+		FxIndex: 0,
+		Outs:    []verify.State{status(keys[0].PublicKey().Address())},
+	}
+	tx.States = []*InitialState{state}
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err != nil {
+		t.Fatal(err)
+	}
+
+	state.Outs = append(state.Outs, status(keys[1].PublicKey().Address()))
+	state.Sort(c)
+	if err := tx.SyntacticVerify(ctx, c, assetID, 0, 0, 1, avax.MaxMemoSize); err != errMultipleAssetStatuses {
+		t.Fatalf("CreateAssetTx should have failed syntactic verification due to multiple asset statuses but got %v", err)
+	}
+}
@@ -53,6 +53,7 @@ func (t *ExportTx) SyntacticVerify(
 	txFee uint64,
 	_ uint64,
 	_ int,
+	maxMemoSize int,
 ) error {
 	switch {
 	case t == nil:
@@ -61,7 +62,7 @@ func (t *ExportTx) SyntacticVerify(
 		return errNoExportOutputs
 	}
 
-	if err := t.MetadataVerify(ctx); err != nil {
+	if err := t.MetadataVerifyWithMaxMemoSize(ctx, maxMemoSize); err != nil {
 		return err
 	}
 
@@ -4,6 +4,8 @@
 package avm
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 )
@@ -16,6 +18,14 @@ var (
 type Factory struct {
 	TxFee            uint64
 	CreateAssetTxFee uint64
+
+	// Maximum number of bytes in a tx's memo field. If non-positive,
+	// avax.MaxMemoSize is used. On Mainnet and Fuji, it can't be larger than
+	// avax.MaxMemoSize.
+	MaxMemoSize int
+
+	// Time that managed assets can be created and updated
+	ApricotPhase6Time time.Time
 }
 
 func (f *Factory) New(*snow.Context) (interface{}, error) {
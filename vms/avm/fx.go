@@ -17,6 +17,10 @@ var (
 	_ Fx = &secp256k1fx.Fx{}
 	_ Fx = &nftfx.Fx{}
 	_ Fx = &propertyfx.Fx{}
+
+	_ BatchVerifierFx = &secp256k1fx.Fx{}
+	_ BatchVerifierFx = &nftfx.Fx{}
+	_ BatchVerifierFx = &propertyfx.Fx{}
 )
 
 type parsedFx struct {
@@ -49,6 +53,21 @@ type Fx interface {
 	VerifyOperation(tx, op, cred interface{}, utxos []interface{}) error
 }
 
+// BatchVerifierFx is implemented by feature extensions that can defer the
+// signature checks of VerifyTransfer and VerifyOperation so that all of a
+// transaction's signatures are verified at once.
+type BatchVerifierFx interface {
+	// StartBatch defers signature verification until VerifyBatch or
+	// AbortBatch is called.
+	StartBatch()
+
+	// VerifyBatch verifies the signatures deferred since StartBatch.
+	VerifyBatch() error
+
+	// AbortBatch discards the signatures deferred since StartBatch.
+	AbortBatch()
+}
+
 type FxOperation interface {
 	verify.Verifiable
 	snow.ContextInitializable
@@ -82,6 +82,7 @@ func (t *ImportTx) SyntacticVerify(
 	txFee uint64,
 	_ uint64,
 	numFxs int,
+	maxMemoSize int,
 ) error {
 	switch {
 	case t == nil:
@@ -90,7 +91,7 @@ func (t *ImportTx) SyntacticVerify(
 		return errNoImportInputs
 	}
 
-	if err := t.MetadataVerify(ctx); err != nil {
+	if err := t.MetadataVerifyWithMaxMemoSize(ctx, maxMemoSize); err != nil {
 		return err
 	}
 
@@ -26,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/index"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
@@ -624,3 +625,204 @@ func setupTestTxsInDB(t *testing.T, db *versiondb.Database, address ids.ShortID,
 	assert.NoError(t, err)
 	return testTxs
 }
+
+func TestAssetTxRoles(t *testing.T) {
+	assert := assert.New(t)
+
+	assetID := ids.GenerateTestID()
+	feeAssetID := ids.GenerateTestID()
+	txID := ids.GenerateTestID()
+	baseTx := buildTX(avax.UTXOID{TxID: ids.GenerateTestID()}, avax.Asset{ID: feeAssetID}, keys[0].PublicKey().Address()).UnsignedTx.(*BaseTx)
+
+	assert.Equal(map[ids.ID]index.AssetTxRole{
+		feeAssetID: index.AssetTxTransferred,
+	}, assetTxRoles(txID, baseTx))
+
+	assert.Equal(map[ids.ID]index.AssetTxRole{
+		feeAssetID: index.AssetTxTransferred,
+		txID:       index.AssetTxCreated,
+	}, assetTxRoles(txID, &CreateAssetTx{BaseTx: *baseTx}))
+
+	assert.Equal(map[ids.ID]index.AssetTxRole{
+		feeAssetID: index.AssetTxTransferred | index.AssetTxMinted,
+		assetID:    index.AssetTxMinted | index.AssetTxTransferred,
+	}, assetTxRoles(txID, &OperationTx{
+		BaseTx: *baseTx,
+		Ops: []*Operation{
+			{Asset: avax.Asset{ID: feeAssetID}, Op: &secp256k1fx.MintOperation{}},
+			{Asset: avax.Asset{ID: assetID}, Op: &nftfx.MintOperation{}},
+			{Asset: avax.Asset{ID: assetID}, Op: &nftfx.TransferOperation{}},
+		},
+	}))
+}
+
+func TestAssetIndexer_Read(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := NewContext(t)
+	indexer, err := index.NewAssetTxsIndexer(memdb.New(), ctx.Log, "", prometheus.NewRegistry(), nil)
+	assert.NoError(err)
+
+	assetID := ids.GenerateTestID()
+	txs := []index.AssetTx{
+		{TxID: ids.GenerateTestID(), Roles: index.AssetTxCreated},
+		{TxID: ids.GenerateTestID(), Roles: index.AssetTxTransferred},
+		{TxID: ids.GenerateTestID(), Roles: index.AssetTxMinted | index.AssetTxTransferred},
+		{TxID: ids.GenerateTestID(), Roles: index.AssetTxTransferred},
+	}
+	for _, tx := range txs {
+		assert.NoError(indexer.Accept(tx.TxID, map[ids.ID]index.AssetTxRole{
+			assetID:              tx.Roles,
+			ids.GenerateTestID(): index.AssetTxTransferred,
+		}))
+	}
+
+	read, cursor, err := indexer.Read(assetID, index.AssetTxAllRoles, 0, 3)
+	assert.NoError(err)
+	assert.Equal(txs[:3], read)
+	assert.EqualValues(3, cursor)
+
+	read, cursor, err = indexer.Read(assetID, index.AssetTxAllRoles, cursor, 3)
+	assert.NoError(err)
+	assert.Equal(txs[3:], read)
+	assert.EqualValues(4, cursor)
+
+	// Reading past the end returns the same cursor
+	read, cursor, err = indexer.Read(assetID, index.AssetTxAllRoles, cursor, 3)
+	assert.NoError(err)
+	assert.Empty(read)
+	assert.EqualValues(4, cursor)
+
+	// Only the txs with the requested roles are returned, but the cursor
+	// moves past every tx that was examined
+	read, cursor, err = indexer.Read(assetID, index.AssetTxMinted, 0, 3)
+	assert.NoError(err)
+	assert.Equal(txs[2:3], read)
+	assert.EqualValues(4, cursor)
+
+	read, cursor, err = indexer.Read(assetID, index.AssetTxCreated|index.AssetTxMinted, 0, 1)
+	assert.NoError(err)
+	assert.Equal(txs[:1], read)
+	assert.EqualValues(1, cursor)
+}
+
+func TestAssetIndexerBackfill(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := NewContext(t)
+	db := memdb.New()
+	assetID := ids.GenerateTestID()
+	missedTxID := ids.GenerateTestID()
+	indexedTxID := ids.GenerateTestID()
+	backfill := func(accept func(ids.ID, map[ids.ID]index.AssetTxRole) error) error {
+		for _, txID := range []ids.ID{missedTxID, indexedTxID} {
+			if err := accept(txID, map[ids.ID]index.AssetTxRole{assetID: index.AssetTxTransferred}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// [missedTxID] is accepted while indexing is disabled
+	_, err := index.NewNoAssetTxsIndexer(db)
+	assert.NoError(err)
+
+	// Without a backfill, the index only has the txs accepted after it was
+	// enabled
+	indexer, err := index.NewAssetTxsIndexer(db, ctx.Log, "", prometheus.NewRegistry(), nil)
+	assert.NoError(err)
+	assert.NoError(indexer.Accept(indexedTxID, map[ids.ID]index.AssetTxRole{assetID: index.AssetTxTransferred}))
+	read, _, err := indexer.Read(assetID, index.AssetTxAllRoles, 0, 10)
+	assert.NoError(err)
+	assert.Equal([]index.AssetTx{{TxID: indexedTxID, Roles: index.AssetTxTransferred}}, read)
+
+	// The backfill replaces the incomplete index
+	indexer, err = index.NewAssetTxsIndexer(db, ctx.Log, "", prometheus.NewRegistry(), backfill)
+	assert.NoError(err)
+	read, _, err = indexer.Read(assetID, index.AssetTxAllRoles, 0, 10)
+	assert.NoError(err)
+	assert.Equal([]index.AssetTx{
+		{TxID: missedTxID, Roles: index.AssetTxTransferred},
+		{TxID: indexedTxID, Roles: index.AssetTxTransferred},
+	}, read)
+
+	// Once the index is complete, it isn't backfilled again
+	_, err = index.NewAssetTxsIndexer(db, ctx.Log, "", prometheus.NewRegistry(), func(func(ids.ID, map[ids.ID]index.AssetTxRole) error) error {
+		t.Fatal("shouldn't have backfilled a complete index")
+		return nil
+	})
+	assert.NoError(err)
+}
+
+func TestServiceGetAssetTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes := BuildGenesisTest(t)
+	issuer := make(chan common.Message, 1)
+	baseDBManager := manager.NewMemDB(version.DefaultVersion1_0_0)
+	ctx := NewContext(t)
+	avaxID := GetAVAXTxFromGenesisTest(genesisBytes, t).ID()
+	vm := setupTestVM(t, ctx, baseDBManager, genesisBytes, issuer, indexEnabledAvmConfig)
+	s := &Service{vm: vm}
+	ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	// The genesis asset was indexed when the chain was initialized
+	reply := &GetAssetTxsReply{}
+	assert.NoError(s.GetAssetTxs(nil, &GetAssetTxsArgs{AssetID: avaxID.String()}, reply))
+	assert.Equal([]index.AssetTx{{TxID: avaxID, Roles: index.AssetTxCreated}}, reply.Txs)
+	assert.EqualValues(1, reply.Cursor)
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	utxoID := avax.UTXOID{TxID: ids.GenerateTestID()}
+	utxo := buildPlatformUTXO(utxoID, avax.Asset{ID: avaxID}, addr)
+	assert.NoError(vm.state.PutUTXO(utxo.InputID(), utxo))
+	tx := buildTX(utxoID, avax.Asset{ID: avaxID}, addr)
+	assert.NoError(signTX(vm.codec, tx, key))
+	txID, err := vm.IssueTx(tx.Bytes())
+	assert.NoError(err)
+	uniqueTx, err := vm.GetTx(txID)
+	assert.NoError(err)
+	assert.NoError(uniqueTx.Accept())
+
+	assert.NoError(s.GetAssetTxs(nil, &GetAssetTxsArgs{
+		AssetID: avaxID.String(),
+		Cursor:  reply.Cursor,
+	}, reply))
+	assert.Equal([]index.AssetTx{{TxID: txID, Roles: index.AssetTxTransferred}}, reply.Txs)
+	assert.EqualValues(2, reply.Cursor)
+
+	assert.NoError(s.GetAssetTxs(nil, &GetAssetTxsArgs{
+		AssetID: avaxID.String(),
+		Roles:   []string{"created", "minted"},
+	}, reply))
+	assert.Equal([]index.AssetTx{{TxID: avaxID, Roles: index.AssetTxCreated}}, reply.Txs)
+	assert.EqualValues(2, reply.Cursor)
+
+	err = s.GetAssetTxs(nil, &GetAssetTxsArgs{
+		AssetID: avaxID.String(),
+		Roles:   []string{"burned"},
+	}, reply)
+	assert.Error(err)
+}
+
+func TestServiceGetAssetTxsIndexingDisabled(t *testing.T) {
+	genesisBytes := BuildGenesisTest(t)
+	baseDBManager := manager.NewMemDB(version.DefaultVersion1_0_0)
+	ctx := NewContext(t)
+	avaxID := GetAVAXTxFromGenesisTest(genesisBytes, t).ID()
+	vm := setupTestVM(t, ctx, baseDBManager, genesisBytes, make(chan common.Message, 1), Config{})
+	s := &Service{vm: vm}
+	ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(t, vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	err := s.GetAssetTxs(nil, &GetAssetTxsArgs{AssetID: avaxID.String()}, &GetAssetTxsReply{})
+	assert.ErrorIs(t, err, index.ErrAssetIndexingDisabled)
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	assetStatusCacheSize = 2048
+)
+
+var _ ManagedAssetState = &managedAssetState{}
+
+// ManagedAssetState is a thin wrapper around a database to provide caching of
+// the UTXO that holds the current accepted status of each managed asset.
+type ManagedAssetState interface {
+	// GetAssetStatus returns the ID of the UTXO that holds the status of the
+	// managed asset. Returns database.ErrNotFound if the asset isn't managed.
+	GetAssetStatus(assetID ids.ID) (ids.ID, error)
+
+	// PutAssetStatus saves the ID of the UTXO that holds the status of the
+	// managed asset.
+	PutAssetStatus(assetID ids.ID, utxoID ids.ID) error
+}
+
+type managedAssetState struct {
+	// Caches AssetID -> UTXO ID
+	statusCache cache.Cacher
+	// Maps AssetID -> UTXO ID
+	statusDB database.Database
+}
+
+func NewManagedAssetState(db database.Database) ManagedAssetState {
+	return &managedAssetState{
+		statusCache: &cache.LRU{
+			Size: assetStatusCacheSize,
+		},
+		statusDB: db,
+	}
+}
+
+func NewMeteredManagedAssetState(db database.Database, metrics prometheus.Registerer) (ManagedAssetState, error) {
+	cache, err := metercacher.New(
+		"asset_status_cache",
+		metrics,
+		&cache.LRU{Size: assetStatusCacheSize},
+	)
+	return &managedAssetState{
+		statusCache: cache,
+		statusDB:    db,
+	}, err
+}
+
+func (s *managedAssetState) GetAssetStatus(assetID ids.ID) (ids.ID, error) {
+	if utxoIDIntf, found := s.statusCache.Get(assetID); found {
+		return utxoIDIntf.(ids.ID), nil
+	}
+
+	utxoIDBytes, err := s.statusDB.Get(assetID[:])
+	if err != nil {
+		return ids.Empty, err
+	}
+	utxoID, err := ids.ToID(utxoIDBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	s.statusCache.Put(assetID, utxoID)
+	return utxoID, nil
+}
+
+func (s *managedAssetState) PutAssetStatus(assetID ids.ID, utxoID ids.ID) error {
+	s.statusCache.Put(assetID, utxoID)
+	return s.statusDB.Put(assetID[:], utxoID[:])
+}
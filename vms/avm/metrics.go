@@ -4,18 +4,64 @@
 package avm
 
 import (
+	"fmt"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
+// utxosPerTxBuckets are the histogram boundaries used for the number of UTXOs
+// produced by accepted txs
+var utxosPerTxBuckets = []float64{1, 2, 3, 5, 10, 25, 50, 100, 250, 500, 1000}
+
 type metrics struct {
 	numTxRefreshes, numTxRefreshHits, numTxRefreshMisses prometheus.Counter
 
+	numBaseTxs,
+	numCreateAssetTxs,
+	numOperationTxs,
+	numImportTxs,
+	numExportTxs,
+	numUnknownTxs prometheus.Counter
+
+	// Mint operations inside accepted OperationTxs
+	numSecpMintOps,
+	numNFTMintOps prometheus.Counter
+
+	// Number of UTXOs produced by each accepted tx
+	utxosPerTx prometheus.Histogram
+
+	// Txs issued into consensus that haven't been decided yet
+	pendingTxs *pendingTxs
+
+	numGossipedTxs,
+	numReceivedGossipTxs,
+	numDuplicateGossipTxs,
+	numInvalidGossipTxs prometheus.Counter
+
 	apiRequestMetric metric.APIInterceptor
 }
 
+func newTxMetrics(namespace string, name string) prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_txs_accepted", name),
+		Help:      fmt.Sprintf("Number of %s transactions accepted", name),
+	})
+}
+
+func newOpMetrics(namespace string, name string) prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_ops_accepted", name),
+		Help:      fmt.Sprintf("Number of %s operations accepted", name),
+	})
+}
+
 func (m *metrics) Initialize(
 	namespace string,
 	registerer prometheus.Registerer,
@@ -36,14 +82,106 @@ func (m *metrics) Initialize(
 		Help:      "Number of times unique txs have not been unique and weren't cached",
 	})
 
+	m.numBaseTxs = newTxMetrics(namespace, "base")
+	m.numCreateAssetTxs = newTxMetrics(namespace, "create_asset")
+	m.numOperationTxs = newTxMetrics(namespace, "operation")
+	m.numImportTxs = newTxMetrics(namespace, "import")
+	m.numExportTxs = newTxMetrics(namespace, "export")
+	m.numUnknownTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "unknown_txs",
+		Help:      "Number of transactions of an unknown type accepted",
+	})
+
+	m.numSecpMintOps = newOpMetrics(namespace, "secp256k1fx_mint")
+	m.numNFTMintOps = newOpMetrics(namespace, "nftfx_mint")
+
+	m.utxosPerTx = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "utxos_per_accepted_tx",
+		Help:      "Number of UTXOs produced by each accepted transaction",
+		Buckets:   utxosPerTxBuckets,
+	})
+
+	m.numGossipedTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gossip_txs_sent",
+		Help:      "Number of transactions gossiped to peers",
+	})
+	m.numReceivedGossipTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gossip_txs_received",
+		Help:      "Number of transactions received through gossip",
+	})
+	m.numDuplicateGossipTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gossip_txs_duplicate",
+		Help:      "Number of transactions received through gossip that were already known",
+	})
+	m.numInvalidGossipTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gossip_txs_invalid",
+		Help:      "Number of transactions received through gossip that failed to parse or verify",
+	})
+
+	pendingTxs, err := newPendingTxs(namespace, registerer)
+	m.pendingTxs = pendingTxs
+	errs := wrappers.Errs{}
+	errs.Add(err)
+
 	apiRequestMetric, err := metric.NewAPIInterceptor(namespace, registerer)
 	m.apiRequestMetric = apiRequestMetric
-	errs := wrappers.Errs{}
 	errs.Add(
 		err,
 		registerer.Register(m.numTxRefreshes),
 		registerer.Register(m.numTxRefreshHits),
 		registerer.Register(m.numTxRefreshMisses),
+
+		registerer.Register(m.numBaseTxs),
+		registerer.Register(m.numCreateAssetTxs),
+		registerer.Register(m.numOperationTxs),
+		registerer.Register(m.numImportTxs),
+		registerer.Register(m.numExportTxs),
+		registerer.Register(m.numUnknownTxs),
+
+		registerer.Register(m.numSecpMintOps),
+		registerer.Register(m.numNFTMintOps),
+
+		registerer.Register(m.utxosPerTx),
+
+		registerer.Register(m.numGossipedTxs),
+		registerer.Register(m.numReceivedGossipTxs),
+		registerer.Register(m.numDuplicateGossipTxs),
+		registerer.Register(m.numInvalidGossipTxs),
 	)
 	return errs.Err
 }
+
+// AcceptTx records that [tx] was accepted. Txs of an unknown type are counted
+// rather than reported as an error, so that they never fail acceptance.
+func (m *metrics) AcceptTx(tx *Tx) {
+	m.utxosPerTx.Observe(float64(len(tx.UTXOs())))
+
+	switch utx := tx.UnsignedTx.(type) {
+	case *BaseTx:
+		m.numBaseTxs.Inc()
+	case *CreateAssetTx:
+		m.numCreateAssetTxs.Inc()
+	case *OperationTx:
+		m.numOperationTxs.Inc()
+		for _, op := range utx.Ops {
+			switch op.Op.(type) {
+			case *secp256k1fx.MintOperation:
+				m.numSecpMintOps.Inc()
+			case *nftfx.MintOperation:
+				m.numNFTMintOps.Inc()
+			}
+		}
+	case *ImportTx:
+		m.numImportTxs.Inc()
+	case *ExportTx:
+		m.numExportTxs.Inc()
+	default:
+		m.numUnknownTxs.Inc()
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestMetricsAcceptTx(t *testing.T) {
+	assert := assert.New(t)
+
+	m := metrics{}
+	assert.NoError(m.Initialize("", prometheus.NewRegistry()))
+
+	m.AcceptTx(&Tx{UnsignedTx: &BaseTx{}})
+	m.AcceptTx(&Tx{UnsignedTx: &CreateAssetTx{}})
+	m.AcceptTx(&Tx{UnsignedTx: &ImportTx{}})
+	m.AcceptTx(&Tx{UnsignedTx: &ExportTx{}})
+	m.AcceptTx(&Tx{UnsignedTx: &OperationTx{
+		Ops: []*Operation{
+			{Op: &secp256k1fx.MintOperation{}},
+			{Op: &nftfx.MintOperation{}},
+			{Op: &nftfx.MintOperation{}},
+			{Op: &nftfx.TransferOperation{}},
+		},
+	}})
+
+	// Unknown tx types are counted rather than rejected
+	type unknownTx struct{ *BaseTx }
+	m.AcceptTx(&Tx{UnsignedTx: unknownTx{&BaseTx{}}})
+
+	assert.Equal(1.0, testutil.ToFloat64(m.numBaseTxs))
+	assert.Equal(1.0, testutil.ToFloat64(m.numCreateAssetTxs))
+	assert.Equal(1.0, testutil.ToFloat64(m.numImportTxs))
+	assert.Equal(1.0, testutil.ToFloat64(m.numExportTxs))
+	assert.Equal(1.0, testutil.ToFloat64(m.numOperationTxs))
+	assert.Equal(1.0, testutil.ToFloat64(m.numUnknownTxs))
+	assert.Equal(1.0, testutil.ToFloat64(m.numSecpMintOps))
+	assert.Equal(2.0, testutil.ToFloat64(m.numNFTMintOps))
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// issueToAcceptBuckets are the histogram boundaries, in milliseconds, used for
+// the time between a tx being issued into consensus and being accepted
+var issueToAcceptBuckets = []float64{100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 300000}
+
+type pendingTx struct {
+	issued time.Time
+	inputs []ids.ID
+	rogue  bool
+}
+
+type localTx struct {
+	tx     *Tx
+	inputs []ids.ID
+}
+
+// pendingTxs tracks the txs that have been issued into consensus, but haven't
+// been decided yet. A pending tx is rogue if another pending tx consumes one
+// of its inputs. The undecided txs that were issued through this node are also
+// tracked, in the order they were issued, until they are decided or dropped.
+type pendingTxs struct {
+	// txID -> pending tx
+	txs map[ids.ID]*pendingTx
+	// inputID -> IDs of the pending txs consuming the input
+	spenders map[ids.ID]ids.Set
+	numRogue int
+
+	// txID -> element of [localTxOrdering] holding the *localTx
+	localTxs        map[ids.ID]*list.Element
+	localTxOrdering *list.List
+	// inputID -> IDs of the local txs consuming the input
+	localSpenders map[ids.ID]ids.Set
+
+	numPendingTxs, numVirtuousTxs, numRogueTxs prometheus.Gauge
+	issueToAccept                              prometheus.Histogram
+	numConflictRejectedTxs                     prometheus.Counter
+}
+
+func newPendingTxs(namespace string, registerer prometheus.Registerer) (*pendingTxs, error) {
+	p := &pendingTxs{
+		txs:             make(map[ids.ID]*pendingTx),
+		spenders:        make(map[ids.ID]ids.Set),
+		localTxs:        make(map[ids.ID]*list.Element),
+		localTxOrdering: list.New(),
+		localSpenders:   make(map[ids.ID]ids.Set),
+		numPendingTxs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pending_txs",
+			Help:      "Number of transactions issued into consensus that haven't been decided",
+		}),
+		numVirtuousTxs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pending_virtuous_txs",
+			Help:      "Number of pending transactions that don't conflict with another pending transaction",
+		}),
+		numRogueTxs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pending_rogue_txs",
+			Help:      "Number of pending transactions that conflict with another pending transaction",
+		}),
+		issueToAccept: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "issue_to_accept",
+			Help:      "Time (in ms) between a transaction being issued into consensus and being accepted",
+			Buckets:   issueToAcceptBuckets,
+		}),
+		numConflictRejectedTxs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "conflict_rejected_txs",
+			Help:      "Number of pending transactions dropped due to conflicts",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(p.numPendingTxs),
+		registerer.Register(p.numVirtuousTxs),
+		registerer.Register(p.numRogueTxs),
+		registerer.Register(p.issueToAccept),
+		registerer.Register(p.numConflictRejectedTxs),
+	)
+	return p, errs.Err
+}
+
+// Issue marks [txID], which consumes [inputs], as issued into consensus at
+// [now]. Issuing a tx that is already pending does nothing.
+func (p *pendingTxs) Issue(txID ids.ID, inputs []ids.ID, now time.Time) {
+	if _, ok := p.txs[txID]; ok {
+		return
+	}
+
+	p.txs[txID] = &pendingTx{
+		issued: now,
+		inputs: inputs,
+	}
+	for _, inputID := range inputs {
+		spenders := p.spenders[inputID]
+		if spenders == nil {
+			spenders = ids.Set{}
+			p.spenders[inputID] = spenders
+		}
+		spenders.Add(txID)
+		if spenders.Len() > 1 {
+			for spenderID := range spenders {
+				p.setRogue(p.txs[spenderID], true)
+			}
+		}
+
+		// The engine drops the local txs that conflict with [txID] and that
+		// haven't been issued into consensus yet
+		for spenderID := range p.localSpenders[inputID] {
+			if _, ok := p.txs[spenderID]; !ok {
+				p.Drop(spenderID)
+			}
+		}
+	}
+	p.updateGauges()
+}
+
+// IssueLocal marks [tx], which consumes [inputs], as issued through this node.
+// Issuing a tx that is already tracked does nothing. [tx] isn't tracked if it
+// conflicts with a pending tx, since the engine drops it.
+func (p *pendingTxs) IssueLocal(tx *Tx, inputs []ids.ID) {
+	txID := tx.ID()
+	if _, ok := p.localTxs[txID]; ok {
+		return
+	}
+	for _, inputID := range inputs {
+		for spenderID := range p.spenders[inputID] {
+			if spenderID != txID {
+				return
+			}
+		}
+	}
+
+	p.localTxs[txID] = p.localTxOrdering.PushBack(&localTx{
+		tx:     tx,
+		inputs: inputs,
+	})
+	for _, inputID := range inputs {
+		spenders := p.localSpenders[inputID]
+		if spenders == nil {
+			spenders = ids.Set{}
+			p.localSpenders[inputID] = spenders
+		}
+		spenders.Add(txID)
+	}
+}
+
+// LocalTxs returns the undecided txs that were issued through this node, in
+// the order they were issued.
+func (p *pendingTxs) LocalTxs() []*Tx {
+	txs := make([]*Tx, 0, p.localTxOrdering.Len())
+	for e := p.localTxOrdering.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*localTx).tx)
+	}
+	return txs
+}
+
+// Accept marks [txID] as accepted at [now]. If [txID] was pending, the time
+// since it was issued is recorded.
+func (p *pendingTxs) Accept(txID ids.ID, now time.Time) {
+	p.removeLocal(txID)
+	tx, ok := p.remove(txID)
+	if !ok {
+		return
+	}
+	p.issueToAccept.Observe(float64(now.Sub(tx.issued).Milliseconds()))
+}
+
+// Reject marks [txID] as rejected.
+func (p *pendingTxs) Reject(txID ids.ID) {
+	p.removeLocal(txID)
+	if _, ok := p.remove(txID); ok {
+		p.numConflictRejectedTxs.Inc()
+	}
+}
+
+// Drop marks [txID] as dropped without being decided, such as when it fails
+// verification. A dropped tx is no longer tracked as issued through this node.
+func (p *pendingTxs) Drop(txID ids.ID) {
+	p.removeLocal(txID)
+}
+
+func (p *pendingTxs) removeLocal(txID ids.ID) {
+	e, ok := p.localTxs[txID]
+	if !ok {
+		return
+	}
+
+	delete(p.localTxs, txID)
+	tx := p.localTxOrdering.Remove(e).(*localTx)
+	for _, inputID := range tx.inputs {
+		spenders := p.localSpenders[inputID]
+		spenders.Remove(txID)
+		if spenders.Len() == 0 {
+			delete(p.localSpenders, inputID)
+		}
+	}
+}
+
+func (p *pendingTxs) remove(txID ids.ID) (*pendingTx, bool) {
+	tx, ok := p.txs[txID]
+	if !ok {
+		return nil, false
+	}
+
+	p.setRogue(tx, false)
+	delete(p.txs, txID)
+	for _, inputID := range tx.inputs {
+		spenders := p.spenders[inputID]
+		spenders.Remove(txID)
+		switch spenders.Len() {
+		case 0:
+			delete(p.spenders, inputID)
+		case 1:
+			// The remaining spender may no longer conflict with anything
+			for spenderID := range spenders {
+				spender := p.txs[spenderID]
+				p.setRogue(spender, p.conflicts(spender))
+			}
+		}
+	}
+	p.updateGauges()
+	return tx, true
+}
+
+// conflicts returns true if another pending tx consumes one of [tx]'s inputs.
+func (p *pendingTxs) conflicts(tx *pendingTx) bool {
+	for _, inputID := range tx.inputs {
+		if p.spenders[inputID].Len() > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *pendingTxs) setRogue(tx *pendingTx, rogue bool) {
+	if tx.rogue == rogue {
+		return
+	}
+	tx.rogue = rogue
+	if rogue {
+		p.numRogue++
+	} else {
+		p.numRogue--
+	}
+}
+
+func (p *pendingTxs) updateGauges() {
+	p.numPendingTxs.Set(float64(len(p.txs)))
+	p.numVirtuousTxs.Set(float64(len(p.txs) - p.numRogue))
+	p.numRogueTxs.Set(float64(p.numRogue))
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func issueToAcceptSamples(t *testing.T, p *pendingTxs) uint64 {
+	metric := &dto.Metric{}
+	assert.NoError(t, p.issueToAccept.Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func assertPendingTxs(t *testing.T, p *pendingTxs, virtuous, rogue int) {
+	assert.Equal(t, float64(virtuous+rogue), testutil.ToFloat64(p.numPendingTxs))
+	assert.Equal(t, float64(virtuous), testutil.ToFloat64(p.numVirtuousTxs))
+	assert.Equal(t, float64(rogue), testutil.ToFloat64(p.numRogueTxs))
+}
+
+func TestPendingTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	p, err := newPendingTxs("", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	now := time.Now()
+	inputs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	txIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+
+	p.Issue(txIDs[0], []ids.ID{inputs[0]}, now)
+	p.Issue(txIDs[1], []ids.ID{inputs[1], inputs[2]}, now)
+	assertPendingTxs(t, p, 2, 0)
+
+	// Issuing a pending tx again is ignored
+	p.Issue(txIDs[0], []ids.ID{inputs[0]}, now)
+	assertPendingTxs(t, p, 2, 0)
+
+	// [txIDs[2]] conflicts with both of the other txs
+	p.Issue(txIDs[2], []ids.ID{inputs[0], inputs[1]}, now)
+	assertPendingTxs(t, p, 0, 3)
+
+	// [txIDs[3]] conflicts with [txIDs[2]]
+	p.Issue(txIDs[3], []ids.ID{inputs[1]}, now)
+	assertPendingTxs(t, p, 0, 4)
+
+	// [txIDs[0]] no longer conflicts with anything, but the other txs still
+	// conflict on [inputs[1]]
+	p.Reject(txIDs[2])
+	assertPendingTxs(t, p, 1, 2)
+	assert.Equal(1.0, testutil.ToFloat64(p.numConflictRejectedTxs))
+
+	p.Accept(txIDs[1], now.Add(time.Second))
+	assertPendingTxs(t, p, 2, 0)
+	assert.EqualValues(1, issueToAcceptSamples(t, p))
+
+	// Deciding txs that aren't pending is ignored
+	p.Accept(txIDs[1], now.Add(time.Second))
+	p.Reject(txIDs[2])
+	p.Accept(ids.GenerateTestID(), now)
+	assertPendingTxs(t, p, 2, 0)
+	assert.EqualValues(1, issueToAcceptSamples(t, p))
+	assert.Equal(1.0, testutil.ToFloat64(p.numConflictRejectedTxs))
+}
+
+// Ensure that the time from issuance to acceptance is observed exactly once per
+// accepted tx.
+func TestPendingTxsIssueToAcceptObservedOnce(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ctx, issueTxs := setupIssueTx(t)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	// [firstTx] and [secondTx] consume the same UTXO
+	firstTx, err := vm.ParseTx(issueTxs[1].Bytes())
+	assert.NoError(err)
+	secondTx, err := vm.ParseTx(issueTxs[2].Bytes())
+	assert.NoError(err)
+
+	assert.NoError(firstTx.Verify())
+	assert.NoError(secondTx.Verify())
+	// Verifying a tx again doesn't re-issue it
+	assert.NoError(firstTx.Verify())
+	assertPendingTxs(t, vm.pendingTxs, 0, 2)
+
+	assert.NoError(firstTx.Accept())
+	assert.EqualValues(1, issueToAcceptSamples(t, vm.pendingTxs))
+	assert.Error(firstTx.Accept())
+	assert.EqualValues(1, issueToAcceptSamples(t, vm.pendingTxs))
+
+	assert.NoError(secondTx.Reject())
+	assertPendingTxs(t, vm.pendingTxs, 0, 0)
+	assert.Equal(1.0, testutil.ToFloat64(vm.pendingTxs.numConflictRejectedTxs))
+	assert.EqualValues(1, issueToAcceptSamples(t, vm.pendingTxs))
+}
+
+func TestPendingTxsLocal(t *testing.T) {
+	assert := assert.New(t)
+
+	p, err := newPendingTxs("", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	inputs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	txs := make([]*Tx, 4)
+	for i := range txs {
+		txs[i] = &Tx{UnsignedTx: &BaseTx{}}
+		txs[i].Initialize(nil, []byte{byte(i)})
+		p.IssueLocal(txs[i], []ids.ID{inputs[i]})
+	}
+	// Issuing a tracked tx again is ignored
+	p.IssueLocal(txs[0], []ids.ID{inputs[0]})
+	assert.Equal(txs, p.LocalTxs())
+
+	p.Reject(txs[1].ID())
+	p.Accept(txs[0].ID(), time.Now())
+	p.Drop(txs[2].ID())
+	assert.Equal([]*Tx{txs[3]}, p.LocalTxs())
+	assert.Len(p.localSpenders, 1)
+
+	// A local tx that conflicts with a pending tx is dropped by the engine, so
+	// it isn't tracked
+	p.Issue(ids.GenerateTestID(), []ids.ID{inputs[0]}, time.Now())
+	conflictingTx := &Tx{UnsignedTx: &BaseTx{}}
+	conflictingTx.Initialize(nil, []byte{4})
+	p.IssueLocal(conflictingTx, []ids.ID{inputs[0]})
+	assert.Equal([]*Tx{txs[3]}, p.LocalTxs())
+
+	// A local tx that hasn't been issued into consensus is dropped once a
+	// conflicting tx is
+	p.Issue(ids.GenerateTestID(), []ids.ID{inputs[3]}, time.Now())
+	assert.Empty(p.LocalTxs())
+	assert.Empty(p.localSpenders)
+
+	// A local tx that has been issued into consensus stays tracked when a
+	// conflicting tx is issued
+	p.IssueLocal(txs[1], []ids.ID{inputs[1]})
+	p.Issue(txs[1].ID(), []ids.ID{inputs[1]}, time.Now())
+	p.Issue(ids.GenerateTestID(), []ids.ID{inputs[1]}, time.Now())
+	assert.Equal([]*Tx{txs[1]}, p.LocalTxs())
+}
+
+// Ensure that locally issued txs that fail verification are no longer tracked.
+func TestPendingTxsLocalFailedVerification(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ctx, issueTxs := setupIssueTx(t)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	// [firstTx] and [secondTx] consume the same UTXO
+	_, err := vm.IssueTx(issueTxs[1].Bytes())
+	assert.NoError(err)
+	assert.Len(vm.pendingTxs.LocalTxs(), 1)
+
+	// Accepting [secondTx] consumes the UTXO that [firstTx] spends
+	secondTx, err := vm.ParseTx(issueTxs[2].Bytes())
+	assert.NoError(err)
+	assert.NoError(secondTx.Accept())
+	assert.Len(vm.pendingTxs.LocalTxs(), 1)
+
+	firstTx, err := vm.ParseTx(issueTxs[1].Bytes())
+	assert.NoError(err)
+	assert.Error(firstTx.Verify())
+	assert.Empty(vm.pendingTxs.LocalTxs())
+}
@@ -19,9 +19,13 @@ func NewPubSubFilterer(tx *Tx) pubsub.Filterer {
 	return &filterer{tx: tx}
 }
 
-// Apply the filter on the addresses.
+// Apply the filter on the txID and the addresses.
 func (f *filterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
 	resp := make([]bool, len(filters))
+	txID := f.tx.ID()
+	for i, c := range filters {
+		resp[i] = c.CheckTxID(txID[:])
+	}
 	for _, utxo := range f.tx.UTXOs() {
 		addressable, ok := utxo.Out.(avax.Addressable)
 		if !ok {
@@ -38,6 +42,6 @@ func (f *filterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
 		}
 	}
 	return resp, api.JSONTxID{
-		TxID: f.tx.ID(),
+		TxID: txID,
 	}
 }
@@ -16,13 +16,18 @@ import (
 )
 
 type mockFilter struct {
-	addr []byte
+	addr   []byte
+	prefix []byte
 }
 
 func (f *mockFilter) Check(addr []byte) bool {
 	return bytes.Equal(addr, f.addr)
 }
 
+func (f *mockFilter) CheckTxID(txID []byte) bool {
+	return len(f.prefix) > 0 && bytes.HasPrefix(txID, f.prefix)
+}
+
 func TestFilter(t *testing.T) {
 	assert := assert.New(t)
 
@@ -48,3 +53,28 @@ func TestFilter(t *testing.T) {
 	fr, _ := parser.Filter([]pubsub.Filter{&mockFilter{addr: addrBytes}})
 	assert.Equal([]bool{true}, fr)
 }
+
+func TestFilterTxID(t *testing.T) {
+	assert := assert.New(t)
+
+	tx := Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		Outs: []*avax.TransferableOutput{
+			{
+				Out: &secp256k1fx.TransferOutput{
+					OutputOwners: secp256k1fx.OutputOwners{
+						Addrs: []ids.ShortID{{1}},
+					},
+				},
+			},
+		},
+	}}}
+	tx.Initialize(nil, []byte{1, 2, 3})
+	txID := tx.ID()
+
+	parser := NewPubSubFilterer(&tx)
+	fr, _ := parser.Filter([]pubsub.Filter{
+		&mockFilter{prefix: txID[:2]},
+		&mockFilter{prefix: []byte{^txID[0]}},
+	})
+	assert.Equal([]bool{true, false}, fr)
+}
@@ -4,6 +4,7 @@
 package avm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math"
@@ -11,13 +12,18 @@ import (
 	"strings"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/encdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -34,6 +40,17 @@ const (
 
 	// Max number of items allowed in a page
 	maxPageSize uint64 = 1024
+
+	// Max size of each tx issued by MintNFTs
+	maxMintNFTsTxSize = 64 * units.KiB
+
+	// Max size of the tx issued by SendMultiple
+	maxSendMultipleTxSize = 64 * units.KiB
+
+	// Max number of txs, and their total size, that can be passed in as
+	// argument to IssueTxs
+	maxIssueTxs     = 1024
+	maxIssueTxsSize = 2 * units.MiB
 )
 
 var (
@@ -47,9 +64,28 @@ var (
 	errInvalidMintAmount      = errors.New("amount minted must be positive")
 	errAddressesCantMintAsset = errors.New("provided addresses don't have the authority to mint the provided asset")
 	errInvalidUTXO            = errors.New("invalid utxo")
+	errAddressesCantManage    = errors.New("provided addresses don't have the authority to manage the provided asset")
+	errAssetAlreadyFrozen     = errors.New("asset is already frozen")
+	errAssetNotFrozen         = errors.New("asset isn't frozen")
+	errNoPropertyOutputs      = errors.New("asset has no propertyfx outputs")
+	errCantMintProperty       = errors.New("user doesn't control the mint output")
+	errCantBurnProperty       = errors.New("user doesn't own the property")
+	errCantPayImportFee       = errors.New("insufficient funds to pay import fee")
 	errNilTxID                = errors.New("nil transaction ID")
 	errNoAddresses            = errors.New("no addresses provided")
+	errNoNFTMints             = errors.New("no NFTs to mint")
+	errNoTxs                  = errors.New("no transactions provided")
 	errNoKeys                 = errors.New("from addresses have no keys or funds")
+	errUnknownChain           = errors.New("unknown chain")
+	errSourceChainNotInSubnet = errors.New("source chain isn't in this chain's subnet")
+	errUTXOEncoding           = errors.New("UTXOs can only be encoded as hex or cb58")
+	errStartIndexWrongChain   = errors.New("start index was returned for a different source chain")
+	errUnknownChangeStrategy  = errors.New("unknown change strategy")
+	errChangeAddrWithStrategy = errors.New("changeAddr can only be provided with the fixed change strategy")
+	errChangeAddrNotOwned     = errors.New("change address isn't controlled by the user")
+	errNoFromAddrs            = errors.New("no from addresses provided")
+	errTooManyOutputs         = errors.New("too many outputs")
+	errTxTooLarge             = errors.New("transaction is too large")
 )
 
 // Service defines the base service for the asset vm
@@ -60,6 +96,35 @@ type FormattedAssetID struct {
 	AssetID ids.ID `json:"assetID"`
 }
 
+// AssetInfo identifies an asset by its ID, along with its alias and, if the
+// asset was created by a CreateAssetTx, its symbol and denomination.
+type AssetInfo struct {
+	AssetID      ids.ID      `json:"assetID"`
+	AssetAlias   string      `json:"assetAlias,omitempty"`
+	Symbol       string      `json:"symbol,omitempty"`
+	Denomination *json.Uint8 `json:"denomination,omitempty"`
+}
+
+// getAssetInfo returns the description of [assetID] that is included in JSON
+// responses
+func (service *Service) getAssetInfo(assetID ids.ID) (AssetInfo, error) {
+	info := AssetInfo{AssetID: assetID}
+	if alias, err := service.vm.PrimaryAlias(assetID); err == nil {
+		info.AssetAlias = alias
+	}
+	desc, err := service.vm.state.GetAssetDescription(assetID)
+	switch {
+	case err == database.ErrNotFound:
+		return info, nil
+	case err != nil:
+		return info, fmt.Errorf("couldn't get description of asset %s: %w", assetID, err)
+	}
+	denomination := json.Uint8(desc.Denomination)
+	info.Symbol = desc.Symbol
+	info.Denomination = &denomination
+	return info, nil
+}
+
 // IssueTx attempts to issue a transaction into consensus
 func (service *Service) IssueTx(r *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
 	service.vm.ctx.Log.Debug("AVM: IssueTx called with %s", args.Tx)
@@ -77,6 +142,64 @@ func (service *Service) IssueTx(r *http.Request, args *api.FormattedTx, reply *a
 	return nil
 }
 
+// IssueTxsArgs are arguments for passing into IssueTxs requests
+type IssueTxsArgs struct {
+	Txs      []string            `json:"txs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// IssueTxResult is the result of issuing one of the txs of an IssueTxs request.
+// Exactly one of [TxID] and [Error] is set.
+type IssueTxResult struct {
+	TxID  *ids.ID `json:"txID,omitempty"`
+	Error string  `json:"error,omitempty"`
+}
+
+// IssueTxsReply is the response from a call to IssueTxs
+type IssueTxsReply struct {
+	// Results[i] is the result of issuing [args.Txs[i]]
+	Results []IssueTxResult `json:"results"`
+}
+
+// IssueTxs attempts to issue each of [args.Txs] into consensus, in order, so
+// that a tx can spend the outputs of the txs before it. A tx that can't be
+// issued doesn't prevent the txs after it from being issued.
+func (service *Service) IssueTxs(r *http.Request, args *IssueTxsArgs, reply *IssueTxsReply) error {
+	service.vm.ctx.Log.Debug("AVM: IssueTxs called with %d txs", len(args.Txs))
+
+	switch {
+	case len(args.Txs) == 0:
+		return errNoTxs
+	case len(args.Txs) > maxIssueTxs:
+		return fmt.Errorf("number of txs given, %d, exceeds maximum, %d", len(args.Txs), maxIssueTxs)
+	}
+
+	txsBytes := make([][]byte, len(args.Txs))
+	totalSize := 0
+	for i, txStr := range args.Txs {
+		txBytes, err := formatting.Decode(args.Encoding, txStr)
+		if err != nil {
+			return fmt.Errorf("problem decoding transaction %d: %w", i, err)
+		}
+		totalSize += len(txBytes)
+		if totalSize > maxIssueTxsSize {
+			return fmt.Errorf("size of txs given exceeds maximum, %d", maxIssueTxsSize)
+		}
+		txsBytes[i] = txBytes
+	}
+
+	reply.Results = make([]IssueTxResult, len(txsBytes))
+	for i, txBytes := range txsBytes {
+		txID, err := service.vm.IssueTx(txBytes)
+		if err != nil {
+			reply.Results[i].Error = err.Error()
+			continue
+		}
+		reply.Results[i].TxID = &txID
+	}
+	return nil
+}
+
 // GetTxStatusReply defines the GetTxStatus replies returned from the API
 type GetTxStatusReply struct {
 	Status choices.Status `json:"status"`
@@ -114,10 +237,13 @@ func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs,
 		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
 	}
 
-	// Lookup assetID
-	assetID, err := service.vm.lookupAssetID(args.AssetID)
-	if err != nil {
-		return fmt.Errorf("specified `assetID` is invalid: %w", err)
+	// Lookup assetID, defaulting to the fee asset
+	assetID := service.vm.feeAssetID
+	if len(args.AssetID) > 0 {
+		assetID, err = service.vm.lookupAssetID(args.AssetID)
+		if err != nil {
+			return fmt.Errorf("specified `assetID` is invalid: %w", err)
+		}
 	}
 
 	cursor := uint64(args.Cursor)
@@ -137,6 +263,65 @@ func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs,
 	return nil
 }
 
+type GetAssetTxsArgs struct {
+	AssetID string `json:"assetID"`
+	// Roles restricts the returned txs to those that played any of these
+	// roles for the asset. If empty, txs are returned regardless of their
+	// role.
+	Roles []string `json:"roles"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+type GetAssetTxsReply struct {
+	Txs []index.AssetTx `json:"txs"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAssetTxs returns the txs that involved a given asset, along with the
+// roles they played for it
+func (service *Service) GetAssetTxs(r *http.Request, args *GetAssetTxsArgs, reply *GetAssetTxsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetAssetTxs called with assetID=%s, roles=%v, cursor=%d, pageSize=%d", args.AssetID, args.Roles, args.Cursor, args.PageSize)
+	pageSize := uint64(args.PageSize)
+	if pageSize > maxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return fmt.Errorf("specified `assetID` is invalid: %w", err)
+	}
+
+	roles := index.AssetTxAllRoles
+	if len(args.Roles) > 0 {
+		roles = 0
+		for _, name := range args.Roles {
+			role, err := index.ParseAssetTxRole(name)
+			if err != nil {
+				return fmt.Errorf("couldn't parse argument 'roles': %w", err)
+			}
+			roles |= role
+		}
+	}
+
+	txs, cursor, err := service.vm.assetTxsIndexer.Read(assetID, roles, uint64(args.Cursor), pageSize)
+	if err != nil {
+		return err
+	}
+	service.vm.ctx.Log.Debug("Fetched %d transactions for assetID %s, cursor %d", len(txs), assetID, args.Cursor)
+
+	// To get the next set of txs, the user should provide this cursor. Txs
+	// with other roles are skipped, so it may be past the last tx returned.
+	reply.Txs = txs
+	reply.Cursor = json.Uint64(cursor)
+	return nil
+}
+
 // GetTxStatus returns the status of the specified transaction
 func (service *Service) GetTxStatus(r *http.Request, args *api.JSONTxID, reply *GetTxStatusReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetTxStatus called with %s", args.TxID)
@@ -173,8 +358,30 @@ func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.G
 	reply.Encoding = args.Encoding
 
 	if args.Encoding == formatting.JSON {
-		reply.Tx = tx
-		return tx.Init(service.vm)
+		if err := tx.Init(service.vm); err != nil {
+			return err
+		}
+		assetIDs := tx.AssetIDs().List()
+		if _, ok := tx.UnsignedTx.(*CreateAssetTx); ok {
+			assetIDs = append(assetIDs, tx.ID())
+		}
+		ids.SortIDs(assetIDs)
+		assets := make([]AssetInfo, len(assetIDs))
+		for i, assetID := range assetIDs {
+			info, err := service.getAssetInfo(assetID)
+			if err != nil {
+				return err
+			}
+			assets[i] = info
+		}
+		reply.Tx = struct {
+			txJSON
+			Assets []AssetInfo `json:"assets"`
+		}{
+			txJSON: tx.toJSON(),
+			Assets: assets,
+		}
+		return nil
 	}
 
 	var err error
@@ -196,15 +403,15 @@ func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
 	}
 
-	var sourceChain ids.ID
-	if args.SourceChain == "" {
-		sourceChain = service.vm.ctx.ChainID
-	} else {
-		chainID, err := service.vm.ctx.BCLookup.Lookup(args.SourceChain)
-		if err != nil {
-			return fmt.Errorf("problem parsing source chainID %q: %w", args.SourceChain, err)
-		}
-		sourceChain = chainID
+	switch args.Encoding {
+	case formatting.Hex, formatting.CB58:
+	default:
+		return fmt.Errorf("%w: %s", errUTXOEncoding, args.Encoding)
+	}
+
+	sourceChain, err := service.vm.lookupSourceChain(args.SourceChain)
+	if err != nil {
+		return err
 	}
 
 	addrSet := ids.ShortSet{}
@@ -216,10 +423,20 @@ func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 		addrSet.Add(addr)
 	}
 
+	// A start index returned for one source chain is meaningless for another
+	if args.StartIndex.SourceChain != "" {
+		startIndexChain, err := service.vm.lookupSourceChain(args.StartIndex.SourceChain)
+		if err != nil {
+			return err
+		}
+		if startIndexChain != sourceChain {
+			return fmt.Errorf("%w: expected %s but got %s", errStartIndexWrongChain, sourceChain, startIndexChain)
+		}
+	}
+
 	startAddr := ids.ShortEmpty
 	startUTXO := ids.Empty
 	if args.StartIndex.Address != "" || args.StartIndex.UTXO != "" {
-		var err error
 		startAddr, err = service.vm.ParseLocalAddress(args.StartIndex.Address)
 		if err != nil {
 			return fmt.Errorf("couldn't parse start index address %q: %w", args.StartIndex.Address, err)
@@ -234,7 +451,6 @@ func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 		utxos     []*avax.UTXO
 		endAddr   ids.ShortID
 		endUTXOID ids.ID
-		err       error
 	)
 	if sourceChain == service.vm.ctx.ChainID {
 		utxos, endAddr, endUTXOID, err = service.vm.getPaginatedUTXOs(
@@ -244,7 +460,7 @@ func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 			int(args.Limit),
 		)
 	} else {
-		utxos, endAddr, endUTXOID, err = service.vm.GetAtomicUTXOs(
+		utxos, endAddr, endUTXOID, err = service.vm.getPaginatedAtomicUTXOs(
 			sourceChain,
 			addrSet,
 			startAddr,
@@ -275,6 +491,7 @@ func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 
 	reply.EndIndex.Address = endAddress
 	reply.EndIndex.UTXO = endUTXOID.String()
+	reply.EndIndex.SourceChain = sourceChain.String()
 	reply.NumFetched = json.Uint64(len(utxos))
 	reply.Encoding = args.Encoding
 	return nil
@@ -302,23 +519,24 @@ func (service *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescr
 		return err
 	}
 
-	tx := &UniqueTx{
-		vm:   service.vm,
-		txID: assetID,
-	}
-	if status := tx.Status(); !status.Fetched() {
-		return errUnknownAssetID
-	}
-	createAssetTx, ok := tx.UnsignedTx.(*CreateAssetTx)
-	if !ok {
-		return errTxNotCreateAsset
+	// The descriptions of accepted assets are cached, so explorers that
+	// repeatedly describe the same assets don't re-parse their txs.
+	desc, err := service.vm.state.GetAssetDescription(assetID)
+	switch {
+	case err == database.ErrNotFound:
+		// The asset may still be processing
+		desc, err = service.vm.assetDescriptionFromTx(assetID)
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return fmt.Errorf("couldn't get description of asset %s: %w", assetID, err)
 	}
 
 	reply.AssetID = assetID
-	reply.Name = createAssetTx.Name
-	reply.Symbol = createAssetTx.Symbol
-	reply.Denomination = json.Uint8(createAssetTx.Denomination)
-
+	reply.Name = desc.Name
+	reply.Symbol = desc.Symbol
+	reply.Denomination = json.Uint8(desc.Denomination)
 	return nil
 }
 
@@ -331,8 +549,12 @@ type GetBalanceArgs struct {
 
 // GetBalanceReply defines the GetBalance replies returned from the API
 type GetBalanceReply struct {
-	Balance json.Uint64   `json:"balance"`
-	UTXOIDs []avax.UTXOID `json:"utxoIDs"`
+	AssetInfo
+	Balance json.Uint64 `json:"balance"`
+	// Unconfirmed is what [Balance] will be once the txs that were issued
+	// through this node, and are still processing, are accepted.
+	Unconfirmed json.Uint64   `json:"unconfirmed"`
+	UTXOIDs     []avax.UTXOID `json:"utxoIDs"`
 }
 
 // GetBalance returns the balance of an asset held by an address.
@@ -352,6 +574,10 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 	if err != nil {
 		return err
 	}
+	reply.AssetInfo, err = service.getAssetInfo(assetID)
+	if err != nil {
+		return err
+	}
 
 	addrSet := ids.ShortSet{}
 	addrSet.Add(addr)
@@ -361,8 +587,28 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 		return fmt.Errorf("problem retrieving UTXOs: %w", err)
 	}
 
+	balance, utxoIDs, err := service.balance(utxos, assetID, args.IncludePartial)
+	if err != nil {
+		return err
+	}
+	reply.Balance = json.Uint64(balance)
+	reply.UTXOIDs = utxoIDs
+
+	unconfirmed, _, err := service.balance(applyLocalTxs(utxos, service.vm.pendingTxs.LocalTxs(), addr), assetID, args.IncludePartial)
+	if err != nil {
+		return err
+	}
+	reply.Unconfirmed = json.Uint64(unconfirmed)
+	return nil
+}
+
+// balance returns the amount of [assetID] held in [utxos], along with the IDs
+// of the UTXOs holding it. If ![includePartial], only UTXOs that are spendable
+// by a single address, and unlocked, are counted.
+func (service *Service) balance(utxos []*avax.UTXO, assetID ids.ID, includePartial bool) (uint64, []avax.UTXOID, error) {
 	now := service.vm.clock.Unix()
-	reply.UTXOIDs = make([]avax.UTXOID, 0, len(utxos))
+	balance := uint64(0)
+	utxoIDs := make([]avax.UTXOID, 0, len(utxos))
 	for _, utxo := range utxos {
 		if utxo.AssetID() != assetID {
 			continue
@@ -373,50 +619,133 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 			continue
 		}
 		owners := transferable.OutputOwners
-		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
+		if !includePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
 			continue
 		}
-		amt, err := safemath.Add64(transferable.Amount(), uint64(reply.Balance))
+		amt, err := safemath.Add64(transferable.Amount(), balance)
 		if err != nil {
-			return err
+			return 0, nil, err
 		}
-		reply.Balance = json.Uint64(amt)
-		reply.UTXOIDs = append(reply.UTXOIDs, utxo.UTXOID)
+		balance = amt
+		utxoIDs = append(utxoIDs, utxo.UTXOID)
 	}
+	return balance, utxoIDs, nil
+}
 
-	return nil
+// applyLocalTxs returns [utxos], which reference [addr], after [txs] have been
+// applied in order. The UTXOs consumed by [txs] are removed, and the UTXOs
+// produced by [txs] that reference [addr] are added.
+func applyLocalTxs(utxos []*avax.UTXO, txs []*Tx, addr ids.ShortID) []*avax.UTXO {
+	if len(txs) == 0 {
+		return utxos
+	}
+
+	utxoMap := make(map[ids.ID]*avax.UTXO, len(utxos))
+	for _, utxo := range utxos {
+		utxoMap[utxo.InputID()] = utxo
+	}
+	for _, tx := range txs {
+		for _, inputUTXO := range tx.InputUTXOs() {
+			delete(utxoMap, inputUTXO.InputID())
+		}
+		for _, utxo := range tx.UTXOs() {
+			addressable, ok := utxo.Out.(avax.Addressable)
+			if !ok {
+				continue
+			}
+			for _, addrBytes := range addressable.Addresses() {
+				if bytes.Equal(addrBytes, addr[:]) {
+					utxoMap[utxo.InputID()] = utxo
+					break
+				}
+			}
+		}
+	}
+
+	newUTXOs := make([]*avax.UTXO, 0, len(utxoMap))
+	for _, utxo := range utxoMap {
+		newUTXOs = append(newUTXOs, utxo)
+	}
+	return newUTXOs
 }
 
 type Balance struct {
-	AssetID string      `json:"asset"`
-	Balance json.Uint64 `json:"balance"`
+	AssetID      string      `json:"asset"`
+	Symbol       string      `json:"symbol,omitempty"`
+	Denomination *json.Uint8 `json:"denomination,omitempty"`
+	Balance      json.Uint64 `json:"balance"`
+	// Locked is the portion of [Balance] that has a locktime in the future.
+	Locked json.Uint64 `json:"locked"`
 }
 
 type GetAllBalancesArgs struct {
 	api.JSONAddress
-	IncludePartial bool `json:"includePartial"`
+	// Addresses whose balances are included along with [Address]
+	Addresses      []string `json:"addresses"`
+	IncludePartial bool     `json:"includePartial"`
+	// If non-empty, only the balances of these assets are returned
+	AssetIDs []string `json:"assetIDs"`
+	// Balances are returned in order of asset ID, starting from
+	// [StartAssetID]. If [Limit] is non-zero, at most [Limit] balances are
+	// returned.
+	Limit        json.Uint32 `json:"limit"`
+	StartAssetID string      `json:"startAssetID"`
 }
 
 // GetAllBalancesReply is the response from a call to GetAllBalances
 type GetAllBalancesReply struct {
 	Balances []Balance `json:"balances"`
+	// If there are more balances to fetch, call GetAllBalances again and set
+	// [StartAssetID] to this value.
+	NextAssetID string `json:"nextAssetID,omitempty"`
 }
 
 // GetAllBalances returns a map where:
 //   Key: ID of an asset such that [args.Address] has a non-zero balance of the asset
 //   Value: The balance of the asset held by the address
-// If ![args.IncludePartial], returns only unlocked balance/UTXOs with a 1-out-of-1 multisig.
-// Otherwise, returned balance/UTXOs includes assets held only partially by the
-// address, and includes balances with locktime in the future.
+// If ![args.IncludePartial], returns only unlocked balance/UTXOs held solely
+// by the given addresses. Otherwise, returned balance/UTXOs includes assets
+// held only partially by the addresses, and includes balances with locktime
+// in the future, which are also reported as locked.
 func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs, reply *GetAllBalancesReply) error {
-	service.vm.ctx.Log.Debug("AVM: GetAllBalances called with address: %s", args.Address)
+	service.vm.ctx.Log.Debug("AVM: GetAllBalances called with address: %s addresses: %v", args.Address, args.Addresses)
 
-	address, err := service.vm.ParseLocalAddress(args.Address)
-	if err != nil {
-		return fmt.Errorf("problem parsing address '%s': %w", args.Address, err)
+	addrStrs := args.Addresses
+	if len(args.Address) > 0 {
+		addrStrs = append([]string{args.Address}, addrStrs...)
+	}
+	if len(addrStrs) == 0 {
+		return errNoAddresses
+	}
+	if len(addrStrs) > maxGetUTXOsAddrs {
+		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(addrStrs), maxGetUTXOsAddrs)
 	}
 	addrSet := ids.ShortSet{}
-	addrSet.Add(address)
+	for _, addrStr := range addrStrs {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("problem parsing address '%s': %w", addrStr, err)
+		}
+		addrSet.Add(addr)
+	}
+
+	filter := ids.Set{}
+	for _, assetStr := range args.AssetIDs {
+		assetID, err := service.vm.lookupAssetID(assetStr)
+		if err != nil {
+			return err
+		}
+		filter.Add(assetID)
+	}
+
+	startAssetID := ids.Empty
+	if len(args.StartAssetID) > 0 {
+		var err error
+		startAssetID, err = service.vm.lookupAssetID(args.StartAssetID)
+		if err != nil {
+			return fmt.Errorf("couldn't parse startAssetID: %w", err)
+		}
+	}
 
 	utxos, err := service.vm.getAllUTXOs(addrSet)
 	if err != nil {
@@ -424,49 +753,81 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 	}
 
 	now := service.vm.clock.Unix()
-	assetIDs := ids.Set{}               // IDs of assets the address has a non-zero balance of
+	assetIDs := ids.Set{}               // IDs of assets the addresses have a non-zero balance of
 	balances := make(map[ids.ID]uint64) // key: ID (as bytes). value: balance of that asset
+	locked := make(map[ids.ID]uint64)   // key: ID (as bytes). value: locked balance of that asset
 	for _, utxo := range utxos {
+		assetID := utxo.AssetID()
+		if filter.Len() > 0 && !filter.Contains(assetID) {
+			continue
+		}
+		if bytes.Compare(assetID[:], startAssetID[:]) < 0 {
+			continue
+		}
 		// TODO make this not specific to *secp256k1fx.TransferOutput
 		transferable, ok := utxo.Out.(*secp256k1fx.TransferOutput)
 		if !ok {
 			continue
 		}
 		owners := transferable.OutputOwners
-		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
+		isLocked := owners.Locktime > now
+		if !args.IncludePartial && (!ownedSolelyBy(&owners, addrSet) || isLocked) {
 			continue
 		}
-		assetID := utxo.AssetID()
 		assetIDs.Add(assetID)
-		balance := balances[assetID] // 0 if key doesn't exist
-		balance, err := safemath.Add64(transferable.Amount(), balance)
-		if err != nil {
-			balances[assetID] = math.MaxUint64
-		} else {
-			balances[assetID] = balance
+		balances[assetID] = addBalance(balances[assetID], transferable.Amount())
+		if isLocked {
+			locked[assetID] = addBalance(locked[assetID], transferable.Amount())
 		}
 	}
 
-	reply.Balances = make([]Balance, assetIDs.Len())
-	i := 0
-	for assetID := range assetIDs {
-		if alias, err := service.vm.PrimaryAlias(assetID); err == nil {
-			reply.Balances[i] = Balance{
-				AssetID: alias,
-				Balance: json.Uint64(balances[assetID]),
-			}
-		} else {
-			reply.Balances[i] = Balance{
-				AssetID: assetID.String(),
-				Balance: json.Uint64(balances[assetID]),
-			}
+	sortedAssetIDs := assetIDs.List()
+	ids.SortIDs(sortedAssetIDs)
+	if limit := int(args.Limit); limit > 0 && len(sortedAssetIDs) > limit {
+		reply.NextAssetID = sortedAssetIDs[limit].String()
+		sortedAssetIDs = sortedAssetIDs[:limit]
+	}
+
+	reply.Balances = make([]Balance, len(sortedAssetIDs))
+	for i, assetID := range sortedAssetIDs {
+		info, err := service.getAssetInfo(assetID)
+		if err != nil {
+			return err
+		}
+		reply.Balances[i] = Balance{
+			AssetID:      assetID.String(),
+			Symbol:       info.Symbol,
+			Denomination: info.Denomination,
+			Balance:      json.Uint64(balances[assetID]),
+			Locked:       json.Uint64(locked[assetID]),
+		}
+		if info.AssetAlias != "" {
+			reply.Balances[i].AssetID = info.AssetAlias
 		}
-		i++
 	}
 
 	return nil
 }
 
+// ownedSolelyBy returns true if every owner of [owners] is in [addrs].
+func ownedSolelyBy(owners *secp256k1fx.OutputOwners, addrs ids.ShortSet) bool {
+	for _, addr := range owners.Addrs {
+		if !addrs.Contains(addr) {
+			return false
+		}
+	}
+	return len(owners.Addrs) > 0
+}
+
+// addBalance returns [balance] + [amount], capped at the maximum uint64.
+func addBalance(balance, amount uint64) uint64 {
+	newBalance, err := safemath.Add64(balance, amount)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return newBalance
+}
+
 // Holder describes how much an address owns of an asset
 type Holder struct {
 	Amount  json.Uint64 `json:"amount"`
@@ -479,6 +840,12 @@ type Owners struct {
 	Minters   []string    `json:"minters"`
 }
 
+// Manager describes who manages an asset
+type Manager struct {
+	Threshold json.Uint32 `json:"threshold"`
+	Addresses []string    `json:"addresses"`
+}
+
 // CreateAssetArgs are arguments for passing into CreateAsset
 type CreateAssetArgs struct {
 	api.JSONSpendHeader           // User, password, from addrs, change addr
@@ -487,6 +854,9 @@ type CreateAssetArgs struct {
 	Denomination        byte      `json:"denomination"`
 	InitialHolders      []*Holder `json:"initialHolders"`
 	MinterSets          []Owners  `json:"minterSets"`
+	// If provided, the asset is managed, and [Manager] is allowed to freeze
+	// and unfreeze transfers of the asset
+	Manager *Manager `json:"manager,omitempty"`
 }
 
 // AssetIDChangeAddr is an asset ID and a change address
@@ -593,6 +963,23 @@ func (service *Service) CreateAsset(r *http.Request, args *CreateAssetArgs, repl
 		ids.SortShortIDs(minter.Addrs)
 		initialState.Outs = append(initialState.Outs, minter)
 	}
+	if args.Manager != nil {
+		status := &secp256k1fx.ManagedAssetStatusOutput{
+			Manager: secp256k1fx.OutputOwners{
+				Threshold: uint32(args.Manager.Threshold),
+				Addrs:     make([]ids.ShortID, 0, len(args.Manager.Addresses)),
+			},
+		}
+		for _, address := range args.Manager.Addresses {
+			addr, err := service.vm.ParseLocalAddress(address)
+			if err != nil {
+				return err
+			}
+			status.Manager.Addrs = append(status.Manager.Addrs, addr)
+		}
+		ids.SortShortIDs(status.Manager.Addrs)
+		initialState.Outs = append(initialState.Outs, status)
+	}
 	initialState.Sort(service.vm.codec)
 
 	tx := Tx{UnsignedTx: &CreateAssetTx{
@@ -763,8 +1150,16 @@ func (service *Service) CreateNFTAsset(r *http.Request, args *CreateNFTAssetArgs
 	return err
 }
 
+// CreateAddressArgs are arguments for CreateAddress
+type CreateAddressArgs struct {
+	api.UserPass
+	// If true, the address is derived from the user's HD seed rather than
+	// generated randomly
+	Derive bool `json:"derive"`
+}
+
 // CreateAddress creates an address for the user [args.Username]
-func (service *Service) CreateAddress(r *http.Request, args *api.UserPass, reply *api.JSONAddress) error {
+func (service *Service) CreateAddress(r *http.Request, args *CreateAddressArgs, reply *api.JSONAddress) error {
 	service.vm.ctx.Log.Debug("AVM: CreateAddress called for user '%s'", args.Username)
 
 	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
@@ -773,36 +1168,110 @@ func (service *Service) CreateAddress(r *http.Request, args *api.UserPass, reply
 	}
 	defer db.Close()
 
+	newAddress, err := service.newAddress(db, args.Derive)
+	if err != nil {
+		return err
+	}
+	reply.Address, err = service.vm.FormatLocalAddress(newAddress)
+	if err != nil {
+		return fmt.Errorf("problem formatting address: %w", err)
+	}
+
+	// Return an error if the DB can't close, this will execute before the above
+	// db close.
+	return db.Close()
+}
+
+// newAddress adds a new address to the user whose database is [db]. If
+// [derive] is true, its key is derived from the user's HD seed. Otherwise, its
+// key is generated randomly.
+func (service *Service) newAddress(db *encdb.Database, derive bool) (ids.ShortID, error) {
 	user := userState{vm: service.vm}
 
 	addresses, _ := user.Addresses(db)
 	if len(addresses) >= maxKeystoreAddresses {
-		return fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
+		return ids.ShortID{}, fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
 	}
 
-	factory := crypto.FactorySECP256K1R{}
-	skIntf, err := factory.NewPrivateKey()
-	if err != nil {
-		return fmt.Errorf("problem generating private key: %w", err)
-	}
-	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
+	var sk *crypto.PrivateKeySECP256K1R
+	if derive {
+		var err error
+		_, sk, err = user.DeriveKey(db)
+		if err != nil {
+			return ids.ShortID{}, fmt.Errorf("problem deriving private key: %w", err)
+		}
+	} else {
+		factory := crypto.FactorySECP256K1R{}
+		skIntf, err := factory.NewPrivateKey()
+		if err != nil {
+			return ids.ShortID{}, fmt.Errorf("problem generating private key: %w", err)
+		}
+		sk = skIntf.(*crypto.PrivateKeySECP256K1R)
 
-	if err := user.SetKey(db, sk); err != nil {
-		return fmt.Errorf("problem saving private key: %w", err)
+		if err := user.SetKey(db, sk); err != nil {
+			return ids.ShortID{}, fmt.Errorf("problem saving private key: %w", err)
+		}
 	}
 
-	addresses = append(addresses, sk.PublicKey().Address())
+	newAddress := sk.PublicKey().Address()
+	// A derived key may have already been imported
+	for _, address := range addresses {
+		if newAddress == address {
+			return newAddress, nil
+		}
+	}
 
+	addresses = append(addresses, newAddress)
 	if err := user.SetAddresses(db, addresses); err != nil {
-		return fmt.Errorf("problem saving address: %w", err)
+		return ids.ShortID{}, fmt.Errorf("problem saving address: %w", err)
+	}
+	return newAddress, nil
+}
+
+// DerivedAddress is an address derived from a user's HD seed
+type DerivedAddress struct {
+	Index   json.Uint32 `json:"index"`
+	Address string      `json:"address"`
+}
+
+// ListDerivedAddressesReply is the response from ListDerivedAddresses
+type ListDerivedAddressesReply struct {
+	Addresses []DerivedAddress `json:"addresses"`
+}
+
+// ListDerivedAddresses returns the addresses derived from the HD seed of the
+// user [args.Username], along with the index each was derived with
+func (service *Service) ListDerivedAddresses(_ *http.Request, args *api.UserPass, reply *ListDerivedAddressesReply) error {
+	service.vm.ctx.Log.Debug("AVM: ListDerivedAddresses called for user '%s'", args.Username)
+
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
 	}
-	reply.Address, err = service.vm.FormatLocalAddress(sk.PublicKey().Address())
+
+	user := userState{vm: service.vm}
+	addresses, err := user.DerivedAddresses(db)
 	if err != nil {
-		return fmt.Errorf("problem formatting address: %w", err)
+		// Drop any potential error closing the database to report the original
+		// error
+		_ = db.Close()
+		return fmt.Errorf("problem retrieving derived addresses: %w", err)
 	}
 
-	// Return an error if the DB can't close, this will execute before the above
-	// db close.
+	reply.Addresses = make([]DerivedAddress, len(addresses))
+	for i, address := range addresses {
+		addr, err := service.vm.FormatLocalAddress(address)
+		if err != nil {
+			// Drop any potential error closing the database to report the
+			// original error
+			_ = db.Close()
+			return fmt.Errorf("problem formatting address: %w", err)
+		}
+		reply.Addresses[i] = DerivedAddress{
+			Index:   json.Uint32(i),
+			Address: addr,
+		}
+	}
 	return db.Close()
 }
 
@@ -959,22 +1428,116 @@ type SendOutput struct {
 	To string `json:"to"`
 }
 
-// SendArgs are arguments for passing into Send requests
-type SendArgs struct {
-	// User, password, from addrs, change addr
-	api.JSONSpendHeader
+// The ways the change address of a tx can be selected
+const (
+	// The change goes to the provided change address, or to an address of
+	// the keys spent if no change address is provided
+	changeStrategyFixed = "fixed"
+	// The change goes to the first of the provided from addresses
+	changeStrategyFirstFrom = "firstFrom"
+	// The change goes to a new address derived from the user's HD seed
+	changeStrategyNewDerived = "newDerived"
+)
 
-	// The amount, assetID, and destination to send funds to
-	SendOutput
+// JSONChangeStrategy is the strategy used to select the change address of a
+// tx. If empty, the fixed strategy is used.
+type JSONChangeStrategy struct {
+	ChangeStrategy string `json:"changeStrategy"`
+}
 
-	// Memo field
-	Memo string `json:"memo"`
+// Verify returns an error if the strategy isn't known
+func (s JSONChangeStrategy) Verify() error {
+	switch s.ChangeStrategy {
+	case "", changeStrategyFixed, changeStrategyFirstFrom, changeStrategyNewDerived:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnknownChangeStrategy, s.ChangeStrategy)
+	}
+}
+
+// selectUserChangeAddr returns the address that the change of a tx spending from
+// the user in [header] is sent to, according to [strategy]. [defaultAddr] is
+// used if the strategy is fixed but no change address was provided. Unless a
+// new address is derived, the change address must be one of the user's.
+func (service *Service) selectUserChangeAddr(
+	header api.JSONSpendHeader,
+	strategy JSONChangeStrategy,
+	defaultAddr ids.ShortID,
+) (ids.ShortID, error) {
+	if err := strategy.Verify(); err != nil {
+		return ids.ShortID{}, err
+	}
+
+	changeAddrStr := header.ChangeAddr
+	switch strategy.ChangeStrategy {
+	case "", changeStrategyFixed:
+		if changeAddrStr == "" {
+			return defaultAddr, nil
+		}
+	case changeStrategyFirstFrom:
+		if changeAddrStr != "" {
+			return ids.ShortID{}, errChangeAddrWithStrategy
+		}
+		if len(header.From) == 0 {
+			return ids.ShortID{}, errNoFromAddrs
+		}
+		changeAddrStr = header.From[0]
+	case changeStrategyNewDerived:
+		if changeAddrStr != "" {
+			return ids.ShortID{}, errChangeAddrWithStrategy
+		}
+	}
+
+	db, err := service.vm.ctx.Keystore.GetDatabase(header.Username, header.Password)
+	if err != nil {
+		return ids.ShortID{}, fmt.Errorf("problem retrieving user %q: %w", header.Username, err)
+	}
+	defer db.Close()
+
+	if strategy.ChangeStrategy == changeStrategyNewDerived {
+		changeAddr, err := service.newAddress(db, true)
+		if err != nil {
+			return ids.ShortID{}, fmt.Errorf("problem deriving change address: %w", err)
+		}
+		return changeAddr, db.Close()
+	}
+
+	changeAddr, err := service.vm.ParseLocalAddress(changeAddrStr)
+	if err != nil {
+		return ids.ShortID{}, fmt.Errorf("couldn't parse changeAddr: %w", err)
+	}
+	user := userState{vm: service.vm}
+	// An error fetching the addresses may just mean that the user has no
+	// addresses.
+	addresses, _ := user.Addresses(db)
+	for _, address := range addresses {
+		if address == changeAddr {
+			return changeAddr, db.Close()
+		}
+	}
+	return ids.ShortID{}, fmt.Errorf("%w: %s", errChangeAddrNotOwned, changeAddrStr)
+}
+
+// SendArgs are arguments for passing into Send requests
+type SendArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// How the change address is selected
+	JSONChangeStrategy
+
+	// The amount, assetID, and destination to send funds to
+	SendOutput
+
+	// Memo field
+	Memo string `json:"memo"`
 }
 
 // SendMultipleArgs are arguments for passing into SendMultiple requests
 type SendMultipleArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
+	// How the change address is selected
+	JSONChangeStrategy
 
 	// The outputs of the transaction
 	Outputs []SendOutput `json:"outputs"`
@@ -986,22 +1549,31 @@ type SendMultipleArgs struct {
 // Send returns the ID of the newly created transaction
 func (service *Service) Send(r *http.Request, args *SendArgs, reply *api.JSONTxIDChangeAddr) error {
 	return service.SendMultiple(r, &SendMultipleArgs{
-		JSONSpendHeader: args.JSONSpendHeader,
-		Outputs:         []SendOutput{args.SendOutput},
-		Memo:            args.Memo,
+		JSONSpendHeader:    args.JSONSpendHeader,
+		JSONChangeStrategy: args.JSONChangeStrategy,
+		Outputs:            []SendOutput{args.SendOutput},
+		Memo:               args.Memo,
 	}, reply)
 }
 
-// SendMultiple sends a transaction with multiple outputs.
+// SendMultiple sends a single transaction with multiple outputs, which may be
+// of different assets. The inputs are selected to cover all of the outputs
+// together, and the fee is paid once.
 func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, reply *api.JSONTxIDChangeAddr) error {
 	service.vm.ctx.Log.Debug("AVM: SendMultiple called with username: %s", args.Username)
 
 	// Validate the memo field
 	memoBytes := []byte(args.Memo)
-	if l := len(memoBytes); l > avax.MaxMemoSize {
-		return fmt.Errorf("max memo length is %d but provided memo field is length %d", avax.MaxMemoSize, l)
+	if err := avax.VerifyMemoFieldLength(memoBytes, service.vm.maxMemoSize); err != nil {
+		return err
 	} else if len(args.Outputs) == 0 {
 		return errNoOutputs
+	} else if len(args.Outputs) > service.vm.maxSendOutputs {
+		return fmt.Errorf("%w: %d outputs were provided but the maximum is %d",
+			errTooManyOutputs,
+			len(args.Outputs),
+			service.vm.maxSendOutputs,
+		)
 	}
 
 	// Parse the from addresses
@@ -1024,7 +1596,7 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	if len(kc.Keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	changeAddr, err := service.selectUserChangeAddr(args.JSONSpendHeader, args.JSONChangeStrategy, kc.Keys[0].PublicKey().Address())
 	if err != nil {
 		return err
 	}
@@ -1122,9 +1694,26 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		Ins:          ins,
 		Memo:         memoBytes,
 	}}}
-	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
+	err = tx.SignSECP256K1Fx(service.vm.codec, keys)
+	if errors.Is(err, wrappers.ErrInsufficientLength) {
+		return fmt.Errorf("%w: sending %d outputs requires %d inputs, making the transaction larger than the codec allows. Send fewer outputs or consolidate UTXOs first",
+			errTxTooLarge,
+			len(args.Outputs),
+			len(ins),
+		)
+	}
+	if err != nil {
 		return err
 	}
+	if txSize := len(tx.Bytes()); txSize > maxSendMultipleTxSize {
+		return fmt.Errorf("%w: sending %d outputs requires %d inputs, making the transaction %d bytes but the maximum is %d bytes. Send fewer outputs or consolidate UTXOs first",
+			errTxTooLarge,
+			len(args.Outputs),
+			len(ins),
+			txSize,
+			maxSendMultipleTxSize,
+		)
+	}
 
 	txID, err := service.vm.IssueTx(tx.Bytes())
 	if err != nil {
@@ -1139,6 +1728,7 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 // MintArgs are arguments for passing into Mint requests
 type MintArgs struct {
 	api.JSONSpendHeader             // User, password, from addrs, change addr
+	JSONChangeStrategy              // How the change address is selected
 	Amount              json.Uint64 `json:"amount"`
 	AssetID             string      `json:"assetID"`
 	To                  string      `json:"to"`
@@ -1182,7 +1772,7 @@ func (service *Service) Mint(r *http.Request, args *MintArgs, reply *api.JSONTxI
 	if len(feeKc.Keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr, err := service.vm.selectChangeAddr(feeKc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	changeAddr, err := service.selectUserChangeAddr(args.JSONSpendHeader, args.JSONChangeStrategy, feeKc.Keys[0].PublicKey().Address())
 	if err != nil {
 		return err
 	}
@@ -1255,9 +1845,124 @@ func (service *Service) Mint(r *http.Request, args *MintArgs, reply *api.JSONTxI
 	return err
 }
 
+// ManagedAssetArgs are arguments for passing into FreezeAsset and
+// UnfreezeAsset requests
+type ManagedAssetArgs struct {
+	api.JSONSpendHeader        // User, password, from addrs, change addr
+	AssetID             string `json:"assetID"`
+}
+
+// FreezeAsset issues a transaction that freezes transfers of a managed asset
+func (service *Service) FreezeAsset(r *http.Request, args *ManagedAssetArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: FreezeAsset called with username: %s", args.Username)
+
+	return service.updateManagedAsset(args, true, reply)
+}
+
+// UnfreezeAsset issues a transaction that unfreezes transfers of a managed
+// asset
+func (service *Service) UnfreezeAsset(r *http.Request, args *ManagedAssetArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: UnfreezeAsset called with username: %s", args.Username)
+
+	return service.updateManagedAsset(args, false, reply)
+}
+
+func (service *Service) updateManagedAsset(args *ManagedAssetArgs, frozen bool, reply *api.JSONTxIDChangeAddr) error {
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Get the UTXOs/keys for the from addresses
+	feeUTXOs, feeKc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(feeKc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(feeKc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	amountsSpent, ins, keys, err := service.vm.Spend(
+		feeUTXOs,
+		feeKc,
+		map[ids.ID]uint64{
+			service.vm.feeAssetID: service.vm.TxFee,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	outs := []*avax.TransferableOutput{}
+	if amountSpent := amountsSpent[service.vm.feeAssetID]; amountSpent > service.vm.TxFee {
+		outs = append(outs, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: service.vm.feeAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amountSpent - service.vm.TxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			},
+		})
+	}
+
+	// Get all UTXOs/keys for the user
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, nil)
+	if err != nil {
+		return err
+	}
+
+	op, opKeys, err := service.vm.UpdateManagedAsset(utxos, kc, assetID, frozen)
+	if err != nil {
+		return err
+	}
+	keys = append(keys, opKeys)
+
+	tx := Tx{UnsignedTx: &OperationTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    service.vm.ctx.NetworkID,
+			BlockchainID: service.vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		Ops: []*Operation{op},
+	}}
+	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
 // SendNFTArgs are arguments for passing into SendNFT requests
 type SendNFTArgs struct {
 	api.JSONSpendHeader             // User, password, from addrs, change addr
+	JSONChangeStrategy              // How the change address is selected
 	AssetID             string      `json:"assetID"`
 	GroupID             json.Uint32 `json:"groupID"`
 	To                  string      `json:"to"`
@@ -1299,7 +2004,7 @@ func (service *Service) SendNFT(r *http.Request, args *SendNFTArgs, reply *api.J
 	if len(kc.Keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	changeAddr, err := service.selectUserChangeAddr(args.JSONSpendHeader, args.JSONChangeStrategy, kc.Keys[0].PublicKey().Address())
 	if err != nil {
 		return err
 	}
@@ -1489,6 +2194,377 @@ func (service *Service) MintNFT(r *http.Request, args *MintNFTArgs, reply *api.J
 	return err
 }
 
+// NFTMint is an NFT to mint in a MintNFTs request
+type NFTMint struct {
+	Payload string `json:"payload"`
+	To      string `json:"to"`
+}
+
+// MintNFTsArgs are arguments for passing into MintNFTs requests
+type MintNFTsArgs struct {
+	api.JSONSpendHeader                     // User, password, from addrs, change addr
+	AssetID             string              `json:"assetID"`
+	Mints               []NFTMint           `json:"mints"`
+	Encoding            formatting.Encoding `json:"encoding"`
+}
+
+// MintNFTsReply is the response from a call to MintNFTs
+type MintNFTsReply struct {
+	// IDs of the issued transactions, in the order they were issued
+	TxIDs []ids.ID `json:"txIDs"`
+	// Number of NFTs minted by the issued transactions. The first [NumMinted]
+	// NFTs of the request were minted.
+	NumMinted json.Uint32 `json:"numMinted"`
+	api.JSONChangeAddr
+}
+
+// MintNFTs issues OperationTxs that mint the NFTs in [args.Mints], in order.
+// As many NFTs as fit are packed into each tx, and more txs are issued until
+// every NFT is minted. If a tx can't be issued after at least one tx was
+// issued, the NFTs minted so far are reported rather than an error.
+func (service *Service) MintNFTs(r *http.Request, args *MintNFTsArgs, reply *MintNFTsReply) error {
+	service.vm.ctx.Log.Debug("AVM: MintNFTs called with username: %s", args.Username)
+
+	if len(args.Mints) == 0 {
+		return errNoNFTMints
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	payloads := make([][]byte, len(args.Mints))
+	tos := make([]ids.ShortID, len(args.Mints))
+	for i, mint := range args.Mints {
+		tos[i], err = service.vm.ParseLocalAddress(mint.To)
+		if err != nil {
+			return fmt.Errorf("problem parsing to address %q: %w", mint.To, err)
+		}
+		payloads[i], err = formatting.Decode(args.Encoding, mint.Payload)
+		if err != nil {
+			return fmt.Errorf("problem decoding payload bytes: %w", err)
+		}
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Get the UTXOs/keys for the from addresses
+	feeUTXOs, feeKc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(feeKc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(feeKc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	// Get all UTXOs/keys
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, nil)
+	if err != nil {
+		return err
+	}
+
+	reply.TxIDs = []ids.ID{}
+	for minted := 0; minted < len(payloads); {
+		tx, numMinted, err := service.buildMintNFTsTx(
+			feeUTXOs,
+			feeKc,
+			utxos,
+			kc,
+			assetID,
+			payloads[minted:],
+			tos[minted:],
+			changeAddr,
+		)
+		if err == nil {
+			_, err = service.vm.IssueTx(tx.Bytes())
+		}
+		if err != nil {
+			if len(reply.TxIDs) == 0 {
+				return fmt.Errorf("problem issuing transaction: %w", err)
+			}
+			service.vm.ctx.Log.Debug("AVM: MintNFTs stopped after minting %d of %d NFTs: %s", minted, len(payloads), err)
+			break
+		}
+
+		reply.TxIDs = append(reply.TxIDs, tx.ID())
+		minted += numMinted
+		reply.NumMinted = json.Uint32(minted)
+
+		// Later txs can't spend the UTXOs consumed by this tx, but they can
+		// spend its change.
+		feeUTXOs = replaceSpentUTXOs(feeUTXOs, tx)
+		utxos = replaceSpentUTXOs(utxos, tx)
+	}
+
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
+// buildMintNFTsTx returns a signed OperationTx that mints as many of
+// [payloads] as fit into a tx, along with the number of NFTs it mints.
+func (service *Service) buildMintNFTsTx(
+	feeUTXOs []*avax.UTXO,
+	feeKc *secp256k1fx.Keychain,
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	payloads [][]byte,
+	tos []ids.ShortID,
+	changeAddr ids.ShortID,
+) (*Tx, int, error) {
+	amountsSpent, ins, secpKeys, err := service.vm.Spend(
+		feeUTXOs,
+		feeKc,
+		map[ids.ID]uint64{
+			service.vm.feeAssetID: service.vm.TxFee,
+		},
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	outs := []*avax.TransferableOutput{}
+	if amountSpent := amountsSpent[service.vm.feeAssetID]; amountSpent > service.vm.TxFee {
+		outs = append(outs, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: service.vm.feeAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amountSpent - service.vm.TxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			},
+		})
+	}
+
+	tx := &Tx{UnsignedTx: &OperationTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    service.vm.ctx.NetworkID,
+			BlockchainID: service.vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+	}}
+	// Sign the tx without any operations to find out how much room is left
+	// for them.
+	if err := tx.SignSECP256K1Fx(service.vm.codec, secpKeys); err != nil {
+		return nil, 0, err
+	}
+
+	ops, nftKeys, err := service.vm.MintNFTs(
+		utxos,
+		kc,
+		assetID,
+		payloads,
+		tos,
+		maxMintNFTsTxSize-len(tx.Bytes()),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx = &Tx{UnsignedTx: &OperationTx{
+		BaseTx: tx.UnsignedTx.(*OperationTx).BaseTx,
+		Ops:    ops,
+	}}
+	if err := tx.SignSECP256K1Fx(service.vm.codec, secpKeys); err != nil {
+		return nil, 0, err
+	}
+	if err := tx.SignNFTFx(service.vm.codec, nftKeys); err != nil {
+		return nil, 0, err
+	}
+	return tx, len(ops), nil
+}
+
+// replaceSpentUTXOs returns [utxos] without the UTXOs consumed by [tx], and
+// with the UTXOs produced by [tx].
+func replaceSpentUTXOs(utxos []*avax.UTXO, tx *Tx) []*avax.UTXO {
+	spent := ids.Set{}
+	for _, utxoID := range tx.InputUTXOs() {
+		spent.Add(utxoID.InputID())
+	}
+
+	remaining := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !spent.Contains(utxo.InputID()) {
+			remaining = append(remaining, utxo)
+		}
+	}
+	return append(remaining, tx.UTXOs()...)
+}
+
+// MintPropertyArgs are arguments for passing into MintProperty requests
+type MintPropertyArgs struct {
+	api.JSONSpendHeader        // User, password, from addrs, change addr
+	AssetID             string `json:"assetID"`
+	To                  string `json:"to"`
+}
+
+// MintProperty issues a transaction that mints a property of [args.AssetID]
+// to [args.To], using a propertyfx mint output controlled by the user.
+func (service *Service) MintProperty(r *http.Request, args *MintPropertyArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: MintProperty called with username: %s", args.Username)
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	to, err := service.vm.ParseLocalAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
+	}
+
+	return service.issuePropertyOperations(
+		&args.JSONSpendHeader,
+		func(utxos []*avax.UTXO, kc *secp256k1fx.Keychain) ([]*Operation, [][]*crypto.PrivateKeySECP256K1R, error) {
+			op, keys, err := service.vm.MintProperty(utxos, kc, assetID, to)
+			if err != nil {
+				return nil, nil, err
+			}
+			return []*Operation{op}, [][]*crypto.PrivateKeySECP256K1R{keys}, nil
+		},
+		reply,
+	)
+}
+
+// BurnPropertyArgs are arguments for passing into BurnProperty requests
+type BurnPropertyArgs struct {
+	api.JSONSpendHeader        // User, password, from addrs, change addr
+	AssetID             string `json:"assetID"`
+}
+
+// BurnProperty issues a transaction that burns every property of
+// [args.AssetID] owned by the user.
+func (service *Service) BurnProperty(r *http.Request, args *BurnPropertyArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("AVM: BurnProperty called with username: %s", args.Username)
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	return service.issuePropertyOperations(
+		&args.JSONSpendHeader,
+		func(utxos []*avax.UTXO, kc *secp256k1fx.Keychain) ([]*Operation, [][]*crypto.PrivateKeySECP256K1R, error) {
+			return service.vm.BurnProperty(utxos, kc, assetID)
+		},
+		reply,
+	)
+}
+
+// issuePropertyOperations issues an OperationTx, paid for by the from
+// addresses in [header], with the propertyfx operations returned by [buildOps]
+// for the user's UTXOs.
+func (service *Service) issuePropertyOperations(
+	header *api.JSONSpendHeader,
+	buildOps func(utxos []*avax.UTXO, kc *secp256k1fx.Keychain) ([]*Operation, [][]*crypto.PrivateKeySECP256K1R, error),
+	reply *api.JSONTxIDChangeAddr,
+) error {
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range header.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// Get the UTXOs/keys for the from addresses
+	feeUTXOs, feeKc, err := service.vm.LoadUser(header.Username, header.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(feeKc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(feeKc.Keys[0].PublicKey().Address(), header.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	amountsSpent, ins, secpKeys, err := service.vm.Spend(
+		feeUTXOs,
+		feeKc,
+		map[ids.ID]uint64{
+			service.vm.feeAssetID: service.vm.TxFee,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	outs := []*avax.TransferableOutput{}
+	if amountSpent := amountsSpent[service.vm.feeAssetID]; amountSpent > service.vm.TxFee {
+		outs = append(outs, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: service.vm.feeAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amountSpent - service.vm.TxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{changeAddr},
+				},
+			},
+		})
+	}
+
+	// Get all UTXOs/keys for the user
+	utxos, kc, err := service.vm.LoadUser(header.Username, header.Password, nil)
+	if err != nil {
+		return err
+	}
+
+	ops, propertyKeys, err := buildOps(utxos, kc)
+	if err != nil {
+		return err
+	}
+
+	tx := Tx{UnsignedTx: &OperationTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    service.vm.ctx.NetworkID,
+			BlockchainID: service.vm.ctx.ChainID,
+			Outs:         outs,
+			Ins:          ins,
+		}},
+		Ops: ops,
+	}}
+	if err := tx.SignSECP256K1Fx(service.vm.codec, secpKeys); err != nil {
+		return err
+	}
+	if err := tx.SignPropertyFx(service.vm.codec, propertyKeys); err != nil {
+		return err
+	}
+
+	txID, err := service.vm.IssueTx(tx.Bytes())
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.TxID = txID
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	return err
+}
+
 // ImportArgs are arguments for passing into Import requests
 type ImportArgs struct {
 	// User that controls To
@@ -1499,6 +2575,12 @@ type ImportArgs struct {
 
 	// Address receiving the imported AVAX
 	To string `json:"to"`
+
+	// Address receiving the change of any funds from this chain that are
+	// spent to pay the fee. Defaults to [To].
+	api.JSONChangeAddr
+	// How the change address is selected
+	JSONChangeStrategy
 }
 
 // Import imports an asset to this chain from the P/C-Chain.
@@ -1516,6 +2598,9 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 	if err != nil {
 		return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
 	}
+	if err := args.JSONChangeStrategy.Verify(); err != nil {
+		return err
+	}
 
 	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, nil)
 	if err != nil {
@@ -1535,7 +2620,23 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 	ins := []*avax.TransferableInput{}
 	keys := [][]*crypto.PrivateKeySECP256K1R{}
 
+	// If the imported funds don't cover the fee, the rest of the fee is paid
+	// with funds from this chain. Then, all of the imported fee asset is
+	// burned, so whatever remains of it is change.
+	changeAddr := to
 	if amountSpent := amountsSpent[service.vm.feeAssetID]; amountSpent < service.vm.TxFee {
+		changeAddr, err = service.selectUserChangeAddr(
+			api.JSONSpendHeader{
+				UserPass:       args.UserPass,
+				JSONChangeAddr: args.JSONChangeAddr,
+			},
+			args.JSONChangeStrategy,
+			to,
+		)
+		if err != nil {
+			return err
+		}
+
 		var localAmountsSpent map[ids.ID]uint64
 		localAmountsSpent, ins, keys, err = service.vm.Spend(
 			utxos,
@@ -1544,6 +2645,22 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 				service.vm.feeAssetID: service.vm.TxFee - amountSpent,
 			},
 		)
+		if errors.Is(err, errInsufficientFunds) {
+			localAmounts, _, _, spendErr := service.vm.SpendAll(utxos, kc)
+			if spendErr != nil {
+				return err
+			}
+			// [localAmounts] doesn't cover the remaining fee, so this can't
+			// underflow.
+			shortfall := service.vm.TxFee - amountSpent - localAmounts[service.vm.feeAssetID]
+			return fmt.Errorf("%w: the fee is %d, but only %d was imported and %d is available on this chain, leaving a shortfall of %d",
+				errCantPayImportFee,
+				service.vm.TxFee,
+				amountSpent,
+				localAmounts[service.vm.feeAssetID],
+				shortfall,
+			)
+		}
 		if err != nil {
 			return err
 		}
@@ -1565,6 +2682,10 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 	outs := []*avax.TransferableOutput{}
 	for assetID, amount := range amountsSpent {
 		if amount > 0 {
+			owner := to
+			if assetID == service.vm.feeAssetID {
+				owner = changeAddr
+			}
 			outs = append(outs, &avax.TransferableOutput{
 				Asset: avax.Asset{ID: assetID},
 				Out: &secp256k1fx.TransferOutput{
@@ -1572,7 +2693,7 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 					OutputOwners: secp256k1fx.OutputOwners{
 						Locktime:  0,
 						Threshold: 1,
-						Addrs:     []ids.ShortID{to},
+						Addrs:     []ids.ShortID{owner},
 					},
 				},
 			})
@@ -1607,6 +2728,8 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 type ExportArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
+	// How the change address is selected
+	JSONChangeStrategy
 	// Amount of nAVAX to send
 	Amount json.Uint64 `json:"amount"`
 
@@ -1658,7 +2781,7 @@ func (service *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSO
 	if len(kc.Keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	changeAddr, err := service.selectUserChangeAddr(args.JSONSpendHeader, args.JSONChangeStrategy, kc.Keys[0].PublicKey().Address())
 	if err != nil {
 		return err
 	}
@@ -6,6 +6,7 @@ package avm
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"math/rand"
 	"strings"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/ids"
@@ -94,9 +96,14 @@ func setupWithIssuer(t *testing.T, isAVAXAsset bool) ([]byte, *VM, *Service, cha
 // 4) atomic memory to use in tests
 func setupWithKeys(t *testing.T, isAVAXAsset bool) ([]byte, *VM, *Service, *atomic.Memory, *Tx) {
 	genesisBytes, vm, s, m, tx := setup(t, isAVAXAsset)
+	importFundedKeys(t, s)
+	return genesisBytes, vm, s, m, tx
+}
 
-	// Import the initially funded private keys
-	user := userState{vm: vm}
+// importFundedKeys imports the initially funded private keys into the keystore
+// user
+func importFundedKeys(t *testing.T, s *Service) {
+	user := userState{vm: s.vm}
 	db, err := s.vm.ctx.Keystore.GetDatabase(username, password)
 	if err != nil {
 		t.Fatalf("Failed to get user database: %s", err)
@@ -112,8 +119,6 @@ func setupWithKeys(t *testing.T, isAVAXAsset bool) ([]byte, *VM, *Service, *atom
 	if err := user.SetAddresses(db, addrs); err != nil {
 		t.Fatalf("Failed to set user addresses: %s", err)
 	}
-
-	return genesisBytes, vm, s, m, tx
 }
 
 // Sample from a set of addresses and return them raw and formatted as strings.
@@ -219,6 +224,83 @@ func TestServiceIssueTx(t *testing.T) {
 	}
 }
 
+func TestServiceIssueTxs(t *testing.T) {
+	_, vm, ctx, issueTxs := setupIssueTx(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+
+	// [childTx] spends the output of [parentTx]
+	parentTx := issueTxs[1]
+	key := keys[0]
+	childTx := &Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        parentTx.ID(),
+				OutputIndex: 0,
+			},
+			Asset: avax.Asset{ID: issueTxs[0].ID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance - vm.TxFee,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{
+						0,
+					},
+				},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: issueTxs[0].ID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: startBalance - 2*vm.TxFee,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{key.PublicKey().Address()},
+				},
+			},
+		}},
+	}}}
+	err := childTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{key}})
+	assert.NoError(t, err)
+
+	txStrs := []string{}
+	for _, txBytes := range [][]byte{parentTx.Bytes(), {1, 2, 3}, childTx.Bytes()} {
+		txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
+		assert.NoError(t, err)
+		txStrs = append(txStrs, txStr)
+	}
+
+	// The invalid tx doesn't prevent the following tx from being issued
+	reply := &IssueTxsReply{}
+	err = s.IssueTxs(nil, &IssueTxsArgs{
+		Txs:      txStrs,
+		Encoding: formatting.Hex,
+	}, reply)
+	assert.NoError(t, err)
+	assert.Len(t, reply.Results, 3)
+	parentTxID, childTxID := parentTx.ID(), childTx.ID()
+	assert.Equal(t, IssueTxResult{TxID: &parentTxID}, reply.Results[0])
+	assert.Nil(t, reply.Results[1].TxID)
+	assert.NotEmpty(t, reply.Results[1].Error)
+	assert.Equal(t, IssueTxResult{TxID: &childTxID}, reply.Results[2])
+	assert.Len(t, vm.PendingTxs(), 2)
+
+	err = s.IssueTxs(nil, &IssueTxsArgs{Encoding: formatting.Hex}, &IssueTxsReply{})
+	assert.ErrorIs(t, err, errNoTxs)
+
+	err = s.IssueTxs(nil, &IssueTxsArgs{
+		Txs:      make([]string, maxIssueTxs+1),
+		Encoding: formatting.Hex,
+	}, &IssueTxsReply{})
+	assert.Error(t, err)
+}
+
 func TestServiceGetTxStatus(t *testing.T) {
 	genesisBytes, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -426,6 +508,86 @@ func TestServiceGetBalanceStrict(t *testing.T) {
 	assert.Len(t, balanceReply.UTXOIDs, 0, "should have returned 0 utxoIDs")
 }
 
+func TestServiceGetBalanceUnconfirmed(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assetID := genesisTx.ID()
+	fromAddrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	toAddrStr, err := vm.FormatLocalAddress(keys[1].PublicKey().Address())
+	assert.NoError(err)
+	getBalances := func() (*GetBalanceReply, *GetBalanceReply) {
+		fromReply := &GetBalanceReply{}
+		assert.NoError(s.GetBalance(nil, &GetBalanceArgs{
+			Address: fromAddrStr,
+			AssetID: assetID.String(),
+		}, fromReply))
+		toReply := &GetBalanceReply{}
+		assert.NoError(s.GetBalance(nil, &GetBalanceArgs{
+			Address: toAddrStr,
+			AssetID: assetID.String(),
+		}, toReply))
+		return fromReply, toReply
+	}
+	send := func(amount uint64) ids.ID {
+		reply := &api.JSONTxIDChangeAddr{}
+		assert.NoError(s.Send(nil, &SendArgs{
+			JSONSpendHeader: api.JSONSpendHeader{
+				UserPass: api.UserPass{
+					Username: username,
+					Password: password,
+				},
+				JSONFromAddrs:  api.JSONFromAddrs{From: []string{fromAddrStr}},
+				JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: fromAddrStr},
+			},
+			SendOutput: SendOutput{
+				Amount:  json.Uint64(amount),
+				AssetID: assetID.String(),
+				To:      toAddrStr,
+			},
+		}, reply))
+		return reply.TxID
+	}
+
+	// The processing tx is reflected in the unconfirmed balances
+	txID := send(10)
+	fromReply, toReply := getBalances()
+	assert.EqualValues(startBalance, fromReply.Balance)
+	assert.EqualValues(startBalance-10-testTxFee, fromReply.Unconfirmed)
+	assert.EqualValues(startBalance, toReply.Balance)
+	assert.EqualValues(startBalance+10, toReply.Unconfirmed)
+
+	// Rejecting the tx reverts the unconfirmed balances
+	tx := &UniqueTx{
+		vm:   vm,
+		txID: txID,
+	}
+	assert.NoError(tx.Reject())
+	fromReply, toReply = getBalances()
+	assert.EqualValues(startBalance, fromReply.Unconfirmed)
+	assert.EqualValues(startBalance, toReply.Unconfirmed)
+
+	// Once a tx is accepted, the balance matches the unconfirmed balance
+	txID = send(20)
+	tx = &UniqueTx{
+		vm:   vm,
+		txID: txID,
+	}
+	assert.NoError(tx.Verify())
+	assert.NoError(tx.Accept())
+	fromReply, toReply = getBalances()
+	assert.EqualValues(startBalance-20-testTxFee, fromReply.Balance)
+	assert.EqualValues(fromReply.Balance, fromReply.Unconfirmed)
+	assert.EqualValues(startBalance+20, toReply.Balance)
+	assert.EqualValues(toReply.Balance, toReply.Unconfirmed)
+}
+
 func TestServiceGetTxs(t *testing.T) {
 	_, vm, s, _, _ := setup(t, true)
 	var err error
@@ -469,6 +631,30 @@ func TestServiceGetTxs(t *testing.T) {
 	assert.Equal(t, getTxsReply.TxIDs, testTxs[10:20])
 }
 
+func TestServiceGetTxsIndexingDisabled(t *testing.T) {
+	_, vm, s, _, _ := setup(t, true)
+	var err error
+	vm.addressTxsIndexer, err = index.NewNoIndexer(vm.db, true)
+	assert.NoError(t, err)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	addrStr, err := vm.FormatLocalAddress(ids.GenerateTestShortID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getTxsArgs := &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+	}
+	err = s.GetAddressTxs(nil, getTxsArgs, &GetAddressTxsReply{})
+	assert.ErrorIs(t, err, index.ErrIndexingDisabled)
+}
+
 func TestServiceGetAllBalances(t *testing.T) {
 	_, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -662,6 +848,137 @@ func TestServiceGetAllBalances(t *testing.T) {
 	assert.Len(t, reply.Balances, 0)
 }
 
+func TestServiceGetAllBalancesMultipleAddresses(t *testing.T) {
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	addrs := []ids.ShortID{ids.GenerateTestShortID(), ids.GenerateTestShortID()}
+	addrStrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStr, err := vm.FormatLocalAddress(addr)
+		assert.NoError(t, err)
+		addrStrs[i] = addrStr
+	}
+
+	assetIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	ids.SortIDs(assetIDs)
+
+	now := vm.clock.Time()
+	utxos := []*avax.UTXO{
+		// Held by the first address
+		{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetIDs[0]},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addrs[0]},
+				},
+			},
+		},
+		// Held by the second address, but locked
+		{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetIDs[0]},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 2,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  uint64(now.Add(time.Hour).Unix()),
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addrs[1]},
+				},
+			},
+		},
+		// Held jointly by both addresses
+		{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetIDs[1]},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 3,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 2,
+					Addrs:     []ids.ShortID{addrs[0], addrs[1]},
+				},
+			},
+		},
+		// Held by the second address
+		{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetIDs[2]},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 4,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addrs[1]},
+				},
+			},
+		},
+	}
+	for _, utxo := range utxos {
+		assert.NoError(t, vm.state.PutUTXO(utxo.InputID(), utxo))
+	}
+
+	// The jointly held UTXO is held solely by the given addresses
+	reply := &GetAllBalancesReply{}
+	err := s.GetAllBalances(nil, &GetAllBalancesArgs{
+		Addresses: addrStrs,
+	}, reply)
+	assert.NoError(t, err)
+	assert.Equal(t, []Balance{
+		{AssetID: assetIDs[0].String(), Balance: 1},
+		{AssetID: assetIDs[1].String(), Balance: 3},
+		{AssetID: assetIDs[2].String(), Balance: 4},
+	}, reply.Balances)
+	assert.Empty(t, reply.NextAssetID)
+
+	// Locked outputs are reported separately
+	reply = &GetAllBalancesReply{}
+	err = s.GetAllBalances(nil, &GetAllBalancesArgs{
+		Addresses:      addrStrs,
+		IncludePartial: true,
+		Limit:          2,
+	}, reply)
+	assert.NoError(t, err)
+	assert.Equal(t, []Balance{
+		{AssetID: assetIDs[0].String(), Balance: 3, Locked: 2},
+		{AssetID: assetIDs[1].String(), Balance: 3},
+	}, reply.Balances)
+	assert.Equal(t, assetIDs[2].String(), reply.NextAssetID)
+
+	reply = &GetAllBalancesReply{}
+	err = s.GetAllBalances(nil, &GetAllBalancesArgs{
+		Addresses:      addrStrs,
+		IncludePartial: true,
+		Limit:          2,
+		StartAssetID:   assetIDs[2].String(),
+	}, reply)
+	assert.NoError(t, err)
+	assert.Equal(t, []Balance{
+		{AssetID: assetIDs[2].String(), Balance: 4},
+	}, reply.Balances)
+	assert.Empty(t, reply.NextAssetID)
+
+	// Only the requested assets are returned
+	reply = &GetAllBalancesReply{}
+	err = s.GetAllBalances(nil, &GetAllBalancesArgs{
+		JSONAddress: api.JSONAddress{Address: addrStrs[0]},
+		AssetIDs:    []string{assetIDs[0].String(), assetIDs[2].String()},
+	}, reply)
+	assert.NoError(t, err)
+	assert.Equal(t, []Balance{
+		{AssetID: assetIDs[0].String(), Balance: 1},
+	}, reply.Balances)
+
+	err = s.GetAllBalances(nil, &GetAllBalancesArgs{}, &GetAllBalancesReply{})
+	assert.ErrorIs(t, err, errNoAddresses)
+}
+
 func TestServiceGetTx(t *testing.T) {
 	_, vm, s, _, genesisTx := setup(t, true)
 	defer func() {
@@ -731,8 +1048,10 @@ func TestServiceGetTxJSON_BaseTx(t *testing.T) {
 	assert.NoError(t, err)
 	jsonString := string(jsonTxBytes)
 	// fxID in the VM is really set to 11111111111111111111111111111111LpoYY for [secp256k1fx.TransferOutput]
+	assert.Contains(t, jsonString, "\"type\":\"base\"")
+	assert.Contains(t, jsonString, "\"credentialCount\":1")
 	assert.Contains(t, jsonString, "\"memo\":\"0x0102030405060708\"")
-	assert.Contains(t, jsonString, "\"inputs\":[{\"txID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"outputIndex\":2,\"assetID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"fxID\":\"11111111111111111111111111111111LpoYY\",\"input\":{\"amount\":50000,\"signatureIndices\":[0]}}]")
+	assert.Contains(t, jsonString, "\"inputs\":[{\"txID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"outputIndex\":2,\"assetID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"fxID\":\"11111111111111111111111111111111LpoYY\",\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"input\":{\"amount\":50000,\"signatureIndices\":[0]}}]")
 	assert.Contains(t, jsonString, "\"outputs\":[{\"assetID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"fxID\":\"11111111111111111111111111111111LpoYY\",\"output\":{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"amount\":49000,\"locktime\":0,\"threshold\":1}}]")
 }
 
@@ -779,7 +1098,7 @@ func TestServiceGetTxJSON_ExportTx(t *testing.T) {
 	assert.NoError(t, err)
 	jsonString := string(jsonTxBytes)
 	// fxID in the VM is really set to 11111111111111111111111111111111LpoYY for [secp256k1fx.TransferOutput]
-	assert.Contains(t, jsonString, "\"inputs\":[{\"txID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"outputIndex\":2,\"assetID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"fxID\":\"11111111111111111111111111111111LpoYY\",\"input\":{\"amount\":50000,\"signatureIndices\":[0]}}]")
+	assert.Contains(t, jsonString, "\"inputs\":[{\"txID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"outputIndex\":2,\"assetID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"fxID\":\"11111111111111111111111111111111LpoYY\",\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"input\":{\"amount\":50000,\"signatureIndices\":[0]}}]")
 	assert.Contains(t, jsonString, "\"exportedOutputs\":[{\"assetID\":\"2XGxUr7VF7j1iwUp2aiGe4b6Ue2yyNghNS1SuNTNmZ77dPpXFZ\",\"fxID\":\"11111111111111111111111111111111LpoYY\",\"output\":{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"amount\":49000,\"locktime\":0,\"threshold\":1}}]}")
 }
 
@@ -870,7 +1189,11 @@ func TestServiceGetTxJSON_CreateAssetTx(t *testing.T) {
 
 	// contains the address in the right format
 	assert.Contains(t, jsonString, "\"outputs\":[{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"groupID\":1,\"locktime\":0,\"threshold\":1},{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"groupID\":2,\"locktime\":0,\"threshold\":1}]}")
-	assert.Contains(t, jsonString, "\"initialStates\":[{\"fxIndex\":0,\"fxID\":\"LUC1cmcxnfNR9LdkACS2ccGKLEK7SYqB4gLLTycQfg1koyfSq\",\"outputs\":[{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"locktime\":0,\"threshold\":1},{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"locktime\":0,\"threshold\":1}]},{\"fxIndex\":1,\"fxID\":\"TtF4d2QWbk5vzQGTEPrN48x6vwgAoAmKQ9cbp79inpQmcRKES\",\"outputs\":[{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"groupID\":1,\"locktime\":0,\"threshold\":1},{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"groupID\":2,\"locktime\":0,\"threshold\":1}]},{\"fxIndex\":2,\"fxID\":\"2mcwQKiD8VEspmMJpL1dc7okQQ5dDVAWeCBZ7FWBFAbxpv3t7w\",\"outputs\":[{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"locktime\":0,\"threshold\":1},{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"locktime\":0,\"threshold\":1}]}]},\"credentials\":[]}")
+	assert.Contains(t, jsonString, "\"initialStates\":[{\"fxIndex\":0,\"fxID\":\"LUC1cmcxnfNR9LdkACS2ccGKLEK7SYqB4gLLTycQfg1koyfSq\",\"outputs\":[{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"locktime\":0,\"threshold\":1},{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"locktime\":0,\"threshold\":1}]},{\"fxIndex\":1,\"fxID\":\"TtF4d2QWbk5vzQGTEPrN48x6vwgAoAmKQ9cbp79inpQmcRKES\",\"outputs\":[{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"groupID\":1,\"locktime\":0,\"threshold\":1},{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"groupID\":2,\"locktime\":0,\"threshold\":1}]},{\"fxIndex\":2,\"fxID\":\"2mcwQKiD8VEspmMJpL1dc7okQQ5dDVAWeCBZ7FWBFAbxpv3t7w\",\"outputs\":[{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"locktime\":0,\"threshold\":1},{\"addresses\":[\"X-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e\"],\"locktime\":0,\"threshold\":1}]}]},\"credentials\":[]")
+	assert.Contains(t, jsonString, "\"type\":\"create_asset\"")
+	assert.Contains(t, jsonString, "\"credentialCount\":0")
+	// the created asset is listed, but isn't described until it is accepted
+	assert.Contains(t, jsonString, fmt.Sprintf("\"assets\":[{\"assetID\":\"%s\"}]", txID))
 }
 
 func TestServiceGetTxJSON_OperationTxWithNftxMintOp(t *testing.T) {
@@ -1653,13 +1976,13 @@ func buildCreateAssetTx(key *crypto.PrivateKeySECP256K1R) *Tx {
 				&propertyfx.MintOutput{
 					OutputOwners: secp256k1fx.OutputOwners{
 						Threshold: 1,
-						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+						Addrs:     []ids.ShortID{key.PublicKey().Address()},
 					},
 				},
 				&propertyfx.MintOutput{
 					OutputOwners: secp256k1fx.OutputOwners{
 						Threshold: 1,
-						Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+						Addrs:     []ids.ShortID{key.PublicKey().Address()},
 					},
 				},
 			},
@@ -2044,6 +2367,127 @@ func TestServiceGetUTXOs(t *testing.T) {
 	}
 }
 
+func TestServiceGetUTXOsSourceChain(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, m, _ := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	rawAddr := ids.GenerateTestShortID()
+	xAddr, err := vm.FormatLocalAddress(rawAddr)
+	assert.NoError(err)
+
+	// Put UTXOs into both this chain's UTXO set and into shared memory
+	numUTXOs := 5
+	elems := make([]*atomic.Element, numUTXOs)
+	newUTXO := func() *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{rawAddr},
+				},
+			},
+		}
+	}
+	for i := range elems {
+		utxo := newUTXO()
+		assert.NoError(vm.state.PutUTXO(utxo.InputID(), utxo))
+
+		utxo = newUTXO()
+		utxoBytes, err := vm.codec.Marshal(codecVersion, utxo)
+		assert.NoError(err)
+		utxoID := utxo.InputID()
+		elems[i] = &atomic.Element{
+			Key:    utxoID[:],
+			Value:  utxoBytes,
+			Traits: [][]byte{rawAddr.Bytes()},
+		}
+	}
+	sm := m.NewSharedMemory(platformChainID)
+	assert.NoError(sm.Apply(map[ids.ID]*atomic.Requests{vm.ctx.ChainID: {PutRequests: elems}}))
+
+	// Page through the UTXOs one at a time
+	getAll := func(sourceChain string, encoding formatting.Encoding) (ids.Set, api.Index) {
+		utxoIDs := ids.Set{}
+		args := &api.GetUTXOsArgs{
+			Addresses:   []string{xAddr},
+			SourceChain: sourceChain,
+			Limit:       1,
+			Encoding:    encoding,
+		}
+		for i := 0; i < 3*numUTXOs; i++ {
+			reply := &api.GetUTXOsReply{}
+			assert.NoError(s.GetUTXOs(nil, args, reply))
+			assert.Equal(encoding, reply.Encoding)
+			if len(reply.UTXOs) == 0 {
+				return utxoIDs, reply.EndIndex
+			}
+			for _, utxoStr := range reply.UTXOs {
+				utxoBytes, err := formatting.Decode(encoding, utxoStr)
+				assert.NoError(err)
+				utxo := &avax.UTXO{}
+				_, err = vm.codec.Unmarshal(utxoBytes, utxo)
+				assert.NoError(err)
+				utxoIDs.Add(utxo.InputID())
+			}
+			args.StartIndex = reply.EndIndex
+		}
+		t.Fatal("didn't finish paging")
+		return nil, api.Index{}
+	}
+
+	xUTXOs, xIndex := getAll("", formatting.CB58)
+	assert.Equal(numUTXOs, xUTXOs.Len())
+	assert.Equal(vm.ctx.ChainID.String(), xIndex.SourceChain)
+
+	// The P-chain can be referenced by its alias or its ID
+	pUTXOs, pIndex := getAll("P", formatting.Hex)
+	assert.Equal(numUTXOs, pUTXOs.Len())
+	assert.Equal(platformChainID.String(), pIndex.SourceChain)
+	pUTXOs.Difference(xUTXOs)
+	assert.Equal(numUTXOs, pUTXOs.Len())
+
+	pUTXOs, _ = getAll(platformChainID.String(), formatting.CB58)
+	assert.Equal(numUTXOs, pUTXOs.Len())
+
+	// A start index can't be used to page through a different chain
+	err = s.GetUTXOs(nil, &api.GetUTXOsArgs{
+		Addresses:   []string{xAddr},
+		SourceChain: "P",
+		StartIndex:  xIndex,
+	}, &api.GetUTXOsReply{})
+	assert.ErrorIs(err, errStartIndexWrongChain)
+
+	err = s.GetUTXOs(nil, &api.GetUTXOsArgs{
+		Addresses:   []string{xAddr},
+		SourceChain: ids.GenerateTestID().String(),
+	}, &api.GetUTXOsReply{})
+	assert.ErrorIs(err, errUnknownChain)
+
+	otherChainID := ids.GenerateTestID()
+	vm.ctx.SNLookup.(*snLookup).chainsToSubnet[otherChainID] = ids.GenerateTestID()
+	err = s.GetUTXOs(nil, &api.GetUTXOsArgs{
+		Addresses:   []string{xAddr},
+		SourceChain: otherChainID.String(),
+	}, &api.GetUTXOsReply{})
+	assert.ErrorIs(err, errSourceChainNotInSubnet)
+
+	err = s.GetUTXOs(nil, &api.GetUTXOsArgs{
+		Addresses: []string{xAddr},
+		Encoding:  formatting.JSON,
+	}, &api.GetUTXOsReply{})
+	assert.ErrorIs(err, errUTXOEncoding)
+}
+
 func TestGetAssetDescription(t *testing.T) {
 	_, vm, s, _, genesisTx := setup(t, true)
 	defer func() {
@@ -2071,6 +2515,101 @@ func TestGetAssetDescription(t *testing.T) {
 	}
 }
 
+func TestGetAssetDescriptionProcessing(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, vm, _ := GenesisVMWithArgs(t, []*common.Fx{{
+		ID: ids.Empty.Prefix(2),
+		Fx: &propertyfx.Fx{},
+	}}, nil)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+	// Allow the asset to be created without spending any funds
+	vm.CreateAssetTxFee = 0
+
+	createAssetTx := newAvaxCreateAssetTxWithOutputs(t, vm)
+	txID, err := vm.IssueTx(createAssetTx.Bytes())
+	assert.NoError(err)
+
+	// The asset can be described before it is accepted
+	reply := GetAssetDescriptionReply{}
+	assert.NoError(s.GetAssetDescription(nil, &GetAssetDescriptionArgs{
+		AssetID: txID.String(),
+	}, &reply))
+	assert.Equal(txID, reply.AssetID)
+	assert.Equal(createAssetTx.UnsignedTx.(*CreateAssetTx).Name, reply.Name)
+
+	// Accepting the asset replaces the cached lookup miss
+	tx, err := vm.GetTx(txID)
+	assert.NoError(err)
+	assert.NoError(tx.Accept())
+	desc, err := vm.state.GetAssetDescription(txID)
+	assert.NoError(err)
+	assert.Equal(reply.Symbol, desc.Symbol)
+
+	assert.ErrorIs(s.GetAssetDescription(nil, &GetAssetDescriptionArgs{
+		AssetID: ids.GenerateTestID().String(),
+	}, &reply), errUnknownAssetID)
+}
+
+func TestServiceAssetSymbols(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	avaxAssetID := genesisTx.ID()
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+
+	// The asset can be referenced by its symbol
+	reply := GetBalanceReply{}
+	assert.NoError(s.GetBalance(nil, &GetBalanceArgs{
+		Address: addrStr,
+		AssetID: "SYMB",
+	}, &reply))
+	assert.Equal(avaxAssetID, reply.AssetID)
+	assert.Equal("asset1", reply.AssetAlias)
+	assert.Equal("SYMB", reply.Symbol)
+	assert.NotNil(reply.Denomination)
+	assert.EqualValues(0, *reply.Denomination)
+	assert.EqualValues(startBalance, reply.Balance)
+
+	allReply := GetAllBalancesReply{}
+	assert.NoError(s.GetAllBalances(nil, &GetAllBalancesArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		AssetIDs:    []string{"SYMB"},
+	}, &allReply))
+	assert.Len(allReply.Balances, 1)
+	assert.Equal("asset1", allReply.Balances[0].AssetID)
+	assert.Equal("SYMB", allReply.Balances[0].Symbol)
+
+	// Once another asset has the same symbol, the symbol is ambiguous
+	assert.NoError(vm.state.PutAssetDescription(ids.GenerateTestID(), &AssetDescription{Symbol: "SYMB"}))
+	err = s.GetBalance(nil, &GetBalanceArgs{
+		Address: addrStr,
+		AssetID: "SYMB",
+	}, &GetBalanceReply{})
+	assert.ErrorIs(err, errAmbiguousAssetAlias)
+	assert.Contains(err.Error(), avaxAssetID.String())
+
+	// Aliases and IDs are still unambiguous
+	assert.NoError(s.GetBalance(nil, &GetBalanceArgs{
+		Address: addrStr,
+		AssetID: "asset1",
+	}, &GetBalanceReply{}))
+	assert.NoError(s.GetBalance(nil, &GetBalanceArgs{
+		Address: addrStr,
+		AssetID: avaxAssetID.String(),
+	}, &GetBalanceReply{}))
+}
+
 func TestGetBalance(t *testing.T) {
 	_, vm, s, _, genesisTx := setup(t, true)
 	defer func() {
@@ -2389,8 +2928,8 @@ func TestNFTWorkflow(t *testing.T) {
 	}
 }
 
-func TestImportExportKey(t *testing.T) {
-	_, vm, s, _, _ := setup(t, true)
+func TestServiceMintNFTs(t *testing.T) {
+	_, vm, s, _, _ := setupWithKeys(t, true)
 	defer func() {
 		if err := vm.Shutdown(); err != nil {
 			t.Fatal(err)
@@ -2398,43 +2937,491 @@ func TestImportExportKey(t *testing.T) {
 		vm.ctx.Lock.Unlock()
 	}()
 
-	factory := crypto.FactorySECP256K1R{}
-	skIntf, err := factory.NewPrivateKey()
-	if err != nil {
-		t.Fatalf("problem generating private key: %s", err)
-	}
-	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
-
-	privKeyStr, err := formatting.EncodeWithChecksum(formatting.CB58, sk.Bytes())
-	if err != nil {
-		t.Fatal(err)
-	}
-	importArgs := &ImportKeyArgs{
+	_, fromAddrsStr := sampleAddrs(t, vm, addrs)
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(t, err)
+	spendHeader := api.JSONSpendHeader{
 		UserPass: api.UserPass{
 			Username: username,
 			Password: password,
 		},
-		PrivateKey: constants.SecretKeyPrefix + privKeyStr,
+		JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: fromAddrsStr[0]},
+	}
+
+	// Each minter set results in a mint output that can be used to mint a
+	// single NFT.
+	numGroups := 80
+	minterSets := make([]Owners, numGroups)
+	for i := range minterSets {
+		minterSets[i] = Owners{
+			Threshold: 1,
+			Minters:   []string{addrStr},
+		}
 	}
-	importReply := &api.JSONAddress{}
-	if err = s.ImportKey(nil, importArgs, importReply); err != nil {
-		t.Fatal(err)
+	createReply := &AssetIDChangeAddr{}
+	err = s.CreateNFTAsset(nil, &CreateNFTAssetArgs{
+		JSONSpendHeader: spendHeader,
+		Name:            "BIG COIN",
+		Symbol:          "COIN",
+		MinterSets:      minterSets,
+	}, createReply)
+	assert.NoError(t, err)
+	createNFTTx := UniqueTx{
+		vm:   vm,
+		txID: createReply.AssetID,
 	}
+	assert.NoError(t, createNFTTx.Accept())
 
-	addrStr, err := vm.FormatLocalAddress(sk.PublicKey().Address())
-	if err != nil {
-		t.Fatal(err)
-	}
-	exportArgs := &ExportKeyArgs{
-		UserPass: api.UserPass{
-			Username: username,
-			Password: password,
-		},
-		Address: addrStr,
+	_, _, err = vm.MintNFTs(nil, nil, createReply.AssetID, [][]byte{{1}}, []ids.ShortID{ids.ShortEmpty}, maxMintNFTsTxSize)
+	assert.ErrorIs(t, err, errAddressesCantMintAsset)
+
+	// Request more NFTs than there are mint outputs, with payloads large
+	// enough that they can't all fit into a single tx.
+	payload, err := formatting.EncodeWithChecksum(formatting.Hex, make([]byte, nftfx.MaxPayloadSize))
+	assert.NoError(t, err)
+	mints := make([]NFTMint, numGroups+10)
+	for i := range mints {
+		mints[i] = NFTMint{
+			Payload: payload,
+			To:      addrStr,
+		}
 	}
-	exportReply := &ExportKeyReply{}
-	if err = s.ExportKey(nil, exportArgs, exportReply); err != nil {
-		t.Fatal(err)
+	mintReply := &MintNFTsReply{}
+	err = s.MintNFTs(nil, &MintNFTsArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         createReply.AssetID.String(),
+		Mints:           mints,
+		Encoding:        formatting.Hex,
+	}, mintReply)
+	assert.NoError(t, err)
+	assert.Equal(t, numGroups, int(mintReply.NumMinted))
+	assert.Len(t, mintReply.TxIDs, 2)
+	assert.Equal(t, fromAddrsStr[0], mintReply.ChangeAddr)
+
+	numOps := 0
+	for _, txID := range mintReply.TxIDs {
+		tx := UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		assert.Equal(t, choices.Processing, tx.Status())
+		assert.LessOrEqual(t, len(tx.Bytes()), maxMintNFTsTxSize)
+		numOps += len(tx.UnsignedTx.(*OperationTx).Ops)
+	}
+	assert.Equal(t, numGroups, numOps)
+
+	err = s.MintNFTs(nil, &MintNFTsArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         createReply.AssetID.String(),
+	}, &MintNFTsReply{})
+	assert.ErrorIs(t, err, errNoNFTMints)
+}
+
+func TestServiceFreezeAsset(t *testing.T) {
+	_, vm, s, _, _ := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// The created asset is held by, and managed by, [addrStr], so it must be
+	// one of the addresses spent from
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(t, err)
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStr}},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStr},
+	}
+	acceptTx := func(txID ids.ID) {
+		tx := UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		assert.NoError(t, tx.Verify())
+		assert.NoError(t, tx.Accept())
+	}
+
+	createReply := &AssetIDChangeAddr{}
+	err = s.CreateAsset(nil, &CreateAssetArgs{
+		JSONSpendHeader: spendHeader,
+		Name:            "STABLE COIN",
+		Symbol:          "USD",
+		InitialHolders: []*Holder{{
+			Amount:  1000,
+			Address: addrStr,
+		}},
+		Manager: &Manager{
+			Threshold: 1,
+			Addresses: []string{addrStr},
+		},
+	}, createReply)
+	assert.NoError(t, err)
+	acceptTx(createReply.AssetID)
+	assetID := createReply.AssetID
+	isFrozen := func() bool {
+		utxoID, err := vm.state.GetAssetStatus(assetID)
+		assert.NoError(t, err)
+		utxo, err := vm.state.GetUTXO(utxoID)
+		assert.NoError(t, err)
+		return utxo.Out.(*secp256k1fx.ManagedAssetStatusOutput).Frozen
+	}
+	assert.False(t, isFrozen())
+
+	sendArgs := &SendArgs{
+		JSONSpendHeader: spendHeader,
+		SendOutput: SendOutput{
+			Amount:  10,
+			AssetID: assetID.String(),
+			To:      addrStr,
+		},
+	}
+	sendReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(t, s.Send(nil, sendArgs, sendReply))
+
+	// The transfer doesn't consume the status of the asset, so it doesn't
+	// conflict with updates of the status
+	statusUTXOID, err := vm.state.GetAssetStatus(assetID)
+	assert.NoError(t, err)
+	sendTx := UniqueTx{
+		vm:   vm,
+		txID: sendReply.TxID,
+	}
+	assert.NotContains(t, sendTx.InputIDs(), statusUTXOID)
+	acceptTx(sendReply.TxID)
+
+	err = s.UnfreezeAsset(nil, &ManagedAssetArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+	}, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(t, err, errAssetNotFrozen)
+
+	freezeReply := &api.JSONTxIDChangeAddr{}
+	err = s.FreezeAsset(nil, &ManagedAssetArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+	}, freezeReply)
+	assert.NoError(t, err)
+	acceptTx(freezeReply.TxID)
+
+	assert.True(t, isFrozen())
+
+	err = s.FreezeAsset(nil, &ManagedAssetArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+	}, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(t, err, errAssetAlreadyFrozen)
+
+	// Transfers of the frozen asset are rejected, but other assets, including
+	// the fee asset, can still be transferred
+	err = s.Send(nil, sendArgs, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(t, err, errFrozenAsset)
+
+	sendArgs.AssetID = vm.feeAssetID.String()
+	assert.NoError(t, s.Send(nil, sendArgs, sendReply))
+	acceptTx(sendReply.TxID)
+
+	unfreezeReply := &api.JSONTxIDChangeAddr{}
+	err = s.UnfreezeAsset(nil, &ManagedAssetArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+	}, unfreezeReply)
+	assert.NoError(t, err)
+	acceptTx(unfreezeReply.TxID)
+
+	assert.False(t, isFrozen())
+
+	sendArgs.AssetID = assetID.String()
+	assert.NoError(t, s.Send(nil, sendArgs, sendReply))
+}
+
+func TestServiceManagedAssetConcurrentTransfers(t *testing.T) {
+	_, vm, s, _, _ := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	addrStrs := make([]string, 2)
+	for i := range addrStrs {
+		addrStr, err := vm.FormatLocalAddress(keys[i].PublicKey().Address())
+		assert.NoError(t, err)
+		addrStrs[i] = addrStr
+	}
+	spendHeader := func(addrStr string) api.JSONSpendHeader {
+		return api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStr}},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStr},
+		}
+	}
+	acceptTx := func(txID ids.ID) {
+		tx := UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		assert.NoError(t, tx.Verify())
+		assert.NoError(t, tx.Accept())
+	}
+
+	createReply := &AssetIDChangeAddr{}
+	err := s.CreateAsset(nil, &CreateAssetArgs{
+		JSONSpendHeader: spendHeader(addrStrs[0]),
+		Name:            "STABLE COIN",
+		Symbol:          "USD",
+		InitialHolders: []*Holder{
+			{
+				Amount:  1000,
+				Address: addrStrs[0],
+			},
+			{
+				Amount:  1000,
+				Address: addrStrs[1],
+			},
+		},
+		Manager: &Manager{
+			Threshold: 1,
+			Addresses: []string{addrStrs[0]},
+		},
+	}, createReply)
+	assert.NoError(t, err)
+	acceptTx(createReply.AssetID)
+
+	// Both holders transfer the asset before either transfer is accepted
+	txIDs := make([]ids.ID, len(addrStrs))
+	for i, addrStr := range addrStrs {
+		sendReply := &api.JSONTxIDChangeAddr{}
+		err := s.Send(nil, &SendArgs{
+			JSONSpendHeader: spendHeader(addrStr),
+			SendOutput: SendOutput{
+				Amount:  10,
+				AssetID: createReply.AssetID.String(),
+				To:      addrStrs[(i+1)%len(addrStrs)],
+			},
+		}, sendReply)
+		assert.NoError(t, err)
+		txIDs[i] = sendReply.TxID
+	}
+
+	// The transfers don't conflict, so both can be accepted
+	inputIDs := ids.Set{}
+	for _, txID := range txIDs {
+		tx := UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		for _, inputID := range tx.InputIDs() {
+			assert.False(t, inputIDs.Contains(inputID))
+			inputIDs.Add(inputID)
+		}
+	}
+	for _, txID := range txIDs {
+		acceptTx(txID)
+	}
+}
+
+func TestServiceMintBurnProperty(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, vm, _ := GenesisVMWithArgs(t, []*common.Fx{{
+		ID: ids.Empty.Prefix(2),
+		Fx: &propertyfx.Fx{},
+	}}, nil)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+	importFundedKeys(t, s)
+	// Allow the property assets to be created without spending any funds
+	vm.CreateAssetTxFee = 0
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		JSONFromAddrs:  api.JSONFromAddrs{From: []string{addrStr}},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStr},
+	}
+	acceptTx := func(txID ids.ID) {
+		tx := UniqueTx{
+			vm:   vm,
+			txID: txID,
+		}
+		assert.NoError(tx.Verify())
+		assert.NoError(tx.Accept())
+	}
+
+	createAssetTx := newAvaxCreateAssetTxWithOutputs(t, vm)
+	assetID, err := vm.IssueTx(createAssetTx.Bytes())
+	assert.NoError(err)
+	acceptTx(assetID)
+
+	mintReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.MintProperty(nil, &MintPropertyArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+		To:              addrStr,
+	}, mintReply))
+	acceptTx(mintReply.TxID)
+
+	burnReply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.BurnProperty(nil, &BurnPropertyArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+	}, burnReply))
+	acceptTx(burnReply.TxID)
+
+	// The property was burned, so there's nothing left to burn
+	err = s.BurnProperty(nil, &BurnPropertyArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         assetID.String(),
+	}, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(err, errCantBurnProperty)
+
+	// Assets without propertyfx outputs can't be minted as properties
+	err = s.MintProperty(nil, &MintPropertyArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         vm.feeAssetID.String(),
+		To:              addrStr,
+	}, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(err, errNoPropertyOutputs)
+
+	// The user must control the mint output
+	factory := crypto.FactorySECP256K1R{}
+	skIntf, err := factory.NewPrivateKey()
+	assert.NoError(err)
+	otherCreateAssetTx := buildCreateAssetTx(skIntf.(*crypto.PrivateKeySECP256K1R))
+	assert.NoError(otherCreateAssetTx.SignSECP256K1Fx(vm.codec, nil))
+	otherAssetID, err := vm.IssueTx(otherCreateAssetTx.Bytes())
+	assert.NoError(err)
+	acceptTx(otherAssetID)
+
+	err = s.MintProperty(nil, &MintPropertyArgs{
+		JSONSpendHeader: spendHeader,
+		AssetID:         otherAssetID.String(),
+		To:              addrStr,
+	}, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(err, errCantMintProperty)
+}
+
+func TestServiceCreateManagedAssetBeforeApricotPhase6(t *testing.T) {
+	_, vm, s, _, _ := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.ApricotPhase6Time = vm.clock.Time().Add(time.Hour)
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(t, err)
+	err = s.CreateAsset(nil, &CreateAssetArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs: api.JSONFromAddrs{From: []string{addrStr}},
+		},
+		Name:   "STABLE COIN",
+		Symbol: "USD",
+		InitialHolders: []*Holder{{
+			Amount:  1000,
+			Address: addrStr,
+		}},
+		Manager: &Manager{
+			Threshold: 1,
+			Addresses: []string{addrStr},
+		},
+	}, &AssetIDChangeAddr{})
+	assert.ErrorIs(t, err, errManagedAssetsNotEnabled)
+}
+
+func TestServiceFreezeAssetNotManaged(t *testing.T) {
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	_, fromAddrsStr := sampleAddrs(t, vm, addrs)
+	err := s.FreezeAsset(nil, &ManagedAssetArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs: api.JSONFromAddrs{From: fromAddrsStr},
+		},
+		AssetID: genesisTx.ID().String(),
+	}, &api.JSONTxIDChangeAddr{})
+	assert.ErrorIs(t, err, errAddressesCantManage)
+}
+
+func TestImportExportKey(t *testing.T) {
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	factory := crypto.FactorySECP256K1R{}
+	skIntf, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("problem generating private key: %s", err)
+	}
+	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
+
+	privKeyStr, err := formatting.EncodeWithChecksum(formatting.CB58, sk.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	importArgs := &ImportKeyArgs{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		PrivateKey: constants.SecretKeyPrefix + privKeyStr,
+	}
+	importReply := &api.JSONAddress{}
+	if err = s.ImportKey(nil, importArgs, importReply); err != nil {
+		t.Fatal(err)
+	}
+
+	addrStr, err := vm.FormatLocalAddress(sk.PublicKey().Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exportArgs := &ExportKeyArgs{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		Address: addrStr,
+	}
+	exportReply := &ExportKeyReply{}
+	if err = s.ExportKey(nil, exportArgs, exportReply); err != nil {
+		t.Fatal(err)
 	}
 
 	if !strings.HasPrefix(exportReply.PrivateKey, constants.SecretKeyPrefix) {
@@ -2534,7 +3521,7 @@ func TestSend(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	changeAddrStr, err := vm.FormatLocalAddress(keys[1].PublicKey().Address())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2573,6 +3560,44 @@ func TestSend(t *testing.T) {
 	}
 }
 
+func TestSendMemoTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	vm.maxMemoSize = 4
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+
+	args := &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+		},
+		SendOutput: SendOutput{
+			Amount:  500,
+			AssetID: genesisTx.ID().String(),
+			To:      addrStr,
+		},
+		Memo: "hello",
+	}
+	reply := &api.JSONTxIDChangeAddr{}
+	err = s.Send(nil, args, reply)
+	assert.ErrorIs(err, avax.ErrMemoTooLarge)
+	assert.Contains(err.Error(), "memo is 5 bytes but the maximum is 4 bytes")
+	assert.Empty(vm.txs)
+
+	args.Memo = "hi"
+	assert.NoError(s.Send(nil, args, reply))
+	assert.Len(vm.txs, 1)
+}
+
 func TestSendMultiple(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2591,7 +3616,7 @@ func TestSendMultiple(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+			changeAddrStr, err := vm.FormatLocalAddress(keys[1].PublicKey().Address())
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2643,6 +3668,285 @@ func TestSendMultiple(t *testing.T) {
 	}
 }
 
+func TestSendMultipleMixedAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, vm, s, _, _ := setupWithKeys(t, false)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	feeAssetID := vm.feeAssetID
+	otherAssetID := GetCreateTxFromGenesisTest(t, genesisBytes, otherAssetName).ID()
+
+	// Send to many recipients, alternating between the assets
+	const numOutputs = 200
+	outputs := make([]SendOutput, numOutputs)
+	sent := map[ids.ID]uint64{}
+	for i := range outputs {
+		assetID := feeAssetID
+		if i%2 == 1 {
+			assetID = otherAssetID
+		}
+		to, err := vm.FormatLocalAddress(ids.GenerateTestShortID())
+		assert.NoError(err)
+		outputs[i] = SendOutput{
+			Amount:  json.Uint64(i + 1),
+			AssetID: assetID.String(),
+			To:      to,
+		}
+		sent[assetID] += uint64(i + 1)
+	}
+
+	reply := &api.JSONTxIDChangeAddr{}
+	assert.NoError(s.SendMultiple(nil, &SendMultipleArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+		},
+		Outputs: outputs,
+	}, reply))
+	assert.Len(vm.txs, 1)
+
+	tx := UniqueTx{
+		vm:   vm,
+		txID: reply.TxID,
+	}
+	assert.True(tx.Status().Fetched())
+	baseTx := tx.UnsignedTx.(*BaseTx)
+
+	consumed := map[ids.ID]uint64{}
+	for _, in := range baseTx.Ins {
+		consumed[in.AssetID()] += in.In.Amount()
+	}
+	produced := map[ids.ID]uint64{}
+	numRecipientOutputs := 0
+	changeAddr, err := vm.ParseLocalAddress(reply.ChangeAddr)
+	assert.NoError(err)
+	for _, out := range baseTx.Outs {
+		produced[out.AssetID()] += out.Out.Amount()
+		if out.Out.(*secp256k1fx.TransferOutput).Addrs[0] != changeAddr {
+			numRecipientOutputs++
+		}
+	}
+	assert.Equal(numOutputs, numRecipientOutputs)
+
+	// The fee is paid once, no matter how many outputs there are
+	assert.Equal(consumed[feeAssetID]-vm.TxFee, produced[feeAssetID])
+	assert.Equal(consumed[otherAssetID], produced[otherAssetID])
+	assert.GreaterOrEqual(produced[feeAssetID], sent[feeAssetID])
+	assert.GreaterOrEqual(produced[otherAssetID], sent[otherAssetID])
+}
+
+func TestSendMultipleLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxSendOutputs int
+		numOutputs     int
+		expectedErr    error
+	}{
+		{
+			name:           "too many outputs",
+			maxSendOutputs: 2,
+			numOutputs:     3,
+			expectedErr:    errTooManyOutputs,
+		},
+		{
+			name:           "tx too large",
+			maxSendOutputs: 1024,
+			numOutputs:     1024,
+			expectedErr:    errTxTooLarge,
+		},
+		{
+			name:           "tx too large to marshal",
+			maxSendOutputs: 5000,
+			numOutputs:     5000,
+			expectedErr:    errTxTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			_, vm, s, _, genesisTx := setupWithKeys(t, true)
+			defer func() {
+				assert.NoError(vm.Shutdown())
+				vm.ctx.Lock.Unlock()
+			}()
+			vm.maxSendOutputs = test.maxSendOutputs
+
+			to, err := vm.FormatLocalAddress(ids.GenerateTestShortID())
+			assert.NoError(err)
+			outputs := make([]SendOutput, test.numOutputs)
+			for i := range outputs {
+				outputs[i] = SendOutput{
+					Amount:  1,
+					AssetID: genesisTx.ID().String(),
+					To:      to,
+				}
+			}
+
+			reply := &api.JSONTxIDChangeAddr{}
+			err = s.SendMultiple(nil, &SendMultipleArgs{
+				JSONSpendHeader: api.JSONSpendHeader{
+					UserPass: api.UserPass{
+						Username: username,
+						Password: password,
+					},
+				},
+				Outputs: outputs,
+			}, reply)
+			assert.ErrorIs(err, test.expectedErr)
+			assert.Contains(err.Error(), fmt.Sprintf("%d outputs", test.numOutputs))
+			assert.Empty(vm.txs)
+		})
+	}
+}
+
+func TestSendChangeStrategy(t *testing.T) {
+	tests := []struct {
+		name string
+		// indices of the keys to spend from
+		from           []int
+		changeAddr     string
+		changeStrategy string
+		// index of the key expected to receive the change, or -1 if the
+		// change is expected to go to a newly derived address
+		expectedChange int
+		expectedErr    error
+	}{
+		{
+			name:           "default",
+			from:           []int{1},
+			expectedChange: 1,
+		},
+		{
+			name:           "fixed",
+			from:           []int{1},
+			changeAddr:     "key2",
+			changeStrategy: changeStrategyFixed,
+			expectedChange: 2,
+		},
+		{
+			name:           "first from",
+			from:           []int{2, 0},
+			changeStrategy: changeStrategyFirstFrom,
+			expectedChange: 2,
+		},
+		{
+			name:           "new derived",
+			from:           []int{0},
+			changeStrategy: changeStrategyNewDerived,
+			expectedChange: -1,
+		},
+		{
+			name:        "change address not controlled by user",
+			from:        []int{0},
+			changeAddr:  "other",
+			expectedErr: errChangeAddrNotOwned,
+		},
+		{
+			name:           "change address with first from",
+			from:           []int{0},
+			changeAddr:     "key1",
+			changeStrategy: changeStrategyFirstFrom,
+			expectedErr:    errChangeAddrWithStrategy,
+		},
+		{
+			name:           "first from without from addresses",
+			changeStrategy: changeStrategyFirstFrom,
+			expectedErr:    errNoFromAddrs,
+		},
+		{
+			name:           "unknown strategy",
+			from:           []int{0},
+			changeStrategy: "random",
+			expectedErr:    errUnknownChangeStrategy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			_, vm, s, _, genesisTx := setupWithKeys(t, true)
+			defer func() {
+				assert.NoError(vm.Shutdown())
+				vm.ctx.Lock.Unlock()
+			}()
+
+			addrStrs := map[string]string{}
+			for i, key := range keys {
+				addrStr, err := vm.FormatLocalAddress(key.PublicKey().Address())
+				assert.NoError(err)
+				addrStrs[fmt.Sprintf("key%d", i)] = addrStr
+			}
+			otherAddr, err := vm.FormatLocalAddress(ids.GenerateTestShortID())
+			assert.NoError(err)
+			addrStrs["other"] = otherAddr
+
+			fromAddrStrs := []string{}
+			for _, i := range test.from {
+				fromAddrStrs = append(fromAddrStrs, addrStrs[fmt.Sprintf("key%d", i)])
+			}
+			user := api.UserPass{
+				Username: username,
+				Password: password,
+			}
+
+			reply := &api.JSONTxIDChangeAddr{}
+			err = s.Send(nil, &SendArgs{
+				JSONSpendHeader: api.JSONSpendHeader{
+					UserPass:       user,
+					JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrStrs},
+					JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: addrStrs[test.changeAddr]},
+				},
+				JSONChangeStrategy: JSONChangeStrategy{ChangeStrategy: test.changeStrategy},
+				SendOutput: SendOutput{
+					Amount:  500,
+					AssetID: genesisTx.ID().String(),
+					To:      otherAddr,
+				},
+			}, reply)
+			assert.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			var expectedChange ids.ShortID
+			if test.expectedChange >= 0 {
+				expectedChange = keys[test.expectedChange].PublicKey().Address()
+			} else {
+				derivedReply := &ListDerivedAddressesReply{}
+				assert.NoError(s.ListDerivedAddresses(nil, &user, derivedReply))
+				assert.Len(derivedReply.Addresses, 1)
+				expectedChange, err = vm.ParseLocalAddress(derivedReply.Addresses[0].Address)
+				assert.NoError(err)
+			}
+			expectedChangeStr, err := vm.FormatLocalAddress(expectedChange)
+			assert.NoError(err)
+			assert.Equal(expectedChangeStr, reply.ChangeAddr)
+
+			// The change output is owned by the selected change address
+			tx := UniqueTx{
+				vm:   vm,
+				txID: reply.TxID,
+			}
+			assert.True(tx.Status().Fetched())
+			foundChange := false
+			for _, out := range tx.UnsignedTx.(*BaseTx).Outs {
+				owners := out.Out.(*secp256k1fx.TransferOutput).OutputOwners
+				if len(owners.Addrs) == 1 && owners.Addrs[0] == expectedChange {
+					foundChange = true
+				}
+			}
+			assert.True(foundChange, "no output is owned by the change address")
+		})
+	}
+}
+
 func TestCreateAndListAddresses(t *testing.T) {
 	_, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -2652,9 +3956,11 @@ func TestCreateAndListAddresses(t *testing.T) {
 		vm.ctx.Lock.Unlock()
 	}()
 
-	createArgs := &api.UserPass{
-		Username: username,
-		Password: password,
+	createArgs := &CreateAddressArgs{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
 	}
 	createReply := &api.JSONAddress{}
 
@@ -2682,6 +3988,95 @@ func TestCreateAndListAddresses(t *testing.T) {
 	t.Fatalf("Failed to find newly created address among %d addresses", len(listReply.Addresses))
 }
 
+func TestCreateDerivedAddresses(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Use a keystore this test can export the user from
+	userKeystore, err := keystore.CreateTestKeystore()
+	assert.NoError(err)
+	assert.NoError(userKeystore.CreateUser(username, password))
+	vm.ctx.Keystore = userKeystore.NewBlockchainKeyStore(vm.ctx.ChainID)
+
+	user := api.UserPass{
+		Username: username,
+		Password: password,
+	}
+
+	privKeyStr, err := formatting.EncodeWithChecksum(formatting.CB58, keys[0].Bytes())
+	assert.NoError(err)
+	importReply := &api.JSONAddress{}
+	assert.NoError(s.ImportKey(nil, &ImportKeyArgs{
+		UserPass:   user,
+		PrivateKey: constants.SecretKeyPrefix + privKeyStr,
+	}, importReply))
+
+	randomReply := &api.JSONAddress{}
+	assert.NoError(s.CreateAddress(nil, &CreateAddressArgs{UserPass: user}, randomReply))
+
+	derivedAddrs := make([]string, 2)
+	for i := range derivedAddrs {
+		reply := &api.JSONAddress{}
+		assert.NoError(s.CreateAddress(nil, &CreateAddressArgs{
+			UserPass: user,
+			Derive:   true,
+		}, reply))
+		derivedAddrs[i] = reply.Address
+	}
+	assert.NotEqual(derivedAddrs[0], derivedAddrs[1])
+
+	derivedReply := &ListDerivedAddressesReply{}
+	assert.NoError(s.ListDerivedAddresses(nil, &user, derivedReply))
+	assert.Equal([]DerivedAddress{
+		{Index: 0, Address: derivedAddrs[0]},
+		{Index: 1, Address: derivedAddrs[1]},
+	}, derivedReply.Addresses)
+
+	// Imported, random, and derived keys are all controlled by the user
+	listReply := &api.JSONAddresses{}
+	assert.NoError(s.ListAddresses(nil, &user, listReply))
+	assert.Equal([]string{importReply.Address, randomReply.Address, derivedAddrs[0], derivedAddrs[1]}, listReply.Addresses)
+	for _, addr := range listReply.Addresses {
+		exportReply := &ExportKeyReply{}
+		assert.NoError(s.ExportKey(nil, &ExportKeyArgs{
+			UserPass: user,
+			Address:  addr,
+		}, exportReply))
+	}
+
+	// A restored user keeps the derived addresses, and derives the same
+	// addresses as the original user from then on
+	userBytes, err := userKeystore.ExportUser(username, password)
+	assert.NoError(err)
+	restoredUser := api.UserPass{
+		Username: "restored" + username,
+		Password: password,
+	}
+	assert.NoError(userKeystore.ImportUser(restoredUser.Username, password, userBytes))
+
+	restoredReply := &ListDerivedAddressesReply{}
+	assert.NoError(s.ListDerivedAddresses(nil, &restoredUser, restoredReply))
+	assert.Equal(derivedReply.Addresses, restoredReply.Addresses)
+
+	nextReply := &api.JSONAddress{}
+	assert.NoError(s.CreateAddress(nil, &CreateAddressArgs{
+		UserPass: user,
+		Derive:   true,
+	}, nextReply))
+	restoredNextReply := &api.JSONAddress{}
+	assert.NoError(s.CreateAddress(nil, &CreateAddressArgs{
+		UserPass: restoredUser,
+		Derive:   true,
+	}, restoredNextReply))
+	assert.Equal(nextReply.Address, restoredNextReply.Address)
+	assert.NotContains(derivedAddrs, nextReply.Address)
+}
+
 func TestImport(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2742,3 +4137,181 @@ func TestImport(t *testing.T) {
 		})
 	}
 }
+
+func TestImportChangeAddr(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, m, genesisTx := setupWithKeys(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// The imported funds don't cover the fee, so funds from this chain are
+	// spent and their change goes to [changeAddr]
+	addr := keys[0].PublicKey().Address()
+	changeAddr := keys[1].PublicKey().Address()
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: genesisTx.ID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: vm.TxFee - 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	utxoBytes, err := vm.codec.Marshal(codecVersion, utxo)
+	assert.NoError(err)
+	utxoID := utxo.InputID()
+	peerSharedMemory := m.NewSharedMemory(platformChainID)
+	assert.NoError(peerSharedMemory.Apply(map[ids.ID]*atomic.Requests{vm.ctx.ChainID: {PutRequests: []*atomic.Element{{
+		Key:    utxoID[:],
+		Value:  utxoBytes,
+		Traits: [][]byte{addr.Bytes()},
+	}}}}))
+
+	addrStr, err := vm.FormatLocalAddress(addr)
+	assert.NoError(err)
+	changeAddrStr, err := vm.FormatLocalAddress(changeAddr)
+	assert.NoError(err)
+	reply := &api.JSONTxID{}
+	assert.NoError(s.Import(nil, &ImportArgs{
+		UserPass: api.UserPass{
+			Username: username,
+			Password: password,
+		},
+		SourceChain:    "P",
+		To:             addrStr,
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+	}, reply))
+
+	tx := UniqueTx{
+		vm:   vm,
+		txID: reply.TxID,
+	}
+	assert.True(tx.Status().Fetched())
+	outs := tx.UnsignedTx.(*ImportTx).Outs
+	assert.Len(outs, 1)
+	assert.Equal([]ids.ShortID{changeAddr}, outs[0].Out.(*secp256k1fx.TransferOutput).Addrs)
+}
+
+func TestSpendInsufficientFunds(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, _, _, _ := setupWithKeys(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	utxos, kc, err := vm.LoadUser(username, password, nil)
+	assert.NoError(err)
+	_, _, _, err = vm.Spend(utxos, kc, map[ids.ID]uint64{
+		vm.feeAssetID: math.MaxUint64,
+	})
+	assert.ErrorIs(err, errInsufficientFunds)
+}
+
+func TestImportFee(t *testing.T) {
+	tests := []struct {
+		name string
+		// amount of the fee asset being imported, relative to the tx fee
+		importedDiff int64
+		// true if the user has funds on this chain
+		localFunds bool
+		// number of inputs from this chain expected to pay the fee
+		expectedLocalIns int
+		expectedErr      error
+	}{
+		{
+			name:             "imported equal to fee",
+			importedDiff:     0,
+			expectedLocalIns: 0,
+		},
+		{
+			name:             "imported one short of fee",
+			importedDiff:     -1,
+			localFunds:       true,
+			expectedLocalIns: 1,
+		},
+		{
+			name:         "imported one short of fee with no local funds",
+			importedDiff: -1,
+			expectedErr:  errCantPayImportFee,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			_, vm, s, m, genesisTx := setup(t, true)
+			defer func() {
+				assert.NoError(vm.Shutdown())
+				vm.ctx.Lock.Unlock()
+			}()
+
+			key := keys[0]
+			if !test.localFunds {
+				factory := crypto.FactorySECP256K1R{}
+				skIntf, err := factory.NewPrivateKey()
+				assert.NoError(err)
+				key = skIntf.(*crypto.PrivateKeySECP256K1R)
+			}
+			user := userState{vm: vm}
+			db, err := vm.ctx.Keystore.GetDatabase(username, password)
+			assert.NoError(err)
+			assert.NoError(user.SetKey(db, key))
+			addr := key.PublicKey().Address()
+			assert.NoError(user.SetAddresses(db, []ids.ShortID{addr}))
+
+			utxo := &avax.UTXO{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: genesisTx.ID()},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: uint64(int64(vm.TxFee) + test.importedDiff),
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr},
+					},
+				},
+			}
+			utxoBytes, err := vm.codec.Marshal(codecVersion, utxo)
+			assert.NoError(err)
+			utxoID := utxo.InputID()
+			peerSharedMemory := m.NewSharedMemory(platformChainID)
+			assert.NoError(peerSharedMemory.Apply(map[ids.ID]*atomic.Requests{vm.ctx.ChainID: {PutRequests: []*atomic.Element{{
+				Key:    utxoID[:],
+				Value:  utxoBytes,
+				Traits: [][]byte{addr.Bytes()},
+			}}}}))
+
+			addrStr, err := vm.FormatLocalAddress(addr)
+			assert.NoError(err)
+			reply := &api.JSONTxID{}
+			err = s.Import(nil, &ImportArgs{
+				UserPass: api.UserPass{
+					Username: username,
+					Password: password,
+				},
+				SourceChain: "P",
+				To:          addrStr,
+			}, reply)
+			assert.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				assert.Contains(err.Error(), "shortfall of 1")
+				return
+			}
+
+			tx := UniqueTx{
+				vm:   vm,
+				txID: reply.TxID,
+			}
+			assert.True(tx.Status().Fetched())
+			importTx := tx.UnsignedTx.(*ImportTx)
+			assert.Len(importTx.ImportedIns, 1)
+			assert.Len(importTx.Ins, test.expectedLocalIns)
+		})
+	}
+}
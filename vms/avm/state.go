@@ -10,6 +10,9 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
 
@@ -17,21 +20,42 @@ const (
 	txDeduplicatorSize = 8192
 )
 
+// utxoLatencyBuckets are the histogram boundaries, in seconds, used for the
+// time spent reading and writing UTXOs
+var utxoLatencyBuckets = []float64{.00001, .000025, .00005, .0001, .00025, .0005, .001, .0025, .005, .01, .025, .1, 1}
+
 var (
-	utxoStatePrefix            = []byte("utxo")
-	statusStatePrefix          = []byte("status")
-	singletonStatePrefix       = []byte("singleton")
-	txStatePrefix              = []byte("tx")
-	_                    State = &state{}
+	utxoStatePrefix      = []byte("utxo")
+	statusStatePrefix    = []byte("status")
+	singletonStatePrefix = []byte("singleton")
+	txStatePrefix        = []byte("tx")
+	assetStatusPrefix    = []byte("assetStatus")
+	assetDescPrefix      = []byte("assetDescription")
+	assetSymbolPrefix    = []byte("assetSymbol")
+
+	// Key, in the singleton database, of the number of UTXOs in the UTXO set
+	utxoCountKey = []byte("utxoCount")
+	// Key, in the singleton database, that is set once the descriptions of
+	// all accepted assets have been indexed
+	assetsIndexedKey = []byte("assetsIndexed")
+
+	_ State          = &state{}
+	_ avax.UTXOState = &countedUTXOState{}
+	_ avax.UTXOState = &timedUTXOState{}
 )
 
-// State persistently maintains a set of UTXOs, transaction, statuses, and
-// singletons.
+// State persistently maintains a set of UTXOs, transaction, statuses,
+// singletons, frozen assets, and asset descriptions.
 type State interface {
 	avax.UTXOState
 	avax.StatusState
 	avax.SingletonState
 	TxState
+	ManagedAssetState
+	AssetDescriptionState
+
+	// ForEachAcceptedTx calls [f] with every accepted tx, in order of txID.
+	ForEachAcceptedTx(f func(txID ids.ID, tx *Tx) error) error
 
 	DeduplicateTx(tx *UniqueTx) *UniqueTx
 }
@@ -41,7 +65,10 @@ type state struct {
 	avax.StatusState
 	avax.SingletonState
 	TxState
+	ManagedAssetState
+	AssetDescriptionState
 
+	txDB      database.Database
 	uniqueTxs cache.Deduplicator
 }
 
@@ -50,26 +77,51 @@ func NewState(db database.Database, genesisCodec, codec codec.Manager) State {
 	statusDB := prefixdb.New(statusStatePrefix, db)
 	singletonDB := prefixdb.New(singletonStatePrefix, db)
 	txDB := prefixdb.New(txStatePrefix, db)
+	assetStatusDB := prefixdb.New(assetStatusPrefix, db)
+	assetDescDB := prefixdb.New(assetDescPrefix, db)
+	assetSymbolDB := prefixdb.New(assetSymbolPrefix, db)
 
 	return &state{
-		UTXOState:      avax.NewUTXOState(utxoDB, codec),
-		StatusState:    avax.NewStatusState(statusDB),
-		SingletonState: avax.NewSingletonState(singletonDB),
-		TxState:        NewTxState(txDB, genesisCodec),
+		UTXOState:             avax.NewUTXOState(utxoDB, codec),
+		StatusState:           avax.NewStatusState(statusDB),
+		SingletonState:        avax.NewSingletonState(singletonDB),
+		TxState:               NewTxState(txDB, genesisCodec),
+		ManagedAssetState:     NewManagedAssetState(assetStatusDB),
+		AssetDescriptionState: NewAssetDescriptionState(assetDescDB, assetSymbolDB, codec),
 
+		txDB: txDB,
 		uniqueTxs: &cache.EvictableLRU{
 			Size: txDeduplicatorSize,
 		},
 	}
 }
 
-func NewMeteredState(db database.Database, genesisCodec, codec codec.Manager, metrics prometheus.Registerer) (State, error) {
+func NewMeteredState(
+	db database.Database,
+	genesisCodec,
+	codec codec.Manager,
+	assetDescriptionCacheSize int,
+	metrics prometheus.Registerer,
+) (State, error) {
 	utxoDB := prefixdb.New(utxoStatePrefix, db)
 	statusDB := prefixdb.New(statusStatePrefix, db)
 	singletonDB := prefixdb.New(singletonStatePrefix, db)
 	txDB := prefixdb.New(txStatePrefix, db)
+	assetStatusDB := prefixdb.New(assetStatusPrefix, db)
+	assetDescDB := prefixdb.New(assetDescPrefix, db)
+	assetSymbolDB := prefixdb.New(assetSymbolPrefix, db)
+
+	singletonState := avax.NewSingletonState(singletonDB)
 
-	utxoState, err := avax.NewMeteredUTXOState(utxoDB, codec, metrics)
+	meteredUTXOState, err := avax.NewMeteredUTXOState(utxoDB, codec, metrics)
+	if err != nil {
+		return nil, err
+	}
+	timedUTXOState, err := newTimedUTXOState(meteredUTXOState, metrics)
+	if err != nil {
+		return nil, err
+	}
+	utxoState, err := newCountedUTXOState(timedUTXOState, utxoDB, singletonDB, singletonState, metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -80,19 +132,274 @@ func NewMeteredState(db database.Database, genesisCodec, codec codec.Manager, me
 	}
 
 	txState, err := NewMeteredTxState(txDB, genesisCodec, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	managedAssetState, err := NewMeteredManagedAssetState(assetStatusDB, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	assetDescState, err := NewMeteredAssetDescriptionState(assetDescDB, assetSymbolDB, codec, assetDescriptionCacheSize, metrics)
+	if err != nil {
+		return nil, err
+	}
+	err = indexAssetDescriptions(txDB, singletonDB, singletonState, statusState, txState, assetDescState)
 	return &state{
-		UTXOState:      utxoState,
-		StatusState:    statusState,
-		SingletonState: avax.NewSingletonState(singletonDB),
-		TxState:        txState,
+		UTXOState:             utxoState,
+		StatusState:           statusState,
+		SingletonState:        singletonState,
+		TxState:               txState,
+		ManagedAssetState:     managedAssetState,
+		AssetDescriptionState: assetDescState,
 
+		txDB: txDB,
 		uniqueTxs: &cache.EvictableLRU{
 			Size: txDeduplicatorSize,
 		},
 	}, err
 }
 
+func (s *state) ForEachAcceptedTx(f func(txID ids.ID, tx *Tx) error) error {
+	return forEachAcceptedTx(s.txDB, s.StatusState, s.TxState, f)
+}
+
 // UniqueTx de-duplicates the transaction.
 func (s *state) DeduplicateTx(tx *UniqueTx) *UniqueTx {
 	return s.uniqueTxs.Deduplicate(tx).(*UniqueTx)
 }
+
+// countedUTXOState tracks the size of the UTXO set, and how many UTXOs have
+// been created and consumed. The size of the UTXO set is persisted so that it
+// doesn't need to be recounted on startup.
+type countedUTXOState struct {
+	avax.UTXOState
+
+	singletonDB database.Database
+	numUTXOs    uint64
+
+	utxosGauge   prometheus.Gauge
+	utxosCreated prometheus.Counter
+	utxosSpent   prometheus.Counter
+}
+
+func newCountedUTXOState(
+	utxoState avax.UTXOState,
+	utxoDB database.Database,
+	singletonDB database.Database,
+	singletonState avax.SingletonState,
+	metrics prometheus.Registerer,
+) (*countedUTXOState, error) {
+	numUTXOs, err := loadNumUTXOs(utxoDB, singletonDB, singletonState)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &countedUTXOState{
+		UTXOState:   utxoState,
+		singletonDB: singletonDB,
+		numUTXOs:    numUTXOs,
+		utxosGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "utxos",
+			Help: "Number of UTXOs in the UTXO set",
+		}),
+		utxosCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "utxos_created",
+			Help: "Number of UTXOs added to the UTXO set",
+		}),
+		utxosSpent: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "utxos_consumed",
+			Help: "Number of UTXOs removed from the UTXO set",
+		}),
+	}
+	s.utxosGauge.Set(float64(numUTXOs))
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		metrics.Register(s.utxosGauge),
+		metrics.Register(s.utxosCreated),
+		metrics.Register(s.utxosSpent),
+	)
+	return s, errs.Err
+}
+
+// loadNumUTXOs returns the persisted size of the UTXO set. If the chain was
+// initialized before the size of the UTXO set was persisted, the UTXOs are
+// counted once.
+func loadNumUTXOs(utxoDB, singletonDB database.Database, singletonState avax.SingletonState) (uint64, error) {
+	numUTXOs, err := database.GetUInt64(singletonDB, utxoCountKey)
+	if err != database.ErrNotFound {
+		return numUTXOs, err
+	}
+
+	initialized, err := singletonState.IsInitialized()
+	if err != nil || !initialized {
+		return 0, err
+	}
+	size, err := avax.NumUTXOs(utxoDB)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(size), database.PutUInt64(singletonDB, utxoCountKey, uint64(size))
+}
+
+// indexAssetDescriptions indexes the descriptions of the assets that were
+// accepted before asset descriptions were indexed. This only needs to be done
+// once, as new assets are indexed when they are accepted.
+func indexAssetDescriptions(
+	txDB database.Database,
+	singletonDB database.Database,
+	singletonState avax.SingletonState,
+	statusState avax.StatusState,
+	txState TxState,
+	assetDescState AssetDescriptionState,
+) error {
+	indexed, err := singletonDB.Has(assetsIndexedKey)
+	if err != nil || indexed {
+		return err
+	}
+
+	initialized, err := singletonState.IsInitialized()
+	if err != nil {
+		return err
+	}
+	if initialized {
+		err := forEachAcceptedTx(txDB, statusState, txState, func(txID ids.ID, tx *Tx) error {
+			desc, ok := assetDescription(tx.UnsignedTx)
+			if !ok {
+				return nil
+			}
+			return assetDescState.PutAssetDescription(txID, desc)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return singletonDB.Put(assetsIndexedKey, nil)
+}
+
+// forEachAcceptedTx calls [f] with every tx in [txDB] that is accepted, in
+// order of txID.
+func forEachAcceptedTx(
+	txDB database.Database,
+	statusState avax.StatusState,
+	txState TxState,
+	f func(txID ids.ID, tx *Tx) error,
+) error {
+	iter := txDB.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return err
+		}
+		status, err := statusState.GetStatus(txID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if status != choices.Accepted {
+			continue
+		}
+		tx, err := txState.GetTx(txID)
+		if err != nil {
+			return err
+		}
+		if err := f(txID, tx); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// PutUTXO assumes that [utxoID] isn't already in the UTXO set.
+func (s *countedUTXOState) PutUTXO(utxoID ids.ID, utxo *avax.UTXO) error {
+	if err := s.UTXOState.PutUTXO(utxoID, utxo); err != nil {
+		return err
+	}
+	if err := s.setNumUTXOs(s.numUTXOs + 1); err != nil {
+		return err
+	}
+	s.utxosCreated.Inc()
+	return nil
+}
+
+func (s *countedUTXOState) DeleteUTXO(utxoID ids.ID) error {
+	if err := s.UTXOState.DeleteUTXO(utxoID); err != nil {
+		return err
+	}
+	if err := s.setNumUTXOs(s.numUTXOs - 1); err != nil {
+		return err
+	}
+	s.utxosSpent.Inc()
+	return nil
+}
+
+func (s *countedUTXOState) setNumUTXOs(numUTXOs uint64) error {
+	if err := database.PutUInt64(s.singletonDB, utxoCountKey, numUTXOs); err != nil {
+		return err
+	}
+	s.numUTXOs = numUTXOs
+	s.utxosGauge.Set(float64(numUTXOs))
+	return nil
+}
+
+// timedUTXOState records how long reads and writes of the UTXO set take. The
+// hits of the UTXO cache are reported by the wrapped UTXO state.
+type timedUTXOState struct {
+	avax.UTXOState
+
+	getUTXOLatency,
+	putUTXOLatency,
+	deleteUTXOLatency prometheus.Histogram
+}
+
+func newUTXOLatencyMetric(name, op string) prometheus.Histogram {
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    name,
+		Help:    "Time (in seconds) spent " + op,
+		Buckets: utxoLatencyBuckets,
+	})
+}
+
+func newTimedUTXOState(utxoState avax.UTXOState, metrics prometheus.Registerer) (*timedUTXOState, error) {
+	s := &timedUTXOState{
+		UTXOState:         utxoState,
+		getUTXOLatency:    newUTXOLatencyMetric("get_utxo_duration", "reading a UTXO"),
+		putUTXOLatency:    newUTXOLatencyMetric("put_utxo_duration", "writing a UTXO"),
+		deleteUTXOLatency: newUTXOLatencyMetric("delete_utxo_duration", "deleting a UTXO"),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		metrics.Register(s.getUTXOLatency),
+		metrics.Register(s.putUTXOLatency),
+		metrics.Register(s.deleteUTXOLatency),
+	)
+	return s, errs.Err
+}
+
+func (s *timedUTXOState) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
+	timer := prometheus.NewTimer(s.getUTXOLatency)
+	defer timer.ObserveDuration()
+
+	return s.UTXOState.GetUTXO(utxoID)
+}
+
+func (s *timedUTXOState) PutUTXO(utxoID ids.ID, utxo *avax.UTXO) error {
+	timer := prometheus.NewTimer(s.putUTXOLatency)
+	defer timer.ObserveDuration()
+
+	return s.UTXOState.PutUTXO(utxoID, utxo)
+}
+
+func (s *timedUTXOState) DeleteUTXO(utxoID ids.ID) error {
+	timer := prometheus.NewTimer(s.deleteUTXOLatency)
+	defer timer.ObserveDuration()
+
+	return s.UTXOState.DeleteUTXO(utxoID)
+}
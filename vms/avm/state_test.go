@@ -7,9 +7,19 @@ import (
 	"math"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -206,3 +216,195 @@ func TestFundingAddresses(t *testing.T) {
 		t.Fatalf("Should have returned 0 utxoIDs")
 	}
 }
+
+func TestUTXOCountPersisted(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	codec, err := staticCodec()
+	assert.NoError(err)
+
+	utxos := make([]*avax.UTXO, 3)
+	for i := range utxos {
+		utxos[i] = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.GenerateTestID(),
+				OutputIndex: uint32(i),
+			},
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+				},
+			},
+		}
+	}
+
+	stateIntf, err := NewMeteredState(db, codec, codec, defaultAssetDescriptionCacheSize, prometheus.NewRegistry())
+	assert.NoError(err)
+	s := stateIntf.(*state).UTXOState.(*countedUTXOState)
+	for _, utxo := range utxos {
+		assert.NoError(s.PutUTXO(utxo.InputID(), utxo))
+	}
+	assert.NoError(s.DeleteUTXO(utxos[0].InputID()))
+	assert.Error(s.DeleteUTXO(utxos[0].InputID()))
+	assert.NoError(stateIntf.SetInitialized())
+
+	assert.Equal(2.0, testutil.ToFloat64(s.utxosGauge))
+	assert.Equal(3.0, testutil.ToFloat64(s.utxosCreated))
+	assert.Equal(1.0, testutil.ToFloat64(s.utxosSpent))
+
+	// The count is loaded rather than recounted on startup
+	stateIntf, err = NewMeteredState(db, codec, codec, defaultAssetDescriptionCacheSize, prometheus.NewRegistry())
+	assert.NoError(err)
+	s = stateIntf.(*state).UTXOState.(*countedUTXOState)
+	assert.Equal(2.0, testutil.ToFloat64(s.utxosGauge))
+
+	// The UTXOs are counted if the count was never persisted
+	assert.NoError(prefixdb.New(singletonStatePrefix, db).Delete(utxoCountKey))
+	stateIntf, err = NewMeteredState(db, codec, codec, defaultAssetDescriptionCacheSize, prometheus.NewRegistry())
+	assert.NoError(err)
+	s = stateIntf.(*state).UTXOState.(*countedUTXOState)
+	assert.Equal(2.0, testutil.ToFloat64(s.utxosGauge))
+}
+
+func TestAssetDescriptionsIndexed(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	codec, err := staticCodec()
+	assert.NoError(err)
+
+	tx := &Tx{UnsignedTx: &CreateAssetTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+		}},
+		Name:         "Team Rocket",
+		Symbol:       "TR",
+		Denomination: 2,
+	}}
+	assert.NoError(tx.SignSECP256K1Fx(codec, nil))
+	txID := tx.ID()
+
+	stateIntf, err := NewMeteredState(db, codec, codec, defaultAssetDescriptionCacheSize, prometheus.NewRegistry())
+	assert.NoError(err)
+	_, err = stateIntf.GetAssetDescription(txID)
+	assert.Equal(database.ErrNotFound, err)
+
+	// Accept the asset without indexing it, as if it was accepted before
+	// asset descriptions were indexed
+	assert.NoError(stateIntf.PutTx(txID, tx))
+	assert.NoError(stateIntf.PutStatus(txID, choices.Accepted))
+	assert.NoError(stateIntf.SetInitialized())
+	assert.NoError(prefixdb.New(singletonStatePrefix, db).Delete(assetsIndexedKey))
+
+	stateIntf, err = NewMeteredState(db, codec, codec, defaultAssetDescriptionCacheSize, prometheus.NewRegistry())
+	assert.NoError(err)
+	desc, err := stateIntf.GetAssetDescription(txID)
+	assert.NoError(err)
+	assert.Equal(&AssetDescription{
+		Name:         "Team Rocket",
+		Symbol:       "TR",
+		Denomination: 2,
+	}, desc)
+
+	// Symbols don't need to be unique
+	otherAssetID := ids.GenerateTestID()
+	assert.NoError(stateIntf.PutAssetDescription(otherAssetID, &AssetDescription{Symbol: "TR"}))
+	assert.NoError(stateIntf.PutAssetDescription(ids.GenerateTestID(), &AssetDescription{Symbol: "TRR"}))
+	assetIDs, err := stateIntf.AssetIDsWithSymbol("TR")
+	assert.NoError(err)
+	assert.ElementsMatch([]ids.ID{txID, otherAssetID}, assetIDs)
+	assetIDs, err = stateIntf.AssetIDsWithSymbol("T")
+	assert.NoError(err)
+	assert.Empty(assetIDs)
+}
+
+func TestAssetDescriptionCacheMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	codec, err := staticCodec()
+	assert.NoError(err)
+	registry := prometheus.NewRegistry()
+	state, err := NewMeteredAssetDescriptionState(memdb.New(), memdb.New(), codec, 1, registry)
+	assert.NoError(err)
+
+	assetIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	for _, assetID := range assetIDs {
+		assert.NoError(state.PutAssetDescription(assetID, &AssetDescription{Symbol: "TR"}))
+	}
+
+	// Only the most recently put description fits in the cache
+	for i := 0; i < 2; i++ {
+		desc, err := state.GetAssetDescription(assetIDs[1])
+		assert.NoError(err)
+		assert.Equal("TR", desc.Symbol)
+	}
+	_, err = state.GetAssetDescription(assetIDs[0])
+	assert.NoError(err)
+
+	metrics, err := registry.Gather()
+	assert.NoError(err)
+	counts := make(map[string]float64)
+	for _, metric := range metrics {
+		counts[metric.GetName()] = metric.GetMetric()[0].GetCounter().GetValue()
+	}
+	assert.Equal(2.0, counts["asset_description_cache_hit"])
+	assert.Equal(1.0, counts["asset_description_cache_miss"])
+}
+
+func utxoLatencySamples(t *testing.T, h prometheus.Histogram) uint64 {
+	metric := &dto.Metric{}
+	assert.NoError(t, h.Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestUTXOLatencyMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	codec, err := staticCodec()
+	assert.NoError(err)
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+			},
+		},
+	}
+	utxoID := utxo.InputID()
+
+	stateIntf, err := NewMeteredState(db, codec, codec, defaultAssetDescriptionCacheSize, prometheus.NewRegistry())
+	assert.NoError(err)
+	s := stateIntf.(*state).UTXOState.(*countedUTXOState).UTXOState.(*timedUTXOState)
+
+	assert.NoError(stateIntf.PutUTXO(utxoID, utxo))
+	_, err = stateIntf.GetUTXO(utxoID)
+	assert.NoError(err)
+	assert.NoError(stateIntf.DeleteUTXO(utxoID))
+	// Failed reads are timed as well
+	_, err = stateIntf.GetUTXO(utxoID)
+	assert.Equal(database.ErrNotFound, err)
+
+	assert.EqualValues(2, utxoLatencySamples(t, s.getUTXOLatency))
+	assert.EqualValues(1, utxoLatencySamples(t, s.putUTXOLatency))
+	assert.EqualValues(1, utxoLatencySamples(t, s.deleteUTXOLatency))
+
+	// Timing a read doesn't allocate more than the read itself
+	assert.NoError(s.PutUTXO(utxoID, utxo))
+	untimedAllocs := testing.AllocsPerRun(100, func() {
+		_, _ = s.UTXOState.GetUTXO(utxoID)
+	})
+	timedAllocs := testing.AllocsPerRun(100, func() {
+		_, _ = s.GetUTXO(utxoID)
+	})
+	assert.Equal(untimedAllocs, timedAllocs)
+}
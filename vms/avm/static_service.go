@@ -4,16 +4,20 @@
 package avm
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/codec/reflectcodec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -26,7 +30,10 @@ import (
 )
 
 var (
-	errUnknownAssetType = errors.New("unknown asset type")
+	errUnknownAssetType     = errors.New("unknown asset type")
+	errInvalidGenesis       = errors.New("invalid genesis")
+	errGenesisDataNotObject = errors.New("genesisData must be an object")
+	errWrongHRP             = errors.New("address has the wrong human readable part")
 
 	_ avax.TransferableIn  = &secp256k1fx.TransferInput{}
 	_ verify.State         = &secp256k1fx.MintOutput{}
@@ -59,6 +66,66 @@ type BuildGenesisArgs struct {
 	NetworkID   cjson.Uint32               `json:"networkID"`
 	GenesisData map[string]AssetDefinition `json:"genesisData"`
 	Encoding    formatting.Encoding        `json:"encoding"`
+
+	// duplicateAliases are the aliases that were defined more than once in the
+	// JSON this was unmarshaled from
+	duplicateAliases []string
+}
+
+// UnmarshalJSON unmarshals the arguments while remembering which asset aliases
+// are defined more than once, so they can be reported rather than silently
+// overwritten.
+func (args *BuildGenesisArgs) UnmarshalJSON(b []byte) error {
+	type buildGenesisArgs BuildGenesisArgs
+	raw := struct {
+		*buildGenesisArgs
+		GenesisData json.RawMessage `json:"genesisData"`
+	}{
+		buildGenesisArgs: (*buildGenesisArgs)(args),
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	args.GenesisData = nil
+	args.duplicateAliases = nil
+	if len(raw.GenesisData) == 0 || string(raw.GenesisData) == "null" {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw.GenesisData))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return errGenesisDataNotObject
+	}
+
+	args.GenesisData = make(map[string]AssetDefinition)
+	duplicates := make(map[string]struct{})
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		// Object keys are always decoded as strings
+		assetAlias := token.(string)
+
+		assetDefinition := AssetDefinition{}
+		if err := decoder.Decode(&assetDefinition); err != nil {
+			return fmt.Errorf("problem unmarshaling asset %q: %w", assetAlias, err)
+		}
+		if _, exists := args.GenesisData[assetAlias]; exists {
+			if _, reported := duplicates[assetAlias]; !reported {
+				duplicates[assetAlias] = struct{}{}
+				args.duplicateAliases = append(args.duplicateAliases, assetAlias)
+			}
+		}
+		args.GenesisData[assetAlias] = assetDefinition
+	}
+	_, err = decoder.Token()
+	return err
 }
 
 type AssetDefinition struct {
@@ -75,19 +142,59 @@ type BuildGenesisReply struct {
 	Encoding formatting.Encoding `json:"encoding"`
 }
 
+// genesisProblems are the problems found while building a genesis, each
+// naming the asset alias and field it was found in
+type genesisProblems []string
+
+func (p *genesisProblems) add(assetAlias, field, format string, args ...interface{}) {
+	problem := fmt.Sprintf(format, args...)
+	if field == "" {
+		*p = append(*p, fmt.Sprintf("asset %q: %s", assetAlias, problem))
+		return
+	}
+	*p = append(*p, fmt.Sprintf("asset %q: %s: %s", assetAlias, field, problem))
+}
+
+// Err returns an error reporting every problem, or nil if there are none
+func (p genesisProblems) Err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errInvalidGenesis, strings.Join(p, "; "))
+}
+
 // BuildGenesis returns the UTXOs such that at least one address in [args.Addresses] is
 // referenced in the UTXO.
+// Every asset is validated before the genesis is encoded. If any asset is
+// invalid, the returned error reports all of the problems that were found.
 func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, reply *BuildGenesisReply) error {
 	manager, err := staticCodec()
 	if err != nil {
 		return err
 	}
 
+	problems := genesisProblems{}
+	for _, assetAlias := range args.duplicateAliases {
+		problems.add(assetAlias, "", "alias is defined more than once")
+	}
+
+	// Sort the aliases so that problems are always reported in the same order
+	assetAliases := make([]string, 0, len(args.GenesisData))
+	for assetAlias := range args.GenesisData {
+		assetAliases = append(assetAliases, assetAlias)
+	}
+	sort.Strings(assetAliases)
+
+	hrp := constants.GetHRP(uint32(args.NetworkID))
 	g := Genesis{}
-	for assetAlias, assetDefinition := range args.GenesisData {
+	for _, assetAlias := range assetAliases {
+		assetDefinition := args.GenesisData[assetAlias]
+		if assetDefinition.Denomination > maxDenomination {
+			problems.add(assetAlias, "denomination", "%d is above the maximum of %d", assetDefinition.Denomination, maxDenomination)
+		}
 		assetMemo, err := formatting.Decode(args.Encoding, assetDefinition.Memo)
 		if err != nil {
-			return fmt.Errorf("problem formatting asset definition memo due to: %w", err)
+			problems.add(assetAlias, "memo", "couldn't decode as %s: %s", args.Encoding, err)
 		}
 		asset := GenesisAsset{
 			Alias: assetAlias,
@@ -102,29 +209,30 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 				Denomination: byte(assetDefinition.Denomination),
 			},
 		}
+		// An asset without any initial state, such as AVAX on a network with no
+		// X-chain allocations, can still be received through imports.
 		if len(assetDefinition.InitialState) > 0 {
 			initialState := &InitialState{
 				FxIndex: 0, // TODO: Should lookup secp256k1fx FxID
 			}
 			for assetType, initialStates := range assetDefinition.InitialState {
+				field := "initialState." + assetType
 				switch assetType {
 				case "fixedCap":
-					for _, state := range initialStates {
-						b, err := json.Marshal(state)
-						if err != nil {
-							return fmt.Errorf("problem marshaling state: %w", err)
-						}
+					if len(initialStates) == 0 {
+						problems.add(assetAlias, field, "no initial holders")
+					}
+					for i, state := range initialStates {
+						holderField := fmt.Sprintf("%s[%d]", field, i)
 						holder := Holder{}
-						if err := json.Unmarshal(b, &holder); err != nil {
-							return fmt.Errorf("problem unmarshaling holder: %w", err)
-						}
-						_, addrbuff, err := formatting.ParseBech32(holder.Address)
-						if err != nil {
-							return fmt.Errorf("problem parsing holder address: %w", err)
+						if err := remarshalState(state, &holder); err != nil {
+							problems.add(assetAlias, holderField, "problem unmarshaling holder: %s", err)
+							continue
 						}
-						addr, err := ids.ToShortID(addrbuff)
+						addr, err := parseGenesisAddress(hrp, holder.Address)
 						if err != nil {
-							return fmt.Errorf("problem parsing holder address: %w", err)
+							problems.add(assetAlias, holderField+".address", "%s", err)
+							continue
 						}
 						initialState.Outs = append(initialState.Outs, &secp256k1fx.TransferOutput{
 							Amt: uint64(holder.Amount),
@@ -135,14 +243,18 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 						})
 					}
 				case "variableCap":
-					for _, state := range initialStates {
-						b, err := json.Marshal(state)
-						if err != nil {
-							return fmt.Errorf("problem marshaling state: %w", err)
-						}
+					if len(initialStates) == 0 {
+						problems.add(assetAlias, field, "no minter sets")
+					}
+					for i, state := range initialStates {
+						ownersField := fmt.Sprintf("%s[%d]", field, i)
 						owners := Owners{}
-						if err := json.Unmarshal(b, &owners); err != nil {
-							return fmt.Errorf("problem unmarshaling Owners: %w", err)
+						if err := remarshalState(state, &owners); err != nil {
+							problems.add(assetAlias, ownersField, "problem unmarshaling owners: %s", err)
+							continue
+						}
+						if len(owners.Minters) == 0 {
+							problems.add(assetAlias, ownersField+".minters", "no minters")
 						}
 
 						out := &secp256k1fx.MintOutput{
@@ -150,14 +262,11 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 								Threshold: 1,
 							},
 						}
-						for _, address := range owners.Minters {
-							_, addrbuff, err := formatting.ParseBech32(address)
+						for j, address := range owners.Minters {
+							addr, err := parseGenesisAddress(hrp, address)
 							if err != nil {
-								return fmt.Errorf("problem parsing minters address: %w", err)
-							}
-							addr, err := ids.ToShortID(addrbuff)
-							if err != nil {
-								return fmt.Errorf("problem parsing minters address: %w", err)
+								problems.add(assetAlias, fmt.Sprintf("%s.minters[%d]", ownersField, j), "%s", err)
+								continue
 							}
 							out.Addrs = append(out.Addrs, addr)
 						}
@@ -166,7 +275,7 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 						initialState.Outs = append(initialState.Outs, out)
 					}
 				default:
-					return errUnknownAssetType
+					problems.add(assetAlias, field, "%s", errUnknownAssetType)
 				}
 			}
 			initialState.Sort(manager)
@@ -175,6 +284,9 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 		asset.Sort()
 		g.Txs = append(g.Txs, &asset)
 	}
+	if err := problems.Err(); err != nil {
+		return err
+	}
 	g.Sort()
 
 	b, err := manager.Marshal(codecVersion, &g)
@@ -190,6 +302,29 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 	return nil
 }
 
+// remarshalState unmarshals the initial state [state], which was decoded
+// without knowing its type, into [dst]
+func remarshalState(state interface{}, dst interface{}) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// parseGenesisAddress parses [addrStr], which must be a bech32 address with
+// the human readable part [hrp]
+func parseGenesisAddress(hrp, addrStr string) (ids.ShortID, error) {
+	addrHRP, addrBytes, err := formatting.ParseBech32(addrStr)
+	if err != nil {
+		return ids.ShortID{}, fmt.Errorf("couldn't parse %q: %w", addrStr, err)
+	}
+	if addrHRP != hrp {
+		return ids.ShortID{}, fmt.Errorf("%w: %q has %q but the network expects %q", errWrongHRP, addrStr, addrHRP, hrp)
+	}
+	return ids.ToShortID(addrBytes)
+}
+
 func staticCodec() (codec.Manager, error) {
 	c := linearcodec.New(reflectcodec.DefaultTagName, 1<<20)
 	manager := codec.NewManager(math.MaxUint32)
@@ -216,6 +351,8 @@ func staticCodec() (codec.Manager, error) {
 		c.RegisterType(&propertyfx.MintOperation{}),
 		c.RegisterType(&propertyfx.BurnOperation{}),
 		c.RegisterType(&propertyfx.Credential{}),
+		c.RegisterType(&secp256k1fx.ManagedAssetStatusOutput{}),
+		c.RegisterType(&secp256k1fx.UpdateManagedAssetOperation{}),
 		manager.RegisterCodec(codecVersion, c),
 	)
 	return manager, errs.Err
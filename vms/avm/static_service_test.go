@@ -4,8 +4,13 @@
 package avm
 
 import (
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -20,8 +25,12 @@ var addrStrArray = []string{
 
 var testHRP = constants.NetworkIDToHRP[networkID]
 
-func TestBuildGenesis(t *testing.T) {
-	ss := CreateStaticService()
+// testBuildGenesisBytes is the genesis built from testBuildGenesisArgs
+const testBuildGenesisBytes = "0x00000000000300066173736574310000000a0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000f6d794669786564436170417373657400044d4643410800000001000000000000000400000007000000000000c350000000000000000000000001000000013f78e510df62bc48b0829ec06d6a6b98062d695300000007000000000000c35000000000000000000000000100000001c54903de5177a16f7811771ef2f4659d9e8646710000000700000000000186a0000000000000000000000001000000013f58fda2e9ea8d9e4b181832a07b26dae286f2cb0000000700000000000186a000000000000000000000000100000001645938bb7ae2193270e6ffef009e3664d11e07c100066173736574320000000a0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000d6d79566172436170417373657400044d5643410000000001000000000000000200000006000000000000000000000001000000023f58fda2e9ea8d9e4b181832a07b26dae286f2cb645938bb7ae2193270e6ffef009e3664d11e07c100000006000000000000000000000001000000023f78e510df62bc48b0829ec06d6a6b98062d6953c54903de5177a16f7811771ef2f4659d9e86467100066173736574330000000a000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000126d794f7468657256617243617041737365740000000000000100000000000000010000000600000000000000000000000100000001645938bb7ae2193270e6ffef009e3664d11e07c1c9210c21"
+
+// testBuildGenesisAddrs returns the bech32 form of each address in
+// [addrStrArray], keyed by its CB58 form
+func testBuildGenesisAddrs(t *testing.T) map[string]string {
 	addrMap := map[string]string{}
 	for _, addrStr := range addrStrArray {
 		b, err := formatting.Decode(formatting.CB58, addrStr)
@@ -33,8 +42,15 @@ func TestBuildGenesis(t *testing.T) {
 			t.Fatal(err)
 		}
 	}
-	args := BuildGenesisArgs{
-		Encoding: formatting.Hex,
+	return addrMap
+}
+
+// testBuildGenesisArgs returns the arguments of a valid genesis
+func testBuildGenesisArgs(t *testing.T) BuildGenesisArgs {
+	addrMap := testBuildGenesisAddrs(t)
+	return BuildGenesisArgs{
+		NetworkID: json.Uint32(networkID),
+		Encoding:  formatting.Hex,
 		GenesisData: map[string]AssetDefinition{
 			"asset1": {
 				Name:         "myFixedCapAsset",
@@ -98,9 +114,188 @@ func TestBuildGenesis(t *testing.T) {
 			},
 		},
 	}
+}
+
+func TestBuildGenesis(t *testing.T) {
+	ss := CreateStaticService()
+	args := testBuildGenesisArgs(t)
 	reply := BuildGenesisReply{}
 	err := ss.BuildGenesis(nil, &args, &reply)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if reply.Bytes != testBuildGenesisBytes {
+		t.Fatalf("expected genesis:\n%s\ngot:\n%s", testBuildGenesisBytes, reply.Bytes)
+	}
+}
+
+func TestBuildGenesisWithoutInitialState(t *testing.T) {
+	ss := CreateStaticService()
+	args := testBuildGenesisArgs(t)
+	asset := args.GenesisData["asset1"]
+	asset.InitialState = nil
+	args.GenesisData["asset1"] = asset
+
+	reply := BuildGenesisReply{}
+	if err := ss.BuildGenesis(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildGenesisInvalid(t *testing.T) {
+	addrMap := testBuildGenesisAddrs(t)
+	wrongHRPAddr, err := formatting.FormatBech32(constants.FallbackHRP, ids.ShortEmpty.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(args *BuildGenesisArgs)
+		problems []string
+	}{
+		{
+			name: "denomination too large",
+			modify: func(args *BuildGenesisArgs) {
+				asset := args.GenesisData["asset1"]
+				asset.Denomination = maxDenomination + 1
+				args.GenesisData["asset1"] = asset
+			},
+			problems: []string{`asset "asset1": denomination`},
+		},
+		{
+			name: "no fixed cap holders",
+			modify: func(args *BuildGenesisArgs) {
+				args.GenesisData["asset1"].InitialState["fixedCap"] = nil
+			},
+			problems: []string{`asset "asset1": initialState.fixedCap: no initial holders`},
+		},
+		{
+			name: "no minters",
+			modify: func(args *BuildGenesisArgs) {
+				args.GenesisData["asset3"].InitialState["variableCap"] = []interface{}{Owners{Threshold: 1}}
+			},
+			problems: []string{`asset "asset3": initialState.variableCap[0].minters: no minters`},
+		},
+		{
+			name: "unknown asset type",
+			modify: func(args *BuildGenesisArgs) {
+				args.GenesisData["asset2"].InitialState["limitedCap"] = nil
+			},
+			problems: []string{`asset "asset2": initialState.limitedCap: unknown asset type`},
+		},
+		{
+			name: "holder address has wrong hrp",
+			modify: func(args *BuildGenesisArgs) {
+				args.GenesisData["asset1"].InitialState["fixedCap"][1] = Holder{
+					Amount:  1,
+					Address: wrongHRPAddr,
+				}
+			},
+			problems: []string{`asset "asset1": initialState.fixedCap[1].address`},
+		},
+		{
+			name: "minter address has wrong hrp",
+			modify: func(args *BuildGenesisArgs) {
+				args.GenesisData["asset2"].InitialState["variableCap"][0] = Owners{
+					Threshold: 1,
+					Minters:   []string{addrMap["A9bTQjfYGBFK3JPRJqF2eh3JYL7cHocvy"], wrongHRPAddr},
+				}
+			},
+			problems: []string{`asset "asset2": initialState.variableCap[0].minters[1]`},
+		},
+		{
+			name: "malformed address",
+			modify: func(args *BuildGenesisArgs) {
+				args.GenesisData["asset1"].InitialState["fixedCap"][0] = Holder{
+					Amount:  1,
+					Address: "not an address",
+				}
+			},
+			problems: []string{`asset "asset1": initialState.fixedCap[0].address`},
+		},
+		{
+			name: "malformed memo",
+			modify: func(args *BuildGenesisArgs) {
+				asset := args.GenesisData["asset3"]
+				asset.Memo = "not hex"
+				args.GenesisData["asset3"] = asset
+			},
+			problems: []string{`asset "asset3": memo`},
+		},
+		{
+			name: "network with a different hrp",
+			modify: func(args *BuildGenesisArgs) {
+				args.NetworkID = json.Uint32(constants.MainnetID)
+			},
+			problems: []string{
+				`asset "asset1": initialState.fixedCap[0].address`,
+				`asset "asset1": initialState.fixedCap[3].address`,
+				`asset "asset2": initialState.variableCap[1].minters[1]`,
+				`asset "asset3": initialState.variableCap[0].minters[0]`,
+			},
+		},
+		{
+			name: "every problem is reported",
+			modify: func(args *BuildGenesisArgs) {
+				asset1 := args.GenesisData["asset1"]
+				asset1.Denomination = maxDenomination + 1
+				args.GenesisData["asset1"] = asset1
+				args.GenesisData["asset3"].InitialState["variableCap"] = nil
+			},
+			problems: []string{
+				`asset "asset1": denomination`,
+				`asset "asset3": initialState.variableCap: no minter sets`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := testBuildGenesisArgs(t)
+			test.modify(&args)
+
+			reply := BuildGenesisReply{}
+			err := CreateStaticService().BuildGenesis(nil, &args, &reply)
+			if !errors.Is(err, errInvalidGenesis) {
+				t.Fatalf("expected %s but got %v", errInvalidGenesis, err)
+			}
+			for _, problem := range test.problems {
+				if !strings.Contains(err.Error(), problem) {
+					t.Fatalf("expected %q to report %q", err, problem)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildGenesisDuplicateAlias(t *testing.T) {
+	addrMap := testBuildGenesisAddrs(t)
+	argsJSON := fmt.Sprintf(`{
+		"networkID": %d,
+		"encoding": "hex",
+		"genesisData": {
+			"asset1": {"name": "myAsset", "initialState": {"fixedCap": [{"amount": 1, "address": %q}]}},
+			"asset1": {"name": "myOtherAsset", "initialState": {"fixedCap": [{"amount": 2, "address": %q}]}}
+		}
+	}`, networkID, addrMap["A9bTQjfYGBFK3JPRJqF2eh3JYL7cHocvy"], addrMap["6mxBGnjGDCKgkVe7yfrmvMA7xE7qCv3vv"])
+
+	args := BuildGenesisArgs{}
+	if err := stdjson.Unmarshal([]byte(argsJSON), &args); err != nil {
+		t.Fatal(err)
+	}
+	if args.NetworkID != json.Uint32(networkID) || args.Encoding != formatting.Hex {
+		t.Fatalf("unexpected arguments %+v", args)
+	}
+	if len(args.GenesisData) != 1 || args.GenesisData["asset1"].Name != "myOtherAsset" {
+		t.Fatalf("unexpected genesis data %+v", args.GenesisData)
+	}
+
+	reply := BuildGenesisReply{}
+	err := CreateStaticService().BuildGenesis(nil, &args, &reply)
+	if !errors.Is(err, errInvalidGenesis) {
+		t.Fatalf("expected %s but got %v", errInvalidGenesis, err)
+	}
+	if !strings.Contains(err.Error(), `asset "asset1": alias is defined more than once`) {
+		t.Fatalf("expected %q to report the duplicate alias", err)
+	}
 }
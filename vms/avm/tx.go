@@ -4,6 +4,7 @@
 package avm
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -40,6 +41,7 @@ type UnsignedTx interface {
 		txFee uint64,
 		creationTxFee uint64,
 		numFxs int,
+		maxMemoSize int,
 	) error
 	SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verifiable) error
 	ExecuteWithSideEffects(vm *VM, batch database.Batch) error
@@ -56,6 +58,47 @@ type Tx struct {
 	Creds []*FxCredential `serialize:"true" json:"credentials"` // The credentials of this transaction
 }
 
+// txJSON is the JSON representation of a Tx
+type txJSON struct {
+	Type            string          `json:"type"`
+	CredentialCount int             `json:"credentialCount"`
+	UnsignedTx      UnsignedTx      `json:"unsignedTx"`
+	Creds           []*FxCredential `json:"credentials"`
+}
+
+// MarshalJSON marshals [t] as JSON, along with its type and the number of
+// credentials it carries. Init must be called before marshalling [t].
+func (t *Tx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.toJSON())
+}
+
+func (t *Tx) toJSON() txJSON {
+	return txJSON{
+		Type:            txTypeName(t.UnsignedTx),
+		CredentialCount: len(t.Creds),
+		UnsignedTx:      t.UnsignedTx,
+		Creds:           t.Creds,
+	}
+}
+
+// txTypeName returns a human readable name of the type of [utx].
+func txTypeName(utx UnsignedTx) string {
+	switch utx.(type) {
+	case *BaseTx:
+		return "base"
+	case *CreateAssetTx:
+		return "create_asset"
+	case *OperationTx:
+		return "operation"
+	case *ImportTx:
+		return "import"
+	case *ExportTx:
+		return "export"
+	default:
+		return "unknown"
+	}
+}
+
 // Init initializes FxID where required
 // Used for JSON marshaling of data
 func (t *Tx) Init(vm *VM) error {
@@ -87,12 +130,13 @@ func (t *Tx) SyntacticVerify(
 	txFee uint64,
 	creationTxFee uint64,
 	numFxs int,
+	maxMemoSize int,
 ) error {
 	if t == nil || t.UnsignedTx == nil {
 		return errNilTx
 	}
 
-	if err := t.UnsignedTx.SyntacticVerify(ctx, c, txFeeAssetID, txFee, creationTxFee, numFxs); err != nil {
+	if err := t.UnsignedTx.SyntacticVerify(ctx, c, txFeeAssetID, txFee, creationTxFee, numFxs, maxMemoSize); err != nil {
 		return err
 	}
 
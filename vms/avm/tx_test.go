@@ -48,7 +48,7 @@ func TestTxNil(t *testing.T) {
 	}
 
 	tx := (*Tx)(nil)
-	if err := tx.SyntacticVerify(ctx, m, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, m, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Should have errored due to nil tx")
 	}
 	if err := tx.SemanticVerify(nil, nil); err == nil {
@@ -60,7 +60,7 @@ func TestTxEmpty(t *testing.T) {
 	ctx := NewContext(t)
 	_, c := setupCodec()
 	tx := &Tx{}
-	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, c, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Should have errored due to nil tx")
 	}
 }
@@ -98,7 +98,7 @@ func TestTxInvalidCredential(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := tx.SyntacticVerify(ctx, m, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, m, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Tx should have failed due to an invalid credential")
 	}
 }
@@ -156,7 +156,7 @@ func TestTxInvalidUnsignedTx(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := tx.SyntacticVerify(ctx, m, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, m, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Tx should have failed due to an invalid unsigned tx")
 	}
 }
@@ -205,7 +205,7 @@ func TestTxInvalidNumberOfCredentials(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := tx.SyntacticVerify(ctx, m, ids.Empty, 0, 0, 1); err == nil {
+	if err := tx.SyntacticVerify(ctx, m, ids.Empty, 0, 0, 1, avax.MaxMemoSize); err == nil {
 		t.Fatalf("Tx should have failed due to an invalid unsigned tx")
 	}
 }
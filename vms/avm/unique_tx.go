@@ -145,6 +145,9 @@ func (tx *UniqueTx) Accept() error {
 	if err := tx.vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs); err != nil {
 		return fmt.Errorf("error indexing tx: %s", err)
 	}
+	if err := tx.vm.assetTxsIndexer.Accept(txID, assetTxRoles(txID, tx.UnsignedTx)); err != nil {
+		return fmt.Errorf("error indexing tx by asset: %w", err)
+	}
 
 	// Remove spent utxos
 	for _, utxo := range inputUTXOIDs {
@@ -160,10 +163,13 @@ func (tx *UniqueTx) Accept() error {
 	// Add new utxos
 	for _, utxo := range outputUTXOs {
 		utxoID := utxo.InputID()
-		if err := tx.vm.state.PutUTXO(utxoID, utxo); err != nil {
+		if err := tx.vm.putUTXO(utxo); err != nil {
 			return fmt.Errorf("couldn't put UTXO %s: %w", utxoID, err)
 		}
 	}
+	if err := tx.vm.putAssetDescription(txID, tx.UnsignedTx); err != nil {
+		return fmt.Errorf("couldn't index asset %s: %w", txID, err)
+	}
 
 	if err := tx.setStatus(choices.Accepted); err != nil {
 		return fmt.Errorf("couldn't set status of tx %s: %w", txID, err)
@@ -178,6 +184,8 @@ func (tx *UniqueTx) Accept() error {
 		return fmt.Errorf("ExecuteWithSideEffects errored while processing tx %s: %w", txID, err)
 	}
 
+	tx.vm.metrics.AcceptTx(tx.Tx)
+	tx.vm.pendingTxs.Accept(txID, tx.vm.clock.Time())
 	tx.vm.pubsub.Publish(NewPubSubFilterer(tx.Tx))
 	tx.vm.walletService.decided(txID)
 
@@ -203,6 +211,7 @@ func (tx *UniqueTx) Reject() error {
 		return err
 	}
 
+	tx.vm.pendingTxs.Reject(txID)
 	tx.vm.walletService.decided(txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
@@ -309,10 +318,15 @@ func (tx *UniqueTx) verifyWithoutCacheWrites() error {
 // Verify the validity of this transaction
 func (tx *UniqueTx) Verify() error {
 	if err := tx.verifyWithoutCacheWrites(); err != nil {
+		// The engine drops txs that fail verification
+		tx.vm.pendingTxs.Drop(tx.ID())
 		return err
 	}
 
 	tx.verifiedState = true
+	if tx.Status() == choices.Processing {
+		tx.vm.pendingTxs.Issue(tx.ID(), tx.InputIDs(), tx.vm.clock.Time())
+	}
 	return nil
 }
 
@@ -336,6 +350,7 @@ func (tx *UniqueTx) SyntacticVerify() error {
 		tx.vm.TxFee,
 		tx.vm.CreateAssetTxFee,
 		len(tx.vm.fxs),
+		tx.vm.maxMemoSize,
 	)
 	return tx.validity
 }
@@ -350,5 +365,23 @@ func (tx *UniqueTx) SemanticVerify() error {
 		return tx.validity
 	}
 
-	return tx.Tx.SemanticVerify(tx.vm, tx.UnsignedTx)
+	// Defer the signature checks so that all of the tx's signatures are
+	// verified in parallel once the rest of the tx is known to be valid.
+	batchFxs := make([]BatchVerifierFx, 0, len(tx.vm.fxs))
+	for _, fx := range tx.vm.fxs {
+		if batchFx, ok := fx.Fx.(BatchVerifierFx); ok {
+			batchFx.StartBatch()
+			batchFxs = append(batchFxs, batchFx)
+		}
+	}
+
+	err := tx.Tx.SemanticVerify(tx.vm, tx.UnsignedTx)
+	for _, batchFx := range batchFxs {
+		if err != nil {
+			batchFx.AbortBatch()
+			continue
+		}
+		err = batchFx.VerifyBatch()
+	}
+	return err
 }
@@ -4,15 +4,30 @@
 package avm
 
 import (
+	"crypto/rand"
 	"fmt"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/encdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
-var addresses = ids.Empty
+const (
+	// hdSeedLen is the number of random bytes in a user's HD seed
+	hdSeedLen = 64
+)
+
+var (
+	addresses = ids.Empty
+
+	// The user's HD seed and the addresses derived from it, in order of
+	// derivation index, are stored under keys that can't collide with an
+	// address
+	hdSeed           = []byte("hdSeed")
+	derivedAddresses = []byte("derivedAddresses")
+)
 
 type userState struct{ vm *VM }
 
@@ -77,3 +92,71 @@ func (s *userState) Key(db *encdb.Database, address ids.ShortID) (*crypto.Privat
 	}
 	return sk.(*crypto.PrivateKeySECP256K1R), nil
 }
+
+// DerivedAddresses returns the addresses derived from the user's HD seed,
+// where the address at index i was derived with index i.
+func (s *userState) DerivedAddresses(db *encdb.Database) ([]ids.ShortID, error) {
+	bytes, err := db.Get(derivedAddresses)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	addresses := []ids.ShortID{}
+	if _, err := s.vm.codec.Unmarshal(bytes, &addresses); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// DeriveKey derives the user's next key from their HD seed, along the BIP44
+// path m/44'/9000'/0'/0/index. The seed is generated if the user doesn't have
+// one yet. The key is saved, but it isn't added to the user's addresses.
+func (s *userState) DeriveKey(db *encdb.Database) (uint32, *crypto.PrivateKeySECP256K1R, error) {
+	seed, err := db.Get(hdSeed)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		seed = make([]byte, hdSeedLen)
+		if _, err := rand.Read(seed); err != nil {
+			return 0, nil, fmt.Errorf("problem generating seed: %w", err)
+		}
+		if err := db.Put(hdSeed, seed); err != nil {
+			return 0, nil, fmt.Errorf("problem saving seed: %w", err)
+		}
+	default:
+		return 0, nil, err
+	}
+
+	derived, err := s.DerivedAddresses(db)
+	if err != nil {
+		return 0, nil, err
+	}
+	index := uint32(len(derived))
+
+	master, err := crypto.NewMasterKeySECP256K1R(seed)
+	if err != nil {
+		return 0, nil, err
+	}
+	extendedKey, err := master.Derive(
+		crypto.HardenedKeyStart+44,
+		crypto.HardenedKeyStart+crypto.AvaxCoinType,
+		crypto.HardenedKeyStart,
+		0,
+		index,
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("problem deriving key %d: %w", index, err)
+	}
+	sk := extendedKey.PrivateKey()
+	if err := s.SetKey(db, sk); err != nil {
+		return 0, nil, err
+	}
+
+	bytes, err := s.vm.codec.Marshal(codecVersion, append(derived, sk.PublicKey().Address()))
+	if err != nil {
+		return 0, nil, err
+	}
+	return index, sk, db.Put(derivedAddresses, bytes)
+}
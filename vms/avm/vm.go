@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/pubsub"
@@ -28,14 +30,17 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
@@ -47,6 +52,14 @@ const (
 	batchSize          = 30
 	assetToFxCacheSize = 1024
 	maxUTXOsToFetch    = 1024
+
+	// We allow [recentTxsCacheSize] to be fairly large because we only store
+	// the IDs of the txs in the cache, not the txs themselves.
+	recentTxsCacheSize = 512
+
+	// defaultMaxSendOutputs is the default maximum number of outputs that can
+	// be passed to SendMultiple
+	defaultMaxSendOutputs = 512
 )
 
 var (
@@ -55,6 +68,12 @@ var (
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 	errInsufficientFunds         = errors.New("insufficient funds")
+	errFrozenAsset               = errors.New("asset is frozen")
+	errManagedAssetsNotEnabled   = errors.New("managed assets aren't enabled yet")
+	errAmbiguousAssetAlias       = errors.New("ambiguous asset alias")
+
+	// Prefix of the index of txs by the assets they involve
+	assetTxsIndexPrefix = []byte("assetTxs")
 
 	_ vertex.DAGVM = &VM{}
 )
@@ -96,15 +115,26 @@ type VM struct {
 	txs          []snowstorm.Tx
 	toEngine     chan<- common.Message
 
+	// Gossips locally issued txs. If nil, txs aren't gossiped.
+	appSender common.AppSender
+	// IDs of the txs that were recently gossiped to or from this node
+	recentTxs *cache.LRU
+
 	baseDB database.Database
 	db     *versiondb.Database
 
 	typeToFxIndex map[reflect.Type]int
 	fxs           []*parsedFx
 
+	// maximum number of bytes in a tx's memo field
+	maxMemoSize int
+	// maximum number of outputs that can be passed to SendMultiple
+	maxSendOutputs int
+
 	walletService WalletService
 
 	addressTxsIndexer index.AddressTxsIndexer
+	assetTxsIndexer   index.AssetTxsIndexer
 }
 
 func (vm *VM) Connected(id ids.ShortID) error {
@@ -124,6 +154,17 @@ func (vm *VM) Disconnected(id ids.ShortID) error {
 type Config struct {
 	IndexTransactions    bool `json:"index-transactions"`
 	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
+	// AssetDescriptionCacheSize is the number of asset descriptions to keep
+	// in memory. If non-positive, a default size is used.
+	AssetDescriptionCacheSize int `json:"asset-description-cache-size"`
+	// IndexAssetTxsBackfill rebuilds the index of txs by asset from every
+	// accepted tx if the index is missing txs. This requires replaying every
+	// accepted tx, so it must be opted into. Backfilled txs are ordered by tx
+	// ID rather than by acceptance time.
+	IndexAssetTxsBackfill bool `json:"index-asset-txs-backfill"`
+	// MaxSendOutputs is the maximum number of outputs that can be passed to
+	// a single SendMultiple call. If non-positive, a default is used.
+	MaxSendOutputs int `json:"max-send-outputs"`
 }
 
 // Initialize implements the avalanche.DAGVM interface
@@ -135,7 +176,7 @@ func (vm *VM) Initialize(
 	configBytes []byte,
 	toEngine chan<- common.Message,
 	fxs []*common.Fx,
-	_ common.AppSender,
+	appSender common.AppSender,
 ) error {
 	avmConfig := Config{}
 	if len(configBytes) > 0 {
@@ -160,6 +201,13 @@ func (vm *VM) Initialize(
 	db := dbManager.Current().Database
 	vm.ctx = ctx
 	vm.toEngine = toEngine
+	vm.appSender = appSender
+	vm.recentTxs = &cache.LRU{Size: recentTxsCacheSize}
+	vm.maxMemoSize = maxMemoSize(ctx, vm.MaxMemoSize)
+	vm.maxSendOutputs = avmConfig.MaxSendOutputs
+	if vm.maxSendOutputs <= 0 {
+		vm.maxSendOutputs = defaultMaxSendOutputs
+	}
 	vm.baseDB = db
 	vm.db = versiondb.New(db)
 	vm.assetToFxCache = &cache.LRU{Size: assetToFxCacheSize}
@@ -196,12 +244,23 @@ func (vm *VM) Initialize(
 
 	vm.AtomicUTXOManager = avax.NewAtomicUTXOManager(ctx.SharedMemory, vm.codec)
 
-	state, err := NewMeteredState(vm.db, vm.genesisCodec, vm.codec, registerer)
+	assetDescriptionCacheSize := avmConfig.AssetDescriptionCacheSize
+	if assetDescriptionCacheSize <= 0 {
+		assetDescriptionCacheSize = defaultAssetDescriptionCacheSize
+	}
+	state, err := NewMeteredState(vm.db, vm.genesisCodec, vm.codec, assetDescriptionCacheSize, registerer)
 	if err != nil {
 		return err
 	}
 	vm.state = state
 
+	// If the chain is being initialized now, the asset index only needs to
+	// be backfilled with the genesis txs.
+	stateInitialized, err := vm.state.IsInitialized()
+	if err != nil {
+		return err
+	}
+
 	if err := vm.initGenesis(genesisBytes); err != nil {
 		return err
 	}
@@ -220,18 +279,32 @@ func (vm *VM) Initialize(
 	vm.walletService.pendingTxOrdering = list.New()
 
 	// use no op impl when disabled in config
+	assetTxsDB := prefixdb.New(assetTxsIndexPrefix, vm.db)
 	if avmConfig.IndexTransactions {
 		vm.ctx.Log.Info("address transaction indexing is enabled")
 		vm.addressTxsIndexer, err = index.NewIndexer(vm.db, vm.ctx.Log, "", registerer, avmConfig.IndexAllowIncomplete)
 		if err != nil {
 			return fmt.Errorf("failed to initialize address transaction indexer: %w", err)
 		}
+
+		var backfill index.AssetTxsBackfiller
+		if !stateInitialized || avmConfig.IndexAssetTxsBackfill {
+			backfill = vm.backfillAssetTxs
+		}
+		vm.assetTxsIndexer, err = index.NewAssetTxsIndexer(assetTxsDB, vm.ctx.Log, "", registerer, backfill)
+		if err != nil {
+			return fmt.Errorf("failed to initialize asset transaction indexer: %w", err)
+		}
 	} else {
 		vm.ctx.Log.Info("address transaction indexing is disabled")
 		vm.addressTxsIndexer, err = index.NewNoIndexer(vm.db, avmConfig.IndexAllowIncomplete)
 		if err != nil {
 			return fmt.Errorf("failed to initialize disabled indexer: %w", err)
 		}
+		vm.assetTxsIndexer, err = index.NewNoAssetTxsIndexer(assetTxsDB)
+		if err != nil {
+			return fmt.Errorf("failed to initialize disabled asset indexer: %w", err)
+		}
 	}
 	return vm.db.Commit()
 }
@@ -369,6 +442,14 @@ func (vm *VM) IssueTx(b []byte) (ids.ID, error) {
 		return ids.ID{}, err
 	}
 	vm.issueTx(tx)
+	if tx.Status() == choices.Processing {
+		vm.pendingTxs.IssueLocal(tx.Tx, tx.InputIDs())
+		// The tx has already been issued, so failing to gossip it shouldn't
+		// be reported as a failure to issue it
+		if err := vm.gossipTx(tx.Tx); err != nil {
+			vm.ctx.Log.Warn("failed to gossip tx %s: %s", tx.ID(), err)
+		}
+	}
 	return tx.ID(), nil
 }
 
@@ -438,6 +519,36 @@ func (vm *VM) getPaginatedUTXOs(
 	return utxos, lastAddr, lastIndex, nil // Didn't reach the [limit] utxos; no more were found
 }
 
+// getPaginatedAtomicUTXOs returns UTXOs exported to this chain from
+// [sourceChain] with the same paging semantics as getPaginatedUTXOs. Shared
+// memory includes the UTXO at the start index in its results, so it is dropped
+// here to ensure that every returned end index makes progress.
+func (vm *VM) getPaginatedAtomicUTXOs(
+	sourceChain ids.ID,
+	addrs ids.ShortSet,
+	startAddr ids.ShortID,
+	startUTXOID ids.ID,
+	limit int,
+) ([]*avax.UTXO, ids.ShortID, ids.ID, error) {
+	if limit <= 0 || limit > maxUTXOsToFetch {
+		limit = maxUTXOsToFetch
+	}
+	if startUTXOID == ids.Empty {
+		return vm.GetAtomicUTXOs(sourceChain, addrs, startAddr, startUTXOID, limit)
+	}
+
+	utxos, lastAddr, lastUTXOID, err := vm.GetAtomicUTXOs(sourceChain, addrs, startAddr, startUTXOID, limit+1)
+	if err != nil {
+		return nil, ids.ShortID{}, ids.ID{}, err
+	}
+	if len(utxos) == 0 || utxos[0].InputID() != startUTXOID {
+		// The start UTXO has since been consumed, so nothing needs to be
+		// dropped.
+		return vm.GetAtomicUTXOs(sourceChain, addrs, startAddr, startUTXOID, limit)
+	}
+	return utxos[1:], lastAddr, lastUTXOID, nil
+}
+
 func (vm *VM) getAllUTXOs(addrs ids.ShortSet) ([]*avax.UTXO, error) {
 	seen := make(ids.Set, maxUTXOsToFetch) // IDs of UTXOs already in the list
 	utxos := make([]*avax.UTXO, 0, maxUTXOsToFetch)
@@ -571,11 +682,55 @@ func (vm *VM) initState(tx Tx) error {
 		return err
 	}
 	for _, utxo := range tx.UTXOs() {
-		if err := vm.state.PutUTXO(utxo.InputID(), utxo); err != nil {
+		if err := vm.putUTXO(utxo); err != nil {
 			return err
 		}
 	}
-	return nil
+	return vm.putAssetDescription(txID, tx.UnsignedTx)
+}
+
+// backfillAssetTxs passes the roles of every accepted tx to [accept].
+func (vm *VM) backfillAssetTxs(accept func(txID ids.ID, roles map[ids.ID]index.AssetTxRole) error) error {
+	return vm.state.ForEachAcceptedTx(func(txID ids.ID, tx *Tx) error {
+		return accept(txID, assetTxRoles(txID, tx.UnsignedTx))
+	})
+}
+
+// putAssetDescription indexes the description of the asset created by [utx],
+// if [utx] creates an asset.
+func (vm *VM) putAssetDescription(txID ids.ID, utx UnsignedTx) error {
+	desc, ok := assetDescription(utx)
+	if !ok {
+		return nil
+	}
+	return vm.state.PutAssetDescription(txID, desc)
+}
+
+// putUTXO adds [utxo] to the UTXO set. If [utxo] is the status of a managed
+// asset, it is indexed as the current status of the asset.
+func (vm *VM) putUTXO(utxo *avax.UTXO) error {
+	utxoID := utxo.InputID()
+	if err := vm.state.PutUTXO(utxoID, utxo); err != nil {
+		return err
+	}
+	if _, ok := utxo.Out.(*secp256k1fx.ManagedAssetStatusOutput); !ok {
+		return nil
+	}
+	return vm.state.PutAssetStatus(utxo.AssetID(), utxoID)
+}
+
+// maxMemoSize returns the maximum memo size to use given the network's
+// [maxMemoSize]. Mainnet and Fuji never allow memos larger than the default.
+func maxMemoSize(ctx *snow.Context, maxMemoSize int) int {
+	switch {
+	case maxMemoSize <= 0:
+		return avax.MaxMemoSize
+	case maxMemoSize > avax.MaxMemoSize && (ctx.NetworkID == constants.MainnetID || ctx.NetworkID == constants.FujiID):
+		ctx.Log.Warn("max memo size of %d exceeds the network maximum, using %d", maxMemoSize, avax.MaxMemoSize)
+		return avax.MaxMemoSize
+	default:
+		return maxMemoSize
+	}
 }
 
 func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
@@ -583,7 +738,12 @@ func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
 	if err != nil {
 		return nil, err
 	}
+	return vm.uniqueTx(rawTx)
+}
 
+// uniqueTx syntactically verifies [rawTx] and, if it wasn't already known,
+// persists it as processing.
+func (vm *VM) uniqueTx(rawTx *Tx) (*UniqueTx, error) {
 	tx := &UniqueTx{
 		TxCachedState: &TxCachedState{
 			Tx: rawTx,
@@ -658,6 +818,43 @@ func (vm *VM) getUTXO(utxoID *avax.UTXOID) (*avax.UTXO, error) {
 	return parentUTXOs[int(inputIndex)], nil
 }
 
+// utxoAddresses returns the addresses that own the UTXO [utxoID], whether or not
+// it has been spent. If the UTXO wasn't produced by a tx on this chain, or its
+// output doesn't have addresses, nil is returned.
+func (vm *VM) utxoAddresses(utxoID *avax.UTXOID) ([]string, error) {
+	inputTx, inputIndex := utxoID.InputSource()
+	parent := UniqueTx{
+		vm:   vm,
+		txID: inputTx,
+	}
+	if !parent.Status().Fetched() {
+		return nil, nil
+	}
+
+	parentUTXOs := parent.UTXOs()
+	if uint32(len(parentUTXOs)) <= inputIndex {
+		return nil, nil
+	}
+	out, ok := parentUTXOs[inputIndex].Out.(avax.Addressable)
+	if !ok {
+		return nil, nil
+	}
+
+	addrs := out.Addresses()
+	formattedAddrs := make([]string, len(addrs))
+	for i, addrBytes := range addrs {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return nil, err
+		}
+		formattedAddrs[i], err = vm.FormatLocalAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return formattedAddrs, nil
+}
+
 func (vm *VM) getFx(val interface{}) (int, error) {
 	valType := reflect.TypeOf(val)
 	fx, exists := vm.typeToFxIndex[valType]
@@ -727,6 +924,10 @@ func (vm *VM) verifyTransferOfUTXO(tx UnsignedTx, in *avax.TransferableInput, cr
 		return errIncompatibleFx
 	}
 
+	if err := vm.verifyNotFrozen(inAssetID); err != nil {
+		return err
+	}
+
 	return fx.VerifyTransfer(tx, in.In, cred, utxo.Out)
 }
 
@@ -765,9 +966,55 @@ func (vm *VM) verifyOperation(tx UnsignedTx, op *Operation, cred verify.Verifiab
 	if !vm.verifyFxUsage(fxIndex, opAssetID) {
 		return errIncompatibleFx
 	}
+
+	// The manager of a frozen asset must still be able to unfreeze it
+	if _, ok := op.Op.(*secp256k1fx.UpdateManagedAssetOperation); ok {
+		if err := vm.verifyManagedAssetsEnabled(); err != nil {
+			return err
+		}
+	} else if err := vm.verifyNotFrozen(opAssetID); err != nil {
+		return err
+	}
 	return fx.VerifyOperation(tx, op.Op, cred, utxos)
 }
 
+// verifyNotFrozen returns an error if [assetID] is managed and its accepted
+// status is frozen. Transfers don't consume the status of the asset, so holders
+// of the asset can transfer it concurrently, and transfers don't conflict with
+// updates of the status. A freeze applies to the transfers verified after the
+// freeze is accepted.
+func (vm *VM) verifyNotFrozen(assetID ids.ID) error {
+	utxoID, err := vm.state.GetAssetStatus(assetID)
+	if err == database.ErrNotFound {
+		// [assetID] isn't managed
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	utxo, err := vm.state.GetUTXO(utxoID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the status of asset %s: %w", assetID, err)
+	}
+	status, ok := utxo.Out.(*secp256k1fx.ManagedAssetStatusOutput)
+	if !ok {
+		return errInvalidUTXO
+	}
+	if status.Frozen {
+		return fmt.Errorf("%w: %s", errFrozenAsset, assetID)
+	}
+	return nil
+}
+
+// verifyManagedAssetsEnabled returns an error if managed assets can't be
+// created or updated yet.
+func (vm *VM) verifyManagedAssetsEnabled() error {
+	if vm.clock.Time().Before(vm.ApricotPhase6Time) {
+		return errManagedAssetsNotEnabled
+	}
+	return nil
+}
+
 // LoadUser returns:
 // 1) The UTXOs that reference one or more addresses controlled by the given user
 // 2) A keychain that contains this user's keys
@@ -859,7 +1106,8 @@ func (vm *VM) Spend(
 
 	for asset, amount := range amounts {
 		if amountsSpent[asset] < amount {
-			return nil, nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
+			return nil, nil, nil, fmt.Errorf("%w: want to spend %d of asset %s but only have %d",
+				errInsufficientFunds,
 				amount,
 				asset,
 				amountsSpent[asset],
@@ -1067,6 +1315,208 @@ func (vm *VM) Mint(
 	return ops, keys, nil
 }
 
+// UpdateManagedAsset returns an operation that sets whether transfers of the
+// managed asset [assetID] are frozen. The manager of the asset is unchanged.
+// Returns an error if none of [utxos] is the status of the asset, or if [kc]
+// can't sign on behalf of its manager.
+func (vm *VM) UpdateManagedAsset(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	frozen bool,
+) (
+	*Operation,
+	[]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	time := vm.clock.Unix()
+
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			continue
+		}
+
+		out, ok := utxo.Out.(*secp256k1fx.ManagedAssetStatusOutput)
+		if !ok {
+			continue
+		}
+		switch {
+		case frozen && out.Frozen:
+			return nil, nil, errAssetAlreadyFrozen
+		case !frozen && !out.Frozen:
+			return nil, nil, errAssetNotFrozen
+		}
+
+		inIntf, signers, err := kc.Spend(out, time)
+		if err != nil {
+			continue
+		}
+
+		in, ok := inIntf.(*secp256k1fx.Input)
+		if !ok {
+			continue
+		}
+
+		return &Operation{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*avax.UTXOID{&utxo.UTXOID},
+			Op: &secp256k1fx.UpdateManagedAssetOperation{
+				Input: *in,
+				Status: secp256k1fx.ManagedAssetStatusOutput{
+					Frozen:  frozen,
+					Manager: out.Manager,
+				},
+			},
+		}, signers, nil
+	}
+	return nil, nil, errAddressesCantManage
+}
+
+// assetDescriptionFromTx returns the description of [assetID] from the
+// tx that created it. Unlike the descriptions in [vm.state], the tx doesn't
+// need to have been accepted.
+func (vm *VM) assetDescriptionFromTx(assetID ids.ID) (*AssetDescription, error) {
+	tx := &UniqueTx{
+		vm:   vm,
+		txID: assetID,
+	}
+	if status := tx.Status(); !status.Fetched() {
+		return nil, errUnknownAssetID
+	}
+	desc, ok := assetDescription(tx.UnsignedTx)
+	if !ok {
+		return nil, errTxNotCreateAsset
+	}
+	return desc, nil
+}
+
+// verifyPropertyAsset returns an error if [assetID] wasn't created with any
+// propertyfx outputs.
+func (vm *VM) verifyPropertyAsset(assetID ids.ID) error {
+	tx := &UniqueTx{
+		vm:   vm,
+		txID: assetID,
+	}
+	if status := tx.Status(); !status.Fetched() {
+		return errUnknownAssetID
+	}
+	createAssetTx, ok := tx.UnsignedTx.(*CreateAssetTx)
+	if !ok {
+		return errTxNotCreateAsset
+	}
+	for _, state := range createAssetTx.States {
+		if int(state.FxIndex) >= len(vm.fxs) || len(state.Outs) == 0 {
+			continue
+		}
+		if _, ok := vm.fxs[state.FxIndex].Fx.(*propertyfx.Fx); ok {
+			return nil
+		}
+	}
+	return errNoPropertyOutputs
+}
+
+// MintProperty returns the operation that consumes a propertyfx mint output
+// of [assetID] that [kc] can spend, and mints the property to [to].
+func (vm *VM) MintProperty(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	to ids.ShortID,
+) (
+	*Operation,
+	[]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	if err := vm.verifyPropertyAsset(assetID); err != nil {
+		return nil, nil, err
+	}
+
+	time := vm.clock.Unix()
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		out, ok := utxo.Out.(*propertyfx.MintOutput)
+		if !ok {
+			continue
+		}
+		indices, signers, ok := kc.Match(&out.OutputOwners, time)
+		if !ok {
+			continue
+		}
+
+		return &Operation{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*avax.UTXOID{&utxo.UTXOID},
+			Op: &propertyfx.MintOperation{
+				MintInput: secp256k1fx.Input{
+					SigIndices: indices,
+				},
+				MintOutput: *out,
+				OwnedOutput: propertyfx.OwnedOutput{
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{to},
+					},
+				},
+			},
+		}, signers, nil
+	}
+	return nil, nil, errCantMintProperty
+}
+
+// BurnProperty returns the operations that burn every propertyfx owned output
+// of [assetID] that [kc] can spend.
+func (vm *VM) BurnProperty(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	if err := vm.verifyPropertyAsset(assetID); err != nil {
+		return nil, nil, err
+	}
+
+	time := vm.clock.Unix()
+	ops := []*Operation{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	for _, utxo := range utxos {
+		// makes sure that the variable isn't overwritten with the next iteration
+		utxo := utxo
+
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		out, ok := utxo.Out.(*propertyfx.OwnedOutput)
+		if !ok {
+			continue
+		}
+		indices, signers, ok := kc.Match(&out.OutputOwners, time)
+		if !ok {
+			continue
+		}
+
+		ops = append(ops, &Operation{
+			Asset:   utxo.Asset,
+			UTXOIDs: []*avax.UTXOID{&utxo.UTXOID},
+			Op: &propertyfx.BurnOperation{Input: secp256k1fx.Input{
+				SigIndices: indices,
+			}},
+		})
+		keys = append(keys, signers)
+	}
+
+	if len(ops) == 0 {
+		return nil, nil, errCantBurnProperty
+	}
+
+	sortOperationsWithSigners(ops, keys, vm.codec)
+	return ops, keys, nil
+}
+
 func (vm *VM) MintNFT(
 	utxos []*avax.UTXO,
 	kc *secp256k1fx.Keychain,
@@ -1077,18 +1527,39 @@ func (vm *VM) MintNFT(
 	[]*Operation,
 	[][]*crypto.PrivateKeySECP256K1R,
 	error,
+) {
+	return vm.MintNFTs(utxos, kc, assetID, [][]byte{payload}, []ids.ShortID{to}, math.MaxInt32)
+}
+
+// MintNFTs returns the operations that mint [payloads[i]] to [tos[i]], in
+// order, each consuming a different mint output of [assetID]. Minting stops
+// once there are no more mint outputs that [kc] can spend, or once the
+// operations and their credentials would take more than [maxSize] bytes, so
+// fewer operations than [payloads] may be returned.
+func (vm *VM) MintNFTs(
+	utxos []*avax.UTXO,
+	kc *secp256k1fx.Keychain,
+	assetID ids.ID,
+	payloads [][]byte,
+	tos []ids.ShortID,
+	maxSize int,
+) (
+	[]*Operation,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
 ) {
 	time := vm.clock.Unix()
 
 	ops := []*Operation{}
 	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	size := 0
 
 	for _, utxo := range utxos {
 		// makes sure that the variable isn't overwritten with the next iteration
 		utxo := utxo
 
-		if len(ops) > 0 {
-			// we have already been able to create the operation needed
+		if len(ops) == len(payloads) {
+			// we have already been able to create the operations needed
 			break
 		}
 
@@ -1108,8 +1579,7 @@ func (vm *VM) MintNFT(
 			continue
 		}
 
-		// add the operation to the array
-		ops = append(ops, &Operation{
+		op := &Operation{
 			Asset: avax.Asset{ID: assetID},
 			UTXOIDs: []*avax.UTXOID{
 				&utxo.UTXOID,
@@ -1119,13 +1589,27 @@ func (vm *VM) MintNFT(
 					SigIndices: indices,
 				},
 				GroupID: out.GroupID,
-				Payload: payload,
+				Payload: payloads[len(ops)],
 				Outputs: []*secp256k1fx.OutputOwners{{
 					Threshold: 1,
-					Addrs:     []ids.ShortID{to},
+					Addrs:     []ids.ShortID{tos[len(ops)]},
 				}},
 			},
-		})
+		}
+		opBytes, err := vm.codec.Marshal(codecVersion, op)
+		if err != nil {
+			return nil, nil, err
+		}
+		// The credential is the fx type ID, the number of signatures and the
+		// signatures themselves.
+		credSize := 2*wrappers.IntLen + len(signers)*crypto.SECP256K1RSigLen
+		size += len(opBytes) + credSize
+		if size > maxSize {
+			break
+		}
+
+		// add the operation to the array
+		ops = append(ops, op)
 		// add the required keys to the array
 		keys = append(keys, signers)
 	}
@@ -1160,7 +1644,46 @@ func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
 	if assetID, err := ids.FromString(asset); err == nil {
 		return assetID, nil
 	}
-	return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
+	assetIDs, err := vm.state.AssetIDsWithSymbol(asset)
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("couldn't look up asset '%s': %w", asset, err)
+	}
+	switch len(assetIDs) {
+	case 0:
+		return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
+	case 1:
+		return assetIDs[0], nil
+	default:
+		return ids.ID{}, fmt.Errorf("%w '%s' could refer to any of %v", errAmbiguousAssetAlias, asset, assetIDs)
+	}
+}
+
+// lookupSourceChain returns the ID of [chain], which is either an alias or
+// the ID of a chain in this chain's subnet. If [chain] is empty, the ID of this
+// chain is returned.
+func (vm *VM) lookupSourceChain(chain string) (ids.ID, error) {
+	if chain == "" {
+		return vm.ctx.ChainID, nil
+	}
+	chainID, err := vm.ctx.BCLookup.Lookup(chain)
+	if err != nil {
+		chainID, err = ids.FromString(chain)
+		if err != nil {
+			return ids.ID{}, fmt.Errorf("%w: %q", errUnknownChain, chain)
+		}
+	}
+	if chainID == vm.ctx.ChainID {
+		return chainID, nil
+	}
+
+	subnetID, err := vm.ctx.SNLookup.SubnetID(chainID)
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("%w: %q", errUnknownChain, chain)
+	}
+	if subnetID != vm.ctx.SubnetID {
+		return ids.ID{}, fmt.Errorf("%w: %q is validated by subnet %s", errSourceChainNotInSubnet, chain, subnetID)
+	}
+	return chainID, nil
 }
 
 // This VM doesn't (currently) have any app-specific messages
@@ -1178,7 +1701,77 @@ func (vm *VM) AppRequestFailed(nodeID ids.ShortID, requestID uint32) error {
 	return nil
 }
 
-// This VM doesn't (currently) have any app-specific messages
+// AppGossip issues the tx gossiped by [nodeID] into consensus. Txs that are
+// invalid or already known are dropped, as is all gossip received while
+// bootstrapping.
 func (vm *VM) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	if !vm.bootstrapped {
+		vm.ctx.Log.Verbo("dropping AppGossip from %s while bootstrapping", nodeID.PrefixedString(constants.NodeIDPrefix))
+		return nil
+	}
+
+	vm.metrics.numReceivedGossipTxs.Inc()
+
+	rawTx, err := vm.parsePrivateTx(msg)
+	if err != nil {
+		vm.ctx.Log.Debug("AppGossip from %s provided an unparsable tx: %s", nodeID.PrefixedString(constants.NodeIDPrefix), err)
+		vm.metrics.numInvalidGossipTxs.Inc()
+		return nil
+	}
+
+	txID := rawTx.ID()
+	if _, seen := vm.recentTxs.Get(txID); seen {
+		vm.metrics.numDuplicateGossipTxs.Inc()
+		return nil
+	}
+	vm.recentTxs.Put(txID, nil)
+	if status := (&UniqueTx{vm: vm, txID: txID}).Status(); status != choices.Unknown {
+		vm.metrics.numDuplicateGossipTxs.Inc()
+		return nil
+	}
+
+	// Verify the tx before persisting it, so that peers can't fill our
+	// database with invalid txs
+	unverifiedTx := &UniqueTx{
+		TxCachedState: &TxCachedState{
+			Tx: rawTx,
+		},
+		vm:   vm,
+		txID: txID,
+	}
+	if err := unverifiedTx.SemanticVerify(); err != nil {
+		vm.ctx.Log.Debug("AppGossip from %s provided invalid tx %s: %s", nodeID.PrefixedString(constants.NodeIDPrefix), txID, err)
+		vm.metrics.numInvalidGossipTxs.Inc()
+		return nil
+	}
+
+	tx, err := vm.uniqueTx(rawTx)
+	if err != nil {
+		vm.ctx.Log.Debug("AppGossip from %s provided invalid tx %s: %s", nodeID.PrefixedString(constants.NodeIDPrefix), txID, err)
+		vm.metrics.numInvalidGossipTxs.Inc()
+		return nil
+	}
+	vm.issueTx(tx)
+	return nil
+}
+
+// gossipTx sends [tx] to a sample of this node's peers, unless it was recently
+// gossiped.
+func (vm *VM) gossipTx(tx *Tx) error {
+	if vm.appSender == nil || !vm.bootstrapped {
+		return nil
+	}
+
+	txID := tx.ID()
+	if _, seen := vm.recentTxs.Get(txID); seen {
+		return nil
+	}
+	vm.recentTxs.Put(txID, nil)
+
+	vm.ctx.Log.Debug("gossiping tx %s", txID)
+	if err := vm.appSender.SendAppGossip(tx.Bytes()); err != nil {
+		return err
+	}
+	vm.metrics.numGossipedTxs.Inc()
 	return nil
 }
@@ -15,7 +15,9 @@ import (
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -28,6 +30,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,6 +41,10 @@ var (
 	testTxFee              = uint64(1000)
 	startBalance           = uint64(50000)
 
+	// The test genesis is built without a network ID, so its addresses use
+	// the HRP of network 0
+	genesisHRP = constants.GetHRP(0)
+
 	keys  []*crypto.PrivateKeySECP256K1R
 	addrs []ids.ShortID // addrs[i] corresponds to keys[i]
 
@@ -147,9 +154,9 @@ func GetAVAXTxFromGenesisTest(genesisBytes []byte, tb testing.TB) *Tx {
 
 // BuildGenesisTest is the common Genesis builder for most tests
 func BuildGenesisTest(tb testing.TB) []byte {
-	addr0Str, _ := formatting.FormatBech32(testHRP, addrs[0].Bytes())
-	addr1Str, _ := formatting.FormatBech32(testHRP, addrs[1].Bytes())
-	addr2Str, _ := formatting.FormatBech32(testHRP, addrs[2].Bytes())
+	addr0Str, _ := formatting.FormatBech32(genesisHRP, addrs[0].Bytes())
+	addr1Str, _ := formatting.FormatBech32(genesisHRP, addrs[1].Bytes())
+	addr2Str, _ := formatting.FormatBech32(genesisHRP, addrs[2].Bytes())
 
 	defaultArgs := &BuildGenesisArgs{
 		Encoding: formatting.Hex,
@@ -712,6 +719,120 @@ func TestIssueTx(t *testing.T) {
 	}
 }
 
+// Ensure that locally issued txs are gossiped once
+func TestIssueTxGossip(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	gossiped := [][]byte(nil)
+	vm.appSender = &common.SenderTest{
+		T:                 t,
+		CantSendAppGossip: true,
+		SendAppGossipF: func(msg []byte) error {
+			gossiped = append(gossiped, msg)
+			return nil
+		},
+	}
+
+	newTx := NewTx(t, genesisBytes, vm)
+	_, err := vm.IssueTx(newTx.Bytes())
+	assert.NoError(err)
+	assert.Equal([][]byte{newTx.Bytes()}, gossiped)
+
+	// Issuing the tx again doesn't gossip it again
+	_, err = vm.IssueTx(newTx.Bytes())
+	assert.NoError(err)
+	assert.Len(gossiped, 1)
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.numGossipedTxs))
+}
+
+// Ensure that a tx is issued even if it can't be gossiped
+func TestIssueTxGossipFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	vm.appSender = &common.SenderTest{
+		SendAppGossipF: func([]byte) error {
+			return errors.New("")
+		},
+	}
+
+	newTx := NewTx(t, genesisBytes, vm)
+	txID, err := vm.IssueTx(newTx.Bytes())
+	assert.NoError(err)
+	assert.Equal(newTx.ID(), txID)
+	assert.Len(vm.PendingTxs(), 1)
+	assert.Equal(0.0, testutil.ToFloat64(vm.metrics.numGossipedTxs))
+}
+
+func TestAppGossip(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, ctx, issueTxs := setupIssueTx(t)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+	// Gossiped txs aren't gossiped again
+	vm.appSender = &common.SenderTest{T: t, CantSendAppGossip: true}
+	nodeID := ids.GenerateTestShortID()
+	txBytes := issueTxs[1].Bytes()
+
+	// Gossip is dropped while bootstrapping
+	vm.bootstrapped = false
+	assert.NoError(vm.AppGossip(nodeID, txBytes))
+	assert.Empty(vm.txs)
+	assert.Equal(0.0, testutil.ToFloat64(vm.metrics.numReceivedGossipTxs))
+	vm.bootstrapped = true
+
+	assert.NoError(vm.AppGossip(nodeID, []byte{1, 2, 3}))
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.numInvalidGossipTxs))
+
+	// A tx for another network fails syntactic verification
+	wrongNetworkTx := &Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID + 1,
+		BlockchainID: chainID,
+	}}}
+	assert.NoError(wrongNetworkTx.SignSECP256K1Fx(vm.codec, nil))
+	assert.NoError(vm.AppGossip(nodeID, wrongNetworkTx.Bytes()))
+	assert.Equal(2.0, testutil.ToFloat64(vm.metrics.numInvalidGossipTxs))
+	assert.Equal(choices.Unknown, (&UniqueTx{vm: vm, txID: wrongNetworkTx.ID()}).Status())
+
+	// A tx signed by the wrong key fails semantic verification, and isn't
+	// persisted
+	wrongSignerTx, err := vm.parsePrivateTx(txBytes)
+	assert.NoError(err)
+	wrongSignerTx.Creds = nil
+	assert.NoError(wrongSignerTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[1]}}))
+	assert.NoError(vm.AppGossip(nodeID, wrongSignerTx.Bytes()))
+	assert.Equal(3.0, testutil.ToFloat64(vm.metrics.numInvalidGossipTxs))
+	assert.Empty(vm.txs)
+	assert.Equal(choices.Unknown, (&UniqueTx{vm: vm, txID: wrongSignerTx.ID()}).Status())
+
+	assert.NoError(vm.AppGossip(nodeID, txBytes))
+	assert.Len(vm.txs, 1)
+	assert.Equal(issueTxs[1].ID(), vm.txs[0].ID())
+	assert.Equal(choices.Processing, (&UniqueTx{vm: vm, txID: issueTxs[1].ID()}).Status())
+
+	assert.NoError(vm.AppGossip(nodeID, txBytes))
+	assert.Len(vm.txs, 1)
+	assert.Equal(5.0, testutil.ToFloat64(vm.metrics.numReceivedGossipTxs))
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.numDuplicateGossipTxs))
+	assert.Equal(3.0, testutil.ToFloat64(vm.metrics.numInvalidGossipTxs))
+}
+
 func TestGenesisGetUTXOs(t *testing.T) {
 	_, _, vm, _ := GenesisVM(t)
 	ctx := vm.ctx
@@ -754,9 +875,9 @@ func TestGenesisGetUTXOs(t *testing.T) {
 // - Pagination when the total UTXOs exceed maxUTXOsToFetch (1024)
 // - Fetching all UTXOs when they exceed maxUTXOsToFetch (1024)
 func TestGenesisGetPaginatedUTXOs(t *testing.T) {
-	addr0Str, _ := formatting.FormatBech32(testHRP, addrs[0].Bytes())
-	addr1Str, _ := formatting.FormatBech32(testHRP, addrs[1].Bytes())
-	addr2Str, _ := formatting.FormatBech32(testHRP, addrs[2].Bytes())
+	addr0Str, _ := formatting.FormatBech32(genesisHRP, addrs[0].Bytes())
+	addr1Str, _ := formatting.FormatBech32(genesisHRP, addrs[1].Bytes())
+	addr2Str, _ := formatting.FormatBech32(genesisHRP, addrs[2].Bytes())
 
 	// Create a starting point of 3000 UTXOs on different addresses
 	utxoCount := 2345
@@ -1190,9 +1311,9 @@ func TestIssueProperty(t *testing.T) {
 }
 
 func setupTxFeeAssets(t *testing.T) ([]byte, chan common.Message, *VM, *atomic.Memory) {
-	addr0Str, _ := formatting.FormatBech32(testHRP, addrs[0].Bytes())
-	addr1Str, _ := formatting.FormatBech32(testHRP, addrs[1].Bytes())
-	addr2Str, _ := formatting.FormatBech32(testHRP, addrs[2].Bytes())
+	addr0Str, _ := formatting.FormatBech32(genesisHRP, addrs[0].Bytes())
+	addr1Str, _ := formatting.FormatBech32(genesisHRP, addrs[1].Bytes())
+	addr2Str, _ := formatting.FormatBech32(genesisHRP, addrs[2].Bytes())
 	assetAlias := "asset1"
 	customArgs := &BuildGenesisArgs{
 		Encoding: formatting.Hex,
@@ -1575,3 +1696,20 @@ func TestTxVerifyAfterVerifyAncestorTx(t *testing.T) {
 		t.Fatalf("Should have errored due to a missing UTXO")
 	}
 }
+
+func TestMaxMemoSize(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := NewContext(t)
+	assert.Equal(avax.MaxMemoSize, maxMemoSize(ctx, 0))
+	assert.Equal(avax.MaxMemoSize, maxMemoSize(ctx, -1))
+	assert.Equal(16, maxMemoSize(ctx, 16))
+	assert.Equal(2*avax.MaxMemoSize, maxMemoSize(ctx, 2*avax.MaxMemoSize))
+
+	// Mainnet and Fuji can't use memos larger than the default
+	for _, networkID := range []uint32{constants.MainnetID, constants.FujiID} {
+		ctx.NetworkID = networkID
+		assert.Equal(16, maxMemoSize(ctx, 16))
+		assert.Equal(avax.MaxMemoSize, maxMemoSize(ctx, 2*avax.MaxMemoSize))
+	}
+}
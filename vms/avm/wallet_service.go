@@ -113,10 +113,8 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 
 	// Validate the memo field
 	memoBytes := []byte(args.Memo)
-	if l := len(memoBytes); l > avax.MaxMemoSize {
-		return fmt.Errorf("max memo length is %d but provided memo field is length %d",
-			avax.MaxMemoSize,
-			l)
+	if err := avax.VerifyMemoFieldLength(memoBytes, w.vm.maxMemoSize); err != nil {
+		return err
 	} else if len(args.Outputs) == 0 {
 		return errNoOutputs
 	}
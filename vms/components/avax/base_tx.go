@@ -16,6 +16,9 @@ import (
 const MaxMemoSize = 256
 
 var (
+	// ErrMemoTooLarge is returned when a tx's memo exceeds the maximum size
+	ErrMemoTooLarge = errors.New("memo is too large")
+
 	errNilTx          = errors.New("nil tx is not valid")
 	errWrongNetworkID = errors.New("tx has wrong network ID")
 	errWrongChainID   = errors.New("tx has wrong chain ID")
@@ -75,6 +78,12 @@ func (t *BaseTx) UTXOs() []*UTXO {
 
 // MetadataVerify ensures that transaction metadata is valid
 func (t *BaseTx) MetadataVerify(ctx *snow.Context) error {
+	return t.MetadataVerifyWithMaxMemoSize(ctx, MaxMemoSize)
+}
+
+// MetadataVerifyWithMaxMemoSize ensures that transaction metadata is valid and
+// that the memo is at most [maxMemoSize] bytes
+func (t *BaseTx) MetadataVerifyWithMaxMemoSize(ctx *snow.Context, maxMemoSize int) error {
 	switch {
 	case t == nil:
 		return errNilTx
@@ -82,10 +91,20 @@ func (t *BaseTx) MetadataVerify(ctx *snow.Context) error {
 		return errWrongNetworkID
 	case t.BlockchainID != ctx.ChainID:
 		return errWrongChainID
-	case len(t.Memo) > MaxMemoSize:
-		return fmt.Errorf("memo length, %d, exceeds maximum memo length, %d",
-			len(t.Memo), MaxMemoSize)
 	default:
+		if err := VerifyMemoFieldLength(t.Memo, maxMemoSize); err != nil {
+			return err
+		}
 		return t.Metadata.Verify()
 	}
 }
+
+// VerifyMemoFieldLength returns an error if [memo] is longer than
+// [maxMemoSize] bytes
+func VerifyMemoFieldLength(memo []byte, maxMemoSize int) error {
+	if l := len(memo); l > maxMemoSize {
+		return fmt.Errorf("%w: memo is %d bytes but the maximum is %d bytes",
+			ErrMemoTooLarge, l, maxMemoSize)
+	}
+	return nil
+}
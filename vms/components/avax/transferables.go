@@ -129,8 +129,11 @@ type TransferableInput struct {
 	UTXOID `serialize:"true"`
 	Asset  `serialize:"true"`
 	// FxID has serialize false because we don't want this to be encoded in bytes
-	FxID ids.ID         `serialize:"false" json:"fxID"`
-	In   TransferableIn `serialize:"true" json:"input"`
+	FxID ids.ID `serialize:"false" json:"fxID"`
+	// Addresses that own the consumed UTXO, if known. Only used for JSON
+	// marshalling, so it isn't encoded in bytes either.
+	Addresses []string       `serialize:"false" json:"addresses,omitempty"`
+	In        TransferableIn `serialize:"true" json:"input"`
 }
 
 // Input returns the feature extension input that this Input is using.
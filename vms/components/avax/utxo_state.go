@@ -37,9 +37,10 @@ type UTXOState interface {
 	// DeleteUTXO deletes the provided utxo from storage.
 	DeleteUTXO(utxoID ids.ID) error
 
-	// UTXOIDs returns the slice of IDs associated with [addr], starting after
-	// [previous].
-	// If [previous] is not in the list, starts at beginning.
+	// UTXOIDs returns the slice of IDs associated with [addr], in increasing
+	// order, starting after [previous].
+	// [previous] doesn't need to still be associated with [addr], so that
+	// pagination isn't affected by the previously returned UTXO being spent.
 	// Returns at most [limit] IDs.
 	UTXOIDs(addr []byte, previous ids.ID, limit int) ([]ids.ID, error)
 }
@@ -95,6 +96,19 @@ func NewMeteredUTXOState(db database.Database, codec codec.Manager, metrics prom
 	}, err
 }
 
+// NumUTXOs returns the number of UTXOs stored by a UTXOState backed by [db].
+// Every UTXO is iterated over, so this can be slow.
+func NumUTXOs(db database.Database) (int, error) {
+	iter := prefixdb.New(utxoPrefix, db).NewIterator()
+	defer iter.Release()
+
+	numUTXOs := 0
+	for iter.Next() {
+		numUTXOs++
+	}
+	return numUTXOs, iter.Error()
+}
+
 func (s *utxoState) GetUTXO(utxoID ids.ID) (*UTXO, error) {
 	if utxoIntf, found := s.utxoCache.Get(utxoID); found {
 		if utxoIntf == nil {
@@ -176,7 +190,7 @@ func (s *utxoState) DeleteUTXO(utxoID ids.ID) error {
 
 func (s *utxoState) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error) {
 	indexList := s.getIndexDB(addr)
-	iter := indexList.NewIteratorWithStart(start[:])
+	iter := indexList.NewSortedIteratorWithStart(start[:])
 	defer iter.Release()
 
 	utxoIDs := []ids.ID(nil)
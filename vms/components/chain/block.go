@@ -29,8 +29,10 @@ func (bw *BlockWrapper) Verify() error {
 	}
 
 	blkID := bw.ID()
+	bw.state.verifiedBlocksLock.Lock()
 	bw.state.unverifiedBlocks.Evict(blkID)
 	bw.state.verifiedBlocks[blkID] = bw
+	bw.state.verifiedBlocksLock.Unlock()
 	return nil
 }
 
@@ -38,8 +40,10 @@ func (bw *BlockWrapper) Verify() error {
 // block, and updates the last accepted block.
 func (bw *BlockWrapper) Accept() error {
 	blkID := bw.ID()
+	bw.state.verifiedBlocksLock.Lock()
 	delete(bw.state.verifiedBlocks, blkID)
 	bw.state.decidedBlocks.Put(blkID, bw)
+	bw.state.verifiedBlocksLock.Unlock()
 	bw.state.lastAcceptedBlock = bw
 
 	return bw.Block.Accept()
@@ -49,7 +53,9 @@ func (bw *BlockWrapper) Accept() error {
 // decided block.
 func (bw *BlockWrapper) Reject() error {
 	blkID := bw.ID()
+	bw.state.verifiedBlocksLock.Lock()
 	delete(bw.state.verifiedBlocks, blkID)
 	bw.state.decidedBlocks.Put(blkID, bw)
+	bw.state.verifiedBlocksLock.Unlock()
 	return bw.Block.Reject()
 }
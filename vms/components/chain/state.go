@@ -5,6 +5,7 @@ package chain
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
@@ -32,6 +33,9 @@ type State struct {
 	// getStatus returns the status of the block
 	getStatus func(snowman.Block) (choices.Status, error)
 
+	// verifiedBlocksLock protects [verifiedBlocks], as blocks may be verified
+	// concurrently with the other operations on the state.
+	verifiedBlocksLock sync.RWMutex
 	// verifiedBlocks is a map of blocks that have been verified and are
 	// therefore currently in consensus.
 	verifiedBlocks map[ids.ID]*BlockWrapper
@@ -212,6 +216,9 @@ func (s *State) GetBlock(blkID ids.ID) (snowman.Block, error) {
 // getCachedBlock checks the caches for [blkID] by priority. Returning
 // true if [blkID] is found in one of the caches.
 func (s *State) getCachedBlock(blkID ids.ID) (snowman.Block, bool) {
+	s.verifiedBlocksLock.RLock()
+	defer s.verifiedBlocksLock.RUnlock()
+
 	if blk, ok := s.verifiedBlocks[blkID]; ok {
 		return blk, true
 	}
@@ -0,0 +1,278 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// The roles a transaction can play for an asset
+const (
+	// AssetTxCreated is the role of the transaction that created the asset
+	AssetTxCreated AssetTxRole = 1 << iota
+	// AssetTxMinted is the role of a transaction that minted more of the asset
+	AssetTxMinted
+	// AssetTxTransferred is the role of a transaction that consumed or
+	// produced the asset in any other way, including burning it
+	AssetTxTransferred
+
+	// AssetTxAllRoles matches transactions regardless of their role
+	AssetTxAllRoles = AssetTxCreated | AssetTxMinted | AssetTxTransferred
+)
+
+var (
+	assetTxRoleNames = []struct {
+		role AssetTxRole
+		name string
+	}{
+		{AssetTxCreated, "created"},
+		{AssetTxMinted, "minted"},
+		{AssetTxTransferred, "transferred"},
+	}
+
+	errUnknownAssetTxRole = errors.New("unknown asset transaction role")
+
+	// ErrAssetIndexingDisabled is returned when reading from the asset index
+	// on a node that isn't indexing transactions
+	ErrAssetIndexingDisabled = errors.New("asset transaction indexing is disabled. Restart the node with indexing enabled")
+)
+
+// AssetTxRole is a set of the roles that a transaction played for an asset
+type AssetTxRole byte
+
+// ParseAssetTxRole returns the role named [name]
+func ParseAssetTxRole(name string) (AssetTxRole, error) {
+	for _, roleName := range assetTxRoleNames {
+		if roleName.name == name {
+			return roleName.role, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownAssetTxRole, name)
+}
+
+// Names returns the names of the roles in this set
+func (r AssetTxRole) Names() []string {
+	names := []string{}
+	for _, roleName := range assetTxRoleNames {
+		if r&roleName.role != 0 {
+			names = append(names, roleName.name)
+		}
+	}
+	return names
+}
+
+// MarshalJSON marshals the set of roles as a list of their names
+func (r AssetTxRole) MarshalJSON() ([]byte, error) { return json.Marshal(r.Names()) }
+
+// UnmarshalJSON unmarshals a list of role names into a set of roles
+func (r *AssetTxRole) UnmarshalJSON(b []byte) error {
+	names := []string(nil)
+	if err := json.Unmarshal(b, &names); err != nil {
+		return err
+	}
+	*r = 0
+	for _, name := range names {
+		role, err := ParseAssetTxRole(name)
+		if err != nil {
+			return err
+		}
+		*r |= role
+	}
+	return nil
+}
+
+// AssetTx is a transaction that involved an asset
+type AssetTx struct {
+	TxID  ids.ID      `json:"txID"`
+	Roles AssetTxRole `json:"roles"`
+}
+
+// AssetTxsIndexer maintains which transactions involved which assets, and the
+// roles they played for each of them.
+type AssetTxsIndexer interface {
+	// Accept is called when [txID] is accepted.
+	// Persists the roles that [txID] played for each asset in [roles].
+	// If the error is non-nil, do not persist [txID] to disk as accepted in
+	// the VM
+	Accept(txID ids.ID, roles map[ids.ID]AssetTxRole) error
+
+	// Read returns the transactions that played any of [roles] for
+	// [assetID], in order of increasing acceptance time, along with the
+	// cursor to read the next page from.
+	// The length of the returned slice <= [pageSize].
+	// [cursor] is the offset to start reading from.
+	Read(assetID ids.ID, roles AssetTxRole, cursor, pageSize uint64) ([]AssetTx, uint64, error)
+}
+
+// AssetTxsBackfiller calls [accept] for every transaction that was accepted
+// before the asset index was enabled.
+type AssetTxsBackfiller func(accept func(txID ids.ID, roles map[ids.ID]AssetTxRole) error) error
+
+// assetIndexer implements AssetTxsIndexer
+type assetIndexer struct {
+	log           logging.Logger
+	numTxsIndexed prometheus.Counter
+	db            database.Database
+}
+
+// NewAssetTxsIndexer returns a new AssetTxsIndexer.
+// If [db] doesn't hold every accepted transaction and [backfill] is non-nil,
+// the index is rebuilt with [backfill]. Otherwise, transactions accepted while
+// the index was disabled are missing from it.
+func NewAssetTxsIndexer(
+	db database.Database,
+	log logging.Logger,
+	metricsNamespace string,
+	metricsRegisterer prometheus.Registerer,
+	backfill AssetTxsBackfiller,
+) (AssetTxsIndexer, error) {
+	i := &assetIndexer{
+		log: log,
+		numTxsIndexed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "asset_txs_indexed",
+			Help:      "Number of transactions indexed by asset",
+		}),
+		db: db,
+	}
+	if err := metricsRegisterer.Register(i.numTxsIndexed); err != nil {
+		return nil, err
+	}
+
+	complete, err := database.GetBool(db, idxCompleteKey)
+	switch {
+	case err == database.ErrNotFound:
+		complete = false
+	case err != nil:
+		return nil, err
+	}
+	if complete {
+		return i, nil
+	}
+
+	if backfill == nil {
+		log.Warn("asset transaction index is missing transactions accepted while it was disabled")
+		return i, database.PutBool(db, idxCompleteKey, false)
+	}
+
+	log.Info("backfilling asset transaction index")
+	if err := database.Clear(db, db); err != nil {
+		return nil, err
+	}
+	if err := backfill(i.Accept); err != nil {
+		return nil, fmt.Errorf("failed to backfill asset transaction index: %w", err)
+	}
+	return i, database.PutBool(db, idxCompleteKey, true)
+}
+
+// Accept persists the roles [txID] played for each asset.
+// The database structure is:
+// [assetID]
+// |  "idx" => 2 		Running transaction index key, represents the next index
+// |  "0"   => txID1 + roles
+// |  "1"   => txID2 + roles
+// See interface documentation AssetTxsIndexer.Accept
+func (i *assetIndexer) Accept(txID ids.ID, roles map[ids.ID]AssetTxRole) error {
+	for assetID, role := range roles {
+		assetPrefixDB := prefixdb.New(assetID[:], i.db)
+
+		var idx uint64
+		idxBytes, err := assetPrefixDB.Get(idxKey)
+		switch err {
+		case nil:
+			idx = binary.BigEndian.Uint64(idxBytes)
+		case database.ErrNotFound:
+			idxBytes = make([]byte, wrappers.LongLen)
+		default:
+			return fmt.Errorf("unexpected error when indexing txID %s: %w", txID, err)
+		}
+
+		i.log.Verbo("writing assetID/index/txID %s/%d/%s", assetID, idx, txID)
+		value := make([]byte, len(txID)+1)
+		copy(value, txID[:])
+		value[len(txID)] = byte(role)
+		if err := assetPrefixDB.Put(idxBytes, value); err != nil {
+			return fmt.Errorf("failed to write txID while indexing %s: %w", txID, err)
+		}
+
+		idx++
+		binary.BigEndian.PutUint64(idxBytes, idx)
+		if err := assetPrefixDB.Put(idxKey, idxBytes); err != nil {
+			return fmt.Errorf("failed to write index txID while indexing %s: %w", txID, err)
+		}
+	}
+	i.numTxsIndexed.Inc()
+	return nil
+}
+
+// Read returns the transactions that played any of [roles] for [assetID],
+// starting at [cursor], in order of transaction acceptance. The returned
+// cursor is the offset after the last transaction that was examined, so
+// transactions skipped because of their role aren't examined again.
+// See AssetTxsIndexer
+func (i *assetIndexer) Read(assetID ids.ID, roles AssetTxRole, cursor, pageSize uint64) ([]AssetTx, uint64, error) {
+	assetPrefixDB := prefixdb.New(assetID[:], i.db)
+
+	cursorBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(cursorBytes, cursor)
+
+	iter := assetPrefixDB.NewIteratorWithStart(cursorBytes)
+	defer iter.Release()
+
+	var txs []AssetTx
+	for uint64(len(txs)) < pageSize && iter.Next() {
+		key := iter.Key()
+		if len(key) != wrappers.LongLen {
+			// This key has the next index to use, not a tx ID
+			continue
+		}
+		cursor = binary.BigEndian.Uint64(key) + 1
+
+		value := iter.Value()
+		if len(value) != len(ids.Empty)+1 {
+			return nil, 0, fmt.Errorf("unexpected asset index entry of length %d", len(value))
+		}
+		txRoles := AssetTxRole(value[len(ids.Empty)])
+		if txRoles&roles == 0 {
+			continue
+		}
+		txID, err := ids.ToID(value[:len(ids.Empty)])
+		if err != nil {
+			return nil, 0, err
+		}
+		txs = append(txs, AssetTx{
+			TxID:  txID,
+			Roles: txRoles,
+		})
+	}
+	return txs, cursor, iter.Error()
+}
+
+type noAssetIndexer struct{}
+
+// NewNoAssetTxsIndexer returns an AssetTxsIndexer that doesn't index anything.
+// Because transactions accepted while it is used won't be in the index, the
+// index in [db] is marked as incomplete.
+func NewNoAssetTxsIndexer(db database.Database) (AssetTxsIndexer, error) {
+	return &noAssetIndexer{}, database.PutBool(db, idxCompleteKey, false)
+}
+
+func (i *noAssetIndexer) Accept(ids.ID, map[ids.ID]AssetTxRole) error {
+	return nil
+}
+
+func (i *noAssetIndexer) Read(ids.ID, AssetTxRole, uint64, uint64) ([]AssetTx, uint64, error) {
+	return nil, 0, ErrAssetIndexingDisabled
+}
@@ -24,6 +24,10 @@ var (
 	idxCompleteKey                 = []byte("complete")
 	errIndexingRequiredFromGenesis = errors.New("running would create incomplete index. Allow incomplete indices or re-sync from genesis with indexing enabled")
 	errCausesIncompleteIndex       = errors.New("running would create incomplete index. Allow incomplete indices or enable indexing")
+
+	// ErrIndexingDisabled is returned when reading from an index on a node
+	// that isn't indexing transactions
+	ErrIndexingDisabled = errors.New("address transaction indexing is disabled. Restart the node with indexing enabled")
 )
 
 // AddressTxsIndexer maintains information about which transactions changed
@@ -245,5 +249,5 @@ func (i *noIndexer) Accept(ids.ID, []*avax.UTXO, []*avax.UTXO) error {
 }
 
 func (i *noIndexer) Read([]byte, ids.ID, uint64, uint64) ([]ids.ID, error) {
-	return nil, nil
+	return nil, ErrIndexingDisabled
 }
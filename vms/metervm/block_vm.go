@@ -19,10 +19,11 @@ import (
 )
 
 var (
-	_ block.ChainVM        = &blockVM{}
-	_ block.BatchedChainVM = &blockVM{}
-	_ snowman.Block        = &meterBlock{}
-	_ snowman.OracleBlock  = &meterBlock{}
+	_ block.ChainVM              = &blockVM{}
+	_ block.BatchedChainVM       = &blockVM{}
+	_ block.ConcurrentVerifierVM = &blockVM{}
+	_ snowman.Block              = &meterBlock{}
+	_ snowman.OracleBlock        = &meterBlock{}
 )
 
 func NewBlockVM(vm block.ChainVM) block.ChainVM {
@@ -177,6 +178,11 @@ func (vm *blockVM) BatchedParseBlock(blks [][]byte) ([]snowman.Block, error) {
 	return wrappedBlocks, err
 }
 
+func (vm *blockVM) ConcurrentVerifyEnabled() bool {
+	cVM, ok := vm.ChainVM.(block.ConcurrentVerifierVM)
+	return ok && cVM.ConcurrentVerifyEnabled()
+}
+
 type meterBlock struct {
 	snowman.Block
 
@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -70,6 +71,7 @@ func (a *AbortBlock) Verify() error {
 
 	a.vm.currentBlocks[blkID] = a
 	parent.addChild(a)
+	a.verifiedTime = time.Now()
 	return nil
 }
 
@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"container/heap"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -24,6 +25,7 @@ var (
 	errDelegatorSubset = errors.New("delegator's time range must be a subset of the validator's time range")
 	errInvalidState    = errors.New("generated output isn't valid state")
 	errOverDelegated   = errors.New("validator would be over delegated")
+	errNotValidating   = errors.New("node isn't validating during the requested period")
 
 	_ UnsignedProposalTx = &UnsignedAddDelegatorTx{}
 	_ TimedTx            = &UnsignedAddDelegatorTx{}
@@ -53,6 +55,16 @@ func (tx *UnsignedAddDelegatorTx) InitCtx(ctx *snow.Context) {
 	tx.RewardsOwner.InitCtx(ctx)
 }
 
+// MarshalJSON marshals [tx] as JSON with human readable addresses.
+// InitCtx must be called before marshalling [tx].
+func (tx *UnsignedAddDelegatorTx) MarshalJSON() ([]byte, error) {
+	fields := tx.BaseTx.jsonFields(txTypeName(tx))
+	fields["validator"] = tx.Validator.jsonFields()
+	fields["stake"] = tx.Stake
+	fields["rewardsOwner"] = tx.RewardsOwner
+	return json.Marshal(fields)
+}
+
 // StartTime of this validator
 func (tx *UnsignedAddDelegatorTx) StartTime() time.Time {
 	return tx.Validator.StartTime()
@@ -161,7 +173,8 @@ func (tx *UnsignedAddDelegatorTx) Execute(
 		if !currentTimestamp.Before(validatorStartTime) {
 			return nil, nil, nil, nil, permError{
 				fmt.Errorf(
-					"chain timestamp (%s) not before validator's start time (%s)",
+					"%w: chain timestamp (%s) not before validator's start time (%s)",
+					errStartTimeTooEarly,
 					currentTimestamp,
 					validatorStartTime,
 				),
@@ -225,27 +238,35 @@ func (tx *UnsignedAddDelegatorTx) Execute(
 			return nil, nil, nil, nil, permError{err}
 		}
 
-		maximumWeight, err := math.Mul64(MaxValidatorWeightFactor, vdrWeight)
+		maximumWeight, err := vm.maxValidatorWeight(vdrWeight, currentTimestamp)
 		if err != nil {
-			return nil, nil, nil, nil, permError{errStakeOverflow}
-		}
-
-		if !currentTimestamp.Before(vm.ApricotPhase3Time) {
-			maximumWeight = math.Min64(maximumWeight, vm.MaxValidatorStake)
+			return nil, nil, nil, nil, permError{err}
 		}
 
-		canDelegate, err := CanDelegate(
+		// The cap must hold at every instant of the delegation period, so
+		// compare against the peak weight the validator would reach.
+		newPeakWeight, err := delegatedPeakStake(
 			currentDelegators,
 			pendingDelegators,
 			tx,
 			currentWeight,
-			maximumWeight,
 		)
 		if err != nil {
 			return nil, nil, nil, nil, permError{err}
 		}
-		if !canDelegate {
-			return nil, nil, nil, nil, permError{errOverDelegated}
+		if newPeakWeight > maximumWeight {
+			return nil, nil, nil, nil, permError{
+				fmt.Errorf(
+					"%w: delegating %d to %s between %s and %s would bring its weight to %d, exceeding the maximum of %d",
+					errOverDelegated,
+					tx.Validator.Wght,
+					tx.Validator.NodeID.PrefixedString(constants.NodeIDPrefix),
+					tx.StartTime(),
+					tx.EndTime(),
+					newPeakWeight,
+					maximumWeight,
+				),
+			}
 		}
 
 		// Verify the flowcheck
@@ -307,9 +328,51 @@ func (vm *VM) newAddDelegatorTx(
 	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
 	changeAddr ids.ShortID, // Address to send change to, if there is any
 ) (*Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := vm.stake(keys, stakeAmt, vm.AddStakerTxFee, changeAddr)
+	rewardsOwner := &secp256k1fx.OutputOwners{
+		Locktime:  0,
+		Threshold: 1,
+		Addrs:     []ids.ShortID{rewardAddress},
+	}
+	return vm.newAddDelegatorTxWithRewardsOwner(stakeAmt, startTime, endTime, nodeID, rewardsOwner, keys, changeAddr)
+}
+
+// newAddDelegatorTxWithRewardsOwner returns a new AddDelegatorTx whose reward,
+// if applicable, is sent to [rewardsOwner]
+func (vm *VM) newAddDelegatorTxWithRewardsOwner(
+	stakeAmt, // Amount the delegator stakes
+	startTime, // Unix time they start delegating
+	endTime uint64, // Unix time they stop delegating
+	nodeID ids.ShortID, // ID of the node we are delegating to
+	rewardsOwner *secp256k1fx.OutputOwners, // Owner of the reward, if applicable
+	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	kc := secp256k1fx.NewKeychain(keys...)
+	utx, signers, err := vm.newUnsignedAddDelegatorTx(stakeAmt, startTime, endTime, nodeID, rewardsOwner, kc.Addresses(), changeAddr)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, err
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(Codec, signerKeys(kc, signers)); err != nil {
+		return nil, err
+	}
+	return tx, utx.SyntacticVerify(vm.ctx)
+}
+
+// newUnsignedAddDelegatorTx returns a new AddDelegatorTx funded by [addrs] and
+// the addresses that must sign each of its inputs
+func (vm *VM) newUnsignedAddDelegatorTx(
+	stakeAmt, // Amount the delegator stakes
+	startTime, // Unix time they start delegating
+	endTime uint64, // Unix time they stop delegating
+	nodeID ids.ShortID, // ID of the node we are delegating to
+	rewardsOwner *secp256k1fx.OutputOwners, // Owner of the reward, if applicable
+	addrs ids.ShortSet, // Addresses providing the staked tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*UnsignedAddDelegatorTx, [][]ids.ShortID, error) {
+	ins, unlockedOuts, lockedOuts, signers, err := vm.stakeFromAddrs(addrs, stakeAmt, vm.AddStakerTxFee, changeAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 	// Create the tx
 	utx := &UnsignedAddDelegatorTx{
@@ -325,18 +388,10 @@ func (vm *VM) newAddDelegatorTx(
 			End:    endTime,
 			Wght:   stakeAmt,
 		},
-		Stake: lockedOuts,
-		RewardsOwner: &secp256k1fx.OutputOwners{
-			Locktime:  0,
-			Threshold: 1,
-			Addrs:     []ids.ShortID{rewardAddress},
-		},
-	}
-	tx := &Tx{UnsignedTx: utx}
-	if err := tx.Sign(Codec, signers); err != nil {
-		return nil, err
+		Stake:        lockedOuts,
+		RewardsOwner: rewardsOwner,
 	}
-	return tx, utx.SyntacticVerify(vm.ctx)
+	return utx, signers, nil
 }
 
 // CanDelegate returns if the [new] delegator can be added to a validator who
@@ -352,15 +407,28 @@ func CanDelegate(
 	currentStake,
 	maximumStake uint64,
 ) (bool, error) {
-	maxStake, err := maxStakeAmount(current, pending, new.StartTime(), new.EndTime(), currentStake)
+	newMaxStake, err := delegatedPeakStake(current, pending, new, currentStake)
 	if err != nil {
 		return false, err
 	}
-	newMaxStake, err := math.Add64(maxStake, new.Validator.Wght)
+	return newMaxStake <= maximumStake, nil
+}
+
+// delegatedPeakStake returns the maximum amount of stake that would be on a
+// validator who has [current] and [pending] delegators at any time during the
+// [new] delegation, if [new] were added. [currentStake] is the current amount
+// of stake on the validator, include the [current] delegators.
+func delegatedPeakStake(
+	current,
+	pending []*UnsignedAddDelegatorTx, // sorted by next start time first
+	new *UnsignedAddDelegatorTx,
+	currentStake uint64,
+) (uint64, error) {
+	maxStake, err := maxStakeAmount(current, pending, new.StartTime(), new.EndTime(), currentStake)
 	if err != nil {
-		return false, err
+		return 0, err
 	}
-	return newMaxStake <= maximumStake, nil
+	return math.Add64(maxStake, new.Validator.Wght)
 }
 
 // Return the maximum amount of stake on a node (including delegations) at any
@@ -542,13 +610,13 @@ func (vm *VM) maxSubnetStakeAmount(
 	}
 
 	if vdrTx == nil {
-		return 0, nil
+		return 0, errNotValidating
 	}
 	if vdrTx.StartTime().After(endTime) {
-		return 0, nil
+		return 0, errNotValidating
 	}
 	if vdrTx.EndTime().Before(startTime) {
-		return 0, nil
+		return 0, errNotValidating
 	}
 	return vdrTx.Weight(), nil
 }
@@ -568,10 +636,10 @@ func (vm *VM) maxPrimarySubnetStakeAmount(
 	case nil:
 		vdrTx := currentValidator.AddValidatorTx()
 		if vdrTx.StartTime().After(endTime) {
-			return 0, nil
+			return 0, errNotValidating
 		}
 		if vdrTx.EndTime().Before(startTime) {
-			return 0, nil
+			return 0, errNotValidating
 		}
 
 		currentWeight := vdrTx.Weight()
@@ -589,16 +657,16 @@ func (vm *VM) maxPrimarySubnetStakeAmount(
 	case database.ErrNotFound:
 		futureValidator, err := pendingStakers.GetValidatorTx(nodeID)
 		if err == database.ErrNotFound {
-			return 0, nil
+			return 0, errNotValidating
 		}
 		if err != nil {
 			return 0, err
 		}
 		if futureValidator.StartTime().After(endTime) {
-			return 0, nil
+			return 0, errNotValidating
 		}
 		if futureValidator.EndTime().Before(startTime) {
-			return 0, nil
+			return 0, errNotValidating
 		}
 
 		return maxStakeAmount(
@@ -613,6 +681,57 @@ func (vm *VM) maxPrimarySubnetStakeAmount(
 	}
 }
 
+// maxDelegatableStakeAmount returns the maximum amount of additional stake
+// that can be delegated to [nodeID] on the primary network between
+// [startTime] and [endTime].
+func (vm *VM) maxDelegatableStakeAmount(
+	nodeID ids.ShortID,
+	startTime time.Time,
+	endTime time.Time,
+) (uint64, error) {
+	currentStake, err := vm.maxPrimarySubnetStakeAmount(nodeID, startTime, endTime)
+	if err != nil {
+		return 0, err
+	}
+
+	var vdrTx *UnsignedAddValidatorTx
+	currentValidator, err := vm.internalState.CurrentStakerChainState().GetValidator(nodeID)
+	switch err {
+	case nil:
+		vdrTx = currentValidator.AddValidatorTx()
+	case database.ErrNotFound:
+		vdrTx, err = vm.internalState.PendingStakerChainState().GetValidatorTx(nodeID)
+		if err != nil {
+			return 0, err
+		}
+	default:
+		return 0, err
+	}
+
+	maximumWeight, err := vm.maxValidatorWeight(vdrTx.Weight(), vm.internalState.GetTimestamp())
+	if err != nil {
+		return 0, err
+	}
+	if currentStake >= maximumWeight {
+		return 0, nil
+	}
+	return maximumWeight - currentStake, nil
+}
+
+// maxValidatorWeight returns the maximum amount of stake, including
+// delegations, that can be placed on a primary network validator that staked
+// [vdrWeight] when the chain time is [currentTimestamp].
+func (vm *VM) maxValidatorWeight(vdrWeight uint64, currentTimestamp time.Time) (uint64, error) {
+	maximumWeight, err := math.Mul64(vm.MaxValidatorWeightFactor, vdrWeight)
+	if err != nil {
+		return 0, errStakeOverflow
+	}
+	if !currentTimestamp.Before(vm.ApricotPhase3Time) {
+		maximumWeight = math.Min64(maximumWeight, vm.MaxValidatorStake)
+	}
+	return maximumWeight, nil
+}
+
 type validatorHeap []*Validator
 
 func (h *validatorHeap) Len() int                 { return len(*h) }
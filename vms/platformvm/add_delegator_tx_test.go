@@ -676,3 +676,86 @@ func verifyAndAcceptProposalCommitment(assert *assert.Assertions, blk snowman.Bl
 	err = abort.Reject()
 	assert.NoError(err)
 }
+
+func TestFactoryDefaultMaxValidatorWeightFactor(t *testing.T) {
+	assert := assert.New(t)
+
+	vmIntf, err := (&Factory{}).New(nil)
+	assert.NoError(err)
+	assert.Equal(MaxValidatorWeightFactor, vmIntf.(*VM).MaxValidatorWeightFactor)
+
+	vmIntf, err = (&Factory{MaxValidatorWeightFactor: 2}).New(nil)
+	assert.NoError(err)
+	assert.EqualValues(2, vmIntf.(*VM).MaxValidatorWeightFactor)
+}
+
+func TestAddDelegatorTxMaxValidatorWeightFactor(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.MaxValidatorWeightFactor = 2
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := uint64(defaultValidateStartTime.Add(5 * time.Second).Unix())
+	endTime := uint64(defaultValidateEndTime.Add(-5 * time.Second).Unix())
+
+	key, err := vm.factory.NewPrivateKey()
+	assert.NoError(err)
+	nodeID := key.PublicKey().Address()
+
+	addValidatorTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		startTime,
+		endTime,
+		nodeID,
+		nodeID,
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	vm.internalState.AddCurrentStaker(addValidatorTx, 0)
+	vm.internalState.AddTx(addValidatorTx, Committed)
+	assert.NoError(vm.internalState.Commit())
+	assert.NoError(vm.internalState.(*internalStateImpl).loadCurrentValidators())
+
+	// With a factor of 2 the validator can attract as much delegation as its
+	// own stake.
+	available, err := vm.maxDelegatableStakeAmount(
+		nodeID,
+		time.Unix(int64(startTime), 0),
+		time.Unix(int64(endTime), 0),
+	)
+	assert.NoError(err)
+	assert.Equal(vm.MinValidatorStake, available)
+
+	tx, err := vm.newAddDelegatorTx(
+		available,
+		startTime,
+		endTime,
+		nodeID,
+		nodeID,
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	_, _, _, _, err = tx.UnsignedTx.(UnsignedProposalTx).Execute(vm, vm.internalState, tx)
+	assert.NoError(err)
+
+	tx, err = vm.newAddDelegatorTx(
+		available+1,
+		startTime,
+		endTime,
+		nodeID,
+		nodeID,
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	_, _, _, _, err = tx.UnsignedTx.(UnsignedProposalTx).Execute(vm, vm.internalState, tx)
+	assert.ErrorIs(err, errOverDelegated)
+}
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -34,6 +35,15 @@ type UnsignedAddSubnetValidatorTx struct {
 	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
 }
 
+// MarshalJSON marshals [tx] as JSON with human readable addresses.
+// InitCtx must be called before marshalling [tx].
+func (tx *UnsignedAddSubnetValidatorTx) MarshalJSON() ([]byte, error) {
+	fields := tx.BaseTx.jsonFields(txTypeName(tx))
+	fields["validator"] = tx.Validator.jsonFields()
+	fields["subnetAuthorization"] = tx.SubnetAuth
+	return json.Marshal(fields)
+}
+
 // StartTime of this validator
 func (tx *UnsignedAddSubnetValidatorTx) StartTime() time.Time {
 	return tx.Validator.StartTime()
@@ -118,7 +128,8 @@ func (tx *UnsignedAddSubnetValidatorTx) Execute(
 		if !currentTimestamp.Before(validatorStartTime) {
 			return nil, nil, nil, nil, permError{
 				fmt.Errorf(
-					"validator's start time (%s) is at or after current chain timestamp (%s)",
+					"%w: validator's start time (%s) is at or after current chain timestamp (%s)",
+					errStartTimeTooEarly,
 					currentTimestamp,
 					validatorStartTime,
 				),
@@ -251,7 +262,9 @@ func (tx *UnsignedAddSubnetValidatorTx) Execute(
 	// Produce the UTXOS
 	produceOutputs(onAbortState, txID, vm.ctx.AVAXAssetID, tx.Outs)
 
-	return onCommitState, onAbortState, nil, nil, nil
+	// If this block is committed, the validator will be added to the pending
+	// validator set.
+	return onCommitState, onAbortState, vm.updateValidatorSetMetrics, nil, nil
 }
 
 // InitiallyPrefersCommit returns true if the proposed validators start time is
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -61,6 +62,17 @@ func (tx *UnsignedAddValidatorTx) InitCtx(ctx *snow.Context) {
 	tx.RewardsOwner.InitCtx(ctx)
 }
 
+// MarshalJSON marshals [tx] as JSON with human readable addresses.
+// InitCtx must be called before marshalling [tx].
+func (tx *UnsignedAddValidatorTx) MarshalJSON() ([]byte, error) {
+	fields := tx.BaseTx.jsonFields(txTypeName(tx))
+	fields["validator"] = tx.Validator.jsonFields()
+	fields["stake"] = tx.Stake
+	fields["rewardsOwner"] = tx.RewardsOwner
+	fields["shares"] = tx.Shares
+	return json.Marshal(fields)
+}
+
 // StartTime of this validator
 func (tx *UnsignedAddValidatorTx) StartTime() time.Time {
 	return tx.Validator.StartTime()
@@ -177,7 +189,8 @@ func (tx *UnsignedAddValidatorTx) Execute(
 		if !currentTimestamp.Before(startTime) {
 			return nil, nil, nil, nil, permError{
 				fmt.Errorf(
-					"validator's start time (%s) at or before current timestamp (%s)",
+					"%w: validator's start time (%s) at or before current timestamp (%s)",
+					errStartTimeTooEarly,
 					startTime,
 					currentTimestamp,
 				),
@@ -264,7 +277,9 @@ func (tx *UnsignedAddValidatorTx) Execute(
 	// Produce the UTXOS
 	produceOutputs(onAbortState, txID, vm.ctx.AVAXAssetID, outs)
 
-	return onCommitState, onAbortState, nil, nil, nil
+	// If this block is committed, the validator will be added to the pending
+	// validator set.
+	return onCommitState, onAbortState, vm.updateValidatorSetMetrics, nil, nil
 }
 
 // InitiallyPrefersCommit returns true if the proposed validators start time is
@@ -284,9 +299,53 @@ func (vm *VM) newAddValidatorTx(
 	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
 	changeAddr ids.ShortID, // Address to send change to, if there is any
 ) (*Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := vm.stake(keys, stakeAmt, vm.AddStakerTxFee, changeAddr)
+	rewardsOwner := &secp256k1fx.OutputOwners{
+		Locktime:  0,
+		Threshold: 1,
+		Addrs:     []ids.ShortID{rewardAddress},
+	}
+	return vm.newAddValidatorTxWithRewardsOwner(stakeAmt, startTime, endTime, nodeID, rewardsOwner, shares, keys, changeAddr)
+}
+
+// newAddValidatorTxWithRewardsOwner returns a new AddValidatorTx whose reward,
+// if applicable, is sent to [rewardsOwner]
+func (vm *VM) newAddValidatorTxWithRewardsOwner(
+	stakeAmt, // Amount the validator stakes
+	startTime, // Unix time they start validating
+	endTime uint64, // Unix time they stop validating
+	nodeID ids.ShortID, // ID of the node we want to validate with
+	rewardsOwner *secp256k1fx.OutputOwners, // Owner of the reward, if applicable
+	shares uint32, // 10,000 times percentage of reward taken from delegators
+	keys []*crypto.PrivateKeySECP256K1R, // Keys providing the staked tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	kc := secp256k1fx.NewKeychain(keys...)
+	utx, signers, err := vm.newUnsignedAddValidatorTx(stakeAmt, startTime, endTime, nodeID, rewardsOwner, shares, kc.Addresses(), changeAddr)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, err
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(Codec, signerKeys(kc, signers)); err != nil {
+		return nil, err
+	}
+	return tx, utx.SyntacticVerify(vm.ctx)
+}
+
+// newUnsignedAddValidatorTx returns a new AddValidatorTx funded by [addrs] and
+// the addresses that must sign each of its inputs
+func (vm *VM) newUnsignedAddValidatorTx(
+	stakeAmt, // Amount the validator stakes
+	startTime, // Unix time they start validating
+	endTime uint64, // Unix time they stop validating
+	nodeID ids.ShortID, // ID of the node we want to validate with
+	rewardsOwner *secp256k1fx.OutputOwners, // Owner of the reward, if applicable
+	shares uint32, // 10,000 times percentage of reward taken from delegators
+	addrs ids.ShortSet, // Addresses providing the staked tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*UnsignedAddValidatorTx, [][]ids.ShortID, error) {
+	ins, unlockedOuts, lockedOuts, signers, err := vm.stakeFromAddrs(addrs, stakeAmt, vm.AddStakerTxFee, changeAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 	// Create the tx
 	utx := &UnsignedAddValidatorTx{
@@ -302,17 +361,9 @@ func (vm *VM) newAddValidatorTx(
 			End:    endTime,
 			Wght:   stakeAmt,
 		},
-		Stake: lockedOuts,
-		RewardsOwner: &secp256k1fx.OutputOwners{
-			Locktime:  0,
-			Threshold: 1,
-			Addrs:     []ids.ShortID{rewardAddress},
-		},
-		Shares: shares,
-	}
-	tx := &Tx{UnsignedTx: utx}
-	if err := tx.Sign(Codec, signers); err != nil {
-		return nil, err
+		Stake:        lockedOuts,
+		RewardsOwner: rewardsOwner,
+		Shares:       shares,
 	}
-	return tx, utx.SyntacticVerify(vm.ctx)
+	return utx, signers, nil
 }
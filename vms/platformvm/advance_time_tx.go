@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -31,6 +32,14 @@ type UnsignedAdvanceTimeTx struct {
 
 func (tx *UnsignedAdvanceTimeTx) InitCtx(*snow.Context) {}
 
+// MarshalJSON marshals [tx] as JSON
+func (tx *UnsignedAdvanceTimeTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type": txTypeName(tx),
+		"time": tx.Time,
+	})
+}
+
 // Timestamp returns the time this block is proposing the chain should be set to
 func (tx *UnsignedAdvanceTimeTx) Timestamp() time.Time {
 	return time.Unix(int64(tx.Time), 0)
@@ -6,6 +6,7 @@ package platformvm
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -122,10 +123,14 @@ func (ab *AtomicBlock) Verify() error {
 		)
 	}
 
+	executeStart := time.Now()
 	onAccept, err := tx.AtomicExecute(ab.vm, parentState, &ab.Tx)
+	ab.Tx.verifyDuration = time.Since(executeStart)
 	if err != nil {
 		txID := tx.ID()
 		ab.vm.droppedTxCache.Put(txID, err.Error()) // cache tx as dropped
+		ab.vm.metrics.MarkTxVerifyFailure(&ab.Tx, err)
+		ab.vm.blockBuilder.ReleaseUTXOs(&ab.Tx)
 		return fmt.Errorf("tx %s failed semantic verification: %w", txID, err)
 	}
 	onAccept.AddTx(&ab.Tx, Committed)
@@ -136,6 +141,7 @@ func (ab *AtomicBlock) Verify() error {
 	ab.vm.blockBuilder.RemoveAtomicTx(&ab.Tx)
 	ab.vm.currentBlocks[blkID] = ab
 	parentIntf.addChild(ab)
+	ab.verifiedTime = time.Now()
 	return nil
 }
 
@@ -179,6 +185,7 @@ func (ab *AtomicBlock) Accept() error {
 		)
 	}
 
+	ab.vm.blockBuilder.ReleaseUTXOs(&ab.Tx)
 	for _, child := range ab.children {
 		child.setBaseState()
 	}
@@ -210,6 +217,7 @@ func (ab *AtomicBlock) Reject() error {
 			ab.Tx.ID(),
 			err,
 		)
+		ab.vm.blockBuilder.ReleaseUTXOs(&ab.Tx)
 	}
 	return ab.CommonDecisionBlock.Reject()
 }
@@ -42,6 +42,19 @@ func (tx *BaseTx) InitCtx(ctx *snow.Context) {
 	}
 }
 
+// jsonFields returns the JSON representation of the fields of this [BaseTx],
+// keyed by their JSON names, along with the [txType] of the embedding tx.
+func (tx *BaseTx) jsonFields(txType string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":         txType,
+		"networkID":    tx.NetworkID,
+		"blockchainID": tx.BlockchainID,
+		"outputs":      tx.Outs,
+		"inputs":       tx.Ins,
+		"memo":         tx.Memo,
+	}
+}
+
 // SyntacticVerify returns nil iff this tx is well formed
 func (tx *BaseTx) SyntacticVerify(ctx *snow.Context) error {
 	switch {
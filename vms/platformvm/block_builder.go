@@ -10,6 +10,9 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -19,7 +22,13 @@ const (
 	// syncBound is the synchrony bound used for safe decision making
 	syncBound = 10 * time.Second
 
-	// BatchSize is the number of decision transactions to place into a block
+	// imminentProposalTxBound is how long after the synchrony bound a proposal
+	// tx can start while still being issued before pending decision txs, so
+	// that it isn't dropped for starting too soon.
+	imminentProposalTxBound = syncBound
+
+	// BatchSize is the default maximum number of decision transactions to
+	// place into a block
 	BatchSize = 30
 )
 
@@ -36,6 +45,9 @@ type blockBuilder struct {
 	// TODO: factor out VM into separable interfaces
 	vm *VM
 
+	// Database the mempool persists its unissued txs to
+	db database.Database
+
 	// This timer goes off when it is time for the next validator to add/leave
 	// the validator set. When it goes off ResetTimer() is called, potentially
 	// triggering creation of a new block.
@@ -48,11 +60,15 @@ type blockBuilder struct {
 // Initialize this builder.
 func (m *blockBuilder) Initialize(vm *VM, registerer prometheus.Registerer) error {
 	m.vm = vm
+	m.db = prefixdb.New(mempoolPrefix, vm.dbManager.Current().Database)
 
 	m.vm.ctx.Log.Verbo("initializing platformVM mempool")
 	mempool, err := NewMempool(
 		"mempool",
 		registerer,
+		m.db,
+		vm.ctx.Log,
+		&vm.metrics,
 	)
 	if err != nil {
 		return err
@@ -83,6 +99,28 @@ func (m *blockBuilder) AddUnverifiedTx(tx *Tx) error {
 		return nil
 	}
 
+	if err := m.verifyAndAddTx(tx); err != nil {
+		return err
+	}
+	return m.vm.GossipTx(tx)
+}
+
+// verifyAndAddTx verifies an initialized transaction against the preferred
+// state and attempts to add it to the mempool. If the transaction is invalid,
+// the reason is recorded in the dropped tx cache.
+func (m *blockBuilder) verifyAndAddTx(tx *Tx) error {
+	txID := tx.ID()
+
+	// Signatures don't depend on the chain state, so malformed credentials
+	// are rejected before doing any state lookups.
+	if err := m.vm.fx.VerifySignatures(tx.UnsignedTx, tx.Creds); err != nil {
+		err = credentialsError{err}
+		m.MarkDropped(txID)
+		m.vm.droppedTxCache.Put(txID, err.Error()) // cache tx as dropped
+		m.vm.metrics.MarkTxVerifyFailure(tx, err)
+		return err
+	}
+
 	// Get the preferred block (which we want to build off)
 	preferred, err := m.vm.Preferred()
 	if err != nil {
@@ -98,13 +136,58 @@ func (m *blockBuilder) AddUnverifiedTx(tx *Tx) error {
 	preferredState := preferredDecision.onAccept()
 	if err := tx.UnsignedTx.SemanticVerify(m.vm, preferredState, tx); err != nil {
 		m.MarkDropped(txID)
+		m.vm.droppedTxCache.Put(txID, err.Error()) // cache tx as dropped
+		m.vm.metrics.MarkTxVerifyFailure(tx, err)
 		return err
 	}
 
 	if err := m.AddVerifiedTx(tx); err != nil {
+		m.vm.droppedTxCache.Put(txID, err.Error()) // cache tx as dropped
 		return err
 	}
-	return m.vm.GossipTx(tx)
+	return nil
+}
+
+// restorePersistedTxs re-verifies the txs that were in the mempool when the
+// node last shut down, and re-admits the ones that are still valid. Invalid
+// txs are recorded in the dropped tx cache.
+func (m *blockBuilder) restorePersistedTxs() error {
+	it := m.db.NewIterator()
+	var txs []*Tx
+	for it.Next() {
+		tx := &Tx{}
+		if _, err := Codec.Unmarshal(it.Value(), tx); err != nil {
+			it.Release()
+			return fmt.Errorf("failed to parse persisted mempool tx: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return fmt.Errorf("failed to iterate over persisted mempool txs: %w", err)
+	}
+
+	for _, tx := range txs {
+		if err := tx.Sign(Codec, nil); err != nil {
+			return err
+		}
+
+		txID := tx.ID()
+		if m.Has(txID) {
+			// The tx was re-issued while the node was bootstrapping
+			continue
+		}
+
+		// The tx is persisted again if it is re-admitted to the mempool
+		if err := m.db.Delete(txID[:]); err != nil {
+			return fmt.Errorf("failed to remove persisted mempool tx %s: %w", txID, err)
+		}
+		if err := m.verifyAndAddTx(tx); err != nil {
+			m.vm.ctx.Log.Debug("dropping persisted mempool tx %s due to: %s", txID, err)
+		}
+	}
+	return nil
 }
 
 // AddVerifiedTx attempts to add a transaction to the mempool
@@ -122,6 +205,15 @@ func (m *blockBuilder) AddVerifiedTx(tx *Tx) error {
 
 // BuildBlock builds a block to be added to consensus
 func (m *blockBuilder) BuildBlock() (snowman.Block, error) {
+	start := time.Now()
+	blk, err := m.buildBlock()
+	if err != nil {
+		return nil, err
+	}
+	return blk, m.vm.metrics.BuildBlock(blk, start, m.Len())
+}
+
+func (m *blockBuilder) buildBlock() (Block, error) {
 	m.dropIncoming = true
 	defer func() {
 		m.dropIncoming = false
@@ -154,18 +246,15 @@ func (m *blockBuilder) BuildBlock() (snowman.Block, error) {
 	// TODO: remove after AP5.
 	enabledAP5 := !currentChainTimestamp.Before(m.vm.ApricotPhase5Time)
 
-	// If there are pending decision txs, build a block with a batch of them
-	if m.HasDecisionTxs() || (enabledAP5 && m.HasAtomicTx()) {
-		txs := make([]*Tx, 0, BatchSize)
-		if m.HasDecisionTxs() {
-			decisionTxs := m.PopDecisionTxs(BatchSize)
-			txs = append(txs, decisionTxs...)
-		}
-		if enabledAP5 && m.HasAtomicTx() {
-			atomicTxs := m.PopAtomicTxs(BatchSize - len(txs))
-			txs = append(txs, atomicTxs...)
-		}
+	// Proposal txs that are about to start are prioritized over decision txs
+	prioritizeProposalTx := m.hasImminentProposalTx()
 
+	// If there are pending decision txs, build a block with a batch of them
+	var txs []*Tx
+	if !prioritizeProposalTx && (m.HasDecisionTxs() || (enabledAP5 && m.HasAtomicTx())) {
+		txs = m.popStandardBlockTxs(enabledAP5)
+	}
+	if len(txs) > 0 {
 		blk, err := m.vm.newStandardBlock(preferredID, nextHeight, txs)
 		if err != nil {
 			m.ResetTimer()
@@ -183,7 +272,7 @@ func (m *blockBuilder) BuildBlock() (snowman.Block, error) {
 	}
 
 	// If there is a pending atomic tx, build a block with it
-	if !enabledAP5 && m.HasAtomicTx() {
+	if !prioritizeProposalTx && !enabledAP5 && m.HasAtomicTx() {
 		tx := m.PopAtomicTx()
 
 		blk, err := m.vm.newAtomicBlock(preferredID, nextHeight, *tx)
@@ -241,17 +330,7 @@ func (m *blockBuilder) BuildBlock() (snowman.Block, error) {
 	localTime := m.vm.clock.Time()
 	if !localTime.Before(nextStakerChangeTime) {
 		// local time is at or after the time for the next staker to start/stop
-		advanceTimeTx, err := m.vm.newAdvanceTimeTx(nextStakerChangeTime)
-		if err != nil {
-			return nil, err
-		}
-		blk, err := m.vm.newProposalBlock(preferredID, nextHeight, *advanceTimeTx)
-		if err != nil {
-			return nil, err
-		}
-
-		m.vm.internalState.AddBlock(blk)
-		return blk, m.vm.internalState.Commit()
+		return m.buildAdvanceTimeBlock(preferredID, nextHeight, nextStakerChangeTime)
 	}
 
 	// Propose adding a new validator but only if their start time is in the
@@ -269,6 +348,7 @@ func (m *blockBuilder) BuildBlock() (snowman.Block, error) {
 				startTime,
 			)
 			m.vm.droppedTxCache.Put(txID, errMsg) // cache tx as dropped
+			m.ReleaseUTXOs(tx)
 			m.vm.ctx.Log.Debug("dropping tx %s: %s", txID, errMsg)
 			continue
 		}
@@ -286,18 +366,7 @@ func (m *blockBuilder) BuildBlock() (snowman.Block, error) {
 		maxChainStartTime := currentChainTimestamp.Add(maxFutureStartTime)
 		if startTime.After(maxChainStartTime) {
 			m.AddProposalTx(tx)
-
-			advanceTimeTx, err := m.vm.newAdvanceTimeTx(localTime)
-			if err != nil {
-				return nil, err
-			}
-			blk, err := m.vm.newProposalBlock(preferredID, nextHeight, *advanceTimeTx)
-			if err != nil {
-				return nil, err
-			}
-
-			m.vm.internalState.AddBlock(blk)
-			return blk, m.vm.internalState.Commit()
+			return m.buildAdvanceTimeBlock(preferredID, nextHeight, localTime)
 		}
 
 		// Attempt to issue the transaction
@@ -320,6 +389,85 @@ func (m *blockBuilder) BuildBlock() (snowman.Block, error) {
 	return nil, errNoPendingBlocks
 }
 
+// popStandardBlockTxs removes from the mempool the txs to place into the next
+// standard block: decision txs and, if [includeAtomicTxs], atomic txs. The
+// block will contain at most [MaxBlockTxs] txs and be at most [MaxBlockSize]
+// bytes, unless it holds a single larger tx. The remaining txs are left for
+// subsequent blocks. Txs that can't fit into any valid block are dropped.
+func (m *blockBuilder) popStandardBlockTxs(includeAtomicTxs bool) []*Tx {
+	maxTxs := m.vm.config.MaxBlockTxs
+	txs := make([]*Tx, 0, maxTxs)
+	blockSize := standardBlockOverhead
+
+	// addTx attempts to add [tx], the next tx in the mempool, to the block.
+	// [pop] removes [tx] from the mempool. Returns false if [tx] was left in
+	// the mempool because the block is full.
+	addTx := func(tx *Tx, pop func()) bool {
+		txSize := standardBlockTxSize(tx)
+		switch {
+		case standardBlockOverhead+txSize > maxStandardBlockSize:
+			pop()
+			txID := tx.ID()
+			errMsg := fmt.Sprintf(
+				"tx size (%d) exceeds the maximum standard block size (%d)",
+				txSize,
+				maxStandardBlockSize-standardBlockOverhead,
+			)
+			m.vm.droppedTxCache.Put(txID, errMsg) // cache tx as dropped
+			m.ReleaseUTXOs(tx)
+			m.vm.ctx.Log.Debug("dropping tx %s: %s", txID, errMsg)
+			return true
+		case len(txs) > 0 && blockSize+txSize > m.vm.config.MaxBlockSize:
+			return false
+		}
+		pop()
+		txs = append(txs, tx)
+		blockSize += txSize
+		return true
+	}
+
+	for len(txs) < maxTxs && m.HasDecisionTxs() {
+		if !addTx(m.PeekDecisionTx(), func() { m.PopDecisionTxs(1) }) {
+			return txs
+		}
+	}
+	for includeAtomicTxs && len(txs) < maxTxs && m.HasAtomicTx() {
+		if !addTx(m.PeekAtomicTx(), func() { m.PopAtomicTx() }) {
+			return txs
+		}
+	}
+	return txs
+}
+
+// buildAdvanceTimeBlock builds a proposal block that moves the chain time to
+// [timestamp]. The caller ensures that [timestamp] is after the chain time:
+// either it's the next staker change time, or it's the local time, which is
+// then past the chain time by more than the max future start time.
+func (m *blockBuilder) buildAdvanceTimeBlock(parentID ids.ID, height uint64, timestamp time.Time) (Block, error) {
+	advanceTimeTx, err := m.vm.newAdvanceTimeTx(timestamp)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := m.vm.newProposalBlock(parentID, height, *advanceTimeTx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.vm.internalState.AddBlock(blk)
+	return blk, m.vm.internalState.Commit()
+}
+
+// hasImminentProposalTx returns true if the next proposal tx in the mempool
+// satisfies the synchrony bound now, but will soon stop satisfying it.
+func (m *blockBuilder) hasImminentProposalTx() bool {
+	if !m.HasProposalTx() {
+		return false
+	}
+	startTime := m.PeekProposalTx().UnsignedTx.(TimedTx).StartTime()
+	syncTime := m.vm.clock.Time().Add(syncBound)
+	return !startTime.Before(syncTime) && startTime.Before(syncTime.Add(imminentProposalTxBound))
+}
+
 // ResetTimer Check if there is a block ready to be added to consensus. If so, notify the
 // consensus engine.
 func (m *blockBuilder) ResetTimer() {
@@ -391,6 +539,7 @@ func (m *blockBuilder) ResetTimer() {
 			txID,
 			errMsg,
 		)
+		m.ReleaseUTXOs(tx)
 		m.vm.ctx.Log.Debug("dropping tx %s: %s", txID, errMsg)
 	}
 
@@ -4,10 +4,26 @@
 package platformvm
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/uptime"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 // shows that a locally generated CreateChainTx can be added to mempool and then
@@ -106,3 +122,554 @@ func TestPreviouslyDroppedTxsCanBeReAddedToMempool(t *testing.T) {
 	assert.True(mempool.Has(txID))
 	assert.False(mempool.WasDropped(txID))
 }
+
+// shows that a tx paying a higher fee is included in the next standard block
+// even if it was issued after enough minimum fee txs to fill the block
+func TestBlockBuilderPrioritizesHighFeeTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+	blockBuilder := &vm.blockBuilder
+	mempool := blockBuilder.Mempool.(*mempool)
+
+	// Each tx is paid for by a different key so that they don't conflict.
+	newTx := func(key *crypto.PrivateKeySECP256K1R, fee uint64) *Tx {
+		vm.CreateAssetTxFee = fee
+		vm.CreateSubnetTxFee = fee
+		tx, err := vm.newCreateSubnetTx(
+			1, // threshold
+			[]ids.ShortID{key.PublicKey().Address()},
+			[]*crypto.PrivateKeySECP256K1R{key},
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+		return tx
+	}
+
+	// Fill more than a block with minimum fee txs
+	vm.config.MaxBlockTxs = 2
+	lowFeeTxs := []*Tx{
+		newTx(keys[0], defaultTxFee),
+		newTx(keys[1], defaultTxFee),
+		newTx(keys[2], defaultTxFee),
+	}
+	highFeeTx := newTx(keys[3], 100*defaultTxFee)
+	vm.CreateAssetTxFee = defaultTxFee
+	vm.CreateSubnetTxFee = defaultTxFee
+
+	for _, tx := range lowFeeTxs {
+		assert.NoError(blockBuilder.AddUnverifiedTx(tx))
+	}
+	assert.NoError(blockBuilder.AddUnverifiedTx(highFeeTx))
+	assert.EqualValues(100*defaultTxFee, mempool.Get(highFeeTx.ID()).burned)
+
+	// The next standard block includes the high fee tx first
+	blkIntf, err := vm.BuildBlock()
+	assert.NoError(err)
+	blk, ok := blkIntf.(*StandardBlock)
+	assert.True(ok, "expected standard block")
+	assert.Len(blk.Txs, 2)
+	assert.Equal(highFeeTx.ID(), blk.Txs[0].ID())
+	assert.Equal(lowFeeTxs[0].ID(), blk.Txs[1].ID())
+
+	// The most recently issued minimum fee txs are left in the mempool
+	assert.True(mempool.Has(lowFeeTxs[1].ID()))
+	assert.True(mempool.Has(lowFeeTxs[2].ID()))
+
+	// Txs re-added after their block is rejected may have been parsed from
+	// the block, so they don't know their burned amount yet.
+	parsedTxs := make([]*Tx, len(blk.Txs))
+	for i, tx := range blk.Txs {
+		parsedTx := &Tx{}
+		_, err := Codec.Unmarshal(tx.Bytes(), parsedTx)
+		assert.NoError(err)
+		unsignedBytes, err := Codec.Marshal(CodecVersion, &parsedTx.UnsignedTx)
+		assert.NoError(err)
+		parsedTx.Initialize(unsignedBytes, tx.Bytes())
+		assert.Zero(parsedTx.burned)
+		parsedTxs[i] = parsedTx
+	}
+	blk.Txs = parsedTxs
+	assert.NoError(blk.Reject())
+
+	// The re-added high fee tx is still prioritized
+	assert.EqualValues(100*defaultTxFee, mempool.Get(highFeeTx.ID()).burned)
+	blkIntf, err = vm.BuildBlock()
+	assert.NoError(err)
+	blk, ok = blkIntf.(*StandardBlock)
+	assert.True(ok, "expected standard block")
+	assert.Len(blk.Txs, 2)
+	assert.Equal(highFeeTx.ID(), blk.Txs[0].ID())
+}
+
+// shows that a proposal tx that is about to violate the synchrony bound is
+// issued before pending decision txs
+func TestBlockBuilderPrioritizesImminentProposalTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+	blockBuilder := &vm.blockBuilder
+
+	decisionTx := getValidTx(vm, t)
+	err := blockBuilder.AddUnverifiedTx(decisionTx)
+	assert.NoError(err)
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestShortID()
+	proposalTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	err = blockBuilder.AddUnverifiedTx(proposalTx)
+	assert.NoError(err)
+
+	blkIntf, err := vm.BuildBlock()
+	assert.NoError(err)
+	blk, ok := blkIntf.(*ProposalBlock)
+	assert.True(ok, "expected proposal block")
+	assert.Equal(proposalTx.ID(), blk.Tx.ID())
+	assert.True(blockBuilder.Has(decisionTx.ID()))
+}
+
+// shows that building a block from a populated mempool updates the block
+// building metrics
+func TestBlockBuilderMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+	blockBuilder := &vm.blockBuilder
+
+	decisionTx := getValidTx(vm, t)
+	err := blockBuilder.AddUnverifiedTx(decisionTx)
+	assert.NoError(err)
+
+	// This proposal tx isn't imminent, so it is left in the mempool while the
+	// decision tx is issued
+	startTime := defaultGenesisTime.Add(syncBound).Add(imminentProposalTxBound).Add(time.Minute)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	nodeID := ids.GenerateTestShortID()
+	proposalTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	err = blockBuilder.AddUnverifiedTx(proposalTx)
+	assert.NoError(err)
+
+	latency := &dto.Metric{}
+	err = vm.metrics.blockBuildLatency.Write(latency)
+	assert.NoError(err)
+	latencySamplesBefore := latency.GetHistogram().GetSampleCount()
+	builtBefore := testutil.ToFloat64(vm.metrics.numStandardBlocksBuilt)
+
+	blkIntf, err := vm.BuildBlock()
+	assert.NoError(err)
+	_, ok := blkIntf.(*StandardBlock)
+	assert.True(ok, "expected standard block")
+
+	assert.Equal(builtBefore+1, testutil.ToFloat64(vm.metrics.numStandardBlocksBuilt))
+	assert.Equal(float64(1), testutil.ToFloat64(vm.metrics.lastBuiltBlockTxs))
+	assert.Equal(float64(1), testutil.ToFloat64(vm.metrics.lastBuiltBlockTxsRemaining))
+
+	latency = &dto.Metric{}
+	err = vm.metrics.blockBuildLatency.Write(latency)
+	assert.NoError(err)
+	assert.Equal(latencySamplesBefore+1, latency.GetHistogram().GetSampleCount())
+}
+
+// shows that the UTXOs consumed by a tx are reserved until the tx is accepted
+func TestBlockBuilderReservesUTXOs(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+	blockBuilder := &vm.blockBuilder
+
+	newExportTx := func() *Tx {
+		tx, err := vm.newExportTx(
+			100,
+			vm.ctx.XChainID,
+			ids.GenerateTestShortID(),
+			[]*crypto.PrivateKeySECP256K1R{keys[0]},
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+		return tx
+	}
+
+	// Both txs are built before either is issued, so they spend the same UTXO
+	tx := newExportTx()
+	conflictingTx := newExportTx()
+
+	err := blockBuilder.AddUnverifiedTx(tx)
+	assert.NoError(err)
+	err = blockBuilder.AddUnverifiedTx(conflictingTx)
+	assert.ErrorIs(err, errUTXOReserved)
+
+	inputs := tx.InputIDs()
+	assert.Equal(1, inputs.Len())
+	utxoID := inputs.List()[0]
+	assert.True(blockBuilder.IsReserved(utxoID))
+
+	// The UTXO is no longer selected while the tx is pending
+	_, err = vm.newExportTx(
+		100,
+		vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.Error(err)
+
+	// The UTXO stays reserved while the tx is in a processing block
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	err = blk.Verify()
+	assert.NoError(err)
+	assert.False(blockBuilder.Has(tx.ID()))
+	assert.True(blockBuilder.IsReserved(utxoID))
+
+	err = blk.Accept()
+	assert.NoError(err)
+	assert.False(blockBuilder.IsReserved(utxoID))
+}
+
+// shows that many txs built and issued in parallel from the same keys don't
+// select the same UTXOs
+func TestBlockBuilderRejectsInvalidSignatures(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+	blockBuilder := &vm.blockBuilder
+
+	tx, err := vm.newExportTx(
+		100,
+		vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	// Corrupt the signature
+	cred, ok := tx.Creds[0].(*secp256k1fx.Credential)
+	assert.True(ok)
+	cred.Sigs[0] = [crypto.SECP256K1RSigLen]byte{}
+
+	err = blockBuilder.AddUnverifiedTx(tx)
+	assert.ErrorIs(err, errInvalidCredentials)
+	assert.False(blockBuilder.Has(tx.ID()))
+	assert.True(blockBuilder.WasDropped(tx.ID()))
+
+	// The consumed UTXO was never reserved
+	for utxoID := range tx.InputIDs() {
+		assert.False(blockBuilder.IsReserved(utxoID))
+	}
+
+	failures := testutil.ToFloat64(vm.txVerifyFailures.WithLabelValues("export", "invalid_signature"))
+	assert.Equal(1.0, failures)
+}
+
+func TestBlockBuilderConcurrentIssuance(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	defer func() {
+		vm.ctx.Lock.Lock()
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Each of the keys controls a single UTXO, which is enough to pay for one
+	// of the txs
+	numTxs := len(keys)
+	errs := make(chan error, numTxs)
+	wg := sync.WaitGroup{}
+	wg.Add(numTxs)
+	for i := 0; i < numTxs; i++ {
+		go func() {
+			defer wg.Done()
+
+			vm.ctx.Lock.Lock()
+			defer vm.ctx.Lock.Unlock()
+
+			tx, err := vm.newExportTx(
+				100,
+				vm.ctx.XChainID,
+				ids.GenerateTestShortID(),
+				keys,
+				ids.ShortEmpty, // change addr
+			)
+			if err != nil {
+				errs <- err
+				return
+			}
+			errs <- vm.blockBuilder.AddUnverifiedTx(tx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(err)
+	}
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+	assert.Equal(numTxs, vm.blockBuilder.Len())
+}
+
+// show that txs in the mempool are re-verified and restored after a restart
+func TestBlockBuilderRestoresPersistedTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, genesisBytes := defaultGenesis()
+	db := manager.NewMemDB(version.DefaultVersion1_0_0)
+
+	newVM := func(minValidatorStake uint64) *VM {
+		vm := &VM{Factory: Factory{
+			Chains:                   chains.MockManager{},
+			UptimeLockedCalculator:   uptime.NewLockedCalculator(),
+			Validators:               validators.NewManager(),
+			TxFee:                    defaultTxFee,
+			CreateSubnetTxFee:        100 * defaultTxFee,
+			MinValidatorStake:        minValidatorStake,
+			MaxValidatorStake:        defaultMaxValidatorStake,
+			MaxValidatorWeightFactor: defaultMaxValidatorWeightFactor,
+			MinStakeDuration:         defaultMinStakingDuration,
+			MaxStakeDuration:         defaultMaxStakingDuration,
+			StakeMintingPeriod:       defaultMaxStakingDuration,
+			ApricotPhase3Time:        defaultValidateEndTime,
+			ApricotPhase4Time:        defaultValidateEndTime,
+			ApricotPhase5Time:        defaultValidateEndTime,
+		}}
+		vm.clock.Set(defaultGenesisTime)
+
+		ctx := defaultContext()
+		ctx.Lock.Lock()
+		defer ctx.Lock.Unlock()
+
+		appSender := &common.SenderTest{}
+		appSender.SendAppGossipF = func([]byte) error { return nil }
+
+		msgChan := make(chan common.Message, 1)
+		err := vm.Initialize(ctx, db.NewPrefixDBManager([]byte{}), genesisBytes, nil, nil, msgChan, nil, appSender)
+		assert.NoError(err)
+		err = vm.Bootstrapped()
+		assert.NoError(err)
+		return vm
+	}
+
+	firstVM := newVM(defaultMinValidatorStake)
+	firstVM.ctx.Lock.Lock()
+
+	subnetTx, err := firstVM.newCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	err = firstVM.blockBuilder.AddUnverifiedTx(subnetTx)
+	assert.NoError(err)
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	validatorTx, err := firstVM.newAddValidatorTx(
+		defaultMinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		ids.GenerateTestShortID(),
+		keys[1].PublicKey().Address(),
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		keys[1].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	err = firstVM.blockBuilder.AddUnverifiedTx(validatorTx)
+	assert.NoError(err)
+
+	err = firstVM.Shutdown()
+	assert.NoError(err)
+	firstVM.ctx.Lock.Unlock()
+
+	// The validator tx no longer stakes enough after the restart
+	secondVM := newVM(defaultMinValidatorStake + 1)
+	secondVM.ctx.Lock.Lock()
+	defer func() {
+		err := secondVM.Shutdown()
+		assert.NoError(err)
+		secondVM.ctx.Lock.Unlock()
+	}()
+
+	assert.True(secondVM.blockBuilder.Has(subnetTx.ID()))
+	assert.False(secondVM.blockBuilder.Has(validatorTx.ID()))
+
+	reason, dropped := secondVM.droppedTxCache.Get(validatorTx.ID())
+	assert.True(dropped)
+	assert.Contains(reason, errWeightTooSmall.Error())
+}
+
+// shows that no advance time proposal is built when no staker change is due
+func TestBlockBuilderSkipsNoOpAdvanceTime(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// There are no pending stakers and the next current staker leaves far in
+	// the future, so there is nothing to build.
+	chainTime := vm.internalState.GetTimestamp()
+	nextStakerChangeTime, err := vm.nextStakerChangeTime(vm.internalState)
+	assert.NoError(err)
+	assert.True(nextStakerChangeTime.After(chainTime.Add(24 * time.Hour)))
+	vm.clock.Set(chainTime.Add(time.Hour))
+
+	_, err = vm.BuildBlock()
+	assert.ErrorIs(err, errNoPendingBlocks)
+
+	// Once the next staker change is due, the chain time is moved to it
+	vm.clock.Set(nextStakerChangeTime)
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	proposalBlk, ok := blk.(*ProposalBlock)
+	assert.True(ok, "expected proposal block")
+	advanceTimeTx, ok := proposalBlk.Tx.UnsignedTx.(*UnsignedAdvanceTimeTx)
+	assert.True(ok, "expected advance time tx")
+	assert.Equal(nextStakerChangeTime.Unix(), advanceTimeTx.Timestamp().Unix())
+}
+
+// shows that standard blocks are built within the configured limits, and that
+// the remaining txs are left for subsequent blocks
+func TestBlockBuilderStandardBlockLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	blockBuilder := &vm.blockBuilder
+	mempool := blockBuilder.Mempool.(*mempool)
+
+	// These txs all consume the same UTXOs, so they are added directly to the
+	// decision tx heap to skip the conflict checks.
+	txs := make([]*Tx, 3)
+	for i := range txs {
+		tx, err := vm.newCreateChainTx(
+			testSubnet1.ID(),
+			nil,
+			avm.ID,
+			nil,
+			fmt.Sprintf("chain %d", i),
+			[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+		txs[i] = tx
+	}
+	txSize := standardBlockTxSize(txs[0])
+	refill := func() {
+		for _, tx := range txs {
+			if !mempool.Has(tx.ID()) {
+				mempool.AddDecisionTx(tx)
+			}
+		}
+	}
+	refill()
+
+	// Case: limited by the number of txs
+	vm.config.MaxBlockTxs = 2
+	assert.Len(blockBuilder.popStandardBlockTxs(false), 2)
+	assert.Len(blockBuilder.popStandardBlockTxs(false), 1)
+	assert.False(mempool.HasDecisionTxs())
+	refill()
+
+	// Case: the txs exactly fill the block
+	vm.config.MaxBlockTxs = defaultMaxBlockTxs
+	vm.config.MaxBlockSize = standardBlockOverhead + 2*txSize
+	blockTxs := blockBuilder.popStandardBlockTxs(false)
+	assert.Len(blockTxs, 2)
+	preferred, err := vm.Preferred()
+	assert.NoError(err)
+	blk, err := vm.newStandardBlock(preferred.ID(), preferred.Height()+1, blockTxs)
+	assert.NoError(err)
+	assert.Len(blk.Bytes(), vm.config.MaxBlockSize)
+	refill()
+
+	// Case: one byte too small for the second tx
+	vm.config.MaxBlockSize = standardBlockOverhead + 2*txSize - 1
+	assert.Len(blockBuilder.popStandardBlockTxs(false), 1)
+	assert.Len(blockBuilder.popStandardBlockTxs(false), 1)
+	assert.Len(blockBuilder.popStandardBlockTxs(false), 1)
+	refill()
+
+	// Case: a tx larger than the limit is built into a block by itself
+	vm.config.MaxBlockSize = standardBlockOverhead + txSize - 1
+	assert.Len(blockBuilder.popStandardBlockTxs(false), 1)
+	refill()
+
+	// Case: a tx that can't fit into any valid block is dropped
+	for _, tx := range txs {
+		mempool.RemoveDecisionTxs([]*Tx{tx})
+	}
+	vm.config.MaxBlockSize = defaultMaxBlockSize
+	// The tx can be marshalled on its own, but not in a block
+	largeTx := txs[0]
+	largeTx.UnsignedTx.(*UnsignedCreateChainTx).GenesisData = make([]byte, maxStandardBlockSize-len(largeTx.Bytes()))
+	assert.NoError(largeTx.Sign(Codec, nil))
+	assert.Len(largeTx.Bytes(), maxStandardBlockSize)
+	mempool.AddDecisionTx(largeTx)
+	assert.Empty(blockBuilder.popStandardBlockTxs(false))
+	assert.False(mempool.Has(largeTx.ID()))
+	_, dropped := vm.droppedTxCache.Get(largeTx.ID())
+	assert.True(dropped)
+}
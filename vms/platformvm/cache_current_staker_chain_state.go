@@ -40,6 +40,10 @@ type currentStakerChainState interface {
 		numTxsToRemove int,
 	) (currentStakerChainState, error)
 	DeleteNextStaker() (currentStakerChainState, error)
+	// DeleteSubnetValidator removes [nodeID] from the validator set of
+	// [subnetID] before its staking period ends. Returns database.ErrNotFound
+	// if [nodeID] isn't currently validating [subnetID].
+	DeleteSubnetValidator(nodeID ids.ShortID, subnetID ids.ID) (currentStakerChainState, error)
 
 	// Stakers returns the current stakers on the network sorted in order of the
 	// order of their future removal from the validator set.
@@ -68,6 +72,12 @@ type currentStakerChainStateImpl struct {
 
 	addedStakers   []*validatorReward
 	deletedStakers []*Tx
+
+	// If non-nil, the changes of [parent] must be applied before the changes
+	// of this state. This is only set when several versions of the staker set
+	// may be created while executing a single block, so that none of their
+	// changes are lost when the block is accepted.
+	parent *currentStakerChainStateImpl
 }
 
 type validatorReward struct {
@@ -266,11 +276,64 @@ func (cs *currentStakerChainStateImpl) DeleteNextStaker() (currentStakerChainSta
 	return newCS, nil
 }
 
+func (cs *currentStakerChainStateImpl) DeleteSubnetValidator(nodeID ids.ShortID, subnetID ids.ID) (currentStakerChainState, error) {
+	vdr, exists := cs.validatorsByNodeID[nodeID]
+	if !exists {
+		return nil, database.ErrNotFound
+	}
+	subnetTx, exists := vdr.subnets[subnetID]
+	if !exists {
+		return nil, database.ErrNotFound
+	}
+	removedTxID := subnetTx.ID()
+	removedTx := cs.validatorsByTxID[removedTxID].addStakerTx
+
+	newCS := &currentStakerChainStateImpl{
+		validatorsByNodeID: make(map[ids.ShortID]*currentValidatorImpl, len(cs.validatorsByNodeID)),
+		validatorsByTxID:   make(map[ids.ID]*validatorReward, len(cs.validatorsByTxID)-1),
+		validators:         make([]*Tx, 0, len(cs.validators)-1),
+
+		deletedStakers: []*Tx{removedTx},
+		parent:         cs,
+	}
+
+	for _, tx := range cs.validators {
+		if tx.ID() != removedTxID {
+			newCS.validators = append(newCS.validators, tx) // sorted in order of removal
+		}
+	}
+
+	for nodeID, vdr := range cs.validatorsByNodeID {
+		newCS.validatorsByNodeID[nodeID] = vdr
+	}
+	newVdr := *vdr
+	newVdr.subnets = make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(vdr.subnets)-1)
+	for otherSubnetID, addTx := range vdr.subnets {
+		if otherSubnetID != subnetID {
+			newVdr.subnets[otherSubnetID] = addTx
+		}
+	}
+	newCS.validatorsByNodeID[nodeID] = &newVdr
+
+	for txID, vdr := range cs.validatorsByTxID {
+		if txID != removedTxID {
+			newCS.validatorsByTxID[txID] = vdr
+		}
+	}
+
+	newCS.setNextStaker()
+	return newCS, nil
+}
+
 func (cs *currentStakerChainStateImpl) Stakers() []*Tx {
 	return cs.validators
 }
 
 func (cs *currentStakerChainStateImpl) Apply(is InternalState) {
+	if cs.parent != nil {
+		cs.parent.Apply(is)
+		cs.parent = nil
+	}
 	for _, added := range cs.addedStakers {
 		is.AddCurrentStaker(added.addStakerTx, added.potentialReward)
 	}
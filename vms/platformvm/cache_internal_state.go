@@ -6,6 +6,7 @@ package platformvm
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -19,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/uptime"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -36,19 +38,24 @@ var (
 	subnetValidatorPrefix = []byte("subnetValidator")
 	validatorDiffsPrefix  = []byte("validatorDiffs")
 	blockPrefix           = []byte("block")
+	blockIDPrefix         = []byte("blockID")
 	txPrefix              = []byte("tx")
 	rewardUTXOsPrefix     = []byte("rewardUTXOs")
 	utxoPrefix            = []byte("utxo")
 	subnetPrefix          = []byte("subnet")
 	chainPrefix           = []byte("chain")
 	singletonPrefix       = []byte("singleton")
+	supplyPrefix          = []byte("supply")
 
 	timestampKey     = []byte("timestamp")
 	currentSupplyKey = []byte("current supply")
 	lastAcceptedKey  = []byte("last accepted")
 	initializedKey   = []byte("initialized")
+	heightIndexedKey = []byte("height indexed")
+	supplyIndexedKey = []byte("supply indexed")
 
-	errWrongNetworkID = errors.New("tx has wrong network ID")
+	errWrongNetworkID   = errors.New("tx has wrong network ID")
+	errSupplyNotIndexed = errors.New("supply isn't indexed at the requested height")
 
 	_ InternalState = &internalStateImpl{}
 )
@@ -67,6 +74,10 @@ const (
 	rewardUTXOsCacheSize    = 2048
 	chainCacheSize          = 2048
 	chainDBCacheSize        = 2048
+
+	// heightIndexCommitFrequency is the number of blocks indexed between
+	// commits while backfilling the height index.
+	heightIndexCommitFrequency = 8192
 )
 
 type InternalState interface {
@@ -91,6 +102,15 @@ type InternalState interface {
 	GetBlock(blockID ids.ID) (Block, error)
 	AddBlock(block Block)
 
+	// GetBlockIDAtHeight returns the ID of the accepted block at [height].
+	// Returns [database.ErrNotFound] if no block has been accepted at [height].
+	GetBlockIDAtHeight(height uint64) (ids.ID, error)
+
+	// GetCurrentSupplyAtHeight returns the current supply after the accepted
+	// block at [height] was applied. Returns [errSupplyNotIndexed] if [height]
+	// predates the supply index.
+	GetCurrentSupplyAtHeight(height uint64) (uint64, error)
+
 	UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error)
 
 	Abort()
@@ -128,6 +148,10 @@ type InternalState interface {
  * |       '-- nodeID -> weightChange
  * |-. blocks
  * | '-- blockID -> block bytes
+ * |-. blockIDs
+ * | '-- height -> blockID of the accepted block
+ * |-. supply
+ * | '-- ^height -> current supply after the accepted block at height
  * |-. txs
  * | '-- txID -> tx bytes + tx status
  * |- rewardUTXOs
@@ -145,6 +169,8 @@ type InternalState interface {
  * |     '-- txID -> nil
  * '-. singletons
  *   |-- initializedKey -> nil
+ *   |-- heightIndexedKey -> nil
+ *   |-- supplyIndexedKey -> first height in the supply index
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
  *   '-- lastAcceptedKey -> lastAccepted
@@ -187,6 +213,10 @@ type internalStateImpl struct {
 	addedBlocks map[ids.ID]Block // map of blockID -> Block
 	blockCache  cache.Cacher     // cache of blockID -> Block, if the entry is nil, it is not in the database
 	blockDB     database.Database
+	blockIDDB   database.Database
+
+	// Only holds the heights at which the current supply changed
+	supplyDB database.Database
 
 	addedTxs map[ids.ID]*txStatusImpl // map of txID -> {*Tx, Status}
 	txCache  cache.Cacher             // cache of txID -> {*Tx, Status} if the entry is nil, it is not in the database
@@ -283,6 +313,8 @@ func newInternalStateDatabases(vm *VM, db database.Database) *internalStateImpl
 
 		addedBlocks: make(map[ids.ID]Block),
 		blockDB:     prefixdb.New(blockPrefix, baseDB),
+		blockIDDB:   prefixdb.New(blockIDPrefix, baseDB),
+		supplyDB:    prefixdb.New(supplyPrefix, baseDB),
 
 		addedTxs: make(map[ids.ID]*txStatusImpl),
 		txDB:     prefixdb.New(txPrefix, baseDB),
@@ -405,9 +437,99 @@ func (st *internalStateImpl) sync(genesis []byte) error {
 			err,
 		)
 	}
+
+	if err := st.indexBlockHeights(); err != nil {
+		return fmt.Errorf(
+			"failed to index the accepted blocks by height: %w",
+			err,
+		)
+	}
+
+	if err := st.indexSupply(); err != nil {
+		return fmt.Errorf(
+			"failed to index the current supply: %w",
+			err,
+		)
+	}
 	return nil
 }
 
+// indexBlockHeights populates the height index with the accepted blocks that
+// were written before the height index existed. This is only done once.
+func (st *internalStateImpl) indexBlockHeights() error {
+	indexed, err := st.singletonDB.Has(heightIndexedKey)
+	if err != nil || indexed {
+		return err
+	}
+
+	st.vm.ctx.Log.Info("indexing accepted blocks by height")
+
+	blkID := st.lastAccepted
+	numIndexed := 0
+	for {
+		blk, err := st.GetBlock(blkID)
+		if err != nil {
+			return err
+		}
+		height := blk.Height()
+		if err := database.PutID(st.blockIDDB, database.PackUInt64(height), blkID); err != nil {
+			return err
+		}
+
+		numIndexed++
+		if numIndexed%heightIndexCommitFrequency == 0 {
+			if err := st.Commit(); err != nil {
+				return err
+			}
+			st.vm.ctx.Log.Info("indexed %d accepted blocks by height", numIndexed)
+		}
+
+		if height == 0 {
+			break
+		}
+		blkID = blk.Parent()
+	}
+
+	if err := st.singletonDB.Put(heightIndexedKey, nil); err != nil {
+		return err
+	}
+	st.vm.ctx.Log.Info("finished indexing %d accepted blocks by height", numIndexed)
+	return st.Commit()
+}
+
+// indexSupply starts the supply index at the last accepted height. The supply
+// at earlier heights isn't known, as it wasn't recorded when those blocks were
+// accepted. This is only done once.
+func (st *internalStateImpl) indexSupply() error {
+	indexed, err := st.singletonDB.Has(supplyIndexedKey)
+	if err != nil || indexed {
+		return err
+	}
+
+	lastAccepted, err := st.GetBlock(st.lastAccepted)
+	if err != nil {
+		return err
+	}
+	height := lastAccepted.Height()
+
+	st.vm.ctx.Log.Info("indexing the current supply starting at height %d", height)
+
+	if err := database.PutUInt64(st.supplyDB, supplyKey(height), st.currentSupply); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(st.singletonDB, supplyIndexedKey, height); err != nil {
+		return err
+	}
+	return st.Commit()
+}
+
+// supplyKey returns the key of the supply index entry at [height]. Heights are
+// inverted so that iterating from the key of a height visits the greatest
+// indexed height that is <= that height first.
+func supplyKey(height uint64) []byte {
+	return database.PackUInt64(math.MaxUint64 - height)
+}
+
 func NewInternalState(vm *VM, db database.Database, genesis []byte) (InternalState, error) {
 	is := newInternalStateDatabases(vm, db)
 	is.initCaches()
@@ -583,9 +705,22 @@ func (st *internalStateImpl) AddTx(tx *Tx, status Status) {
 }
 
 func (st *internalStateImpl) GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error) {
-	if utxos, exists := st.addedRewardUTXOs[txID]; exists {
+	// Reward UTXOs may be added to [txID] over multiple batches, so the
+	// unwritten ones are appended to the ones that were already written.
+	utxos, err := st.getWrittenRewardUTXOs(txID)
+	if err != nil {
+		return nil, err
+	}
+	added := st.addedRewardUTXOs[txID]
+	if len(added) == 0 {
 		return utxos, nil
 	}
+	allUTXOs := make([]*avax.UTXO, 0, len(utxos)+len(added))
+	allUTXOs = append(allUTXOs, utxos...)
+	return append(allUTXOs, added...), nil
+}
+
+func (st *internalStateImpl) getWrittenRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error) {
 	if utxos, exists := st.rewardUTXOsCache.Get(txID); exists {
 		return utxos.([]*avax.UTXO), nil
 	}
@@ -673,6 +808,37 @@ func (st *internalStateImpl) AddBlock(block Block) {
 	st.addedBlocks[block.ID()] = block
 }
 
+func (st *internalStateImpl) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	// Blocks that haven't been committed yet are still indexed by the in-memory
+	// blocks.
+	for blkID, blk := range st.addedBlocks {
+		if blk.Height() == height && blk.Status() == choices.Accepted {
+			return blkID, nil
+		}
+	}
+	return database.GetID(st.blockIDDB, database.PackUInt64(height))
+}
+
+func (st *internalStateImpl) GetCurrentSupplyAtHeight(height uint64) (uint64, error) {
+	firstIndexedHeight, err := database.GetUInt64(st.singletonDB, supplyIndexedKey)
+	if err != nil {
+		return 0, err
+	}
+	if height < firstIndexedHeight {
+		return 0, fmt.Errorf("%w: %d is before %d", errSupplyNotIndexed, height, firstIndexedHeight)
+	}
+
+	it := st.supplyDB.NewIteratorWithStart(supplyKey(height))
+	defer it.Release()
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("%w: %d", errSupplyNotIndexed, height)
+	}
+	return database.ParseUInt64(it.Value())
+}
+
 func (st *internalStateImpl) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error) {
 	return st.utxoState.UTXOIDs(addr, start, limit)
 }
@@ -799,6 +965,9 @@ func (st *internalStateImpl) CommitBatch() (database.Batch, error) {
 	if err := st.writeCurrentStakers(); err != nil {
 		return nil, fmt.Errorf("failed to write current stakers with: %w", err)
 	}
+	if err := st.pruneValidatorDiffs(); err != nil {
+		return nil, fmt.Errorf("failed to prune validator diffs with: %w", err)
+	}
 	if err := st.writePendingStakers(); err != nil {
 		return nil, fmt.Errorf("failed to write pending stakers with: %w", err)
 	}
@@ -842,6 +1011,8 @@ func (st *internalStateImpl) Close() error {
 		st.currentValidatorsDB.Close(),
 		st.validatorsDB.Close(),
 		st.blockDB.Close(),
+		st.blockIDDB.Close(),
+		st.supplyDB.Close(),
 		st.txDB.Close(),
 		st.rewardUTXODB.Close(),
 		st.utxoDB.Close(),
@@ -862,6 +1033,38 @@ type currentValidatorState struct {
 	PotentialReward uint64        `serialize:"true"`
 }
 
+// parseCurrentValidatorState parses the record stored for the current
+// validator added by [txID], which started validating at [startTime].
+//
+// Records written before uptimes were tracked only contain the potential
+// reward of the validator. For those, no uptime has been observed, so the
+// uptime is tracked as if it was last updated when the validator started.
+func parseCurrentValidatorState(txID ids.ID, b []byte, startTime time.Time) (*currentValidatorState, error) {
+	if len(b) == wrappers.LongLen {
+		potentialReward, err := database.ParseUInt64(b)
+		if err != nil {
+			return nil, err
+		}
+		return &currentValidatorState{
+			txID:        txID,
+			lastUpdated: startTime,
+
+			UpDuration:      0,
+			LastUpdated:     uint64(startTime.Unix()),
+			PotentialReward: potentialReward,
+		}, nil
+	}
+
+	uptime := &currentValidatorState{
+		txID: txID,
+	}
+	if _, err := GenesisCodec.Unmarshal(b, uptime); err != nil {
+		return nil, err
+	}
+	uptime.lastUpdated = time.Unix(int64(uptime.LastUpdated), 0)
+	return uptime, nil
+}
+
 func (st *internalStateImpl) writeCurrentStakers() error {
 	weightDiffs := make(map[ids.ID]map[ids.ShortID]*ValidatorWeightDiff) // subnetID -> nodeID -> weightDiff
 	for _, currentStaker := range st.addedCurrentStakers {
@@ -917,6 +1120,7 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 		default:
 			return errWrongTxType
 		}
+		st.vm.metrics.AddStaker(currentStaker.addStakerTx, true)
 
 		subnetDiffs, ok := weightDiffs[subnetID]
 		if !ok {
@@ -975,6 +1179,7 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 		if err := db.Delete(txID[:]); err != nil {
 			return err
 		}
+		st.vm.metrics.RemoveStaker(tx, true)
 
 		subnetDiffs, ok := weightDiffs[subnetID]
 		if !ok {
@@ -1033,6 +1238,63 @@ func (st *internalStateImpl) writeCurrentStakers() error {
 	return nil
 }
 
+// pruneValidatorDiffs deletes the validator weight diffs of the height that
+// just left the retention window. They are only needed to compute the
+// validator sets at heights that are no longer retained.
+func (st *internalStateImpl) pruneValidatorDiffs() error {
+	retention := st.vm.config.ValidatorDiffsRetention
+	if st.currentHeight <= retention {
+		return nil
+	}
+	height := st.currentHeight - retention
+
+	subnets, err := st.GetSubnets()
+	if err != nil {
+		return err
+	}
+	subnetIDs := make([]ids.ID, 0, len(subnets)+1)
+	subnetIDs = append(subnetIDs, constants.PrimaryNetworkID)
+	for _, subnet := range subnets {
+		subnetIDs = append(subnetIDs, subnet.ID())
+	}
+
+	for _, subnetID := range subnetIDs {
+		prefixStruct := heightWithSubnet{
+			Height:   height,
+			SubnetID: subnetID,
+		}
+		prefixBytes, err := GenesisCodec.Marshal(CodecVersion, prefixStruct)
+		if err != nil {
+			return err
+		}
+		st.validatorDiffsCache.Evict(string(prefixBytes))
+
+		rawDiffDB := prefixdb.New(prefixBytes, st.validatorDiffsDB)
+		keys, err := keysOf(rawDiffDB)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := rawDiffDB.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keysOf returns all the keys in [db]
+func keysOf(db database.Iteratee) ([][]byte, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	keys := [][]byte(nil)
+	for it.Next() {
+		keys = append(keys, utils.CopyBytes(it.Key()))
+	}
+	return keys, it.Error()
+}
+
 func (st *internalStateImpl) writePendingStakers() error {
 	for _, tx := range st.addedPendingStakers {
 		var db database.KeyValueWriter
@@ -1051,6 +1313,7 @@ func (st *internalStateImpl) writePendingStakers() error {
 		if err := db.Put(txID[:], nil); err != nil {
 			return err
 		}
+		st.vm.metrics.AddStaker(tx, false)
 	}
 	st.addedPendingStakers = nil
 
@@ -1071,6 +1334,7 @@ func (st *internalStateImpl) writePendingStakers() error {
 		if err := db.Delete(txID[:]); err != nil {
 			return err
 		}
+		st.vm.metrics.RemoveStaker(tx, false)
 	}
 	st.deletedPendingStakers = nil
 	return nil
@@ -1113,6 +1377,13 @@ func (st *internalStateImpl) writeBlocks() error {
 		if err := st.blockDB.Put(blkID[:], btxBytes); err != nil {
 			return err
 		}
+
+		if blk.Status() != choices.Accepted {
+			continue
+		}
+		if err := database.PutID(st.blockIDDB, database.PackUInt64(blk.Height()), blkID); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1143,7 +1414,9 @@ func (st *internalStateImpl) writeRewardUTXOs() error {
 	for txID, utxos := range st.addedRewardUTXOs {
 		delete(st.addedRewardUTXOs, txID)
 
-		st.rewardUTXOsCache.Put(txID, utxos)
+		// The cached UTXOs may only be a subset of the UTXOs written under
+		// [txID], so they're re-read from the database when needed.
+		st.rewardUTXOsCache.Evict(txID)
 
 		rawTxDB := prefixdb.New(txID[:], st.rewardUTXODB)
 		txDB := linkeddb.NewDefault(rawTxDB)
@@ -1217,6 +1490,9 @@ func (st *internalStateImpl) writeSingletons() error {
 		if err := database.PutUInt64(st.singletonDB, currentSupplyKey, st.currentSupply); err != nil {
 			return err
 		}
+		if err := database.PutUInt64(st.supplyDB, supplyKey(st.currentHeight), st.currentSupply); err != nil {
+			return err
+		}
 		st.originalCurrentSupply = st.currentSupply
 	}
 	if st.originalLastAccepted != st.lastAccepted {
@@ -1282,20 +1558,21 @@ func (st *internalStateImpl) loadCurrentValidators() error {
 			return err
 		}
 
-		uptimeBytes := validatorIt.Value()
-		uptime := &currentValidatorState{
-			txID: txID,
-		}
-		if _, err := GenesisCodec.Unmarshal(uptimeBytes, uptime); err != nil {
-			return err
-		}
-		uptime.lastUpdated = time.Unix(int64(uptime.LastUpdated), 0)
-
 		addValidatorTx, ok := tx.UnsignedTx.(*UnsignedAddValidatorTx)
 		if !ok {
 			return errWrongTxType
 		}
 
+		uptimeBytes := validatorIt.Value()
+		uptime, err := parseCurrentValidatorState(txID, uptimeBytes, addValidatorTx.StartTime())
+		if err != nil {
+			return err
+		}
+		if len(uptimeBytes) == wrappers.LongLen {
+			// Rewrite the legacy record in the current format
+			st.updatedUptimes[addValidatorTx.Validator.NodeID] = struct{}{}
+		}
+
 		cs.validators = append(cs.validators, tx)
 		cs.validatorsByNodeID[addValidatorTx.Validator.NodeID] = &currentValidatorImpl{
 			validatorImpl: validatorImpl{
@@ -1395,6 +1672,7 @@ func (st *internalStateImpl) loadCurrentValidators() error {
 	cs.setNextStaker()
 
 	st.currentStakerChainState = cs
+	st.vm.metrics.SetStakers(cs.validators, true)
 	return nil
 }
 
@@ -1500,6 +1778,7 @@ func (st *internalStateImpl) loadPendingValidators() error {
 	sortValidatorsByAddition(ps.validators)
 
 	st.pendingStakerChainState = ps
+	st.vm.metrics.SetStakers(ps.validators, false)
 	return nil
 }
 
@@ -23,6 +23,10 @@ type pendingStakerChainState interface {
 
 	AddStaker(addStakerTx *Tx) pendingStakerChainState
 	DeleteStakers(numToRemove int) pendingStakerChainState
+	// DeleteSubnetValidator removes [nodeID] from the pending validator set of
+	// [subnetID]. Returns database.ErrNotFound if [nodeID] isn't pending to
+	// validate [subnetID].
+	DeleteSubnetValidator(nodeID ids.ShortID, subnetID ids.ID) (pendingStakerChainState, error)
 
 	// Stakers returns the list of pending validators in order of their removal
 	// from the pending staker set
@@ -45,6 +49,10 @@ type pendingStakerChainStateImpl struct {
 
 	addedStakers   []*Tx
 	deletedStakers []*Tx
+
+	// If non-nil, the changes of [parent] must be applied before the changes
+	// of this state. See currentStakerChainStateImpl.
+	parent *pendingStakerChainStateImpl
 }
 
 func (ps *pendingStakerChainStateImpl) GetValidatorTx(nodeID ids.ShortID) (addStakerTx *UnsignedAddValidatorTx, err error) {
@@ -194,11 +202,62 @@ func (ps *pendingStakerChainStateImpl) DeleteStakers(numToRemove int) pendingSta
 	return newPS
 }
 
+func (ps *pendingStakerChainStateImpl) DeleteSubnetValidator(nodeID ids.ShortID, subnetID ids.ID) (pendingStakerChainState, error) {
+	vdr, exists := ps.validatorExtrasByNodeID[nodeID]
+	if !exists {
+		return nil, database.ErrNotFound
+	}
+	subnetTx, exists := vdr.subnets[subnetID]
+	if !exists {
+		return nil, database.ErrNotFound
+	}
+	removedTxID := subnetTx.ID()
+
+	newPS := &pendingStakerChainStateImpl{
+		validatorsByNodeID:      ps.validatorsByNodeID,
+		validatorExtrasByNodeID: make(map[ids.ShortID]*validatorImpl, len(ps.validatorExtrasByNodeID)),
+		validators:              make([]*Tx, 0, len(ps.validators)-1),
+
+		parent: ps,
+	}
+
+	for _, tx := range ps.validators {
+		if tx.ID() == removedTxID {
+			newPS.deletedStakers = []*Tx{tx}
+		} else {
+			newPS.validators = append(newPS.validators, tx) // sorted in order of addition
+		}
+	}
+
+	for otherNodeID, otherVdr := range ps.validatorExtrasByNodeID {
+		if otherNodeID != nodeID {
+			newPS.validatorExtrasByNodeID[otherNodeID] = otherVdr
+		}
+	}
+	if len(vdr.delegators) != 0 || len(vdr.subnets) != 1 {
+		newSubnets := make(map[ids.ID]*UnsignedAddSubnetValidatorTx, len(vdr.subnets)-1)
+		for otherSubnetID, addTx := range vdr.subnets {
+			if otherSubnetID != subnetID {
+				newSubnets[otherSubnetID] = addTx
+			}
+		}
+		newPS.validatorExtrasByNodeID[nodeID] = &validatorImpl{
+			delegators: vdr.delegators,
+			subnets:    newSubnets,
+		}
+	}
+	return newPS, nil
+}
+
 func (ps *pendingStakerChainStateImpl) Stakers() []*Tx {
 	return ps.validators
 }
 
 func (ps *pendingStakerChainStateImpl) Apply(is InternalState) {
+	if ps.parent != nil {
+		ps.parent.Apply(is)
+		ps.parent = nil
+	}
 	for _, added := range ps.addedStakers {
 		is.AddPendingStaker(added)
 	}
@@ -62,6 +62,9 @@ type MutableState interface {
 type VersionedState interface {
 	MutableState
 
+	SetCurrentStakerChainState(currentStakerChainState)
+	SetPendingStakerChainState(pendingStakerChainState)
+
 	SetBase(MutableState)
 	Apply(InternalState)
 }
@@ -238,10 +241,17 @@ func (vs *versionedStateImpl) AddTx(tx *Tx, status Status) {
 }
 
 func (vs *versionedStateImpl) GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error) {
-	if utxos, exists := vs.addedRewardUTXOs[txID]; exists {
+	utxos, err := vs.parentState.GetRewardUTXOs(txID)
+	if err != nil {
+		return nil, err
+	}
+	added := vs.addedRewardUTXOs[txID]
+	if len(added) == 0 {
 		return utxos, nil
 	}
-	return vs.parentState.GetRewardUTXOs(txID)
+	allUTXOs := make([]*avax.UTXO, 0, len(utxos)+len(added))
+	allUTXOs = append(allUTXOs, utxos...)
+	return append(allUTXOs, added...), nil
 }
 
 func (vs *versionedStateImpl) AddRewardUTXO(txID ids.ID, utxo *avax.UTXO) {
@@ -297,6 +307,14 @@ func (vs *versionedStateImpl) PendingStakerChainState() pendingStakerChainState
 	return vs.pendingStakerChainState
 }
 
+func (vs *versionedStateImpl) SetCurrentStakerChainState(cs currentStakerChainState) {
+	vs.currentStakerChainState = cs
+}
+
+func (vs *versionedStateImpl) SetPendingStakerChainState(ps pendingStakerChainState) {
+	vs.pendingStakerChainState = ps
+}
+
 func (vs *versionedStateImpl) SetBase(parentState MutableState) {
 	vs.parentState = parentState
 }
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
@@ -25,8 +26,9 @@ type Client interface {
 	ExportKey(user api.UserPass, address string) (string, error)
 	// ImportKey imports the specified [privateKey] to [user]'s keystore
 	ImportKey(user api.UserPass, address string) (string, error)
-	// GetBalance returns the balance of [address] on the P Chain
-	GetBalance(addr string) (*GetBalanceResponse, error)
+	// GetBalance returns the combined balance of [addrs] on the P Chain, as
+	// well as the balance of each of them
+	GetBalance(addrs []string) (*GetBalanceResponse, error)
 	// CreateAddress creates a new address for [user]
 	CreateAddress(user api.UserPass) (string, error)
 	// ListAddresses returns an array of platform addresses controlled by [user]
@@ -54,10 +56,21 @@ type Client interface {
 	GetStakingAssetID(ids.ID) (ids.ID, error)
 	// GetCurrentValidators returns the list of current validators for subnet with ID [subnetID]
 	GetCurrentValidators(subnetID ids.ID, nodeIDs []ids.ShortID) ([]interface{}, error)
+	// GetCurrentValidatorsPage returns at most [limit] current validators for
+	// subnet with ID [subnetID], in order of their node IDs, starting from
+	// [startNodeID]. If more validators remain, the node ID to continue from
+	// is returned. Otherwise, it's empty.
+	GetCurrentValidatorsPage(subnetID ids.ID, startNodeID string, limit uint32) ([]interface{}, string, error)
 	// GetPendingValidators returns the list of pending validators for subnet with ID [subnetID]
 	GetPendingValidators(subnetID ids.ID, nodeIDs []ids.ShortID) ([]interface{}, []interface{}, error)
 	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system
 	GetCurrentSupply() (uint64, error)
+	// GetCurrentSupplyAtHeight returns an upper bound on the supply of AVAX in
+	// the system after the accepted block at [height] was applied
+	GetCurrentSupplyAtHeight(height uint64) (uint64, error)
+	// EstimateReward returns the reward, in nAVAX, and the annualized reward
+	// percentage of staking [amount] from [startTime] to [endTime]
+	EstimateReward(amount, startTime, endTime uint64) (uint64, float64, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 	SampleValidators(subnetID ids.ID, sampleSize uint16) ([]string, error)
 	// AddValidator issues a transaction to add a validator to the primary network
@@ -97,6 +110,15 @@ type Client interface {
 		startTime,
 		endTime uint64,
 	) (ids.ID, error)
+	// RemoveSubnetValidator issues a transaction to remove validator [nodeID]
+	// from the subnet with ID [subnetID] and returns the txID
+	RemoveSubnetValidator(
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		subnetID,
+		nodeID string,
+	) (ids.ID, error)
 	// CreateSubnet issues a transaction to create [subnet] and returns the txID
 	CreateSubnet(
 		user api.UserPass,
@@ -113,6 +135,16 @@ type Client interface {
 		to string,
 		amount uint64,
 	) (ids.ID, error)
+	// ExportAVAXToChain issues an ExportTx transaction to [targetChain] and
+	// returns the txID
+	ExportAVAXToChain(
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		to string,
+		targetChain string,
+		amount uint64,
+	) (ids.ID, error)
 	// ImportAVAX issues an ImportTx transaction and returns the txID
 	ImportAVAX(
 		user api.UserPass,
@@ -121,6 +153,37 @@ type Client interface {
 		to,
 		sourceChain string,
 	) (ids.ID, error)
+	// BuildAddValidatorTx returns an unsigned transaction, funded by [from],
+	// that adds [nodeID] as a validator of the primary network
+	BuildAddValidatorTx(
+		from []string,
+		changeAddr string,
+		rewardAddress,
+		nodeID string,
+		stakeAmount,
+		startTime,
+		endTime uint64,
+		delegationFeeRate float32,
+	) (*BuildUnsignedTxReply, error)
+	// BuildAddDelegatorTx returns an unsigned transaction, funded by [from],
+	// that adds a delegator to [nodeID]
+	BuildAddDelegatorTx(
+		from []string,
+		changeAddr string,
+		rewardAddress,
+		nodeID string,
+		stakeAmount,
+		startTime,
+		endTime uint64,
+	) (*BuildUnsignedTxReply, error)
+	// BuildExportAVAXTx returns an unsigned ExportTx, funded by [from]
+	BuildExportAVAXTx(
+		from []string,
+		changeAddr string,
+		to string,
+		targetChain string,
+		amount uint64,
+	) (*BuildUnsignedTxReply, error)
 	// CreateBlockchain issues a CreateBlockchain transaction and returns the txID
 	CreateBlockchain(
 		user api.UserPass,
@@ -140,6 +203,11 @@ type Client interface {
 	Validates(subnetID ids.ID) ([]ids.ID, error)
 	// GetBlockchains returns the list of blockchains on the platform
 	GetBlockchains() ([]APIBlockchain, error)
+	// GetBlockchainsPage returns at most [limit] blockchains, in order of
+	// their IDs, starting from [startID]. If [subnetID] isn't nil, only the
+	// blockchains validated by that subnet are returned. If more blockchains
+	// remain, the ID to continue from is returned. Otherwise, it's nil.
+	GetBlockchainsPage(subnetID *ids.ID, startID ids.ID, limit uint32) ([]APIBlockchain, *ids.ID, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(tx []byte) (ids.ID, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
@@ -164,6 +232,11 @@ type Client interface {
 	// GetValidatorsAt returns the weights of the validator set of a provided subnet
 	// at the specified height.
 	GetValidatorsAt(subnetID ids.ID, height uint64) (map[string]uint64, error)
+	// GetBlockByHeight returns the ID and bytes of the accepted block at the
+	// provided height.
+	GetBlockByHeight(height uint64) (ids.ID, []byte, error)
+	// GetBlock returns the bytes of the block with the provided ID.
+	GetBlock(blockID ids.ID) ([]byte, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -202,10 +275,10 @@ func (c *client) ImportKey(user api.UserPass, privateKey string) (string, error)
 	return res.Address, err
 }
 
-func (c *client) GetBalance(address string) (*GetBalanceResponse, error) {
+func (c *client) GetBalance(addrs []string) (*GetBalanceResponse, error) {
 	res := &GetBalanceResponse{}
-	err := c.requester.SendRequest("getBalance", &api.JSONAddress{
-		Address: address,
+	err := c.requester.SendRequest("getBalance", &GetBalanceRequest{
+		Addresses: addrs,
 	}, res)
 	return res, err
 }
@@ -282,6 +355,19 @@ func (c *client) GetCurrentValidators(subnetID ids.ID, nodeIDs []ids.ShortID) ([
 	return res.Validators, err
 }
 
+func (c *client) GetCurrentValidatorsPage(subnetID ids.ID, startNodeID string, limit uint32) ([]interface{}, string, error) {
+	res := &GetCurrentValidatorsReply{}
+	err := c.requester.SendRequest("getCurrentValidators", &GetCurrentValidatorsArgs{
+		SubnetID:    subnetID,
+		Limit:       cjson.Uint32(limit),
+		StartNodeID: startNodeID,
+	}, res)
+	if err != nil || res.NextNodeID == nil {
+		return res.Validators, "", err
+	}
+	return res.Validators, *res.NextNodeID, nil
+}
+
 func (c *client) GetPendingValidators(subnetID ids.ID, nodeIDs []ids.ShortID) ([]interface{}, []interface{}, error) {
 	nodeIDsStr := []string{}
 	for _, nodeID := range nodeIDs {
@@ -297,10 +383,29 @@ func (c *client) GetPendingValidators(subnetID ids.ID, nodeIDs []ids.ShortID) ([
 
 func (c *client) GetCurrentSupply() (uint64, error) {
 	res := &GetCurrentSupplyReply{}
-	err := c.requester.SendRequest("getCurrentSupply", struct{}{}, res)
+	err := c.requester.SendRequest("getCurrentSupply", &GetCurrentSupplyArgs{}, res)
+	return uint64(res.Supply), err
+}
+
+func (c *client) GetCurrentSupplyAtHeight(height uint64) (uint64, error) {
+	res := &GetCurrentSupplyReply{}
+	cjsonHeight := cjson.Uint64(height)
+	err := c.requester.SendRequest("getCurrentSupply", &GetCurrentSupplyArgs{
+		Height: &cjsonHeight,
+	}, res)
 	return uint64(res.Supply), err
 }
 
+func (c *client) EstimateReward(amount, startTime, endTime uint64) (uint64, float64, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest("estimateReward", &EstimateRewardArgs{
+		Amount:    cjson.Uint64(amount),
+		StartTime: cjson.Uint64(startTime),
+		EndTime:   cjson.Uint64(endTime),
+	}, res)
+	return uint64(res.Reward), float64(res.AnnualizedPercentage), err
+}
+
 func (c *client) SampleValidators(subnetID ids.ID, sampleSize uint16) ([]string, error) {
 	res := &SampleValidatorsReply{}
 	err := c.requester.SendRequest("sampleValidators", &SampleValidatorsArgs{
@@ -334,7 +439,9 @@ func (c *client) AddValidator(
 			StartTime:   cjson.Uint64(startTime),
 			EndTime:     cjson.Uint64(endTime),
 		},
-		RewardAddress:     rewardAddress,
+		APIRewardOwnerArgs: APIRewardOwnerArgs{
+			RewardAddress: rewardAddress,
+		},
 		DelegationFeeRate: cjson.Float32(delegationFeeRate),
 	}, res)
 	return res.TxID, err
@@ -363,7 +470,9 @@ func (c *client) AddDelegator(
 			StartTime:   cjson.Uint64(startTime),
 			EndTime:     cjson.Uint64(endTime),
 		},
-		RewardAddress: rewardAddress,
+		APIRewardOwnerArgs: APIRewardOwnerArgs{
+			RewardAddress: rewardAddress,
+		},
 	}, res)
 	return res.TxID, err
 }
@@ -397,6 +506,26 @@ func (c *client) AddSubnetValidator(
 	return res.TxID, err
 }
 
+func (c *client) RemoveSubnetValidator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	subnetID,
+	nodeID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("removeSubnetValidator", &RemoveSubnetValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		NodeID:   nodeID,
+		SubnetID: subnetID,
+	}, res)
+	return res.TxID, err
+}
+
 func (c *client) CreateSubnet(
 	user api.UserPass,
 	from []string,
@@ -425,6 +554,17 @@ func (c *client) ExportAVAX(
 	changeAddr string,
 	to string,
 	amount uint64,
+) (ids.ID, error) {
+	return c.ExportAVAXToChain(user, from, changeAddr, to, "", amount)
+}
+
+func (c *client) ExportAVAXToChain(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	to string,
+	targetChain string,
+	amount uint64,
 ) (ids.ID, error) {
 	res := &api.JSONTxID{}
 	err := c.requester.SendRequest("exportAVAX", &ExportAVAXArgs{
@@ -433,8 +573,9 @@ func (c *client) ExportAVAX(
 			JSONFromAddrs:  api.JSONFromAddrs{From: from},
 			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
 		},
-		To:     to,
-		Amount: cjson.Uint64(amount),
+		To:          to,
+		TargetChain: targetChain,
+		Amount:      cjson.Uint64(amount),
 	}, res)
 	return res.TxID, err
 }
@@ -459,6 +600,83 @@ func (c *client) ImportAVAX(
 	return res.TxID, err
 }
 
+func (c *client) BuildAddValidatorTx(
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	delegationFeeRate float32,
+) (*BuildUnsignedTxReply, error) {
+	res := &BuildUnsignedTxReply{}
+	jsonStakeAmount := cjson.Uint64(stakeAmount)
+	err := c.requester.SendRequest("buildAddValidatorTx", &BuildAddValidatorTxArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: from},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &jsonStakeAmount,
+			StartTime:   cjson.Uint64(startTime),
+			EndTime:     cjson.Uint64(endTime),
+		},
+		APIRewardOwnerArgs: APIRewardOwnerArgs{
+			RewardAddress: rewardAddress,
+		},
+		DelegationFeeRate: cjson.Float32(delegationFeeRate),
+		Encoding:          formatting.Hex,
+	}, res)
+	return res, err
+}
+
+func (c *client) BuildAddDelegatorTx(
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+) (*BuildUnsignedTxReply, error) {
+	res := &BuildUnsignedTxReply{}
+	jsonStakeAmount := cjson.Uint64(stakeAmount)
+	err := c.requester.SendRequest("buildAddDelegatorTx", &BuildAddDelegatorTxArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: from},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &jsonStakeAmount,
+			StartTime:   cjson.Uint64(startTime),
+			EndTime:     cjson.Uint64(endTime),
+		},
+		APIRewardOwnerArgs: APIRewardOwnerArgs{
+			RewardAddress: rewardAddress,
+		},
+		Encoding: formatting.Hex,
+	}, res)
+	return res, err
+}
+
+func (c *client) BuildExportAVAXTx(
+	from []string,
+	changeAddr string,
+	to string,
+	targetChain string,
+	amount uint64,
+) (*BuildUnsignedTxReply, error) {
+	res := &BuildUnsignedTxReply{}
+	err := c.requester.SendRequest("buildExportAVAXTx", &BuildExportAVAXTxArgs{
+		JSONFromAddrs:  api.JSONFromAddrs{From: from},
+		JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		To:             to,
+		TargetChain:    targetChain,
+		Amount:         cjson.Uint64(amount),
+		Encoding:       formatting.Hex,
+	}, res)
+	return res, err
+}
+
 func (c *client) CreateBlockchain(
 	user api.UserPass,
 	from []string,
@@ -517,10 +735,20 @@ func (c *client) Validates(subnetID ids.ID) ([]ids.ID, error) {
 
 func (c *client) GetBlockchains() ([]APIBlockchain, error) {
 	res := &GetBlockchainsResponse{}
-	err := c.requester.SendRequest("getBlockchains", struct{}{}, res)
+	err := c.requester.SendRequest("getBlockchains", &GetBlockchainsArgs{}, res)
 	return res.Blockchains, err
 }
 
+func (c *client) GetBlockchainsPage(subnetID *ids.ID, startID ids.ID, limit uint32) ([]APIBlockchain, *ids.ID, error) {
+	res := &GetBlockchainsResponse{}
+	err := c.requester.SendRequest("getBlockchains", &GetBlockchainsArgs{
+		SubnetID: subnetID,
+		Limit:    cjson.Uint32(limit),
+		StartID:  startID,
+	}, res)
+	return res.Blockchains, res.NextID, err
+}
+
 func (c *client) IssueTx(txBytes []byte) (ids.ID, error) {
 	txStr, err := formatting.EncodeWithChecksum(formatting.Hex, txBytes)
 	if err != nil {
@@ -572,7 +800,7 @@ func (c *client) GetMinStake() (uint64, uint64, error) {
 
 func (c *client) GetTotalStake() (uint64, error) {
 	res := new(GetTotalStakeReply)
-	err := c.requester.SendRequest("getTotalStake", struct{}{}, res)
+	err := c.requester.SendRequest("getTotalStake", &GetTotalStakeArgs{}, res)
 	return uint64(res.Stake), err
 }
 
@@ -618,3 +846,32 @@ func (c *client) GetValidatorsAt(subnetID ids.ID, height uint64) (map[string]uin
 	}, res)
 	return res.Validators, err
 }
+
+func (c *client) GetBlockByHeight(height uint64) (ids.ID, []byte, error) {
+	res := &GetBlockByHeightReply{}
+	err := c.requester.SendRequest("getBlockByHeight", &GetBlockByHeightArgs{
+		Height:   cjson.Uint64(height),
+		Encoding: formatting.Hex,
+	}, res)
+	if err != nil {
+		return ids.ID{}, nil, err
+	}
+	blkBytes, err := formatting.Decode(res.Encoding, res.Block)
+	return res.BlockID, blkBytes, err
+}
+
+func (c *client) GetBlock(blockID ids.ID) ([]byte, error) {
+	res := &GetBlockReply{}
+	err := c.requester.SendRequest("getBlock", &GetBlockArgs{
+		BlockID:  blockID,
+		Encoding: formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, err
+	}
+	blkStr, ok := res.Block.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected block to be a string but got %T", res.Block)
+	}
+	return formatting.Decode(res.Encoding, blkStr)
+}
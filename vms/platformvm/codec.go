@@ -65,6 +65,8 @@ func init() {
 
 			c.RegisterType(&StakeableLockIn{}),
 			c.RegisterType(&StakeableLockOut{}),
+
+			c.RegisterType(&UnsignedRemoveSubnetValidatorTx{}),
 		)
 	}
 	errs.Add(
@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -70,6 +71,7 @@ func (c *CommitBlock) Verify() error {
 
 	c.vm.currentBlocks[blkID] = c
 	parent.addChild(c)
+	c.verifiedTime = time.Now()
 	return nil
 }
 
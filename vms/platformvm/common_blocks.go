@@ -138,6 +138,9 @@ type CommonBlock struct {
 	status    choices.Status
 	vm        *VM
 
+	// Wall-clock time this block was last verified at. Only used for metrics.
+	verifiedTime time.Time
+
 	// This block's children
 	children []Block
 }
@@ -227,6 +230,9 @@ func (b *CommonBlock) Reject() error {
 	b.status = choices.Rejected
 	// TODO: don't write rejected blocks to disk
 	b.vm.internalState.AddBlock(b.self)
+	if err := b.vm.metrics.RejectBlock(b.self); err != nil {
+		return err
+	}
 	return b.vm.internalState.Commit()
 }
 
@@ -238,7 +244,10 @@ func (b *CommonBlock) Accept() error {
 	b.vm.internalState.SetLastAccepted(blkID)
 	b.vm.internalState.SetHeight(b.Hght)
 	b.vm.lastAcceptedID = blkID
-	return b.vm.metrics.AcceptBlock(b.self)
+	if err := b.vm.metrics.AcceptBlock(b.self); err != nil {
+		return err
+	}
+	return b.vm.publishAcceptedBlock(b.self)
 }
 
 // CommonDecisionBlock contains the fields and methods common to all decision blocks
@@ -274,6 +283,9 @@ func (cdb *CommonDecisionBlock) Reject() error {
 	cdb.status = choices.Rejected
 	// TODO: don't write rejected blocks to disk
 	cdb.vm.internalState.AddBlock(cdb.self)
+	if err := cdb.vm.metrics.RejectBlock(cdb.self); err != nil {
+		return err
+	}
 	return cdb.vm.internalState.Commit()
 }
 
@@ -311,6 +323,7 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 		return fmt.Errorf("failed to commit vm's state: %w", err)
 	}
 
+	ddb.vm.blockBuilder.ReleaseUTXOs(&parent.Tx)
 	for _, child := range ddb.children {
 		child.setBaseState()
 	}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// By default, every historical validator set can be queried. Other chains
+	// may depend on P-chain heights from arbitrarily far back while
+	// bootstrapping.
+	defaultValidatorDiffsRetention = math.MaxUint64
+
+	defaultDroppedTxCacheSize = 4096
+
+	defaultMaxBlockTxs  = BatchSize
+	defaultMaxBlockSize = 64 * units.KiB
+)
+
+var (
+	errNoMaxBlockTxs       = errors.New("max-block-txs must be positive")
+	errInvalidMaxBlockSize = errors.New("invalid max-block-size")
+)
+
+// Config contains the chain specific configuration of the P-chain. It is
+// parsed from the chain's config bytes.
+type Config struct {
+	// Number of heights, counting back from the last accepted height, for
+	// which historical validator sets can be queried. The validator diffs of
+	// older heights are deleted as blocks are accepted.
+	ValidatorDiffsRetention uint64 `json:"validator-diffs-retention"`
+
+	// Maximum number of recently dropped transactions, and the reasons they
+	// were dropped, to remember.
+	DroppedTxCacheSize int `json:"dropped-tx-cache-size"`
+
+	// Maximum number of txs the block builder places into a standard block.
+	MaxBlockTxs int `json:"max-block-txs"`
+
+	// Maximum size, in bytes, of the standard blocks built by this node. A
+	// single tx larger than this is still built into a block by itself, as
+	// long as the block is at most [maxStandardBlockSize] bytes.
+	MaxBlockSize int `json:"max-block-size"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		ValidatorDiffsRetention: defaultValidatorDiffsRetention,
+		DroppedTxCacheSize:      defaultDroppedTxCacheSize,
+		MaxBlockTxs:             defaultMaxBlockTxs,
+		MaxBlockSize:            defaultMaxBlockSize,
+	}
+}
+
+// verify returns an error if [c] can't be used by the VM
+func (c *Config) verify() error {
+	switch {
+	case c.MaxBlockTxs <= 0:
+		return errNoMaxBlockTxs
+	case c.MaxBlockSize <= standardBlockOverhead || c.MaxBlockSize > maxStandardBlockSize:
+		return fmt.Errorf(
+			"%w: %d must be greater than %d and at most %d",
+			errInvalidMaxBlockSize,
+			c.MaxBlockSize,
+			standardBlockOverhead,
+			maxStandardBlockSize,
+		)
+	default:
+		return nil
+	}
+}
+
+// parseConfig returns the config described by [configBytes]. Any field not
+// specified in [configBytes] is set to its default value.
+func parseConfig(configBytes []byte) (Config, error) {
+	config := defaultConfig()
+	if len(configBytes) == 0 {
+		return config, nil
+	}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return config, err
+	}
+	return config, config.verify()
+}
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/types"
 )
 
 var (
@@ -53,6 +55,19 @@ type UnsignedCreateChainTx struct {
 	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
 }
 
+// MarshalJSON marshals [tx] as JSON with human readable addresses.
+// InitCtx must be called before marshalling [tx].
+func (tx *UnsignedCreateChainTx) MarshalJSON() ([]byte, error) {
+	fields := tx.BaseTx.jsonFields(txTypeName(tx))
+	fields["subnetID"] = tx.SubnetID
+	fields["chainName"] = tx.ChainName
+	fields["vmID"] = tx.VMID
+	fields["fxIDs"] = tx.FxIDs
+	fields["genesisData"] = types.JSONByteSlice(tx.GenesisData)
+	fields["subnetAuthorization"] = tx.SubnetAuth
+	return json.Marshal(fields)
+}
+
 func (tx *UnsignedCreateChainTx) InputUTXOs() ids.Set { return nil }
 
 func (tx *UnsignedCreateChainTx) AtomicOperations() (ids.ID, *atomic.Requests, error) {
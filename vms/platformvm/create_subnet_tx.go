@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -40,6 +41,14 @@ func (tx *UnsignedCreateSubnetTx) InitCtx(ctx *snow.Context) {
 	tx.Owner.InitCtx(ctx)
 }
 
+// MarshalJSON marshals [tx] as JSON with human readable addresses.
+// InitCtx must be called before marshalling [tx].
+func (tx *UnsignedCreateSubnetTx) MarshalJSON() ([]byte, error) {
+	fields := tx.BaseTx.jsonFields(txTypeName(tx))
+	fields["owner"] = tx.Owner
+	return json.Marshal(fields)
+}
+
 // SyntacticVerify verifies that this transaction is well-formed
 func (tx *UnsignedCreateSubnetTx) SyntacticVerify(ctx *snow.Context) error {
 	switch {
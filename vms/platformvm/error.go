@@ -3,6 +3,10 @@
 
 package platformvm
 
+import (
+	"fmt"
+)
+
 var (
 	_ TxError = &tempError{}
 	_ TxError = &permError{}
@@ -25,3 +29,13 @@ type permError struct{ error }
 
 func (e permError) Unwrap() error { return e.error }
 func (permError) Temporary() bool { return false }
+
+// credentialsError reports that a tx's credentials are invalid. It matches
+// errInvalidCredentials and wraps the error that caused it.
+type credentialsError struct{ error }
+
+func (e credentialsError) Error() string {
+	return fmt.Sprintf("%s: %s", errInvalidCredentials, e.error)
+}
+func (e credentialsError) Unwrap() error      { return e.error }
+func (credentialsError) Is(target error) bool { return target == errInvalidCredentials }
@@ -0,0 +1,518 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Size of the ws read and write buffers
+	eventsBufferSize = units.KiB
+
+	// Time allowed to write a message to the subscriber.
+	eventsWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the subscriber.
+	eventsPongWait = 60 * time.Second
+
+	// Send pings to the subscriber with this period. Must be less than
+	// eventsPongWait.
+	eventsPingPeriod = (eventsPongWait * 9) / 10
+
+	// Maximum size of a subscription message sent by a subscriber.
+	maxSubscriptionSize = 10 * units.KiB
+
+	// Maximum number of events that may be pending delivery to a subscriber.
+	// A subscriber that falls this far behind is disconnected.
+	maxPendingEvents = 1024
+
+	blockEventName       = "block"
+	txEventName          = "tx"
+	stakerEndedEventName = "stakerEnded"
+)
+
+var (
+	errEventsSubscriberTooSlow = errors.New("subscriber fell too far behind")
+
+	eventsUpgrader = websocket.Upgrader{
+		ReadBufferSize:  eventsBufferSize,
+		WriteBufferSize: eventsBufferSize,
+		CheckOrigin:     func(*http.Request) bool { return true },
+	}
+)
+
+// EventSubscription is sent by a subscriber to choose which accepted
+// transactions it is notified of. Accepted blocks are always published.
+// Each subscription replaces the previous one.
+type EventSubscription struct {
+	// If true, accepted transactions are published.
+	Txs bool `json:"txs"`
+	// If non-empty, only transactions of these types are published. Types are
+	// named as in the P-chain's metrics, e.g. "add_validator".
+	TxTypes []string `json:"txTypes"`
+	// If true, a StakerEndedEvent is published whenever a validator or
+	// delegator leaves the current staker set.
+	StakersEnded bool `json:"stakersEnded"`
+	// If non-empty, only staking transactions and staker ended events that
+	// affect one of these nodes are published.
+	NodeIDs []string `json:"nodeIDs"`
+}
+
+// BlockEvent is published when a block is accepted
+type BlockEvent struct {
+	Event  string `json:"event"`
+	ID     ids.ID `json:"id"`
+	Height uint64 `json:"height"`
+	Type   string `json:"type"`
+}
+
+// TxEvent is published when a transaction is decided by an accepted block.
+// Proposal transactions are published once their commit or abort block is
+// accepted.
+type TxEvent struct {
+	Event   string `json:"event"`
+	TxID    ids.ID `json:"txID"`
+	BlockID ids.ID `json:"blockID"`
+	Type    string `json:"type"`
+	Status  Status `json:"status"`
+	NodeID  string `json:"nodeID,omitempty"`
+
+	nodeID ids.ShortID
+}
+
+// StakerEndedEvent is published when a validator or delegator of the primary
+// network is removed from the current staker set by an accepted
+// RewardValidatorTx.
+type StakerEndedEvent struct {
+	Event   string `json:"event"`
+	BlockID ids.ID `json:"blockID"`
+	// ID of the RewardValidatorTx that removed the staker
+	TxID ids.ID `json:"txID"`
+	// ID of the tx that added the staker
+	StakerTxID ids.ID `json:"stakerTxID"`
+	NodeID     string `json:"nodeID"`
+	// Committed if the staker was rewarded, Aborted otherwise
+	Status Status `json:"status"`
+	// Total reward paid out for the staker's stake. If the staker is a
+	// delegator, this includes the delegation fee paid to its validator.
+	Reward json.Uint64 `json:"reward"`
+
+	nodeID ids.ShortID
+}
+
+type eventsErrorMsg struct {
+	Error string `json:"error"`
+}
+
+// eventSubscription is the parsed form of an EventSubscription
+type eventSubscription struct {
+	txs          bool
+	txTypes      map[string]struct{}
+	stakersEnded bool
+	nodeIDs      ids.ShortSet
+}
+
+func (s *eventSubscription) wants(event *TxEvent) bool {
+	if !s.txs {
+		return false
+	}
+	if len(s.txTypes) > 0 {
+		if _, ok := s.txTypes[event.Type]; !ok {
+			return false
+		}
+	}
+	return s.nodeIDs.Len() == 0 || s.nodeIDs.Contains(event.nodeID)
+}
+
+func (s *eventSubscription) wantsStakerEnded(event *StakerEndedEvent) bool {
+	return s.stakersEnded && (s.nodeIDs.Len() == 0 || s.nodeIDs.Contains(event.nodeID))
+}
+
+// eventServer publishes accepted blocks and transactions to websocket
+// subscribers.
+type eventServer struct {
+	log logging.Logger
+
+	lock        sync.RWMutex
+	subscribers map[*eventSubscriber]struct{}
+}
+
+func newEventServer(log logging.Logger) *eventServer {
+	return &eventServer{
+		log:         log,
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+func (s *eventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wsConn, err := eventsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade events connection: %s", err)
+		return
+	}
+	sub := &eventSubscriber{
+		s:      s,
+		conn:   wsConn,
+		send:   make(chan interface{}, maxPendingEvents),
+		active: 1,
+	}
+
+	s.lock.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.lock.Unlock()
+
+	go sub.writePump()
+	go sub.readPump()
+}
+
+func (s *eventServer) removeSubscriber(sub *eventSubscriber) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.subscribers, sub)
+}
+
+func (s *eventServer) hasSubscribers() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.subscribers) > 0
+}
+
+// publish [blockEvent] to every subscriber and each of [txEvents] and
+// [stakerEndedEvent], if non-nil, to the subscribers whose subscription matches
+// it. This never blocks; subscribers that can't keep up are disconnected.
+func (s *eventServer) publish(
+	blockEvent *BlockEvent,
+	txEvents []*TxEvent,
+	stakerEndedEvent *StakerEndedEvent,
+) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for sub := range s.subscribers {
+		if !sub.notify(blockEvent) {
+			continue
+		}
+		subscription := sub.subscription()
+		active := true
+		for _, txEvent := range txEvents {
+			if subscription.wants(txEvent) && !sub.notify(txEvent) {
+				active = false
+				break
+			}
+		}
+		if active && stakerEndedEvent != nil && subscription.wantsStakerEnded(stakerEndedEvent) {
+			sub.notify(stakerEndedEvent)
+		}
+	}
+}
+
+// eventSubscriber is a single websocket connection to the event server.
+type eventSubscriber struct {
+	s *eventServer
+
+	conn *websocket.Conn
+
+	// Bounded buffer of events waiting to be written to [conn].
+	send chan interface{}
+
+	active uint32
+
+	lock sync.Mutex
+	sub  eventSubscription
+}
+
+func (sub *eventSubscriber) isActive() bool {
+	return atomic.LoadUint32(&sub.active) != 0
+}
+
+func (sub *eventSubscriber) deactivate() {
+	atomic.StoreUint32(&sub.active, 0)
+}
+
+func (sub *eventSubscriber) subscription() eventSubscription {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+
+	return sub.sub
+}
+
+func (sub *eventSubscriber) subscribe(subscription eventSubscription) {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+
+	sub.sub = subscription
+}
+
+// notify queues [msg] to be sent to this subscriber. If the subscriber's
+// buffer is full, the subscriber is disconnected and false is returned.
+func (sub *eventSubscriber) notify(msg interface{}) bool {
+	if !sub.isActive() {
+		return false
+	}
+	select {
+	case sub.send <- msg:
+		return true
+	default:
+	}
+
+	sub.s.log.Debug("disconnecting events subscriber: %s", errEventsSubscriberTooSlow)
+	sub.deactivate()
+
+	// Closing the connection causes both pumps to exit, which removes this
+	// subscriber from the server.
+	_ = sub.conn.Close()
+	return false
+}
+
+// readPump reads subscription messages from the websocket connection.
+func (sub *eventSubscriber) readPump() {
+	defer func() {
+		sub.deactivate()
+		sub.s.removeSubscriber(sub)
+
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = sub.conn.Close()
+	}()
+
+	sub.conn.SetReadLimit(maxSubscriptionSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := sub.conn.SetReadDeadline(time.Now().Add(eventsPongWait)); err != nil {
+		return
+	}
+	sub.conn.SetPongHandler(func(string) error {
+		return sub.conn.SetReadDeadline(time.Now().Add(eventsPongWait))
+	})
+
+	for {
+		args := EventSubscription{}
+		if err := sub.conn.ReadJSON(&args); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				sub.s.log.Debug("unexpected close in events websocket: %s", err)
+			}
+			return
+		}
+
+		subscription, err := parseEventSubscription(&args)
+		if err != nil {
+			sub.notify(&eventsErrorMsg{Error: err.Error()})
+			continue
+		}
+		sub.subscribe(subscription)
+	}
+}
+
+// writePump writes queued events to the websocket connection.
+func (sub *eventSubscriber) writePump() {
+	ticker := time.NewTicker(eventsPingPeriod)
+	defer func() {
+		sub.deactivate()
+		ticker.Stop()
+		sub.s.removeSubscriber(sub)
+
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = sub.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg := <-sub.send:
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(eventsWriteWait)); err != nil {
+				sub.s.log.Debug("failed to set the write deadline, closing the connection due to %s", err)
+				return
+			}
+			if err := sub.conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(eventsWriteWait)); err != nil {
+				sub.s.log.Debug("failed to set the write deadline, closing the connection due to %s", err)
+				return
+			}
+			if err := sub.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func parseEventSubscription(args *EventSubscription) (eventSubscription, error) {
+	subscription := eventSubscription{
+		txs:          args.Txs,
+		txTypes:      make(map[string]struct{}, len(args.TxTypes)),
+		stakersEnded: args.StakersEnded,
+	}
+	for _, txType := range args.TxTypes {
+		subscription.txTypes[txType] = struct{}{}
+	}
+	for _, nodeIDStr := range args.NodeIDs {
+		nodeID, err := ids.ShortFromPrefixedString(nodeIDStr, constants.NodeIDPrefix)
+		if err != nil {
+			return eventSubscription{}, fmt.Errorf("couldn't parse nodeID %q: %w", nodeIDStr, err)
+		}
+		subscription.nodeIDs.Add(nodeID)
+	}
+	return subscription, nil
+}
+
+// publishAcceptedBlock notifies the event subscribers that [blk] was accepted.
+// If [blk] removes a staker from the current staker set, the staker ended event
+// is also dispatched to [vm.StakerEndedEvents]. The returned error is fatal.
+func (vm *VM) publishAcceptedBlock(blk Block) error {
+	hasSubscribers := vm.events.hasSubscribers()
+	if !hasSubscribers && vm.StakerEndedEvents == nil {
+		return nil
+	}
+
+	blkID := blk.ID()
+	var (
+		txs              []*Tx
+		status           = Committed
+		stakerEndedEvent *StakerEndedEvent
+	)
+	switch blk := blk.(type) {
+	case *StandardBlock:
+		txs = blk.Txs
+	case *AtomicBlock:
+		txs = []*Tx{&blk.Tx}
+	case *CommitBlock, *AbortBlock:
+		if _, ok := blk.(*AbortBlock); ok {
+			status = Aborted
+		}
+		parentIntf, err := blk.parentBlock()
+		if err != nil {
+			vm.ctx.Log.Debug("couldn't get parent of %s for events: %s", blkID, err)
+			break
+		}
+		parent, ok := parentIntf.(*ProposalBlock)
+		if !ok {
+			break
+		}
+		txs = []*Tx{&parent.Tx}
+		if utx, ok := parent.Tx.UnsignedTx.(*UnsignedRewardValidatorTx); ok {
+			stakerEndedEvent = vm.newStakerEndedEvent(blkID, parent.Tx.ID(), utx, status)
+		}
+	}
+
+	if stakerEndedEvent != nil && vm.StakerEndedEvents != nil {
+		if err := vm.dispatchStakerEnded(stakerEndedEvent); err != nil {
+			return err
+		}
+	}
+	if !hasSubscribers {
+		return nil
+	}
+
+	blockEvent := &BlockEvent{
+		Event:  blockEventName,
+		ID:     blkID,
+		Height: blk.Height(),
+		Type:   blockTypeName(blk),
+	}
+	txEvents := make([]*TxEvent, len(txs))
+	for i, tx := range txs {
+		txEvent := &TxEvent{
+			Event:   txEventName,
+			TxID:    tx.ID(),
+			BlockID: blkID,
+			Type:    txTypeName(tx.UnsignedTx),
+			Status:  status,
+		}
+		if nodeID, ok := vm.txNodeID(tx.UnsignedTx); ok {
+			txEvent.nodeID = nodeID
+			txEvent.NodeID = nodeID.PrefixedString(constants.NodeIDPrefix)
+		}
+		txEvents[i] = txEvent
+	}
+	vm.events.publish(blockEvent, txEvents, stakerEndedEvent)
+	return nil
+}
+
+// dispatchStakerEnded passes [event] to [vm.StakerEndedEvents], e.g. to be
+// indexed, identified by the ID of the RewardValidatorTx that removed the
+// staker.
+func (vm *VM) dispatchStakerEnded(event *StakerEndedEvent) error {
+	eventBytes, err := stdjson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal staker ended event: %w", err)
+	}
+	ctx := &snow.ConsensusContext{Context: vm.ctx}
+	return vm.StakerEndedEvents.Accept(ctx, event.TxID, eventBytes)
+}
+
+// newStakerEndedEvent returns the event describing the removal of the staker
+// by [utx]. Returns nil if the staker can't be found.
+//
+// This must be called before the block deciding [utx] applies its state
+// changes, as the staker is still in the current staker set until then.
+func (vm *VM) newStakerEndedEvent(
+	blkID ids.ID,
+	txID ids.ID,
+	utx *UnsignedRewardValidatorTx,
+	status Status,
+) *StakerEndedEvent {
+	stakerTx, potentialReward, err := vm.internalState.CurrentStakerChainState().GetStaker(utx.TxID)
+	if err != nil {
+		vm.ctx.Log.Debug("couldn't get staker %s for events: %s", utx.TxID, err)
+		return nil
+	}
+	nodeID, _ := vm.txNodeID(stakerTx.UnsignedTx)
+
+	event := &StakerEndedEvent{
+		Event:      stakerEndedEventName,
+		BlockID:    blkID,
+		TxID:       txID,
+		StakerTxID: utx.TxID,
+		NodeID:     nodeID.PrefixedString(constants.NodeIDPrefix),
+		Status:     status,
+		nodeID:     nodeID,
+	}
+	if status == Committed {
+		event.Reward = json.Uint64(potentialReward)
+	}
+	return event
+}
+
+// txNodeID returns the node affected by the staking tx [utx]. Returns false if
+// [utx] doesn't affect a node.
+func (vm *VM) txNodeID(utx UnsignedTx) (ids.ShortID, bool) {
+	switch utx := utx.(type) {
+	case *UnsignedAddValidatorTx:
+		return utx.Validator.NodeID, true
+	case *UnsignedAddDelegatorTx:
+		return utx.Validator.NodeID, true
+	case *UnsignedAddSubnetValidatorTx:
+		return utx.Validator.NodeID, true
+	case *UnsignedRemoveSubnetValidatorTx:
+		return utx.NodeID, true
+	case *UnsignedRewardValidatorTx:
+		stakerTx, _, err := vm.internalState.GetTx(utx.TxID)
+		if err != nil {
+			vm.ctx.Log.Debug("couldn't get staker tx %s for events: %s", utx.TxID, err)
+			return ids.ShortID{}, false
+		}
+		return vm.txNodeID(stakerTx.UnsignedTx)
+	default:
+		return ids.ShortID{}, false
+	}
+}
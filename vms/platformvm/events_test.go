@@ -0,0 +1,335 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	stdjson "encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func dialEvents(t *testing.T, handler http.Handler) (*websocket.Conn, func()) {
+	server := httptest.NewServer(handler)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return conn, func() {
+		_ = conn.Close()
+		server.Close()
+	}
+}
+
+// subscribeEvents connects to [vm]'s event server and returns once
+// [subscription] has been registered.
+func subscribeEvents(t *testing.T, vm *VM, subscription *EventSubscription) (*websocket.Conn, func()) {
+	conn, closeFn := dialEvents(t, vm.events)
+	if err := conn.WriteJSON(subscription); err != nil {
+		closeFn()
+		t.Fatal(err)
+	}
+
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		vm.events.lock.RLock()
+		registered := false
+		for sub := range vm.events.subscribers {
+			s := sub.subscription()
+			registered = registered || s.txs || s.stakersEnded
+		}
+		vm.events.lock.RUnlock()
+
+		if registered {
+			return conn, closeFn
+		}
+	}
+	closeFn()
+	t.Fatal("subscription wasn't registered")
+	return nil, nil
+}
+
+func TestEventsPublishAcceptedBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	conn, closeFn := subscribeEvents(t, vm, &EventSubscription{
+		Txs:     true,
+		TxTypes: []string{"create_subnet"},
+	})
+	defer closeFn()
+
+	createSubnetTx, err := vm.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(createSubnetTx))
+
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	assert.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+
+	blockEvent := BlockEvent{}
+	assert.NoError(conn.ReadJSON(&blockEvent))
+	assert.Equal(BlockEvent{
+		Event:  blockEventName,
+		ID:     blk.ID(),
+		Height: blk.Height(),
+		Type:   "standard",
+	}, blockEvent)
+
+	txEvent := TxEvent{}
+	assert.NoError(conn.ReadJSON(&txEvent))
+	assert.Equal(TxEvent{
+		Event:   txEventName,
+		TxID:    createSubnetTx.ID(),
+		BlockID: blk.ID(),
+		Type:    "create_subnet",
+		Status:  Committed,
+	}, txEvent)
+}
+
+func TestEventsPublishStakerEnded(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// The genesis validator that will be removed first
+	stakerTx, potentialReward, err := vm.internalState.CurrentStakerChainState().GetNextStaker()
+	assert.NoError(err)
+	stakerTxID := stakerTx.ID()
+	nodeID := stakerTx.UnsignedTx.(*UnsignedAddValidatorTx).Validator.NodeID
+
+	conn, closeFn := subscribeEvents(t, vm, &EventSubscription{
+		StakersEnded: true,
+		NodeIDs:      []string{nodeID.PrefixedString(constants.NodeIDPrefix)},
+	})
+	defer closeFn()
+
+	// Advance the chain time to the end of the genesis validators' staking
+	// periods.
+	vm.clock.Set(defaultValidateEndTime)
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	options, err := blk.(*ProposalBlock).Options()
+	assert.NoError(err)
+	assert.NoError(blk.Accept())
+	assert.NoError(options[0].Verify())
+	assert.NoError(options[0].Accept())
+
+	// Reward the first genesis validator.
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	rewardTx := blk.(*ProposalBlock).Tx
+	assert.Equal(stakerTxID, rewardTx.UnsignedTx.(*UnsignedRewardValidatorTx).TxID)
+
+	options, err = blk.(*ProposalBlock).Options()
+	assert.NoError(err)
+	assert.NoError(blk.Accept())
+	assert.NoError(options[0].Verify())
+	assert.NoError(options[0].Accept())
+
+	// Every subscriber is notified of accepted blocks, so skip those.
+	assert.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	for {
+		event := StakerEndedEvent{}
+		assert.NoError(conn.ReadJSON(&event))
+		if event.Event != stakerEndedEventName {
+			continue
+		}
+		assert.Equal(StakerEndedEvent{
+			Event:      stakerEndedEventName,
+			BlockID:    options[0].ID(),
+			TxID:       rewardTx.ID(),
+			StakerTxID: stakerTxID,
+			NodeID:     nodeID.PrefixedString(constants.NodeIDPrefix),
+			Status:     Committed,
+			Reward:     cjson.Uint64(potentialReward),
+		}, event)
+		return
+	}
+}
+
+// stakerEndedRecorder records the containers accepted by a dispatcher
+type stakerEndedRecorder struct {
+	snow.EventDispatcher
+
+	containerIDs []ids.ID
+	containers   [][]byte
+}
+
+func (r *stakerEndedRecorder) Accept(_ *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	r.containerIDs = append(r.containerIDs, containerID)
+	r.containers = append(r.containers, container)
+	return nil
+}
+
+func TestEventsDispatchStakerEnded(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	recorder := &stakerEndedRecorder{}
+	vm.StakerEndedEvents = recorder
+
+	stakerTx, potentialReward, err := vm.internalState.CurrentStakerChainState().GetNextStaker()
+	assert.NoError(err)
+	nodeID := stakerTx.UnsignedTx.(*UnsignedAddValidatorTx).Validator.NodeID
+
+	// Advancing the chain time doesn't remove any stakers.
+	vm.clock.Set(defaultValidateEndTime)
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	options, err := blk.(*ProposalBlock).Options()
+	assert.NoError(err)
+	assert.NoError(blk.Accept())
+	assert.NoError(options[0].Verify())
+	assert.NoError(options[0].Accept())
+	assert.Empty(recorder.containerIDs)
+
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	rewardTx := blk.(*ProposalBlock).Tx
+	options, err = blk.(*ProposalBlock).Options()
+	assert.NoError(err)
+	assert.NoError(blk.Accept())
+	assert.NoError(options[0].Verify())
+	assert.NoError(options[0].Accept())
+
+	assert.Equal([]ids.ID{rewardTx.ID()}, recorder.containerIDs)
+	event := StakerEndedEvent{}
+	assert.NoError(stdjson.Unmarshal(recorder.containers[0], &event))
+	assert.Equal(StakerEndedEvent{
+		Event:      stakerEndedEventName,
+		BlockID:    options[0].ID(),
+		TxID:       rewardTx.ID(),
+		StakerTxID: stakerTx.ID(),
+		NodeID:     nodeID.PrefixedString(constants.NodeIDPrefix),
+		Status:     Committed,
+		Reward:     cjson.Uint64(potentialReward),
+	}, event)
+}
+
+func TestEventSubscriptionFilters(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeID := ids.GenerateTestShortID()
+	otherNodeID := ids.GenerateTestShortID()
+
+	addValidator := &TxEvent{Type: "add_validator", nodeID: nodeID}
+	addDelegator := &TxEvent{Type: "add_delegator", nodeID: otherNodeID}
+	createSubnet := &TxEvent{Type: "create_subnet"}
+
+	subscription, err := parseEventSubscription(&EventSubscription{})
+	assert.NoError(err)
+	assert.False(subscription.wants(addValidator))
+
+	subscription, err = parseEventSubscription(&EventSubscription{Txs: true})
+	assert.NoError(err)
+	assert.True(subscription.wants(addValidator))
+	assert.True(subscription.wants(addDelegator))
+	assert.True(subscription.wants(createSubnet))
+
+	subscription, err = parseEventSubscription(&EventSubscription{
+		Txs:     true,
+		TxTypes: []string{"add_validator", "add_delegator"},
+	})
+	assert.NoError(err)
+	assert.True(subscription.wants(addValidator))
+	assert.True(subscription.wants(addDelegator))
+	assert.False(subscription.wants(createSubnet))
+
+	subscription, err = parseEventSubscription(&EventSubscription{
+		Txs:     true,
+		NodeIDs: []string{nodeID.PrefixedString(constants.NodeIDPrefix)},
+	})
+	assert.NoError(err)
+	assert.True(subscription.wants(addValidator))
+	assert.False(subscription.wants(addDelegator))
+	assert.False(subscription.wants(createSubnet))
+
+	_, err = parseEventSubscription(&EventSubscription{
+		Txs:     true,
+		NodeIDs: []string{"not a node ID"},
+	})
+	assert.Error(err)
+}
+
+func TestEventsSlowSubscriberDisconnected(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newEventServer(logging.NoLog{})
+	conns := make(chan *websocket.Conn, 1)
+	conn, closeFn := dialEvents(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := eventsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conns <- wsConn
+	}))
+	defer closeFn()
+
+	// The pumps aren't started, so nothing drains the subscriber's buffer.
+	sub := &eventSubscriber{
+		s:      s,
+		conn:   <-conns,
+		send:   make(chan interface{}, 1),
+		active: 1,
+	}
+	s.subscribers[sub] = struct{}{}
+
+	blockEvent := &BlockEvent{Event: blockEventName}
+	s.publish(blockEvent, nil, nil)
+	assert.True(sub.isActive())
+
+	s.publish(blockEvent, nil, nil)
+	assert.False(sub.isActive())
+
+	// The subscriber's connection should have been closed.
+	assert.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	_, _, err := conn.ReadMessage()
+	assert.Error(err)
+	netErr, ok := err.(net.Error)
+	assert.False(ok && netErr.Timeout(), "subscriber wasn't disconnected")
+}
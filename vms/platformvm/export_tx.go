@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -48,6 +49,15 @@ func (tx *UnsignedExportTx) InitCtx(ctx *snow.Context) {
 	}
 }
 
+// MarshalJSON marshals [tx] as JSON with human readable addresses.
+// InitCtx must be called before marshalling [tx].
+func (tx *UnsignedExportTx) MarshalJSON() ([]byte, error) {
+	fields := tx.BaseTx.jsonFields(txTypeName(tx))
+	fields["destinationChain"] = tx.DestinationChain
+	fields["exportedOutputs"] = tx.ExportedOutputs
+	return json.Marshal(fields)
+}
+
 // InputUTXOs returns an empty set
 func (tx *UnsignedExportTx) InputUTXOs() ids.Set { return nil }
 
@@ -196,13 +206,34 @@ func (vm *VM) newExportTx(
 	keys []*crypto.PrivateKeySECP256K1R, // Pay the fee and provide the tokens
 	changeAddr ids.ShortID, // Address to send change to, if there is any
 ) (*Tx, error) {
+	kc := secp256k1fx.NewKeychain(keys...)
+	utx, signers, err := vm.newUnsignedExportTx(amount, chainID, to, kc.Addresses(), changeAddr)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(Codec, signerKeys(kc, signers)); err != nil {
+		return nil, err
+	}
+	return tx, utx.SyntacticVerify(vm.ctx)
+}
+
+// newUnsignedExportTx returns a new ExportTx funded by [addrs] and the
+// addresses that must sign each of its inputs
+func (vm *VM) newUnsignedExportTx(
+	amount uint64, // Amount of tokens to export
+	chainID ids.ID, // Chain to send the UTXOs to
+	to ids.ShortID, // Address of chain recipient
+	addrs ids.ShortSet, // Pay the fee and provide the tokens
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*UnsignedExportTx, [][]ids.ShortID, error) {
 	toBurn, err := math.Add64(amount, vm.TxFee)
 	if err != nil {
-		return nil, errOverflowExport
+		return nil, nil, errOverflowExport
 	}
-	ins, outs, _, signers, err := vm.stake(keys, 0, toBurn, changeAddr)
+	ins, outs, _, signers, err := vm.stakeFromAddrs(addrs, 0, toBurn, changeAddr)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
 	// Create the transaction
@@ -226,9 +257,5 @@ func (vm *VM) newExportTx(
 			},
 		}},
 	}
-	tx := &Tx{UnsignedTx: utx}
-	if err := tx.Sign(Codec, signers); err != nil {
-		return nil, err
-	}
-	return tx, utx.SyntacticVerify(vm.ctx)
+	return utx, signers, nil
 }
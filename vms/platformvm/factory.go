@@ -56,6 +56,11 @@ type Factory struct {
 	// The maximum amount of tokens that can be bonded on a validator
 	MaxValidatorStake uint64
 
+	// The maximum factor of a validator's own stake that its total weight,
+	// including delegations, may reach. If 0, [MaxValidatorWeightFactor] is
+	// used.
+	MaxValidatorWeightFactor uint64
+
 	// Minimum stake, in nAVAX, that can be delegated on the primary network
 	MinDelegatorStake uint64
 
@@ -82,9 +87,21 @@ type Factory struct {
 
 	// Time of the AP5 network upgrade
 	ApricotPhase5Time time.Time
+
+	// Time of the AP6 network upgrade
+	ApricotPhase6Time time.Time
+
+	// If non-nil, notified whenever a staker is removed from the current
+	// staker set. The accepted container is the JSON encoding of the
+	// StakerEndedEvent and is identified by the RewardValidatorTx's ID.
+	StakerEndedEvents snow.EventDispatcher
 }
 
 // New returns a new instance of the Platform Chain
 func (f *Factory) New(*snow.Context) (interface{}, error) {
-	return &VM{Factory: *f}, nil
+	vm := &VM{Factory: *f}
+	if vm.MaxValidatorWeightFactor == 0 {
+		vm.MaxValidatorWeightFactor = MaxValidatorWeightFactor
+	}
+	return vm, nil
 }
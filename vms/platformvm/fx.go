@@ -31,6 +31,11 @@ type Fx interface {
 	// assents to [tx]
 	VerifyPermission(tx, in, cred, controlGroup interface{}) error
 
+	// VerifySignatures verifies that the provided credentials carry valid
+	// signatures over [tx]. It doesn't check that the signers own the outputs
+	// being consumed.
+	VerifySignatures(tx interface{}, creds []verify.Verifiable) error
+
 	// CreateOutput creates a new output with the provided control group worth
 	// the specified amount
 	CreateOutput(amount uint64, controlGroup interface{}) (interface{}, error)
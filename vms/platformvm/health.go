@@ -12,12 +12,15 @@ import (
 // HealthCheck implements the common.VM interface
 func (vm *VM) HealthCheck() (interface{}, error) {
 	// Returns nil if this node is connected to > alpha percent of the Primary Network's stake
-	percentConnected, err := vm.getPercentConnected()
+	percentConnected, err := vm.getPercentConnected(constants.PrimaryNetworkID)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get percent connected: %w", err)
 	}
 
 	vm.metrics.percentConnected.Set(percentConnected)
+	if err := vm.updateSubnetPercentConnected(); err != nil {
+		return nil, fmt.Errorf("couldn't get subnet percent connected: %w", err)
+	}
 
 	details := map[string]float64{
 		"percentConnected": percentConnected,
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -47,6 +48,15 @@ func (tx *UnsignedImportTx) InitCtx(ctx *snow.Context) {
 	}
 }
 
+// MarshalJSON marshals [tx] as JSON with human readable addresses.
+// InitCtx must be called before marshalling [tx].
+func (tx *UnsignedImportTx) MarshalJSON() ([]byte, error) {
+	fields := tx.BaseTx.jsonFields(txTypeName(tx))
+	fields["sourceChain"] = tx.SourceChain
+	fields["importedInputs"] = tx.ImportedInputs
+	return json.Marshal(fields)
+}
+
 // InputUTXOs returns the UTXOIDs of the imported funds
 func (tx *UnsignedImportTx) InputUTXOs() ids.Set {
 	set := ids.NewSet(len(tx.ImportedInputs))
@@ -214,6 +224,7 @@ func (vm *VM) newImportTx(
 	if err != nil {
 		return nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
 	}
+	atomicUTXOs = vm.unreservedUTXOs(atomicUTXOs)
 
 	importedInputs := []*avax.TransferableInput{}
 	signers := [][]*crypto.PrivateKeySECP256K1R{}
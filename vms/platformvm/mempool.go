@@ -6,11 +6,14 @@ package platformvm
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/units"
 )
 
@@ -18,16 +21,20 @@ const (
 	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to cache
 	droppedTxIDsCacheSize = 50
 
-	initialConsumedUTXOsSize = 512
+	initialReservedUTXOsSize = 512
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
 	maxMempoolSize = 64 * units.MiB
 )
 
 var (
+	// mempoolPrefix is the database prefix under which the unissued txs are
+	// persisted, so that they survive a restart of the node
+	mempoolPrefix = []byte("mempool")
+
 	errUnknownTxType = errors.New("unknown transaction type")
 	errDuplicatedTx  = errors.New("duplicated transaction")
-	errConflictingTx = errors.New("conflicting transaction")
+	errUTXOReserved  = errors.New("utxo reserved by pending tx")
 	errMempoolFull   = errors.New("mempool is full")
 
 	_ Mempool = &mempool{}
@@ -56,8 +63,22 @@ type Mempool interface {
 	PopAtomicTxs(numTxs int) []*Tx
 	PopProposalTx() *Tx
 
+	PeekDecisionTx() *Tx
+	PeekAtomicTx() *Tx
+	PeekProposalTx() *Tx
+
+	// Len returns the number of txs waiting to be issued
+	Len() int
+
 	MarkDropped(txID ids.ID)
 	WasDropped(txID ids.ID) bool
+
+	// IsReserved returns true if [utxoID] is consumed by a tx that was added
+	// to the mempool and hasn't been decided or dropped yet
+	IsReserved(utxoID ids.ID) bool
+	// ReleaseUTXOs frees the UTXOs reserved by [tx]. It should be called once
+	// [tx] is accepted, rejected or dropped.
+	ReleaseUTXOs(tx *Tx)
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -73,10 +94,35 @@ type mempool struct {
 
 	droppedTxIDs *cache.LRU
 
-	consumedUTXOs ids.Set
+	// UTXOs consumed by txs that were added to the mempool. A UTXO stays
+	// reserved after its tx is issued into a block, until the tx is decided
+	// or dropped, so that new txs don't double spend it in the meantime.
+	// Key: UTXO ID
+	// Value: ID of the tx consuming the UTXO
+	reservedUTXOs map[ids.ID]ids.ID
+
+	// All unissued txs ordered by the time they were added to the mempool, used
+	// to report the age of the oldest pending tx.
+	unissuedTxsByAge TxHeap
+	// Key: Tx ID
+	// Value: Time the tx was added to the mempool
+	txAddedTimes map[ids.ID]time.Time
+
+	// Key: Tx ID
+	// Value: Tx bytes
+	db  database.Database
+	log logging.Logger
+
+	metrics *metrics
 }
 
-func NewMempool(namespace string, registerer prometheus.Registerer) (Mempool, error) {
+func NewMempool(
+	namespace string,
+	registerer prometheus.Registerer,
+	db database.Database,
+	log logging.Logger,
+	metrics *metrics,
+) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "bytes_available",
@@ -87,7 +133,7 @@ func NewMempool(namespace string, registerer prometheus.Registerer) (Mempool, er
 	}
 
 	unissuedDecisionTxs, err := NewTxHeapWithMetrics(
-		NewTxHeapByAge(),
+		NewTxHeapByFeeRate(),
 		fmt.Sprintf("%s_decision_txs", namespace),
 		registerer,
 	)
@@ -96,7 +142,7 @@ func NewMempool(namespace string, registerer prometheus.Registerer) (Mempool, er
 	}
 
 	unissuedAtomicTxs, err := NewTxHeapWithMetrics(
-		NewTxHeapByAge(),
+		NewTxHeapByFeeRate(),
 		fmt.Sprintf("%s_atomic_txs", namespace),
 		registerer,
 	)
@@ -131,7 +177,12 @@ func NewMempool(namespace string, registerer prometheus.Registerer) (Mempool, er
 		unissuedProposalTxs:  unissuedProposalTxs,
 		unknownTxs:           unknownTxs,
 		droppedTxIDs:         &cache.LRU{Size: droppedTxIDsCacheSize},
-		consumedUTXOs:        ids.NewSet(initialConsumedUTXOsSize),
+		reservedUTXOs:        make(map[ids.ID]ids.ID, initialReservedUTXOsSize),
+		unissuedTxsByAge:     NewTxHeapByAge(),
+		txAddedTimes:         make(map[ids.ID]time.Time),
+		db:                   db,
+		log:                  log,
+		metrics:              metrics,
 	}, nil
 }
 
@@ -146,10 +197,22 @@ func (m *mempool) Add(tx *Tx) error {
 	}
 
 	inputs := tx.InputIDs()
-	if m.consumedUTXOs.Overlaps(inputs) {
-		return errConflictingTx
+	for utxoID := range inputs {
+		// A tx that is re-added after its block was rejected still holds its
+		// own reservations
+		if reservingTxID, reserved := m.reservedUTXOs[utxoID]; reserved && reservingTxID != txID {
+			return fmt.Errorf("%w: %s is consumed by %s", errUTXOReserved, utxoID, reservingTxID)
+		}
 	}
 
+	// Txs that are re-added after their block was rejected may have been
+	// parsed from the block, so the burned amount is always recomputed here.
+	burned, err := burnedAmount(tx.UnsignedTx)
+	if err != nil {
+		return err
+	}
+	tx.burned = burned
+
 	switch tx.UnsignedTx.(type) {
 	case TimedTx:
 		m.AddProposalTx(tx)
@@ -162,8 +225,10 @@ func (m *mempool) Add(tx *Tx) error {
 		return errUnknownTxType
 	}
 
-	// Mark these UTXOs as consumed in the mempool
-	m.consumedUTXOs.Union(inputs)
+	// Reserve these UTXOs until this tx is decided or dropped
+	for utxoID := range inputs {
+		m.reservedUTXOs[utxoID] = txID
+	}
 
 	// ensure that a mempool tx is either dropped or available (not both)
 	m.droppedTxIDs.Evict(txID)
@@ -278,6 +343,20 @@ func (m *mempool) PopProposalTx() *Tx {
 	return tx
 }
 
+func (m *mempool) PeekDecisionTx() *Tx {
+	return m.unissuedDecisionTxs.Peek()
+}
+
+func (m *mempool) PeekAtomicTx() *Tx {
+	return m.unissuedAtomicTxs.Peek()
+}
+
+func (m *mempool) PeekProposalTx() *Tx {
+	return m.unissuedProposalTxs.Peek()
+}
+
+func (m *mempool) Len() int { return m.unissuedTxsByAge.Len() }
+
 func (m *mempool) MarkDropped(txID ids.ID) {
 	m.droppedTxIDs.Put(txID, struct{}{})
 }
@@ -287,10 +366,35 @@ func (m *mempool) WasDropped(txID ids.ID) bool {
 	return exist
 }
 
+func (m *mempool) IsReserved(utxoID ids.ID) bool {
+	_, reserved := m.reservedUTXOs[utxoID]
+	return reserved
+}
+
+func (m *mempool) ReleaseUTXOs(tx *Tx) {
+	txID := tx.ID()
+	for utxoID := range tx.InputIDs() {
+		// Only release the UTXOs that are reserved by this tx, a conflicting
+		// tx may hold the reservation instead
+		if m.reservedUTXOs[utxoID] == txID {
+			delete(m.reservedUTXOs, utxoID)
+		}
+	}
+}
+
 func (m *mempool) register(tx *Tx) {
 	txBytes := tx.Bytes()
 	m.bytesAvailable -= len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
+
+	txID := tx.ID()
+	m.unissuedTxsByAge.Add(tx)
+	m.txAddedTimes[txID] = time.Now()
+	m.updateMetrics()
+
+	if err := m.db.Put(txID[:], txBytes); err != nil {
+		m.log.Warn("failed to persist mempool tx %s: %s", txID, err)
+	}
 }
 
 func (m *mempool) deregister(tx *Tx) {
@@ -298,6 +402,25 @@ func (m *mempool) deregister(tx *Tx) {
 	m.bytesAvailable += len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
-	inputs := tx.InputIDs()
-	m.consumedUTXOs.Difference(inputs)
+	txID := tx.ID()
+	m.unissuedTxsByAge.Remove(txID)
+	delete(m.txAddedTimes, txID)
+	m.updateMetrics()
+
+	if err := m.db.Delete(txID[:]); err != nil {
+		m.log.Warn("failed to remove persisted mempool tx %s: %s", txID, err)
+	}
+}
+
+func (m *mempool) updateMetrics() {
+	oldestTxTime := time.Time{}
+	if m.unissuedTxsByAge.Len() > 0 {
+		oldestTx := m.unissuedTxsByAge.Peek()
+		oldestTxTime = m.txAddedTimes[oldestTx.ID()]
+	}
+	m.metrics.MempoolChanged(
+		m.unissuedProposalTxs.Len(),
+		m.unissuedDecisionTxs.Len()+m.unissuedAtomicTxs.Len(),
+		oldestTxTime,
+	)
 }
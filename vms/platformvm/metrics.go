@@ -6,20 +6,33 @@ package platformvm
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
-var errUnknownBlockType = errors.New("unknown block type")
+var (
+	errUnknownBlockType = errors.New("unknown block type")
+
+	// latencyBuckets are the histogram boundaries, in milliseconds, used for
+	// block and transaction latencies.
+	latencyBuckets = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+)
 
 type metrics struct {
-	percentConnected prometheus.Gauge
-	localStake       prometheus.Gauge
-	totalStake       prometheus.Gauge
+	percentConnected       prometheus.Gauge
+	percentConnectedSubnet *prometheus.GaugeVec
+	localStake             prometheus.Gauge
+	totalStake             prometheus.Gauge
 
 	numAbortBlocks,
 	numAtomicBlocks,
@@ -27,6 +40,33 @@ type metrics struct {
 	numProposalBlocks,
 	numStandardBlocks prometheus.Counter
 
+	numAbortBlocksRejected,
+	numAtomicBlocksRejected,
+	numCommitBlocksRejected,
+	numProposalBlocksRejected,
+	numStandardBlocksRejected prometheus.Counter
+
+	numAtomicBlocksBuilt,
+	numProposalBlocksBuilt,
+	numStandardBlocksBuilt prometheus.Counter
+	blockBuildLatency prometheus.Histogram
+	// Number of txs included in the most recently built block, and the number
+	// of txs that were left in the mempool after it was built
+	lastBuiltBlockTxs          prometheus.Gauge
+	lastBuiltBlockTxsRemaining prometheus.Gauge
+
+	abortBlockAcceptLatency,
+	atomicBlockAcceptLatency,
+	commitBlockAcceptLatency,
+	proposalBlockAcceptLatency,
+	standardBlockAcceptLatency prometheus.Histogram
+	// Wall-clock time the last block was accepted. Initialized to the time the
+	// metrics were initialized so that a chain that never accepts a block
+	// after startup is still reported as stalled.
+	lastAcceptedTime      utils.AtomicInterface
+	timeSinceLastAccepted prometheus.GaugeFunc
+	lastAcceptedHeight    prometheus.Gauge
+
 	numVotesWon, numVotesLost prometheus.Counter
 
 	numAddDelegatorTxs,
@@ -37,13 +77,60 @@ type metrics struct {
 	numCreateSubnetTxs,
 	numExportTxs,
 	numImportTxs,
+	numRemoveSubnetValidatorTxs,
 	numRewardValidatorTxs prometheus.Counter
 
+	addDelegatorTxVerifyLatency,
+	addSubnetValidatorTxVerifyLatency,
+	addValidatorTxVerifyLatency,
+	advanceTimeTxVerifyLatency,
+	createChainTxVerifyLatency,
+	createSubnetTxVerifyLatency,
+	exportTxVerifyLatency,
+	importTxVerifyLatency,
+	removeSubnetValidatorTxVerifyLatency,
+	rewardValidatorTxVerifyLatency prometheus.Histogram
+
+	// Labeled by tx type and by the reason the verification failed
+	txVerifyFailures *prometheus.CounterVec
+
 	validatorSetsCached     prometheus.Counter
 	validatorSetsCreated    prometheus.Counter
 	validatorSetsHeightDiff prometheus.Gauge
 	validatorSetsDuration   prometheus.Gauge
 
+	numPendingProposalTxs prometheus.Gauge
+	numPendingDecisionTxs prometheus.Gauge
+	oldestPendingTxAge    prometheus.GaugeFunc
+	// Time the oldest tx currently in the mempool was added. Holds the zero
+	// time when the mempool is empty.
+	oldestPendingTxTime utils.AtomicInterface
+
+	numCurrentValidators *prometheus.GaugeVec
+	numPendingValidators *prometheus.GaugeVec
+	currentStake         *prometheus.GaugeVec
+	pendingStake         *prometheus.GaugeVec
+	// Subnets that have had validators since their gauges were last deleted.
+	// Once all of a subnet's validators leave, its gauges are deleted. Only
+	// subnets with validators are reported, so creating subnets doesn't grow
+	// the label set.
+	subnetsWithValidators ids.Set
+
+	// Number of primary network validators and delegators, updated as stakers
+	// are written to and removed from the current and pending staker sets
+	numPrimaryCurrentValidators prometheus.Gauge
+	numPrimaryPendingValidators prometheus.Gauge
+	numPrimaryCurrentDelegators prometheus.Gauge
+	numPrimaryPendingDelegators prometheus.Gauge
+
+	numGossipedTxs        prometheus.Counter
+	numReceivedGossipTxs  prometheus.Counter
+	numDuplicateGossipTxs prometheus.Counter
+	numDroppedGossipTxs   prometheus.Counter
+	// Number of txs remembered as recently gossiped, which aren't gossiped
+	// again
+	numRecentGossipTxs prometheus.Gauge
+
 	apiRequestMetrics metric.APIInterceptor
 }
 
@@ -55,6 +142,31 @@ func newBlockMetrics(namespace string, name string) prometheus.Counter {
 	})
 }
 
+func newRejectedBlockMetrics(namespace string, name string) prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_blks_rejected", name),
+		Help:      fmt.Sprintf("Number of %s blocks rejected", name),
+	})
+}
+
+func newBuiltBlockMetrics(namespace string, name string) prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_blks_built", name),
+		Help:      fmt.Sprintf("Number of %s blocks built", name),
+	})
+}
+
+func newBlockLatencyMetrics(namespace string, name string) prometheus.Histogram {
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_blk_accept_latency_ms", name),
+		Help:      fmt.Sprintf("Time (in ms) between a %s block being verified and accepted", name),
+		Buckets:   latencyBuckets,
+	})
+}
+
 func newTxMetrics(namespace string, name string) prometheus.Counter {
 	return prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
@@ -63,6 +175,15 @@ func newTxMetrics(namespace string, name string) prometheus.Counter {
 	})
 }
 
+func newTxLatencyMetrics(namespace string, name string) prometheus.Histogram {
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_tx_verify_latency_ms", name),
+		Help:      fmt.Sprintf("Time (in ms) spent verifying accepted %s transactions", name),
+		Buckets:   latencyBuckets,
+	})
+}
+
 // Initialize platformvm metrics
 func (m *metrics) Initialize(
 	namespace string,
@@ -73,6 +194,14 @@ func (m *metrics) Initialize(
 		Name:      "percent_connected",
 		Help:      "Percent of connected stake",
 	})
+	m.percentConnectedSubnet = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "percent_connected_subnet",
+			Help:      "Percent of connected subnet weight",
+		},
+		[]string{"subnet"},
+	)
 	m.localStake = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "local_staked",
@@ -90,6 +219,63 @@ func (m *metrics) Initialize(
 	m.numProposalBlocks = newBlockMetrics(namespace, "proposal")
 	m.numStandardBlocks = newBlockMetrics(namespace, "standard")
 
+	m.numAbortBlocksRejected = newRejectedBlockMetrics(namespace, "abort")
+	m.numAtomicBlocksRejected = newRejectedBlockMetrics(namespace, "atomic")
+	m.numCommitBlocksRejected = newRejectedBlockMetrics(namespace, "commit")
+	m.numProposalBlocksRejected = newRejectedBlockMetrics(namespace, "proposal")
+	m.numStandardBlocksRejected = newRejectedBlockMetrics(namespace, "standard")
+
+	m.numAtomicBlocksBuilt = newBuiltBlockMetrics(namespace, "atomic")
+	m.numProposalBlocksBuilt = newBuiltBlockMetrics(namespace, "proposal")
+	m.numStandardBlocksBuilt = newBuiltBlockMetrics(namespace, "standard")
+	m.blockBuildLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "blk_build_latency_ms",
+		Help:      "Time (in ms) spent building a block",
+		Buckets:   latencyBuckets,
+	})
+	m.lastBuiltBlockTxs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_built_blk_txs",
+		Help:      "Number of transactions included in the most recently built block",
+	})
+	m.lastBuiltBlockTxsRemaining = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_built_blk_txs_remaining",
+		Help:      "Number of transactions left in the mempool after the most recently built block",
+	})
+
+	m.abortBlockAcceptLatency = newBlockLatencyMetrics(namespace, "abort")
+	m.atomicBlockAcceptLatency = newBlockLatencyMetrics(namespace, "atomic")
+	m.commitBlockAcceptLatency = newBlockLatencyMetrics(namespace, "commit")
+	m.proposalBlockAcceptLatency = newBlockLatencyMetrics(namespace, "proposal")
+	m.standardBlockAcceptLatency = newBlockLatencyMetrics(namespace, "standard")
+	m.lastAcceptedTime.SetValue(time.Now())
+	m.timeSinceLastAccepted = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "time_since_last_accepted",
+			Help:      "Time (in seconds) since the last block was accepted",
+		},
+		func() float64 {
+			return time.Since(m.lastAcceptedTime.GetValue().(time.Time)).Seconds()
+		},
+	)
+	m.lastAcceptedHeight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_accepted_height",
+		Help:      "Height of the last accepted block",
+	})
+
+	m.txVerifyFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tx_verify_failures",
+			Help:      "Number of transactions that failed verification",
+		},
+		[]string{"tx_type", "reason"},
+	)
+
 	m.numVotesWon = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "votes_won",
@@ -109,8 +295,20 @@ func (m *metrics) Initialize(
 	m.numCreateSubnetTxs = newTxMetrics(namespace, "create_subnet")
 	m.numExportTxs = newTxMetrics(namespace, "export")
 	m.numImportTxs = newTxMetrics(namespace, "import")
+	m.numRemoveSubnetValidatorTxs = newTxMetrics(namespace, "remove_subnet_validator")
 	m.numRewardValidatorTxs = newTxMetrics(namespace, "reward_validator")
 
+	m.addDelegatorTxVerifyLatency = newTxLatencyMetrics(namespace, "add_delegator")
+	m.addSubnetValidatorTxVerifyLatency = newTxLatencyMetrics(namespace, "add_subnet_validator")
+	m.addValidatorTxVerifyLatency = newTxLatencyMetrics(namespace, "add_validator")
+	m.advanceTimeTxVerifyLatency = newTxLatencyMetrics(namespace, "advance_time")
+	m.createChainTxVerifyLatency = newTxLatencyMetrics(namespace, "create_chain")
+	m.createSubnetTxVerifyLatency = newTxLatencyMetrics(namespace, "create_subnet")
+	m.exportTxVerifyLatency = newTxLatencyMetrics(namespace, "export")
+	m.importTxVerifyLatency = newTxLatencyMetrics(namespace, "import")
+	m.removeSubnetValidatorTxVerifyLatency = newTxLatencyMetrics(namespace, "remove_subnet_validator")
+	m.rewardValidatorTxVerifyLatency = newTxLatencyMetrics(namespace, "reward_validator")
+
 	m.validatorSetsCached = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "validator_sets_cached",
@@ -132,6 +330,113 @@ func (m *metrics) Initialize(
 		Help:      "Total amount of time generating validator sets in nanoseconds",
 	})
 
+	m.numPendingProposalTxs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_proposal_txs",
+		Help:      "Number of proposal transactions waiting in the mempool",
+	})
+	m.numPendingDecisionTxs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_decision_txs",
+		Help:      "Number of decision transactions waiting in the mempool",
+	})
+	m.oldestPendingTxTime.SetValue(time.Time{})
+	m.oldestPendingTxAge = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "oldest_pending_tx_age",
+			Help:      "Time (in seconds) the oldest transaction in the mempool has been waiting",
+		},
+		func() float64 {
+			oldest := m.oldestPendingTxTime.GetValue().(time.Time)
+			if oldest.IsZero() {
+				return 0
+			}
+			return time.Since(oldest).Seconds()
+		},
+	)
+
+	m.numCurrentValidators = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "validators_current",
+			Help:      "Number of validators currently validating the subnet",
+		},
+		[]string{"subnet"},
+	)
+	m.numPendingValidators = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "validators_pending",
+			Help:      "Number of validators slated to start validating the subnet",
+		},
+		[]string{"subnet"},
+	)
+	m.currentStake = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "staked_current",
+			Help:      "Weight currently staked on the subnet, including delegations",
+		},
+		[]string{"subnet"},
+	)
+	m.pendingStake = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "staked_pending",
+			Help:      "Weight slated to be staked on the subnet, including delegations",
+		},
+		[]string{"subnet"},
+	)
+	m.subnetsWithValidators = ids.Set{}
+
+	m.numPrimaryCurrentValidators = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "current_validators",
+		Help:      "Number of validators currently validating the primary network",
+	})
+	m.numPrimaryPendingValidators = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_validators",
+		Help:      "Number of validators slated to start validating the primary network",
+	})
+	m.numPrimaryCurrentDelegators = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "current_delegators",
+		Help:      "Number of delegators currently delegating on the primary network",
+	})
+	m.numPrimaryPendingDelegators = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_delegators",
+		Help:      "Number of delegators slated to start delegating on the primary network",
+	})
+
+	m.numGossipedTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gossip_txs_sent",
+		Help:      "Number of transactions gossiped to peers",
+	})
+	m.numReceivedGossipTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gossip_txs_received",
+		Help:      "Number of transactions received through gossip",
+	})
+	m.numDuplicateGossipTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gossip_txs_duplicate",
+		Help:      "Number of transactions received through gossip that were already known",
+	})
+	m.numDroppedGossipTxs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gossip_txs_dropped",
+		Help:      "Number of transactions received through gossip that were recently dropped from the mempool",
+	})
+	m.numRecentGossipTxs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "gossip_recent_txs",
+		Help:      "Number of recently gossiped transactions that won't be gossiped again",
+	})
+
 	apiRequestMetrics, err := metric.NewAPIInterceptor(namespace, registerer)
 	m.apiRequestMetrics = apiRequestMetrics
 	errs := wrappers.Errs{}
@@ -139,6 +444,7 @@ func (m *metrics) Initialize(
 		err,
 
 		registerer.Register(m.percentConnected),
+		registerer.Register(m.percentConnectedSubnet),
 		registerer.Register(m.localStake),
 		registerer.Register(m.totalStake),
 
@@ -148,6 +454,27 @@ func (m *metrics) Initialize(
 		registerer.Register(m.numProposalBlocks),
 		registerer.Register(m.numStandardBlocks),
 
+		registerer.Register(m.numAbortBlocksRejected),
+		registerer.Register(m.numAtomicBlocksRejected),
+		registerer.Register(m.numCommitBlocksRejected),
+		registerer.Register(m.numProposalBlocksRejected),
+		registerer.Register(m.numStandardBlocksRejected),
+
+		registerer.Register(m.numAtomicBlocksBuilt),
+		registerer.Register(m.numProposalBlocksBuilt),
+		registerer.Register(m.numStandardBlocksBuilt),
+		registerer.Register(m.blockBuildLatency),
+		registerer.Register(m.lastBuiltBlockTxs),
+		registerer.Register(m.lastBuiltBlockTxsRemaining),
+
+		registerer.Register(m.abortBlockAcceptLatency),
+		registerer.Register(m.atomicBlockAcceptLatency),
+		registerer.Register(m.commitBlockAcceptLatency),
+		registerer.Register(m.proposalBlockAcceptLatency),
+		registerer.Register(m.standardBlockAcceptLatency),
+		registerer.Register(m.timeSinceLastAccepted),
+		registerer.Register(m.lastAcceptedHeight),
+
 		registerer.Register(m.numVotesWon),
 		registerer.Register(m.numVotesLost),
 
@@ -159,30 +486,72 @@ func (m *metrics) Initialize(
 		registerer.Register(m.numCreateSubnetTxs),
 		registerer.Register(m.numExportTxs),
 		registerer.Register(m.numImportTxs),
+		registerer.Register(m.numRemoveSubnetValidatorTxs),
 		registerer.Register(m.numRewardValidatorTxs),
 
+		registerer.Register(m.addDelegatorTxVerifyLatency),
+		registerer.Register(m.addSubnetValidatorTxVerifyLatency),
+		registerer.Register(m.addValidatorTxVerifyLatency),
+		registerer.Register(m.advanceTimeTxVerifyLatency),
+		registerer.Register(m.createChainTxVerifyLatency),
+		registerer.Register(m.createSubnetTxVerifyLatency),
+		registerer.Register(m.exportTxVerifyLatency),
+		registerer.Register(m.importTxVerifyLatency),
+		registerer.Register(m.removeSubnetValidatorTxVerifyLatency),
+		registerer.Register(m.rewardValidatorTxVerifyLatency),
+
+		registerer.Register(m.txVerifyFailures),
+
 		registerer.Register(m.validatorSetsCreated),
 		registerer.Register(m.validatorSetsCached),
 		registerer.Register(m.validatorSetsHeightDiff),
 		registerer.Register(m.validatorSetsDuration),
+
+		registerer.Register(m.numPendingProposalTxs),
+		registerer.Register(m.numPendingDecisionTxs),
+		registerer.Register(m.oldestPendingTxAge),
+
+		registerer.Register(m.numCurrentValidators),
+		registerer.Register(m.numPendingValidators),
+		registerer.Register(m.currentStake),
+		registerer.Register(m.pendingStake),
+
+		registerer.Register(m.numPrimaryCurrentValidators),
+		registerer.Register(m.numPrimaryPendingValidators),
+		registerer.Register(m.numPrimaryCurrentDelegators),
+		registerer.Register(m.numPrimaryPendingDelegators),
+
+		registerer.Register(m.numGossipedTxs),
+		registerer.Register(m.numReceivedGossipTxs),
+		registerer.Register(m.numDuplicateGossipTxs),
+		registerer.Register(m.numDroppedGossipTxs),
+		registerer.Register(m.numRecentGossipTxs),
 	)
 	return errs.Err
 }
 
 func (m *metrics) AcceptBlock(b snowman.Block) error {
+	m.lastAcceptedTime.SetValue(time.Now())
+	m.lastAcceptedHeight.Set(float64(b.Height()))
+
 	switch b := b.(type) {
 	case *AbortBlock:
 		m.numAbortBlocks.Inc()
+		observeLatency(m.abortBlockAcceptLatency, b.verifiedTime)
 	case *AtomicBlock:
 		m.numAtomicBlocks.Inc()
+		observeLatency(m.atomicBlockAcceptLatency, b.verifiedTime)
 		return m.AcceptTx(&b.Tx)
 	case *CommitBlock:
 		m.numCommitBlocks.Inc()
+		observeLatency(m.commitBlockAcceptLatency, b.verifiedTime)
 	case *ProposalBlock:
 		m.numProposalBlocks.Inc()
+		observeLatency(m.proposalBlockAcceptLatency, b.verifiedTime)
 		return m.AcceptTx(&b.Tx)
 	case *StandardBlock:
 		m.numStandardBlocks.Inc()
+		observeLatency(m.standardBlockAcceptLatency, b.verifiedTime)
 		for _, tx := range b.Txs {
 			if err := m.AcceptTx(tx); err != nil {
 				return err
@@ -194,28 +563,240 @@ func (m *metrics) AcceptBlock(b snowman.Block) error {
 	return nil
 }
 
+func (m *metrics) RejectBlock(b snowman.Block) error {
+	switch b.(type) {
+	case *AbortBlock:
+		m.numAbortBlocksRejected.Inc()
+	case *AtomicBlock:
+		m.numAtomicBlocksRejected.Inc()
+	case *CommitBlock:
+		m.numCommitBlocksRejected.Inc()
+	case *ProposalBlock:
+		m.numProposalBlocksRejected.Inc()
+	case *StandardBlock:
+		m.numStandardBlocksRejected.Inc()
+	default:
+		return errUnknownBlockType
+	}
+	return nil
+}
+
+// BuildBlock records that [b] was built, that building it started at [start],
+// and that [numTxsRemaining] txs were left in the mempool afterwards.
+func (m *metrics) BuildBlock(b snowman.Block, start time.Time, numTxsRemaining int) error {
+	switch b := b.(type) {
+	case *AtomicBlock:
+		m.numAtomicBlocksBuilt.Inc()
+		m.lastBuiltBlockTxs.Set(1)
+	case *ProposalBlock:
+		m.numProposalBlocksBuilt.Inc()
+		m.lastBuiltBlockTxs.Set(1)
+	case *StandardBlock:
+		m.numStandardBlocksBuilt.Inc()
+		m.lastBuiltBlockTxs.Set(float64(len(b.Txs)))
+	default:
+		return errUnknownBlockType
+	}
+	observeLatency(m.blockBuildLatency, start)
+	m.lastBuiltBlockTxsRemaining.Set(float64(numTxsRemaining))
+	return nil
+}
+
 func (m *metrics) AcceptTx(tx *Tx) error {
+	verifyLatency := float64(tx.verifyDuration) / float64(time.Millisecond)
 	switch tx.UnsignedTx.(type) {
 	case *UnsignedAddDelegatorTx:
 		m.numAddDelegatorTxs.Inc()
+		m.addDelegatorTxVerifyLatency.Observe(verifyLatency)
 	case *UnsignedAddSubnetValidatorTx:
 		m.numAddSubnetValidatorTxs.Inc()
+		m.addSubnetValidatorTxVerifyLatency.Observe(verifyLatency)
 	case *UnsignedAddValidatorTx:
 		m.numAddValidatorTxs.Inc()
+		m.addValidatorTxVerifyLatency.Observe(verifyLatency)
 	case *UnsignedAdvanceTimeTx:
 		m.numAdvanceTimeTxs.Inc()
+		m.advanceTimeTxVerifyLatency.Observe(verifyLatency)
 	case *UnsignedCreateChainTx:
 		m.numCreateChainTxs.Inc()
+		m.createChainTxVerifyLatency.Observe(verifyLatency)
 	case *UnsignedCreateSubnetTx:
 		m.numCreateSubnetTxs.Inc()
+		m.createSubnetTxVerifyLatency.Observe(verifyLatency)
 	case *UnsignedImportTx:
 		m.numImportTxs.Inc()
+		m.importTxVerifyLatency.Observe(verifyLatency)
 	case *UnsignedExportTx:
 		m.numExportTxs.Inc()
+		m.exportTxVerifyLatency.Observe(verifyLatency)
+	case *UnsignedRemoveSubnetValidatorTx:
+		m.numRemoveSubnetValidatorTxs.Inc()
+		m.removeSubnetValidatorTxVerifyLatency.Observe(verifyLatency)
 	case *UnsignedRewardValidatorTx:
 		m.numRewardValidatorTxs.Inc()
+		m.rewardValidatorTxVerifyLatency.Observe(verifyLatency)
 	default:
 		return errUnknownTxType
 	}
 	return nil
 }
+
+// MarkTxVerifyFailure records that [tx] failed verification with [err].
+func (m *metrics) MarkTxVerifyFailure(tx *Tx, err error) {
+	m.txVerifyFailures.WithLabelValues(txTypeName(tx.UnsignedTx), txVerifyFailureReason(err)).Inc()
+}
+
+// txTypeName returns the metrics label of the type of [utx].
+func txTypeName(utx UnsignedTx) string {
+	switch utx.(type) {
+	case *UnsignedAddDelegatorTx:
+		return "add_delegator"
+	case *UnsignedAddSubnetValidatorTx:
+		return "add_subnet_validator"
+	case *UnsignedAddValidatorTx:
+		return "add_validator"
+	case *UnsignedAdvanceTimeTx:
+		return "advance_time"
+	case *UnsignedCreateChainTx:
+		return "create_chain"
+	case *UnsignedCreateSubnetTx:
+		return "create_subnet"
+	case *UnsignedExportTx:
+		return "export"
+	case *UnsignedImportTx:
+		return "import"
+	case *UnsignedRemoveSubnetValidatorTx:
+		return "remove_subnet_validator"
+	case *UnsignedRewardValidatorTx:
+		return "reward_validator"
+	default:
+		return "unknown"
+	}
+}
+
+// txVerifyFailureReason maps [err] to one of a fixed set of reasons, so that
+// the cardinality of the tx verification failure metrics is bounded.
+func txVerifyFailureReason(err error) string {
+	switch {
+	case errors.Is(err, secp256k1fx.ErrTimelocked),
+		errors.Is(err, errLockedFundsNotMarkedAsLocked),
+		errors.Is(err, errWrongLocktime):
+		return "locked_utxo"
+	case errors.Is(err, errInvalidCredentials),
+		errors.Is(err, errWrongNumberOfCredentials):
+		return "invalid_signature"
+	case errors.Is(err, database.ErrNotFound):
+		return "missing_utxo"
+	case errors.Is(err, errStartTimeTooEarly),
+		errors.Is(err, errStartAfterEndTime),
+		errors.Is(err, errFutureStakeTime),
+		errors.Is(err, errStakeTooShort),
+		errors.Is(err, errStakeTooLong),
+		errors.Is(err, errDelegatorSubset),
+		errors.Is(err, errDSValidatorSubset):
+		return "bad_timing"
+	case errors.Is(err, errInsufficientFunds):
+		return "insufficient_fee"
+	default:
+		return "other"
+	}
+}
+
+// MempoolChanged updates the mempool depth and age metrics. [oldestTxTime]
+// should be the zero time if the mempool is empty.
+func (m *metrics) MempoolChanged(numProposalTxs, numDecisionTxs int, oldestTxTime time.Time) {
+	m.numPendingProposalTxs.Set(float64(numProposalTxs))
+	m.numPendingDecisionTxs.Set(float64(numDecisionTxs))
+	m.oldestPendingTxTime.SetValue(oldestTxTime)
+}
+
+// SetValidatorSets updates the validator set size and stake gauges. Subnets
+// that are missing from both [current] and [pending] are treated as having no
+// validators.
+func (m *metrics) SetValidatorSets(current, pending map[ids.ID]stakerSummary) {
+	for subnetID := range m.subnetsWithValidators {
+		if current[subnetID].numValidators != 0 || pending[subnetID].numValidators != 0 || subnetID == constants.PrimaryNetworkID {
+			continue
+		}
+		// The last validator of this subnet left, so stop reporting it.
+		subnetStr := subnetID.String()
+		m.numCurrentValidators.DeleteLabelValues(subnetStr)
+		m.numPendingValidators.DeleteLabelValues(subnetStr)
+		m.currentStake.DeleteLabelValues(subnetStr)
+		m.pendingStake.DeleteLabelValues(subnetStr)
+		m.subnetsWithValidators.Remove(subnetID)
+	}
+	for _, summaries := range []map[ids.ID]stakerSummary{current, pending} {
+		for subnetID, summary := range summaries {
+			if summary.numValidators == 0 {
+				continue
+			}
+			m.subnetsWithValidators.Add(subnetID)
+			subnetStr := subnetID.String()
+			m.numCurrentValidators.WithLabelValues(subnetStr).Set(float64(current[subnetID].numValidators))
+			m.numPendingValidators.WithLabelValues(subnetStr).Set(float64(pending[subnetID].numValidators))
+			m.currentStake.WithLabelValues(subnetStr).Set(float64(current[subnetID].weight))
+			m.pendingStake.WithLabelValues(subnetStr).Set(float64(pending[subnetID].weight))
+		}
+	}
+}
+
+// SetStakers sets the primary network staker gauges to the number of
+// validators and delegators in [stakers], which are the current stakers if
+// [current] is true and the pending stakers otherwise.
+func (m *metrics) SetStakers(stakers []*Tx, current bool) {
+	validators, delegators := m.numPrimaryPendingValidators, m.numPrimaryPendingDelegators
+	if current {
+		validators, delegators = m.numPrimaryCurrentValidators, m.numPrimaryCurrentDelegators
+	}
+	validators.Set(0)
+	delegators.Set(0)
+	for _, tx := range stakers {
+		m.AddStaker(tx, current)
+	}
+}
+
+// AddStaker records that [tx] was added to the current stakers if [current] is
+// true and to the pending stakers otherwise.
+func (m *metrics) AddStaker(tx *Tx, current bool) {
+	if gauge := m.stakerGauge(tx, current); gauge != nil {
+		gauge.Inc()
+	}
+}
+
+// RemoveStaker records that [tx] was removed from the current stakers if
+// [current] is true and from the pending stakers otherwise.
+func (m *metrics) RemoveStaker(tx *Tx, current bool) {
+	if gauge := m.stakerGauge(tx, current); gauge != nil {
+		gauge.Dec()
+	}
+}
+
+// stakerGauge returns the gauge that counts [tx], or nil if [tx] doesn't
+// stake on the primary network.
+func (m *metrics) stakerGauge(tx *Tx, current bool) prometheus.Gauge {
+	switch tx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		if current {
+			return m.numPrimaryCurrentValidators
+		}
+		return m.numPrimaryPendingValidators
+	case *UnsignedAddDelegatorTx:
+		if current {
+			return m.numPrimaryCurrentDelegators
+		}
+		return m.numPrimaryPendingDelegators
+	default:
+		return nil
+	}
+}
+
+// observeLatency records the time in milliseconds since [start]. If the block
+// was never verified by this node, [start] is the zero time and nothing is
+// recorded.
+func observeLatency(h prometheus.Histogram, start time.Time) {
+	if start.IsZero() {
+		return
+	}
+	h.Observe(float64(time.Since(start)) / float64(time.Millisecond))
+}
@@ -0,0 +1,333 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestTxVerifyFailureReason(t *testing.T) {
+	tests := []struct {
+		err            error
+		expectedReason string
+	}{
+		{
+			err:            permError{credentialsError{fmt.Errorf("failed to verify transfer: %w", errors.New("input has less signers than expected"))}},
+			expectedReason: "invalid_signature",
+		},
+		{
+			err:            permError{credentialsError{fmt.Errorf("failed to verify transfer: %w", secp256k1fx.ErrTimelocked)}},
+			expectedReason: "locked_utxo",
+		},
+		{
+			err:            permError{errWrongLocktime},
+			expectedReason: "locked_utxo",
+		},
+		{
+			err:            tempError{fmt.Errorf("failed to read consumed UTXO due to: %w", database.ErrNotFound)},
+			expectedReason: "missing_utxo",
+		},
+		{
+			err:            permError{fmt.Errorf("%w: validator's start time at or before current timestamp", errStartTimeTooEarly)},
+			expectedReason: "bad_timing",
+		},
+		{
+			err:            permError{errFutureStakeTime},
+			expectedReason: "bad_timing",
+		},
+		{
+			err:            permError{fmt.Errorf("failed semanticVerifySpend: %w", permError{errInsufficientFunds})},
+			expectedReason: "insufficient_fee",
+		},
+		{
+			err:            errors.New("unexpected error"),
+			expectedReason: "other",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.err.Error(), func(t *testing.T) {
+			assert.Equal(t, test.expectedReason, txVerifyFailureReason(test.err))
+		})
+	}
+}
+
+func TestCredentialsErrorWrapsCause(t *testing.T) {
+	assert := assert.New(t)
+
+	err := error(permError{credentialsError{
+		fmt.Errorf("failed to verify transfer: %w", secp256k1fx.ErrTimelocked),
+	}})
+	assert.ErrorIs(err, errInvalidCredentials)
+	assert.ErrorIs(err, secp256k1fx.ErrTimelocked)
+	assert.Equal("invalid credentials: failed to verify transfer: output is time locked", err.Error())
+
+	var credsErr credentialsError
+	assert.True(errors.As(err, &credsErr))
+}
+
+func TestLastAcceptedMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	lastAccepted, err := vm.getBlock(vm.lastAcceptedID)
+	assert.NoError(err)
+	assert.Equal(float64(lastAccepted.Height()), testutil.ToFloat64(vm.metrics.lastAcceptedHeight))
+
+	// Pretend the last block was accepted a minute ago
+	vm.metrics.lastAcceptedTime.SetValue(time.Now().Add(-time.Minute))
+	assert.GreaterOrEqual(testutil.ToFloat64(vm.metrics.timeSinceLastAccepted), time.Minute.Seconds())
+
+	tx, err := vm.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	assert.Equal(float64(blk.Height()), testutil.ToFloat64(vm.metrics.lastAcceptedHeight))
+	assert.Less(testutil.ToFloat64(vm.metrics.timeSinceLastAccepted), time.Minute.Seconds())
+}
+
+func TestAcceptLatencyMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	histograms := []prometheus.Histogram{
+		vm.metrics.abortBlockAcceptLatency,
+		vm.metrics.commitBlockAcceptLatency,
+		vm.metrics.proposalBlockAcceptLatency,
+		vm.metrics.standardBlockAcceptLatency,
+		vm.metrics.addValidatorTxVerifyLatency,
+		vm.metrics.createSubnetTxVerifyLatency,
+	}
+	// sampleCounts returns the number of observations in each of [histograms]
+	sampleCounts := func() []uint64 {
+		counts := make([]uint64, len(histograms))
+		for i, h := range histograms {
+			metric := &dto.Metric{}
+			assert.NoError(h.Write(metric))
+			counts[i] = metric.GetHistogram().GetSampleCount()
+		}
+		return counts
+	}
+	// assertNewSamples asserts that, since [before], [expected][i]
+	// observations were added to [histograms][i]
+	assertNewSamples := func(before []uint64, expected ...uint64) {
+		after := sampleCounts()
+		for i := range histograms {
+			assert.Equal(before[i]+expected[i], after[i])
+		}
+	}
+
+	tx, err := vm.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+
+	before := sampleCounts()
+	assert.NoError(blk.Verify())
+	// Nothing is recorded until the block is accepted
+	assertNewSamples(before, 0, 0, 0, 0, 0, 0)
+	assert.NoError(blk.Accept())
+	assertNewSamples(before, 0, 0, 0, 1, 0, 1)
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	key, err := vm.factory.NewPrivateKey()
+	assert.NoError(err)
+	nodeID := key.PublicKey().Address()
+	addValidatorTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(addValidatorTx))
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+
+	// The abort block is verified, but rejected, so it isn't recorded
+	before = sampleCounts()
+	verifyAndAcceptProposalCommitment(assert, blk)
+	assertNewSamples(before, 0, 1, 1, 0, 1, 0)
+}
+
+func TestStakerCountMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	assertStakers := func(currentValidators, pendingValidators, currentDelegators, pendingDelegators int) {
+		assert.Equal(float64(currentValidators), testutil.ToFloat64(vm.metrics.numPrimaryCurrentValidators))
+		assert.Equal(float64(pendingValidators), testutil.ToFloat64(vm.metrics.numPrimaryPendingValidators))
+		assert.Equal(float64(currentDelegators), testutil.ToFloat64(vm.metrics.numPrimaryCurrentDelegators))
+		assert.Equal(float64(pendingDelegators), testutil.ToFloat64(vm.metrics.numPrimaryPendingDelegators))
+	}
+	numGenesisValidators := len(keys)
+	assertStakers(numGenesisValidators, 0, 0, 0)
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	delegatorEndTime := startTime.Add(defaultMinStakingDuration)
+	validatorEndTime := delegatorEndTime.Add(defaultMinStakingDuration)
+
+	key, err := vm.factory.NewPrivateKey()
+	assert.NoError(err)
+	nodeID := key.PublicKey().Address()
+
+	addValidatorTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(validatorEndTime.Unix()),
+		nodeID,
+		nodeID,
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(addValidatorTx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	verifyAndAcceptProposalCommitment(assert, blk)
+	assertStakers(numGenesisValidators, 1, 0, 0)
+
+	addDelegatorTx, err := vm.newAddDelegatorTx(
+		vm.MinDelegatorStake,
+		uint64(startTime.Unix()),
+		uint64(delegatorEndTime.Unix()),
+		nodeID,
+		nodeID,
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(addDelegatorTx))
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	verifyAndAcceptProposalCommitment(assert, blk)
+	assertStakers(numGenesisValidators, 1, 0, 1)
+
+	// Both stakers start at the same time
+	vm.clock.Set(startTime)
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	verifyAndAcceptProposalCommitment(assert, blk)
+	assertStakers(numGenesisValidators+1, 0, 1, 0)
+
+	// The delegator stops first
+	vm.clock.Set(delegatorEndTime)
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	verifyAndAcceptProposalCommitment(assert, blk)
+	assertStakers(numGenesisValidators+1, 0, 1, 0)
+
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	assert.IsType(&UnsignedRewardValidatorTx{}, blk.(*ProposalBlock).Tx.UnsignedTx)
+	verifyAndAcceptProposalCommitment(assert, blk)
+	assertStakers(numGenesisValidators+1, 0, 0, 0)
+
+	vm.clock.Set(validatorEndTime)
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	verifyAndAcceptProposalCommitment(assert, blk)
+	assertStakers(numGenesisValidators+1, 0, 0, 0)
+
+	blk, err = vm.BuildBlock()
+	assert.NoError(err)
+	assert.IsType(&UnsignedRewardValidatorTx{}, blk.(*ProposalBlock).Tx.UnsignedTx)
+	verifyAndAcceptProposalCommitment(assert, blk)
+	assertStakers(numGenesisValidators, 0, 0, 0)
+}
+
+func TestSubnetValidatorSetMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Creating a subnet doesn't report it until it has validators
+	numSubnets := testutil.CollectAndCount(vm.metrics.numCurrentValidators)
+	tx, err := vm.newCreateSubnetTx(
+		1, // threshold
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+	assert.Equal(numSubnets, testutil.CollectAndCount(vm.metrics.numCurrentValidators))
+
+	subnetID := tx.ID()
+	current := map[ids.ID]stakerSummary{
+		constants.PrimaryNetworkID: {numValidators: 1, weight: 2},
+		subnetID:                   {numValidators: 1, weight: 1},
+	}
+	vm.metrics.SetValidatorSets(current, nil)
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.numCurrentValidators.WithLabelValues(subnetID.String())))
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.currentStake.WithLabelValues(subnetID.String())))
+
+	// Once the last validator leaves, the subnet's series are deleted
+	delete(current, subnetID)
+	vm.metrics.SetValidatorSets(current, nil)
+	assert.Equal(1, testutil.CollectAndCount(vm.metrics.numCurrentValidators))
+	assert.Equal(1, testutil.CollectAndCount(vm.metrics.pendingStake))
+}
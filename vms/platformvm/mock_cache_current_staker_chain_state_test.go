@@ -42,6 +42,29 @@ func (_m *mockCurrentStakerChainState) DeleteNextStaker() (currentStakerChainSta
 	return r0, r1
 }
 
+// DeleteSubnetValidator provides a mock function with given fields: nodeID, subnetID
+func (_m *mockCurrentStakerChainState) DeleteSubnetValidator(nodeID ids.ShortID, subnetID ids.ID) (currentStakerChainState, error) {
+	ret := _m.Called(nodeID, subnetID)
+
+	var r0 currentStakerChainState
+	if rf, ok := ret.Get(0).(func(ids.ShortID, ids.ID) currentStakerChainState); ok {
+		r0 = rf(nodeID, subnetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(currentStakerChainState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ids.ShortID, ids.ID) error); ok {
+		r1 = rf(nodeID, subnetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNextStaker provides a mock function with given fields:
 func (_m *mockCurrentStakerChainState) GetNextStaker() (*Tx, uint64, error) {
 	ret := _m.Called()
@@ -168,6 +168,29 @@ func (_m *MockInternalState) GetBlock(blockID ids.ID) (Block, error) {
 	return r0, r1
 }
 
+// GetBlockIDAtHeight provides a mock function with given fields: height
+func (_m *MockInternalState) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	ret := _m.Called(height)
+
+	var r0 ids.ID
+	if rf, ok := ret.Get(0).(func(uint64) ids.ID); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ids.ID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChains provides a mock function with given fields: subnetID
 func (_m *MockInternalState) GetChains(subnetID ids.ID) ([]*Tx, error) {
 	ret := _m.Called(subnetID)
@@ -205,6 +228,27 @@ func (_m *MockInternalState) GetCurrentSupply() uint64 {
 	return r0
 }
 
+// GetCurrentSupplyAtHeight provides a mock function with given fields: height
+func (_m *MockInternalState) GetCurrentSupplyAtHeight(height uint64) (uint64, error) {
+	ret := _m.Called(height)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(uint64) uint64); ok {
+		r0 = rf(height)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLastAccepted provides a mock function with given fields:
 func (_m *MockInternalState) GetLastAccepted() ids.ID {
 	ret := _m.Called()
@@ -101,9 +101,17 @@ func (n *network) AppGossip(nodeID ids.ShortID, msgBytes []byte) error {
 	}
 	tx.Initialize(unsignedBytes, msg.Tx)
 
+	n.vm.metrics.numReceivedGossipTxs.Inc()
+
 	txID := tx.ID()
+	if _, seen := n.recentTxs.Get(txID); seen || n.mempool.Has(txID) {
+		// If the tx was already learned about - just ignore it
+		n.vm.metrics.numDuplicateGossipTxs.Inc()
+		return nil
+	}
 	if n.mempool.WasDropped(txID) {
 		// If the tx is being dropped - just ignore it
+		n.vm.metrics.numDroppedGossipTxs.Inc()
 		return nil
 	}
 
@@ -125,6 +133,7 @@ func (n *network) GossipTx(tx *Tx) error {
 		return nil
 	}
 	n.recentTxs.Put(txID, nil)
+	n.vm.metrics.numRecentGossipTxs.Set(float64(n.recentTxs.Len()))
 
 	n.log.Debug("gossiping tx %s", txID)
 
@@ -135,5 +144,9 @@ func (n *network) GossipTx(tx *Tx) error {
 	if err != nil {
 		return fmt.Errorf("GossipTx: failed to build Tx message with: %w", err)
 	}
-	return n.appSender.SendAppGossip(msgBytes)
+	if err := n.appSender.SendAppGossip(msgBytes); err != nil {
+		return err
+	}
+	n.vm.metrics.numGossipedTxs.Inc()
+	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/message"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,6 +63,8 @@ func TestMempoolValidGossipedTxIsAddedToMempool(t *testing.T) {
 	msgBytes, err := message.Build(&msg)
 	assert.NoError(err)
 
+	numGossipedTxs := testutil.ToFloat64(vm.metrics.numGossipedTxs)
+
 	// show that unknown tx is added to mempool
 	err = vm.AppGossip(nodeID, msgBytes)
 	assert.NoError(err, "error in reception of gossiped tx")
@@ -86,6 +89,16 @@ func TestMempoolValidGossipedTxIsAddedToMempool(t *testing.T) {
 
 	retrivedTx.Initialize(unsignedBytes, reply.Tx)
 	assert.Equal(txID, retrivedTx.ID())
+
+	// show that a tx that is already known is counted as a duplicate
+	err = vm.AppGossip(nodeID, msgBytes)
+	assert.NoError(err, "error in reception of gossiped tx")
+
+	assert.Equal(2.0, testutil.ToFloat64(vm.metrics.numReceivedGossipTxs))
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.numDuplicateGossipTxs))
+	assert.Equal(numGossipedTxs+1, testutil.ToFloat64(vm.metrics.numGossipedTxs))
+	assert.Equal(0.0, testutil.ToFloat64(vm.metrics.numDroppedGossipTxs))
+	assert.Equal(float64(vm.network.recentTxs.Len()), testutil.ToFloat64(vm.metrics.numRecentGossipTxs))
 }
 
 // show that txs already marked as invalid are not re-requested on gossiping
@@ -118,6 +131,10 @@ func TestMempoolInvalidGossipedTxIsNotAddedToMempool(t *testing.T) {
 	err = vm.AppGossip(nodeID, msgBytes)
 	assert.NoError(err, "error in reception of gossiped tx")
 	assert.False(vm.mempool.Has(txID))
+
+	// show that the tx is counted as dropped rather than as a duplicate
+	assert.Equal(1.0, testutil.ToFloat64(vm.metrics.numDroppedGossipTxs))
+	assert.Equal(0.0, testutil.ToFloat64(vm.metrics.numDuplicateGossipTxs))
 }
 
 // show that locally generated txs are gossiped
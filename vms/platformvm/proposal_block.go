@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -76,6 +77,7 @@ func (pb *ProposalBlock) Reject() error {
 			pb.Tx.ID(),
 			err,
 		)
+		pb.vm.blockBuilder.ReleaseUTXOs(&pb.Tx)
 	}
 	return pb.CommonBlock.Reject()
 }
@@ -175,10 +177,14 @@ func (pb *ProposalBlock) Verify() error {
 	parentState := parent.onAccept()
 
 	var err TxError
+	executeStart := time.Now()
 	pb.onCommitState, pb.onAbortState, pb.onCommitFunc, pb.onAbortFunc, err = tx.Execute(pb.vm, parentState, &pb.Tx)
+	pb.Tx.verifyDuration = time.Since(executeStart)
 	if err != nil {
 		txID := tx.ID()
 		pb.vm.droppedTxCache.Put(txID, err.Error()) // cache tx as dropped
+		pb.vm.metrics.MarkTxVerifyFailure(&pb.Tx, err)
+		pb.vm.blockBuilder.ReleaseUTXOs(&pb.Tx)
 		// If this block's transaction proposes to advance the timestamp, the
 		// transaction may fail verification now but be valid in the future, so
 		// don't (permanently) mark the block as rejected.
@@ -202,6 +208,7 @@ func (pb *ProposalBlock) Verify() error {
 	pb.vm.blockBuilder.RemoveProposalTx(&pb.Tx)
 	pb.vm.currentBlocks[blkID] = pb
 	parentIntf.addChild(pb)
+	pb.verifiedTime = time.Now()
 	return nil
 }
 
@@ -0,0 +1,236 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	errNotSubnetValidator                = errors.New("node isn't a validator of the subnet")
+	errRemoveSubnetValidatorTxNotEnabled = errors.New("removing subnet validators isn't enabled")
+
+	_ UnsignedDecisionTx = &UnsignedRemoveSubnetValidatorTx{}
+)
+
+// UnsignedRemoveSubnetValidatorTx is an unsigned removeSubnetValidatorTx. It
+// removes a validator from a subnet other than the primary network before the
+// validator's staking period ends.
+type UnsignedRemoveSubnetValidatorTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node to remove from the subnet
+	NodeID ids.ShortID `serialize:"true" json:"nodeID"`
+	// The subnet to remove the node from
+	Subnet ids.ID `serialize:"true" json:"subnet"`
+	// Auth that will be allowing this validator to be removed from the subnet
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+// InputUTXOs for [DecisionTxs] will return an empty set to diffrentiate from the [AtomicTxs] input UTXOs
+func (tx *UnsignedRemoveSubnetValidatorTx) InputUTXOs() ids.Set { return nil }
+
+func (tx *UnsignedRemoveSubnetValidatorTx) AtomicOperations() (ids.ID, *atomic.Requests, error) {
+	return ids.ID{}, nil, nil
+}
+
+// MarshalJSON marshals [tx] as JSON with human readable addresses.
+// InitCtx must be called before marshalling [tx].
+func (tx *UnsignedRemoveSubnetValidatorTx) MarshalJSON() ([]byte, error) {
+	fields := tx.BaseTx.jsonFields(txTypeName(tx))
+	fields["nodeID"] = tx.NodeID.PrefixedString(constants.NodeIDPrefix)
+	fields["subnet"] = tx.Subnet
+	fields["subnetAuthorization"] = tx.SubnetAuth
+	return json.Marshal(fields)
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *UnsignedRemoveSubnetValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.syntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return errBadSubnetID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(tx.SubnetAuth); err != nil {
+		return err
+	}
+
+	// cache that this is valid
+	tx.syntacticallyVerified = true
+	return nil
+}
+
+// Attempts to verify this transaction with the provided state.
+func (tx *UnsignedRemoveSubnetValidatorTx) SemanticVerify(vm *VM, parentState MutableState, stx *Tx) error {
+	vs := newVersionedState(
+		parentState,
+		parentState.CurrentStakerChainState(),
+		parentState.PendingStakerChainState(),
+	)
+	_, err := tx.Execute(vm, vs, stx)
+	return err
+}
+
+// Execute this transaction.
+func (tx *UnsignedRemoveSubnetValidatorTx) Execute(
+	vm *VM,
+	vs VersionedState,
+	stx *Tx,
+) (
+	func() error,
+	TxError,
+) {
+	// Verify the tx is well-formed
+	if err := tx.SyntacticVerify(vm.ctx); err != nil {
+		return nil, permError{err}
+	}
+	if len(stx.Creds) == 0 {
+		return nil, permError{errWrongNumberOfCredentials}
+	}
+
+	currentTimestamp := vs.GetTimestamp()
+	if currentTimestamp.Before(vm.ApricotPhase6Time) {
+		return nil, permError{
+			fmt.Errorf(
+				"%w: the chain timestamp (%d) is before the apricot phase 6 time (%d)",
+				errRemoveSubnetValidatorTxNotEnabled,
+				currentTimestamp.Unix(),
+				vm.ApricotPhase6Time.Unix(),
+			),
+		}
+	}
+
+	// The validator is removed from whichever of the current and pending
+	// validator sets it is in.
+	currentStakers := vs.CurrentStakerChainState()
+	pendingStakers := vs.PendingStakerChainState()
+	isCurrent := true
+	newCurrentStakers, err := currentStakers.DeleteSubnetValidator(tx.NodeID, tx.Subnet)
+	newPendingStakers := pendingStakers
+	if err == database.ErrNotFound {
+		isCurrent = false
+		newCurrentStakers = currentStakers
+		newPendingStakers, err = pendingStakers.DeleteSubnetValidator(tx.NodeID, tx.Subnet)
+	}
+	if err != nil {
+		return nil, permError{
+			fmt.Errorf(
+				"%w: %s isn't validating subnet %s",
+				errNotSubnetValidator,
+				tx.NodeID.PrefixedString(constants.NodeIDPrefix),
+				tx.Subnet,
+			),
+		}
+	}
+
+	if vm.bootstrapped.GetValue() {
+		baseTxCredsLen := len(stx.Creds) - 1
+		baseTxCreds := stx.Creds[:baseTxCredsLen]
+		subnetCred := stx.Creds[baseTxCredsLen]
+
+		subnetIntf, _, err := vs.GetTx(tx.Subnet)
+		if err != nil {
+			if err == database.ErrNotFound {
+				return nil, permError{
+					fmt.Errorf("subnet %s doesn't exist", tx.Subnet),
+				}
+			}
+			return nil, tempError{
+				fmt.Errorf(
+					"couldn't find subnet %s with %w",
+					tx.Subnet,
+					err,
+				),
+			}
+		}
+
+		subnet, ok := subnetIntf.UnsignedTx.(*UnsignedCreateSubnetTx)
+		if !ok {
+			return nil, permError{
+				fmt.Errorf(
+					"%s is not a subnet",
+					tx.Subnet,
+				),
+			}
+		}
+
+		if err := vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnet.Owner); err != nil {
+			return nil, permError{err}
+		}
+
+		// Verify the flowcheck
+		if err := vm.semanticVerifySpend(vs, tx, tx.Ins, tx.Outs, baseTxCreds, vm.TxFee, vm.ctx.AVAXAssetID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Consume the UTXOS
+	consumeInputs(vs, tx.Ins)
+	// Produce the UTXOS
+	produceOutputs(vs, tx.ID(), vm.ctx.AVAXAssetID, tx.Outs)
+	// Remove the validator
+	vs.SetCurrentStakerChainState(newCurrentStakers)
+	vs.SetPendingStakerChainState(newPendingStakers)
+
+	if isCurrent {
+		// The subnet's validator set changes once this tx is accepted.
+		return func() error { return vm.updateValidators(false) }, nil
+	}
+	return vm.updateValidatorSetMetrics, nil
+}
+
+// Create a new transaction
+func (vm *VM) newRemoveSubnetValidatorTx(
+	nodeID ids.ShortID, // ID of the node to remove
+	subnetID ids.ID, // ID of the subnet the validator will be removed from
+	keys []*crypto.PrivateKeySECP256K1R, // Keys to use for removing the validator
+	changeAddr ids.ShortID, // Address to send change to, if there is any
+) (*Tx, error) {
+	ins, outs, _, signers, err := vm.stake(keys, 0, vm.TxFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	subnetAuth, subnetSigners, err := vm.authorize(vm.internalState, subnetID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't authorize tx's subnet restrictions: %w", err)
+	}
+	signers = append(signers, subnetSigners)
+
+	// Create the tx
+	utx := &UnsignedRemoveSubnetValidatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    vm.ctx.NetworkID,
+			BlockchainID: vm.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		NodeID:     nodeID,
+		Subnet:     subnetID,
+		SubnetAuth: subnetAuth,
+	}
+	tx := &Tx{UnsignedTx: utx}
+	if err := tx.Sign(Codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.SyntacticVerify(vm.ctx)
+}
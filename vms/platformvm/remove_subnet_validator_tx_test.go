@@ -0,0 +1,202 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+func TestRemoveSubnetValidatorTxSyntacticVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Case: tx is nil
+	var unsignedTx *UnsignedRemoveSubnetValidatorTx
+	assert.Error(unsignedTx.SyntacticVerify(vm.ctx))
+
+	// Case: valid tx
+	tx, err := vm.newRemoveSubnetValidatorTx(
+		keys[0].PublicKey().Address(),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	// Case: removing a primary network validator
+	utx := tx.UnsignedTx.(*UnsignedRemoveSubnetValidatorTx)
+	utx.Subnet = constants.PrimaryNetworkID
+	utx.syntacticallyVerified = false
+	assert.ErrorIs(utx.SyntacticVerify(vm.ctx), errBadSubnetID)
+}
+
+func TestRemoveSubnetValidatorTxExecute(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	subnetKeys := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+	currentNodeID := keys[0].PublicKey().Address()
+	pendingNodeID := keys[1].PublicKey().Address()
+
+	// Make [currentNodeID] a current validator of the subnet and
+	// [pendingNodeID] a pending validator of the subnet.
+	currentTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(defaultValidateStartTime.Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		currentNodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	pendingTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(defaultValidateStartTime.Add(defaultMinStakingDuration).Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		pendingNodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	vm.internalState.AddCurrentStaker(currentTx, 0)
+	vm.internalState.AddTx(currentTx, Committed)
+	vm.internalState.AddPendingStaker(pendingTx)
+	vm.internalState.AddTx(pendingTx, Committed)
+	assert.NoError(vm.internalState.Commit())
+	assert.NoError(vm.internalState.(*internalStateImpl).loadCurrentValidators())
+	assert.NoError(vm.internalState.(*internalStateImpl).loadPendingValidators())
+
+	newVS := func() VersionedState {
+		return newVersionedState(
+			vm.internalState,
+			vm.internalState.CurrentStakerChainState(),
+			vm.internalState.PendingStakerChainState(),
+		)
+	}
+
+	// Case: node isn't validating the subnet
+	tx, err := vm.newRemoveSubnetValidatorTx(keys[2].PublicKey().Address(), subnetID, subnetKeys, ids.ShortEmpty)
+	assert.NoError(err)
+	_, err = tx.UnsignedTx.(UnsignedDecisionTx).Execute(vm, newVS(), tx)
+	assert.ErrorIs(err, errNotSubnetValidator)
+
+	// Case: subnet authorization isn't signed by the subnet's control keys
+	tx, err = vm.newRemoveSubnetValidatorTx(currentNodeID, subnetID, subnetKeys, ids.ShortEmpty)
+	assert.NoError(err)
+	tx.Creds[len(tx.Creds)-1] = tx.Creds[0]
+	_, err = tx.UnsignedTx.(UnsignedDecisionTx).Execute(vm, newVS(), tx)
+	assert.Error(err)
+
+	// Case: remove both validators while executing the same block
+	vs := newVS()
+	removeCurrentTx, err := vm.newRemoveSubnetValidatorTx(currentNodeID, subnetID, subnetKeys, ids.ShortEmpty)
+	assert.NoError(err)
+	_, err = removeCurrentTx.UnsignedTx.(UnsignedDecisionTx).Execute(vm, vs, removeCurrentTx)
+	assert.NoError(err)
+	// Reserve the UTXOs consumed by [removeCurrentTx] so that
+	// [removePendingTx] pays its fee with different ones.
+	assert.NoError(vm.blockBuilder.AddUnverifiedTx(removeCurrentTx))
+	removePendingTx, err := vm.newRemoveSubnetValidatorTx(pendingNodeID, subnetID, subnetKeys, ids.ShortEmpty)
+	assert.NoError(err)
+	_, err = removePendingTx.UnsignedTx.(UnsignedDecisionTx).Execute(vm, vs, removePendingTx)
+	assert.NoError(err)
+
+	// The same validator can't be removed twice
+	_, err = removeCurrentTx.UnsignedTx.(UnsignedDecisionTx).Execute(vm, vs, removeCurrentTx)
+	assert.ErrorIs(err, errNotSubnetValidator)
+
+	vs.Apply(vm.internalState)
+	assert.NoError(vm.internalState.Commit())
+
+	// Both validators should have been removed from disk
+	assert.NoError(vm.internalState.(*internalStateImpl).loadCurrentValidators())
+	assert.NoError(vm.internalState.(*internalStateImpl).loadPendingValidators())
+
+	currentValidator, err := vm.internalState.CurrentStakerChainState().GetValidator(currentNodeID)
+	assert.NoError(err)
+	assert.NotContains(currentValidator.SubnetValidators(), subnetID)
+	subnetValidators, err := vm.internalState.CurrentStakerChainState().ValidatorSet(subnetID)
+	assert.NoError(err)
+	assert.False(subnetValidators.Contains(currentNodeID))
+
+	pendingValidator := vm.internalState.PendingStakerChainState().GetValidator(pendingNodeID)
+	assert.NotContains(pendingValidator.SubnetValidators(), subnetID)
+	for _, staker := range vm.internalState.PendingStakerChainState().Stakers() {
+		assert.NotEqual(pendingTx.ID(), staker.ID())
+	}
+}
+
+func TestRemoveSubnetValidatorTxApricotPhase6(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	subnetKeys := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+	nodeID := keys[0].PublicKey().Address()
+
+	addTx, err := vm.newAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(defaultValidateStartTime.Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		nodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	vm.internalState.AddCurrentStaker(addTx, 0)
+	vm.internalState.AddTx(addTx, Committed)
+	assert.NoError(vm.internalState.Commit())
+	assert.NoError(vm.internalState.(*internalStateImpl).loadCurrentValidators())
+
+	tx, err := vm.newRemoveSubnetValidatorTx(nodeID, subnetID, subnetKeys, ids.ShortEmpty)
+	assert.NoError(err)
+	execute := func() error {
+		vs := newVersionedState(
+			vm.internalState,
+			vm.internalState.CurrentStakerChainState(),
+			vm.internalState.PendingStakerChainState(),
+		)
+		_, err := tx.UnsignedTx.(UnsignedDecisionTx).Execute(vm, vs, tx)
+		return err
+	}
+
+	// Case: before the upgrade
+	chainTime := vm.internalState.GetTimestamp()
+	vm.ApricotPhase6Time = chainTime.Add(time.Second)
+	assert.ErrorIs(execute(), errRemoveSubnetValidatorTxNotEnabled)
+
+	// Case: at the upgrade
+	vm.ApricotPhase6Time = chainTime
+	assert.NoError(execute())
+}
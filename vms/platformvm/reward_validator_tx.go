@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -45,6 +46,14 @@ type UnsignedRewardValidatorTx struct {
 
 func (tx *UnsignedRewardValidatorTx) InitCtx(*snow.Context) {}
 
+// MarshalJSON marshals [tx] as JSON
+func (tx *UnsignedRewardValidatorTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type": txTypeName(tx),
+		"txID": tx.TxID,
+	})
+}
+
 func (tx *UnsignedRewardValidatorTx) InputIDs() ids.Set {
 	return nil
 }
@@ -280,6 +289,9 @@ func (tx *UnsignedRewardValidatorTx) Execute(
 
 			onCommitState.AddUTXO(utxo)
 			onCommitState.AddRewardUTXO(tx.TxID, utxo)
+			// The delegation fee is also reported as a reward of the
+			// validator's staking tx.
+			onCommitState.AddRewardUTXO(vdrTx.ID(), utxo)
 		}
 
 		nodeID = uStakerTx.Validator.ID()
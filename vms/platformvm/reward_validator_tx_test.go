@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
@@ -278,6 +279,114 @@ func TestRewardDelegatorTxExecuteOnCommit(t *testing.T) {
 
 	assert.Less(vdrReward, delReward, "the delegator's reward should be greater than the delegatee's because the delegatee's share is 25%")
 	assert.Equal(expectedReward, delReward+vdrReward, "expected total reward to be %d but is %d", expectedReward, delReward+vdrReward)
+
+	// The delegation fee should be reported as a reward of the validator
+	vdrRewardUTXOs, err := vm.internalState.GetRewardUTXOs(vdrTx.ID())
+	assert.NoError(err)
+	assert.Len(vdrRewardUTXOs, 1)
+	delRewardUTXOs, err := vm.internalState.GetRewardUTXOs(delTx.ID())
+	assert.NoError(err)
+	assert.Len(delRewardUTXOs, 2)
+	assert.Contains(delRewardUTXOs, vdrRewardUTXOs[0])
+}
+
+func TestRewardMultipleDelegatorsTxExecuteOnCommit(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+
+		vm.ctx.Lock.Unlock()
+	}()
+
+	vdrStartTime := uint64(defaultValidateStartTime.Unix()) + 1
+	vdrEndTime := uint64(defaultValidateStartTime.Add(2 * defaultMinStakingDuration).Unix())
+	vdrNodeID := ids.GenerateTestShortID()
+	vdrTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake, // stakeAmt
+		vdrStartTime,
+		vdrEndTime,
+		vdrNodeID,                 // node ID
+		ids.GenerateTestShortID(), // reward address
+		PercentDenominator/4,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // fee payer
+		ids.ShortEmpty,                          // change addr
+	)
+	assert.NoError(err)
+	vm.internalState.AddCurrentStaker(vdrTx, 0)
+	vm.internalState.AddTx(vdrTx, Committed)
+
+	// The delegators stop delegating one after the other
+	numDelegators := 3
+	delTxs := make([]*Tx, numDelegators)
+	for i := range delTxs {
+		delTx, err := vm.newAddDelegatorTx(
+			vm.MinDelegatorStake, // stakeAmt
+			vdrStartTime,
+			vdrEndTime-uint64(numDelegators-i),
+			vdrNodeID,                               // node ID
+			ids.GenerateTestShortID(),               // reward address
+			[]*crypto.PrivateKeySECP256K1R{keys[0]}, // fee payer
+			ids.ShortEmpty,                          // change addr
+		)
+		assert.NoError(err)
+		vm.internalState.AddCurrentStaker(delTx, 1000000)
+		vm.internalState.AddTx(delTx, Committed)
+		delTxs[i] = delTx
+	}
+	err = vm.internalState.Commit()
+	assert.NoError(err)
+	err = vm.internalState.(*internalStateImpl).loadCurrentValidators()
+	assert.NoError(err)
+
+	feeUTXOIDs := []ids.ID(nil)
+	for i, delTx := range delTxs {
+		vm.internalState.SetTimestamp(time.Unix(int64(vdrEndTime-uint64(numDelegators-i)), 0))
+		err = vm.internalState.Commit()
+		assert.NoError(err)
+
+		tx, err := vm.newRewardValidatorTx(delTx.ID())
+		assert.NoError(err)
+
+		onCommitState, _, _, _, err := tx.UnsignedTx.(UnsignedProposalTx).Execute(vm, vm.internalState, tx)
+		assert.NoError(err)
+
+		// The delegator's reward UTXOs are its own reward and the
+		// delegation fee
+		delRewardUTXOs, err := onCommitState.GetRewardUTXOs(delTx.ID())
+		assert.NoError(err)
+		assert.Len(delRewardUTXOs, 2)
+		feeUTXOIDs = append(feeUTXOIDs, delRewardUTXOs[1].InputID())
+
+		// Before and after being committed, the validator's reward UTXOs
+		// include the delegation fees of every delegator rewarded so far
+		vdrRewardUTXOs, err := onCommitState.GetRewardUTXOs(vdrTx.ID())
+		assert.NoError(err)
+		assert.ElementsMatch(feeUTXOIDs, utxoIDs(vdrRewardUTXOs))
+
+		onCommitState.Apply(vm.internalState)
+		vdrRewardUTXOs, err = vm.internalState.GetRewardUTXOs(vdrTx.ID())
+		assert.NoError(err)
+		assert.ElementsMatch(feeUTXOIDs, utxoIDs(vdrRewardUTXOs))
+
+		err = vm.internalState.Commit()
+		assert.NoError(err)
+		vdrRewardUTXOs, err = vm.internalState.GetRewardUTXOs(vdrTx.ID())
+		assert.NoError(err)
+		assert.ElementsMatch(feeUTXOIDs, utxoIDs(vdrRewardUTXOs))
+	}
+	assert.Len(feeUTXOIDs, numDelegators)
+}
+
+func utxoIDs(utxos []*avax.UTXO) []ids.ID {
+	utxoIDs := make([]ids.ID, len(utxos))
+	for i, utxo := range utxos {
+		utxoIDs[i] = utxo.InputID()
+	}
+	return utxoIDs
 }
 
 func TestRewardDelegatorTxExecuteOnAbort(t *testing.T) {
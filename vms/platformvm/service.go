@@ -4,12 +4,16 @@
 package platformvm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
+	stdjson "encoding/json"
+
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -21,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
@@ -44,6 +49,8 @@ var (
 	errNoFunds                    = errors.New("no spendable funds were found")
 	errNoSubnetID                 = errors.New("argument 'subnetID' not provided")
 	errNoRewardAddress            = errors.New("argument 'rewardAddress' not provided")
+	errRewardAddressConflict      = errors.New("arguments 'rewardAddress' and 'rewardAddresses' can't both be provided")
+	errInvalidRewardThreshold     = errors.New("argument 'rewardThreshold' exceeds the number of reward addresses")
 	errInvalidDelegationRate      = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
 	errNoAddresses                = errors.New("no addresses provided")
 	errNoKeys                     = errors.New("user has no keys or funds")
@@ -54,14 +61,16 @@ var (
 	errTotalOverflow              = errors.New("overflow while calculating total balance")
 	errUnlockedOverflow           = errors.New("overflow while calculating unlocked balance")
 	errLockedOverflow             = errors.New("overflow while calculating locked balance")
-	errNotStakeableOverflow       = errors.New("overflow while calculating locked not stakeable balance")
 	errLockedNotStakeableOverflow = errors.New("overflow while calculating locked not stakeable balance")
 	errUnlockedStakeableOverflow  = errors.New("overflow while calculating unlocked stakeable balance")
 	errNamedSubnetCantBePrimary   = errors.New("subnet validator attempts to validate primary network")
 	errNoAmount                   = errors.New("argument 'amount' must be > 0")
+	errWrongTargetChain           = errors.New("argument 'to' isn't on argument 'targetChain'")
 	errMissingName                = errors.New("argument 'name' not given")
 	errMissingVMID                = errors.New("argument 'vmID' not given")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errBlockHeightNotFound        = errors.New("no block has been accepted at the requested height")
+	errBlockNotFound              = errors.New("block not found")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -181,8 +190,16 @@ func (service *Service) ImportKey(r *http.Request, args *ImportKeyArgs, reply *a
  ******************************************************
  */
 
-type GetBalanceResponse struct {
-	// Balance, in nAVAX, of the address
+// GetBalanceRequest is the request for GetBalance
+type GetBalanceRequest struct {
+	// Deprecated: use Addresses instead. If provided, it is treated as one
+	// more element of Addresses.
+	Address   string   `json:"address,omitempty"`
+	Addresses []string `json:"addresses"`
+}
+
+// APIBalance is the balance, in nAVAX, of a set of UTXOs
+type APIBalance struct {
 	Balance            json.Uint64    `json:"balance"`
 	Unlocked           json.Uint64    `json:"unlocked"`
 	LockedStakeable    json.Uint64    `json:"lockedStakeable"`
@@ -190,49 +207,124 @@ type GetBalanceResponse struct {
 	UTXOIDs            []*avax.UTXOID `json:"utxoIDs"`
 }
 
-// GetBalance gets the balance of an address
-func (service *Service) GetBalance(_ *http.Request, args *api.JSONAddress, response *GetBalanceResponse) error {
-	service.vm.ctx.Log.Debug("Platform: GetBalance called for address %s", args.Address)
+// GetBalanceResponse is the response from GetBalance
+type GetBalanceResponse struct {
+	// Combined balance of the requested addresses. A UTXO owned by more than
+	// one of the addresses is only counted once.
+	APIBalance
+	// Balance of each requested address, keyed by the formatted address. A
+	// UTXO is counted towards each of the requested addresses that own it.
+	Addresses map[string]*APIBalance `json:"addresses"`
+}
+
+// utxoBalance accumulates the balance of a set of UTXOs
+type utxoBalance struct {
+	unlocked           uint64
+	lockedStakeable    uint64
+	lockedNotStakeable uint64
+	utxoIDs            []*avax.UTXOID
+}
 
-	// Parse to address
-	addr, err := service.vm.ParseLocalAddress(args.Address)
+func (b *utxoBalance) addUnlocked(amount uint64, utxoID *avax.UTXOID) error {
+	newBalance, err := math.Add64(b.unlocked, amount)
 	if err != nil {
-		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+		return errUnlockedOverflow
 	}
+	b.unlocked = newBalance
+	b.utxoIDs = append(b.utxoIDs, utxoID)
+	return nil
+}
 
-	addrs := ids.ShortSet{}
-	addrs.Add(addr)
-	utxos, err := service.vm.getAllUTXOs(addrs)
+func (b *utxoBalance) addLockedStakeable(amount uint64, utxoID *avax.UTXOID) error {
+	newBalance, err := math.Add64(b.lockedStakeable, amount)
+	if err != nil {
+		return errUnlockedStakeableOverflow
+	}
+	b.lockedStakeable = newBalance
+	b.utxoIDs = append(b.utxoIDs, utxoID)
+	return nil
+}
+
+func (b *utxoBalance) addLockedNotStakeable(amount uint64, utxoID *avax.UTXOID) error {
+	newBalance, err := math.Add64(b.lockedNotStakeable, amount)
+	if err != nil {
+		return errLockedNotStakeableOverflow
+	}
+	b.lockedNotStakeable = newBalance
+	b.utxoIDs = append(b.utxoIDs, utxoID)
+	return nil
+}
+
+func (b *utxoBalance) format() (*APIBalance, error) {
+	lockedBalance, err := math.Add64(b.lockedStakeable, b.lockedNotStakeable)
+	if err != nil {
+		return nil, errLockedOverflow
+	}
+	balance, err := math.Add64(b.unlocked, lockedBalance)
 	if err != nil {
-		addr, err2 := service.vm.FormatLocalAddress(addr)
-		if err2 != nil {
-			return fmt.Errorf("problem formatting address: %w", err2)
+		return nil, errTotalOverflow
+	}
+	return &APIBalance{
+		Balance:            json.Uint64(balance),
+		Unlocked:           json.Uint64(b.unlocked),
+		LockedStakeable:    json.Uint64(b.lockedStakeable),
+		LockedNotStakeable: json.Uint64(b.lockedNotStakeable),
+		UTXOIDs:            b.utxoIDs,
+	}, nil
+}
+
+// GetBalance gets the combined balance of a set of addresses, as well as the
+// balance of each of them. Locktimes are compared against the timestamp of the
+// last accepted block.
+func (service *Service) GetBalance(_ *http.Request, args *GetBalanceRequest, response *GetBalanceResponse) error {
+	addrStrs := args.Addresses
+	if args.Address != "" {
+		addrStrs = append(addrStrs, args.Address)
+	}
+	service.vm.ctx.Log.Debug("Platform: GetBalance called for addresses %v", addrStrs)
+
+	if len(addrStrs) == 0 {
+		return errNoAddresses
+	}
+
+	// Parse the addresses
+	addrs := ids.NewShortSet(len(addrStrs))
+	for _, addrStr := range addrStrs {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
 		}
-		return fmt.Errorf("couldn't get UTXO set of %s: %w", addr, err)
+		addrs.Add(addr)
+	}
+
+	// Fetch the UTXOs of all the addresses at once so that UTXOs owned by many
+	// of them are only read once.
+	utxos, err := service.vm.getAllUTXOs(addrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get UTXO set: %w", err)
 	}
 
-	currentTime := service.vm.clock.Unix()
+	currentTime := uint64(service.vm.internalState.GetTimestamp().Unix())
 
-	unlocked := uint64(0)
-	lockedStakeable := uint64(0)
-	lockedNotStakeable := uint64(0)
+	total := &utxoBalance{}
+	addrBalances := make(map[ids.ShortID]*utxoBalance, addrs.Len())
+	for addr := range addrs {
+		addrBalances[addr] = &utxoBalance{}
+	}
 
 utxoFor:
 	for _, utxo := range utxos {
+		var (
+			owners *secp256k1fx.OutputOwners
+			add    func(b *utxoBalance, amount uint64, utxoID *avax.UTXOID) error
+		)
 		switch out := utxo.Out.(type) {
 		case *secp256k1fx.TransferOutput:
+			owners = &out.OutputOwners
 			if out.Locktime <= currentTime {
-				newBalance, err := math.Add64(unlocked, out.Amount())
-				if err != nil {
-					return errUnlockedOverflow
-				}
-				unlocked = newBalance
+				add = (*utxoBalance).addUnlocked
 			} else {
-				newBalance, err := math.Add64(lockedNotStakeable, out.Amount())
-				if err != nil {
-					return errNotStakeableOverflow
-				}
-				lockedNotStakeable = newBalance
+				add = (*utxoBalance).addLockedNotStakeable
 			}
 		case *StakeableLockOut:
 			innerOut, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
@@ -242,44 +334,46 @@ utxoFor:
 					out.TransferableOut)
 				continue utxoFor
 			case innerOut.Locktime > currentTime:
-				newBalance, err := math.Add64(lockedNotStakeable, out.Amount())
-				if err != nil {
-					return errLockedNotStakeableOverflow
-				}
-				lockedNotStakeable = newBalance
+				add = (*utxoBalance).addLockedNotStakeable
 			case out.Locktime <= currentTime:
-				newBalance, err := math.Add64(unlocked, out.Amount())
-				if err != nil {
-					return errUnlockedOverflow
-				}
-				unlocked = newBalance
+				add = (*utxoBalance).addUnlocked
 			default:
-				newBalance, err := math.Add64(lockedStakeable, out.Amount())
-				if err != nil {
-					return errUnlockedStakeableOverflow
-				}
-				lockedStakeable = newBalance
+				add = (*utxoBalance).addLockedStakeable
 			}
+			owners = &innerOut.OutputOwners
 		default:
 			continue utxoFor
 		}
 
-		response.UTXOIDs = append(response.UTXOIDs, &utxo.UTXOID)
+		amount := utxo.Out.(avax.TransferableOut).Amount()
+		if err := add(total, amount, &utxo.UTXOID); err != nil {
+			return err
+		}
+		for _, addr := range owners.Addrs {
+			if addrBalance, ok := addrBalances[addr]; ok {
+				if err := add(addrBalance, amount, &utxo.UTXOID); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
-	lockedBalance, err := math.Add64(lockedStakeable, lockedNotStakeable)
+	totalBalance, err := total.format()
 	if err != nil {
-		return errLockedOverflow
+		return err
 	}
-	balance, err := math.Add64(unlocked, lockedBalance)
-	if err != nil {
-		return errTotalOverflow
+	response.APIBalance = *totalBalance
+	response.Addresses = make(map[string]*APIBalance, len(addrBalances))
+	for addr, addrBalance := range addrBalances {
+		addrStr, err := service.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return fmt.Errorf("problem formatting address: %w", err)
+		}
+		response.Addresses[addrStr], err = addrBalance.format()
+		if err != nil {
+			return err
+		}
 	}
-
-	response.Balance = json.Uint64(balance)
-	response.Unlocked = json.Uint64(unlocked)
-	response.LockedStakeable = json.Uint64(lockedStakeable)
-	response.LockedNotStakeable = json.Uint64(lockedNotStakeable)
 	return nil
 }
 
@@ -357,9 +451,12 @@ type Index struct {
 // [StartIndex] defines where to start fetching UTXOs (for pagination.)
 // UTXOs fetched are from addresses equal to or greater than [StartIndex.Address]
 // For address [StartIndex.Address], only UTXOs with IDs greater than [StartIndex.UTXO] will be returned.
+// That is, [StartIndex] is exclusive, and it remains valid if the UTXO it
+// references was spent since it was returned.
 // If [StartIndex] is omitted, gets all UTXOs.
-// If GetUTXOs is called multiple times, with our without [StartIndex], it is not guaranteed
-// that returned UTXOs are unique. That is, the same UTXO may appear in the response of multiple calls.
+// When paginating through native UTXOs with [EndIndex], each UTXO that exists
+// for the duration of the pagination is returned exactly once. For atomic
+// UTXOs, the same UTXO may appear in the response of multiple calls.
 // [Encoding] defines the encoding format to use for the returned UTXOs. Can be either "cb58" or "hex"
 type GetUTXOsArgs struct {
 	Addresses   []string            `json:"addresses"`
@@ -449,6 +546,11 @@ func (service *Service) GetUTXOs(_ *http.Request, args *GetUTXOsArgs, response *
 			startUTXO,
 			int(args.Limit),
 		)
+		// Shared memory includes the UTXO at the start index, which was
+		// already returned by the previous call.
+		if err == nil && startUTXO != ids.Empty && len(utxos) > 0 && utxos[0].InputID() == startUTXO {
+			utxos = utxos[1:]
+		}
 	}
 	if err != nil {
 		return fmt.Errorf("problem retrieving UTXOs: %w", err)
@@ -501,6 +603,12 @@ type GetSubnetsArgs struct {
 	// IDs of the subnets to retrieve information about
 	// If omitted, gets all subnets
 	IDs []ids.ID `json:"ids"`
+
+	// When getting all subnets, they are returned in order of their IDs,
+	// starting from [StartID]. If [Limit] is non-zero, at most [Limit] subnets
+	// are returned.
+	Limit   json.Uint32 `json:"limit"`
+	StartID ids.ID      `json:"startID"`
 }
 
 // GetSubnetsResponse is the response from calling GetSubnets
@@ -508,6 +616,11 @@ type GetSubnetsResponse struct {
 	// Each element is a subnet that exists
 	// Null if there are no subnets other than the primary network
 	Subnets []APISubnet `json:"subnets"`
+
+	// ID of the next subnet, if the response was truncated. Used for
+	// pagination. To get the rest of the subnets, call GetSubnets again and
+	// set [StartID] to this value.
+	NextID *ids.ID `json:"nextID,omitempty"`
 }
 
 // GetSubnets returns the subnets whose ID are in [args.IDs]
@@ -522,30 +635,32 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 			return fmt.Errorf("error getting subnets from database: %w", err)
 		}
 
-		response.Subnets = make([]APISubnet, len(subnets)+1)
-		for i, subnet := range subnets {
-			unsignedTx := subnet.UnsignedTx.(*UnsignedCreateSubnetTx)
-			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
-			controlAddrs := []string{}
-			for _, controlKeyID := range owner.Addrs {
-				addr, err := service.vm.FormatLocalAddress(controlKeyID)
-				if err != nil {
-					return fmt.Errorf("problem formatting address: %w", err)
-				}
-				controlAddrs = append(controlAddrs, addr)
+		apiSubnets := make([]APISubnet, 0, len(subnets)+1)
+		// Include primary network
+		if primaryNetworkID := constants.PrimaryNetworkID; bytes.Compare(primaryNetworkID[:], args.StartID[:]) >= 0 {
+			apiSubnets = append(apiSubnets, primaryNetworkAPISubnet())
+		}
+		for _, subnet := range subnets {
+			subnetID := subnet.ID()
+			if bytes.Compare(subnetID[:], args.StartID[:]) < 0 {
+				continue
 			}
-			response.Subnets[i] = APISubnet{
-				ID:          subnet.ID(),
-				ControlKeys: controlAddrs,
-				Threshold:   json.Uint32(owner.Threshold),
+			apiSubnet, err := service.toAPISubnet(subnet)
+			if err != nil {
+				return err
 			}
+			apiSubnets = append(apiSubnets, apiSubnet)
 		}
-		// Include primary network
-		response.Subnets[len(subnets)] = APISubnet{
-			ID:          constants.PrimaryNetworkID,
-			ControlKeys: []string{},
-			Threshold:   json.Uint32(0),
+		sort.Slice(apiSubnets, func(i, j int) bool {
+			return bytes.Compare(apiSubnets[i].ID[:], apiSubnets[j].ID[:]) < 0
+		})
+
+		if limit := int(args.Limit); limit > 0 && len(apiSubnets) > limit {
+			nextID := apiSubnets[limit].ID
+			response.NextID = &nextID
+			apiSubnets = apiSubnets[:limit]
 		}
+		response.Subnets = apiSubnets
 		return nil
 	}
 
@@ -557,13 +672,7 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 		subnetSet.Add(subnetID)
 
 		if subnetID == constants.PrimaryNetworkID {
-			response.Subnets = append(response.Subnets,
-				APISubnet{
-					ID:          constants.PrimaryNetworkID,
-					ControlKeys: []string{},
-					Threshold:   json.Uint32(0),
-				},
-			)
+			response.Subnets = append(response.Subnets, primaryNetworkAPISubnet())
 			continue
 		}
 
@@ -575,33 +684,50 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 			return err
 		}
 
-		subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
-		if !ok {
-			return errWrongTxType
-		}
-		owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
-		if !ok {
-			return errUnknownOwners
+		apiSubnet, err := service.toAPISubnet(subnetTx)
+		if err != nil {
+			return err
 		}
+		response.Subnets = append(response.Subnets, apiSubnet)
+	}
+	return nil
+}
 
-		controlAddrs := make([]string, len(owner.Addrs))
-		for i, controlKeyID := range owner.Addrs {
-			addr, err := service.vm.FormatLocalAddress(controlKeyID)
-			if err != nil {
-				return fmt.Errorf("problem formatting address: %w", err)
-			}
-			controlAddrs[i] = addr
+// toAPISubnet returns the API representation of the subnet created by
+// [subnetTx].
+func (service *Service) toAPISubnet(subnetTx *Tx) (APISubnet, error) {
+	subnet, ok := subnetTx.UnsignedTx.(*UnsignedCreateSubnetTx)
+	if !ok {
+		return APISubnet{}, errWrongTxType
+	}
+	owner, ok := subnet.Owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return APISubnet{}, errUnknownOwners
+	}
+
+	controlAddrs := make([]string, len(owner.Addrs))
+	for i, controlKeyID := range owner.Addrs {
+		addr, err := service.vm.FormatLocalAddress(controlKeyID)
+		if err != nil {
+			return APISubnet{}, fmt.Errorf("problem formatting address: %w", err)
 		}
+		controlAddrs[i] = addr
+	}
+	return APISubnet{
+		ID:          subnetTx.ID(),
+		ControlKeys: controlAddrs,
+		Threshold:   json.Uint32(owner.Threshold),
+	}, nil
+}
 
-		response.Subnets = append(response.Subnets,
-			APISubnet{
-				ID:          subnet.ID(),
-				ControlKeys: controlAddrs,
-				Threshold:   json.Uint32(owner.Threshold),
-			},
-		)
+// primaryNetworkAPISubnet returns the API representation of the primary
+// network, which is permissionless and therefore has no control keys.
+func primaryNetworkAPISubnet() APISubnet {
+	return APISubnet{
+		ID:          constants.PrimaryNetworkID,
+		ControlKeys: []string{},
+		Threshold:   json.Uint32(0),
 	}
-	return nil
 }
 
 // GetStakingAssetIDArgs are the arguments to GetStakingAssetID
@@ -644,12 +770,26 @@ type GetCurrentValidatorsArgs struct {
 	// some nodeIDs are not currently validators, they
 	// will be omitted from the response.
 	NodeIDs []string `json:"nodeIDs"`
+	// If true, each primary network validator in the response lists the
+	// delegators currently delegating to it.
+	IncludeDelegators bool `json:"includeDelegators"`
+	// If [Limit] or [StartNodeID] is set, validators are returned in order of
+	// their node IDs, starting from [StartNodeID]. If [Limit] is non-zero, at
+	// most [Limit] validators are returned. Otherwise, validators are returned
+	// in order of increasing stop time.
+	Limit       json.Uint32 `json:"limit"`
+	StartNodeID string      `json:"startNodeID"`
 }
 
 // GetCurrentValidatorsReply are the results from calling GetCurrentValidators.
-// Each validator contains a list of delegators to itself.
+// Each primary network validator contains the number and total weight of the
+// delegations to itself, and optionally the list of delegators.
 type GetCurrentValidatorsReply struct {
 	Validators []interface{} `json:"validators"`
+	// Node ID of the next validator, if the response was truncated. Used for
+	// pagination. To get the rest of the validators, call GetCurrentValidators
+	// again and set [StartNodeID] to this value.
+	NextNodeID *string `json:"nextNodeID,omitempty"`
 }
 
 // GetCurrentValidators returns current validators and delegators
@@ -658,9 +798,6 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 
 	reply.Validators = []interface{}{}
 
-	// Validator's node ID as string --> Delegators to them
-	vdrToDelegators := map[string][]APIPrimaryDelegator{}
-
 	// Create set of nodeIDs
 	nodeIDs := ids.ShortSet{}
 	for _, nodeID := range args.NodeIDs {
@@ -672,22 +809,106 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 	}
 	includeAllNodes := nodeIDs.Len() == 0
 
-	currentValidators := service.vm.internalState.CurrentStakerChainState()
-
-	for _, tx := range currentValidators.Stakers() { // Iterates in order of increasing stop time
-		_, reward, err := currentValidators.GetStaker(tx.ID())
+	paginate := args.Limit > 0 || len(args.StartNodeID) > 0
+	startNodeID := ids.ShortEmpty
+	if len(args.StartNodeID) > 0 {
+		var err error
+		startNodeID, err = ids.ShortFromPrefixedString(args.StartNodeID, constants.NodeIDPrefix)
 		if err != nil {
-			return err
+			return fmt.Errorf("couldn't parse startNodeID: %w", err)
 		}
+	}
+
+	currentValidators := service.vm.internalState.CurrentStakerChainState()
+	stakers := currentValidators.Stakers() // Iterates in order of increasing stop time
+
+	// Find the validators that will be returned before looking at any
+	// delegations, so that only the delegations to them are aggregated.
+	type validatorTx struct {
+		nodeID ids.ShortID
+		tx     *Tx
+	}
+	vdrTxs := []validatorTx{}
+	for _, tx := range stakers {
+		var nodeID ids.ShortID
 		switch staker := tx.UnsignedTx.(type) {
 		case *UnsignedAddDelegatorTx:
+			continue
+		case *UnsignedAddValidatorTx:
 			if args.SubnetID != constants.PrimaryNetworkID {
 				continue
 			}
-			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
+			nodeID = staker.Validator.ID()
+		case *UnsignedAddSubnetValidatorTx:
+			if args.SubnetID != staker.Validator.Subnet {
+				continue
+			}
+			nodeID = staker.Validator.ID()
+		default:
+			return fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
+		}
+		if !includeAllNodes && !nodeIDs.Contains(nodeID) {
+			continue
+		}
+		if paginate && bytes.Compare(nodeID[:], startNodeID[:]) < 0 {
+			continue
+		}
+		vdrTxs = append(vdrTxs, validatorTx{
+			nodeID: nodeID,
+			tx:     tx,
+		})
+	}
+
+	if paginate {
+		sort.Slice(vdrTxs, func(i, j int) bool {
+			return bytes.Compare(vdrTxs[i].nodeID[:], vdrTxs[j].nodeID[:]) < 0
+		})
+		if limit := int(args.Limit); limit > 0 && len(vdrTxs) > limit {
+			nextNodeID := vdrTxs[limit].nodeID.PrefixedString(constants.NodeIDPrefix)
+			reply.NextNodeID = &nextNodeID
+			vdrTxs = vdrTxs[:limit]
+		}
+	}
+
+	pageNodeIDs := ids.NewShortSet(len(vdrTxs))
+	for _, vdrTx := range vdrTxs {
+		pageNodeIDs.Add(vdrTx.nodeID)
+	}
+
+	// Validator's node ID --> Delegators to them
+	vdrToDelegators := map[ids.ShortID][]APIPrimaryDelegator{}
+	// Validator's node ID --> Number of delegators to them
+	vdrToDelegatorCount := map[ids.ShortID]uint64{}
+	// Validator's node ID --> Total weight delegated to them
+	vdrToDelegatorWeight := map[ids.ShortID]uint64{}
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		for _, tx := range stakers {
+			staker, ok := tx.UnsignedTx.(*UnsignedAddDelegatorTx)
+			if !ok {
+				continue
+			}
+			nodeID := staker.Validator.ID()
+			if !pageNodeIDs.Contains(nodeID) {
+				continue
+			}
+
+			delegatorWeight, err := math.Add64(vdrToDelegatorWeight[nodeID], staker.Validator.Weight())
+			if err != nil {
+				return err
+			}
+			vdrToDelegatorWeight[nodeID] = delegatorWeight
+			vdrToDelegatorCount[nodeID]++
+
+			if !args.IncludeDelegators {
 				continue
 			}
 
+			_, reward, err := currentValidators.GetStaker(tx.ID())
+			if err != nil {
+				return err
+			}
+
 			weight := json.Uint64(staker.Validator.Weight())
 
 			var rewardOwner *APIOwner
@@ -713,21 +934,25 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 					StartTime:   json.Uint64(staker.StartTime().Unix()),
 					EndTime:     json.Uint64(staker.EndTime().Unix()),
 					StakeAmount: &weight,
-					NodeID:      staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+					NodeID:      nodeID.PrefixedString(constants.NodeIDPrefix),
 				},
 				RewardOwner:     rewardOwner,
 				PotentialReward: &potentialReward,
 			}
-			vdrToDelegators[delegator.NodeID] = append(vdrToDelegators[delegator.NodeID], delegator)
+			vdrToDelegators[nodeID] = append(vdrToDelegators[nodeID], delegator)
+		}
+	}
+
+	for _, vdrTx := range vdrTxs {
+		tx := vdrTx.tx
+		nodeID := vdrTx.nodeID
+		switch staker := tx.UnsignedTx.(type) {
 		case *UnsignedAddValidatorTx:
-			if args.SubnetID != constants.PrimaryNetworkID {
-				continue
-			}
-			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
-				continue
+			_, reward, err := currentValidators.GetStaker(tx.ID())
+			if err != nil {
+				return err
 			}
 
-			nodeID := staker.Validator.ID()
 			startTime := staker.StartTime()
 			weight := json.Uint64(staker.Validator.Weight())
 			potentialReward := json.Uint64(reward)
@@ -756,6 +981,8 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 				}
 			}
 
+			delegatorCount := json.Uint64(vdrToDelegatorCount[nodeID])
+			delegatorWeight := json.Uint64(vdrToDelegatorWeight[nodeID])
 			reply.Validators = append(reply.Validators, APIPrimaryValidator{
 				APIStaker: APIStaker{
 					TxID:        tx.ID(),
@@ -769,37 +996,20 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 				PotentialReward: &potentialReward,
 				RewardOwner:     rewardOwner,
 				DelegationFee:   delegationFee,
+				DelegatorCount:  &delegatorCount,
+				DelegatorWeight: &delegatorWeight,
+				Delegators:      vdrToDelegators[nodeID],
 			})
 		case *UnsignedAddSubnetValidatorTx:
-			if args.SubnetID != staker.Validator.Subnet {
-				continue
-			}
-			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
-				continue
-			}
-
 			weight := json.Uint64(staker.Validator.Weight())
 			reply.Validators = append(reply.Validators, APIStaker{
 				TxID:      tx.ID(),
-				NodeID:    staker.Validator.ID().PrefixedString(constants.NodeIDPrefix),
+				NodeID:    nodeID.PrefixedString(constants.NodeIDPrefix),
 				StartTime: json.Uint64(staker.StartTime().Unix()),
 				EndTime:   json.Uint64(staker.EndTime().Unix()),
 				Weight:    &weight,
 			})
-		default:
-			return fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
-		}
-	}
-
-	for i, vdrIntf := range reply.Validators {
-		vdr, ok := vdrIntf.(APIPrimaryValidator)
-		if !ok {
-			continue
 		}
-		if delegators, ok := vdrToDelegators[vdr.NodeID]; ok {
-			vdr.Delegators = delegators
-		}
-		reply.Validators[i] = vdr
 	}
 
 	return nil
@@ -909,16 +1119,109 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 	return nil
 }
 
+// GetCurrentSupplyArgs are the arguments for calling GetCurrentSupply
+type GetCurrentSupplyArgs struct {
+	// If provided, the supply after the accepted block at this height was
+	// applied is returned. Otherwise, the latest supply is returned.
+	Height *json.Uint64 `json:"height,omitempty"`
+}
+
 // GetCurrentSupplyReply are the results from calling GetCurrentSupply
 type GetCurrentSupplyReply struct {
 	Supply json.Uint64 `json:"supply"`
 }
 
 // GetCurrentSupply returns an upper bound on the supply of AVAX in the system
-func (service *Service) GetCurrentSupply(_ *http.Request, _ *struct{}, reply *GetCurrentSupplyReply) error {
+func (service *Service) GetCurrentSupply(_ *http.Request, args *GetCurrentSupplyArgs, reply *GetCurrentSupplyReply) error {
 	service.vm.ctx.Log.Debug("Platform: GetCurrentSupply called")
 
-	reply.Supply = json.Uint64(service.vm.internalState.GetCurrentSupply())
+	if args.Height == nil {
+		reply.Supply = json.Uint64(service.vm.internalState.GetCurrentSupply())
+		return nil
+	}
+
+	height := uint64(*args.Height)
+	if _, err := service.vm.internalState.GetBlockIDAtHeight(height); err == database.ErrNotFound {
+		return fmt.Errorf("%w: %d", errBlockHeightNotFound, height)
+	} else if err != nil {
+		return fmt.Errorf("couldn't get block ID at height %d: %w", height, err)
+	}
+
+	supply, err := service.vm.internalState.GetCurrentSupplyAtHeight(height)
+	if err != nil {
+		return fmt.Errorf("couldn't get current supply at height %d: %w", height, err)
+	}
+	reply.Supply = json.Uint64(supply)
+	return nil
+}
+
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// Amount, in nAVAX, that would be staked
+	Amount json.Uint64 `json:"amount"`
+	// Unix time the staking period would start. If omitted, defaults to the
+	// earliest time a staker added now could start.
+	StartTime json.Uint64 `json:"startTime"`
+	// Unix time the staking period would end
+	EndTime json.Uint64 `json:"endTime"`
+}
+
+// EstimateRewardReply are the results from calling EstimateReward
+type EstimateRewardReply struct {
+	// Reward, in nAVAX, the staker would receive
+	Reward json.Uint64 `json:"reward"`
+	// Reward as an annualized percentage of the staked amount
+	AnnualizedPercentage json.Float64 `json:"annualizedPercentage"`
+}
+
+// EstimateReward returns the reward a staker of [args.Amount] nAVAX would
+// receive for staking from [args.StartTime] to [args.EndTime] given the
+// current supply.
+func (service *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	service.vm.ctx.Log.Debug("Platform: EstimateReward called")
+
+	now := service.vm.clock.Time()
+	minAddStakerUnix := json.Uint64(now.Add(minAddStakerDelay).Unix())
+	maxAddStakerUnix := json.Uint64(now.Add(maxFutureStartTime).Unix())
+
+	if args.StartTime == 0 {
+		args.StartTime = minAddStakerUnix
+	}
+
+	switch {
+	case args.StartTime < minAddStakerUnix:
+		return errStartTimeTooSoon
+	case args.StartTime > maxAddStakerUnix:
+		return errStartTimeTooLate
+	case args.EndTime <= args.StartTime:
+		return errStartAfterEndTime
+	case uint64(args.Amount) < service.vm.MinDelegatorStake:
+		return errWeightTooSmall
+	case uint64(args.Amount) > service.vm.MaxValidatorStake:
+		return errWeightTooLarge
+	}
+
+	duration := time.Duration(args.EndTime-args.StartTime) * time.Second
+	switch {
+	case duration < service.vm.MinStakeDuration:
+		return errStakeTooShort
+	case duration > service.vm.MaxStakeDuration:
+		return errStakeTooLong
+	}
+
+	// This is the same calculation performed when the staker is moved into
+	// the current staker set.
+	r := reward(
+		duration,
+		uint64(args.Amount),
+		service.vm.internalState.GetCurrentSupply(),
+		service.vm.StakeMintingPeriod,
+	)
+
+	reply.Reward = json.Uint64(r)
+	reply.AnnualizedPercentage = json.Float64(
+		100 * float64(r) / float64(args.Amount) * float64(365*24*time.Hour) / float64(duration),
+	)
 	return nil
 }
 
@@ -973,13 +1276,64 @@ func (service *Service) SampleValidators(_ *http.Request, args *SampleValidators
  ******************************************************
  */
 
+// APIRewardOwnerArgs describe who the staking reward, if applicable, will go
+// to. Either [RewardAddress] or [RewardAddresses] must be provided.
+type APIRewardOwnerArgs struct {
+	// The address the staking reward will go to
+	RewardAddress string `json:"rewardAddress"`
+	// The addresses that will be able to spend the staking reward
+	RewardAddresses []string `json:"rewardAddresses"`
+	// The number of [RewardAddresses] that must sign to spend the reward. If
+	// omitted, defaults to 1.
+	RewardThreshold json.Uint32 `json:"rewardThreshold"`
+	// The time before which the reward can't be spent
+	RewardLocktime json.Uint64 `json:"rewardLocktime"`
+}
+
+// parseRewardsOwner returns the output owners described by [args]
+func (service *Service) parseRewardsOwner(args *APIRewardOwnerArgs) (*secp256k1fx.OutputOwners, error) {
+	addrStrs := args.RewardAddresses
+	switch {
+	case args.RewardAddress != "" && len(addrStrs) > 0:
+		return nil, errRewardAddressConflict
+	case args.RewardAddress != "":
+		addrStrs = []string{args.RewardAddress}
+	case len(addrStrs) == 0:
+		return nil, errNoRewardAddress
+	}
+
+	addrs := ids.ShortSet{}
+	for _, addrStr := range addrStrs {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return nil, fmt.Errorf("problem while parsing reward address %s: %w", addrStr, err)
+		}
+		addrs.Add(addr)
+	}
+
+	threshold := uint32(args.RewardThreshold)
+	if threshold == 0 {
+		threshold = 1
+	}
+	if int(threshold) > addrs.Len() {
+		return nil, errInvalidRewardThreshold
+	}
+
+	owner := &secp256k1fx.OutputOwners{
+		Locktime:  uint64(args.RewardLocktime),
+		Threshold: threshold,
+		Addrs:     addrs.List(),
+	}
+	owner.Sort()
+	return owner, nil
+}
+
 // AddValidatorArgs are the arguments to AddValidator
 type AddValidatorArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
 	APIStaker
-	// The address the staking reward, if applicable, will go to
-	RewardAddress     string       `json:"rewardAddress"`
+	APIRewardOwnerArgs
 	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
 }
 
@@ -999,8 +1353,6 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 	}
 
 	switch {
-	case args.RewardAddress == "":
-		return errNoRewardAddress
 	case args.StartTime < minAddStakerUnix:
 		return errStartTimeTooSoon
 	case args.StartTime > maxAddStakerUnix:
@@ -1031,10 +1383,10 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 		fromAddrs.Add(addr)
 	}
 
-	// Parse the reward address
-	rewardAddress, err := service.vm.ParseLocalAddress(args.RewardAddress)
+	// Parse the reward owner
+	rewardsOwner, err := service.parseRewardsOwner(&args.APIRewardOwnerArgs)
 	if err != nil {
-		return fmt.Errorf("problem while parsing reward address: %w", err)
+		return err
 	}
 
 	// Get the keys controlled by the user
@@ -1076,12 +1428,12 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 	}
 
 	// Create the transaction
-	tx, err := service.vm.newAddValidatorTx(
+	tx, err := service.vm.newAddValidatorTxWithRewardsOwner(
 		args.weight(),                        // Stake amount
 		uint64(args.StartTime),               // Start time
 		uint64(args.EndTime),                 // End time
 		nodeID,                               // Node ID
-		rewardAddress,                        // Reward Address
+		rewardsOwner,                         // Reward owner
 		uint32(10000*args.DelegationFeeRate), // Shares
 		filteredPrivKeys,                     // Private keys
 		changeAddr,                           // Change address
@@ -1107,7 +1459,7 @@ type AddDelegatorArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
 	APIStaker
-	RewardAddress string `json:"rewardAddress"`
+	APIRewardOwnerArgs
 }
 
 // AddDelegator creates and signs and issues a transaction to add a delegator to
@@ -1126,8 +1478,6 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 	}
 
 	switch {
-	case args.RewardAddress == "":
-		return errNoRewardAddress
 	case args.StartTime < minAddStakerUnix:
 		return errStartTimeTooSoon
 	case args.StartTime > maxAddStakerUnix:
@@ -1146,10 +1496,10 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 		nodeID = nID
 	}
 
-	// Parse the reward address
-	rewardAddress, err := service.vm.ParseLocalAddress(args.RewardAddress)
+	// Parse the reward owner
+	rewardsOwner, err := service.parseRewardsOwner(&args.APIRewardOwnerArgs)
 	if err != nil {
-		return fmt.Errorf("problem parsing 'rewardAddress': %w", err)
+		return err
 	}
 
 	// Get the keys controlled by the user
@@ -1201,12 +1551,12 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 	}
 
 	// Create the transaction
-	tx, err := service.vm.newAddDelegatorTx(
+	tx, err := service.vm.newAddDelegatorTxWithRewardsOwner(
 		args.weight(),          // Stake amount
 		uint64(args.StartTime), // Start time
 		uint64(args.EndTime),   // End time
 		nodeID,                 // Node ID
-		rewardAddress,          // Reward Address
+		rewardsOwner,           // Reward owner
 		filteredPrivKeys,       // Private keys
 		changeAddr,             // Change address
 	)
@@ -1347,35 +1697,140 @@ func (service *Service) AddSubnetValidator(_ *http.Request, args *AddSubnetValid
 	return errs.Err
 }
 
-// CreateSubnetArgs are the arguments to CreateSubnet
-type CreateSubnetArgs struct {
+// RemoveSubnetValidatorArgs are the arguments to RemoveSubnetValidator
+type RemoveSubnetValidatorArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-	// The ID member of APISubnet is ignored
-	APISubnet
+	// ID of the node to remove from the subnet
+	NodeID string `json:"nodeID"`
+	// ID of the subnet to remove the node from
+	SubnetID string `json:"subnetID"`
 }
 
-// CreateSubnet creates and signs and issues a transaction to create a new
-// subnet
-func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, response *api.JSONTxIDChangeAddr) error {
-	service.vm.ctx.Log.Debug("Platform: CreateSubnet called")
+// RemoveSubnetValidator creates and signs and issues a transaction to remove a
+// validator from a subnet other than the primary network before its staking
+// period ends
+func (service *Service) RemoveSubnetValidator(_ *http.Request, args *RemoveSubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: RemoveSubnetValidator called")
 
-	// Parse the control keys
-	controlKeys := []ids.ShortID{}
-	for _, controlKey := range args.ControlKeys {
-		controlKeyID, err := service.vm.ParseLocalAddress(controlKey)
-		if err != nil {
-			return fmt.Errorf("problem parsing control key %q: %w", controlKey, err)
-		}
-		controlKeys = append(controlKeys, controlKeyID)
+	if args.SubnetID == "" {
+		return errNoSubnetID
 	}
 
-	// Get the keys controlled by the user
-	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	// Parse the node ID
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
 	if err != nil {
-		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+		return fmt.Errorf("error parsing nodeID: %q: %w", args.NodeID, err)
 	}
-	defer db.Close()
+
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return errNamedSubnetCantBePrimary
+	}
+
+	// Get the keys controlled by the user
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{db: db}
+	keys, err := user.getKeys()
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address.
+	if len(keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = service.vm.ParseLocalAddress(args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Parse the from addresses
+	fromAddrs := ids.ShortSet{}
+	for _, addrStr := range args.From {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+	}
+
+	// If fromAddrs given, only use those addrs to pay fee
+	filteredPrivKeys := []*crypto.PrivateKeySECP256K1R{}
+	if fromAddrs.Len() == 0 {
+		filteredPrivKeys = keys
+	} else {
+		for _, key := range keys {
+			if fromAddrs.Contains(key.PublicKey().Address()) {
+				filteredPrivKeys = append(filteredPrivKeys, key)
+			}
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.newRemoveSubnetValidatorTx(
+		nodeID,           // Node ID
+		subnetID,         // Subnet ID
+		filteredPrivKeys, // Keys
+		changeAddr,       // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.blockBuilder.AddUnverifiedTx(tx),
+		db.Close(),
+	)
+	return errs.Err
+}
+
+// CreateSubnetArgs are the arguments to CreateSubnet
+type CreateSubnetArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// The ID member of APISubnet is ignored
+	APISubnet
+}
+
+// CreateSubnet creates and signs and issues a transaction to create a new
+// subnet
+func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: CreateSubnet called")
+
+	// Parse the control keys
+	controlKeys := []ids.ShortID{}
+	for _, controlKey := range args.ControlKeys {
+		controlKeyID, err := service.vm.ParseLocalAddress(controlKey)
+		if err != nil {
+			return fmt.Errorf("problem parsing control key %q: %w", controlKey, err)
+		}
+		controlKeys = append(controlKeys, controlKeyID)
+	}
+
+	// Get the keys controlled by the user
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user %q: %w", args.Username, err)
+	}
+	defer db.Close()
 
 	user := user{db: db}
 	privKeys, err := user.getKeys()
@@ -1452,10 +1907,15 @@ type ExportAVAXArgs struct {
 	// ID of the address that will receive the AVAX. This address includes the
 	// chainID, which is used to determine what the destination chain is.
 	To string `json:"to"`
+
+	// Alias or ID of the chain the AVAX is sent to. If provided, it must be
+	// the chain [To] is on.
+	TargetChain string `json:"targetChain"`
 }
 
-// ExportAVAX exports AVAX from the P-Chain to the X-Chain
-// It must be imported on the X-Chain to complete the transfer
+// ExportAVAX exports AVAX from the P-Chain to another chain in the primary
+// network. It must be imported on the destination chain to complete the
+// transfer.
 func (service *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, response *api.JSONTxIDChangeAddr) error {
 	service.vm.ctx.Log.Debug("Platform: ExportAVAX called")
 
@@ -1469,6 +1929,20 @@ func (service *Service) ExportAVAX(_ *http.Request, args *ExportAVAXArgs, respon
 		return err
 	}
 
+	// Parse the target chain
+	if args.TargetChain != "" {
+		targetChainID, err := service.vm.ctx.BCLookup.Lookup(args.TargetChain)
+		if err != nil {
+			return fmt.Errorf("problem parsing targetChain %q: %w", args.TargetChain, err)
+		}
+		if targetChainID != chainID {
+			return fmt.Errorf("%w: %s is on chain %s, not %s", errWrongTargetChain, args.To, chainID, targetChainID)
+		}
+	}
+	if err := verify.SameSubnet(service.vm.ctx, chainID); err != nil {
+		return fmt.Errorf("can't export to chain %s: %w", chainID, err)
+	}
+
 	// Get this user's data
 	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
 	if err != nil {
@@ -1553,8 +2027,8 @@ type ImportAVAXArgs struct {
 	To string `json:"to"`
 }
 
-// ImportAVAX issues a transaction to import AVAX from the X-chain. The AVAX
-// must have already been exported from the X-Chain.
+// ImportAVAX issues a transaction to import AVAX from another chain in the
+// primary network. The AVAX must have already been exported from that chain.
 func (service *Service) ImportAVAX(_ *http.Request, args *ImportAVAXArgs, response *api.JSONTxIDChangeAddr) error {
 	service.vm.ctx.Log.Debug("Platform: ImportAVAX called")
 
@@ -1563,6 +2037,9 @@ func (service *Service) ImportAVAX(_ *http.Request, args *ImportAVAXArgs, respon
 	if err != nil {
 		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
 	}
+	if err := verify.SameSubnet(service.vm.ctx, chainID); err != nil {
+		return fmt.Errorf("can't import from chain %s: %w", chainID, err)
+	}
 
 	// Parse the to address
 	to, err := service.vm.ParseLocalAddress(args.To)
@@ -1972,24 +2449,47 @@ type APIBlockchain struct {
 	VMID ids.ID `json:"vmID"`
 }
 
+// GetBlockchainsArgs are the arguments for calling GetBlockchains.
+// If [SubnetID] is provided, only the blockchains validated by that subnet are
+// returned. Blockchains are returned in order of their IDs, starting from
+// [StartID]. If [Limit] is non-zero, at most [Limit] blockchains are returned.
+type GetBlockchainsArgs struct {
+	SubnetID *ids.ID     `json:"subnetID"`
+	Limit    json.Uint32 `json:"limit"`
+	StartID  ids.ID      `json:"startID"`
+}
+
 // GetBlockchainsResponse is the response from a call to GetBlockchains
 type GetBlockchainsResponse struct {
 	// blockchains that exist
 	Blockchains []APIBlockchain `json:"blockchains"`
+	// ID of the next blockchain, if the response was truncated. Used for
+	// pagination. To get the rest of the blockchains, call GetBlockchains
+	// again and set [StartID] to this value.
+	NextID *ids.ID `json:"nextID,omitempty"`
 }
 
-// GetBlockchains returns all of the blockchains that exist
-func (service *Service) GetBlockchains(_ *http.Request, args *struct{}, response *GetBlockchainsResponse) error {
+// GetBlockchains returns the blockchains that exist
+func (service *Service) GetBlockchains(_ *http.Request, args *GetBlockchainsArgs, response *GetBlockchainsResponse) error {
 	service.vm.ctx.Log.Debug("Platform: GetBlockchains called")
 
-	subnets, err := service.vm.internalState.GetSubnets()
-	if err != nil {
-		return fmt.Errorf("couldn't retrieve subnets: %w", err)
+	var subnetIDs []ids.ID
+	if args.SubnetID != nil {
+		subnetIDs = []ids.ID{*args.SubnetID}
+	} else {
+		subnets, err := service.vm.internalState.GetSubnets()
+		if err != nil {
+			return fmt.Errorf("couldn't retrieve subnets: %w", err)
+		}
+		subnetIDs = make([]ids.ID, 0, len(subnets)+1)
+		for _, subnet := range subnets {
+			subnetIDs = append(subnetIDs, subnet.ID())
+		}
+		subnetIDs = append(subnetIDs, constants.PrimaryNetworkID)
 	}
 
-	response.Blockchains = []APIBlockchain{}
-	for _, subnet := range subnets {
-		subnetID := subnet.ID()
+	blockchains := []APIBlockchain{}
+	for _, subnetID := range subnetIDs {
 		chains, err := service.vm.internalState.GetChains(subnetID)
 		if err != nil {
 			return fmt.Errorf(
@@ -2004,36 +2504,344 @@ func (service *Service) GetBlockchains(_ *http.Request, args *struct{}, response
 			if !ok {
 				return errWrongTxType
 			}
-			response.Blockchains = append(response.Blockchains, APIBlockchain{
-				ID:       chain.ID(),
+			chainID := chain.ID()
+			if bytes.Compare(chainID[:], args.StartID[:]) < 0 {
+				continue
+			}
+			blockchains = append(blockchains, APIBlockchain{
+				ID:       chainID,
 				Name:     chain.ChainName,
 				SubnetID: subnetID,
 				VMID:     chain.VMID,
 			})
 		}
 	}
+	sort.Slice(blockchains, func(i, j int) bool {
+		return bytes.Compare(blockchains[i].ID[:], blockchains[j].ID[:]) < 0
+	})
+
+	if limit := int(args.Limit); limit > 0 && len(blockchains) > limit {
+		nextID := blockchains[limit].ID
+		response.NextID = &nextID
+		blockchains = blockchains[:limit]
+	}
+	response.Blockchains = blockchains
+	return nil
+}
+
+// BuildUnsignedTxReply is the response from the methods that build unsigned
+// txs for signing outside of the node
+type BuildUnsignedTxReply struct {
+	// The serialized unsigned tx. The hash of these bytes is what each
+	// credential of the tx signs.
+	UnsignedTx string `json:"unsignedTx"`
+	// The UTXOs consumed by the tx, in the order of the tx's inputs
+	UTXOs []string `json:"utxos"`
+	// Signers[i] are the addresses, in order, whose signatures make up the
+	// credential of the tx's i-th input
+	Signers  [][]string          `json:"signers"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// parseSpendAddrs returns the addresses that fund an unsigned tx and the
+// address any change is sent to. If [changeAddrStr] is empty, change is sent to
+// the first of [fromAddrStrs].
+func (service *Service) parseSpendAddrs(fromAddrStrs []string, changeAddrStr string) (ids.ShortSet, ids.ShortID, error) {
+	if len(fromAddrStrs) == 0 {
+		return nil, ids.ShortEmpty, errNoAddresses
+	}
+
+	fromAddrs := ids.NewShortSet(len(fromAddrStrs))
+	var changeAddr ids.ShortID
+	for i, addrStr := range fromAddrStrs {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return nil, ids.ShortEmpty, fmt.Errorf("couldn't parse 'from' address %s: %w", addrStr, err)
+		}
+		fromAddrs.Add(addr)
+		if i == 0 {
+			changeAddr = addr
+		}
+	}
+
+	if changeAddrStr != "" {
+		addr, err := service.vm.ParseLocalAddress(changeAddrStr)
+		if err != nil {
+			return nil, ids.ShortEmpty, fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+		changeAddr = addr
+	}
+	return fromAddrs, changeAddr, nil
+}
 
-	chains, err := service.vm.internalState.GetChains(constants.PrimaryNetworkID)
+// formatUnsignedTx populates [reply] with the serialization of [utx], the UTXOs
+// consumed by [ins] and the addresses that must sign each input.
+func (service *Service) formatUnsignedTx(
+	utx UnsignedTx,
+	ins []*avax.TransferableInput,
+	signers [][]ids.ShortID,
+	encoding formatting.Encoding,
+	reply *BuildUnsignedTxReply,
+) error {
+	// Marshal the interface, rather than the concrete type, so that the type ID
+	// is included. This matches the bytes hashed by Tx.Sign.
+	unsignedBytes, err := Codec.Marshal(CodecVersion, &utx)
 	if err != nil {
-		return fmt.Errorf("couldn't retrieve subnets: %w", err)
+		return fmt.Errorf("couldn't marshal unsigned tx: %w", err)
 	}
-	for _, chainTx := range chains {
-		chain, ok := chainTx.UnsignedTx.(*UnsignedCreateChainTx)
-		if !ok {
-			return errWrongTxType
+	reply.UnsignedTx, err = formatting.EncodeWithChecksum(encoding, unsignedBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't encode unsigned tx as %s: %w", encoding, err)
+	}
+
+	reply.UTXOs = make([]string, len(ins))
+	for i, in := range ins {
+		utxo, err := service.vm.internalState.GetUTXO(in.InputID())
+		if err != nil {
+			return fmt.Errorf("couldn't get UTXO %s: %w", in.InputID(), err)
+		}
+		utxoBytes, err := Codec.Marshal(CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't marshal UTXO %s: %w", in.InputID(), err)
+		}
+		reply.UTXOs[i], err = formatting.EncodeWithChecksum(encoding, utxoBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as %s: %w", in.InputID(), encoding, err)
 		}
-		response.Blockchains = append(response.Blockchains, APIBlockchain{
-			ID:       chain.ID(),
-			Name:     chain.ChainName,
-			SubnetID: constants.PrimaryNetworkID,
-			VMID:     chain.VMID,
-		})
 	}
 
+	reply.Signers = make([][]string, len(signers))
+	for i, inputSigners := range signers {
+		reply.Signers[i] = make([]string, len(inputSigners))
+		for j, addr := range inputSigners {
+			reply.Signers[i][j], err = service.vm.FormatLocalAddress(addr)
+			if err != nil {
+				return fmt.Errorf("couldn't format address %s: %w", addr, err)
+			}
+		}
+	}
+	reply.Encoding = encoding
 	return nil
 }
 
-// IssueTx issues a tx
+// BuildAddValidatorTxArgs are the arguments to BuildAddValidatorTx
+type BuildAddValidatorTxArgs struct {
+	// From addrs, change addr
+	api.JSONFromAddrs
+	api.JSONChangeAddr
+	APIStaker
+	APIRewardOwnerArgs
+	DelegationFeeRate json.Float32        `json:"delegationFeeRate"`
+	Encoding          formatting.Encoding `json:"encoding"`
+}
+
+// BuildAddValidatorTx builds, but doesn't sign or issue, a transaction to add a
+// validator to the primary network. The tx is funded by [args.From], which
+// don't need to be in the keystore.
+func (service *Service) BuildAddValidatorTx(_ *http.Request, args *BuildAddValidatorTxArgs, reply *BuildUnsignedTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: BuildAddValidatorTx called")
+
+	now := service.vm.clock.Time()
+	minAddStakerUnix := json.Uint64(now.Add(minAddStakerDelay).Unix())
+	maxAddStakerUnix := json.Uint64(now.Add(maxFutureStartTime).Unix())
+
+	if args.StartTime == 0 {
+		args.StartTime = minAddStakerUnix
+	}
+
+	switch {
+	case args.StartTime < minAddStakerUnix:
+		return errStartTimeTooSoon
+	case args.StartTime > maxAddStakerUnix:
+		return errStartTimeTooLate
+	case args.DelegationFeeRate < 0 || args.DelegationFeeRate > 100:
+		return errInvalidDelegationRate
+	}
+
+	// Parse the node ID
+	var nodeID ids.ShortID
+	if args.NodeID == "" {
+		nodeID = service.vm.ctx.NodeID // If omitted, use this node's ID
+	} else {
+		nID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		nodeID = nID
+	}
+
+	// Parse the reward owner
+	rewardsOwner, err := service.parseRewardsOwner(&args.APIRewardOwnerArgs)
+	if err != nil {
+		return err
+	}
+
+	fromAddrs, changeAddr, err := service.parseSpendAddrs(args.From, args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	// Create the transaction
+	utx, signers, err := service.vm.newUnsignedAddValidatorTx(
+		args.weight(),                        // Stake amount
+		uint64(args.StartTime),               // Start time
+		uint64(args.EndTime),                 // End time
+		nodeID,                               // Node ID
+		rewardsOwner,                         // Reward owner
+		uint32(10000*args.DelegationFeeRate), // Shares
+		fromAddrs,                            // Addresses funding the tx
+		changeAddr,                           // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+	return service.formatUnsignedTx(utx, utx.Ins, signers, args.Encoding, reply)
+}
+
+// BuildAddDelegatorTxArgs are the arguments to BuildAddDelegatorTx
+type BuildAddDelegatorTxArgs struct {
+	// From addrs, change addr
+	api.JSONFromAddrs
+	api.JSONChangeAddr
+	APIStaker
+	APIRewardOwnerArgs
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildAddDelegatorTx builds, but doesn't sign or issue, a transaction to add a
+// delegator to the primary network. The tx is funded by [args.From], which
+// don't need to be in the keystore.
+func (service *Service) BuildAddDelegatorTx(_ *http.Request, args *BuildAddDelegatorTxArgs, reply *BuildUnsignedTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: BuildAddDelegatorTx called")
+
+	now := service.vm.clock.Time()
+	minAddStakerUnix := json.Uint64(now.Add(minAddStakerDelay).Unix())
+	maxAddStakerUnix := json.Uint64(now.Add(maxFutureStartTime).Unix())
+
+	if args.StartTime == 0 {
+		args.StartTime = minAddStakerUnix
+	}
+
+	switch {
+	case args.StartTime < minAddStakerUnix:
+		return errStartTimeTooSoon
+	case args.StartTime > maxAddStakerUnix:
+		return errStartTimeTooLate
+	}
+
+	// Parse the node ID
+	var nodeID ids.ShortID
+	if args.NodeID == "" { // If ID unspecified, use this node's ID
+		nodeID = service.vm.ctx.NodeID
+	} else {
+		nID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		nodeID = nID
+	}
+
+	// Parse the reward owner
+	rewardsOwner, err := service.parseRewardsOwner(&args.APIRewardOwnerArgs)
+	if err != nil {
+		return err
+	}
+
+	fromAddrs, changeAddr, err := service.parseSpendAddrs(args.From, args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	// Create the transaction
+	utx, signers, err := service.vm.newUnsignedAddDelegatorTx(
+		args.weight(),          // Stake amount
+		uint64(args.StartTime), // Start time
+		uint64(args.EndTime),   // End time
+		nodeID,                 // Node ID
+		rewardsOwner,           // Reward owner
+		fromAddrs,              // Addresses funding the tx
+		changeAddr,             // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+	return service.formatUnsignedTx(utx, utx.Ins, signers, args.Encoding, reply)
+}
+
+// BuildExportAVAXTxArgs are the arguments to BuildExportAVAXTx
+type BuildExportAVAXTxArgs struct {
+	// From addrs, change addr
+	api.JSONFromAddrs
+	api.JSONChangeAddr
+
+	// Amount of AVAX to send
+	Amount json.Uint64 `json:"amount"`
+
+	// ID of the address that will receive the AVAX. This address includes the
+	// chainID, which is used to determine what the destination chain is.
+	To string `json:"to"`
+
+	// Alias or ID of the chain the AVAX is sent to. If provided, it must be
+	// the chain [To] is on.
+	TargetChain string `json:"targetChain"`
+
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildExportAVAXTx builds, but doesn't sign or issue, a transaction that
+// exports AVAX from the P-Chain to another chain in the primary network. The tx
+// is funded by [args.From], which don't need to be in the keystore.
+func (service *Service) BuildExportAVAXTx(_ *http.Request, args *BuildExportAVAXTxArgs, reply *BuildUnsignedTxReply) error {
+	service.vm.ctx.Log.Debug("Platform: BuildExportAVAXTx called")
+
+	if args.Amount == 0 {
+		return errNoAmount
+	}
+
+	// Parse the to address
+	chainID, to, err := service.vm.ParseAddress(args.To)
+	if err != nil {
+		return err
+	}
+
+	// Parse the target chain
+	if args.TargetChain != "" {
+		targetChainID, err := service.vm.ctx.BCLookup.Lookup(args.TargetChain)
+		if err != nil {
+			return fmt.Errorf("problem parsing targetChain %q: %w", args.TargetChain, err)
+		}
+		if targetChainID != chainID {
+			return fmt.Errorf("%w: %s is on chain %s, not %s", errWrongTargetChain, args.To, chainID, targetChainID)
+		}
+	}
+	if err := verify.SameSubnet(service.vm.ctx, chainID); err != nil {
+		return fmt.Errorf("can't export to chain %s: %w", chainID, err)
+	}
+
+	fromAddrs, changeAddr, err := service.parseSpendAddrs(args.From, args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+
+	// Create the transaction
+	utx, signers, err := service.vm.newUnsignedExportTx(
+		uint64(args.Amount), // Amount
+		chainID,             // ID of the chain to send the funds to
+		to,                  // Address
+		fromAddrs,           // Addresses funding the tx
+		changeAddr,          // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+	return service.formatUnsignedTx(utx, utx.Ins, signers, args.Encoding, reply)
+}
+
+// IssueTx issues a tx. The tx may have been signed outside of the node, for
+// example by adding credentials to a tx returned by BuildAddValidatorTx,
+// BuildAddDelegatorTx or BuildExportAVAXTx. Credential i must contain, in
+// order, signatures of the hash of the unsigned tx by the addresses in
+// Signers[i].
 func (service *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.JSONTxID) error {
 	service.vm.ctx.Log.Debug("Platform: IssueTx called")
 
@@ -2054,20 +2862,32 @@ func (service *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response
 }
 
 // GetTx gets a tx
-func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.FormattedTx) error {
+func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	service.vm.ctx.Log.Debug("Platform: GetTx called")
 
 	tx, _, err := service.vm.internalState.GetTx(args.TxID)
 	if err != nil {
 		return fmt.Errorf("couldn't get tx: %w", err)
 	}
+	response.Encoding = args.Encoding
+
+	if args.Encoding == formatting.JSON {
+		tx.InitCtx(service.vm.ctx)
+		// Marshal here, rather than when the response is written, so that
+		// unsupported tx types are reported as an error from this call.
+		txJSON, err := stdjson.Marshal(tx)
+		if err != nil {
+			return fmt.Errorf("couldn't marshal tx as JSON: %w", err)
+		}
+		response.Tx = stdjson.RawMessage(txJSON)
+		return nil
+	}
 
 	txBytes := tx.Bytes()
 	response.Tx, err = formatting.EncodeWithChecksum(args.Encoding, txBytes)
 	if err != nil {
 		return fmt.Errorf("couldn't encode tx as a string: %s", err)
 	}
-	response.Encoding = args.Encoding
 	return nil
 }
 
@@ -2174,10 +2994,25 @@ type GetStakeReply struct {
 	// String representation of staked outputs
 	// Each is of type avax.TransferableOutput
 	Outputs []string `json:"stakedOutputs"`
+	// Staked outputs grouped by the staking tx that locked them
+	StakedOutputsByTx []APIStakedOutputs `json:"stakedOutputsByTx"`
 	// Encoding of [Outputs]
 	Encoding formatting.Encoding `json:"encoding"`
 }
 
+// APIStakedOutputs are the outputs, owned by the requested addresses, that
+// were staked by a single validator or delegator tx.
+type APIStakedOutputs struct {
+	TxID ids.ID `json:"txID"`
+	// Unix time at which the stake will be returned
+	EndTime json.Uint64 `json:"endTime"`
+	// Amount staked by [Outputs]
+	Staked json.Uint64 `json:"staked"`
+	// String representation of staked outputs
+	// Each is of type avax.TransferableOutput
+	Outputs []string `json:"outputs"`
+}
+
 // Takes in a staker and a set of addresses
 // Returns:
 // 1) The total amount staked by addresses in [addrs]
@@ -2266,52 +3101,75 @@ func (service *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *
 	}
 
 	currentStakers := service.vm.internalState.CurrentStakerChainState()
-	stakers := currentStakers.Stakers()
+	pendingStakers := service.vm.internalState.PendingStakerChainState()
+	stakerSets := [][]*Tx{
+		currentStakers.Stakers(),
+		pendingStakers.Stakers(),
+	}
 
 	var (
 		totalStake uint64
-		stakedOuts = make([]avax.TransferableOutput, 0, len(stakers))
+		stakedOuts []avax.TransferableOutput
+		byTx       = []APIStakedOutputs{}
 	)
-	for _, tx := range stakers { // Iterates over current stakers
-		stakedAmt, outs, err := service.getStakeHelper(tx, addrs)
-		if err != nil {
-			return err
-		}
-		totalStake, err = math.Add64(totalStake, stakedAmt)
-		if err != nil {
-			return err
-		}
-		stakedOuts = append(stakedOuts, outs...)
-	}
+	for _, stakers := range stakerSets {
+		for _, tx := range stakers { // Iterates over current and pending stakers
+			stakedAmt, outs, err := service.getStakeHelper(tx, addrs)
+			if err != nil {
+				return err
+			}
+			if len(outs) == 0 {
+				continue
+			}
+			totalStake, err = math.Add64(totalStake, stakedAmt)
+			if err != nil {
+				return err
+			}
+			stakedOuts = append(stakedOuts, outs...)
 
-	pendingStakers := service.vm.internalState.PendingStakerChainState()
-	for _, tx := range pendingStakers.Stakers() { // Iterates over pending stakers
-		stakedAmt, outs, err := service.getStakeHelper(tx, addrs)
-		if err != nil {
-			return err
-		}
-		totalStake, err = math.Add64(totalStake, stakedAmt)
-		if err != nil {
-			return err
+			encodedOuts, err := encodeStakedOutputs(args.Encoding, outs)
+			if err != nil {
+				return err
+			}
+			staker, ok := tx.UnsignedTx.(TimedTx)
+			if !ok {
+				return errWrongTxType
+			}
+			byTx = append(byTx, APIStakedOutputs{
+				TxID:    tx.ID(),
+				EndTime: json.Uint64(staker.EndTime().Unix()),
+				Staked:  json.Uint64(stakedAmt),
+				Outputs: encodedOuts,
+			})
 		}
-		stakedOuts = append(stakedOuts, outs...)
 	}
 
+	encodedOuts, err := encodeStakedOutputs(args.Encoding, stakedOuts)
+	if err != nil {
+		return err
+	}
 	response.Staked = json.Uint64(totalStake)
-	response.Outputs = make([]string, len(stakedOuts))
-	for i, output := range stakedOuts {
+	response.Outputs = encodedOuts
+	response.StakedOutputsByTx = byTx
+	response.Encoding = args.Encoding
+	return nil
+}
+
+// encodeStakedOutputs returns the string representations of [outs] in the
+// provided encoding.
+func encodeStakedOutputs(encoding formatting.Encoding, outs []avax.TransferableOutput) ([]string, error) {
+	encodedOuts := make([]string, len(outs))
+	for i, output := range outs {
 		bytes, err := Codec.Marshal(CodecVersion, output)
 		if err != nil {
-			return fmt.Errorf("couldn't serialize output %s: %w", output.ID, err)
+			return nil, fmt.Errorf("couldn't serialize output %s: %w", output.ID, err)
 		}
-		response.Outputs[i], err = formatting.EncodeWithChecksum(args.Encoding, bytes)
+		encodedOuts[i], err = formatting.EncodeWithChecksum(encoding, bytes)
 		if err != nil {
-			return fmt.Errorf("couldn't encode output %s as string: %s", output.ID, err)
+			return nil, fmt.Errorf("couldn't encode output %s as string: %w", output.ID, err)
 		}
 	}
-	response.Encoding = args.Encoding
-
-	return nil
+	return encodedOuts, nil
 }
 
 // GetMinStakeReply is the response from calling GetMinStake.
@@ -2329,18 +3187,48 @@ func (service *Service) GetMinStake(_ *http.Request, _ *struct{}, reply *GetMinS
 	return nil
 }
 
+// GetTotalStakeArgs are the arguments for calling GetTotalStake.
+type GetTotalStakeArgs struct {
+	// If true, the reply breaks the stake down into current and pending stake
+	IncludeBreakdown bool `json:"includeBreakdown"`
+}
+
+// APIStakeBreakdown is the stake of the Primary Network, split into the stake
+// of current and pending stakers.
+type APIStakeBreakdown struct {
+	Current json.Uint64 `json:"current"`
+	Pending json.Uint64 `json:"pending"`
+}
+
 // GetTotalStakeReply is the response from calling GetTotalStake.
 type GetTotalStakeReply struct {
-	Stake json.Uint64 `json:"stake"`
+	Stake     json.Uint64        `json:"stake"`
+	Breakdown *APIStakeBreakdown `json:"breakdown,omitempty"`
 }
 
 // GetTotalStake returns the total amount staked on the Primary Network
-func (service *Service) GetTotalStake(_ *http.Request, _ *struct{}, reply *GetTotalStakeReply) error {
+func (service *Service) GetTotalStake(_ *http.Request, args *GetTotalStakeArgs, reply *GetTotalStakeReply) error {
 	vdrs, ok := service.vm.Validators.GetValidators(constants.PrimaryNetworkID)
 	if !ok {
 		return errNoPrimaryValidators
 	}
 	reply.Stake = json.Uint64(vdrs.Weight())
+	if !args.IncludeBreakdown {
+		return nil
+	}
+
+	current, err := summarizeStakersBySubnet(service.vm.internalState.CurrentStakerChainState().Stakers())
+	if err != nil {
+		return fmt.Errorf("couldn't summarize current stakers: %w", err)
+	}
+	pending, err := summarizeStakersBySubnet(service.vm.internalState.PendingStakerChainState().Stakers())
+	if err != nil {
+		return fmt.Errorf("couldn't summarize pending stakers: %w", err)
+	}
+	reply.Breakdown = &APIStakeBreakdown{
+		Current: json.Uint64(current[constants.PrimaryNetworkID].weight),
+		Pending: json.Uint64(pending[constants.PrimaryNetworkID].weight),
+	}
 	return nil
 }
 
@@ -2354,7 +3242,11 @@ type GetMaxStakeAmountArgs struct {
 
 // GetMaxStakeAmountReply is the response from calling GetMaxStakeAmount.
 type GetMaxStakeAmountReply struct {
+	// Maximum amount staked on the node at any point during the time period
 	Amount json.Uint64 `json:"amount"`
+	// Maximum amount of additional stake that can be delegated to the node
+	// during the time period. Only non-zero for the primary network.
+	Available json.Uint64 `json:"available"`
 }
 
 // GetMaxStakeAmount returns the maximum amount of nAVAX staking to the named
@@ -2373,8 +3265,16 @@ func (service *Service) GetMaxStakeAmount(_ *http.Request, args *GetMaxStakeAmou
 		startTime,
 		endTime,
 	)
-
+	if err != nil {
+		return err
+	}
 	reply.Amount = json.Uint64(maxStakeAmount)
+
+	if args.SubnetID != constants.PrimaryNetworkID {
+		return nil
+	}
+	available, err := service.vm.maxDelegatableStakeAmount(nodeID, startTime, endTime)
+	reply.Available = json.Uint64(available)
 	return err
 }
 
@@ -2420,13 +3320,17 @@ func (service *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, rep
 type GetTimestampReply struct {
 	// Current timestamp
 	Timestamp time.Time `json:"timestamp"`
+	// Current timestamp, in Unix seconds
+	Unix json.Uint64 `json:"unix"`
 }
 
-// GetTimestamp returns the current timestamp on chain.
+// GetTimestamp returns the current timestamp on chain. This is the timestamp
+// of the state after the last accepted block, not of any processing block.
 func (service *Service) GetTimestamp(_ *http.Request, args *struct{}, reply *GetTimestampReply) error {
 	service.vm.ctx.Log.Debug("Platform: GetTimestamp called")
 
 	reply.Timestamp = service.vm.internalState.GetTimestamp()
+	reply.Unix = json.Uint64(reply.Timestamp.Unix())
 	return nil
 }
 
@@ -2461,3 +3365,138 @@ func (service *Service) GetValidatorsAt(_ *http.Request, args *GetValidatorsAtAr
 	}
 	return nil
 }
+
+// GetBlockByHeightArgs is the request for GetBlockByHeight
+type GetBlockByHeightArgs struct {
+	Height   json.Uint64         `json:"height"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetBlockByHeightReply is the response from GetBlockByHeight
+type GetBlockByHeightReply struct {
+	BlockID  ids.ID              `json:"blockID"`
+	Block    string              `json:"block"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetBlockByHeight returns the accepted block at the provided height.
+func (service *Service) GetBlockByHeight(_ *http.Request, args *GetBlockByHeightArgs, reply *GetBlockByHeightReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetBlockByHeight called with Height %d", args.Height)
+
+	blkID, err := service.vm.internalState.GetBlockIDAtHeight(uint64(args.Height))
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%w: %d", errBlockHeightNotFound, args.Height)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get block ID at height %d: %w", args.Height, err)
+	}
+	blk, err := service.vm.getBlock(blkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", blkID, err)
+	}
+
+	reply.Block, err = formatting.EncodeWithChecksum(args.Encoding, blk.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode block %s as a string: %w", blkID, err)
+	}
+	reply.BlockID = blkID
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// GetBlockArgs is the request for GetBlock
+type GetBlockArgs struct {
+	BlockID  ids.ID              `json:"blockID"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetBlockReply is the response from GetBlock
+type GetBlockReply struct {
+	// If [GetBlockArgs.Encoding] is [Hex] or [CB58], [Block] is the string
+	// representation of the block under that encoding.
+	// If [GetBlockArgs.Encoding] is [JSON], [Block] is the decoded block,
+	// which will be returned as JSON to the caller.
+	Block    interface{}         `json:"block"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetBlock returns the block with the provided ID.
+func (service *Service) GetBlock(_ *http.Request, args *GetBlockArgs, reply *GetBlockReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetBlock called with blockID %s", args.BlockID)
+
+	blk, err := service.vm.getBlock(args.BlockID)
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%w: %s", errBlockNotFound, args.BlockID)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", args.BlockID, err)
+	}
+	reply.Encoding = args.Encoding
+
+	if args.Encoding == formatting.JSON {
+		fields, err := blockJSONFields(blk)
+		if err != nil {
+			return err
+		}
+		// Marshal here, rather than when the response is written, so that
+		// unsupported tx types are reported as an error from this call.
+		blkJSON, err := stdjson.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("couldn't marshal block %s as JSON: %w", args.BlockID, err)
+		}
+		reply.Block = stdjson.RawMessage(blkJSON)
+		return nil
+	}
+
+	reply.Block, err = formatting.EncodeWithChecksum(args.Encoding, blk.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode block %s as a string: %w", args.BlockID, err)
+	}
+	return nil
+}
+
+// blockJSONFields returns the JSON representation of [blk], keyed by the JSON
+// field names. The txs in [blk] are rendered with their own JSON marshallers.
+func blockJSONFields(blk Block) (map[string]interface{}, error) {
+	fields := map[string]interface{}{
+		"id":       blk.ID(),
+		"parentID": blk.Parent(),
+		"height":   blk.Height(),
+	}
+	switch blk := blk.(type) {
+	case *AbortBlock, *CommitBlock:
+	case *AtomicBlock:
+		fields["tx"] = &blk.Tx
+	case *ProposalBlock:
+		fields["tx"] = &blk.Tx
+		// The time proposed by this block, if it proposes to advance the
+		// chain time
+		if advanceTimeTx, ok := blk.Tx.UnsignedTx.(*UnsignedAdvanceTimeTx); ok {
+			fields["timestamp"] = advanceTimeTx.Time
+		}
+	case *StandardBlock:
+		fields["txs"] = blk.Txs
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnknownBlockType, blk)
+	}
+	fields["type"] = blockTypeName(blk)
+	return fields, nil
+}
+
+// blockTypeName returns the name used to describe [blk]'s type to API clients
+func blockTypeName(blk Block) string {
+	switch blk.(type) {
+	case *AbortBlock:
+		return "abort"
+	case *AtomicBlock:
+		return "atomic"
+	case *CommitBlock:
+		return "commit"
+	case *ProposalBlock:
+		return "proposal"
+	case *StandardBlock:
+		return "standard"
+	default:
+		return "unknown"
+	}
+}
@@ -12,17 +12,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/avm"
@@ -86,7 +89,7 @@ func defaultAddress(t *testing.T, service *Service) {
 }
 
 func TestAddValidator(t *testing.T) {
-	expectedJSONString := `{"username":"","password":"","from":null,"changeAddr":"","txID":"11111111111111111111111111111111LpoYY","startTime":"0","endTime":"0","nodeID":"","rewardAddress":"","delegationFeeRate":"0.0000"}`
+	expectedJSONString := `{"username":"","password":"","from":null,"changeAddr":"","txID":"11111111111111111111111111111111LpoYY","startTime":"0","endTime":"0","nodeID":"","rewardAddress":"","rewardAddresses":null,"rewardThreshold":"0","rewardLocktime":"0","delegationFeeRate":"0.0000"}`
 	args := AddValidatorArgs{}
 	bytes, err := json.Marshal(&args)
 	if err != nil {
@@ -98,6 +101,61 @@ func TestAddValidator(t *testing.T) {
 	}
 }
 
+func TestParseRewardsOwner(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	addr0 := keys[0].PublicKey().Address()
+	addr1 := keys[1].PublicKey().Address()
+	addr0Str, err := service.vm.FormatLocalAddress(addr0)
+	assert.NoError(err)
+	addr1Str, err := service.vm.FormatLocalAddress(addr1)
+	assert.NoError(err)
+
+	owner, err := service.parseRewardsOwner(&APIRewardOwnerArgs{
+		RewardAddress: addr0Str,
+	})
+	assert.NoError(err)
+	assert.Equal(&secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr0},
+	}, owner)
+
+	owner, err = service.parseRewardsOwner(&APIRewardOwnerArgs{
+		RewardAddresses: []string{addr0Str, addr1Str},
+		RewardThreshold: 2,
+		RewardLocktime:  10,
+	})
+	assert.NoError(err)
+	assert.EqualValues(10, owner.Locktime)
+	assert.EqualValues(2, owner.Threshold)
+	assert.Len(owner.Addrs, 2)
+	assert.True(ids.IsSortedAndUniqueShortIDs(owner.Addrs))
+
+	_, err = service.parseRewardsOwner(&APIRewardOwnerArgs{})
+	assert.ErrorIs(err, errNoRewardAddress)
+
+	_, err = service.parseRewardsOwner(&APIRewardOwnerArgs{
+		RewardAddress:   addr0Str,
+		RewardAddresses: []string{addr1Str},
+	})
+	assert.ErrorIs(err, errRewardAddressConflict)
+
+	_, err = service.parseRewardsOwner(&APIRewardOwnerArgs{
+		RewardAddresses: []string{addr0Str, addr1Str},
+		RewardThreshold: 3,
+	})
+	assert.ErrorIs(err, errInvalidRewardThreshold)
+}
+
 func TestCreateBlockchainArgsParsing(t *testing.T) {
 	jsonString := `{"vmID":"lol","fxIDs":["secp256k1"], "name":"awesome", "username":"bob loblaw", "password":"yeet", "genesisData":"SkB92YpWm4Q2iPnLGCuDPZPgUQMxajqQQuz91oi3xD984f8r"}`
 	args := CreateBlockchainArgs{}
@@ -268,10 +326,25 @@ func TestGetTxStatus(t *testing.T) {
 	}
 
 	// put the chain in existing chain list
-	if err := service.vm.blockBuilder.AddUnverifiedTx(tx); err == nil {
+	addErr := service.vm.blockBuilder.AddUnverifiedTx(tx)
+	if addErr == nil {
 		t.Fatal("should have errored because of missing funds")
 	}
 
+	resp = GetTxStatusResponse{} // reset
+	err = service.GetTxStatus(nil, argIncludeReason, &resp)
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case resp.Status != Dropped:
+		t.Fatalf("status should be Dropped but is %s", resp.Status)
+	case resp.Reason != addErr.Error():
+		t.Fatalf("reason should be %q but is %q", addErr.Error(), resp.Reason)
+	}
+	if failures := testutil.ToFloat64(service.vm.txVerifyFailures.WithLabelValues("import", "missing_utxo")); failures != 1 {
+		t.Fatalf("expected 1 import tx verification failure due to a missing UTXO but got %f", failures)
+	}
+
 	service.vm.AtomicUTXOManager = newAtomicUTXOManager
 	service.vm.ctx.SharedMemory = sm
 
@@ -369,7 +442,7 @@ func TestGetTx(t *testing.T) {
 			TxID:     tx.ID(),
 			Encoding: formatting.CB58,
 		}
-		var response api.FormattedTx
+		var response api.GetTxReply
 		if err := service.GetTx(nil, arg, &response); err == nil {
 			t.Fatalf("failed test '%s': haven't issued tx yet so shouldn't be able to get it", test.description)
 		} else if err := service.vm.blockBuilder.AddUnverifiedTx(tx); err != nil {
@@ -393,7 +466,7 @@ func TestGetTx(t *testing.T) {
 		} else if err := service.GetTx(nil, arg, &response); err != nil {
 			t.Fatalf("failed test '%s': %s", test.description, err)
 		} else {
-			responseTxBytes, err := formatting.Decode(response.Encoding, response.Tx)
+			responseTxBytes, err := formatting.Decode(response.Encoding, response.Tx.(string))
 			if err != nil {
 				t.Fatalf("failed test '%s': %s", test.description, err)
 			}
@@ -404,6 +477,131 @@ func TestGetTx(t *testing.T) {
 	}
 }
 
+// Test retrieving a transaction in its JSON representation
+func TestGetTxJSON(t *testing.T) {
+	assert := assert.New(t)
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// issueAndGetJSON accepts [tx] and returns its JSON representation as
+	// reported by GetTx
+	issueAndGetJSON := func(tx *Tx) json.RawMessage {
+		err := service.vm.blockBuilder.AddUnverifiedTx(tx)
+		assert.NoError(err)
+		block, err := service.vm.BuildBlock()
+		assert.NoError(err)
+		err = block.Verify()
+		assert.NoError(err)
+		err = block.Accept()
+		assert.NoError(err)
+
+		args := &api.GetTxArgs{
+			TxID:     tx.ID(),
+			Encoding: formatting.JSON,
+		}
+		response := api.GetTxReply{}
+		err = service.GetTx(nil, args, &response)
+		assert.NoError(err)
+		assert.Equal(formatting.JSON, response.Encoding)
+
+		txJSON, ok := response.Tx.(json.RawMessage)
+		assert.True(ok)
+		return txJSON
+	}
+
+	createChainTx, err := service.vm.newCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		avm.ID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+
+	var parsedCreateChainTx struct {
+		ID         ids.ID `json:"id"`
+		UnsignedTx struct {
+			Type       string `json:"type"`
+			SubnetID   ids.ID `json:"subnetID"`
+			ChainName  string `json:"chainName"`
+			SubnetAuth struct {
+				SigIndices []uint32 `json:"signatureIndices"`
+			} `json:"subnetAuthorization"`
+		} `json:"unsignedTx"`
+		Credentials []struct {
+			NumSignatures int `json:"numSignatures"`
+		} `json:"credentials"`
+	}
+	err = json.Unmarshal(issueAndGetJSON(createChainTx), &parsedCreateChainTx)
+	assert.NoError(err)
+	assert.Equal(createChainTx.ID(), parsedCreateChainTx.ID)
+	assert.Equal("create_chain", parsedCreateChainTx.UnsignedTx.Type)
+	assert.Equal(testSubnet1.ID(), parsedCreateChainTx.UnsignedTx.SubnetID)
+	assert.Equal("chain name", parsedCreateChainTx.UnsignedTx.ChainName)
+	assert.Len(parsedCreateChainTx.UnsignedTx.SubnetAuth.SigIndices, 2)
+	assert.Len(parsedCreateChainTx.Credentials, len(createChainTx.Creds))
+	// The subnet auth credential is the last credential
+	subnetAuthCred := parsedCreateChainTx.Credentials[len(parsedCreateChainTx.Credentials)-1]
+	assert.Equal(2, subnetAuthCred.NumSignatures)
+
+	exportAddr := ids.GenerateTestShortID()
+	exportTx, err := service.vm.newExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		exportAddr,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+
+	var parsedExportTx struct {
+		UnsignedTx struct {
+			Type             string `json:"type"`
+			DestinationChain ids.ID `json:"destinationChain"`
+			ExportedOutputs  []struct {
+				Output struct {
+					Amount    uint64   `json:"amount"`
+					Addresses []string `json:"addresses"`
+				} `json:"output"`
+			} `json:"exportedOutputs"`
+		} `json:"unsignedTx"`
+	}
+	err = json.Unmarshal(issueAndGetJSON(exportTx), &parsedExportTx)
+	assert.NoError(err)
+	assert.Equal("export", parsedExportTx.UnsignedTx.Type)
+	assert.Equal(service.vm.ctx.XChainID, parsedExportTx.UnsignedTx.DestinationChain)
+	assert.Len(parsedExportTx.UnsignedTx.ExportedOutputs, 1)
+
+	exportedOutput := parsedExportTx.UnsignedTx.ExportedOutputs[0].Output
+	assert.EqualValues(100, exportedOutput.Amount)
+	exportAddrStr, err := service.vm.FormatLocalAddress(exportAddr)
+	assert.NoError(err)
+	assert.Equal([]string{exportAddrStr}, exportedOutput.Addresses)
+}
+
+type unknownUnsignedTx struct {
+	UnsignedTx
+}
+
+// Test that txs which don't define a JSON format fail to marshal
+func TestTxMarshalJSONUnknownType(t *testing.T) {
+	tx := &Tx{
+		UnsignedTx: &unknownUnsignedTx{
+			UnsignedTx: &UnsignedAdvanceTimeTx{},
+		},
+	}
+	_, err := json.Marshal(tx)
+	assert.ErrorIs(t, err, errUnsupportedTxJSON)
+}
+
 // Test method GetBalance
 func TestGetBalance(t *testing.T) {
 	service := defaultService(t)
@@ -419,7 +617,7 @@ func TestGetBalance(t *testing.T) {
 	// Ensure GetStake is correct for each of the genesis validators
 	genesis, _ := defaultGenesis()
 	for _, utxo := range genesis.UTXOs {
-		request := api.JSONAddress{
+		request := GetBalanceRequest{
 			Address: fmt.Sprintf("P-%s", utxo.Address),
 		}
 		reply := GetBalanceResponse{}
@@ -441,6 +639,101 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetBalanceMultipleAddresses(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	addr0 := keys[0].PublicKey().Address()
+	addr1 := keys[1].PublicKey().Address()
+	addr0Str, err := service.vm.FormatLocalAddress(addr0)
+	assert.NoError(err)
+	addr1Str, err := service.vm.FormatLocalAddress(addr1)
+	assert.NoError(err)
+
+	// The locktime has passed according to the wall clock, but not according to
+	// the chain timestamp.
+	chainTime := uint64(service.vm.internalState.GetTimestamp().Unix())
+	locktime := chainTime + 1
+	service.vm.clock.Set(time.Unix(int64(locktime+1), 0))
+
+	addUTXO := func(out avax.TransferableOut) *avax.UTXO {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+			Out:    out,
+		}
+		service.vm.internalState.AddUTXO(utxo)
+		return utxo
+	}
+	sharedUTXO := addUTXO(&secp256k1fx.TransferOutput{
+		Amt: 1,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr0, addr1},
+		},
+	})
+	lockedStakeableUTXO := addUTXO(&StakeableLockOut{
+		Locktime: locktime,
+		TransferableOut: &secp256k1fx.TransferOutput{
+			Amt: 2,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr0},
+			},
+		},
+	})
+	lockedNotStakeableUTXO := addUTXO(&secp256k1fx.TransferOutput{
+		Amt: 4,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Locktime:  locktime,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr1},
+		},
+	})
+	assert.NoError(service.vm.internalState.Commit())
+
+	reply := GetBalanceResponse{}
+	assert.NoError(service.GetBalance(nil, &GetBalanceRequest{
+		Addresses: []string{addr0Str, addr1Str},
+	}, &reply))
+
+	// The shared UTXO is only counted once in the totals
+	assert.Equal(cjson.Uint64(2*defaultBalance+1+2+4), reply.Balance)
+	assert.Equal(cjson.Uint64(2*defaultBalance+1), reply.Unlocked)
+	assert.Equal(cjson.Uint64(2), reply.LockedStakeable)
+	assert.Equal(cjson.Uint64(4), reply.LockedNotStakeable)
+	assert.Len(reply.UTXOIDs, 5)
+
+	assert.Len(reply.Addresses, 2)
+	balance0 := reply.Addresses[addr0Str]
+	assert.Equal(cjson.Uint64(defaultBalance+1+2), balance0.Balance)
+	assert.Equal(cjson.Uint64(defaultBalance+1), balance0.Unlocked)
+	assert.Equal(cjson.Uint64(2), balance0.LockedStakeable)
+	assert.Equal(cjson.Uint64(0), balance0.LockedNotStakeable)
+	assert.Contains(balance0.UTXOIDs, &sharedUTXO.UTXOID)
+	assert.Contains(balance0.UTXOIDs, &lockedStakeableUTXO.UTXOID)
+	assert.Len(balance0.UTXOIDs, 3)
+
+	balance1 := reply.Addresses[addr1Str]
+	assert.Equal(cjson.Uint64(defaultBalance+1+4), balance1.Balance)
+	assert.Equal(cjson.Uint64(defaultBalance+1), balance1.Unlocked)
+	assert.Equal(cjson.Uint64(0), balance1.LockedStakeable)
+	assert.Equal(cjson.Uint64(4), balance1.LockedNotStakeable)
+	assert.Contains(balance1.UTXOIDs, &sharedUTXO.UTXOID)
+	assert.Contains(balance1.UTXOIDs, &lockedNotStakeableUTXO.UTXOID)
+	assert.Len(balance1.UTXOIDs, 3)
+
+	// Case: no addresses
+	err = service.GetBalance(nil, &GetBalanceRequest{}, &reply)
+	assert.ErrorIs(err, errNoAddresses)
+}
+
 // Test method GetStake
 func TestGetStake(t *testing.T) {
 	assert := assert.New(t)
@@ -552,6 +845,13 @@ func TestGetStake(t *testing.T) {
 	}
 	// Make sure the stake amount is as expected
 	assert.EqualValues(stakeAmt+oldStake, outputs[0].Out.Amount()+outputs[1].Out.Amount())
+	// Make sure the delegator's stake is grouped under its staking tx
+	assert.Len(response.StakedOutputsByTx, 2)
+	delegatorStake, ok := findStakedOutputs(response.StakedOutputsByTx, tx.ID())
+	assert.True(ok)
+	assert.EqualValues(delegatorEndTime, delegatorStake.EndTime)
+	assert.EqualValues(stakeAmt, delegatorStake.Staked)
+	assert.Len(delegatorStake.Outputs, 1)
 
 	oldStake = uint64(response.Staked)
 
@@ -594,6 +894,22 @@ func TestGetStake(t *testing.T) {
 	}
 	// Make sure the stake amount is as expected
 	assert.EqualValues(stakeAmt+oldStake, outputs[0].Out.Amount()+outputs[1].Out.Amount()+outputs[2].Out.Amount())
+	// Make sure the pending validator's stake is grouped under its staking tx
+	assert.Len(response.StakedOutputsByTx, 3)
+	pendingStake, ok := findStakedOutputs(response.StakedOutputsByTx, tx.ID())
+	assert.True(ok)
+	assert.EqualValues(pendingStakerEndTime, pendingStake.EndTime)
+	assert.EqualValues(stakeAmt, pendingStake.Staked)
+	assert.Len(pendingStake.Outputs, 1)
+}
+
+func findStakedOutputs(stakedOutputs []APIStakedOutputs, txID ids.ID) (APIStakedOutputs, bool) {
+	for _, outs := range stakedOutputs {
+		if outs.TxID == txID {
+			return outs, true
+		}
+	}
+	return APIStakedOutputs{}, false
 }
 
 // Test method GetCurrentValidators
@@ -688,7 +1004,10 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 
 	// Call getCurrentValidators
-	args = GetCurrentValidatorsArgs{SubnetID: constants.PrimaryNetworkID}
+	args = GetCurrentValidatorsArgs{
+		SubnetID:          constants.PrimaryNetworkID,
+		IncludeDelegators: true,
+	}
 	err = service.GetCurrentValidators(nil, &args, &response)
 	switch {
 	case err != nil:
@@ -705,6 +1024,12 @@ func TestGetCurrentValidators(t *testing.T) {
 			continue
 		}
 		found = true
+		switch {
+		case vdr.DelegatorCount == nil || uint64(*vdr.DelegatorCount) != 1:
+			t.Fatalf("%s should have a delegator count of 1", vdr.NodeID)
+		case vdr.DelegatorWeight == nil || uint64(*vdr.DelegatorWeight) != stakeAmt:
+			t.Fatalf("%s should have a delegator weight of %d", vdr.NodeID, stakeAmt)
+		}
 		if len(vdr.Delegators) != 1 {
 			t.Fatalf("%s should have 1 delegator", vdr.NodeID)
 		}
@@ -725,6 +1050,176 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
+func TestGetCurrentValidatorsFilterNodeIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	genesis, _ := defaultGenesis()
+	requestedNodeID := genesis.Validators[0].NodeID
+	unknownNodeID := ids.GenerateTestShortID().PrefixedString(constants.NodeIDPrefix)
+
+	args := GetCurrentValidatorsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeIDs:  []string{requestedNodeID, unknownNodeID},
+	}
+	response := GetCurrentValidatorsReply{}
+	err := service.GetCurrentValidators(nil, &args, &response)
+	assert.NoError(err)
+	assert.Len(response.Validators, 1)
+
+	vdr, ok := response.Validators[0].(APIPrimaryValidator)
+	assert.True(ok)
+	assert.Equal(requestedNodeID, vdr.NodeID)
+
+	pendingArgs := GetPendingValidatorsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeIDs:  []string{unknownNodeID},
+	}
+	pendingResponse := GetPendingValidatorsReply{}
+	err = service.GetPendingValidators(nil, &pendingArgs, &pendingResponse)
+	assert.NoError(err)
+	assert.Empty(pendingResponse.Validators)
+	assert.Empty(pendingResponse.Delegators)
+}
+
+func TestGetCurrentValidatorsPagination(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	genesis, _ := defaultGenesis()
+	expectedNodeIDs := make([]ids.ShortID, len(genesis.Validators))
+	for i, vdr := range genesis.Validators {
+		nodeID, err := ids.ShortFromPrefixedString(vdr.NodeID, constants.NodeIDPrefix)
+		assert.NoError(err)
+		expectedNodeIDs[i] = nodeID
+	}
+	ids.SortShortIDs(expectedNodeIDs)
+
+	args := GetCurrentValidatorsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		Limit:    2,
+	}
+	nodeIDs := []ids.ShortID{}
+	for {
+		response := GetCurrentValidatorsReply{}
+		err := service.GetCurrentValidators(nil, &args, &response)
+		assert.NoError(err)
+		assert.LessOrEqual(len(response.Validators), 2)
+
+		for _, vdrIntf := range response.Validators {
+			vdr, ok := vdrIntf.(APIPrimaryValidator)
+			assert.True(ok)
+			assert.NotNil(vdr.DelegatorCount)
+			nodeID, err := ids.ShortFromPrefixedString(vdr.NodeID, constants.NodeIDPrefix)
+			assert.NoError(err)
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+
+		if response.NextNodeID == nil {
+			break
+		}
+		args.StartNodeID = *response.NextNodeID
+	}
+	assert.Equal(expectedNodeIDs, nodeIDs)
+
+	args.StartNodeID = "not a node ID"
+	err := service.GetCurrentValidators(nil, &args, &GetCurrentValidatorsReply{})
+	assert.Error(err)
+}
+
+func TestGetMaxStakeAmount(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	args := GetMaxStakeAmountArgs{
+		SubnetID:  constants.PrimaryNetworkID,
+		NodeID:    keys[0].PublicKey().Address().PrefixedString(constants.NodeIDPrefix),
+		StartTime: cjson.Uint64(defaultValidateStartTime.Unix()),
+		EndTime:   cjson.Uint64(defaultValidateEndTime.Unix()),
+	}
+	reply := GetMaxStakeAmountReply{}
+	err := service.GetMaxStakeAmount(nil, &args, &reply)
+	assert.NoError(err)
+	assert.EqualValues(defaultWeight, reply.Amount)
+	assert.EqualValues((defaultMaxValidatorWeightFactor-1)*defaultWeight, reply.Available)
+
+	args.NodeID = ids.GenerateTestShortID().PrefixedString(constants.NodeIDPrefix)
+	err = service.GetMaxStakeAmount(nil, &args, &reply)
+	assert.ErrorIs(err, errNotValidating)
+}
+
+func TestGetTotalStake(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := defaultGenesisTime.Add(syncBound).Add(time.Second)
+	tx, err := service.vm.newAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		ids.GenerateTestShortID(),
+		keys[0].PublicKey().Address(),
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	assert.NoError(err)
+	pendingStakers := service.vm.internalState.PendingStakerChainState()
+	service.vm.internalState.SetPendingStakerChainState(pendingStakers.AddStaker(tx))
+
+	reply := GetTotalStakeReply{}
+	err = service.GetTotalStake(nil, &GetTotalStakeArgs{}, &reply)
+	assert.NoError(err)
+	assert.EqualValues(len(keys)*defaultWeight, reply.Stake)
+	assert.Nil(reply.Breakdown)
+
+	err = service.GetTotalStake(nil, &GetTotalStakeArgs{IncludeBreakdown: true}, &reply)
+	assert.NoError(err)
+	assert.EqualValues(len(keys)*defaultWeight, reply.Stake)
+	assert.NotNil(reply.Breakdown)
+	assert.EqualValues(len(keys)*defaultWeight, reply.Breakdown.Current)
+	assert.EqualValues(service.vm.MinValidatorStake, reply.Breakdown.Pending)
+
+	// The metrics agree with the API once the validator sets are updated
+	err = service.vm.updateValidatorSetMetrics()
+	assert.NoError(err)
+	primaryNetworkStr := constants.PrimaryNetworkID.String()
+	assert.EqualValues(reply.Breakdown.Current, testutil.ToFloat64(service.vm.currentStake.WithLabelValues(primaryNetworkStr)))
+	assert.EqualValues(reply.Breakdown.Pending, testutil.ToFloat64(service.vm.pendingStake.WithLabelValues(primaryNetworkStr)))
+}
+
 func TestGetTimestamp(t *testing.T) {
 	assert := assert.New(t)
 
@@ -750,4 +1245,1037 @@ func TestGetTimestamp(t *testing.T) {
 	assert.NoError(err)
 
 	assert.Equal(newTimestamp, reply.Timestamp)
+	assert.EqualValues(newTimestamp.Unix(), reply.Unix)
+
+	// Advance the chain time through consensus
+	advancedTimestamp := newTimestamp.Add(time.Second)
+	service.vm.clock.Set(advancedTimestamp)
+	tx, err := service.vm.newAdvanceTimeTx(advancedTimestamp)
+	assert.NoError(err)
+
+	preferred, err := service.vm.Preferred()
+	assert.NoError(err)
+	blk, err := service.vm.newProposalBlock(preferred.ID(), preferred.Height()+1, *tx)
+	assert.NoError(err)
+	err = blk.Verify()
+	assert.NoError(err)
+	options, err := blk.Options()
+	assert.NoError(err)
+	commit := options[0].(*CommitBlock)
+	err = commit.Verify()
+	assert.NoError(err)
+
+	// The timestamp of a processing block isn't reported
+	err = service.GetTimestamp(nil, nil, &reply)
+	assert.NoError(err)
+	assert.Equal(newTimestamp, reply.Timestamp)
+
+	err = blk.Accept()
+	assert.NoError(err)
+	err = commit.Accept()
+	assert.NoError(err)
+
+	err = service.GetTimestamp(nil, nil, &reply)
+	assert.NoError(err)
+	assert.Equal(advancedTimestamp, reply.Timestamp)
+	assert.EqualValues(advancedTimestamp.Unix(), reply.Unix)
+}
+
+func TestGetBlockByHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	lastAcceptedID, err := service.vm.LastAccepted()
+	assert.NoError(err)
+	height, err := service.vm.GetCurrentHeight()
+	assert.NoError(err)
+
+	reply := GetBlockByHeightReply{}
+	err = service.GetBlockByHeight(nil, &GetBlockByHeightArgs{
+		Height:   cjson.Uint64(height),
+		Encoding: formatting.Hex,
+	}, &reply)
+	assert.NoError(err)
+	assert.Equal(lastAcceptedID, reply.BlockID)
+
+	tx, err := service.vm.newCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		avm.ID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+
+	err = service.GetBlockByHeight(nil, &GetBlockByHeightArgs{
+		Height:   cjson.Uint64(height + 1),
+		Encoding: formatting.Hex,
+	}, &reply)
+	assert.ErrorIs(err, errBlockHeightNotFound)
+
+	// Build the block on top of the last accepted block
+	err = service.vm.SetPreference(lastAcceptedID)
+	assert.NoError(err)
+	err = service.vm.blockBuilder.AddUnverifiedTx(tx)
+	assert.NoError(err)
+	blk, err := service.vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	err = service.GetBlockByHeight(nil, &GetBlockByHeightArgs{
+		Height:   cjson.Uint64(height + 1),
+		Encoding: formatting.Hex,
+	}, &reply)
+	assert.NoError(err)
+	assert.Equal(blk.ID(), reply.BlockID)
+
+	blkBytes, err := formatting.Decode(reply.Encoding, reply.Block)
+	assert.NoError(err)
+	assert.Equal(blk.Bytes(), blkBytes)
+}
+
+func TestGetBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetBlockReply{}
+	err := service.GetBlock(nil, &GetBlockArgs{
+		BlockID:  ids.GenerateTestID(),
+		Encoding: formatting.Hex,
+	}, &reply)
+	assert.ErrorIs(err, errBlockNotFound)
+
+	tx, err := service.vm.newCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		avm.ID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	err = service.vm.blockBuilder.AddUnverifiedTx(tx)
+	assert.NoError(err)
+	blk, err := service.vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	err = service.GetBlock(nil, &GetBlockArgs{
+		BlockID:  blk.ID(),
+		Encoding: formatting.Hex,
+	}, &reply)
+	assert.NoError(err)
+	blkBytes, err := formatting.Decode(reply.Encoding, reply.Block.(string))
+	assert.NoError(err)
+	assert.Equal(blk.Bytes(), blkBytes)
+
+	err = service.GetBlock(nil, &GetBlockArgs{
+		BlockID:  blk.ID(),
+		Encoding: formatting.JSON,
+	}, &reply)
+	assert.NoError(err)
+	assert.Equal(formatting.JSON, reply.Encoding)
+
+	blkJSON, ok := reply.Block.(json.RawMessage)
+	assert.True(ok)
+	var parsedBlk struct {
+		Type     string `json:"type"`
+		ID       ids.ID `json:"id"`
+		ParentID ids.ID `json:"parentID"`
+		Height   uint64 `json:"height"`
+		Txs      []struct {
+			ID         ids.ID `json:"id"`
+			UnsignedTx struct {
+				Type string `json:"type"`
+			} `json:"unsignedTx"`
+		} `json:"txs"`
+	}
+	err = json.Unmarshal(blkJSON, &parsedBlk)
+	assert.NoError(err)
+	assert.Equal("standard", parsedBlk.Type)
+	assert.Equal(blk.ID(), parsedBlk.ID)
+	assert.Equal(blk.Parent(), parsedBlk.ParentID)
+	assert.Equal(blk.Height(), parsedBlk.Height)
+	assert.Len(parsedBlk.Txs, 1)
+	assert.Equal(tx.ID(), parsedBlk.Txs[0].ID)
+	assert.Equal("create_chain", parsedBlk.Txs[0].UnsignedTx.Type)
+
+	// A processing proposal block reports the time it proposes
+	proposedTime := defaultGenesisTime.Add(time.Second)
+	advanceTimeTx, err := service.vm.newAdvanceTimeTx(proposedTime)
+	assert.NoError(err)
+	proposalBlk, err := service.vm.newProposalBlock(blk.ID(), blk.Height()+1, *advanceTimeTx)
+	assert.NoError(err)
+	service.vm.clock.Set(proposedTime)
+	assert.NoError(proposalBlk.Verify())
+
+	err = service.GetBlock(nil, &GetBlockArgs{
+		BlockID:  proposalBlk.ID(),
+		Encoding: formatting.JSON,
+	}, &reply)
+	assert.NoError(err)
+	var parsedProposalBlk struct {
+		Type      string `json:"type"`
+		Timestamp uint64 `json:"timestamp"`
+	}
+	err = json.Unmarshal(reply.Block.(json.RawMessage), &parsedProposalBlk)
+	assert.NoError(err)
+	assert.Equal("proposal", parsedProposalBlk.Type)
+	assert.EqualValues(proposedTime.Unix(), parsedProposalBlk.Timestamp)
+}
+
+func TestGetValidatorsAtRetention(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+	service.vm.config.ValidatorDiffsRetention = 0
+
+	lastAcceptedID, err := service.vm.LastAccepted()
+	assert.NoError(err)
+	height, err := service.vm.GetCurrentHeight()
+	assert.NoError(err)
+
+	reply := GetValidatorsAtReply{}
+	err = service.GetValidatorsAt(nil, &GetValidatorsAtArgs{
+		Height:   cjson.Uint64(height),
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply)
+	assert.NoError(err)
+	assert.Len(reply.Validators, len(keys))
+
+	tx, err := service.vm.newCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		avm.ID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+
+	err = service.vm.SetPreference(lastAcceptedID)
+	assert.NoError(err)
+	err = service.vm.blockBuilder.AddUnverifiedTx(tx)
+	assert.NoError(err)
+	blk, err := service.vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	err = service.GetValidatorsAt(nil, &GetValidatorsAtArgs{
+		Height:   cjson.Uint64(height),
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply)
+	assert.ErrorIs(err, errHeightNotRetained)
+}
+
+func TestGetBlockchains(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	primaryReply := GetBlockchainsResponse{}
+	err := service.GetBlockchains(nil, &GetBlockchainsArgs{SubnetID: &constants.PrimaryNetworkID}, &primaryReply)
+	assert.NoError(err)
+	numPrimaryChains := len(primaryReply.Blockchains)
+
+	subnetID := testSubnet1.ID()
+	chainIDs := make([]ids.ID, 3)
+	for i := range chainIDs {
+		tx, err := service.vm.newCreateChainTx(
+			subnetID,
+			nil,
+			avm.ID,
+			nil,
+			fmt.Sprintf("chain%d", i),
+			[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+		service.vm.internalState.AddChain(tx)
+		chainIDs[i] = tx.ID()
+	}
+	ids.SortIDs(chainIDs)
+
+	reply := GetBlockchainsResponse{}
+	err = service.GetBlockchains(nil, &GetBlockchainsArgs{}, &reply)
+	assert.NoError(err)
+	assert.Len(reply.Blockchains, numPrimaryChains+len(chainIDs))
+	assert.Nil(reply.NextID)
+	for i := 1; i < len(reply.Blockchains); i++ {
+		prev, curr := reply.Blockchains[i-1].ID, reply.Blockchains[i].ID
+		assert.Negative(bytes.Compare(prev[:], curr[:]), "blockchains should be sorted by ID")
+	}
+
+	// Only return the chains of the requested subnet
+	args := &GetBlockchainsArgs{
+		SubnetID: &subnetID,
+		Limit:    2,
+	}
+	reply = GetBlockchainsResponse{}
+	err = service.GetBlockchains(nil, args, &reply)
+	assert.NoError(err)
+	assert.Len(reply.Blockchains, 2)
+	assert.Equal(chainIDs[0], reply.Blockchains[0].ID)
+	assert.Equal(chainIDs[1], reply.Blockchains[1].ID)
+	assert.Equal(subnetID, reply.Blockchains[0].SubnetID)
+	assert.NotNil(reply.NextID)
+	assert.Equal(chainIDs[2], *reply.NextID)
+
+	// Continue from the returned cursor
+	args.StartID = *reply.NextID
+	reply = GetBlockchainsResponse{}
+	err = service.GetBlockchains(nil, args, &reply)
+	assert.NoError(err)
+	assert.Len(reply.Blockchains, 1)
+	assert.Equal(chainIDs[2], reply.Blockchains[0].ID)
+	assert.Nil(reply.NextID)
+}
+
+func TestGetSubnets(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	for i := 0; i < 2; i++ {
+		tx, err := service.vm.newCreateSubnetTx(
+			uint32(i+1),
+			[]ids.ShortID{keys[0].PublicKey().Address(), keys[1].PublicKey().Address()},
+			[]*crypto.PrivateKeySECP256K1R{keys[i]},
+			keys[i].PublicKey().Address(),
+		)
+		assert.NoError(err)
+		service.vm.internalState.AddSubnet(tx)
+	}
+
+	reply := GetSubnetsResponse{}
+	err := service.GetSubnets(nil, &GetSubnetsArgs{}, &reply)
+	assert.NoError(err)
+	allSubnets := reply.Subnets
+	assert.Len(allSubnets, 4) // testSubnet1, the 2 new subnets, and the primary network
+	assert.Nil(reply.NextID)
+
+	// The primary network has the smallest ID, so it is returned first
+	assert.Equal(constants.PrimaryNetworkID, allSubnets[0].ID)
+	assert.Empty(allSubnets[0].ControlKeys)
+	assert.EqualValues(0, allSubnets[0].Threshold)
+	for i := 1; i < len(allSubnets); i++ {
+		prev, curr := allSubnets[i-1].ID, allSubnets[i].ID
+		assert.Negative(bytes.Compare(prev[:], curr[:]), "subnets should be sorted by ID")
+
+		if curr == testSubnet1.ID() {
+			assert.Len(allSubnets[i].ControlKeys, len(testSubnet1ControlKeys))
+			assert.EqualValues(2, allSubnets[i].Threshold)
+		}
+		for _, controlKey := range allSubnets[i].ControlKeys {
+			_, err := service.vm.ParseLocalAddress(controlKey)
+			assert.NoError(err)
+		}
+	}
+
+	// Listing a subnet by ID returns the same representation
+	idReply := GetSubnetsResponse{}
+	err = service.GetSubnets(nil, &GetSubnetsArgs{IDs: []ids.ID{allSubnets[1].ID}}, &idReply)
+	assert.NoError(err)
+	assert.Equal(allSubnets[1:2], idReply.Subnets)
+
+	// Page through the subnets
+	args := &GetSubnetsArgs{Limit: 3}
+	reply = GetSubnetsResponse{}
+	err = service.GetSubnets(nil, args, &reply)
+	assert.NoError(err)
+	assert.Equal(allSubnets[:3], reply.Subnets)
+	assert.NotNil(reply.NextID)
+	assert.Equal(allSubnets[3].ID, *reply.NextID)
+
+	args.StartID = *reply.NextID
+	reply = GetSubnetsResponse{}
+	err = service.GetSubnets(nil, args, &reply)
+	assert.NoError(err)
+	assert.Equal(allSubnets[3:], reply.Subnets)
+	assert.Nil(reply.NextID)
+}
+
+func TestGetCurrentSupplyAtHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	initialSupply := service.vm.internalState.GetCurrentSupply()
+
+	acceptExportTx := func(key *crypto.PrivateKeySECP256K1R) {
+		tx, err := service.vm.newExportTx(
+			100,
+			service.vm.ctx.XChainID,
+			ids.GenerateTestShortID(),
+			[]*crypto.PrivateKeySECP256K1R{key},
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+		err = service.vm.blockBuilder.AddUnverifiedTx(tx)
+		assert.NoError(err)
+		blk, err := service.vm.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		assert.NoError(blk.Accept())
+		err = service.vm.SetPreference(blk.ID())
+		assert.NoError(err)
+	}
+
+	// Change the supply at height 1, but not at height 2
+	acceptExportTx(keys[0])
+	reducedSupply := initialSupply - 1000
+	service.vm.internalState.SetCurrentSupply(reducedSupply)
+	err := service.vm.internalState.Commit()
+	assert.NoError(err)
+	acceptExportTx(keys[1])
+
+	getSupply := func(height *cjson.Uint64) (uint64, error) {
+		reply := GetCurrentSupplyReply{}
+		err := service.GetCurrentSupply(nil, &GetCurrentSupplyArgs{
+			Height: height,
+		}, &reply)
+		return uint64(reply.Supply), err
+	}
+	heightArg := func(height uint64) *cjson.Uint64 {
+		h := cjson.Uint64(height)
+		return &h
+	}
+
+	supply, err := getSupply(nil)
+	assert.NoError(err)
+	assert.Equal(reducedSupply, supply)
+
+	supply, err = getSupply(heightArg(0))
+	assert.NoError(err)
+	assert.Equal(initialSupply, supply)
+
+	supply, err = getSupply(heightArg(1))
+	assert.NoError(err)
+	assert.Equal(reducedSupply, supply)
+
+	supply, err = getSupply(heightArg(2))
+	assert.NoError(err)
+	assert.Equal(reducedSupply, supply)
+
+	_, err = getSupply(heightArg(3))
+	assert.ErrorIs(err, errBlockHeightNotFound)
+
+	// Simulate a node that started indexing the supply at height 1
+	is, ok := service.vm.internalState.(*internalStateImpl)
+	assert.True(ok)
+	err = database.PutUInt64(is.singletonDB, supplyIndexedKey, 1)
+	assert.NoError(err)
+
+	_, err = getSupply(heightArg(0))
+	assert.ErrorIs(err, errSupplyNotIndexed)
+
+	supply, err = getSupply(heightArg(1))
+	assert.NoError(err)
+	assert.Equal(reducedSupply, supply)
+}
+
+func TestGetUTXOsPaginationWithSpends(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	addr0 := ids.GenerateTestShortID()
+	addr1 := ids.GenerateTestShortID()
+	addr0Str, err := service.vm.FormatLocalAddress(addr0)
+	assert.NoError(err)
+	addr1Str, err := service.vm.FormatLocalAddress(addr1)
+	assert.NoError(err)
+
+	// Some of the UTXOs reference both of the addresses
+	owners := [][]ids.ShortID{
+		{addr0},
+		{addr1},
+		{addr0, addr1},
+	}
+	utxos := make(map[ids.ID]*avax.UTXO)
+	for i := 0; i < 150; i++ {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.GenerateTestID(),
+				OutputIndex: uint32(i),
+			},
+			Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: uint64(i + 1),
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     owners[i%len(owners)],
+				},
+			},
+		}
+		service.vm.internalState.AddUTXO(utxo)
+		utxos[utxo.InputID()] = utxo
+	}
+	err = service.vm.internalState.Commit()
+	assert.NoError(err)
+
+	returned := make(map[ids.ID]int)
+	spent := ids.Set{}
+	startIndex := Index{}
+	for page := 0; ; page++ {
+		reply := GetUTXOsResponse{}
+		err := service.GetUTXOs(nil, &GetUTXOsArgs{
+			Addresses:  []string{addr0Str, addr1Str},
+			Limit:      7,
+			StartIndex: startIndex,
+			Encoding:   formatting.Hex,
+		}, &reply)
+		assert.NoError(err)
+		if len(reply.UTXOs) == 0 {
+			break
+		}
+
+		for _, utxoStr := range reply.UTXOs {
+			utxoBytes, err := formatting.Decode(reply.Encoding, utxoStr)
+			assert.NoError(err)
+			utxo := &avax.UTXO{}
+			_, err = Codec.Unmarshal(utxoBytes, utxo)
+			assert.NoError(err)
+			returned[utxo.InputID()]++
+		}
+
+		// Spend the UTXO at the cursor, and one UTXO that hasn't been
+		// returned yet
+		cursorUTXOID, err := ids.FromString(reply.EndIndex.UTXO)
+		assert.NoError(err)
+		service.vm.internalState.DeleteUTXO(cursorUTXOID)
+		spent.Add(cursorUTXOID)
+		for utxoID := range utxos {
+			if returned[utxoID] == 0 && !spent.Contains(utxoID) {
+				service.vm.internalState.DeleteUTXO(utxoID)
+				spent.Add(utxoID)
+				break
+			}
+		}
+		err = service.vm.internalState.Commit()
+		assert.NoError(err)
+
+		startIndex = reply.EndIndex
+	}
+
+	for utxoID := range utxos {
+		count := returned[utxoID]
+		if spent.Contains(utxoID) {
+			assert.LessOrEqual(count, 1, "UTXO %s was returned more than once", utxoID)
+		} else {
+			assert.Equal(1, count, "UTXO %s wasn't returned exactly once", utxoID)
+		}
+	}
+	assert.NotZero(spent.Len())
+}
+
+func TestEstimateReward(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := service.vm.clock.Time().Add(minAddStakerDelay).Add(time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+
+	// Invalid staking periods are rejected
+	reply := EstimateRewardReply{}
+	err := service.EstimateReward(nil, &EstimateRewardArgs{
+		Amount:    cjson.Uint64(service.vm.MinValidatorStake),
+		StartTime: cjson.Uint64(startTime.Unix()),
+		EndTime:   cjson.Uint64(startTime.Add(defaultMinStakingDuration - time.Second).Unix()),
+	}, &reply)
+	assert.ErrorIs(err, errStakeTooShort)
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		Amount:    cjson.Uint64(service.vm.MinValidatorStake),
+		StartTime: cjson.Uint64(startTime.Unix()),
+		EndTime:   cjson.Uint64(startTime.Add(defaultMaxStakingDuration + time.Second).Unix()),
+	}, &reply)
+	assert.ErrorIs(err, errStakeTooLong)
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		Amount:    cjson.Uint64(service.vm.MinValidatorStake),
+		StartTime: cjson.Uint64(service.vm.clock.Time().Unix()),
+		EndTime:   cjson.Uint64(endTime.Unix()),
+	}, &reply)
+	assert.ErrorIs(err, errStartTimeTooSoon)
+
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		Amount:    cjson.Uint64(service.vm.MinValidatorStake),
+		StartTime: cjson.Uint64(startTime.Unix()),
+		EndTime:   cjson.Uint64(endTime.Unix()),
+	}, &reply)
+	assert.NoError(err)
+	assert.NotZero(reply.Reward)
+	assert.Greater(float64(reply.AnnualizedPercentage), float64(0))
+
+	// The estimate should match the potential reward the validator is given
+	// once it starts validating.
+	nodeID := ids.GenerateTestShortID()
+	tx, err := service.vm.newAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	assert.NoError(service.vm.blockBuilder.AddUnverifiedTx(tx))
+
+	acceptProposal := func() {
+		blk, err := service.vm.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		options, err := blk.(*ProposalBlock).Options()
+		assert.NoError(err)
+		assert.NoError(blk.Accept())
+		assert.NoError(options[0].Verify())
+		assert.NoError(options[0].Accept())
+		assert.NoError(service.vm.SetPreference(options[0].ID()))
+	}
+	acceptProposal()
+
+	service.vm.clock.Set(startTime)
+	acceptProposal()
+
+	_, potentialReward, err := service.vm.internalState.CurrentStakerChainState().GetStaker(tx.ID())
+	assert.NoError(err)
+	assert.Equal(uint64(reply.Reward), potentialReward)
+}
+
+func TestExportImportAVAXCChain(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// Allow moving funds to chains other than the X-chain
+	service.vm.ApricotPhase5Time = defaultGenesisTime
+
+	m := &atomic.Memory{}
+	assert.NoError(m.Initialize(logging.NoLog{}, prefixdb.New([]byte{5}, service.vm.dbManager.Current().Database)))
+	service.vm.ctx.SharedMemory = m.NewSharedMemory(service.vm.ctx.ChainID)
+	service.vm.AtomicUTXOManager = avax.NewAtomicUTXOManager(service.vm.ctx.SharedMemory, Codec)
+	cChainSharedMemory := m.NewSharedMemory(cChainID)
+
+	acceptTx := func(txID ids.ID) {
+		blk, err := service.vm.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		assert.NoError(blk.Accept())
+		assert.NoError(service.vm.SetPreference(blk.ID()))
+
+		_, status, err := service.vm.internalState.GetTx(txID)
+		assert.NoError(err)
+		assert.Equal(Committed, status)
+	}
+
+	recipient := keys[0].PublicKey().Address()
+	cChainAddr, err := service.vm.FormatAddress(cChainID, recipient)
+	assert.NoError(err)
+	pChainAddr, err := service.vm.FormatLocalAddress(recipient)
+	assert.NoError(err)
+	spendHeader := api.JSONSpendHeader{
+		UserPass: api.UserPass{
+			Username: testUsername,
+			Password: testPassword,
+		},
+	}
+
+	// P -> C
+	exportReply := api.JSONTxIDChangeAddr{}
+	assert.NoError(service.ExportAVAX(nil, &ExportAVAXArgs{
+		JSONSpendHeader: spendHeader,
+		Amount:          100,
+		To:              cChainAddr,
+		TargetChain:     "C",
+	}, &exportReply))
+	acceptTx(exportReply.TxID)
+
+	exportedUTXOs, _, _, err := cChainSharedMemory.Indexed(
+		service.vm.ctx.ChainID,
+		[][]byte{recipient.Bytes()},
+		nil,
+		nil,
+		10,
+	)
+	assert.NoError(err)
+	assert.Len(exportedUTXOs, 1)
+
+	// C -> P
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: avaxAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 2 * service.vm.TxFee,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{recipient},
+			},
+		},
+	}
+	utxoBytes, err := Codec.Marshal(CodecVersion, utxo)
+	assert.NoError(err)
+	inputID := utxo.InputID()
+	assert.NoError(cChainSharedMemory.Apply(map[ids.ID]*atomic.Requests{
+		service.vm.ctx.ChainID: {PutRequests: []*atomic.Element{{
+			Key:    inputID[:],
+			Value:  utxoBytes,
+			Traits: [][]byte{recipient.Bytes()},
+		}}},
+	}))
+
+	importReply := api.JSONTxIDChangeAddr{}
+	assert.NoError(service.ImportAVAX(nil, &ImportAVAXArgs{
+		JSONSpendHeader: spendHeader,
+		SourceChain:     "C",
+		To:              pChainAddr,
+	}, &importReply))
+	acceptTx(importReply.TxID)
+
+	_, err = cChainSharedMemory.Get(service.vm.ctx.ChainID, [][]byte{inputID[:]})
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	// The target chain must be the chain of the recipient address
+	err = service.ExportAVAX(nil, &ExportAVAXArgs{
+		JSONSpendHeader: spendHeader,
+		Amount:          100,
+		To:              cChainAddr,
+		TargetChain:     "X",
+	}, &exportReply)
+	assert.ErrorIs(err, errWrongTargetChain)
+
+	// Chains outside of the primary network can't be targeted
+	otherChainID := ids.GenerateTestID()
+	assert.NoError(service.vm.ctx.BCLookup.(ids.Aliaser).Alias(otherChainID, "D"))
+	service.vm.ctx.SNLookup.(*snLookup).chainsToSubnet[otherChainID] = ids.GenerateTestID()
+	otherChainAddr, err := service.vm.FormatAddress(otherChainID, recipient)
+	assert.NoError(err)
+
+	err = service.ExportAVAX(nil, &ExportAVAXArgs{
+		JSONSpendHeader: spendHeader,
+		Amount:          100,
+		To:              otherChainAddr,
+	}, &exportReply)
+	assert.Error(err)
+	err = service.ImportAVAX(nil, &ImportAVAXArgs{
+		JSONSpendHeader: spendHeader,
+		SourceChain:     "D",
+		To:              pChainAddr,
+	}, &importReply)
+	assert.Error(err)
+
+	// Funds can't be moved to the P-chain itself
+	err = service.ImportAVAX(nil, &ImportAVAXArgs{
+		JSONSpendHeader: spendHeader,
+		SourceChain:     "P",
+		To:              pChainAddr,
+	}, &importReply)
+	assert.Error(err)
+}
+
+func TestServiceSpendMultisigUTXOs(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	defaultAddress(t, service)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	factory := crypto.FactorySECP256K1R{}
+	newKey := func() *crypto.PrivateKeySECP256K1R {
+		key, err := factory.NewPrivateKey()
+		assert.NoError(err)
+		return key.(*crypto.PrivateKeySECP256K1R)
+	}
+	userKeys := []*crypto.PrivateKeySECP256K1R{newKey(), newKey(), newKey()}
+	outsiderKey := newKey()
+
+	userDB, err := service.vm.ctx.Keystore.GetDatabase(testUsername, testPassword)
+	assert.NoError(err)
+	defer userDB.Close()
+	u := user{db: userDB}
+	// The user controls 2 of the 3 owners of the multisig UTXO. keys[1] is a
+	// control key of [testSubnet1], along with keys[0] from [defaultAddress].
+	assert.NoError(u.putAddress(userKeys[0]))
+	assert.NoError(u.putAddress(userKeys[2]))
+	assert.NoError(u.putAddress(keys[1]))
+
+	addUTXO := func(amount uint64, threshold uint32, owners ...*crypto.PrivateKeySECP256K1R) *avax.UTXO {
+		addrs := make([]ids.ShortID, len(owners))
+		for i, owner := range owners {
+			addrs[i] = owner.PublicKey().Address()
+		}
+		ids.SortShortIDs(addrs)
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: threshold,
+					Addrs:     addrs,
+				},
+			},
+		}
+		service.vm.internalState.AddUTXO(utxo)
+		return utxo
+	}
+	multisigUTXO := addUTXO(10*service.vm.TxFee, 2, userKeys...)
+	// The user only controls 1 of the 2 required owners of this UTXO, so it
+	// must be skipped even though it has enough funds.
+	addUTXO(100*service.vm.TxFee, 2, userKeys[0], outsiderKey)
+	assert.NoError(service.vm.internalState.Commit())
+
+	acceptTx := func(txID ids.ID) *Tx {
+		blk, err := service.vm.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		assert.NoError(blk.Accept())
+		assert.NoError(service.vm.SetPreference(blk.ID()))
+
+		tx, status, err := service.vm.internalState.GetTx(txID)
+		assert.NoError(err)
+		assert.Equal(Committed, status)
+		return tx
+	}
+
+	from := make([]string, 0, len(userKeys))
+	for _, key := range userKeys {
+		addr, err := service.vm.FormatLocalAddress(key.PublicKey().Address())
+		assert.NoError(err)
+		from = append(from, addr)
+	}
+	to, err := service.vm.FormatAddress(xChainID, ids.GenerateTestShortID())
+	assert.NoError(err)
+
+	exportReply := api.JSONTxIDChangeAddr{}
+	assert.NoError(service.ExportAVAX(nil, &ExportAVAXArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: testUsername,
+				Password: testPassword,
+			},
+			JSONFromAddrs: api.JSONFromAddrs{From: from},
+		},
+		Amount: cjson.Uint64(service.vm.TxFee),
+		To:     to,
+	}, &exportReply))
+	exportTx := acceptTx(exportReply.TxID)
+
+	ins := exportTx.UnsignedTx.(*UnsignedExportTx).Ins
+	assert.Len(ins, 1)
+	assert.Equal(multisigUTXO.InputID(), ins[0].InputID())
+
+	// The signature indices must follow the ordering of the owners' addresses
+	owners := multisigUTXO.Out.(*secp256k1fx.TransferOutput).Addrs
+	expectedSigIndices := []uint32{}
+	for i, addr := range owners {
+		if addr != userKeys[1].PublicKey().Address() {
+			expectedSigIndices = append(expectedSigIndices, uint32(i))
+		}
+	}
+	assert.Equal(expectedSigIndices, ins[0].In.(*secp256k1fx.TransferInput).SigIndices)
+
+	// The subnet authorization is signed by multiple control keys pulled from
+	// the keystore.
+	genesisData, err := formatting.EncodeWithChecksum(formatting.Hex, nil)
+	assert.NoError(err)
+	createChainReply := api.JSONTxIDChangeAddr{}
+	assert.NoError(service.CreateBlockchain(nil, &CreateBlockchainArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: testUsername,
+				Password: testPassword,
+			},
+		},
+		SubnetID:    testSubnet1.ID(),
+		VMID:        avm.ID.String(),
+		Name:        "chain name",
+		GenesisData: genesisData,
+		Encoding:    formatting.Hex,
+	}, &createChainReply))
+	createChainTx := acceptTx(createChainReply.TxID)
+
+	subnetAuth := createChainTx.UnsignedTx.(*UnsignedCreateChainTx).SubnetAuth.(*secp256k1fx.Input)
+	assert.Len(subnetAuth.SigIndices, 2)
+}
+
+func TestBuildUnsignedTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	service := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// The funding key is never put into the keystore
+	key := keys[1]
+	addr := key.PublicKey().Address()
+	addrStr, err := service.vm.FormatLocalAddress(addr)
+	assert.NoError(err)
+	nodeID := ids.GenerateTestShortID()
+	startTime := defaultGenesisTime.Add(minAddStakerDelay + time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	service.vm.clock.Set(defaultGenesisTime)
+
+	// Case: no from addresses
+	xChainAddr, err := service.vm.FormatAddress(xChainID, addr)
+	assert.NoError(err)
+	reply := BuildUnsignedTxReply{}
+	err = service.BuildExportAVAXTx(nil, &BuildExportAVAXTxArgs{
+		Amount: 100,
+		To:     xChainAddr,
+	}, &reply)
+	assert.ErrorIs(err, errNoAddresses)
+
+	stakeAmount := cjson.Uint64(service.vm.MinValidatorStake)
+	reply = BuildUnsignedTxReply{}
+	assert.NoError(service.BuildAddValidatorTx(nil, &BuildAddValidatorTxArgs{
+		JSONFromAddrs: api.JSONFromAddrs{From: []string{addrStr}},
+		APIStaker: APIStaker{
+			NodeID:      nodeID.PrefixedString(constants.NodeIDPrefix),
+			StakeAmount: &stakeAmount,
+			StartTime:   cjson.Uint64(startTime.Unix()),
+			EndTime:     cjson.Uint64(endTime.Unix()),
+		},
+		APIRewardOwnerArgs: APIRewardOwnerArgs{RewardAddress: addrStr},
+		DelegationFeeRate:  2,
+		Encoding:           formatting.Hex,
+	}, &reply))
+
+	unsignedBytes, err := formatting.Decode(reply.Encoding, reply.UnsignedTx)
+	assert.NoError(err)
+	var utx UnsignedTx
+	_, err = Codec.Unmarshal(unsignedBytes, &utx)
+	assert.NoError(err)
+	addValidatorTx, ok := utx.(*UnsignedAddValidatorTx)
+	assert.True(ok)
+	assert.Equal(nodeID, addValidatorTx.Validator.NodeID)
+
+	// The UTXOs and signers are given in the order of the tx's inputs
+	assert.Len(reply.UTXOs, len(addValidatorTx.Ins))
+	assert.Len(reply.Signers, len(addValidatorTx.Ins))
+	for i, utxoStr := range reply.UTXOs {
+		utxoBytes, err := formatting.Decode(reply.Encoding, utxoStr)
+		assert.NoError(err)
+		utxo := &avax.UTXO{}
+		_, err = Codec.Unmarshal(utxoBytes, utxo)
+		assert.NoError(err)
+		assert.Equal(addValidatorTx.Ins[i].InputID(), utxo.InputID())
+		assert.Equal([]string{addrStr}, reply.Signers[i])
+	}
+
+	// Sign the tx as an offline signer would
+	sig, err := key.SignHash(hashing.ComputeHash256(unsignedBytes))
+	assert.NoError(err)
+	cred := &secp256k1fx.Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, 1)}
+	copy(cred.Sigs[0][:], sig)
+	signedTx := &Tx{UnsignedTx: utx}
+	for range reply.Signers {
+		signedTx.Creds = append(signedTx.Creds, cred)
+	}
+	signedBytes, err := Codec.Marshal(CodecVersion, signedTx)
+	assert.NoError(err)
+
+	// The tx must be identical to the one signed by the node
+	expectedTx, err := service.vm.newAddValidatorTx(
+		uint64(stakeAmount),
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		addr,
+		20000,
+		[]*crypto.PrivateKeySECP256K1R{key},
+		addr,
+	)
+	assert.NoError(err)
+	assert.Equal(expectedTx.UnsignedBytes(), unsignedBytes)
+	assert.Equal(expectedTx.Bytes(), signedBytes)
+
+	signedTxStr, err := formatting.EncodeWithChecksum(formatting.Hex, signedBytes)
+	assert.NoError(err)
+	issueReply := api.JSONTxID{}
+	assert.NoError(service.IssueTx(nil, &api.FormattedTx{
+		Tx:       signedTxStr,
+		Encoding: formatting.Hex,
+	}, &issueReply))
+	assert.Equal(expectedTx.ID(), issueReply.TxID)
 }
@@ -4,8 +4,10 @@
 package platformvm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
@@ -21,8 +23,22 @@ var (
 	errWrongLocktime                = errors.New("wrong locktime reported")
 	errUnknownOwners                = errors.New("unknown owners")
 	errCantSign                     = errors.New("can't sign")
+	errInvalidCredentials           = errors.New("invalid credentials")
+	errInsufficientFunds            = errors.New("insufficient funds")
 )
 
+// unreservedUTXOs returns the UTXOs in [utxos] that aren't already consumed
+// by a pending tx, so that txs built in quick succession don't double spend.
+func (vm *VM) unreservedUTXOs(utxos []*avax.UTXO) []*avax.UTXO {
+	unreserved := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !vm.blockBuilder.IsReserved(utxo.InputID()) {
+			unreserved = append(unreserved, utxo)
+		}
+	}
+	return unreserved
+}
+
 // stake the provided amount while deducting the provided fee.
 // Arguments:
 // - [keys] are the owners of the funds
@@ -48,16 +64,34 @@ func (vm *VM) stake(
 	[][]*crypto.PrivateKeySECP256K1R, // signers
 	error,
 ) {
-	addrs := ids.NewShortSet(len(keys)) // The addresses controlled by [keys]
-	for _, key := range keys {
-		addrs.Add(key.PublicKey().Address())
+	kc := secp256k1fx.NewKeychain(keys...)
+	ins, returnedOuts, stakedOuts, signerAddrs, err := vm.stakeFromAddrs(kc.Addresses(), amount, fee, changeAddr)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
-	utxos, err := vm.getAllUTXOs(addrs) // The UTXOs controlled by [keys]
+	return ins, returnedOuts, stakedOuts, signerKeys(kc, signerAddrs), nil
+}
+
+// stakeFromAddrs is the same as [stake] but only requires the addresses that
+// own the funds. Rather than the keys that must sign each input, it returns
+// the addresses of those keys, so that the tx can be signed elsewhere.
+func (vm *VM) stakeFromAddrs(
+	addrs ids.ShortSet,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // returnedOutputs
+	[]*avax.TransferableOutput, // stakedOutputs
+	[][]ids.ShortID, // signers
+	error,
+) {
+	utxos, err := vm.getAllUTXOs(addrs) // The UTXOs controlled by [addrs]
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
-
-	kc := secp256k1fx.NewKeychain(keys...) // Keychain consumes UTXOs and creates new ones
+	utxos = vm.unreservedUTXOs(utxos)
 
 	// Minimum time this transaction will be issued at
 	now := uint64(vm.clock.Time().Unix())
@@ -65,7 +99,7 @@ func (vm *VM) stake(
 	ins := []*avax.TransferableInput{}
 	returnedOuts := []*avax.TransferableOutput{}
 	stakedOuts := []*avax.TransferableOutput{}
-	signers := [][]*crypto.PrivateKeySECP256K1R{}
+	signers := [][]ids.ShortID{}
 
 	// Amount of AVAX that has been staked
 	amountStaked := uint64(0)
@@ -100,7 +134,7 @@ func (vm *VM) stake(
 			continue
 		}
 
-		inIntf, inSigners, err := kc.Spend(out.TransferableOut, now)
+		inIntf, inSigners, err := spendOutput(addrs, out.TransferableOut, now)
 		if err != nil {
 			// We couldn't spend the output, so move on to the next one
 			continue
@@ -190,7 +224,7 @@ func (vm *VM) stake(
 			out = inner.TransferableOut
 		}
 
-		inIntf, inSigners, err := kc.Spend(out, now)
+		inIntf, inSigners, err := spendOutput(addrs, out, now)
 		if err != nil {
 			// We couldn't spend this UTXO, so we skip to the next one
 			continue
@@ -268,13 +302,87 @@ func (vm *VM) stake(
 			amountBurned, amountStaked, fee, amount)
 	}
 
-	avax.SortTransferableInputsWithSigners(ins, signers) // sort inputs and keys
-	avax.SortTransferableOutputs(returnedOuts, Codec)    // sort outputs
-	avax.SortTransferableOutputs(stakedOuts, Codec)      // sort outputs
+	sortTransferableInputsWithSignerAddrs(ins, signers) // sort inputs and signers
+	avax.SortTransferableOutputs(returnedOuts, Codec)   // sort outputs
+	avax.SortTransferableOutputs(stakedOuts, Codec)     // sort outputs
 
 	return ins, returnedOuts, stakedOuts, signers, nil
 }
 
+// spendOutput attempts to create an input that spends [out] with keys for
+// [addrs]. It mirrors [secp256k1fx.Keychain.Spend] but returns the addresses
+// of the keys that must sign the input.
+func spendOutput(addrs ids.ShortSet, out verify.Verifiable, time uint64) (verify.Verifiable, []ids.ShortID, error) {
+	transferOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+	}
+
+	owners := &transferOut.OutputOwners
+	if time < owners.Locktime {
+		return nil, nil, errCantSign
+	}
+	sigIndices := make([]uint32, 0, owners.Threshold)
+	signers := make([]ids.ShortID, 0, owners.Threshold)
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(signers)) < owners.Threshold; i++ {
+		if addr := owners.Addrs[i]; addrs.Contains(addr) {
+			sigIndices = append(sigIndices, i)
+			signers = append(signers, addr)
+		}
+	}
+	if uint32(len(signers)) != owners.Threshold {
+		return nil, nil, errCantSign
+	}
+	return &secp256k1fx.TransferInput{
+		Amt: transferOut.Amt,
+		Input: secp256k1fx.Input{
+			SigIndices: sigIndices,
+		},
+	}, signers, nil
+}
+
+// signerKeys returns the keys in [kc] for each of the addresses in [signers]
+func signerKeys(kc *secp256k1fx.Keychain, signers [][]ids.ShortID) [][]*crypto.PrivateKeySECP256K1R {
+	keys := make([][]*crypto.PrivateKeySECP256K1R, len(signers))
+	for i, addrs := range signers {
+		keys[i] = make([]*crypto.PrivateKeySECP256K1R, len(addrs))
+		for j, addr := range addrs {
+			keys[i][j], _ = kc.Get(addr)
+		}
+	}
+	return keys
+}
+
+type innerSortTransferableInputsWithSignerAddrs struct {
+	ins     []*avax.TransferableInput
+	signers [][]ids.ShortID
+}
+
+func (ins *innerSortTransferableInputsWithSignerAddrs) Less(i, j int) bool {
+	iID, iIndex := ins.ins[i].InputSource()
+	jID, jIndex := ins.ins[j].InputSource()
+
+	switch bytes.Compare(iID[:], jID[:]) {
+	case -1:
+		return true
+	case 0:
+		return iIndex < jIndex
+	default:
+		return false
+	}
+}
+func (ins *innerSortTransferableInputsWithSignerAddrs) Len() int { return len(ins.ins) }
+func (ins *innerSortTransferableInputsWithSignerAddrs) Swap(i, j int) {
+	ins.ins[j], ins.ins[i] = ins.ins[i], ins.ins[j]
+	ins.signers[j], ins.signers[i] = ins.signers[i], ins.signers[j]
+}
+
+// sortTransferableInputsWithSignerAddrs sorts the inputs and signers the same
+// way as [avax.SortTransferableInputsWithSigners]
+func sortTransferableInputsWithSignerAddrs(ins []*avax.TransferableInput, signers [][]ids.ShortID) {
+	sort.Sort(&innerSortTransferableInputsWithSignerAddrs{ins: ins, signers: signers})
+}
+
 // authorize an operation on behalf of the named subnet with the provided keys.
 func (vm *VM) authorize(
 	vs MutableState,
@@ -376,7 +484,7 @@ func (vm *VM) semanticVerifySpendUTXOs(
 	}
 	for _, cred := range creds { // Verify credentials are well-formed.
 		if err := cred.Verify(); err != nil {
-			return permError{err}
+			return permError{credentialsError{err}}
 		}
 	}
 
@@ -426,9 +534,9 @@ func (vm *VM) semanticVerifySpendUTXOs(
 
 		// Verify that this tx's credentials allow [in] to be spent
 		if err := vm.fx.VerifyTransfer(tx, in, creds[index], out); err != nil {
-			return permError{
+			return permError{credentialsError{
 				fmt.Errorf("failed to verify transfer: %w", err),
-			}
+			}}
 		}
 
 		amount := in.Amount()
@@ -523,7 +631,14 @@ func (vm *VM) semanticVerifySpendUTXOs(
 			if producedAmount > consumedAmount {
 				increase := producedAmount - consumedAmount
 				if increase > unlockedConsumed {
-					return permError{fmt.Errorf("address %s produces %d unlocked and consumes %d unlocked for locktime %d", ownerID, increase, unlockedConsumed, locktime)}
+					return permError{fmt.Errorf(
+						"%w: address %s produces %d unlocked and consumes %d unlocked for locktime %d",
+						errInsufficientFunds,
+						ownerID,
+						increase,
+						unlockedConsumed,
+						locktime,
+					)}
 				}
 				unlockedConsumed -= increase
 			}
@@ -532,7 +647,12 @@ func (vm *VM) semanticVerifySpendUTXOs(
 
 	// More unlocked tokens produced than consumed. Invalid.
 	if unlockedProduced > unlockedConsumed {
-		return permError{fmt.Errorf("tx produces more unlocked (%d) than it consumes (%d)", unlockedProduced, unlockedConsumed)}
+		return permError{fmt.Errorf(
+			"%w: tx produces more unlocked (%d) than it consumes (%d)",
+			errInsufficientFunds,
+			unlockedProduced,
+			unlockedConsumed,
+		)}
 	}
 
 	return nil
@@ -567,3 +687,55 @@ func produceOutputs(
 		})
 	}
 }
+
+// burnedAmount returns the amount of tokens that [tx] consumes but doesn't
+// produce. Only decision txs are supported, the burned amount of any other tx
+// is reported as 0.
+// Precondition: [tx] has already been semantically verified
+func burnedAmount(tx UnsignedTx) (uint64, error) {
+	var (
+		ins  [][]*avax.TransferableInput
+		outs [][]*avax.TransferableOutput
+	)
+	switch utx := tx.(type) {
+	case *UnsignedCreateChainTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *UnsignedCreateSubnetTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *UnsignedRemoveSubnetValidatorTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *UnsignedImportTx:
+		ins = [][]*avax.TransferableInput{utx.Ins, utx.ImportedInputs}
+		outs = [][]*avax.TransferableOutput{utx.Outs}
+	case *UnsignedExportTx:
+		ins = [][]*avax.TransferableInput{utx.Ins}
+		outs = [][]*avax.TransferableOutput{utx.Outs, utx.ExportedOutputs}
+	default:
+		return 0, nil
+	}
+
+	consumed := uint64(0)
+	for _, inputs := range ins {
+		for _, in := range inputs {
+			newConsumed, err := math.Add64(consumed, in.In.Amount())
+			if err != nil {
+				return 0, err
+			}
+			consumed = newConsumed
+		}
+	}
+	produced := uint64(0)
+	for _, outputs := range outs {
+		for _, out := range outputs {
+			newProduced, err := math.Add64(produced, out.Out.Amount())
+			if err != nil {
+				return 0, err
+			}
+			produced = newProduced
+		}
+	}
+	return math.Sub64(consumed, produced)
+}
@@ -6,14 +6,32 @@ package platformvm
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// maxStandardBlockSize is the maximum size, in bytes, of a valid
+	// StandardBlock. It is a consensus rule, so unlike the limit on the size of
+	// the blocks this node builds, it isn't configurable. It matches the
+	// maximum size of a message that [Codec] will unmarshal.
+	maxStandardBlockSize = 256 * units.KiB
+
+	// standardBlockOverhead is the size of a serialized StandardBlock that
+	// contains no txs: the codec version, the block's type ID, its parent ID,
+	// its height and the number of txs.
+	standardBlockOverhead = wrappers.ShortLen + wrappers.IntLen + hashing.HashLen + wrappers.LongLen + wrappers.IntLen
 )
 
 var (
-	errConflictingBatchTxs = errors.New("block contains conflicting transactions")
+	errConflictingBatchTxs   = errors.New("block contains conflicting transactions")
+	errStandardBlockTooLarge = errors.New("standard block is too large")
 
 	_ Block    = &StandardBlock{}
 	_ decision = &StandardBlock{}
@@ -31,6 +49,13 @@ type StandardBlock struct {
 	inputs ids.Set
 }
 
+// standardBlockTxSize returns the number of bytes [tx] adds to the
+// serialization of a StandardBlock. Txs in a block aren't prefixed with the
+// codec version.
+func standardBlockTxSize(tx *Tx) int {
+	return len(tx.Bytes()) - wrappers.ShortLen
+}
+
 func (sb *StandardBlock) initialize(vm *VM, bytes []byte, status choices.Status, blk Block) error {
 	if err := sb.CommonDecisionBlock.initialize(vm, bytes, status, blk); err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
@@ -79,6 +104,22 @@ func (sb *StandardBlock) Verify() error {
 		return err
 	}
 
+	if blkSize := len(sb.Bytes()); blkSize > maxStandardBlockSize {
+		if err := sb.Reject(); err != nil {
+			sb.vm.ctx.Log.Error(
+				"failed to reject standard block %s due to %s",
+				blkID,
+				err,
+			)
+		}
+		return fmt.Errorf(
+			"%w: %d bytes exceeds the maximum of %d",
+			errStandardBlockTooLarge,
+			blkSize,
+			maxStandardBlockSize,
+		)
+	}
+
 	parentIntf, err := sb.parentBlock()
 	if err != nil {
 		return err
@@ -137,9 +178,13 @@ func (sb *StandardBlock) Verify() error {
 		// Add UTXOs to batch
 		sb.inputs.Union(inputUTXOs)
 
+		executeStart := time.Now()
 		onAccept, err := utx.Execute(sb.vm, sb.onAcceptState, tx)
+		tx.verifyDuration = time.Since(executeStart)
 		if err != nil {
 			sb.vm.droppedTxCache.Put(txID, err.Error()) // cache tx as dropped
+			sb.vm.metrics.MarkTxVerifyFailure(tx, err)
+			sb.vm.blockBuilder.ReleaseUTXOs(tx)
 			if err := sb.Reject(); err != nil {
 				sb.vm.ctx.Log.Error(
 					"failed to reject standard block %s due to %s",
@@ -186,6 +231,7 @@ func (sb *StandardBlock) Verify() error {
 	sb.vm.blockBuilder.RemoveAtomicTxs(sb.Txs)
 	sb.vm.currentBlocks[blkID] = sb
 	parentIntf.addChild(sb)
+	sb.verifiedTime = time.Now()
 	return nil
 }
 
@@ -244,6 +290,9 @@ func (sb *StandardBlock) Accept() error {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
 
+	for _, tx := range sb.Txs {
+		sb.vm.blockBuilder.ReleaseUTXOs(tx)
+	}
 	for _, child := range sb.children {
 		child.setBaseState()
 	}
@@ -272,6 +321,7 @@ func (sb *StandardBlock) Reject() error {
 				tx.ID(),
 				err,
 			)
+			sb.vm.blockBuilder.ReleaseUTXOs(tx)
 		}
 	}
 	return sb.CommonDecisionBlock.Reject()
@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -95,3 +96,33 @@ func TestAtomicTxImports(t *testing.T) {
 	// Ensure standard block contains one atomic transaction
 	assert.Equal(b.(*StandardBlock).inputs.Len(), 1)
 }
+
+func TestStandardBlockMaxSize(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	preferred, err := vm.Preferred()
+	assert.NoError(err)
+	newBlock := func(size int) *StandardBlock {
+		sb := &StandardBlock{
+			CommonDecisionBlock: CommonDecisionBlock{
+				CommonBlock: CommonBlock{
+					PrntID: preferred.ID(),
+					Hght:   preferred.Height() + 1,
+				},
+			},
+		}
+		// Only the size of the serialized block matters here
+		assert.NoError(sb.initialize(vm, make([]byte, size), choices.Processing, sb))
+		return sb
+	}
+
+	assert.NoError(newBlock(maxStandardBlockSize).Verify())
+	assert.ErrorIs(newBlock(maxStandardBlockSize+1).Verify(), errStandardBlockTooLarge)
+}
@@ -19,11 +19,17 @@ import (
 // If [limit] <= 0 or [limit] > maxUTXOsToFetch, it is set to [maxUTXOsToFetch].
 // Only returns UTXOs associated with addresses >= [startAddr].
 // For address [startAddr], only returns UTXOs whose IDs are greater than
-// [startUTXOID].
+// [startUTXOID]. [startUTXOID] doesn't need to still exist, so a UTXO being
+// spent between calls doesn't cause other UTXOs to be skipped.
+// A UTXO that references multiple addresses in [addrs] is only returned for
+// the least of those addresses, so that paginating calls never return the
+// same UTXO twice.
 // Returns:
 // * The fetched UTXOs
 // * The address associated with the last UTXO fetched
 // * The ID of the last UTXO fetched
+// If no UTXOs are fetched, [startAddr] and [startUTXOID] are returned, so that
+// the cursor doesn't move back to the beginning.
 func (vm *VM) getPaginatedUTXOs(
 	addrs ids.ShortSet,
 	startAddr ids.ShortID,
@@ -34,12 +40,11 @@ func (vm *VM) getPaginatedUTXOs(
 		limit = maxUTXOsToFetch
 	}
 
-	lastAddr := ids.ShortEmpty
-	lastIndex := ids.Empty
+	lastAddr := startAddr
+	lastIndex := startUTXOID
 
 	utxos := make([]*avax.UTXO, 0, limit)
 	seen := make(ids.Set, limit) // IDs of UTXOs already in the list
-	searchSize := limit          // the limit diminishes which can impact the expected return
 
 	// enforces the same ordering for pagination
 	addrsList := addrs.SortedList()
@@ -52,34 +57,62 @@ func (vm *VM) getPaginatedUTXOs(
 			start = startUTXOID
 		}
 
-		utxoIDs, err := vm.internalState.UTXOIDs(addr.Bytes(), start, searchSize) // Get UTXOs associated with [addr]
-		if err != nil {
-			return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXOs for address %s: %w", addr, err)
-		}
-		for _, utxoID := range utxoIDs {
-			lastIndex = utxoID // The last searched UTXO - not the last found
-			lastAddr = addr    // The last address searched that has UTXOs (even duplicated) - not the last found
-
-			if seen.Contains(utxoID) { // Already have this UTXO in the list
-				continue
-			}
-
-			utxo, err := vm.internalState.GetUTXO(utxoID)
+		for {
+			utxoIDs, err := vm.internalState.UTXOIDs(addr.Bytes(), start, limit-len(utxos)) // Get UTXOs associated with [addr]
 			if err != nil {
-				return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
+				return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXOs for address %s: %w", addr, err)
+			}
+			if len(utxoIDs) == 0 {
+				break // Searched all of the UTXOs associated with [addr]
 			}
 
-			utxos = append(utxos, utxo)
-			seen.Add(utxoID)
-			limit--
-			if limit <= 0 {
-				return utxos, lastAddr, lastIndex, nil // Found [limit] utxos; stop.
+			for _, utxoID := range utxoIDs {
+				start = utxoID
+				lastIndex = utxoID // The last searched UTXO - not the last found
+				lastAddr = addr    // The last address searched that has UTXOs (even duplicated) - not the last found
+
+				if seen.Contains(utxoID) { // Already have this UTXO in the list
+					continue
+				}
+				seen.Add(utxoID)
+
+				utxo, err := vm.internalState.GetUTXO(utxoID)
+				if err != nil {
+					return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
+				}
+				if referencesLesserAddr(utxo, addr, addrs) { // Returned for a previous address
+					continue
+				}
+
+				utxos = append(utxos, utxo)
+				if len(utxos) >= limit {
+					return utxos, lastAddr, lastIndex, nil // Found [limit] utxos; stop.
+				}
 			}
 		}
 	}
 	return utxos, lastAddr, lastIndex, nil // Didnt reach the [limit] utxos; no more were found
 }
 
+// referencesLesserAddr returns true if [utxo] references an address in [addrs]
+// that is less than [addr].
+func referencesLesserAddr(utxo *avax.UTXO, addr ids.ShortID, addrs ids.ShortSet) bool {
+	addressable, ok := utxo.Out.(avax.Addressable)
+	if !ok {
+		return false
+	}
+	for _, addrBytes := range addressable.Addresses() {
+		otherAddr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			continue
+		}
+		if addrs.Contains(otherAddr) && bytes.Compare(otherAddr.Bytes(), addr.Bytes()) == -1 {
+			return true
+		}
+	}
+	return false
+}
+
 func (vm *VM) getAllUTXOs(
 	addrs ids.ShortSet,
 ) ([]*avax.UTXO, error) {
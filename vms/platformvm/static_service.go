@@ -76,8 +76,12 @@ type APIPrimaryValidator struct {
 	Uptime             *json.Float32 `json:"uptime,omitempty"`
 	Connected          *bool         `json:"connected,omitempty"`
 	Staked             []APIUTXO     `json:"staked,omitempty"`
+	// The number of delegators delegating to this validator
+	DelegatorCount *json.Uint64 `json:"delegatorCount,omitempty"`
+	// The total amount delegated to this validator
+	DelegatorWeight *json.Uint64 `json:"delegatorWeight,omitempty"`
 	// The delegators delegating to this validator
-	Delegators []APIPrimaryDelegator `json:"delegators"`
+	Delegators []APIPrimaryDelegator `json:"delegators,omitempty"`
 }
 
 // APIPrimaryDelegator is the repr. of a primary network delegator sent over APIs.
@@ -4,6 +4,8 @@
 package platformvm
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,11 +15,17 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
+var (
+	errUnsupportedTxJSON         = errors.New("tx type doesn't support JSON marshalling")
+	errUnsupportedCredentialJSON = errors.New("credential type doesn't support JSON marshalling")
+)
+
 type TimedTx interface {
 	ID() ids.ID
 	StartTime() time.Time
@@ -97,6 +105,14 @@ type Tx struct {
 
 	// The credentials of this transaction
 	Creds []verify.Verifiable `serialize:"true" json:"credentials"`
+
+	// Time spent executing this transaction during its most recent
+	// verification. Only used for metrics.
+	verifyDuration time.Duration
+
+	// Amount of AVAX burned by this transaction. Set when the transaction is
+	// added to the mempool. Only used to prioritize transactions.
+	burned uint64
 }
 
 // Sign this transaction with the provided signers
@@ -129,3 +145,39 @@ func (tx *Tx) Sign(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) er
 	tx.Initialize(unsignedBytes, signedBytes)
 	return nil
 }
+
+// MarshalJSON marshals [tx] as JSON with human readable addresses. InitCtx
+// must be called before marshalling [tx]. Returns an error, rather than
+// guessing at a representation, if the unsigned tx or one of the credentials
+// is of a type that doesn't describe its own JSON format.
+func (tx *Tx) MarshalJSON() ([]byte, error) {
+	utx, ok := tx.UnsignedTx.(json.Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errUnsupportedTxJSON, tx.UnsignedTx)
+	}
+
+	creds := make([]map[string]interface{}, len(tx.Creds))
+	for i, credIntf := range tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", errUnsupportedCredentialJSON, credIntf)
+		}
+		sigs := make([]string, len(cred.Sigs))
+		for j, sig := range cred.Sigs {
+			sigStr, err := formatting.EncodeWithoutChecksum(formatting.Hex, sig[:])
+			if err != nil {
+				return nil, fmt.Errorf("couldn't convert signature to string: %w", err)
+			}
+			sigs[j] = sigStr
+		}
+		creds[i] = map[string]interface{}{
+			"signatures":    sigs,
+			"numSignatures": len(sigs),
+		}
+	}
+	return json.Marshal(map[string]interface{}{
+		"id":          tx.ID(),
+		"unsignedTx":  utx,
+		"credentials": creds,
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import "math/bits"
+
+var _ TxHeap = &txHeapByFeeRate{}
+
+// txHeapByFeeRate orders txs by decreasing fee per byte. Txs paying the same
+// fee per byte are ordered by age.
+type txHeapByFeeRate struct {
+	txHeap
+}
+
+func NewTxHeapByFeeRate() TxHeap {
+	h := &txHeapByFeeRate{}
+	h.initialize(h)
+	return h
+}
+
+func (h *txHeapByFeeRate) Less(i, j int) bool {
+	iTx := h.txs[i].tx
+	jTx := h.txs[j].tx
+
+	// Compare iTx.burned/len(iTx.Bytes()) to jTx.burned/len(jTx.Bytes())
+	// without losing precision.
+	iHi, iLo := bits.Mul64(iTx.burned, uint64(len(jTx.Bytes())))
+	jHi, jLo := bits.Mul64(jTx.burned, uint64(len(iTx.Bytes())))
+	switch {
+	case iHi != jHi:
+		return iHi > jHi
+	case iLo != jLo:
+		return iLo > jLo
+	default:
+		return h.txs[i].age < h.txs[j].age
+	}
+}
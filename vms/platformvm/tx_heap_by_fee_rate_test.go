@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/avm"
+)
+
+func TestTxHeapByFeeRate(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+
+	txs := make([]*Tx, 3)
+	for i := range txs {
+		tx, err := vm.newCreateChainTx(
+			testSubnet1.ID(),
+			nil,
+			avm.ID,
+			nil,
+			fmt.Sprintf("chain %d", i),
+			[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+		txs[i] = tx
+	}
+	txs[0].burned = 1000 * uint64(len(txs[0].Bytes()))
+	txs[1].burned = 2000 * uint64(len(txs[1].Bytes()))
+	txs[2].burned = 2000 * uint64(len(txs[2].Bytes()))
+
+	txHeap := NewTxHeapByFeeRate()
+	for _, tx := range txs {
+		txHeap.Add(tx)
+	}
+
+	// The highest fee rate is removed first, with ties broken by the order the
+	// txs were added in.
+	assert.Equal(txs[1].ID(), txHeap.RemoveTop().ID())
+	assert.Equal(txs[2].ID(), txHeap.RemoveTop().ID())
+	assert.Equal(txs[0].ID(), txHeap.RemoveTop().ID())
+	assert.Zero(txHeap.Len())
+}
@@ -43,6 +43,17 @@ func (v *Validator) Duration() time.Duration { return v.EndTime().Sub(v.StartTim
 // Weight is this validator's weight when sampling
 func (v *Validator) Weight() uint64 { return v.Wght }
 
+// jsonFields returns the JSON representation of this validator, keyed by the
+// JSON field names, with the node ID in its human readable format.
+func (v *Validator) jsonFields() map[string]interface{} {
+	return map[string]interface{}{
+		"nodeID": v.NodeID.PrefixedString(constants.NodeIDPrefix),
+		"start":  v.Start,
+		"end":    v.End,
+		"weight": v.Wght,
+	}
+}
+
 // Verify validates the ID for this validator
 func (v *Validator) Verify() error {
 	switch {
@@ -71,6 +82,14 @@ type SubnetValidator struct {
 // SubnetID is the ID of the subnet this validator is validating
 func (v *SubnetValidator) SubnetID() ids.ID { return v.Subnet }
 
+// jsonFields returns the JSON representation of this validator, keyed by the
+// JSON field names.
+func (v *SubnetValidator) jsonFields() map[string]interface{} {
+	fields := v.Validator.jsonFields()
+	fields["subnet"] = v.Subnet
+	return fields
+}
+
 // Verify this validator is valid
 func (v *SubnetValidator) Verify() error {
 	switch v.Subnet {
@@ -31,6 +31,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -46,7 +47,6 @@ const (
 	// PercentDenominator is the denominator used to calculate percentages
 	PercentDenominator = 1000000
 
-	droppedTxCacheSize     = 64
 	validatorSetsCacheSize = 64
 
 	maxUTXOsToFetch = 1024
@@ -61,7 +61,8 @@ const (
 	MinConsumptionRate = 100000 // 10%
 
 	// MaxValidatorWeightFactor is the maximum factor of the validator stake
-	// that is allowed to be placed on a validator.
+	// that is allowed to be placed on a validator, if the Factory doesn't
+	// specify one.
 	MaxValidatorWeightFactor uint64 = 5
 
 	// SupplyCap is the maximum amount of AVAX that should ever exist
@@ -69,6 +70,11 @@ const (
 
 	// Maximum future start time for staking/delegating
 	maxFutureStartTime = 24 * 7 * 2 * time.Hour
+
+	// How often the observed validator uptimes are written to disk. If the
+	// node stops without shutting down cleanly, at most this much of the
+	// observed uptime is unaccounted for.
+	uptimePersistFrequency = 5 * time.Minute
 )
 
 var (
@@ -77,6 +83,7 @@ var (
 	errStartTimeTooEarly = errors.New("start time is before the current chain time")
 	errStartAfterEndTime = errors.New("start time is after the end time")
 	errWrongCacheType    = errors.New("unexpectedly cached type")
+	errHeightNotRetained = errors.New("validator set diffs are no longer retained for the requested height")
 
 	_ block.ChainVM        = &VM{}
 	_ validators.Connector = &VM{}
@@ -88,6 +95,8 @@ var (
 type VM struct {
 	Factory
 	metrics
+
+	config Config
 	avax.AddressManager
 	avax.AtomicUTXOManager
 	*network
@@ -102,6 +111,9 @@ type VM struct {
 
 	uptimeManager uptime.Manager
 
+	// Periodically writes the observed validator uptimes to disk
+	uptimePersistTimer *timer.Timer
+
 	// The context of this vm
 	ctx       *snow.Context
 	dbManager manager.Manager
@@ -131,6 +143,9 @@ type VM struct {
 	// Value: String repr. of the verification error
 	droppedTxCache cache.LRU
 
+	// Publishes accepted blocks and transactions to websocket subscribers
+	events *eventServer
+
 	// Maps caches for each subnet that is currently whitelisted.
 	// Key: Subnet ID
 	// Value: cache mapping height -> validator set map
@@ -157,6 +172,13 @@ func (vm *VM) Initialize(
 ) error {
 	ctx.Log.Verbo("initializing platform chain")
 
+	config, err := parseConfig(configBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+	vm.config = config
+	ctx.Log.Info("VM config initialized %+v", config)
+
 	registerer := prometheus.NewRegistry()
 	if err := ctx.Metrics.Register(registerer); err != nil {
 		return err
@@ -184,7 +206,8 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	vm.droppedTxCache = cache.LRU{Size: droppedTxCacheSize}
+	vm.droppedTxCache = cache.LRU{Size: vm.config.DroppedTxCacheSize}
+	vm.events = newEventServer(ctx.Log)
 	vm.validatorSetCaches = make(map[ids.ID]cache.Cacher)
 	vm.currentBlocks = make(map[ids.ID]Block)
 
@@ -205,6 +228,16 @@ func (vm *VM) Initialize(
 	// Initialize the utility to track validator uptimes
 	vm.uptimeManager = uptime.NewManager(is)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &ctx.Lock, vm.uptimeManager)
+	vm.uptimePersistTimer = timer.NewTimer(func() {
+		vm.ctx.Lock.Lock()
+		defer vm.ctx.Lock.Unlock()
+
+		if err := vm.persistUptimes(); err != nil {
+			vm.ctx.Log.Error("failed to persist validator uptimes: %s", err)
+		}
+		vm.uptimePersistTimer.SetTimeoutIn(uptimePersistFrequency)
+	})
+	go vm.ctx.Log.RecoverAndPanic(vm.uptimePersistTimer.Dispatch)
 
 	if err := vm.updateValidators(true); err != nil {
 		return fmt.Errorf(
@@ -224,6 +257,15 @@ func (vm *VM) Initialize(
 	vm.lastAcceptedID = is.GetLastAccepted()
 
 	ctx.Log.Info("initializing last accepted block as %s", vm.lastAcceptedID)
+	lastAccepted, err := vm.getBlock(vm.lastAcceptedID)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to get last accepted block %s: %w",
+			vm.lastAcceptedID,
+			err,
+		)
+	}
+	vm.metrics.lastAcceptedHeight.Set(float64(lastAccepted.Height()))
 
 	// Build off the most recently accepted block
 	return vm.SetPreference(vm.lastAcceptedID)
@@ -318,21 +360,26 @@ func (vm *VM) Bootstrapped() error {
 		return errs.Err
 	}
 
-	primaryValidatorSet, exist := vm.Validators.GetValidators(constants.PrimaryNetworkID)
-	if !exist {
-		return errNoPrimaryValidators
+	// Now that the chain state is up to date, re-admit the txs that were in
+	// the mempool when the node last shut down
+	if err := vm.blockBuilder.restorePersistedTxs(); err != nil {
+		return err
 	}
-	primaryValidators := primaryValidatorSet.List()
 
-	validatorIDs := make([]ids.ShortID, len(primaryValidators))
-	for i, vdr := range primaryValidators {
-		validatorIDs[i] = vdr.ID()
+	validatorIDs, err := vm.primaryValidatorIDs()
+	if err != nil {
+		return err
 	}
 
 	if err := vm.uptimeManager.StartTracking(validatorIDs); err != nil {
 		return err
 	}
-	return vm.internalState.Commit()
+	if err := vm.internalState.Commit(); err != nil {
+		return err
+	}
+
+	vm.uptimePersistTimer.SetTimeoutIn(uptimePersistFrequency)
+	return nil
 }
 
 // Shutdown this blockchain
@@ -343,16 +390,18 @@ func (vm *VM) Shutdown() error {
 
 	vm.blockBuilder.Shutdown()
 
-	if vm.bootstrapped.GetValue() {
-		primaryValidatorSet, exist := vm.Validators.GetValidators(constants.PrimaryNetworkID)
-		if !exist {
-			return errNoPrimaryValidators
-		}
-		primaryValidators := primaryValidatorSet.List()
+	if vm.uptimePersistTimer != nil {
+		// The lock must be released before stopping the timer, as the timer may
+		// be about to persist the uptimes.
+		vm.ctx.Lock.Unlock()
+		vm.uptimePersistTimer.Stop()
+		vm.ctx.Lock.Lock()
+	}
 
-		validatorIDs := make([]ids.ShortID, len(primaryValidators))
-		for i, vdr := range primaryValidators {
-			validatorIDs[i] = vdr.ID()
+	if vm.bootstrapped.GetValue() {
+		validatorIDs, err := vm.primaryValidatorIDs()
+		if err != nil {
+			return err
 		}
 
 		if err := vm.uptimeManager.Shutdown(validatorIDs); err != nil {
@@ -371,6 +420,40 @@ func (vm *VM) Shutdown() error {
 	return errs.Err
 }
 
+// persistUptimes writes the uptimes of the current primary network validators,
+// as observed up to now, to disk.
+func (vm *VM) persistUptimes() error {
+	if !vm.bootstrapped.GetValue() {
+		return nil
+	}
+
+	validatorIDs, err := vm.primaryValidatorIDs()
+	if err != nil {
+		return err
+	}
+
+	if err := vm.uptimeManager.Sync(validatorIDs); err != nil {
+		return err
+	}
+	return vm.internalState.Commit()
+}
+
+// primaryValidatorIDs returns the node IDs of the current primary network
+// validators.
+func (vm *VM) primaryValidatorIDs() ([]ids.ShortID, error) {
+	primaryValidatorSet, exist := vm.Validators.GetValidators(constants.PrimaryNetworkID)
+	if !exist {
+		return nil, errNoPrimaryValidators
+	}
+	primaryValidators := primaryValidatorSet.List()
+
+	validatorIDs := make([]ids.ShortID, len(primaryValidators))
+	for i, vdr := range primaryValidators {
+		validatorIDs[i] = vdr.ID()
+	}
+	return validatorIDs, nil
+}
+
 // BuildBlock builds a block to be added to consensus
 func (vm *VM) BuildBlock() (snowman.Block, error) { return vm.blockBuilder.BuildBlock() }
 
@@ -455,6 +538,10 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		"": {
 			Handler: server,
 		},
+		"/events": {
+			LockOptions: common.NoLock,
+			Handler:     vm.events,
+		},
 	}, nil
 }
 
@@ -479,7 +566,10 @@ func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
 
 // Connected implements validators.Connector
 func (vm *VM) Connected(vdrID ids.ShortID) error {
-	return vm.uptimeManager.Connect(vdrID)
+	if err := vm.uptimeManager.Connect(vdrID); err != nil {
+		return err
+	}
+	return vm.updateSubnetPercentConnected()
 }
 
 // Disconnected implements validators.Connector
@@ -487,6 +577,9 @@ func (vm *VM) Disconnected(vdrID ids.ShortID) error {
 	if err := vm.uptimeManager.Disconnect(vdrID); err != nil {
 		return err
 	}
+	if err := vm.updateSubnetPercentConnected(); err != nil {
+		return err
+	}
 	return vm.internalState.Commit()
 }
 
@@ -502,6 +595,23 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.ShortID]u
 		}
 	}
 
+	lastAcceptedHeight, err := vm.GetCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+	if lastAcceptedHeight < height {
+		return nil, database.ErrNotFound
+	}
+	if lastAcceptedHeight-height > vm.config.ValidatorDiffsRetention {
+		return nil, fmt.Errorf(
+			"%w: height %d is more than %d blocks behind the last accepted height %d",
+			errHeightNotRetained,
+			height,
+			vm.config.ValidatorDiffsRetention,
+			lastAcceptedHeight,
+		)
+	}
+
 	if validatorSetIntf, ok := validatorSetsCache.Get(height); ok {
 		validatorSet, ok := validatorSetIntf.(map[ids.ShortID]uint64)
 		if !ok {
@@ -511,14 +621,6 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.ShortID]u
 		return validatorSet, nil
 	}
 
-	lastAcceptedHeight, err := vm.GetCurrentHeight()
-	if err != nil {
-		return nil, err
-	}
-	if lastAcceptedHeight < height {
-		return nil, database.ErrNotFound
-	}
-
 	// get the start time to track metrics
 	startTime := vm.Clock().Time()
 
@@ -611,9 +713,74 @@ func (vm *VM) updateValidators(force bool) error {
 			return err
 		}
 	}
+	if err := vm.updateSubnetPercentConnected(); err != nil {
+		return err
+	}
+	return vm.updateValidatorSetMetrics()
+}
+
+// updateValidatorSetMetrics reports the number of current and pending
+// validators of every subnet.
+func (vm *VM) updateValidatorSetMetrics() error {
+	current, err := summarizeStakersBySubnet(vm.internalState.CurrentStakerChainState().Stakers())
+	if err != nil {
+		return err
+	}
+	pending, err := summarizeStakersBySubnet(vm.internalState.PendingStakerChainState().Stakers())
+	if err != nil {
+		return err
+	}
+	vm.metrics.SetValidatorSets(current, pending)
 	return nil
 }
 
+// stakerSummary describes the stakers of a subnet
+type stakerSummary struct {
+	// Number of validators. Delegators are not counted.
+	numValidators int
+	// Total weight staked, including delegations
+	weight uint64
+}
+
+// summarizeStakersBySubnet returns the number of validators, and the total
+// staked weight, in [stakers] of each subnet.
+func summarizeStakersBySubnet(stakers []*Tx) (map[ids.ID]stakerSummary, error) {
+	summaries := make(map[ids.ID]stakerSummary)
+	for _, tx := range stakers {
+		var (
+			subnetID    ids.ID
+			weight      uint64
+			isValidator = true
+		)
+		switch staker := tx.UnsignedTx.(type) {
+		case *UnsignedAddValidatorTx:
+			subnetID = constants.PrimaryNetworkID
+			weight = staker.Validator.Weight()
+		case *UnsignedAddSubnetValidatorTx:
+			subnetID = staker.Validator.Subnet
+			weight = staker.Validator.Weight()
+		case *UnsignedAddDelegatorTx:
+			subnetID = constants.PrimaryNetworkID
+			weight = staker.Validator.Weight()
+			isValidator = false
+		default:
+			return nil, errWrongTxType
+		}
+
+		summary := summaries[subnetID]
+		if isValidator {
+			summary.numValidators++
+		}
+		newWeight, err := safemath.Add64(summary.weight, weight)
+		if err != nil {
+			return nil, err
+		}
+		summary.weight = newWeight
+		summaries[subnetID] = summary
+	}
+	return summaries, nil
+}
+
 // Returns the time when the next staker of any subnet starts/stops staking
 // after the current timestamp
 func (vm *VM) nextStakerChangeTime(vs ValidatorState) (time.Time, error) {
@@ -652,12 +819,19 @@ func (vm *VM) Clock() *mockable.Clock { return &vm.clock }
 
 func (vm *VM) Logger() logging.Logger { return vm.ctx.Log }
 
-// Returns the percentage of the total stake on the Primary Network of nodes
-// connected to this node.
-func (vm *VM) getPercentConnected() (float64, error) {
-	vdrSet, exists := vm.Validators.GetValidators(constants.PrimaryNetworkID)
+// Returns the percentage of the total weight on [subnetID] of nodes connected
+// to this node. A subnet without any validator weight is reported as 0.
+func (vm *VM) getPercentConnected(subnetID ids.ID) (float64, error) {
+	vdrSet, exists := vm.Validators.GetValidators(subnetID)
 	if !exists {
-		return 0, errNoPrimaryValidators
+		if subnetID == constants.PrimaryNetworkID {
+			return 0, errNoPrimaryValidators
+		}
+		return 0, nil
+	}
+	totalWeight := vdrSet.Weight()
+	if totalWeight == 0 {
+		return 0, nil
 	}
 
 	vdrs := vdrSet.List()
@@ -675,7 +849,20 @@ func (vm *VM) getPercentConnected() (float64, error) {
 			return 0, err
 		}
 	}
-	return float64(connectedStake) / float64(vdrSet.Weight()), nil
+	return float64(connectedStake) / float64(totalWeight), nil
+}
+
+// updateSubnetPercentConnected updates the percent connected gauges of the
+// subnets this node tracks.
+func (vm *VM) updateSubnetPercentConnected() error {
+	for subnetID := range vm.WhitelistedSubnets {
+		percentConnected, err := vm.getPercentConnected(subnetID)
+		if err != nil {
+			return err
+		}
+		vm.percentConnectedSubnet.WithLabelValues(subnetID.String()).Set(percentConnected)
+	}
+	return nil
 }
 
 // TODO: remove after AP5
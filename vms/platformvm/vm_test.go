@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
@@ -31,6 +32,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -47,6 +49,7 @@ import (
 
 	smcon "github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+	uptimemeter "github.com/ava-labs/avalanchego/utils/uptime"
 )
 
 var (
@@ -74,6 +77,8 @@ var (
 	defaultMaxValidatorStake = 500 * units.MilliAvax
 	defaultMinDelegatorStake = 1 * units.MilliAvax
 
+	defaultMaxValidatorWeightFactor uint64 = 5
+
 	// amount all genesis validators have in defaultVM
 	defaultBalance = 100 * defaultMinValidatorStake
 
@@ -308,21 +313,22 @@ func BuildGenesisTestWithArgs(t *testing.T, args *BuildGenesisArgs) (*BuildGenes
 
 func defaultVM() (*VM, database.Database, *common.SenderTest) {
 	vm := &VM{Factory: Factory{
-		Chains:                 chains.MockManager{},
-		UptimeLockedCalculator: uptime.NewLockedCalculator(),
-		Validators:             validators.NewManager(),
-		TxFee:                  defaultTxFee,
-		CreateSubnetTxFee:      100 * defaultTxFee,
-		CreateBlockchainTxFee:  100 * defaultTxFee,
-		MinValidatorStake:      defaultMinValidatorStake,
-		MaxValidatorStake:      defaultMaxValidatorStake,
-		MinDelegatorStake:      defaultMinDelegatorStake,
-		MinStakeDuration:       defaultMinStakingDuration,
-		MaxStakeDuration:       defaultMaxStakingDuration,
-		StakeMintingPeriod:     defaultMaxStakingDuration,
-		ApricotPhase3Time:      defaultValidateEndTime,
-		ApricotPhase4Time:      defaultValidateEndTime,
-		ApricotPhase5Time:      defaultValidateEndTime,
+		Chains:                   chains.MockManager{},
+		UptimeLockedCalculator:   uptime.NewLockedCalculator(),
+		Validators:               validators.NewManager(),
+		TxFee:                    defaultTxFee,
+		CreateSubnetTxFee:        100 * defaultTxFee,
+		CreateBlockchainTxFee:    100 * defaultTxFee,
+		MinValidatorStake:        defaultMinValidatorStake,
+		MaxValidatorStake:        defaultMaxValidatorStake,
+		MaxValidatorWeightFactor: defaultMaxValidatorWeightFactor,
+		MinDelegatorStake:        defaultMinDelegatorStake,
+		MinStakeDuration:         defaultMinStakingDuration,
+		MaxStakeDuration:         defaultMaxStakingDuration,
+		StakeMintingPeriod:       defaultMaxStakingDuration,
+		ApricotPhase3Time:        defaultValidateEndTime,
+		ApricotPhase4Time:        defaultValidateEndTime,
+		ApricotPhase5Time:        defaultValidateEndTime,
 	}}
 
 	baseDBManager := manager.NewMemDB(version.DefaultVersion1_0_0)
@@ -388,16 +394,17 @@ func GenesisVMWithArgs(t *testing.T, args *BuildGenesisArgs) ([]byte, chan commo
 	}
 
 	vm := &VM{Factory: Factory{
-		Chains:                 chains.MockManager{},
-		Validators:             validators.NewManager(),
-		UptimeLockedCalculator: uptime.NewLockedCalculator(),
-		TxFee:                  defaultTxFee,
-		MinValidatorStake:      defaultMinValidatorStake,
-		MaxValidatorStake:      defaultMaxValidatorStake,
-		MinDelegatorStake:      defaultMinDelegatorStake,
-		MinStakeDuration:       defaultMinStakingDuration,
-		MaxStakeDuration:       defaultMaxStakingDuration,
-		StakeMintingPeriod:     defaultMaxStakingDuration,
+		Chains:                   chains.MockManager{},
+		Validators:               validators.NewManager(),
+		UptimeLockedCalculator:   uptime.NewLockedCalculator(),
+		TxFee:                    defaultTxFee,
+		MinValidatorStake:        defaultMinValidatorStake,
+		MaxValidatorStake:        defaultMaxValidatorStake,
+		MaxValidatorWeightFactor: defaultMaxValidatorWeightFactor,
+		MinDelegatorStake:        defaultMinDelegatorStake,
+		MinStakeDuration:         defaultMinStakingDuration,
+		MaxStakeDuration:         defaultMaxStakingDuration,
+		StakeMintingPeriod:       defaultMaxStakingDuration,
 	}}
 
 	baseDBManager := manager.NewMemDB(version.DefaultVersion1_0_0)
@@ -1912,6 +1919,113 @@ func TestRestartPartiallyAccepted(t *testing.T) {
 	}
 }
 
+// Ensures that the uptimes observed before the node stopped without shutting
+// down cleanly are resumed from the last time they were persisted.
+func TestUptimesPersistedAcrossUncleanRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	_, genesisBytes := defaultGenesis()
+	db := manager.NewMemDB(version.DefaultVersion1_0_0)
+	nodeID := keys[0].PublicKey().Address()
+
+	firstDB := db.NewPrefixDBManager([]byte{})
+	firstVM := &VM{Factory: Factory{
+		Chains:                 chains.MockManager{},
+		Validators:             validators.NewManager(),
+		UptimeLockedCalculator: uptime.NewLockedCalculator(),
+		StakeMintingPeriod:     defaultMaxStakingDuration,
+	}}
+
+	firstCtx := defaultContext()
+	firstCtx.Lock.Lock()
+
+	firstMsgChan := make(chan common.Message, 1)
+	err := firstVM.Initialize(firstCtx, firstDB, genesisBytes, nil, nil, firstMsgChan, nil, nil)
+	assert.NoError(err)
+
+	firstVM.clock.Set(defaultValidateStartTime)
+	firstVM.uptimeManager.(uptime.TestManager).SetTime(defaultValidateStartTime)
+
+	err = firstVM.Bootstrapping()
+	assert.NoError(err)
+	err = firstVM.Bootstrapped()
+	assert.NoError(err)
+
+	// The validator is observed to be offline for the first 10 hours
+	persistTime := defaultValidateStartTime.Add(10 * time.Hour)
+	firstVM.uptimeManager.(uptime.TestManager).SetTime(persistTime)
+	err = firstVM.persistUptimes()
+	assert.NoError(err)
+
+	// Stop the first VM without shutting it down, so the uptimes aren't
+	// written again
+	firstCtx.Lock.Unlock()
+	firstVM.uptimePersistTimer.Stop()
+
+	secondDB := db.NewPrefixDBManager([]byte{})
+	secondVM := &VM{Factory: Factory{
+		Chains:                 chains.MockManager{},
+		Validators:             validators.NewManager(),
+		UptimeLockedCalculator: uptime.NewLockedCalculator(),
+		StakeMintingPeriod:     defaultMaxStakingDuration,
+	}}
+
+	secondCtx := defaultContext()
+	secondCtx.Lock.Lock()
+	defer func() {
+		err := secondVM.Shutdown()
+		assert.NoError(err)
+		secondCtx.Lock.Unlock()
+	}()
+
+	secondMsgChan := make(chan common.Message, 1)
+	err = secondVM.Initialize(secondCtx, secondDB, genesisBytes, nil, nil, secondMsgChan, nil, nil)
+	assert.NoError(err)
+
+	// The node was offline for an hour after the uptimes were persisted
+	restartTime := persistTime.Add(time.Hour)
+	secondVM.clock.Set(restartTime)
+	secondVM.uptimeManager.(uptime.TestManager).SetTime(restartTime)
+
+	err = secondVM.Bootstrapping()
+	assert.NoError(err)
+	err = secondVM.Bootstrapped()
+	assert.NoError(err)
+
+	// Only the hour the node wasn't running for is unknown, so it isn't
+	// counted as downtime. The observed downtime is remembered.
+	upDuration, lastUpdated, err := secondVM.uptimeManager.CalculateUptime(nodeID)
+	assert.NoError(err)
+	assert.Equal(time.Hour, upDuration)
+	assert.Equal(restartTime, lastUpdated)
+}
+
+func TestParseLegacyCurrentValidatorState(t *testing.T) {
+	assert := assert.New(t)
+
+	txID := ids.GenerateTestID()
+	startTime := time.Unix(defaultValidateStartTime.Unix(), 0)
+	legacyBytes := database.PackUInt64(1234)
+
+	uptime, err := parseCurrentValidatorState(txID, legacyBytes, startTime)
+	assert.NoError(err)
+	assert.Equal(txID, uptime.txID)
+	assert.Equal(startTime, uptime.lastUpdated)
+	assert.Equal(time.Duration(0), uptime.UpDuration)
+	assert.Equal(uint64(1234), uptime.PotentialReward)
+
+	uptime.UpDuration = time.Hour
+	uptime.LastUpdated = uint64(startTime.Add(time.Hour).Unix())
+	uptimeBytes, err := GenesisCodec.Marshal(CodecVersion, uptime)
+	assert.NoError(err)
+
+	parsedUptime, err := parseCurrentValidatorState(txID, uptimeBytes, startTime)
+	assert.NoError(err)
+	assert.Equal(time.Hour, parsedUptime.UpDuration)
+	assert.Equal(startTime.Add(time.Hour), parsedUptime.lastUpdated)
+	assert.Equal(uint64(1234), parsedUptime.PotentialReward)
+}
+
 // test restarting the node
 func TestRestartFullyAccepted(t *testing.T) {
 	_, genesisBytes := defaultGenesis()
@@ -2113,7 +2227,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 
 	chainRouter := &router.ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace")
+	mc, err := message.NewCreator(metrics, true /*compressionEnabled*/, "dummyNamespace", nil /*maxSizes*/)
 	assert.NoError(t, err)
 	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, mc, &timeoutManager, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
@@ -2189,6 +2303,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		&engine,
 		vdrs,
 		msgChan,
+		tracker.NewCPUTracker(uptimemeter.IntervalFactory{}, time.Second),
 	)
 	assert.NoError(t, err)
 
@@ -2358,13 +2473,21 @@ func TestMaxStakeAmount(t *testing.T) {
 		endTime        time.Time
 		validatorID    ids.ShortID
 		expectedAmount uint64
+		expectedErr    error
 	}{
 		{
-			description:    "startTime after validation period ends",
-			startTime:      defaultValidateEndTime.Add(time.Minute),
-			endTime:        defaultValidateEndTime.Add(2 * time.Minute),
-			validatorID:    keys[0].PublicKey().Address(),
-			expectedAmount: 0,
+			description: "startTime after validation period ends",
+			startTime:   defaultValidateEndTime.Add(time.Minute),
+			endTime:     defaultValidateEndTime.Add(2 * time.Minute),
+			validatorID: keys[0].PublicKey().Address(),
+			expectedErr: errNotValidating,
+		},
+		{
+			description: "not a validator",
+			startTime:   defaultValidateStartTime,
+			endTime:     defaultValidateEndTime,
+			validatorID: ids.GenerateTestShortID(),
+			expectedErr: errNotValidating,
 		},
 		{
 			description:    "startTime when validation period ends",
@@ -2406,8 +2529,8 @@ func TestMaxStakeAmount(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.description, func(t *testing.T) {
 			amount, err := vm.maxStakeAmount(vm.ctx.SubnetID, test.validatorID, test.startTime, test.endTime)
-			if err != nil {
-				t.Fatal(err)
+			if err != test.expectedErr {
+				t.Fatalf("expected error %v but got %v", test.expectedErr, err)
 			}
 			if amount != test.expectedAmount {
 				t.Fatalf("wrong max stake amount. Expected %d ; Returned %d",
@@ -2511,3 +2634,134 @@ func TestUnverifiedParentPanic(t *testing.T) {
 	// Doesn't matter what verify returns as long as it's not panicking.
 	_ = addSubnetBlk2.Verify()
 }
+
+func TestSubnetPercentConnected(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	vm.WhitelistedSubnets.Add(subnetID)
+
+	connectedID := ids.GenerateTestShortID()
+	disconnectedID := ids.GenerateTestShortID()
+	subnetValidators := validators.NewSet()
+	err := subnetValidators.AddWeight(connectedID, 1)
+	assert.NoError(err)
+	err = subnetValidators.AddWeight(disconnectedID, 3)
+	assert.NoError(err)
+	err = vm.Validators.Set(subnetID, subnetValidators)
+	assert.NoError(err)
+
+	gauge := vm.percentConnectedSubnet.WithLabelValues(subnetID.String())
+
+	err = vm.Connected(connectedID)
+	assert.NoError(err)
+	assert.Equal(0.25, testutil.ToFloat64(gauge))
+
+	// The gauge is recomputed when the subnet's validator set changes
+	err = subnetValidators.RemoveWeight(disconnectedID, 2)
+	assert.NoError(err)
+	err = vm.updateSubnetPercentConnected()
+	assert.NoError(err)
+	assert.Equal(0.5, testutil.ToFloat64(gauge))
+
+	err = vm.Disconnected(connectedID)
+	assert.NoError(err)
+	assert.Equal(0.0, testutil.ToFloat64(gauge))
+
+	// Only tracked subnets are reported
+	assert.Equal(1, testutil.CollectAndCount(vm.percentConnectedSubnet))
+}
+
+func TestRejectedBlockMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		err := vm.Shutdown()
+		assert.NoError(err)
+		vm.ctx.Lock.Unlock()
+	}()
+
+	tx := getValidTx(vm, t)
+	err := vm.blockBuilder.AddUnverifiedTx(tx)
+	assert.NoError(err)
+
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	_, ok := blk.(*StandardBlock)
+	assert.True(ok, "expected standard block")
+
+	err = blk.Verify()
+	assert.NoError(err)
+	err = blk.Reject()
+	assert.NoError(err)
+
+	assert.Equal(1.0, testutil.ToFloat64(vm.numStandardBlocksRejected))
+	assert.Equal(0.0, testutil.ToFloat64(vm.numProposalBlocksRejected))
+
+	err = vm.metrics.RejectBlock(&smcon.TestBlock{})
+	assert.ErrorIs(err, errUnknownBlockType)
+}
+
+// Ensure that the validator diffs of the heights that leave the retention
+// window are deleted.
+func TestValidatorDiffsPruned(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.config.ValidatorDiffsRetention = 2
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	height, err := vm.GetCurrentHeight()
+	assert.NoError(err)
+
+	key, err := vm.factory.NewPrivateKey()
+	assert.NoError(err)
+	nodeID := key.PublicKey().Address()
+
+	addValidatorTx, err := vm.newAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(defaultValidateStartTime.Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		nodeID,
+		nodeID,
+		PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+	vm.internalState.AddCurrentStaker(addValidatorTx, 0)
+	vm.internalState.AddTx(addValidatorTx, Committed)
+	vm.internalState.SetHeight(height + 1)
+	assert.NoError(vm.internalState.Commit())
+
+	diffs, err := vm.internalState.GetValidatorWeightDiffs(height+1, constants.PrimaryNetworkID)
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+
+	// The diffs are still within the retention window
+	vm.internalState.SetHeight(height + 2)
+	assert.NoError(vm.internalState.Commit())
+	diffs, err = vm.internalState.GetValidatorWeightDiffs(height+1, constants.PrimaryNetworkID)
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+
+	vm.internalState.SetHeight(height + 3)
+	assert.NoError(vm.internalState.Commit())
+	diffs, err = vm.internalState.GetValidatorWeightDiffs(height+1, constants.PrimaryNetworkID)
+	assert.NoError(err)
+	assert.Empty(diffs)
+}
@@ -100,7 +100,7 @@ func (vm *VM) BatchedParseBlock(blks [][]byte) ([]snowman.Block, error) {
 		}
 
 		blkID := statelessBlock.ID()
-		block, exists := vm.verifiedBlocks[blkID]
+		block, exists := vm.getVerifiedBlock(blkID)
 		if exists {
 			blocks[blocksIndex] = block
 			continue
@@ -161,7 +161,7 @@ func (vm *VM) BatchedParseBlock(blks [][]byte) ([]snowman.Block, error) {
 }
 
 func (vm *VM) getStatelessBlk(blkID ids.ID) (statelessblock.Block, error) {
-	if currentBlk, exists := vm.verifiedBlocks[blkID]; exists {
+	if currentBlk, exists := vm.getVerifiedBlock(blkID); exists {
 		return currentBlk.getStatelessBlk(), nil
 	}
 	statelessBlock, _, err := vm.State.GetBlock(blkID)
@@ -34,17 +34,17 @@ func (b *postForkBlock) Accept() error {
 		return err
 	}
 
-	delete(b.vm.verifiedBlocks, blkID)
+	b.vm.removeVerifiedBlock(blkID)
 	b.vm.lastAcceptedTime = b.Timestamp()
 
 	// mark the inner block as accepted and all conflicting inner blocks as
 	// rejected
-	return b.vm.Tree.Accept(b.innerBlk)
+	return b.vm.acceptInnerBlk(b.innerBlk)
 }
 
 func (b *postForkBlock) Reject() error {
 	// We do not reject the inner block here because it may be accepted later
-	delete(b.vm.verifiedBlocks, b.ID())
+	b.vm.removeVerifiedBlock(b.ID())
 
 	// Persist this block with its status
 	b.status = choices.Rejected
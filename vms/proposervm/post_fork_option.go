@@ -40,18 +40,18 @@ func (b *postForkOption) Accept() error {
 		return err
 	}
 
-	delete(b.vm.verifiedBlocks, blkID)
+	b.vm.removeVerifiedBlock(blkID)
 
 	// mark the inner block as accepted and all conflicting inner blocks as
 	// rejected
-	return b.vm.Tree.Accept(b.innerBlk)
+	return b.vm.acceptInnerBlk(b.innerBlk)
 }
 
 func (b *postForkOption) Reject() error {
 	// we do not reject the inner block here because that block may be contained
 	// in the proposer block that causing this block to be rejected.
 
-	delete(b.vm.verifiedBlocks, b.ID())
+	b.vm.removeVerifiedBlock(b.ID())
 
 	// Persist this block and its status
 	b.status = choices.Rejected
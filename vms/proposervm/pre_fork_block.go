@@ -222,7 +222,7 @@ func (b *preForkBlock) verifyIsPreForkBlock() error {
 			// error.
 			return err
 		}
-	} else if _, contains := b.vm.Tree.Get(b.Block); contains {
+	} else if b.vm.isInnerBlkVerified(b.Block) {
 		// If this block is a preForkBlock, then it's inner block shouldn't have
 		// been registered into the inner block tree. If this block was
 		// registered into the inner block tree, then it wasn't a preForkBlock.
@@ -5,6 +5,7 @@ package proposer
 
 import (
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -37,6 +38,10 @@ type windower struct {
 	state       validators.State
 	subnetID    ids.ID
 	chainSource uint64
+
+	// samplerLock protects [sampler], as the delay may be calculated by
+	// multiple goroutines at the same time.
+	samplerLock sync.Mutex
 	sampler     sampler.WeightedWithoutReplacement
 }
 
@@ -87,19 +92,13 @@ func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.Short
 		validatorWeights[i] = v.weight
 	}
 
-	if err := w.sampler.Initialize(validatorWeights); err != nil {
-		return 0, err
-	}
-
 	numToSample := MaxWindows
 	if weight < uint64(numToSample) {
 		numToSample = int(weight)
 	}
 
 	seed := chainHeight ^ w.chainSource
-	w.sampler.Seed(int64(seed))
-
-	indices, err := w.sampler.Sample(numToSample)
+	indices, err := w.sample(validatorWeights, int64(seed), numToSample)
 	if err != nil {
 		return 0, err
 	}
@@ -114,3 +113,14 @@ func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.Short
 	}
 	return delay, nil
 }
+
+func (w *windower) sample(weights []uint64, seed int64, count int) ([]int, error) {
+	w.samplerLock.Lock()
+	defer w.samplerLock.Unlock()
+
+	if err := w.sampler.Initialize(weights); err != nil {
+		return nil, err
+	}
+	w.sampler.Seed(seed)
+	return w.sampler.Sample(count)
+}
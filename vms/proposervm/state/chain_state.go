@@ -4,6 +4,8 @@
 package state
 
 import (
+	"sync"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 )
@@ -27,6 +29,9 @@ type ChainState interface {
 }
 
 type chainState struct {
+	// lock protects [lastAccepted], which may be read while blocks are being
+	// verified concurrently.
+	lock         sync.Mutex
 	lastAccepted ids.ID
 	db           database.Database
 }
@@ -36,6 +41,9 @@ func NewChainState(db database.Database) ChainState {
 }
 
 func (s *chainState) SetLastAccepted(blkID ids.ID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	if s.lastAccepted == blkID {
 		return nil
 	}
@@ -44,11 +52,17 @@ func (s *chainState) SetLastAccepted(blkID ids.ID) error {
 }
 
 func (s *chainState) DeleteLastAccepted() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	s.lastAccepted = ids.Empty
 	return s.db.Delete(lastAcceptedKey)
 }
 
 func (s *chainState) GetLastAccepted() (ids.ID, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	if s.lastAccepted != ids.Empty {
 		return s.lastAccepted, nil
 	}
@@ -65,5 +79,8 @@ func (s *chainState) GetLastAccepted() (ids.ID, error) {
 }
 
 func (s *chainState) clearCache() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	s.lastAccepted = ids.Empty
 }
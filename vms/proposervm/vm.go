@@ -4,6 +4,7 @@
 package proposervm
 
 import (
+	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -36,7 +37,8 @@ const (
 var (
 	dbPrefix = []byte("proposervm")
 
-	_ block.ChainVM = &VM{}
+	_ block.ChainVM              = &VM{}
+	_ block.ConcurrentVerifierVM = &VM{}
 )
 
 type VM struct {
@@ -54,12 +56,19 @@ type VM struct {
 	db          *versiondb.Database
 	toScheduler chan<- common.Message
 
+	// verifiedBlocksLock protects [verifiedBlocks], [verifyingInnerBlks] and
+	// the inner block tree, as blocks may be verified concurrently if the
+	// inner VM supports it.
+	verifiedBlocksLock sync.Mutex
 	// Block ID --> Block
 	// Each element is a block that passed verification but
 	// hasn't yet been accepted/rejected
 	verifiedBlocks map[ids.ID]PostForkBlock
-	preferred      ids.ID
-	bootstrapped   bool
+	// Inner block ID --> Channel closed once the inner block's verification
+	// finishes
+	verifyingInnerBlks map[ids.ID]chan struct{}
+	preferred          ids.ID
+	bootstrapped       bool
 
 	// lastAcceptedOptionTime is set to the last accepted PostForkBlock's
 	// timestamp if the last accepted block has been a PostForkOption block
@@ -102,6 +111,7 @@ func (vm *VM) Initialize(
 	})
 
 	vm.verifiedBlocks = make(map[ids.ID]PostForkBlock)
+	vm.verifyingInnerBlks = make(map[ids.ID]chan struct{})
 
 	err := vm.ChainVM.Initialize(
 		ctx,
@@ -134,6 +144,13 @@ func (vm *VM) Bootstrapped() error {
 	return vm.ChainVM.Bootstrapped()
 }
 
+// ConcurrentVerifyEnabled returns true if the inner VM's blocks may be verified
+// concurrently.
+func (vm *VM) ConcurrentVerifyEnabled() bool {
+	cVM, ok := vm.ChainVM.(block.ConcurrentVerifierVM)
+	return ok && cVM.ConcurrentVerifyEnabled()
+}
+
 func (vm *VM) BuildBlock() (snowman.Block, error) {
 	preferredBlock, err := vm.getBlock(vm.preferred)
 	if err != nil {
@@ -344,7 +361,7 @@ func (vm *VM) getBlock(id ids.ID) (Block, error) {
 }
 
 func (vm *VM) getPostForkBlock(blkID ids.ID) (PostForkBlock, error) {
-	block, exists := vm.verifiedBlocks[blkID]
+	block, exists := vm.getVerifiedBlock(blkID)
 	if exists {
 		return block, nil
 	}
@@ -395,6 +412,38 @@ func (vm *VM) storePostForkBlock(blk PostForkBlock) error {
 	return vm.db.Commit()
 }
 
+func (vm *VM) getVerifiedBlock(blkID ids.ID) (PostForkBlock, bool) {
+	vm.verifiedBlocksLock.Lock()
+	defer vm.verifiedBlocksLock.Unlock()
+
+	blk, exists := vm.verifiedBlocks[blkID]
+	return blk, exists
+}
+
+func (vm *VM) removeVerifiedBlock(blkID ids.ID) {
+	vm.verifiedBlocksLock.Lock()
+	defer vm.verifiedBlocksLock.Unlock()
+
+	delete(vm.verifiedBlocks, blkID)
+}
+
+// acceptInnerBlk marks the inner block as accepted and all conflicting inner
+// blocks as rejected
+func (vm *VM) acceptInnerBlk(innerBlk snowman.Block) error {
+	vm.verifiedBlocksLock.Lock()
+	defer vm.verifiedBlocksLock.Unlock()
+
+	return vm.Tree.Accept(innerBlk)
+}
+
+func (vm *VM) isInnerBlkVerified(innerBlk snowman.Block) bool {
+	vm.verifiedBlocksLock.Lock()
+	defer vm.verifiedBlocksLock.Unlock()
+
+	_, contains := vm.Tree.Get(innerBlk)
+	return contains
+}
+
 func (vm *VM) verifyAndRecordInnerBlk(postFork PostForkBlock) error {
 	// If inner block's Verify returned true, don't call it again.
 	//
@@ -402,15 +451,42 @@ func (vm *VM) verifyAndRecordInnerBlk(postFork PostForkBlock) error {
 	// must always remain the case to maintain the inner block's invariant that
 	// if it's Verify() returns nil, it is eventually accepted or rejected.
 	currentInnerBlk := postFork.getInnerBlk()
-	if originalInnerBlk, contains := vm.Tree.Get(currentInnerBlk); !contains {
-		if err := currentInnerBlk.Verify(); err != nil {
-			return err
+	innerBlkID := currentInnerBlk.ID()
+
+	vm.verifiedBlocksLock.Lock()
+	for {
+		if originalInnerBlk, contains := vm.Tree.Get(currentInnerBlk); contains {
+			postFork.setInnerBlk(originalInnerBlk)
+			vm.verifiedBlocks[postFork.ID()] = postFork
+			vm.verifiedBlocksLock.Unlock()
+			return nil
 		}
-		vm.Tree.Add(currentInnerBlk)
-	} else {
-		postFork.setInnerBlk(originalInnerBlk)
+
+		// If the inner block is currently being verified as part of another
+		// block, wait for that verification rather than verifying it twice.
+		verifying, ok := vm.verifyingInnerBlks[innerBlkID]
+		if !ok {
+			break
+		}
+		vm.verifiedBlocksLock.Unlock()
+		<-verifying
+		vm.verifiedBlocksLock.Lock()
 	}
+	verifying := make(chan struct{})
+	vm.verifyingInnerBlks[innerBlkID] = verifying
+	vm.verifiedBlocksLock.Unlock()
+
+	err := currentInnerBlk.Verify()
+
+	vm.verifiedBlocksLock.Lock()
+	defer vm.verifiedBlocksLock.Unlock()
 
+	delete(vm.verifyingInnerBlks, innerBlkID)
+	close(verifying)
+	if err != nil {
+		return err
+	}
+	vm.Tree.Add(currentInnerBlk)
 	vm.verifiedBlocks[postFork.ID()] = postFork
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"crypto"
 	"crypto/tls"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/metervm"
 	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 
 	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
@@ -145,7 +147,7 @@ func initTestProposerVM(
 
 func TestBuildBlockTimestampAreRoundedToSeconds(t *testing.T) {
 	// given the same core block, BuildBlock returns the same proposer block
-	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 	skewedTimestamp := time.Now().Truncate(time.Second).Add(time.Millisecond)
 	proVM.Set(skewedTimestamp)
 
@@ -174,7 +176,7 @@ func TestBuildBlockTimestampAreRoundedToSeconds(t *testing.T) {
 
 func TestBuildBlockIsIdempotent(t *testing.T) {
 	// given the same core block, BuildBlock returns the same proposer block
-	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	coreBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -206,7 +208,7 @@ func TestBuildBlockIsIdempotent(t *testing.T) {
 
 func TestFirstProposerBlockIsBuiltOnTopOfGenesis(t *testing.T) {
 	// setup
-	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	coreBlk := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -239,7 +241,7 @@ func TestFirstProposerBlockIsBuiltOnTopOfGenesis(t *testing.T) {
 
 // both core blocks and pro blocks must be built on preferred
 func TestProposerBlocksAreBuiltOnPreferredProBlock(t *testing.T) {
-	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	// add two proBlks...
 	coreBlk1 := &snowman.TestBlock{
@@ -338,7 +340,7 @@ func TestProposerBlocksAreBuiltOnPreferredProBlock(t *testing.T) {
 }
 
 func TestCoreBlocksMustBeBuiltOnPreferredCoreBlock(t *testing.T) {
-	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	coreBlk1 := &snowman.TestBlock{
 		TestDecidable: choices.TestDecidable{
@@ -436,7 +438,7 @@ func TestCoreBlocksMustBeBuiltOnPreferredCoreBlock(t *testing.T) {
 
 // VM.ParseBlock tests section
 func TestCoreBlockFailureCauseProposerBlockParseFailure(t *testing.T) {
-	coreVM, _, proVM, _, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, _, proVM, _, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	innerBlk := &snowman.TestBlock{
 		BytesV:     []byte{1},
@@ -474,7 +476,7 @@ func TestCoreBlockFailureCauseProposerBlockParseFailure(t *testing.T) {
 }
 
 func TestTwoProBlocksWrappingSameCoreBlockCanBeParsed(t *testing.T) {
-	coreVM, _, proVM, gencoreBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, _, proVM, gencoreBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	// create two Proposer blocks at the same height
 	innerBlk := &snowman.TestBlock{
@@ -556,7 +558,7 @@ func TestTwoProBlocksWrappingSameCoreBlockCanBeParsed(t *testing.T) {
 
 // VM.BuildBlock and VM.ParseBlock interoperability tests section
 func TestTwoProBlocksWithSameParentCanBothVerify(t *testing.T) {
-	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	// one block is built from this proVM
 	localcoreBlk := &snowman.TestBlock{
@@ -1271,7 +1273,7 @@ func TestInnerVMRollback(t *testing.T) {
 }
 
 func TestBuildBlockDuringWindow(t *testing.T) {
-	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
 
 	valState.GetValidatorSetF = func(height uint64, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
 		return map[ids.ShortID]uint64{
@@ -1705,3 +1707,102 @@ func TestLaggedPChainHeight(t *testing.T) {
 	pChainHeight := block.PChainHeight()
 	assert.Equal(pChainHeight, defaultPChainHeight-optimalHeightDelay)
 }
+
+type concurrentTestVM struct {
+	*block.TestVM
+}
+
+func (*concurrentTestVM) ConcurrentVerifyEnabled() bool { return true }
+
+func TestConcurrentVerifyEnabledIsForwarded(t *testing.T) {
+	assert := assert.New(t)
+
+	coreVM := &block.TestVM{}
+	proVM := New(metervm.NewBlockVM(coreVM), time.Time{}, 0)
+	assert.False(proVM.ConcurrentVerifyEnabled())
+
+	concurrentVM := &concurrentTestVM{TestVM: coreVM}
+	proVM = New(metervm.NewBlockVM(concurrentVM), time.Time{}, 0)
+	assert.True(proVM.ConcurrentVerifyEnabled())
+}
+
+// blockingBlock blocks in Verify until [release] is closed
+type blockingBlock struct {
+	*snowman.TestBlock
+	verifies int32
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func (b *blockingBlock) Verify() error {
+	if atomic.AddInt32(&b.verifies, 1) == 1 {
+		close(b.started)
+	}
+	<-b.release
+	return b.TestBlock.Verify()
+}
+
+func TestConcurrentInnerBlockVerificationIsDeduplicated(t *testing.T) {
+	assert := assert.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0)
+
+	coreBlk := &blockingBlock{
+		TestBlock: &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			BytesV:     []byte{1},
+			ParentV:    coreGenBlk.ID(),
+			HeightV:    coreGenBlk.Height() + 1,
+			TimestampV: coreGenBlk.Timestamp(),
+		},
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	coreVM.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk.Bytes()):
+			return coreBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	// Two proposer blocks wrapping the same inner block
+	outerBlks := make([]snowman.Block, 2)
+	for i := range outerBlks {
+		statelessBlk, err := statelessblock.BuildUnsigned(
+			coreGenBlk.ID(),
+			coreBlk.Timestamp(),
+			uint64(i),
+			coreBlk.Bytes(),
+		)
+		assert.NoError(err)
+
+		outerBlks[i], err = proVM.ParseBlock(statelessBlk.Bytes())
+		assert.NoError(err)
+	}
+
+	errs := make(chan error, len(outerBlks))
+	for _, outerBlk := range outerBlks {
+		outerBlk := outerBlk
+		go func() {
+			errs <- outerBlk.Verify()
+		}()
+	}
+
+	// Give the second verification time to reach the inner block before the
+	// first one finishes
+	<-coreBlk.started
+	time.Sleep(50 * time.Millisecond)
+	close(coreBlk.release)
+
+	for range outerBlks {
+		assert.NoError(<-errs)
+	}
+	assert.EqualValues(1, atomic.LoadInt32(&coreBlk.verifies))
+}
@@ -49,8 +49,9 @@ import (
 var (
 	errUnsupportedFXs = errors.New("unsupported feature extensions")
 
-	_ block.ChainVM        = &VMClient{}
-	_ block.BatchedChainVM = &VMClient{}
+	_ block.ChainVM              = &VMClient{}
+	_ block.BatchedChainVM       = &VMClient{}
+	_ block.ConcurrentVerifierVM = &VMClient{}
 )
 
 const (
@@ -607,6 +608,16 @@ func (vm *VMClient) BatchedParseBlock(blksBytes [][]byte) ([]snowman.Block, erro
 	return res, nil
 }
 
+// ConcurrentVerifyEnabled reports whether the plugin's blocks may be verified
+// concurrently. Plugins that don't support the request are assumed not to.
+func (vm *VMClient) ConcurrentVerifyEnabled() bool {
+	resp, err := vm.client.ConcurrentVerifyEnabled(
+		context.Background(),
+		&emptypb.Empty{},
+	)
+	return err == nil && resp.Enabled
+}
+
 func (vm *VMClient) Version() (string, error) {
 	resp, err := vm.client.Version(
 		context.Background(),
@@ -394,6 +394,13 @@ func (vm *VMServer) BatchedParseBlock(
 	}, nil
 }
 
+func (vm *VMServer) ConcurrentVerifyEnabled(context.Context, *emptypb.Empty) (*vmproto.ConcurrentVerifyEnabledResponse, error) {
+	cVM, ok := vm.vm.(block.ConcurrentVerifierVM)
+	return &vmproto.ConcurrentVerifyEnabledResponse{
+		Enabled: ok && cVM.ConcurrentVerifyEnabled(),
+	}, nil
+}
+
 func (vm *VMServer) GetBlock(_ context.Context, req *vmproto.GetBlockRequest) (*vmproto.GetBlockResponse, error) {
 	id, err := ids.ToID(req.Id)
 	if err != nil {
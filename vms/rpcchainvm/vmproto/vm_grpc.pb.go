@@ -43,6 +43,7 @@ type VMClient interface {
 	BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetAncestors(ctx context.Context, in *GetAncestorsRequest, opts ...grpc.CallOption) (*GetAncestorsResponse, error)
 	BatchedParseBlock(ctx context.Context, in *BatchedParseBlockRequest, opts ...grpc.CallOption) (*BatchedParseBlockResponse, error)
+	ConcurrentVerifyEnabled(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConcurrentVerifyEnabledResponse, error)
 }
 
 type vMClient struct {
@@ -269,6 +270,15 @@ func (c *vMClient) BatchedParseBlock(ctx context.Context, in *BatchedParseBlockR
 	return out, nil
 }
 
+func (c *vMClient) ConcurrentVerifyEnabled(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConcurrentVerifyEnabledResponse, error) {
+	out := new(ConcurrentVerifyEnabledResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/ConcurrentVerifyEnabled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VMServer is the server API for VM service.
 // All implementations must embed UnimplementedVMServer
 // for forward compatibility
@@ -297,6 +307,7 @@ type VMServer interface {
 	BlockReject(context.Context, *BlockRejectRequest) (*emptypb.Empty, error)
 	GetAncestors(context.Context, *GetAncestorsRequest) (*GetAncestorsResponse, error)
 	BatchedParseBlock(context.Context, *BatchedParseBlockRequest) (*BatchedParseBlockResponse, error)
+	ConcurrentVerifyEnabled(context.Context, *emptypb.Empty) (*ConcurrentVerifyEnabledResponse, error)
 	mustEmbedUnimplementedVMServer()
 }
 
@@ -376,6 +387,9 @@ func (UnimplementedVMServer) GetAncestors(context.Context, *GetAncestorsRequest)
 func (UnimplementedVMServer) BatchedParseBlock(context.Context, *BatchedParseBlockRequest) (*BatchedParseBlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchedParseBlock not implemented")
 }
+func (UnimplementedVMServer) ConcurrentVerifyEnabled(context.Context, *emptypb.Empty) (*ConcurrentVerifyEnabledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConcurrentVerifyEnabled not implemented")
+}
 func (UnimplementedVMServer) mustEmbedUnimplementedVMServer() {}
 
 // UnsafeVMServer may be embedded to opt out of forward compatibility for this service.
@@ -821,6 +835,24 @@ func _VM_BatchedParseBlock_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VM_ConcurrentVerifyEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).ConcurrentVerifyEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/ConcurrentVerifyEnabled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).ConcurrentVerifyEnabled(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VM_ServiceDesc is the grpc.ServiceDesc for VM service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -924,6 +956,10 @@ var VM_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchedParseBlock",
 			Handler:    _VM_BatchedParseBlock_Handler,
 		},
+		{
+			MethodName: "ConcurrentVerifyEnabled",
+			Handler:    _VM_ConcurrentVerifyEnabled_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vm.proto",
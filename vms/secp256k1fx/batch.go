@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// sigJob is a single signature that must recover to [addr] over [txHash]
+type sigJob struct {
+	txHash []byte
+	sig    []byte
+	addr   ids.ShortID
+}
+
+// sigBatch collects the signatures of credentials whose verification was
+// deferred until the end of a batch
+type sigBatch struct {
+	jobs []sigJob
+	// keys of the credentials that are verified once all of [jobs] are
+	credKeys []ids.ID
+}
+
+// StartBatch defers signature verification in VerifyCredentials until
+// VerifyBatch or AbortBatch is called. Checks that don't require recovering a
+// public key are still performed immediately.
+func (fx *Fx) StartBatch() { fx.batch = &sigBatch{} }
+
+// VerifyBatch verifies every signature deferred since StartBatch across a pool
+// of workers, returning the first failure found. Once any signature fails, the
+// remaining signatures are skipped.
+func (fx *Fx) VerifyBatch() error {
+	batch := fx.batch
+	fx.batch = nil
+	if batch == nil {
+		return nil
+	}
+
+	if err := fx.verifyParallel(batch.jobs); err != nil {
+		return err
+	}
+	for _, key := range batch.credKeys {
+		fx.verifiedCreds.Put(key, nil)
+	}
+	return nil
+}
+
+// AbortBatch discards the signatures deferred since StartBatch without
+// verifying them.
+func (fx *Fx) AbortBatch() { fx.batch = nil }
+
+func (fx *Fx) verifyParallel(jobs []sigJob) error {
+	numWorkers := runtime.NumCPU()
+	if numWorkers > len(jobs) {
+		numWorkers = len(jobs)
+	}
+
+	var (
+		next    int64
+		failed  int32
+		errOnce sync.Once
+		err     error
+		wg      sync.WaitGroup
+	)
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for atomic.LoadInt32(&failed) == 0 {
+				index := atomic.AddInt64(&next, 1) - 1
+				if index >= int64(len(jobs)) {
+					return
+				}
+				job := jobs[index]
+				if jobErr := fx.verifySignature(job.txHash, job.sig, job.addr); jobErr != nil {
+					errOnce.Do(func() {
+						err = jobErr
+						atomic.StoreInt32(&failed, 1)
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -16,6 +17,8 @@ import (
 
 const (
 	defaultCacheSize = 256
+	// number of credentials whose signatures are remembered to be valid
+	verifiedCredsCacheSize = 2048
 )
 
 var (
@@ -28,7 +31,7 @@ var (
 	errWrongOwnerType                 = errors.New("wrong owner type")
 	errWrongNumberOfUTXOs             = errors.New("wrong number of utxos for the operation")
 	errWrongMintCreated               = errors.New("wrong mint output created from the operation")
-	errTimelocked                     = errors.New("output is time locked")
+	ErrTimelocked                     = errors.New("output is time locked")
 	errTooManySigners                 = errors.New("input has more signers than expected")
 	errTooFewSigners                  = errors.New("input has less signers than expected")
 	errInputOutputIndexOutOfBounds    = errors.New("input referenced a nonexistent address in the output")
@@ -40,6 +43,13 @@ type Fx struct {
 	VM           VM
 	SECPFactory  crypto.FactorySECP256K1R
 	bootstrapped bool
+
+	// Caches the keys of credentials whose signatures were already verified,
+	// so that a tx verified after being gossiped isn't verified again when
+	// it's issued.
+	verifiedCreds cache.LRU
+	// If non-nil, signature verification is deferred until VerifyBatch
+	batch *sigBatch
 }
 
 func (fx *Fx) Initialize(vmIntf interface{}) error {
@@ -53,6 +63,7 @@ func (fx *Fx) Initialize(vmIntf interface{}) error {
 	fx.SECPFactory = crypto.FactorySECP256K1R{
 		Cache: cache.LRU{Size: defaultCacheSize},
 	}
+	fx.verifiedCreds = cache.LRU{Size: verifiedCredsCacheSize}
 	c := fx.VM.CodecRegistry()
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -65,6 +76,19 @@ func (fx *Fx) Initialize(vmIntf interface{}) error {
 	return errs.Err
 }
 
+// RegisterManagedAssetTypes registers the types used to manage assets. They
+// aren't registered in Initialize so that registering them doesn't change the
+// type IDs of types registered after this fx.
+func (fx *Fx) RegisterManagedAssetTypes() error {
+	c := fx.VM.CodecRegistry()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&ManagedAssetStatusOutput{}),
+		c.RegisterType(&UpdateManagedAssetOperation{}),
+	)
+	return errs.Err
+}
+
 func (fx *Fx) InitializeVM(vmIntf interface{}) error {
 	vm, ok := vmIntf.(VM)
 	if !ok {
@@ -107,22 +131,36 @@ func (fx *Fx) VerifyOperation(txIntf, opIntf, credIntf interface{}, utxosIntf []
 	if !ok {
 		return errWrongTxType
 	}
-	op, ok := opIntf.(*MintOperation)
-	if !ok {
+	switch op := opIntf.(type) {
+	case *MintOperation:
+		cred, ok := credIntf.(*Credential)
+		if !ok {
+			return errWrongCredentialType
+		}
+		if len(utxosIntf) != 1 {
+			return errWrongNumberOfUTXOs
+		}
+		out, ok := utxosIntf[0].(*MintOutput)
+		if !ok {
+			return errWrongUTXOType
+		}
+		return fx.verifyOperation(tx, op, cred, out)
+	case *UpdateManagedAssetOperation:
+		cred, ok := credIntf.(*Credential)
+		if !ok {
+			return errWrongCredentialType
+		}
+		if len(utxosIntf) != 1 {
+			return errWrongNumberOfUTXOs
+		}
+		out, ok := utxosIntf[0].(*ManagedAssetStatusOutput)
+		if !ok {
+			return errWrongUTXOType
+		}
+		return fx.verifyUpdateManagedAsset(tx, op, cred, out)
+	default:
 		return errWrongOpType
 	}
-	cred, ok := credIntf.(*Credential)
-	if !ok {
-		return errWrongCredentialType
-	}
-	if len(utxosIntf) != 1 {
-		return errWrongNumberOfUTXOs
-	}
-	out, ok := utxosIntf[0].(*MintOutput)
-	if !ok {
-		return errWrongUTXOType
-	}
-	return fx.verifyOperation(tx, op, cred, out)
 }
 
 func (fx *Fx) verifyOperation(tx Tx, op *MintOperation, cred *Credential, utxo *MintOutput) error {
@@ -135,6 +173,15 @@ func (fx *Fx) verifyOperation(tx Tx, op *MintOperation, cred *Credential, utxo *
 	return fx.VerifyCredentials(tx, &op.MintInput, cred, &utxo.OutputOwners)
 }
 
+// verifyUpdateManagedAsset ensures that the manager of the asset, as recorded
+// in [utxo], assents to the new status of the asset.
+func (fx *Fx) verifyUpdateManagedAsset(tx Tx, op *UpdateManagedAssetOperation, cred *Credential, utxo *ManagedAssetStatusOutput) error {
+	if err := verify.All(op, cred, utxo); err != nil {
+		return err
+	}
+	return fx.VerifyCredentials(tx, &op.Input, cred, &utxo.Manager)
+}
+
 func (fx *Fx) VerifyTransfer(txIntf, inIntf, credIntf, utxoIntf interface{}) error {
 	tx, ok := txIntf.(Tx)
 	if !ok {
@@ -172,7 +219,7 @@ func (fx *Fx) VerifyCredentials(tx Tx, in *Input, cred *Credential, out *OutputO
 	numSigs := len(in.SigIndices)
 	switch {
 	case out.Locktime > fx.VM.Clock().Unix():
-		return errTimelocked
+		return ErrTimelocked
 	case out.Threshold < uint32(numSigs):
 		return errTooManySigners
 	case out.Threshold > uint32(numSigs):
@@ -183,26 +230,103 @@ func (fx *Fx) VerifyCredentials(tx Tx, in *Input, cred *Credential, out *OutputO
 		return nil
 	}
 
-	txHash := hashing.ComputeHash256(tx.UnsignedBytes())
-	for i, index := range in.SigIndices {
+	for _, index := range in.SigIndices {
 		// Make sure the input references an address that exists
 		if index >= uint32(len(out.Addrs)) {
 			return errInputOutputIndexOutOfBounds
 		}
+	}
+
+	txHash := hashing.ComputeHash256(tx.UnsignedBytes())
+	key := credentialKey(txHash, in, cred, out)
+	if _, verified := fx.verifiedCreds.Get(key); verified {
+		return nil
+	}
+
+	if fx.batch != nil {
+		for i, index := range in.SigIndices {
+			fx.batch.jobs = append(fx.batch.jobs, sigJob{
+				txHash: txHash,
+				sig:    cred.Sigs[i][:],
+				addr:   out.Addrs[index],
+			})
+		}
+		fx.batch.credKeys = append(fx.batch.credKeys, key)
+		return nil
+	}
+
+	for i, index := range in.SigIndices {
 		// Make sure each signature in the signature list is from an owner of
 		// the output being consumed
-		sig := cred.Sigs[i]
-		pk, err := fx.SECPFactory.RecoverHashPublicKey(txHash, sig[:])
-		if err != nil {
+		if err := fx.verifySignature(txHash, cred.Sigs[i][:], out.Addrs[index]); err != nil {
 			return err
 		}
-		if expectedAddress := out.Addrs[index]; expectedAddress != pk.Address() {
-			return fmt.Errorf("expected signature from %s but got from %s",
-				expectedAddress,
-				pk.Address())
+	}
+	fx.verifiedCreds.Put(key, nil)
+	return nil
+}
+
+// verifySignature ensures that [sig] is a signature over [txHash] by
+// [expectedAddress].
+func (fx *Fx) verifySignature(txHash, sig []byte, expectedAddress ids.ShortID) error {
+	pk, err := fx.SECPFactory.RecoverHashPublicKey(txHash, sig)
+	if err != nil {
+		return err
+	}
+	if expectedAddress != pk.Address() {
+		return fmt.Errorf("expected signature from %s but got from %s",
+			expectedAddress,
+			pk.Address())
+	}
+	return nil
+}
+
+// credentialKey returns the key that a credential signing [txHash] to spend
+// [in] from [out] is cached under. The key commits to each signature and the
+// address it must be from, which is everything the signature check depends on.
+func credentialKey(txHash []byte, in *Input, cred *Credential, out *OutputOwners) ids.ID {
+	keyBytes := make([]byte, 0, len(txHash)+len(cred.Sigs)*(hashing.AddrLen+crypto.SECP256K1RSigLen))
+	keyBytes = append(keyBytes, txHash...)
+	for i, index := range in.SigIndices {
+		keyBytes = append(keyBytes, out.Addrs[index][:]...)
+		keyBytes = append(keyBytes, cred.Sigs[i][:]...)
+	}
+	return ids.ID(hashing.ComputeHash256Array(keyBytes))
+}
+
+// VerifySignatures ensures that every signature in [credsIntf] is a
+// well-formed signature over [txIntf], without checking which outputs the
+// signers are allowed to spend. The tx hash is computed once for the whole
+// batch of credentials, and the recovered public keys are cached so that a
+// later call to VerifyCredentials for the same tx doesn't recover them again.
+func (fx *Fx) VerifySignatures(txIntf interface{}, credsIntf []verify.Verifiable) error {
+	tx, ok := txIntf.(Tx)
+	if !ok {
+		return errWrongTxType
+	}
+	creds := make([]*Credential, len(credsIntf))
+	for i, credIntf := range credsIntf {
+		cred, ok := credIntf.(*Credential)
+		if !ok {
+			return errWrongCredentialType
+		}
+		if err := cred.Verify(); err != nil {
+			return err
 		}
+		creds[i] = cred
+	}
+	if !fx.bootstrapped { // disable signature verification during bootstrapping
+		return nil
 	}
 
+	txHash := hashing.ComputeHash256(tx.UnsignedBytes())
+	for _, cred := range creds {
+		for _, sig := range cred.Sigs {
+			if _, err := fx.SECPFactory.RecoverHashPublicKey(txHash, sig[:]); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
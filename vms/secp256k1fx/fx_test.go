@@ -11,7 +11,9 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
 var (
@@ -985,6 +987,87 @@ func TestFxVerifyOperationMismatchedMintOutputs(t *testing.T) {
 	}
 }
 
+func TestFxVerifyUpdateManagedAssetOperation(t *testing.T) {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	date := time.Date(2019, time.January, 19, 16, 25, 17, 3, time.UTC)
+	vm.CLK.Set(date)
+	fx := Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.RegisterManagedAssetTypes(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &TestTx{Bytes: txBytes}
+	utxo := &ManagedAssetStatusOutput{
+		Manager: OutputOwners{
+			Threshold: 1,
+			Addrs: []ids.ShortID{
+				addr,
+			},
+		},
+	}
+	// Freeze the asset and transfer its management to [addr2]
+	op := &UpdateManagedAssetOperation{
+		Input: Input{
+			SigIndices: []uint32{0},
+		},
+		Status: ManagedAssetStatusOutput{
+			Frozen: true,
+			Manager: OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					addr2,
+				},
+			},
+		},
+	}
+	cred := &Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigBytes,
+		},
+	}
+
+	utxos := []interface{}{utxo}
+	if err := fx.VerifyOperation(tx, op, cred, utxos); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the current manager can update the status of the asset
+	wrongCred := &Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sig2Bytes,
+		},
+	}
+	if err := fx.VerifyOperation(tx, op, wrongCred, utxos); err == nil {
+		t.Fatalf("Should have errored due to the signer not being the manager")
+	}
+
+	// The status output must be consumed
+	mintUTXOs := []interface{}{&MintOutput{OutputOwners: utxo.Manager}}
+	if err := fx.VerifyOperation(tx, op, cred, mintUTXOs); err != errWrongUTXOType {
+		t.Fatalf("Should have errored due to the wrong UTXO type but got %v", err)
+	}
+
+	if err := fx.VerifyOperation(tx, op, cred, nil); err != errWrongNumberOfUTXOs {
+		t.Fatalf("Should have errored due to the wrong number of UTXOs but got %v", err)
+	}
+
+	// Even re-creating the status unchanged requires the manager's signature
+	unsignedOp := &UpdateManagedAssetOperation{
+		Status: *utxo,
+	}
+	if err := fx.VerifyOperation(tx, unsignedOp, &Credential{}, utxos); err != errTooFewSigners {
+		t.Fatalf("Should have errored due to the missing signature but got %v", err)
+	}
+}
+
 func TestVerifyPermission(t *testing.T) {
 	vm := TestVM{
 		Codec: linearcodec.NewDefault(),
@@ -1141,3 +1224,266 @@ func TestVerifyPermission(t *testing.T) {
 		}
 	}
 }
+
+func TestFxVerifySignatures(t *testing.T) {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &TestTx{Bytes: txBytes}
+	creds := []verify.Verifiable{
+		&Credential{
+			Sigs: [][crypto.SECP256K1RSigLen]byte{
+				sigBytes,
+				sig2Bytes,
+			},
+		},
+		&Credential{
+			Sigs: [][crypto.SECP256K1RSigLen]byte{
+				sigBytes,
+			},
+		},
+	}
+
+	if err := fx.VerifySignatures(tx, creds); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFxVerifySignaturesInvalidSignature(t *testing.T) {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapping(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &TestTx{Bytes: txBytes}
+	creds := []verify.Verifiable{
+		&Credential{
+			Sigs: [][crypto.SECP256K1RSigLen]byte{
+				sigBytes,
+			},
+		},
+		&Credential{
+			Sigs: [][crypto.SECP256K1RSigLen]byte{
+				{},
+			},
+		},
+	}
+
+	if err := fx.VerifySignatures(tx, creds); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fx.VerifySignatures(tx, creds); err == nil {
+		t.Fatalf("Should have errored due to an invalid signature")
+	}
+}
+
+func TestFxVerifySignaturesUnknownCredential(t *testing.T) {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &TestTx{Bytes: txBytes}
+	creds := []verify.Verifiable{
+		nil,
+	}
+
+	if err := fx.VerifySignatures(tx, creds); err == nil {
+		t.Fatalf("Should have errored due to an unknown credential")
+	}
+}
+
+// newSignedTransfers returns a tx spending [numInputs] outputs, each owned by a
+// different key, along with the inputs and credentials that spend them.
+func newSignedTransfers(tb testing.TB, numInputs int) (*TestTx, []*TransferInput, []*Credential, []*TransferOutput) {
+	tx := &TestTx{Bytes: txBytes}
+	txHash := hashing.ComputeHash256(txBytes)
+	factory := crypto.FactorySECP256K1R{}
+	ins := make([]*TransferInput, numInputs)
+	creds := make([]*Credential, numInputs)
+	outs := make([]*TransferOutput, numInputs)
+	for i := 0; i < numInputs; i++ {
+		keyIntf, err := factory.NewPrivateKey()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		key := keyIntf.(*crypto.PrivateKeySECP256K1R)
+		sig, err := key.SignHash(txHash)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		cred := &Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, 1)}
+		copy(cred.Sigs[0][:], sig)
+
+		ins[i] = &TransferInput{
+			Amt:   1,
+			Input: Input{SigIndices: []uint32{0}},
+		}
+		creds[i] = cred
+		outs[i] = &TransferOutput{
+			Amt: 1,
+			OutputOwners: OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{key.PublicKey().Address()},
+			},
+		}
+	}
+	return tx, ins, creds, outs
+}
+
+func newBootstrappedFx(tb testing.TB) *Fx {
+	vm := TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := &Fx{}
+	if err := fx.Initialize(&vm); err != nil {
+		tb.Fatal(err)
+	}
+	if err := fx.Bootstrapping(); err != nil {
+		tb.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		tb.Fatal(err)
+	}
+	return fx
+}
+
+func TestFxVerifyBatch(t *testing.T) {
+	fx := newBootstrappedFx(t)
+	tx, ins, creds, outs := newSignedTransfers(t, 8)
+	txHash := hashing.ComputeHash256(txBytes)
+
+	fx.StartBatch()
+	for i := range ins {
+		if err := fx.VerifyTransfer(tx, ins[i], creds[i], outs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The signatures haven't been checked yet, so nothing should be cached
+	for i := range ins {
+		if _, ok := fx.verifiedCreds.Get(credentialKey(txHash, &ins[i].Input, creds[i], &outs[i].OutputOwners)); ok {
+			t.Fatalf("credential %d shouldn't have been marked as verified before the batch was verified", i)
+		}
+	}
+	if err := fx.VerifyBatch(); err != nil {
+		t.Fatal(err)
+	}
+	for i := range ins {
+		if _, ok := fx.verifiedCreds.Get(credentialKey(txHash, &ins[i].Input, creds[i], &outs[i].OutputOwners)); !ok {
+			t.Fatalf("credential %d should have been marked as verified", i)
+		}
+	}
+
+	// Verifying a batch that was never started does nothing
+	if err := fx.VerifyBatch(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFxVerifyBatchInvalidSignature(t *testing.T) {
+	fx := newBootstrappedFx(t)
+	tx, ins, creds, outs := newSignedTransfers(t, 8)
+	txHash := hashing.ComputeHash256(txBytes)
+
+	// Swap the signatures of two of the credentials
+	creds[2], creds[5] = creds[5], creds[2]
+
+	fx.StartBatch()
+	for i := range ins {
+		if err := fx.VerifyTransfer(tx, ins[i], creds[i], outs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fx.VerifyBatch(); err == nil {
+		t.Fatalf("Should have errored due to a signature from the wrong key")
+	}
+	for i := range ins {
+		if _, ok := fx.verifiedCreds.Get(credentialKey(txHash, &ins[i].Input, creds[i], &outs[i].OutputOwners)); ok {
+			t.Fatalf("credential %d shouldn't have been marked as verified", i)
+		}
+	}
+
+	// Without a batch, the failure is reported immediately
+	if err := fx.VerifyTransfer(tx, ins[2], creds[2], outs[2]); err == nil {
+		t.Fatalf("Should have errored due to a signature from the wrong key")
+	}
+}
+
+func TestFxAbortBatch(t *testing.T) {
+	fx := newBootstrappedFx(t)
+	tx, ins, creds, outs := newSignedTransfers(t, 1)
+	txHash := hashing.ComputeHash256(txBytes)
+
+	fx.StartBatch()
+	if err := fx.VerifyTransfer(tx, ins[0], creds[0], outs[0]); err != nil {
+		t.Fatal(err)
+	}
+	fx.AbortBatch()
+	if _, ok := fx.verifiedCreds.Get(credentialKey(txHash, &ins[0].Input, creds[0], &outs[0].OutputOwners)); ok {
+		t.Fatalf("aborted credential shouldn't have been marked as verified")
+	}
+}
+
+func BenchmarkFxVerifyCredentials(b *testing.B) {
+	const numInputs = 64
+
+	fx := newBootstrappedFx(b)
+	tx, ins, creds, outs := newSignedTransfers(b, numInputs)
+
+	// Flush the caches so that every iteration recovers every public key
+	flush := func() {
+		fx.verifiedCreds.Flush()
+		fx.SECPFactory.Cache.Flush()
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			flush()
+			for i := range ins {
+				if err := fx.VerifyTransfer(tx, ins[i], creds[i], outs[i]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			flush()
+			fx.StartBatch()
+			for i := range ins {
+				if err := fx.VerifyTransfer(tx, ins[i], creds[i], outs[i]); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := fx.VerifyBatch(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -81,6 +81,13 @@ func (kc *Keychain) Spend(out verify.Verifiable, time uint64) (verify.Verifiable
 			}, keys, nil
 		}
 		return nil, nil, errCantSpend
+	case *ManagedAssetStatusOutput:
+		if sigIndices, keys, able := kc.Match(&out.Manager, time); able {
+			return &Input{
+				SigIndices: sigIndices,
+			}, keys, nil
+		}
+		return nil, nil, errCantSpend
 	case *TransferOutput:
 		if sigIndices, keys, able := kc.Match(&out.OutputOwners, time); able {
 			return &TransferInput{
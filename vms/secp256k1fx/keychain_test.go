@@ -223,6 +223,56 @@ func TestKeychainSpendMint(t *testing.T) {
 	}
 }
 
+func TestKeychainSpendManagedAssetStatus(t *testing.T) {
+	kc := NewKeychain()
+
+	sks := []*crypto.PrivateKeySECP256K1R{}
+	for _, keyStr := range keys {
+		skBytes, err := formatting.Decode(defaultEncoding, keyStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		skIntf, err := kc.factory.ToPrivateKey(skBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sk, ok := skIntf.(*crypto.PrivateKeySECP256K1R)
+		if !ok {
+			t.Fatalf("Factory should have returned secp256k1r private key")
+		}
+		sks = append(sks, sk)
+	}
+
+	status := ManagedAssetStatusOutput{Manager: OutputOwners{
+		Threshold: 1,
+		Addrs: []ids.ShortID{
+			sks[1].PublicKey().Address(),
+		},
+	}}
+	if err := status.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	kc.Add(sks[0])
+	if _, _, err := kc.Spend(&status, 0); err == nil {
+		t.Fatalf("Shouldn't have been able to spend without the manager's key")
+	}
+
+	kc.Add(sks[1])
+	if input, keys, err := kc.Spend(&status, 0); err != nil {
+		t.Fatal(err)
+	} else if input, ok := input.(*Input); !ok {
+		t.Fatalf("Wrong input type returned")
+	} else if numSigs := len(input.SigIndices); numSigs != 1 {
+		t.Fatalf("Should have returned one signer")
+	} else if numKeys := len(keys); numKeys != 1 {
+		t.Fatalf("Should have returned one key")
+	} else if key := keys[0]; key.PublicKey().Address() != sks[1].PublicKey().Address() {
+		t.Fatalf("Returned wrong key")
+	}
+}
+
 func TestKeychainSpendTransfer(t *testing.T) {
 	kc := NewKeychain()
 
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var _ verify.State = &ManagedAssetStatusOutput{}
+
+// ManagedAssetStatusOutput records whether transfers of a managed asset are
+// frozen, and who is allowed to change that.
+type ManagedAssetStatusOutput struct {
+	// Frozen is true if the asset can't currently be transferred
+	Frozen bool `serialize:"true" json:"frozen"`
+	// Manager is allowed to freeze, unfreeze and transfer the management of
+	// the asset
+	Manager OutputOwners `serialize:"true" json:"manager"`
+}
+
+func (out *ManagedAssetStatusOutput) InitCtx(ctx *snow.Context) {
+	out.Manager.InitCtx(ctx)
+}
+
+// Addresses returns the addresses of the manager of the asset
+func (out *ManagedAssetStatusOutput) Addresses() [][]byte {
+	return out.Manager.Addresses()
+}
+
+func (out *ManagedAssetStatusOutput) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	default:
+		return out.Manager.Verify()
+	}
+}
+
+func (out *ManagedAssetStatusOutput) VerifyState() error { return out.Verify() }
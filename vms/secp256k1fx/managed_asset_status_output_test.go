@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+func TestManagedAssetStatusOutputVerifyNil(t *testing.T) {
+	out := (*ManagedAssetStatusOutput)(nil)
+	if err := out.Verify(); err == nil {
+		t.Fatalf("ManagedAssetStatusOutput.Verify should have returned an error due to an nil output")
+	}
+}
+
+func TestManagedAssetStatusOutputVerifyInvalidManager(t *testing.T) {
+	out := &ManagedAssetStatusOutput{
+		Manager: OutputOwners{
+			Threshold: 2,
+			Addrs: []ids.ShortID{
+				addr,
+			},
+		},
+	}
+	if err := out.Verify(); err == nil {
+		t.Fatalf("ManagedAssetStatusOutput.Verify should have returned an error due to an unspendable manager")
+	}
+}
+
+func TestManagedAssetStatusOutputAddresses(t *testing.T) {
+	out := &ManagedAssetStatusOutput{
+		Manager: OutputOwners{
+			Threshold: 1,
+			Addrs: []ids.ShortID{
+				addr,
+			},
+		},
+	}
+	addrs := out.Addresses()
+	if len(addrs) != 1 {
+		t.Fatalf("Wrong number of addresses")
+	}
+	if !bytes.Equal(addrs[0], addr[:]) {
+		t.Fatalf("Wrong address")
+	}
+}
+
+func TestManagedAssetStatusOutputState(t *testing.T) {
+	intf := interface{}(&ManagedAssetStatusOutput{})
+	if _, ok := intf.(verify.State); !ok {
+		t.Fatalf("should be marked as state")
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var errNilUpdateManagedAssetOperation = errors.New("nil update managed asset operation")
+
+// UpdateManagedAssetOperation consumes the status output of a managed asset
+// and replaces it with [Status]. It is used to freeze and unfreeze the asset,
+// as well as to transfer the management of the asset to a new manager.
+type UpdateManagedAssetOperation struct {
+	Input  Input                    `serialize:"true" json:"input"`
+	Status ManagedAssetStatusOutput `serialize:"true" json:"status"`
+}
+
+func (op *UpdateManagedAssetOperation) InitCtx(ctx *snow.Context) {
+	op.Status.InitCtx(ctx)
+}
+
+func (op *UpdateManagedAssetOperation) Cost() (uint64, error) {
+	return op.Input.Cost()
+}
+
+func (op *UpdateManagedAssetOperation) Outs() []verify.State {
+	return []verify.State{&op.Status}
+}
+
+func (op *UpdateManagedAssetOperation) Verify() error {
+	switch {
+	case op == nil:
+		return errNilUpdateManagedAssetOperation
+	default:
+		return verify.All(&op.Input, &op.Status)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+func TestUpdateManagedAssetOperationVerifyNil(t *testing.T) {
+	op := (*UpdateManagedAssetOperation)(nil)
+	if err := op.Verify(); err == nil {
+		t.Fatalf("UpdateManagedAssetOperation.Verify should have returned an error due to an nil operation")
+	}
+}
+
+func TestUpdateManagedAssetOperationOuts(t *testing.T) {
+	op := &UpdateManagedAssetOperation{
+		Input: Input{
+			SigIndices: []uint32{0},
+		},
+		Status: ManagedAssetStatusOutput{
+			Frozen: true,
+			Manager: OutputOwners{
+				Threshold: 1,
+				Addrs: []ids.ShortID{
+					addr,
+				},
+			},
+		},
+	}
+	if err := op.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	outs := op.Outs()
+	if len(outs) != 1 {
+		t.Fatalf("Wrong number of outputs")
+	}
+	if outs[0] != &op.Status {
+		t.Fatalf("Wrong output")
+	}
+}
+
+func TestUpdateManagedAssetOperationState(t *testing.T) {
+	intf := interface{}(&UpdateManagedAssetOperation{})
+	if _, ok := intf.(verify.State); ok {
+		t.Fatalf("shouldn't be marked as state")
+	}
+}